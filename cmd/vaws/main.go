@@ -5,8 +5,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"vaws/internal/app"
+	"vaws/internal/deeplink"
+	"vaws/internal/report"
 )
 
 func main() {
@@ -19,11 +22,16 @@ func main() {
 	testConn := flag.Bool("test", false, "Test AWS connection without starting TUI")
 	noAltScreen := flag.Bool("no-alt-screen", false, "Disable alternate screen (allows text selection/copy)")
 	themeFlag := flag.String("theme", "auto", "Color theme: auto, dark, or light")
+	ascii := flag.Bool("ascii", false, "Use ASCII instead of emoji and Unicode symbols")
+	output := flag.String("output", "text", "Output format for --test and --list-profiles: text or json")
+	metricsAddr := flag.String("metrics-addr", "", "Serve Prometheus metrics of tunnels and AWS calls on this address (e.g. :9095)")
 
 	// Custom usage
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "vaws - AWS CloudFormation & ECS Explorer\n\n")
-		fmt.Fprintf(os.Stderr, "Usage: vaws [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: vaws [options] [open <vaws:// link or ARN>]\n")
+		fmt.Fprintf(os.Stderr, "       vaws [options] report <stack|cluster> <name>\n")
+		fmt.Fprintf(os.Stderr, "       vaws completion <bash|zsh|fish>\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nNavigation:\n")
@@ -37,6 +45,41 @@ func main() {
 
 	flag.Parse()
 
+	if *output != app.OutputText && *output != app.OutputJSON {
+		fmt.Fprintf(os.Stderr, "Error: unknown output format %q (use text or json)\n", *output)
+		os.Exit(2)
+	}
+
+	// vaws open <link> starts at a resource; URI handlers pass the link alone.
+	// vaws report prints a snapshot of a stack or cluster instead of the TUI
+	var link *deeplink.Link
+	var reportScope *report.Scope
+	switch {
+	case flag.Arg(0) == "completion" && flag.NArg() == 2:
+		if err := app.PrintCompletion(flag.Arg(1), flag.CommandLine); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		return
+	case flag.Arg(0) == app.CompleteCommand && flag.NArg() == 2:
+		app.PrintCompletionValues(flag.Arg(1))
+		return
+	case flag.Arg(0) == "report" && flag.NArg() == 3:
+		kind, err := report.ParseKind(flag.Arg(1))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		reportScope = &report.Scope{Kind: kind, Name: flag.Arg(2)}
+	case flag.Arg(0) == "open" && flag.NArg() == 2:
+		link = mustParseLink(flag.Arg(1))
+	case strings.HasPrefix(flag.Arg(0), deeplink.Scheme+"://") && flag.NArg() == 1:
+		link = mustParseLink(flag.Arg(0))
+	case flag.NArg() > 0:
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	// Handle special flags
 	if *version {
 		app.PrintVersion()
@@ -44,7 +87,7 @@ func main() {
 	}
 
 	if *listProfiles {
-		if err := app.PrintProfiles(); err != nil {
+		if err := app.PrintProfiles(*output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -58,6 +101,18 @@ func main() {
 		Debug:       *debug,
 		NoAltScreen: *noAltScreen,
 		Theme:       *themeFlag,
+		ASCII:       *ascii,
+		Output:      *output,
+		MetricsAddr: *metricsAddr,
+		Link:        link,
+	}
+
+	if reportScope != nil {
+		if err := app.PrintReport(cfg, *reportScope); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
 	// Test connection mode
@@ -72,3 +127,13 @@ func main() {
 	// Run the application
 	app.MustRun(cfg)
 }
+
+// mustParseLink parses a vaws:// link or ARN, exiting if it is invalid.
+func mustParseLink(s string) *deeplink.Link {
+	link, err := deeplink.Parse(s)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	return &link
+}
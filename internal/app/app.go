@@ -10,7 +10,12 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"vaws/internal/aws"
+	"vaws/internal/config"
+	"vaws/internal/deeplink"
 	"vaws/internal/log"
+	"vaws/internal/metrics"
+	"vaws/internal/report"
+	"vaws/internal/tunnel"
 	"vaws/internal/ui"
 	"vaws/internal/ui/theme"
 )
@@ -20,13 +25,22 @@ type Config struct {
 	Profile     string
 	Region      string
 	Debug       bool
-	NoAltScreen bool     // Disable alternate screen for easier copy/paste
-	Profiles    []string // Available AWS profiles (populated if no profile specified)
-	Theme       string   // Theme override: "auto", "dark", or "light"
+	NoAltScreen bool           // Disable alternate screen for easier copy/paste
+	Profiles    []string       // Available AWS profiles (populated if no profile specified)
+	Theme       string         // Theme override: "auto", "dark", or "light"
+	Output      string         // Output format of the non-TUI commands: "text" or "json"
+	ASCII       bool           // Replace emoji and Unicode symbols with ASCII
+	Link        *deeplink.Link // Resource to open at, from vaws open
+	MetricsAddr string         // Address of the Prometheus metrics endpoint, off if empty
 }
 
 // Run starts the application with the given configuration.
 func Run(cfg Config) error {
+	started := time.Now()
+	if cfg.Debug {
+		log.Default().SetLevel(log.LevelDebug)
+	}
+
 	// Initialize theme
 	switch cfg.Theme {
 	case "dark":
@@ -37,6 +51,23 @@ func Run(cfg Config) error {
 		// Auto-detect theme
 		theme.SetByName(theme.ThemeAuto)
 	}
+	theme.SetASCII(cfg.ASCII)
+
+	if cfg.MetricsAddr != "" {
+		if err := metrics.Serve(cfg.MetricsAddr); err != nil {
+			return err
+		}
+	}
+
+	// A link picks the profile and region, unless the flags do
+	if cfg.Link != nil {
+		if cfg.Profile == "" {
+			cfg.Profile = cfg.Link.Profile
+		}
+		if cfg.Region == "" {
+			cfg.Region = cfg.Link.Region
+		}
+	}
 
 	// If no profile specified, load available profiles for selection
 	if cfg.Profile == "" {
@@ -48,6 +79,9 @@ func Run(cfg Config) error {
 
 		// Create TUI model without AWS client (will be created after profile selection)
 		model := ui.NewWithProfileSelection(cfg.Profiles, cfg.Region, log.Default(), "v"+Version)
+		if cfg.Link != nil {
+			model.SetStartLink(*cfg.Link)
+		}
 
 		// Create and run the program
 		opts := []tea.ProgramOption{}
@@ -68,9 +102,14 @@ func Run(cfg Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to create AWS client: %w", err)
 	}
+	client.SetConcurrency(config.Get().GetConcurrency(cfg.Profile))
 
 	// Create TUI model
 	model := ui.New(client, log.Default(), "v"+Version)
+	model.TimeStartupFrom(started, "aws client")
+	if cfg.Link != nil {
+		model.SetStartLink(*cfg.Link)
+	}
 
 	// Create and run the program
 	opts := []tea.ProgramOption{}
@@ -92,7 +131,15 @@ func ListProfiles() ([]string, error) {
 }
 
 // PrintProfiles prints all available AWS profiles to stdout.
-func PrintProfiles() error {
+func PrintProfiles(output string) error {
+	if output == OutputJSON {
+		details, err := aws.ListProfileDetails()
+		if err != nil {
+			return err
+		}
+		return printJSON(newProfilesOutput(details))
+	}
+
 	profiles, err := ListProfiles()
 	if err != nil {
 		return err
@@ -127,8 +174,13 @@ func MustRun(cfg Config) {
 	}
 }
 
-// TestConnection tests AWS connectivity by attempting to list stacks.
+// TestConnection tests AWS connectivity by attempting to list stacks, then
+// checks which of the other services the credentials can reach.
 func TestConnection(cfg Config) error {
+	if cfg.Output == OutputJSON {
+		return testConnectionJSON(cfg)
+	}
+
 	fmt.Printf("Testing AWS connection...\n")
 	fmt.Printf("  Profile: %s\n", cfg.Profile)
 	fmt.Printf("  Region:  %s\n", cfg.Region)
@@ -142,6 +194,10 @@ func TestConnection(cfg Config) error {
 	}
 
 	fmt.Printf("  Resolved region: %s\n", client.Region())
+	if identity, err := client.GetCallerIdentity(ctx); err == nil {
+		fmt.Printf("  Account: %s\n", identity.Account)
+		fmt.Printf("  Identity: %s\n", identity.ARN)
+	}
 	fmt.Printf("\nListing CloudFormation stacks...\n")
 
 	stacks, err := client.ListStacks(ctx)
@@ -160,5 +216,50 @@ func TestConnection(cfg Config) error {
 		}
 	}
 
+	fmt.Printf("\nChecking services...\n")
+	for _, check := range client.CheckServices(ctx) {
+		if check.OK {
+			fmt.Printf("  ok    %-15s %s\n", check.Service, check.Latency.Round(time.Millisecond))
+		} else {
+			fmt.Printf("  FAIL  %-15s %s\n", check.Service, check.Error)
+		}
+	}
+
+	fmt.Printf("\nChecking tunnel prerequisites...\n")
+	prereqs := tunnel.CheckPrerequisites()
+	for _, b := range []tunnel.Binary{prereqs.AWSCLI, prereqs.Plugin} {
+		if b.OK() {
+			fmt.Printf("  ok    %-22s %s\n", b.Name, b.Version)
+		} else {
+			fmt.Printf("  MISS  %-22s %v; install: %s\n", b.Name, b.Err, tunnel.InstallHint(b.Name))
+		}
+	}
+	if !prereqs.OK() {
+		fmt.Printf("Port forwarding and shells are disabled until these are installed.\n")
+	}
+
+	return nil
+}
+
+// PrintReport prints a Markdown report of a stack or cluster to stdout, for
+// generating on a schedule or piping into a doc.
+func PrintReport(cfg Config, scope report.Scope) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, cfg.Profile, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+	client.SetConcurrency(config.Get().GetConcurrency(cfg.Profile))
+
+	r, err := report.Build(ctx, client, scope)
+	if err != nil {
+		return err
+	}
+	for _, w := range r.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: could not read %s\n", w)
+	}
+	fmt.Print(r.Markdown())
 	return nil
 }
@@ -0,0 +1,298 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"vaws/internal/aws"
+	"vaws/internal/ui/components"
+)
+
+// CompletionShells are the shells vaws completion writes scripts for.
+var CompletionShells = []string{"bash", "zsh", "fish"}
+
+// CompleteCommand is the hidden command the completion scripts run for
+// values read at completion time, e.g. "vaws __complete profiles".
+const CompleteCommand = "__complete"
+
+// subcommand is a command taking positional arguments after the flags.
+type subcommand struct {
+	name        string
+	description string
+	args        [][]string // Choices of each argument, nil for free text
+}
+
+// subcommands are completed after the flags. Flags must come first, as the
+// flag package stops at the first argument.
+var subcommands = []subcommand{
+	{name: "open", description: "Open at a vaws:// link or ARN"},
+	{name: "report", description: "Print a Markdown snapshot of a stack or cluster", args: [][]string{{"stack", "cluster"}}},
+	{name: "completion", description: "Print a shell completion script", args: [][]string{CompletionShells}},
+}
+
+// flagValues are the values completed after a flag: fixed choices, or the
+// name of what __complete lists.
+var flagValues = map[string]struct {
+	choices []string
+	dynamic string
+}{
+	"profile": {dynamic: "profiles"},
+	"region":  {dynamic: "regions"},
+	"theme":   {choices: []string{"auto", "dark", "light"}},
+	"output":  {choices: []string{OutputText, OutputJSON}},
+}
+
+// completionFlag is a flag of the command line as the scripts complete it.
+type completionFlag struct {
+	name    string
+	usage   string
+	takes   bool // Takes a value, unlike boolean flags
+	choices []string
+	dynamic string
+}
+
+// completionFlags returns the flags of fs in name order.
+func completionFlags(fs *flag.FlagSet) []completionFlag {
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		b, ok := f.Value.(interface{ IsBoolFlag() bool })
+		v := flagValues[f.Name]
+		flags = append(flags, completionFlag{
+			name:    f.Name,
+			usage:   f.Usage,
+			takes:   !ok || !b.IsBoolFlag(),
+			choices: v.choices,
+			dynamic: v.dynamic,
+		})
+	})
+	return flags
+}
+
+// PrintCompletion writes the completion script of a shell for the flags of
+// fs and the subcommands to stdout.
+func PrintCompletion(shell string, fs *flag.FlagSet) error {
+	flags := completionFlags(fs)
+	switch shell {
+	case "bash":
+		writeBashCompletion(os.Stdout, flags)
+	case "zsh":
+		writeZshCompletion(os.Stdout, flags)
+	case "fish":
+		writeFishCompletion(os.Stdout, flags)
+	default:
+		return fmt.Errorf("unknown shell %q (use %s)", shell, strings.Join(CompletionShells, ", "))
+	}
+	return nil
+}
+
+// PrintCompletionValues prints the values the scripts complete at
+// completion time, one per line: profiles as the profile selector lists
+// them, or regions. Errors print nothing, as they would end up in the
+// middle of the command being typed.
+func PrintCompletionValues(kind string) {
+	var values []string
+	switch kind {
+	case "profiles":
+		values, _ = aws.ListProfiles()
+	case "regions":
+		for _, g := range components.AWSRegions {
+			for _, r := range g.Regions {
+				values = append(values, r.Code)
+			}
+		}
+	}
+	for _, v := range values {
+		fmt.Println(v)
+	}
+}
+
+// subcommandNames returns the names of the subcommands, space separated.
+func subcommandNames() string {
+	names := make([]string, len(subcommands))
+	for i, c := range subcommands {
+		names[i] = c.name
+	}
+	return strings.Join(names, " ")
+}
+
+func writeBashCompletion(w io.Writer, flags []completionFlag) {
+	var names, valued []string
+	for _, f := range flags {
+		names = append(names, "--"+f.name)
+		if f.takes {
+			valued = append(valued, "--"+f.name, "-"+f.name)
+		}
+	}
+
+	fmt.Fprintf(w, "# bash completion for vaws, from: vaws completion bash\n\n")
+	fmt.Fprintf(w, "_vaws() {\n")
+	fmt.Fprintf(w, "    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n\n")
+	fmt.Fprintf(w, "    case \"$prev\" in\n")
+	for _, f := range flags {
+		if !f.takes {
+			continue
+		}
+		fmt.Fprintf(w, "        --%s|-%s)\n", f.name, f.name)
+		switch {
+		case f.dynamic != "":
+			fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"$(vaws %s %s 2>/dev/null)\" -- \"$cur\"))\n", CompleteCommand, f.dynamic)
+		case len(f.choices) > 0:
+			fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(f.choices, " "))
+		}
+		fmt.Fprintf(w, "            return ;;\n")
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+
+	// The subcommand is the first word that is neither a flag nor its value
+	fmt.Fprintf(w, "    local i word cmd=\"\" nargs=0\n")
+	fmt.Fprintf(w, "    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	fmt.Fprintf(w, "        word=\"${COMP_WORDS[i]}\"\n")
+	fmt.Fprintf(w, "        if [[ -z $cmd ]]; then\n")
+	fmt.Fprintf(w, "            case \"$word\" in\n")
+	fmt.Fprintf(w, "                %s) ((i++)) ;;\n", strings.Join(valued, "|"))
+	fmt.Fprintf(w, "                -*) ;;\n")
+	fmt.Fprintf(w, "                *) cmd=\"$word\" ;;\n")
+	fmt.Fprintf(w, "            esac\n")
+	fmt.Fprintf(w, "        else\n")
+	fmt.Fprintf(w, "            ((nargs++))\n")
+	fmt.Fprintf(w, "        fi\n")
+	fmt.Fprintf(w, "    done\n\n")
+
+	fmt.Fprintf(w, "    case \"$cmd\" in\n")
+	fmt.Fprintf(w, "        \"\")\n")
+	fmt.Fprintf(w, "            if [[ $cur == -* ]]; then\n")
+	fmt.Fprintf(w, "                COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(names, " "))
+	fmt.Fprintf(w, "            else\n")
+	fmt.Fprintf(w, "                COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", subcommandNames())
+	fmt.Fprintf(w, "            fi ;;\n")
+	for _, c := range subcommands {
+		if len(c.args) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "        %s)\n", c.name)
+		fmt.Fprintf(w, "            case $nargs in\n")
+		for i, choices := range c.args {
+			if choices != nil {
+				fmt.Fprintf(w, "                %d) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n", i, strings.Join(choices, " "))
+			}
+		}
+		fmt.Fprintf(w, "            esac ;;\n")
+	}
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "complete -F _vaws vaws\n")
+}
+
+// zshQuote escapes a description for an _arguments spec in single quotes.
+func zshQuote(s string) string {
+	return strings.NewReplacer("'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(s)
+}
+
+func writeZshCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprintf(w, "#compdef vaws\n")
+	fmt.Fprintf(w, "# zsh completion for vaws, from: vaws completion zsh\n\n")
+
+	fmt.Fprintf(w, "_vaws() {\n")
+	fmt.Fprintf(w, "    local -a subcommands\n")
+	fmt.Fprintf(w, "    subcommands=(\n")
+	for _, c := range subcommands {
+		fmt.Fprintf(w, "        '%s:%s'\n", c.name, zshQuote(c.description))
+	}
+	fmt.Fprintf(w, "    )\n\n")
+
+	fmt.Fprintf(w, "    local state line\n")
+	fmt.Fprintf(w, "    _arguments -C \\\n")
+	for _, f := range flags {
+		spec := fmt.Sprintf("--%s[%s]", f.name, zshQuote(f.usage))
+		if f.takes {
+			action := " "
+			switch {
+			case f.dynamic != "":
+				action = fmt.Sprintf("{_vaws_values %s}", f.dynamic)
+			case len(f.choices) > 0:
+				action = "(" + strings.Join(f.choices, " ") + ")"
+			}
+			spec += fmt.Sprintf(":%s:%s", f.name, action)
+		}
+		fmt.Fprintf(w, "        '%s' \\\n", spec)
+	}
+	fmt.Fprintf(w, "        '1: :->command' \\\n")
+	fmt.Fprintf(w, "        '*:: :->args'\n\n")
+
+	fmt.Fprintf(w, "    case $state in\n")
+	fmt.Fprintf(w, "        command) _describe -t commands 'vaws command' subcommands ;;\n")
+	fmt.Fprintf(w, "        args)\n")
+	fmt.Fprintf(w, "            case $line[1] in\n")
+	for _, c := range subcommands {
+		if len(c.args) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "                %s)\n", c.name)
+		fmt.Fprintf(w, "                    case $CURRENT in\n")
+		for i, choices := range c.args {
+			if choices != nil {
+				fmt.Fprintf(w, "                        %d) _values '%s' %s ;;\n", i+2, c.name, strings.Join(choices, " "))
+			}
+		}
+		fmt.Fprintf(w, "                    esac ;;\n")
+	}
+	fmt.Fprintf(w, "            esac ;;\n")
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "_vaws_values() {\n")
+	fmt.Fprintf(w, "    local -a values\n")
+	fmt.Fprintf(w, "    values=(${(f)\"$(vaws %s $1 2>/dev/null)\"})\n", CompleteCommand)
+	fmt.Fprintf(w, "    _describe $1 values\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	// Loaded from fpath the file is the function; sourced it registers it
+	fmt.Fprintf(w, "if [[ $funcstack[1] == _vaws ]]; then\n")
+	fmt.Fprintf(w, "    _vaws \"$@\"\n")
+	fmt.Fprintf(w, "else\n")
+	fmt.Fprintf(w, "    compdef _vaws vaws\n")
+	fmt.Fprintf(w, "fi\n")
+}
+
+// fishQuote escapes a string for single quotes in fish.
+func fishQuote(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s)
+}
+
+func writeFishCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprintf(w, "# fish completion for vaws, from: vaws completion fish\n\n")
+	fmt.Fprintf(w, "complete -c vaws -f\n\n")
+
+	names := subcommandNames()
+	noCommand := fmt.Sprintf("not __fish_seen_subcommand_from %s", names)
+	for _, f := range flags {
+		line := fmt.Sprintf("complete -c vaws -n '%s' -l %s -d '%s'", noCommand, f.name, fishQuote(f.usage))
+		if f.takes {
+			line += " -r"
+			switch {
+			case f.dynamic != "":
+				line += fmt.Sprintf(" -a '(vaws %s %s 2>/dev/null)'", CompleteCommand, f.dynamic)
+			case len(f.choices) > 0:
+				line += fmt.Sprintf(" -a '%s'", strings.Join(f.choices, " "))
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w)
+
+	for _, c := range subcommands {
+		fmt.Fprintf(w, "complete -c vaws -n '%s' -a %s -d '%s'\n", noCommand, c.name, fishQuote(c.description))
+	}
+	for _, c := range subcommands {
+		// Only the first argument has choices so far; later ones would need
+		// counting the words typed
+		if len(c.args) == 0 || c.args[0] == nil {
+			continue
+		}
+		first := strings.Join(c.args[0], " ")
+		fmt.Fprintf(w, "complete -c vaws -n '__fish_seen_subcommand_from %s; and not __fish_seen_subcommand_from %s' -a '%s'\n", c.name, first, first)
+	}
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"vaws/internal/aws"
+	"vaws/internal/model"
+	"vaws/internal/tunnel"
+)
+
+// Output formats of the non-TUI commands.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// profileOutput is a profile as printed by --list-profiles --output json.
+type profileOutput struct {
+	Name          string `json:"name"`
+	Region        string `json:"region,omitempty"`
+	AccountID     string `json:"account_id,omitempty"`
+	SSO           bool   `json:"sso"`
+	RoleARN       string `json:"role_arn,omitempty"`
+	SourceProfile string `json:"source_profile,omitempty"`
+}
+
+// profilesOutput is the result of --list-profiles --output json.
+type profilesOutput struct {
+	Profiles []profileOutput `json:"profiles"`
+}
+
+func newProfilesOutput(profiles []model.AWSProfile) profilesOutput {
+	out := profilesOutput{Profiles: make([]profileOutput, len(profiles))}
+	for i, p := range profiles {
+		out.Profiles[i] = profileOutput{
+			Name:          p.Name,
+			Region:        p.Region,
+			AccountID:     p.AccountID,
+			SSO:           p.SSO,
+			RoleARN:       p.RoleARN,
+			SourceProfile: p.SourceProfile,
+		}
+	}
+	return out
+}
+
+// identityOutput is the caller identity in --test --output json.
+type identityOutput struct {
+	Account string `json:"account"`
+	ARN     string `json:"arn"`
+	UserID  string `json:"user_id"`
+}
+
+// serviceCheckOutput is one service check in --test --output json.
+type serviceCheckOutput struct {
+	Service   string `json:"service"`
+	OK        bool   `json:"ok"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// toolCheckOutput is one program tunnels need in --test --output json.
+type toolCheckOutput struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Path    string `json:"path,omitempty"`
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Install string `json:"install,omitempty"`
+}
+
+// connectionOutput is the result of --test --output json.
+type connectionOutput struct {
+	OK       bool                 `json:"ok"`
+	Error    string               `json:"error,omitempty"`
+	Profile  string               `json:"profile"`
+	Region   string               `json:"region"`
+	Identity *identityOutput      `json:"identity,omitempty"`
+	Stacks   int                  `json:"stacks"`
+	Services []serviceCheckOutput `json:"services"`
+	Tools    []toolCheckOutput    `json:"tools"`
+}
+
+// testConnectionJSON runs the same checks as TestConnection and prints them
+// as one JSON document. The document is printed on failure too, so scripts can
+// read the reason; the error is still returned for the exit code.
+func testConnectionJSON(cfg Config) error {
+	out := connectionOutput{Profile: cfg.Profile, Region: cfg.Region, Services: []serviceCheckOutput{}}
+	err := checkConnection(cfg, &out)
+	prereqs := tunnel.CheckPrerequisites()
+	for _, b := range []tunnel.Binary{prereqs.AWSCLI, prereqs.Plugin} {
+		tool := toolCheckOutput{Name: b.Name, OK: b.OK(), Path: b.Path, Version: b.Version}
+		if !b.OK() {
+			tool.Error = b.Err.Error()
+			tool.Install = tunnel.InstallHint(b.Name)
+		}
+		out.Tools = append(out.Tools, tool)
+	}
+	if err != nil {
+		out.Error = err.Error()
+	}
+	out.OK = err == nil
+	if printErr := printJSON(out); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+// checkConnection fills out with the identity, stack count and service checks.
+func checkConnection(cfg Config, out *connectionOutput) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	client, err := aws.NewClient(ctx, cfg.Profile, cfg.Region)
+	if err != nil {
+		return fmt.Errorf("failed to create AWS client: %w", err)
+	}
+	out.Region = client.Region()
+
+	identity, err := client.GetCallerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+	out.Identity = &identityOutput{Account: identity.Account, ARN: identity.ARN, UserID: identity.UserID}
+
+	stacks, err := client.ListStacks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list stacks: %w", err)
+	}
+	out.Stacks = len(stacks)
+
+	for _, check := range client.CheckServices(ctx) {
+		out.Services = append(out.Services, serviceCheckOutput{
+			Service:   check.Service,
+			OK:        check.OK,
+			LatencyMS: check.Latency.Milliseconds(),
+			Error:     check.Error,
+		})
+	}
+	return nil
+}
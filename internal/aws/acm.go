@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// ListCertificates returns the ACM certificates of the region, of every key
+// algorithm (ListCertificates only returns RSA 2048 ones unless asked).
+func (c *Client) ListCertificates(ctx context.Context) ([]model.Certificate, error) {
+	log.Debug("Listing ACM certificates...")
+
+	var certs []model.Certificate
+	paginator := acm.NewListCertificatesPaginator(c.acm, &acm.ListCertificatesInput{
+		Includes: &acmtypes.Filters{KeyTypes: acmtypes.KeyAlgorithm("").Values()},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list certificates: %w", err)
+		}
+		for _, s := range page.CertificateSummaryList {
+			certs = append(certs, model.Certificate{
+				ARN:        aws.ToString(s.CertificateArn),
+				DomainName: aws.ToString(s.DomainName),
+				Status:     string(s.Status),
+				Type:       string(s.Type),
+				NotAfter:   aws.ToTime(s.NotAfter),
+				InUse:      aws.ToBool(s.InUse),
+				Renewable:  s.RenewalEligibility == acmtypes.RenewalEligibilityEligible,
+			})
+		}
+	}
+
+	log.Debug("Found %d ACM certificates", len(certs))
+	return certs, nil
+}
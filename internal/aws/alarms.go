@@ -0,0 +1,67 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// ListAlarms returns all CloudWatch metric alarms, those in ALARM state first.
+func (c *Client) ListAlarms(ctx context.Context) ([]model.Alarm, error) {
+	log.Debug("Listing CloudWatch alarms...")
+
+	var alarms []model.Alarm
+	paginator := cloudwatch.NewDescribeAlarmsPaginator(c.cw, &cloudwatch.DescribeAlarmsInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe alarms: %w", err)
+		}
+
+		for _, a := range page.MetricAlarms {
+			alarms = append(alarms, model.Alarm{
+				Name:      aws.ToString(a.AlarmName),
+				State:     model.AlarmState(a.StateValue),
+				Reason:    aws.ToString(a.StateReason),
+				Metric:    aws.ToString(a.MetricName),
+				UpdatedAt: aws.ToTime(a.StateUpdatedTimestamp),
+			})
+		}
+	}
+
+	sort.SliceStable(alarms, func(i, j int) bool {
+		if (alarms[i].State == model.AlarmStateAlarm) != (alarms[j].State == model.AlarmStateAlarm) {
+			return alarms[i].State == model.AlarmStateAlarm
+		}
+		return alarms[i].Name < alarms[j].Name
+	})
+
+	log.Debug("Found %d CloudWatch alarms", len(alarms))
+	return alarms, nil
+}
+
+// GetAlarmsFromStack returns the names of the CloudWatch alarms defined in a
+// CloudFormation stack.
+func (c *Client) GetAlarmsFromStack(ctx context.Context, stackName string) ([]string, error) {
+	resources, err := c.GetStackResources(ctx, stackName, "AWS::CloudWatch::Alarm")
+	if err != nil {
+		return nil, err
+	}
+
+	var alarms []string
+	for _, r := range resources {
+		if name := aws.ToString(r.PhysicalResourceId); name != "" {
+			alarms = append(alarms, name)
+		}
+	}
+
+	log.Debug("Found %d CloudWatch alarms in stack %s", len(alarms), stackName)
+	return alarms, nil
+}
@@ -0,0 +1,273 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"vaws/internal/model"
+)
+
+// API is everything the UI needs from AWS. Client implements it against the
+// real services; internal/aws/fake implements it in memory for tests.
+type API interface {
+	Profile() string
+	Region() string
+
+	StacksAPI
+	ECSAPI
+	LambdaAPI
+	APIGatewayAPI
+	SQSAPI
+	DynamoDBAPI
+	EC2API
+	LogsAPI
+	CloudWatchAPI
+	AppRunnerAPI
+	FirehoseAPI
+	CognitoAPI
+	MSKAPI
+	MQAPI
+	DatabasesAPI
+	EFSAPI
+	SchedulerAPI
+	SecretsAPI
+	SESAPI
+	CloudControlAPI
+	CloudTrailAPI
+	HealthAPI
+	CostsAPI
+	TerraformAPI
+	OrganizationsAPI
+	RegionsAPI
+	TaggingAPI
+}
+
+// StacksAPI lists CloudFormation stacks, their events and the resources
+// they own, reads their templates, and sets their termination protection and stack policy.
+type StacksAPI interface {
+	ListStacks(ctx context.Context) ([]model.Stack, error)
+	DescribeStack(ctx context.Context, stackName string) (*model.Stack, error)
+	GetStackEvents(ctx context.Context, stackName string) ([]model.StackEvent, error)
+	GetTemplate(ctx context.Context, stackName string) (string, error)
+	SetTerminationProtection(ctx context.Context, stackName string, enabled bool) error
+	SetStackPolicy(ctx context.Context, stackName, policy string) error
+	GetServicesForStack(ctx context.Context, stackName string) ([]model.Service, error)
+	GetLambdaFunctionsFromStack(ctx context.Context, stackName string) ([]string, error)
+	GetQueuesFromStack(ctx context.Context, stackName string) ([]string, error)
+	GetAlarmsFromStack(ctx context.Context, stackName string) ([]string, error)
+	GetAPIGatewaysFromStack(ctx context.Context, stackName string) (restAPIIDs []string, httpAPIIDs []string, err error)
+}
+
+// ECSAPI lists ECS clusters, services and tasks, and the images they run,
+// sums up clusters, and stops and protects tasks.
+type ECSAPI interface {
+	ListClusters(ctx context.Context) ([]model.Cluster, error)
+	ListServices(ctx context.Context, clusterARN string) ([]model.Service, error)
+	DescribeService(ctx context.Context, clusterARN, serviceName string) (*model.Service, error)
+	ListTasksForService(ctx context.Context, clusterARN, serviceName string) ([]model.Task, error)
+	ListStoppedTasksForService(ctx context.Context, clusterARN, serviceName string) ([]model.Task, error)
+	GetTaskDefinitionDocument(ctx context.Context, taskDef string) (string, error)
+	GetContainerLogConfigs(ctx context.Context, taskDefARN, taskID string) ([]model.ContainerLogConfig, error)
+	GetServiceImages(ctx context.Context, services []model.Service) ([]model.ServiceImage, error)
+	GetServiceVulnerabilities(ctx context.Context, svc model.Service) ([]model.ImageVulnerabilities, error)
+	StopTasks(ctx context.Context, clusterARN string, taskARNs []string, reason string) (int, error)
+	GetTaskProtection(ctx context.Context, clusterARN string, taskARNs []string) (map[string]model.TaskProtection, error)
+	UpdateTaskProtection(ctx context.Context, clusterARN string, taskARNs []string, enabled bool, expiresInMinutes int) ([]model.TaskProtection, error)
+	GetClusterDashboard(ctx context.Context, cluster model.Cluster) (*model.ClusterDashboard, error)
+}
+
+// LambdaAPI lists and invokes Lambda functions.
+type LambdaAPI interface {
+	ListFunctionsPagedCallback(ctx context.Context, callback func(functions []model.Function, hasMore bool) bool) error
+	DescribeFunction(ctx context.Context, functionName string) (*model.Function, error)
+	DescribeFunctions(ctx context.Context, names []string) ([]model.Function, map[string]error)
+	InvokeFunction(ctx context.Context, functionName, payload string) (*model.InvocationResult, error)
+	ListAliases(ctx context.Context, functionName string) ([]model.LambdaAlias, error)
+	ListVersions(ctx context.Context, functionName string) ([]string, error)
+	ShiftAliasTraffic(ctx context.Context, functionName, aliasName, version, routingVersion string, weight float64) (*model.LambdaAlias, error)
+	GetLambdaPerformance(ctx context.Context, fn model.Function, r model.TimeRange) (*model.LambdaPerformance, error)
+}
+
+// APIGatewayAPI lists REST and HTTP APIs, their stages and VPC endpoints,
+// and rolls REST API stages between deployments.
+type APIGatewayAPI interface {
+	ListRestAPIs(ctx context.Context) ([]model.RestAPI, error)
+	GetRestAPI(ctx context.Context, apiID string) (*model.RestAPI, error)
+	GetRestAPIStages(ctx context.Context, apiID string) ([]model.APIStage, error)
+	ListRestAPIDeployments(ctx context.Context, apiID string) ([]model.APIDeployment, error)
+	SetRestAPIStageDeployment(ctx context.Context, apiID, stageName, deploymentID string) error
+	ListHttpAPIs(ctx context.Context) ([]model.HttpAPI, error)
+	GetHttpAPI(ctx context.Context, apiID string) (*model.HttpAPI, error)
+	GetHttpAPIStages(ctx context.Context, apiID string) ([]model.APIStage, error)
+	GetRestAPIRoutes(ctx context.Context, apiID string) ([]model.APIRoute, error)
+	GetHttpAPIRoutes(ctx context.Context, apiID string) ([]model.APIRoute, error)
+	ListAPIGatewayVpcEndpoints(ctx context.Context) (map[string]*model.VpcEndpoint, error)
+}
+
+// SQSAPI lists SQS queues, reads and sends their messages, maps who uses
+// them and why their consumers fail.
+type SQSAPI interface {
+	ListQueuesPagedCallback(ctx context.Context, callback func(queues []model.Queue, hasMore bool) bool) error
+	GetQueueAttributes(ctx context.Context, queueURL string) (*model.Queue, error)
+	ReceiveMessages(ctx context.Context, queueURL string, visibilityTimeout int32) ([]model.QueueMessage, error)
+	SendMessage(ctx context.Context, queueURL, body, groupID, dedupID string) (string, error)
+	GetQueueRelations(ctx context.Context, queue model.Queue) (*model.QueueRelations, error)
+	GetQueueFailures(ctx context.Context, queue model.Queue) (*model.QueueFailures, error)
+}
+
+// DynamoDBAPI lists, queries and scans DynamoDB tables, and puts and
+// deletes their items.
+type DynamoDBAPI interface {
+	ListTablesPagedCallback(ctx context.Context, callback func(tables []model.Table, hasMore bool) bool) error
+	QueryTable(ctx context.Context, params model.QueryParams, lastKey map[string]interface{}) (*model.QueryResult, error)
+	ScanTable(ctx context.Context, params model.ScanParams, lastKey map[string]interface{}) (*model.QueryResult, error)
+	PutItem(ctx context.Context, tableName, item string) error
+	DeleteItem(ctx context.Context, tableName, key string) error
+}
+
+// RegionsAPI lists the account's regions and measures how fast they answer.
+type RegionsAPI interface {
+	ListRegions(ctx context.Context) ([]string, error)
+	RegionLatencies(ctx context.Context, regions []string) map[string]time.Duration
+}
+
+// EC2API lists EC2 instances and finds the ones tunnels go through.
+type EC2API interface {
+	ListInstances(ctx context.Context) ([]model.EC2Instance, error)
+	FindJumpHost(ctx context.Context, vpcID string, jumpHostConfig, jumpHostTagConfig string, defaultTags, defaultNames []string, preferredVPCs ...string) (*model.EC2Instance, error)
+	ListSSMManagedInstances(ctx context.Context) ([]model.EC2Instance, error)
+	GetSubnetVPC(ctx context.Context, subnetID string) (string, error)
+}
+
+// LogsAPI reads and searches CloudWatch Logs, and manages the retention of
+// log groups.
+type LogsAPI interface {
+	FetchLogs(ctx context.Context, logGroup, logStream string, startTime int64, limit int32) ([]model.CloudWatchLogEntry, int64, error)
+	FetchLogStreams(ctx context.Context, logGroup string, logStreams []string, startTime int64, limit int32) ([]model.CloudWatchLogEntry, int64, error)
+	FetchLambdaLogs(ctx context.Context, logGroup string, startTime int64, limit int32) ([]model.CloudWatchLogEntry, int64, error)
+	StackLogSources(ctx context.Context, stackName string) ([]model.LogSource, error)
+	SearchLogGroups(ctx context.Context, sources []model.LogSource, pattern string, r model.TimeRange, limit int) ([]model.LogSearchHit, error)
+	ListLogGroups(ctx context.Context) ([]model.LogGroup, error)
+	SetLogGroupRetention(ctx context.Context, name string, days int32) error
+	DeleteLogGroup(ctx context.Context, name string) error
+}
+
+// CloudWatchAPI lists CloudWatch alarms.
+type CloudWatchAPI interface {
+	ListAlarms(ctx context.Context) ([]model.Alarm, error)
+}
+
+// AppRunnerAPI lists and operates App Runner services.
+type AppRunnerAPI interface {
+	ListAppRunnerServices(ctx context.Context) ([]model.AppRunnerService, error)
+	ListAppRunnerOperations(ctx context.Context, serviceARN string, limit int) ([]model.AppRunnerOperation, error)
+	PauseAppRunnerService(ctx context.Context, serviceARN string) error
+	ResumeAppRunnerService(ctx context.Context, serviceARN string) error
+	StartAppRunnerDeployment(ctx context.Context, serviceARN string) (string, error)
+}
+
+// FirehoseAPI lists delivery streams and tests them.
+type FirehoseAPI interface {
+	ListDeliveryStreams(ctx context.Context) ([]model.DeliveryStream, error)
+	FetchDeliveryErrors(ctx context.Context, stream *model.DeliveryStream, since time.Time, limit int32) ([]model.CloudWatchLogEntry, error)
+	PutTestRecord(ctx context.Context, streamName, testID string) (string, error)
+}
+
+// CognitoAPI browses user pools and manages their users.
+type CognitoAPI interface {
+	ListUserPools(ctx context.Context) ([]model.UserPool, error)
+	GetUserPoolDetails(ctx context.Context, poolID string) (*model.UserPoolDetails, error)
+	SearchUsers(ctx context.Context, poolID, query string) ([]model.CognitoUser, error)
+	ConfirmUser(ctx context.Context, poolID, username string) error
+	SetUserEnabled(ctx context.Context, poolID, username string, enabled bool) error
+}
+
+// MSKAPI lists Kafka clusters and their brokers.
+type MSKAPI interface {
+	ListMSKClusters(ctx context.Context) ([]model.MSKCluster, error)
+	GetMSKBootstrapBrokers(ctx context.Context, clusterARN string) (*model.MSKBootstrapBrokers, error)
+}
+
+// MQAPI lists Amazon MQ brokers and their endpoints.
+type MQAPI interface {
+	ListMQBrokers(ctx context.Context) ([]model.MQBroker, error)
+}
+
+// DatabasesAPI lists the RDS, RDS Proxy and Redshift endpoints tunneled to.
+type DatabasesAPI interface {
+	ListDatabases(ctx context.Context) ([]model.Database, error)
+}
+
+// EFSAPI lists EFS file systems and what mounts them.
+type EFSAPI interface {
+	ListEFSFileSystems(ctx context.Context) ([]model.EFSFileSystem, error)
+	GetEFSDetails(ctx context.Context, fileSystemID string) (*model.EFSDetails, error)
+}
+
+// SchedulerAPI lists EventBridge Scheduler schedules, pauses them and runs
+// them on demand.
+type SchedulerAPI interface {
+	ListSchedules(ctx context.Context) ([]model.Schedule, error)
+	SetScheduleState(ctx context.Context, group, name string, state model.ScheduleState) error
+	RunScheduleNow(ctx context.Context, group, name string) (string, error)
+}
+
+// SecretsAPI lists Secrets Manager secrets and rotates them on demand.
+type SecretsAPI interface {
+	ListSecrets(ctx context.Context) ([]model.Secret, error)
+	RotateSecret(ctx context.Context, secretARN string) error
+}
+
+// SESAPI reads SES sending state and manages the suppression list.
+type SESAPI interface {
+	GetSESAccount(ctx context.Context) (*model.SESAccount, error)
+	ListSESIdentities(ctx context.Context) ([]model.SESIdentity, error)
+	ListSESConfigurationSets(ctx context.Context) ([]model.SESConfigurationSet, error)
+	ListSuppressedDestinations(ctx context.Context) ([]model.SESSuppressedDestination, bool, error)
+	RemoveSuppressedDestination(ctx context.Context, email string) error
+	SendTestEmail(ctx context.Context, from, to string) (string, error)
+}
+
+// CloudControlAPI lists resources of any type through Cloud Control.
+type CloudControlAPI interface {
+	ListResources(ctx context.Context, typeName string) ([]model.CloudResource, error)
+	GetResource(ctx context.Context, typeName, identifier string) (*model.CloudResource, error)
+}
+
+// CloudTrailAPI looks up who changed a resource.
+type CloudTrailAPI interface {
+	ListResourceActivity(ctx context.Context, names []string, r model.TimeRange, limit int) ([]model.ActivityEvent, error)
+}
+
+// HealthAPI summarizes what needs attention in the account.
+type HealthAPI interface {
+	GetAccountHealth(ctx context.Context) *model.AccountHealth
+}
+
+// CostsAPI reads the account's estimated charges and budgets.
+type CostsAPI interface {
+	GetCostSummary(ctx context.Context) (*model.CostSummary, error)
+}
+
+// OrganizationsAPI lists the accounts of the organization and assumes a role
+// into them.
+type OrganizationsAPI interface {
+	GetCallerIdentity(ctx context.Context) (*model.CallerIdentity, error)
+	ListOrganizationAccounts(ctx context.Context) ([]model.OrgAccount, error)
+	AssumeAccount(ctx context.Context, accountID, roleName string) (API, error)
+	AssumedAccount() string
+	CLIEnv(ctx context.Context) ([]string, error)
+}
+
+// TaggingAPI finds the resources of the region that carry a tag.
+type TaggingAPI interface {
+	GetTaggedResources(ctx context.Context, filter model.TagFilter) ([]string, error)
+}
+
+// TerraformAPI reads Terraform states, from local files or S3, to tell which
+// Terraform resources manage the AWS ones.
+type TerraformAPI interface {
+	LoadTerraformState(ctx context.Context, location string) ([]model.TerraformResource, error)
+}
+
+var _ API = (*Client)(nil)
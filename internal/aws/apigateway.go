@@ -3,9 +3,12 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigwtypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
 	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
 
 	"vaws/internal/model"
@@ -92,19 +95,70 @@ func (c *Client) GetRestAPIStages(ctx context.Context, apiID string) ([]model.AP
 		invokeURL := fmt.Sprintf("https://%s.execute-api.%s.amazonaws.com/%s",
 			apiID, c.region, aws.ToString(s.StageName))
 
-		stages = append(stages, model.APIStage{
+		stage := model.APIStage{
 			Name:         aws.ToString(s.StageName),
 			Description:  aws.ToString(s.Description),
 			DeploymentID: aws.ToString(s.DeploymentId),
 			CreatedDate:  aws.ToTime(s.CreatedDate),
 			LastUpdated:  aws.ToTime(s.LastUpdatedDate),
 			InvokeURL:    invokeURL,
-		})
+		}
+		if s.AccessLogSettings != nil {
+			stage.AccessLogGroup = logGroupFromARN(aws.ToString(s.AccessLogSettings.DestinationArn))
+			stage.AccessLogFormat = aws.ToString(s.AccessLogSettings.Format)
+		}
+		stages = append(stages, stage)
 	}
 
 	return stages, nil
 }
 
+// ListRestAPIDeployments returns the deployments of a REST API, newest
+// first.
+func (c *Client) ListRestAPIDeployments(ctx context.Context, apiID string) ([]model.APIDeployment, error) {
+	var deployments []model.APIDeployment
+
+	paginator := apigateway.NewGetDeploymentsPaginator(c.apigw, &apigateway.GetDeploymentsInput{
+		RestApiId: aws.String(apiID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployments of REST API %s: %w", apiID, err)
+		}
+		for _, d := range page.Items {
+			deployments = append(deployments, model.APIDeployment{
+				ID:          aws.ToString(d.Id),
+				Description: aws.ToString(d.Description),
+				CreatedDate: aws.ToTime(d.CreatedDate),
+			})
+		}
+	}
+
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].CreatedDate.After(deployments[j].CreatedDate)
+	})
+	return deployments, nil
+}
+
+// SetRestAPIStageDeployment points a stage of a REST API at a deployment,
+// e.g. to roll it back to an earlier one.
+func (c *Client) SetRestAPIStageDeployment(ctx context.Context, apiID, stageName, deploymentID string) error {
+	_, err := c.apigw.UpdateStage(ctx, &apigateway.UpdateStageInput{
+		RestApiId: aws.String(apiID),
+		StageName: aws.String(stageName),
+		PatchOperations: []apigwtypes.PatchOperation{{
+			Op:    apigwtypes.OpReplace,
+			Path:  aws.String("/deploymentId"),
+			Value: aws.String(deploymentID),
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update stage %s of REST API %s: %w", stageName, apiID, err)
+	}
+	return nil
+}
+
 // ListHttpAPIs lists all HTTP APIs (API Gateway v2).
 func (c *Client) ListHttpAPIs(ctx context.Context) ([]model.HttpAPI, error) {
 	var apis []model.HttpAPI
@@ -170,41 +224,98 @@ func (c *Client) GetHttpAPIStages(ctx context.Context, apiID string) ([]model.AP
 
 	var stages []model.APIStage
 	for _, s := range out.Items {
-		stages = append(stages, model.APIStage{
+		stage := model.APIStage{
 			Name:         aws.ToString(s.StageName),
 			Description:  aws.ToString(s.Description),
 			DeploymentID: aws.ToString(s.DeploymentId),
 			CreatedDate:  aws.ToTime(s.CreatedDate),
 			LastUpdated:  aws.ToTime(s.LastUpdatedDate),
-		})
+		}
+		if s.AccessLogSettings != nil {
+			stage.AccessLogGroup = logGroupFromARN(aws.ToString(s.AccessLogSettings.DestinationArn))
+			stage.AccessLogFormat = aws.ToString(s.AccessLogSettings.Format)
+		}
+		stages = append(stages, stage)
 	}
 
 	return stages, nil
 }
 
+// logGroupFromARN returns the name of the log group an access log ARN
+// points to, or "" for other destinations such as Firehose.
+// e.g. "arn:aws:logs:eu-west-1:123456789012:log-group:api-access:*" -> "api-access"
+func logGroupFromARN(arn string) string {
+	_, name, ok := strings.Cut(arn, ":log-group:")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSuffix(name, ":*")
+}
+
 // GetHttpAPIRoutes returns the routes for an HTTP API.
 func (c *Client) GetHttpAPIRoutes(ctx context.Context, apiID string) ([]model.APIRoute, error) {
-	out, err := c.apigwv2.GetRoutes(ctx, &apigatewayv2.GetRoutesInput{
-		ApiId: aws.String(apiID),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get routes for HTTP API %s: %w", apiID, err)
+	var routes []model.APIRoute
+	input := &apigatewayv2.GetRoutesInput{ApiId: aws.String(apiID)}
+	for {
+		out, err := c.apigwv2.GetRoutes(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get routes for HTTP API %s: %w", apiID, err)
+		}
+
+		for _, r := range out.Items {
+			authType := "NONE"
+			if r.AuthorizationType != "" {
+				authType = string(r.AuthorizationType)
+			}
+
+			routes = append(routes, model.APIRoute{
+				RouteKey: aws.ToString(r.RouteKey),
+				RouteID:  aws.ToString(r.RouteId),
+				Target:   aws.ToString(r.Target),
+				AuthType: authType,
+			})
+		}
+
+		if aws.ToString(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
 	}
 
+	sort.Slice(routes, func(i, j int) bool { return routes[i].RouteKey < routes[j].RouteKey })
+	return routes, nil
+}
+
+// GetRestAPIRoutes returns the methods of a REST API's resources as routes,
+// e.g. "GET /orders/{id}", in the form HTTP API routes take.
+func (c *Client) GetRestAPIRoutes(ctx context.Context, apiID string) ([]model.APIRoute, error) {
 	var routes []model.APIRoute
-	for _, r := range out.Items {
-		authType := "NONE"
-		if r.AuthorizationType != "" {
-			authType = string(r.AuthorizationType)
+
+	paginator := apigateway.NewGetResourcesPaginator(c.apigw, &apigateway.GetResourcesInput{
+		RestApiId: aws.String(apiID),
+		Embed:     []string{"methods"},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resources for REST API %s: %w", apiID, err)
 		}
 
-		routes = append(routes, model.APIRoute{
-			RouteKey: aws.ToString(r.RouteKey),
-			RouteID:  aws.ToString(r.RouteId),
-			Target:   aws.ToString(r.Target),
-			AuthType: authType,
-		})
+		for _, r := range page.Items {
+			for method, m := range r.ResourceMethods {
+				route := model.APIRoute{
+					RouteKey: method + " " + aws.ToString(r.Path),
+					RouteID:  aws.ToString(r.Id),
+					AuthType: aws.ToString(m.AuthorizationType),
+				}
+				if m.MethodIntegration != nil {
+					route.Target = string(m.MethodIntegration.Type)
+				}
+				routes = append(routes, route)
+			}
+		}
 	}
 
+	sort.Slice(routes, func(i, j int) bool { return routes[i].RouteKey < routes[j].RouteKey })
 	return routes, nil
 }
@@ -0,0 +1,208 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apprunner"
+	apprunnertypes "github.com/aws/aws-sdk-go-v2/service/apprunner/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// ListAppRunnerServices lists all App Runner services with their source and instance details.
+func (c *Client) ListAppRunnerServices(ctx context.Context) ([]model.AppRunnerService, error) {
+	log.Debug("Listing App Runner services...")
+
+	var summaries []apprunnertypes.ServiceSummary
+	paginator := apprunner.NewListServicesPaginator(c.apprunner, &apprunner.ListServicesInput{})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list App Runner services: %w", err)
+		}
+		summaries = append(summaries, page.ServiceSummaryList...)
+	}
+
+	if len(summaries) == 0 {
+		log.Info("No App Runner services found")
+		return nil, nil
+	}
+
+	type serviceResult struct {
+		index   int
+		service model.AppRunnerService
+	}
+
+	results := make(chan serviceResult, len(summaries))
+	sem := c.slots(ConcurrencyAppRunner)
+
+	var wg sync.WaitGroup
+	for i, summary := range summaries {
+		wg.Add(1)
+		go func(idx int, summary apprunnertypes.ServiceSummary) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			svc, err := c.DescribeAppRunnerService(ctx, aws.ToString(summary.ServiceArn))
+			if err != nil {
+				// Fall back to the summary so the service is still listed
+				log.Warn("Failed to describe App Runner service: %v", err)
+				results <- serviceResult{index: idx, service: convertAppRunnerSummary(summary)}
+				return
+			}
+			results <- serviceResult{index: idx, service: *svc}
+		}(i, summary)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	services := make([]model.AppRunnerService, len(summaries))
+	described := 0
+	reportProgress(ctx, "DescribeService", "services", 0, len(summaries))
+	for result := range results {
+		services[result.index] = result.service
+		described++
+		reportProgress(ctx, "DescribeService", "services", described, len(summaries))
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+
+	log.Info("Found %d App Runner services", len(services))
+	return services, nil
+}
+
+// DescribeAppRunnerService returns details for a single App Runner service.
+func (c *Client) DescribeAppRunnerService(ctx context.Context, serviceARN string) (*model.AppRunnerService, error) {
+	out, err := c.apprunner.DescribeService(ctx, &apprunner.DescribeServiceInput{
+		ServiceArn: aws.String(serviceARN),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe App Runner service %s: %w", serviceARN, err)
+	}
+
+	svc := convertAppRunnerService(out.Service)
+	return &svc, nil
+}
+
+// ListAppRunnerOperations returns the most recent operations for an App Runner service.
+func (c *Client) ListAppRunnerOperations(ctx context.Context, serviceARN string, limit int) ([]model.AppRunnerOperation, error) {
+	log.Debug("Listing App Runner operations for %s...", serviceARN)
+
+	out, err := c.apprunner.ListOperations(ctx, &apprunner.ListOperationsInput{
+		ServiceArn: aws.String(serviceARN),
+		MaxResults: aws.Int32(int32(limit)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list App Runner operations: %w", err)
+	}
+
+	ops := make([]model.AppRunnerOperation, 0, len(out.OperationSummaryList))
+	for _, op := range out.OperationSummaryList {
+		ops = append(ops, model.AppRunnerOperation{
+			ID:        aws.ToString(op.Id),
+			Type:      string(op.Type),
+			Status:    string(op.Status),
+			StartedAt: aws.ToTime(op.StartedAt),
+			EndedAt:   aws.ToTime(op.EndedAt),
+		})
+	}
+	return ops, nil
+}
+
+// PauseAppRunnerService pauses an App Runner service.
+func (c *Client) PauseAppRunnerService(ctx context.Context, serviceARN string) error {
+	log.Info("Pausing App Runner service: %s", serviceARN)
+
+	_, err := c.apprunner.PauseService(ctx, &apprunner.PauseServiceInput{
+		ServiceArn: aws.String(serviceARN),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pause App Runner service: %w", err)
+	}
+	return nil
+}
+
+// ResumeAppRunnerService resumes a paused App Runner service.
+func (c *Client) ResumeAppRunnerService(ctx context.Context, serviceARN string) error {
+	log.Info("Resuming App Runner service: %s", serviceARN)
+
+	_, err := c.apprunner.ResumeService(ctx, &apprunner.ResumeServiceInput{
+		ServiceArn: aws.String(serviceARN),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resume App Runner service: %w", err)
+	}
+	return nil
+}
+
+// StartAppRunnerDeployment triggers a manual deployment of an App Runner service.
+func (c *Client) StartAppRunnerDeployment(ctx context.Context, serviceARN string) (string, error) {
+	log.Info("Starting App Runner deployment: %s", serviceARN)
+
+	out, err := c.apprunner.StartDeployment(ctx, &apprunner.StartDeploymentInput{
+		ServiceArn: aws.String(serviceARN),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start App Runner deployment: %w", err)
+	}
+	return aws.ToString(out.OperationId), nil
+}
+
+// convertAppRunnerSummary converts an SDK service summary to our model.
+func convertAppRunnerSummary(s apprunnertypes.ServiceSummary) model.AppRunnerService {
+	return model.AppRunnerService{
+		Name:      aws.ToString(s.ServiceName),
+		ARN:       aws.ToString(s.ServiceArn),
+		ServiceID: aws.ToString(s.ServiceId),
+		URL:       aws.ToString(s.ServiceUrl),
+		Status:    model.AppRunnerStatus(s.Status),
+		CreatedAt: aws.ToTime(s.CreatedAt),
+		UpdatedAt: aws.ToTime(s.UpdatedAt),
+	}
+}
+
+// convertAppRunnerService converts an SDK service to our model.
+func convertAppRunnerService(s *apprunnertypes.Service) model.AppRunnerService {
+	svc := model.AppRunnerService{
+		Name:      aws.ToString(s.ServiceName),
+		ARN:       aws.ToString(s.ServiceArn),
+		ServiceID: aws.ToString(s.ServiceId),
+		URL:       aws.ToString(s.ServiceUrl),
+		Status:    model.AppRunnerStatus(s.Status),
+		CreatedAt: aws.ToTime(s.CreatedAt),
+		UpdatedAt: aws.ToTime(s.UpdatedAt),
+	}
+
+	if s.InstanceConfiguration != nil {
+		svc.CPU = aws.ToString(s.InstanceConfiguration.Cpu)
+		svc.Memory = aws.ToString(s.InstanceConfiguration.Memory)
+	}
+
+	if sc := s.SourceConfiguration; sc != nil {
+		svc.AutoDeploy = aws.ToBool(sc.AutoDeploymentsEnabled)
+		if sc.ImageRepository != nil {
+			svc.SourceType = "Image"
+			svc.Source = aws.ToString(sc.ImageRepository.ImageIdentifier)
+		} else if sc.CodeRepository != nil {
+			svc.SourceType = "Code"
+			svc.Source = aws.ToString(sc.CodeRepository.RepositoryUrl)
+			if v := sc.CodeRepository.SourceCodeVersion; v != nil {
+				svc.Source += "@" + aws.ToString(v.Value)
+			}
+		}
+	}
+
+	return svc
+}
@@ -10,33 +10,61 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
 	"github.com/aws/aws-sdk-go-v2/service/apigateway"
 	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apprunner"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cognito "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"vaws/internal/model"
 )
 
 // Client wraps AWS service clients for a specific profile/region.
 type Client struct {
-	cfg      aws.Config
-	profile  string
-	region   string
-	cfn      *cloudformation.Client
-	ecs      *ecs.Client
-	lambda   *lambda.Client
-	apigw    *apigateway.Client
-	apigwv2  *apigatewayv2.Client
-	ec2      *ec2.Client
-	ssm      *ssm.Client
-	cwlogs   *cloudwatchlogs.Client
-	sqs      *sqs.Client
-	dynamodb *dynamodb.Client
+	cfg          aws.Config
+	profile      string
+	region       string
+	cfn          *cloudformation.Client
+	ecs          *ecs.Client
+	lambda       *lambda.Client
+	apigw        *apigateway.Client
+	apigwv2      *apigatewayv2.Client
+	ec2          *ec2.Client
+	ssm          *ssm.Client
+	cwlogs       *cloudwatchlogs.Client
+	cw           *cloudwatch.Client
+	sqs          *sqs.Client
+	dynamodb     *dynamodb.Client
+	apprunner    *apprunner.Client
+	cloudmap     *servicediscovery.Client
+	firehose     *firehose.Client
+	cognito      *cognito.Client
+	cloudcontrol *cloudcontrol.Client
+	ses          *sesv2.Client
+	cloudtrail   *cloudtrail.Client
+	acm          *acm.Client
+	sts          *sts.Client
+
+	cloudMapCache cloudMapCache
+	pool          workerPool
+
+	// Member account assumed into with AssumeAccount, if any
+	account string
 }
 
 // NewClient creates a new AWS client using the specified profile.
@@ -61,22 +89,38 @@ func NewClient(ctx context.Context, profile, region string) (*Client, error) {
 	if region == "" {
 		region = cfg.Region
 	}
+	cfg.APIOptions = append(cfg.APIOptions, addCallMetrics)
+
+	return newClientFromConfig(cfg, profile, region), nil
+}
 
+// newClientFromConfig creates the service clients of a loaded AWS config.
+func newClientFromConfig(cfg aws.Config, profile, region string) *Client {
 	return &Client{
-		cfg:      cfg,
-		profile:  profile,
-		region:   region,
-		cfn:      cloudformation.NewFromConfig(cfg),
-		ecs:      ecs.NewFromConfig(cfg),
-		lambda:   lambda.NewFromConfig(cfg),
-		apigw:    apigateway.NewFromConfig(cfg),
-		apigwv2:  apigatewayv2.NewFromConfig(cfg),
-		ec2:      ec2.NewFromConfig(cfg),
-		ssm:      ssm.NewFromConfig(cfg),
-		cwlogs:   cloudwatchlogs.NewFromConfig(cfg),
-		sqs:      sqs.NewFromConfig(cfg),
-		dynamodb: dynamodb.NewFromConfig(cfg),
-	}, nil
+		cfg:          cfg,
+		profile:      profile,
+		region:       region,
+		cfn:          cloudformation.NewFromConfig(cfg),
+		ecs:          ecs.NewFromConfig(cfg),
+		lambda:       lambda.NewFromConfig(cfg),
+		apigw:        apigateway.NewFromConfig(cfg),
+		apigwv2:      apigatewayv2.NewFromConfig(cfg),
+		ec2:          ec2.NewFromConfig(cfg),
+		ssm:          ssm.NewFromConfig(cfg),
+		cwlogs:       cloudwatchlogs.NewFromConfig(cfg),
+		cw:           cloudwatch.NewFromConfig(cfg),
+		sqs:          sqs.NewFromConfig(cfg),
+		dynamodb:     dynamodb.NewFromConfig(cfg),
+		apprunner:    apprunner.NewFromConfig(cfg),
+		cloudmap:     servicediscovery.NewFromConfig(cfg),
+		firehose:     firehose.NewFromConfig(cfg),
+		cognito:      cognito.NewFromConfig(cfg),
+		cloudcontrol: cloudcontrol.NewFromConfig(cfg),
+		ses:          sesv2.NewFromConfig(cfg),
+		cloudtrail:   cloudtrail.NewFromConfig(cfg),
+		acm:          acm.NewFromConfig(cfg),
+		sts:          sts.NewFromConfig(cfg),
+	}
 }
 
 // Profile returns the configured profile name.
@@ -129,6 +173,11 @@ func (c *Client) CloudWatchLogs() *cloudwatchlogs.Client {
 	return c.cwlogs
 }
 
+// CloudWatch returns the CloudWatch client.
+func (c *Client) CloudWatch() *cloudwatch.Client {
+	return c.cw
+}
+
 // SQS returns the SQS client.
 func (c *Client) SQS() *sqs.Client {
 	return c.sqs
@@ -139,6 +188,46 @@ func (c *Client) DynamoDB() *dynamodb.Client {
 	return c.dynamodb
 }
 
+// AppRunner returns the App Runner client.
+func (c *Client) AppRunner() *apprunner.Client {
+	return c.apprunner
+}
+
+// CloudMap returns the Cloud Map (service discovery) client.
+func (c *Client) CloudMap() *servicediscovery.Client {
+	return c.cloudmap
+}
+
+// Firehose returns the Kinesis Data Firehose client.
+func (c *Client) Firehose() *firehose.Client {
+	return c.firehose
+}
+
+// Cognito returns the Cognito user pools client.
+func (c *Client) Cognito() *cognito.Client {
+	return c.cognito
+}
+
+// CloudControl returns the Cloud Control API client.
+func (c *Client) CloudControl() *cloudcontrol.Client {
+	return c.cloudcontrol
+}
+
+// SES returns the SES v2 client.
+func (c *Client) SES() *sesv2.Client {
+	return c.ses
+}
+
+// CloudTrail returns the CloudTrail client.
+func (c *Client) CloudTrail() *cloudtrail.Client {
+	return c.cloudtrail
+}
+
+// ACM returns the Certificate Manager client.
+func (c *Client) ACM() *acm.Client {
+	return c.acm
+}
+
 // Config returns the underlying AWS config.
 func (c *Client) Config() aws.Config {
 	return c.cfg
@@ -146,6 +235,21 @@ func (c *Client) Config() aws.Config {
 
 // ListProfiles returns all available AWS profiles from the config file.
 func ListProfiles() ([]string, error) {
+	details, err := ListProfileDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]string, len(details))
+	for i, p := range details {
+		profiles[i] = p.Name
+	}
+	return profiles, nil
+}
+
+// ListProfileDetails returns the profiles of the config file with their
+// region and how they get credentials.
+func ListProfileDetails() ([]model.AWSProfile, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get home directory: %w", err)
@@ -155,26 +259,77 @@ func ListProfiles() ([]string, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return []string{"default"}, nil
+			return []model.AWSProfile{{Name: "default"}}, nil
 		}
 		return nil, fmt.Errorf("failed to read AWS config: %w", err)
 	}
 
-	var profiles []string
+	var profiles []model.AWSProfile
+	var current *model.AWSProfile // Nil inside non-profile sections such as [sso-session]
+	session := ""                 // Name of the [sso-session] section being read
+	sessionRegions := make(map[string]string)
 	lines := strings.Split(string(data), "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "[profile ") && strings.HasSuffix(line, "]") {
-			name := strings.TrimPrefix(line, "[profile ")
-			name = strings.TrimSuffix(name, "]")
-			profiles = append(profiles, name)
-		} else if line == "[default]" {
-			profiles = append(profiles, "default")
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = nil
+			session = ""
+			name := ""
+			if strings.HasPrefix(line, "[sso-session ") {
+				session = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, "[sso-session "), "]"))
+			} else if strings.HasPrefix(line, "[profile ") {
+				name = strings.TrimSuffix(strings.TrimPrefix(line, "[profile "), "]")
+			} else if line == "[default]" {
+				name = "default"
+			}
+			if name != "" {
+				profiles = append(profiles, model.AWSProfile{Name: name})
+				current = &profiles[len(profiles)-1]
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if session != "" && key == "sso_region" {
+			sessionRegions[session] = value
+		}
+		if current == nil {
+			continue
+		}
+		switch key {
+		case "region":
+			current.Region = value
+		case "sso_account_id":
+			current.AccountID = value
+		case "sso_region":
+			current.SSORegion = value
+		case "sso_session":
+			current.SSO = true
+			current.SSOSession = value
+		case "sso_start_url":
+			current.SSO = true
+			if current.SSOSession == "" {
+				current.SSOSession = value
+			}
+		case "role_arn":
+			current.RoleARN = value
+		case "source_profile":
+			current.SourceProfile = value
+		}
+	}
+
+	for i, p := range profiles {
+		if region, ok := sessionRegions[p.SSOSession]; ok && p.SSORegion == "" {
+			profiles[i].SSORegion = region
 		}
 	}
 
 	if len(profiles) == 0 {
-		profiles = append(profiles, "default")
+		profiles = append(profiles, model.AWSProfile{Name: "default"})
 	}
 
 	return profiles, nil
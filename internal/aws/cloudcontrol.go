@@ -0,0 +1,102 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	cctypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// ListResources lists resources of a CloudFormation resource type (e.g.,
+// AWS::MSK::Cluster) through the Cloud Control API.
+func (c *Client) ListResources(ctx context.Context, typeName string) ([]model.CloudResource, error) {
+	log.Debug("Listing %s resources...", typeName)
+
+	var resources []model.CloudResource
+	paginator := cloudcontrol.NewListResourcesPaginator(c.cloudcontrol, &cloudcontrol.ListResourcesInput{
+		TypeName: aws.String(typeName),
+	})
+
+	for page := 1; paginator.HasMorePages(); page++ {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s resources: %w", typeName, err)
+		}
+		for _, desc := range out.ResourceDescriptions {
+			resources = append(resources, convertCloudResource(typeName, desc))
+		}
+		reportProgress(ctx, "ListResources", "pages", page, 0)
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Identifier < resources[j].Identifier
+	})
+
+	log.Info("Found %d %s resources", len(resources), typeName)
+	return resources, nil
+}
+
+// GetResource returns the full properties of a resource. Listing may return
+// only a subset of the properties for some resource types.
+func (c *Client) GetResource(ctx context.Context, typeName, identifier string) (*model.CloudResource, error) {
+	log.Debug("Getting %s %s...", typeName, identifier)
+
+	out, err := c.cloudcontrol.GetResource(ctx, &cloudcontrol.GetResourceInput{
+		TypeName:   aws.String(typeName),
+		Identifier: aws.String(identifier),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", typeName, identifier, err)
+	}
+	if out.ResourceDescription == nil {
+		return nil, fmt.Errorf("resource %s not found", identifier)
+	}
+
+	resource := convertCloudResource(typeName, *out.ResourceDescription)
+	return &resource, nil
+}
+
+// convertCloudResource converts a Cloud Control resource description to our model.
+func convertCloudResource(typeName string, desc cctypes.ResourceDescription) model.CloudResource {
+	resource := model.CloudResource{
+		TypeName:   typeName,
+		Identifier: aws.ToString(desc.Identifier),
+		Properties: aws.ToString(desc.Properties),
+	}
+	resource.Name = cloudResourceName(resource.Properties)
+	return resource
+}
+
+// cloudResourceName picks a name-like top-level property, such as ClusterName
+// for AWS::MSK::Cluster, so resources identified by ARN stay readable.
+func cloudResourceName(properties string) string {
+	var props map[string]any
+	if err := json.Unmarshal([]byte(properties), &props); err != nil {
+		return ""
+	}
+	if name, ok := props["Name"].(string); ok {
+		return name
+	}
+
+	var keys []string
+	for key := range props {
+		if strings.HasSuffix(key, "Name") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys) // Stable choice when there are several
+	for _, key := range keys {
+		if name, ok := props[key].(string); ok && name != "" {
+			return name
+		}
+	}
+	return ""
+}
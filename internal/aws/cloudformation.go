@@ -14,11 +14,13 @@ import (
 	"vaws/internal/model"
 )
 
-// ListStacks returns all CloudFormation stacks (excluding deleted ones).
+// ListStacks returns all CloudFormation stacks (excluding deleted ones), as
+// summaries; DescribeStack fills in the rest of a stack.
 func (c *Client) ListStacks(ctx context.Context) ([]model.Stack, error) {
 	log.Debug("Listing CloudFormation stacks...")
 
 	var stacks []model.Stack
+	pages := 0
 	paginator := cloudformation.NewListStacksPaginator(c.cfn, &cloudformation.ListStacksInput{
 		StackStatusFilter: []cftypes.StackStatus{
 			cftypes.StackStatusCreateComplete,
@@ -53,6 +55,8 @@ func (c *Client) ListStacks(ctx context.Context) ([]model.Stack, error) {
 				UpdatedAt:    aws.ToTime(s.LastUpdatedTime),
 			})
 		}
+		pages++
+		reportProgress(ctx, "ListStacks", "pages", pages, 0)
 	}
 
 	// Sort stacks alphabetically by name (case-insensitive)
@@ -64,7 +68,10 @@ func (c *Client) ListStacks(ctx context.Context) ([]model.Stack, error) {
 	return stacks, nil
 }
 
-// DescribeStack returns detailed information about a specific stack.
+// DescribeStack returns detailed information about a specific stack: what
+// ListStacks returns, plus its description, tags, outputs, parameters, drift
+// status, termination protection and stack policy. A policy that can't be
+// read is reported in the stack rather than failing the description.
 func (c *Client) DescribeStack(ctx context.Context, stackName string) (*model.Stack, error) {
 	log.Debug("Describing stack: %s", stackName)
 
@@ -90,6 +97,13 @@ func (c *Client) DescribeStack(ctx context.Context, stackName string) (*model.St
 		UpdatedAt:    aws.ToTime(s.LastUpdatedTime),
 		Description:  aws.ToString(s.Description),
 		Tags:         make(map[string]string),
+		Described:    true,
+
+		TerminationProtection: aws.ToBool(s.EnableTerminationProtection),
+	}
+	if drift := s.DriftInformation; drift != nil {
+		stack.DriftStatus = string(drift.StackDriftStatus)
+		stack.DriftCheckedAt = aws.ToTime(drift.LastCheckTimestamp)
 	}
 
 	for _, tag := range s.Tags {
@@ -112,9 +126,105 @@ func (c *Client) DescribeStack(ctx context.Context, stackName string) (*model.St
 		})
 	}
 
+	policy, err := c.cfn.GetStackPolicy(ctx, &cloudformation.GetStackPolicyInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		log.Debug("Failed to get stack policy of %s: %v", stackName, err)
+		stack.StackPolicyError = err.Error()
+	} else {
+		stack.StackPolicy = aws.ToString(policy.StackPolicyBody)
+	}
+
 	return stack, nil
 }
 
+// maxStackEvents caps the events GetStackEvents reads, as long-lived stacks
+// have thousands and only the recent ones explain the current status.
+const maxStackEvents = 500
+
+// GetStackEvents returns the events of a stack and its resources, newest
+// first, up to maxStackEvents.
+func (c *Client) GetStackEvents(ctx context.Context, stackName string) ([]model.StackEvent, error) {
+	log.Debug("Getting events for stack: %s", stackName)
+
+	var events []model.StackEvent
+	paginator := cloudformation.NewDescribeStackEventsPaginator(c.cfn, &cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(stackName),
+	})
+
+	for paginator.HasMorePages() && len(events) < maxStackEvents {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events of stack %s: %w", stackName, err)
+		}
+
+		for _, e := range page.StackEvents {
+			events = append(events, model.StackEvent{
+				ID:           aws.ToString(e.EventId),
+				Time:         aws.ToTime(e.Timestamp),
+				LogicalID:    aws.ToString(e.LogicalResourceId),
+				PhysicalID:   aws.ToString(e.PhysicalResourceId),
+				ResourceType: aws.ToString(e.ResourceType),
+				Status:       string(e.ResourceStatus),
+				StatusReason: aws.ToString(e.ResourceStatusReason),
+			})
+		}
+	}
+	if len(events) > maxStackEvents {
+		events = events[:maxStackEvents]
+	}
+
+	log.Debug("Found %d events for stack %s", len(events), stackName)
+	return events, nil
+}
+
+// GetTemplate returns the template of a stack as it was submitted, JSON or
+// YAML, before transforms such as AWS::Serverless are expanded.
+func (c *Client) GetTemplate(ctx context.Context, stackName string) (string, error) {
+	log.Debug("Getting template for stack: %s", stackName)
+
+	out, err := c.cfn.GetTemplate(ctx, &cloudformation.GetTemplateInput{
+		StackName:     aws.String(stackName),
+		TemplateStage: cftypes.TemplateStageOriginal,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get template of stack %s: %w", stackName, err)
+	}
+	return aws.ToString(out.TemplateBody), nil
+}
+
+// SetTerminationProtection turns the termination protection of a stack on
+// or off.
+func (c *Client) SetTerminationProtection(ctx context.Context, stackName string, enabled bool) error {
+	log.Info("Setting termination protection of stack %s to %v", stackName, enabled)
+
+	_, err := c.cfn.UpdateTerminationProtection(ctx, &cloudformation.UpdateTerminationProtectionInput{
+		StackName:                   aws.String(stackName),
+		EnableTerminationProtection: aws.Bool(enabled),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update termination protection of %s: %w", stackName, err)
+	}
+	return nil
+}
+
+// SetStackPolicy replaces the stack policy of a stack. CloudFormation has no
+// call to remove one, so a policy can only be relaxed, e.g. to one allowing
+// Update:* on every resource.
+func (c *Client) SetStackPolicy(ctx context.Context, stackName, policy string) error {
+	log.Info("Setting stack policy of %s", stackName)
+
+	_, err := c.cfn.SetStackPolicy(ctx, &cloudformation.SetStackPolicyInput{
+		StackName:       aws.String(stackName),
+		StackPolicyBody: aws.String(policy),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set stack policy of %s: %w", stackName, err)
+	}
+	return nil
+}
+
 // GetStackResources returns resources for a stack, optionally filtered by type.
 func (c *Client) GetStackResources(ctx context.Context, stackName string, resourceType string) ([]cftypes.StackResourceSummary, error) {
 	log.Debug("Getting resources for stack: %s (type filter: %s)", stackName, resourceType)
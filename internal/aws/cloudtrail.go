@@ -0,0 +1,113 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+const (
+	// ActivityLookback is how far back resource activity is looked up.
+	// CloudTrail keeps management events for 90 days.
+	ActivityLookback = 30 * 24 * time.Hour
+
+	// activityMaxPages caps the pages read per resource name, since read-only
+	// events are dropped and LookupEvents allows 2 calls per second.
+	activityMaxPages = 5
+)
+
+// cloudTrailRecord holds the fields of the full event JSON that the lookup
+// response doesn't have.
+type cloudTrailRecord struct {
+	UserIdentity struct {
+		ARN string `json:"arn"`
+	} `json:"userIdentity"`
+	SourceIPAddress string `json:"sourceIPAddress"`
+	ErrorCode       string `json:"errorCode"`
+}
+
+// ListResourceActivity returns the latest management events that changed a
+// resource within a time range, ActivityLookback if it is zero, newest first. names are the names the resource can be recorded
+// under, such as its name and ARN; events matching any of them are merged.
+// Read-only calls (Describe*, List*, Get*) are left out.
+func (c *Client) ListResourceActivity(ctx context.Context, names []string, r model.TimeRange, limit int) ([]model.ActivityEvent, error) {
+	seen := make(map[string]bool)
+	var events []model.ActivityEvent
+	if r.IsZero() {
+		r = model.LastRange(ActivityLookback)
+	}
+	start, end := r.Bounds(time.Now())
+
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		input := &cloudtrail.LookupEventsInput{
+			LookupAttributes: []types.LookupAttribute{{
+				AttributeKey:   types.LookupAttributeKeyResourceName,
+				AttributeValue: aws.String(name),
+			}},
+			StartTime:  aws.Time(start),
+			EndTime:    aws.Time(end),
+			MaxResults: aws.Int32(50),
+		}
+
+		found := 0
+		paginator := cloudtrail.NewLookupEventsPaginator(c.cloudtrail, input)
+		for page := 0; paginator.HasMorePages() && page < activityMaxPages && found < limit; page++ {
+			out, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up CloudTrail events: %w", err)
+			}
+			for _, e := range out.Events {
+				id := aws.ToString(e.EventId)
+				if aws.ToString(e.ReadOnly) == "true" || seen[id] {
+					continue
+				}
+				seen[id] = true
+				events = append(events, activityEvent(e))
+				found++
+			}
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Time.After(events[j].Time)
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+// activityEvent converts a CloudTrail event, reading the caller and error
+// from the full event JSON.
+func activityEvent(e types.Event) model.ActivityEvent {
+	event := model.ActivityEvent{
+		ID:       aws.ToString(e.EventId),
+		Name:     aws.ToString(e.EventName),
+		Source:   aws.ToString(e.EventSource),
+		Time:     aws.ToTime(e.EventTime),
+		Username: aws.ToString(e.Username),
+		Raw:      aws.ToString(e.CloudTrailEvent),
+	}
+
+	var record cloudTrailRecord
+	if err := json.Unmarshal([]byte(event.Raw), &record); err != nil {
+		log.Debug("Failed to parse CloudTrail event %s: %v", event.ID, err)
+		return event
+	}
+	event.Principal = record.UserIdentity.ARN
+	event.SourceIP = record.SourceIPAddress
+	event.ErrorCode = record.ErrorCode
+	return event
+}
@@ -4,6 +4,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -17,11 +18,18 @@ import (
 // startTime is milliseconds since epoch for incremental fetching.
 // Returns log entries, the next startTime to use, and any error.
 func (c *Client) FetchLogs(ctx context.Context, logGroup, logStream string, startTime int64, limit int32) ([]model.CloudWatchLogEntry, int64, error) {
-	log.Debug("Fetching CloudWatch logs: group=%s, stream=%s, startTime=%d", logGroup, logStream, startTime)
+	return c.FetchLogStreams(ctx, logGroup, []string{logStream}, startTime, limit)
+}
+
+// FetchLogStreams retrieves logs from several streams of a log group at
+// once, e.g. those of every task of a service, sorted by time. CloudWatch
+// takes up to 100 streams.
+func (c *Client) FetchLogStreams(ctx context.Context, logGroup string, logStreams []string, startTime int64, limit int32) ([]model.CloudWatchLogEntry, int64, error) {
+	log.Debug("Fetching CloudWatch logs: group=%s, streams=%v, startTime=%d", logGroup, logStreams, startTime)
 
 	input := &cloudwatchlogs.FilterLogEventsInput{
 		LogGroupName:   aws.String(logGroup),
-		LogStreamNames: []string{logStream},
+		LogStreamNames: logStreams,
 		Limit:          aws.Int32(limit),
 	}
 
@@ -45,7 +53,7 @@ func (c *Client) FetchLogs(ctx context.Context, logGroup, logStream string, star
 				Timestamp:     time.UnixMilli(aws.ToInt64(event.Timestamp)),
 				Message:       aws.ToString(event.Message),
 				IngestionTime: time.UnixMilli(aws.ToInt64(event.IngestionTime)),
-				LogStreamName: logStream,
+				LogStreamName: aws.ToString(event.LogStreamName),
 			}
 			entries = append(entries, entry)
 
@@ -58,6 +66,9 @@ func (c *Client) FetchLogs(ctx context.Context, logGroup, logStream string, star
 	if len(entries) > 0 {
 		log.Debug("Fetched %d log entries from CloudWatch", len(entries))
 	}
+	if len(logStreams) > 1 {
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Timestamp.Before(entries[j].Timestamp) })
+	}
 
 	// Return lastTimestamp + 1 to avoid duplicate on next fetch
 	nextStartTime := startTime
@@ -0,0 +1,217 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cognito "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	cognitotypes "github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// maxUserSearchResults limits how many users a search returns
+const maxUserSearchResults = 50
+
+// ListUserPools lists all Cognito user pools.
+func (c *Client) ListUserPools(ctx context.Context) ([]model.UserPool, error) {
+	log.Debug("Listing Cognito user pools...")
+
+	var pools []model.UserPool
+	paginator := cognito.NewListUserPoolsPaginator(c.cognito, &cognito.ListUserPoolsInput{
+		MaxResults: aws.Int32(60), // Required, and the maximum allowed
+	})
+
+	for page := 1; paginator.HasMorePages(); page++ {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list user pools: %w", err)
+		}
+		for _, p := range out.UserPools {
+			pools = append(pools, model.UserPool{
+				ID:        aws.ToString(p.Id),
+				Name:      aws.ToString(p.Name),
+				CreatedAt: aws.ToTime(p.CreationDate),
+				UpdatedAt: aws.ToTime(p.LastModifiedDate),
+			})
+		}
+		reportProgress(ctx, "ListUserPools", "pages", page, 0)
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Name < pools[j].Name
+	})
+
+	log.Info("Found %d Cognito user pools", len(pools))
+	return pools, nil
+}
+
+// GetUserPoolDetails returns the configuration and app clients of a user pool.
+func (c *Client) GetUserPoolDetails(ctx context.Context, poolID string) (*model.UserPoolDetails, error) {
+	log.Debug("Describing Cognito user pool %s...", poolID)
+
+	out, err := c.cognito.DescribeUserPool(ctx, &cognito.DescribeUserPoolInput{
+		UserPoolId: aws.String(poolID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe user pool %s: %w", poolID, err)
+	}
+
+	pool := out.UserPool
+	details := &model.UserPoolDetails{
+		PoolID:         poolID,
+		ARN:            aws.ToString(pool.Arn),
+		EstimatedUsers: int(pool.EstimatedNumberOfUsers),
+		MFA:            string(pool.MfaConfiguration),
+		Domain:         aws.ToString(pool.CustomDomain),
+	}
+	if details.Domain == "" {
+		details.Domain = aws.ToString(pool.Domain)
+	}
+	for _, attr := range pool.UsernameAttributes {
+		details.UsernameAttributes = append(details.UsernameAttributes, string(attr))
+	}
+
+	// App client descriptions only carry names, so each client is described
+	paginator := cognito.NewListUserPoolClientsPaginator(c.cognito, &cognito.ListUserPoolClientsInput{
+		UserPoolId: aws.String(poolID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list app clients: %w", err)
+		}
+		for _, desc := range page.UserPoolClients {
+			client, err := c.cognito.DescribeUserPoolClient(ctx, &cognito.DescribeUserPoolClientInput{
+				UserPoolId: aws.String(poolID),
+				ClientId:   desc.ClientId,
+			})
+			if err != nil {
+				// Keep the client listed by name
+				log.Warn("Failed to describe app client %s: %v", aws.ToString(desc.ClientName), err)
+				details.Clients = append(details.Clients, model.UserPoolClient{
+					ID:   aws.ToString(desc.ClientId),
+					Name: aws.ToString(desc.ClientName),
+				})
+				continue
+			}
+			details.Clients = append(details.Clients, convertUserPoolClient(client.UserPoolClient))
+		}
+	}
+
+	sort.Slice(details.Clients, func(i, j int) bool {
+		return details.Clients[i].Name < details.Clients[j].Name
+	})
+	return details, nil
+}
+
+// SearchUsers finds users of a pool whose email (for queries containing @) or
+// username starts with query.
+func (c *Client) SearchUsers(ctx context.Context, poolID, query string) ([]model.CognitoUser, error) {
+	attr := "username"
+	if strings.Contains(query, "@") {
+		attr = "email"
+	}
+	// Quotes and backslashes must be escaped in the filter value
+	value := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(query)
+	filter := fmt.Sprintf(`%s ^= "%s"`, attr, value)
+	log.Debug("Searching Cognito users in %s: %s", poolID, filter)
+
+	var users []model.CognitoUser
+	paginator := cognito.NewListUsersPaginator(c.cognito, &cognito.ListUsersInput{
+		UserPoolId: aws.String(poolID),
+		Filter:     aws.String(filter),
+	})
+	for paginator.HasMorePages() && len(users) < maxUserSearchResults {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search users: %w", err)
+		}
+		for _, u := range page.Users {
+			users = append(users, convertCognitoUser(u))
+		}
+	}
+	if len(users) > maxUserSearchResults {
+		users = users[:maxUserSearchResults]
+	}
+
+	log.Info("Found %d users matching %q", len(users), query)
+	return users, nil
+}
+
+// ConfirmUser confirms the sign-up of an unconfirmed user.
+func (c *Client) ConfirmUser(ctx context.Context, poolID, username string) error {
+	log.Info("Confirming Cognito user %s in %s", username, poolID)
+
+	_, err := c.cognito.AdminConfirmSignUp(ctx, &cognito.AdminConfirmSignUpInput{
+		UserPoolId: aws.String(poolID),
+		Username:   aws.String(username),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to confirm user: %w", err)
+	}
+	return nil
+}
+
+// SetUserEnabled enables or disables a user. Disabled users cannot sign in.
+func (c *Client) SetUserEnabled(ctx context.Context, poolID, username string, enabled bool) error {
+	var err error
+	if enabled {
+		log.Info("Enabling Cognito user %s in %s", username, poolID)
+		_, err = c.cognito.AdminEnableUser(ctx, &cognito.AdminEnableUserInput{
+			UserPoolId: aws.String(poolID),
+			Username:   aws.String(username),
+		})
+	} else {
+		log.Info("Disabling Cognito user %s in %s", username, poolID)
+		_, err = c.cognito.AdminDisableUser(ctx, &cognito.AdminDisableUserInput{
+			UserPoolId: aws.String(poolID),
+			Username:   aws.String(username),
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	return nil
+}
+
+// convertUserPoolClient converts an SDK app client to our model.
+func convertUserPoolClient(c *cognitotypes.UserPoolClientType) model.UserPoolClient {
+	client := model.UserPoolClient{
+		ID:           aws.ToString(c.ClientId),
+		Name:         aws.ToString(c.ClientName),
+		HasSecret:    aws.ToString(c.ClientSecret) != "",
+		CallbackURLs: c.CallbackURLs,
+		LogoutURLs:   c.LogoutURLs,
+		OAuthScopes:  c.AllowedOAuthScopes,
+	}
+	for _, flow := range c.AllowedOAuthFlows {
+		client.OAuthFlows = append(client.OAuthFlows, string(flow))
+	}
+	for _, flow := range c.ExplicitAuthFlows {
+		client.AuthFlows = append(client.AuthFlows, string(flow))
+	}
+	return client
+}
+
+// convertCognitoUser converts an SDK user to our model.
+func convertCognitoUser(u cognitotypes.UserType) model.CognitoUser {
+	user := model.CognitoUser{
+		Username:   aws.ToString(u.Username),
+		Status:     model.CognitoUserStatus(u.UserStatus),
+		Enabled:    u.Enabled,
+		Attributes: make(map[string]string, len(u.Attributes)),
+		CreatedAt:  aws.ToTime(u.UserCreateDate),
+		UpdatedAt:  aws.ToTime(u.UserLastModifiedDate),
+	}
+	for _, attr := range u.Attributes {
+		user.Attributes[aws.ToString(attr.Name)] = aws.ToString(attr.Value)
+	}
+	user.Email = user.Attributes["email"]
+	user.EmailVerified = user.Attributes["email_verified"] == "true"
+	return user
+}
@@ -0,0 +1,160 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+
+	"vaws/internal/log"
+)
+
+// Services whose loaders fan out, as named in the concurrency config.
+const (
+	ConcurrencyAppRunner = "apprunner" // DescribeService per App Runner service
+	ConcurrencyDynamoDB  = "dynamodb"  // DescribeTable per table
+	ConcurrencyECR       = "ecr"       // Image lookups per service and repository
+	ConcurrencyECS       = "ecs"       // DescribeTaskDefinition per family
+	ConcurrencyFirehose  = "firehose"  // DescribeDeliveryStream per stream
+	ConcurrencyIAM       = "iam"       // Policy reads per role, for queue maps
+	ConcurrencyLambda    = "lambda"    // GetFunction per function of a stack
+	ConcurrencyLogs      = "logs"      // FilterLogEvents per log group searched
+	ConcurrencyMQ        = "mq"        // DescribeBroker per broker
+	ConcurrencyRegions   = "regions"   // Latency pings per region
+	ConcurrencyScheduler = "scheduler" // GetSchedule per schedule
+	ConcurrencySES       = "ses"       // GetConfigurationSet per configuration set
+	ConcurrencySQS       = "sqs"       // GetQueueAttributes per queue
+
+	// concurrencyDefault is the config key that applies to every service not
+	// listed on its own.
+	concurrencyDefault = "default"
+)
+
+// DefaultConcurrency is how many calls each service's loaders make at once
+// unless configured otherwise.
+var DefaultConcurrency = map[string]int{
+	ConcurrencyAppRunner: 5,
+	ConcurrencyDynamoDB:  10,
+	ConcurrencyECR:       8,
+	ConcurrencyECS:       8,
+	ConcurrencyFirehose:  5,
+	ConcurrencyIAM:       4,
+	ConcurrencyLambda:    5,
+	// FilterLogEvents is throttled per account, so this stays low
+	ConcurrencyLogs:      4,
+	ConcurrencyMQ:        5,
+	ConcurrencyRegions:   8,
+	ConcurrencyScheduler: 5,
+	ConcurrencySES:       5,
+	ConcurrencySQS:       10,
+}
+
+// workerPool bounds the calls in flight per service. The slots of a service
+// are shared by all of its loaders, so that two views loading at once stay
+// within the same limit.
+type workerPool struct {
+	mu     sync.Mutex
+	limits map[string]int
+	slots  map[string]chan struct{}
+}
+
+// slotsOf returns the slots of a service: a call takes one by sending to the
+// channel and frees it by receiving.
+func (p *workerPool) slotsOf(service string) chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, ok := p.slots[service]; ok {
+		return s
+	}
+	n := p.limits[service]
+	if n <= 0 {
+		n = DefaultConcurrency[service]
+	}
+	if n <= 0 {
+		n = 1
+	}
+	if p.slots == nil {
+		p.slots = make(map[string]chan struct{})
+	}
+	s := make(chan struct{}, n)
+	p.slots[service] = s
+	return s
+}
+
+// slots returns the shared slots that bound the concurrent calls of a
+// service's loaders.
+func (c *Client) slots(service string) chan struct{} {
+	return c.pool.slotsOf(service)
+}
+
+// SetConcurrency sets how many calls each service's loaders make at once,
+// keyed by service with "default" applying to the services not listed.
+// Services left out, and limits below 1, keep DefaultConcurrency. Loaders
+// already running finish under the limits they started with.
+func (c *Client) SetConcurrency(limits map[string]int) {
+	resolved := make(map[string]int, len(DefaultConcurrency))
+	for service, n := range DefaultConcurrency {
+		resolved[service] = n
+		if d := limits[concurrencyDefault]; d > 0 {
+			resolved[service] = d
+		}
+	}
+	var unknown []string
+	for service, n := range limits {
+		service = strings.ToLower(service)
+		if service == concurrencyDefault {
+			continue
+		}
+		if _, ok := DefaultConcurrency[service]; !ok {
+			unknown = append(unknown, service)
+			continue
+		}
+		if n > 0 {
+			resolved[service] = n
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		log.Warn("Ignoring concurrency limits of unknown services: %s", strings.Join(unknown, ", "))
+	}
+
+	c.pool.mu.Lock()
+	defer c.pool.mu.Unlock()
+	c.pool.limits = resolved
+	c.pool.slots = nil
+}
+
+// retryBackoff is the wait before each further attempt of a call that is
+// still throttled, or failing transiently, after the SDK's own retries.
+// Fan-outs hit the per-account rate limits the SDK's short backoff was not
+// made for.
+var retryBackoff = []time.Duration{500 * time.Millisecond, 1500 * time.Millisecond, 3 * time.Second}
+
+// retryable reports whether an error is worth another attempt: throttling,
+// timeouts and server errors, not missing resources or denied access.
+func retryable(err error) bool {
+	return retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary ||
+		retry.IsErrorRetryables(retry.DefaultRetryables).IsErrorRetryable(err) == aws.TrueTernary
+}
+
+// withRetries runs call until it succeeds, fails for good, or the attempts
+// of retryBackoff run out.
+func withRetries(ctx context.Context, call func() error) error {
+	err := call()
+	for _, wait := range retryBackoff {
+		if err == nil || !retryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		err = call()
+	}
+	return err
+}
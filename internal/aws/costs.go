@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// billingRegion is the only region CloudWatch publishes billing metrics and
+// AWS Budgets signs requests in.
+const billingRegion = "us-east-1"
+
+// budgetSpend is an amount of the DescribeBudgets response. Amounts are
+// decimal strings.
+type budgetSpend struct {
+	Amount string `json:"Amount"`
+	Unit   string `json:"Unit"`
+}
+
+// budgetEntry is a budget of the DescribeBudgets response.
+type budgetEntry struct {
+	BudgetName      string      `json:"BudgetName"`
+	BudgetType      string      `json:"BudgetType"`
+	TimeUnit        string      `json:"TimeUnit"`
+	BudgetLimit     budgetSpend `json:"BudgetLimit"`
+	CalculatedSpend struct {
+		ActualSpend     budgetSpend `json:"ActualSpend"`
+		ForecastedSpend budgetSpend `json:"ForecastedSpend"`
+	} `json:"CalculatedSpend"`
+}
+
+// GetCostSummary returns the account's estimated charges for the month, from
+// the EstimatedCharges billing metric, and its budgets with what has been
+// spent against them. Either source may be unavailable, e.g. without billing
+// alerts turned on, and is then reported in Warnings.
+func (c *Client) GetCostSummary(ctx context.Context) (*model.CostSummary, error) {
+	summary := &model.CostSummary{}
+
+	if err := c.estimatedCharges(ctx, summary); err != nil {
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf("billing metric: %v", err))
+	}
+
+	budgets, err := c.listBudgets(ctx)
+	if err != nil {
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf("budgets: %v", err))
+	}
+	summary.Budgets = budgets
+
+	if summary.Currency == "" && len(summary.Budgets) == 0 && len(summary.Warnings) == 2 {
+		return nil, fmt.Errorf("failed to read costs: %s; %s", summary.Warnings[0], summary.Warnings[1])
+	}
+	return summary, nil
+}
+
+// estimatedCharges sets the latest estimated charges of the month on
+// summary. CloudWatch updates the metric a few times a day.
+func (c *Client) estimatedCharges(ctx context.Context, summary *model.CostSummary) error {
+	cw := cloudwatch.NewFromConfig(c.cfg, func(o *cloudwatch.Options) { o.Region = billingRegion })
+	now := time.Now()
+	out, err := cw.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/Billing"),
+		MetricName: aws.String("EstimatedCharges"),
+		Dimensions: []cwtypes.Dimension{{Name: aws.String("Currency"), Value: aws.String("USD")}},
+		StartTime:  aws.Time(now.Add(-2 * 24 * time.Hour)),
+		EndTime:    aws.Time(now),
+		Period:     aws.Int32(21600),
+		Statistics: []cwtypes.Statistic{cwtypes.StatisticMaximum},
+	})
+	if err != nil {
+		return err
+	}
+	if len(out.Datapoints) == 0 {
+		log.Debug("No EstimatedCharges data points; billing alerts may be off")
+		return nil
+	}
+
+	latest := out.Datapoints[0]
+	for _, dp := range out.Datapoints[1:] {
+		if aws.ToTime(dp.Timestamp).After(aws.ToTime(latest.Timestamp)) {
+			latest = dp
+		}
+	}
+	// The metric runs up over the month and restarts on the 1st, so a point
+	// from last month isn't this month's charges
+	at := aws.ToTime(latest.Timestamp)
+	if at.UTC().Month() != now.UTC().Month() {
+		return nil
+	}
+	summary.Charges = aws.ToFloat64(latest.Maximum)
+	summary.Currency = "USD"
+	summary.ChargesAt = at
+	return nil
+}
+
+// listBudgets lists the budgets of the account, by name.
+func (c *Client) listBudgets(ctx context.Context) ([]model.Budget, error) {
+	identity, err := c.GetCallerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var budgets []model.Budget
+	body := map[string]any{"AccountId": identity.Account, "MaxResults": 100}
+	for {
+		var out struct {
+			Budgets   []budgetEntry `json:"Budgets"`
+			NextToken string        `json:"NextToken"`
+		}
+		if err := c.callJSONAt(ctx, "budgets.amazonaws.com", "budgets", billingRegion, "AWSBudgetServiceGateway.DescribeBudgets", body, &out); err != nil {
+			return nil, err
+		}
+		for _, b := range out.Budgets {
+			budgets = append(budgets, convertBudget(b))
+		}
+		if out.NextToken == "" {
+			break
+		}
+		body["NextToken"] = out.NextToken
+	}
+
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].Name < budgets[j].Name
+	})
+	return budgets, nil
+}
+
+// convertBudget converts a DescribeBudgets entry to a model.Budget.
+func convertBudget(b budgetEntry) model.Budget {
+	amount := func(s budgetSpend) float64 {
+		v, _ := strconv.ParseFloat(s.Amount, 64)
+		return v
+	}
+	return model.Budget{
+		Name:     b.BudgetName,
+		Type:     b.BudgetType,
+		TimeUnit: b.TimeUnit,
+		Limit:    amount(b.BudgetLimit),
+		Actual:   amount(b.CalculatedSpend.ActualSpend),
+		Forecast: amount(b.CalculatedSpend.ForecastedSpend),
+		Unit:     b.BudgetLimit.Unit,
+	}
+}
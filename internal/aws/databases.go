@@ -0,0 +1,359 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+const (
+	// rdsAPIVersion is the version of the RDS Query API vaws speaks. RDS
+	// Proxy is part of it.
+	rdsAPIVersion = "2014-10-31"
+
+	// redshiftAPIVersion is the version of the Redshift Query API vaws speaks.
+	redshiftAPIVersion = "2012-12-01"
+)
+
+// rdsInstance is a DB instance of the RDS DescribeDBInstances response.
+type rdsInstance struct {
+	Identifier string `xml:"DBInstanceIdentifier"`
+	ARN        string `xml:"DBInstanceArn"`
+	Engine     string `xml:"Engine"`
+	Status     string `xml:"DBInstanceStatus"`
+	Address    string `xml:"Endpoint>Address"`
+	Port       int    `xml:"Endpoint>Port"`
+	VPCID      string `xml:"DBSubnetGroup>VpcId"`
+	Public     bool   `xml:"PubliclyAccessible"`
+	Cluster    string `xml:"DBClusterIdentifier"`
+}
+
+// rdsCluster is a DB cluster of the RDS DescribeDBClusters response.
+type rdsCluster struct {
+	Identifier     string   `xml:"DBClusterIdentifier"`
+	ARN            string   `xml:"DBClusterArn"`
+	Engine         string   `xml:"Engine"`
+	Status         string   `xml:"Status"`
+	Endpoint       string   `xml:"Endpoint"`
+	ReaderEndpoint string   `xml:"ReaderEndpoint"`
+	Port           int      `xml:"Port"`
+	Public         bool     `xml:"PubliclyAccessible"`
+	Members        []string `xml:"DBClusterMembers>DBClusterMember>DBInstanceIdentifier"`
+}
+
+// rdsProxy is a proxy of the RDS DescribeDBProxies response.
+type rdsProxy struct {
+	Name         string `xml:"DBProxyName"`
+	ARN          string `xml:"DBProxyArn"`
+	Status       string `xml:"Status"`
+	EngineFamily string `xml:"EngineFamily"`
+	VPCID        string `xml:"VpcId"`
+	Endpoint     string `xml:"Endpoint"`
+}
+
+// rdsProxyEndpoint is an endpoint of the RDS DescribeDBProxyEndpoints
+// response.
+type rdsProxyEndpoint struct {
+	Name       string `xml:"DBProxyEndpointName"`
+	ARN        string `xml:"DBProxyEndpointArn"`
+	Proxy      string `xml:"DBProxyName"`
+	Status     string `xml:"Status"`
+	VPCID      string `xml:"VpcId"`
+	Endpoint   string `xml:"Endpoint"`
+	TargetRole string `xml:"TargetRole"`
+	Default    bool   `xml:"IsDefault"`
+}
+
+// redshiftCluster is a cluster of the Redshift DescribeClusters response.
+type redshiftCluster struct {
+	Identifier   string `xml:"ClusterIdentifier"`
+	Status       string `xml:"ClusterStatus"`
+	Address      string `xml:"Endpoint>Address"`
+	Port         int    `xml:"Endpoint>Port"`
+	VPCID        string `xml:"VpcId"`
+	Public       bool   `xml:"PubliclyAccessible"`
+	NamespaceARN string `xml:"ClusterNamespaceArn"`
+}
+
+// proxyPorts are the ports RDS Proxy listens on, by engine family. Unlike
+// instances and clusters, proxies don't report theirs.
+var proxyPorts = map[string]int{
+	"MYSQL":      3306,
+	"POSTGRESQL": 5432,
+	"SQLSERVER":  1433,
+}
+
+// ListDatabases lists the endpoints vaws tunnels to for databases: RDS
+// instances, the writer and reader endpoints of RDS clusters, RDS Proxy
+// endpoints and Redshift clusters, by name. The services are read in
+// parallel; one that fails, for instance for lack of permissions, is logged
+// and the others still list, unless all of them fail.
+func (c *Client) ListDatabases(ctx context.Context) ([]model.Database, error) {
+	log.Debug("Listing databases...")
+
+	sources := map[string]func(context.Context) ([]model.Database, error){
+		"RDS":       c.listRDSDatabases,
+		"RDS Proxy": c.listRDSProxies,
+		"Redshift":  c.listRedshiftClusters,
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		databases []model.Database
+		errs      []error
+	)
+	done := 0
+	for name, list := range sources {
+		wg.Add(1)
+		go func(name string, list func(context.Context) ([]model.Database, error)) {
+			defer wg.Done()
+			found, err := list(withoutProgress(ctx))
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			reportProgress(ctx, "Listing databases", "services", done, len(sources))
+			if err != nil {
+				log.Warn("Failed to list %s databases: %v", name, err)
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				return
+			}
+			databases = append(databases, found...)
+		}(name, list)
+	}
+	wg.Wait()
+
+	if len(errs) == len(sources) {
+		return nil, fmt.Errorf("failed to list databases: %w", errs[0])
+	}
+	sort.Slice(databases, func(i, j int) bool {
+		if databases[i].Name != databases[j].Name {
+			return databases[i].Name < databases[j].Name
+		}
+		return databases[i].Kind < databases[j].Kind
+	})
+	log.Info("Found %d databases", len(databases))
+	return databases, nil
+}
+
+// listRDSDatabases lists RDS instances and clusters. Instances of a cluster
+// are reached through the cluster's endpoints, so they aren't listed on
+// their own; clusters take the VPC of their instances, which
+// DescribeDBClusters doesn't report.
+func (c *Client) listRDSDatabases(ctx context.Context) ([]model.Database, error) {
+	var instances []rdsInstance
+	params := url.Values{"MaxRecords": {"100"}}
+	for {
+		var out struct {
+			Instances []rdsInstance `xml:"DescribeDBInstancesResult>DBInstances>DBInstance"`
+			Marker    string        `xml:"DescribeDBInstancesResult>Marker"`
+		}
+		if err := c.callQuery(ctx, "rds", rdsAPIVersion, "DescribeDBInstances", params, &out); err != nil {
+			return nil, err
+		}
+		instances = append(instances, out.Instances...)
+		if out.Marker == "" {
+			break
+		}
+		params.Set("Marker", out.Marker)
+	}
+
+	var clusters []rdsCluster
+	params = url.Values{"MaxRecords": {"100"}}
+	for {
+		var out struct {
+			Clusters []rdsCluster `xml:"DescribeDBClustersResult>DBClusters>DBCluster"`
+			Marker   string       `xml:"DescribeDBClustersResult>Marker"`
+		}
+		if err := c.callQuery(ctx, "rds", rdsAPIVersion, "DescribeDBClusters", params, &out); err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, out.Clusters...)
+		if out.Marker == "" {
+			break
+		}
+		params.Set("Marker", out.Marker)
+	}
+
+	var databases []model.Database
+	vpcs := make(map[string]string, len(instances))
+	for _, inst := range instances {
+		vpcs[inst.Identifier] = inst.VPCID
+		if inst.Cluster != "" {
+			continue
+		}
+		databases = append(databases, model.Database{
+			ID:       inst.ARN,
+			Name:     inst.Identifier,
+			ARN:      inst.ARN,
+			Kind:     model.DatabaseKindInstance,
+			Engine:   inst.Engine,
+			Status:   inst.Status,
+			Endpoint: inst.Address,
+			Port:     inst.Port,
+			VPCID:    inst.VPCID,
+			Public:   inst.Public,
+		})
+	}
+	for _, cl := range clusters {
+		var vpcID string
+		for _, member := range cl.Members {
+			if vpcID = vpcs[member]; vpcID != "" {
+				break
+			}
+		}
+		db := model.Database{
+			ID:       cl.ARN,
+			Name:     cl.Identifier,
+			ARN:      cl.ARN,
+			Kind:     model.DatabaseKindCluster,
+			Engine:   cl.Engine,
+			Status:   cl.Status,
+			Endpoint: cl.Endpoint,
+			Port:     cl.Port,
+			VPCID:    vpcID,
+			Public:   cl.Public,
+		}
+		databases = append(databases, db)
+		if cl.ReaderEndpoint != "" && cl.ReaderEndpoint != cl.Endpoint {
+			db.ID = cl.ARN + "/reader"
+			db.Kind = model.DatabaseKindReader
+			db.Endpoint = cl.ReaderEndpoint
+			databases = append(databases, db)
+		}
+	}
+	return databases, nil
+}
+
+// listRDSProxies lists the default endpoint of each RDS proxy and the
+// endpoints added to it, which take the proxy's port.
+func (c *Client) listRDSProxies(ctx context.Context) ([]model.Database, error) {
+	var proxies []rdsProxy
+	params := url.Values{"MaxRecords": {"100"}}
+	for {
+		var out struct {
+			Proxies []rdsProxy `xml:"DescribeDBProxiesResult>DBProxies>member"`
+			Marker  string     `xml:"DescribeDBProxiesResult>Marker"`
+		}
+		if err := c.callQuery(ctx, "rds", rdsAPIVersion, "DescribeDBProxies", params, &out); err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, out.Proxies...)
+		if out.Marker == "" {
+			break
+		}
+		params.Set("Marker", out.Marker)
+	}
+	if len(proxies) == 0 {
+		return nil, nil
+	}
+
+	var endpoints []rdsProxyEndpoint
+	params = url.Values{"MaxRecords": {"100"}}
+	for {
+		var out struct {
+			Endpoints []rdsProxyEndpoint `xml:"DescribeDBProxyEndpointsResult>DBProxyEndpoints>member"`
+			Marker    string             `xml:"DescribeDBProxyEndpointsResult>Marker"`
+		}
+		if err := c.callQuery(ctx, "rds", rdsAPIVersion, "DescribeDBProxyEndpoints", params, &out); err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, out.Endpoints...)
+		if out.Marker == "" {
+			break
+		}
+		params.Set("Marker", out.Marker)
+	}
+
+	byName := make(map[string]rdsProxy, len(proxies))
+	var databases []model.Database
+	for _, p := range proxies {
+		byName[p.Name] = p
+		databases = append(databases, model.Database{
+			ID:       p.ARN,
+			Name:     p.Name,
+			ARN:      p.ARN,
+			Kind:     model.DatabaseKindProxy,
+			Engine:   strings.ToLower(p.EngineFamily),
+			Status:   p.Status,
+			Endpoint: p.Endpoint,
+			Port:     proxyPorts[p.EngineFamily],
+			VPCID:    p.VPCID,
+		})
+	}
+	for _, e := range endpoints {
+		p, ok := byName[e.Proxy]
+		if e.Default || !ok {
+			continue
+		}
+		kind := model.DatabaseKindProxy
+		if e.TargetRole == "READ_ONLY" {
+			kind = model.DatabaseKindProxyReader
+		}
+		databases = append(databases, model.Database{
+			ID:       e.ARN,
+			Name:     e.Proxy + "/" + e.Name,
+			ARN:      e.ARN,
+			Kind:     kind,
+			Engine:   strings.ToLower(p.EngineFamily),
+			Status:   e.Status,
+			Endpoint: e.Endpoint,
+			Port:     proxyPorts[p.EngineFamily],
+			VPCID:    e.VPCID,
+		})
+	}
+	return databases, nil
+}
+
+// listRedshiftClusters lists provisioned Redshift clusters. Serverless
+// workgroups have an API of their own and aren't listed.
+func (c *Client) listRedshiftClusters(ctx context.Context) ([]model.Database, error) {
+	var databases []model.Database
+	params := url.Values{"MaxRecords": {"100"}}
+	for {
+		var out struct {
+			Clusters []redshiftCluster `xml:"DescribeClustersResult>Clusters>Cluster"`
+			Marker   string            `xml:"DescribeClustersResult>Marker"`
+		}
+		if err := c.callQuery(ctx, "redshift", redshiftAPIVersion, "DescribeClusters", params, &out); err != nil {
+			return nil, err
+		}
+		for _, cl := range out.Clusters {
+			databases = append(databases, model.Database{
+				ID:       "redshift:" + cl.Identifier,
+				Name:     cl.Identifier,
+				ARN:      redshiftClusterARN(cl),
+				Kind:     model.DatabaseKindRedshift,
+				Engine:   "redshift",
+				Status:   cl.Status,
+				Endpoint: cl.Address,
+				Port:     cl.Port,
+				VPCID:    cl.VPCID,
+				Public:   cl.Public,
+			})
+		}
+		if out.Marker == "" {
+			break
+		}
+		params.Set("Marker", out.Marker)
+	}
+	return databases, nil
+}
+
+// redshiftClusterARN builds the ARN of a cluster, which DescribeClusters
+// doesn't report, from the ARN of its namespace: both share the partition,
+// region and account.
+func redshiftClusterARN(cl redshiftCluster) string {
+	prefix, _, ok := strings.Cut(cl.NamespaceARN, ":namespace:")
+	if !ok {
+		return ""
+	}
+	return prefix + ":cluster:" + cl.Identifier
+}
@@ -15,9 +15,6 @@ import (
 	"vaws/internal/model"
 )
 
-// maxConcurrentDynamoDBCalls limits concurrent API calls to avoid throttling
-const maxConcurrentDynamoDBCalls = 10
-
 // ListTables lists all DynamoDB tables in the region with their details.
 func (c *Client) ListTables(ctx context.Context) ([]model.Table, error) {
 	log.Debug("Listing DynamoDB tables...")
@@ -48,7 +45,7 @@ func (c *Client) ListTables(ctx context.Context) ([]model.Table, error) {
 	}
 
 	results := make(chan tableResult, len(tableNames))
-	sem := make(chan struct{}, maxConcurrentDynamoDBCalls) // Limit concurrency
+	sem := c.slots(ConcurrencyDynamoDB) // Limit concurrency
 
 	var wg sync.WaitGroup
 	for i, name := range tableNames {
@@ -403,8 +400,9 @@ func convertItem(item map[string]dbtypes.AttributeValue, pkName, skName string)
 	jsonStr := string(jsonBytes)
 
 	ddbItem := model.DynamoDBItem{
-		Raw:  raw,
-		JSON: jsonStr,
+		Raw:   raw,
+		JSON:  jsonStr,
+		Typed: typedItemJSON(item),
 	}
 
 	// Extract PK/SK values for display
@@ -542,7 +540,7 @@ func (c *Client) fetchTableDetailsBatch(ctx context.Context, tableNames []string
 	}
 
 	results := make(chan tableResult, len(tableNames))
-	sem := make(chan struct{}, maxConcurrentDynamoDBCalls)
+	sem := c.slots(ConcurrencyDynamoDB)
 
 	var wg sync.WaitGroup
 	for i, name := range tableNames {
@@ -0,0 +1,276 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"vaws/internal/log"
+)
+
+// PutItem writes an item given in DynamoDB JSON to a table, replacing the
+// item with the same key if there is one.
+func (c *Client) PutItem(ctx context.Context, tableName, item string) error {
+	attrs, err := parseTypedItem(item)
+	if err != nil {
+		return err
+	}
+	log.Info("Putting item into %s", tableName)
+	_, err = c.dynamodb.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(tableName),
+		Item:      attrs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put item into %s: %w", tableName, err)
+	}
+	return nil
+}
+
+// DeleteItem deletes the item with a key given in DynamoDB JSON from a
+// table.
+func (c *Client) DeleteItem(ctx context.Context, tableName, key string) error {
+	attrs, err := parseTypedItem(key)
+	if err != nil {
+		return err
+	}
+	log.Info("Deleting item from %s", tableName)
+	_, err = c.dynamodb.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(tableName),
+		Key:       attrs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete item from %s: %w", tableName, err)
+	}
+	return nil
+}
+
+// ValidateDynamoDBItem checks that text is an item in DynamoDB JSON, each
+// attribute tagged with its type, e.g. {"id": {"S": "a"}, "n": {"N": "1"}},
+// holding the key attributes of its table as strings, numbers or binary.
+func ValidateDynamoDBItem(text string, keys ...string) error {
+	attrs, err := parseTypedItem(text)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if k == "" {
+			continue
+		}
+		switch attrs[k].(type) {
+		case *dbtypes.AttributeValueMemberS, *dbtypes.AttributeValueMemberN, *dbtypes.AttributeValueMemberB:
+		case nil:
+			return fmt.Errorf("the item has no key attribute %q", k)
+		default:
+			return fmt.Errorf("key attribute %q must be a string (S), number (N) or binary (B)", k)
+		}
+	}
+	return nil
+}
+
+// DynamoDBItemKey returns the key attributes of an item in DynamoDB JSON,
+// as DeleteItem takes them.
+func DynamoDBItemKey(item string, keys ...string) (string, error) {
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(item), &attrs); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+	key := make(map[string]json.RawMessage)
+	for _, k := range keys {
+		if k == "" {
+			continue
+		}
+		v, ok := attrs[k]
+		if !ok {
+			return "", fmt.Errorf("the item has no key attribute %q", k)
+		}
+		key[k] = v
+	}
+	data, err := json.Marshal(key)
+	return string(data), err
+}
+
+// typedItemJSON returns an item in DynamoDB JSON. Unlike the JSON shown in
+// the results, it keeps numbers, binary values and sets apart, so that the
+// item can be edited and put back as it was.
+func typedItemJSON(item map[string]dbtypes.AttributeValue) string {
+	typed := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		typed[k] = typedValue(v)
+	}
+	data, _ := json.Marshal(typed)
+	return string(data)
+}
+
+// typedValue tags a value with its type. Binary values are encoded as
+// base64, as the AWS CLI shows them.
+func typedValue(av dbtypes.AttributeValue) interface{} {
+	switch v := av.(type) {
+	case *dbtypes.AttributeValueMemberS:
+		return map[string]interface{}{"S": v.Value}
+	case *dbtypes.AttributeValueMemberN:
+		return map[string]interface{}{"N": v.Value}
+	case *dbtypes.AttributeValueMemberB:
+		return map[string]interface{}{"B": v.Value}
+	case *dbtypes.AttributeValueMemberBOOL:
+		return map[string]interface{}{"BOOL": v.Value}
+	case *dbtypes.AttributeValueMemberNULL:
+		return map[string]interface{}{"NULL": true}
+	case *dbtypes.AttributeValueMemberSS:
+		return map[string]interface{}{"SS": v.Value}
+	case *dbtypes.AttributeValueMemberNS:
+		return map[string]interface{}{"NS": v.Value}
+	case *dbtypes.AttributeValueMemberBS:
+		return map[string]interface{}{"BS": v.Value}
+	case *dbtypes.AttributeValueMemberL:
+		list := make([]interface{}, len(v.Value))
+		for i, item := range v.Value {
+			list[i] = typedValue(item)
+		}
+		return map[string]interface{}{"L": list}
+	case *dbtypes.AttributeValueMemberM:
+		m := make(map[string]interface{}, len(v.Value))
+		for k, item := range v.Value {
+			m[k] = typedValue(item)
+		}
+		return map[string]interface{}{"M": m}
+	default:
+		return map[string]interface{}{"NULL": true}
+	}
+}
+
+// parseTypedItem reads an item in DynamoDB JSON.
+func parseTypedItem(text string) (map[string]dbtypes.AttributeValue, error) {
+	if !json.Valid([]byte(text)) {
+		var v any
+		return nil, fmt.Errorf("invalid JSON: %w", json.Unmarshal([]byte(text), &v))
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(text), &raw); err != nil || raw == nil {
+		return nil, fmt.Errorf("the item must be a JSON object")
+	}
+	return parseTypedMap(raw, "")
+}
+
+func parseTypedMap(raw map[string]json.RawMessage, path string) (map[string]dbtypes.AttributeValue, error) {
+	attrs := make(map[string]dbtypes.AttributeValue, len(raw))
+	for k, v := range raw {
+		av, err := parseTypedValue(v, path+k)
+		if err != nil {
+			return nil, err
+		}
+		attrs[k] = av
+	}
+	return attrs, nil
+}
+
+// parseTypedValue reads a value tagged with its type, naming the attribute
+// at path in the error if it isn't one.
+func parseTypedValue(data json.RawMessage, path string) (dbtypes.AttributeValue, error) {
+	var tagged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tagged); err != nil || len(tagged) != 1 {
+		return nil, fmt.Errorf("%s: want one type and its value, e.g. {\"S\": \"text\"} or {\"N\": \"1\"}", path)
+	}
+	for typ, value := range tagged {
+		null := bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+		invalid := func(want string) error {
+			return fmt.Errorf("%s: %s value must be %s, got %s", path, typ, want, value)
+		}
+		switch typ {
+		case "S":
+			var s string
+			if null || json.Unmarshal(value, &s) != nil {
+				return nil, invalid("a string")
+			}
+			return &dbtypes.AttributeValueMemberS{Value: s}, nil
+		case "N":
+			var n string
+			if json.Unmarshal(value, &n) != nil || !isNumber(n) {
+				return nil, invalid(`a number in a string, e.g. "1.5"`)
+			}
+			return &dbtypes.AttributeValueMemberN{Value: n}, nil
+		case "B":
+			var b []byte
+			if null || json.Unmarshal(value, &b) != nil {
+				return nil, invalid("base64 in a string")
+			}
+			return &dbtypes.AttributeValueMemberB{Value: b}, nil
+		case "BOOL":
+			var b bool
+			if null || json.Unmarshal(value, &b) != nil {
+				return nil, invalid("true or false")
+			}
+			return &dbtypes.AttributeValueMemberBOOL{Value: b}, nil
+		case "NULL":
+			if !bytes.Equal(bytes.TrimSpace(value), []byte("true")) {
+				return nil, invalid("true")
+			}
+			return &dbtypes.AttributeValueMemberNULL{Value: true}, nil
+		case "SS":
+			var ss []string
+			if json.Unmarshal(value, &ss) != nil || len(ss) == 0 {
+				return nil, invalid("a non-empty list of strings")
+			}
+			return &dbtypes.AttributeValueMemberSS{Value: ss}, nil
+		case "NS":
+			var ns []string
+			if json.Unmarshal(value, &ns) != nil || len(ns) == 0 {
+				return nil, invalid("a non-empty list of numbers in strings")
+			}
+			for _, n := range ns {
+				if !isNumber(n) {
+					return nil, invalid("a non-empty list of numbers in strings")
+				}
+			}
+			return &dbtypes.AttributeValueMemberNS{Value: ns}, nil
+		case "BS":
+			var bs [][]byte
+			if json.Unmarshal(value, &bs) != nil || len(bs) == 0 {
+				return nil, invalid("a non-empty list of base64 strings")
+			}
+			return &dbtypes.AttributeValueMemberBS{Value: bs}, nil
+		case "L":
+			var list []json.RawMessage
+			if json.Unmarshal(value, &list) != nil || list == nil {
+				return nil, invalid("a list")
+			}
+			values := make([]dbtypes.AttributeValue, len(list))
+			for i, item := range list {
+				av, err := parseTypedValue(item, fmt.Sprintf("%s[%d]", path, i))
+				if err != nil {
+					return nil, err
+				}
+				values[i] = av
+			}
+			return &dbtypes.AttributeValueMemberL{Value: values}, nil
+		case "M":
+			var m map[string]json.RawMessage
+			if json.Unmarshal(value, &m) != nil || m == nil {
+				return nil, invalid("an object")
+			}
+			attrs, err := parseTypedMap(m, path+".")
+			if err != nil {
+				return nil, err
+			}
+			return &dbtypes.AttributeValueMemberM{Value: attrs}, nil
+		default:
+			return nil, fmt.Errorf("%s: unknown type %q (want S, N, B, BOOL, NULL, SS, NS, BS, L or M)", path, typ)
+		}
+	}
+	return nil, nil
+}
+
+// isNumber reports whether s is a number as JSON writes them, which
+// DynamoDB takes too.
+func isNumber(s string) bool {
+	if s == "" || s[0] == '"' || strings.TrimSpace(s) != s {
+		return false
+	}
+	var n json.Number
+	return json.Unmarshal([]byte(s), &n) == nil
+}
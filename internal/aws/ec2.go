@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 
+	"vaws/internal/log"
 	"vaws/internal/model"
 )
 
@@ -79,6 +81,20 @@ func (c *Client) GetEC2InstanceByID(ctx context.Context, instanceID string) (*mo
 	return nil, fmt.Errorf("instance not found: %s", instanceID)
 }
 
+// GetSubnetVPC returns the ID of the VPC a subnet belongs to
+func (c *Client) GetSubnetVPC(ctx context.Context, subnetID string) (string, error) {
+	out, err := c.ec2.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: []string{subnetID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe subnet %s: %w", subnetID, err)
+	}
+	if len(out.Subnets) == 0 {
+		return "", fmt.Errorf("subnet not found: %s", subnetID)
+	}
+	return aws.ToString(out.Subnets[0].VpcId), nil
+}
+
 // FindEC2InstanceByTag finds an EC2 instance by a tag filter string (e.g., "Name=bastion")
 func (c *Client) FindEC2InstanceByTag(ctx context.Context, tagFilter string) (*model.EC2Instance, error) {
 	parts := strings.SplitN(tagFilter, "=", 2)
@@ -104,6 +120,60 @@ func (c *Client) FindEC2InstanceByTag(ctx context.Context, tagFilter string) (*m
 	return &instances[0], nil
 }
 
+// ListInstances lists the EC2 instances that aren't terminated, marking the
+// ones online in SSM, which shells and port forwards need.
+func (c *Client) ListInstances(ctx context.Context) ([]model.EC2Instance, error) {
+	instances, err := c.ListEC2Instances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paginator := ec2.NewDescribeInstancesPaginator(c.ec2, &ec2.DescribeInstancesInput{
+		Filters: []types.Filter{{
+			Name:   aws.String("instance-state-name"),
+			Values: []string{"pending", "stopping", "stopped"},
+		}},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list EC2 instances: %w", err)
+		}
+		for _, reservation := range page.Reservations {
+			for _, inst := range reservation.Instances {
+				instances = append(instances, convertEC2Instance(inst))
+			}
+		}
+	}
+
+	online := make(map[string]bool)
+	ssmPaginator := ssm.NewDescribeInstanceInformationPaginator(c.ssm, &ssm.DescribeInstanceInformationInput{})
+	for ssmPaginator.HasMorePages() {
+		page, err := ssmPaginator.NextPage(ctx)
+		if err != nil {
+			// The instances are still worth showing without their SSM status
+			log.Warn("Failed to list SSM instances: %v", err)
+			break
+		}
+		for _, info := range page.InstanceInformationList {
+			if info.InstanceId != nil && info.PingStatus == "Online" {
+				online[*info.InstanceId] = true
+			}
+		}
+	}
+	for i := range instances {
+		instances[i].SSMManaged = online[instances[i].InstanceID]
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].Name != instances[j].Name {
+			return instances[i].Name < instances[j].Name
+		}
+		return instances[i].InstanceID < instances[j].InstanceID
+	})
+	return instances, nil
+}
+
 // ListSSMManagedInstances lists EC2 instances that are managed by SSM
 func (c *Client) ListSSMManagedInstances(ctx context.Context) ([]model.EC2Instance, error) {
 	// First get all SSM managed instance IDs
@@ -0,0 +1,298 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrtypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// ecrImageRe matches ECR image URIs: registry, region, repository, and an
+// optional tag and digest.
+var ecrImageRe = regexp.MustCompile(`^(\d{12})\.dkr\.ecr\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?/([^:@]+)(?::([^@]+))?(?:@(sha256:[0-9a-f]+))?$`)
+
+// ecrRepo identifies a repository, which may be in another account or region.
+type ecrRepo struct {
+	registry, region, name string
+}
+
+// ecrImage is what a repository knows of an image.
+type ecrImage struct {
+	digest   string
+	tags     []string
+	pushedAt time.Time
+}
+
+// GetServiceImages compares the image each container of the services runs
+// with the image pushed last to its ECR repository. Containers running
+// images from other registries are listed with an error saying so.
+func (c *Client) GetServiceImages(ctx context.Context, services []model.Service) ([]model.ServiceImage, error) {
+	log.Debug("Checking images of %d services...", len(services))
+
+	perService := make([][]model.ServiceImage, len(services))
+	var wg sync.WaitGroup
+	sem := c.slots(ConcurrencyECR)
+	for i, svc := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			perService[i] = c.serviceImages(ctx, svc)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to check images: %w", err)
+	}
+
+	var images []model.ServiceImage
+	repos := make(map[ecrRepo]bool)
+	for _, list := range perService {
+		for _, img := range list {
+			if m := ecrImageRe.FindStringSubmatch(img.Image); m != nil {
+				repos[ecrRepo{registry: m[1], region: m[2], name: m[3]}] = true
+			}
+			images = append(images, img)
+		}
+	}
+
+	// Each repository is listed once, however many services use it
+	type listing struct {
+		images []ecrImage
+		err    error
+	}
+	listings := make(map[ecrRepo]listing, len(repos))
+	var mu sync.Mutex
+	for repo := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			imgs, err := c.listRepositoryImages(ctx, repo)
+			mu.Lock()
+			listings[repo] = listing{images: imgs, err: err}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	for i := range images {
+		img := &images[i]
+		m := ecrImageRe.FindStringSubmatch(img.Image)
+		if m == nil {
+			img.Error = "not an ECR image"
+			continue
+		}
+		l := listings[ecrRepo{registry: m[1], region: m[2], name: m[3]}]
+		if l.err != nil {
+			img.Error = l.err.Error()
+			continue
+		}
+		compareImage(img, l.images)
+	}
+
+	log.Debug("Checked %d container images in %d repositories", len(images), len(repos))
+	return images, nil
+}
+
+// serviceImages returns the containers of a service's task definition with
+// the digests its running tasks pulled.
+func (c *Client) serviceImages(ctx context.Context, svc model.Service) []model.ServiceImage {
+	var deployedAt time.Time
+	for _, d := range svc.Deployments {
+		if d.Status == "PRIMARY" {
+			deployedAt = d.UpdatedAt
+			if deployedAt.IsZero() {
+				deployedAt = d.CreatedAt
+			}
+		}
+	}
+
+	digests := c.runningDigests(ctx, svc)
+	var images []model.ServiceImage
+	for _, cd := range c.getContainerDefinitions(ctx, svc.TaskDefinition) {
+		img := model.ServiceImage{
+			Service:     svc.Name,
+			ClusterName: svc.ClusterName,
+			Container:   aws.ToString(cd.Name),
+			Image:       aws.ToString(cd.Image),
+			DeployedAt:  deployedAt,
+		}
+		img.Digest = digests[img.Container]
+		if m := ecrImageRe.FindStringSubmatch(img.Image); m != nil {
+			img.Repository, img.Tag = m[3], m[4]
+			if img.Digest == "" {
+				img.Digest = m[5]
+			}
+			if img.Tag == "" && m[5] == "" {
+				img.Tag = "latest"
+			}
+		}
+		images = append(images, img)
+	}
+	return images
+}
+
+// GetServiceVulnerabilities reads the last ECR scan, basic or enhanced, of
+// the image each container of the service runs. Containers running images
+// from other registries are left out, and images never scanned have no
+// counts.
+func (c *Client) GetServiceVulnerabilities(ctx context.Context, svc model.Service) ([]model.ImageVulnerabilities, error) {
+	images := c.serviceImages(ctx, svc)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read image scans: %w", err)
+	}
+	var scans []model.ImageVulnerabilities
+	for _, img := range images {
+		if m := ecrImageRe.FindStringSubmatch(img.Image); m != nil {
+			scans = append(scans, c.imageScan(ctx, img, ecrRepo{registry: m[1], region: m[2], name: m[3]}))
+		}
+	}
+	log.Debug("Read the image scans of %d containers of %s", len(scans), svc.Name)
+	return scans, nil
+}
+
+// imageScan reads the scan status and finding counts of the image a
+// container runs from its repository, by digest if known and by tag
+// otherwise.
+func (c *Client) imageScan(ctx context.Context, img model.ServiceImage, repo ecrRepo) model.ImageVulnerabilities {
+	scan := model.ImageVulnerabilities{Container: img.Container, Image: img.Image}
+	id := ecrtypes.ImageIdentifier{ImageTag: aws.String(img.Tag)}
+	if img.Digest != "" {
+		id = ecrtypes.ImageIdentifier{ImageDigest: aws.String(img.Digest)}
+	}
+
+	client := ecr.NewFromConfig(c.cfg, func(o *ecr.Options) { o.Region = repo.region })
+	out, err := client.DescribeImages(ctx, &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repo.name),
+		RegistryId:     aws.String(repo.registry),
+		ImageIds:       []ecrtypes.ImageIdentifier{id},
+	})
+	if err != nil {
+		scan.Error = fmt.Sprintf("failed to describe image in %s: %v", repo.name, err)
+		return scan
+	}
+	if len(out.ImageDetails) == 0 {
+		scan.Error = "image no longer in " + repo.name
+		return scan
+	}
+
+	d := out.ImageDetails[0]
+	if s := d.ImageScanStatus; s != nil {
+		scan.ScanStatus = string(s.Status)
+		scan.ScanDetail = aws.ToString(s.Description)
+	}
+	if f := d.ImageScanFindingsSummary; f != nil && f.ImageScanCompletedAt != nil {
+		scan.ScannedAt = aws.ToTime(f.ImageScanCompletedAt)
+		scan.Counts = make(map[string]int, len(f.FindingSeverityCounts))
+		for severity, n := range f.FindingSeverityCounts {
+			scan.Counts[severity] = int(n)
+		}
+	}
+	return scan
+}
+
+// runningDigests returns the image digest of each container, by name, of a
+// running task of the service's current task definition.
+func (c *Client) runningDigests(ctx context.Context, svc model.Service) map[string]string {
+	digests := make(map[string]string)
+	list, err := c.ecs.ListTasks(ctx, &ecs.ListTasksInput{
+		Cluster:       aws.String(svc.ClusterARN),
+		ServiceName:   aws.String(svc.Name),
+		DesiredStatus: ecstypes.DesiredStatusRunning,
+		MaxResults:    aws.Int32(10),
+	})
+	if err != nil || len(list.TaskArns) == 0 {
+		return digests
+	}
+	out, err := c.ecs.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+		Cluster: aws.String(svc.ClusterARN),
+		Tasks:   list.TaskArns,
+	})
+	if err != nil {
+		log.Debug("Failed to describe tasks of %s: %v", svc.Name, err)
+		return digests
+	}
+	for _, t := range out.Tasks {
+		if aws.ToString(t.TaskDefinitionArn) != svc.TaskDefinition {
+			continue
+		}
+		for _, cont := range t.Containers {
+			if d := aws.ToString(cont.ImageDigest); d != "" {
+				digests[aws.ToString(cont.Name)] = d
+			}
+		}
+	}
+	return digests
+}
+
+// listRepositoryImages returns the tagged images of a repository.
+func (c *Client) listRepositoryImages(ctx context.Context, repo ecrRepo) ([]ecrImage, error) {
+	client := ecr.NewFromConfig(c.cfg, func(o *ecr.Options) { o.Region = repo.region })
+	paginator := ecr.NewDescribeImagesPaginator(client, &ecr.DescribeImagesInput{
+		RepositoryName: aws.String(repo.name),
+		RegistryId:     aws.String(repo.registry),
+		Filter:         &ecrtypes.DescribeImagesFilter{TagStatus: ecrtypes.TagStatusTagged},
+	})
+
+	var images []ecrImage
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe images of %s: %w", repo.name, err)
+		}
+		for _, d := range page.ImageDetails {
+			images = append(images, ecrImage{
+				digest:   aws.ToString(d.ImageDigest),
+				tags:     d.ImageTags,
+				pushedAt: aws.ToTime(d.ImagePushedAt),
+			})
+		}
+	}
+	return images, nil
+}
+
+// compareImage fills in when the running image was pushed and which image
+// was pushed last. Without a running task the digest is the one the tag
+// points to now.
+func compareImage(img *model.ServiceImage, images []ecrImage) {
+	var latest *ecrImage
+	for i := range images {
+		e := &images[i]
+		if latest == nil || e.pushedAt.After(latest.pushedAt) {
+			latest = e
+		}
+		if img.Digest == "" {
+			for _, t := range e.tags {
+				if t == img.Tag {
+					img.Digest = e.digest
+				}
+			}
+		}
+	}
+	for _, e := range images {
+		if e.digest == img.Digest {
+			img.PushedAt = e.pushedAt
+		}
+	}
+	if latest == nil {
+		return
+	}
+	img.LatestDigest = latest.digest
+	img.LatestPushedAt = latest.pushedAt
+	if len(latest.tags) > 0 {
+		img.LatestTag = latest.tags[0]
+	}
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -19,6 +20,7 @@ func (c *Client) ListClusters(ctx context.Context) ([]model.Cluster, error) {
 	log.Debug("Listing ECS clusters...")
 
 	var clusterARNs []string
+	pages := 0
 	paginator := ecs.NewListClustersPaginator(c.ecs, &ecs.ListClustersInput{})
 
 	for paginator.HasMorePages() {
@@ -27,6 +29,8 @@ func (c *Client) ListClusters(ctx context.Context) ([]model.Cluster, error) {
 			return nil, fmt.Errorf("failed to list clusters: %w", err)
 		}
 		clusterARNs = append(clusterARNs, page.ClusterArns...)
+		pages++
+		reportProgress(ctx, "ListClusters", "pages", pages, 0)
 	}
 
 	if len(clusterARNs) == 0 {
@@ -34,12 +38,14 @@ func (c *Client) ListClusters(ctx context.Context) ([]model.Cluster, error) {
 	}
 
 	// Describe clusters to get details
+	reportProgress(ctx, "DescribeClusters", "calls", 0, 1)
 	out, err := c.ecs.DescribeClusters(ctx, &ecs.DescribeClustersInput{
 		Clusters: clusterARNs,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe clusters: %w", err)
 	}
+	reportProgress(ctx, "DescribeClusters", "calls", 1, 1)
 
 	var clusters []model.Cluster
 	for _, cl := range out.Clusters {
@@ -63,6 +69,7 @@ func (c *Client) ListServices(ctx context.Context, clusterARN string) ([]model.S
 	log.Debug("Listing ECS services in cluster: %s", clusterARN)
 
 	var serviceARNs []string
+	pages := 0
 	paginator := ecs.NewListServicesPaginator(c.ecs, &ecs.ListServicesInput{
 		Cluster: aws.String(clusterARN),
 	})
@@ -73,6 +80,8 @@ func (c *Client) ListServices(ctx context.Context, clusterARN string) ([]model.S
 			return nil, fmt.Errorf("failed to list services: %w", err)
 		}
 		serviceARNs = append(serviceARNs, page.ServiceArns...)
+		pages++
+		reportProgress(ctx, "ListServices", "pages", pages, 0)
 	}
 
 	if len(serviceARNs) == 0 {
@@ -90,6 +99,9 @@ func (c *Client) DescribeServices(ctx context.Context, clusterARN string, servic
 
 	// DescribeServices has a limit of 10 services per call
 	var services []model.Service
+	batches := (len(serviceARNs) + 9) / 10
+	reportProgress(ctx, "DescribeServices", "batches", 0, batches)
+	reportProgress(ctx, "DescribeTaskDefinition", "services", 0, len(serviceARNs))
 	for i := 0; i < len(serviceARNs); i += 10 {
 		end := i + 10
 		if end > len(serviceARNs) {
@@ -115,9 +127,13 @@ func (c *Client) DescribeServices(ctx context.Context, clusterARN string, servic
 			}
 
 			services = append(services, service)
+			reportProgress(ctx, "DescribeTaskDefinition", "services", len(services), len(serviceARNs))
 		}
+		reportProgress(ctx, "DescribeServices", "batches", i/10+1, batches)
 	}
 
+	c.resolveDiscoveryEndpoints(ctx, services)
+
 	log.Debug("Described %d ECS services", len(services))
 	return services, nil
 }
@@ -138,8 +154,9 @@ func (c *Client) DescribeService(ctx context.Context, clusterARN, serviceName st
 		return nil, fmt.Errorf("service %s not found", serviceName)
 	}
 
-	svc := convertService(out.Services[0])
-	return &svc, nil
+	services := []model.Service{convertService(out.Services[0])}
+	c.resolveDiscoveryEndpoints(ctx, services)
+	return &services[0], nil
 }
 
 // GetServicesForStack returns ECS services for clusters defined in a CloudFormation stack.
@@ -223,18 +240,75 @@ func convertService(svc ecstypes.Service) model.Service {
 		service.Deployments = append(service.Deployments, model.Deployment{
 			ID:             aws.ToString(d.Id),
 			Status:         aws.ToString(d.Status),
+			RolloutState:   string(d.RolloutState),
+			RolloutReason:  aws.ToString(d.RolloutStateReason),
 			DesiredCount:   int(d.DesiredCount),
 			RunningCount:   int(d.RunningCount),
 			PendingCount:   int(d.PendingCount),
+			FailedTasks:    int(d.FailedTasks),
 			TaskDefinition: aws.ToString(d.TaskDefinition),
 			CreatedAt:      aws.ToTime(d.CreatedAt),
 			UpdatedAt:      aws.ToTime(d.UpdatedAt),
 		})
 	}
 
+	service.DiscoveryEndpoints = convertDiscoveryEndpoints(svc)
+
 	return service
 }
 
+// convertDiscoveryEndpoints extracts Service Connect endpoints from the primary
+// deployment and Cloud Map registries from the service. Namespace and service
+// names that are only known by ARN are filled in by resolveDiscoveryEndpoints.
+func convertDiscoveryEndpoints(svc ecstypes.Service) []model.DiscoveryEndpoint {
+	var endpoints []model.DiscoveryEndpoint
+
+	for _, d := range svc.Deployments {
+		if aws.ToString(d.Status) != "PRIMARY" || d.ServiceConnectConfiguration == nil || !d.ServiceConnectConfiguration.Enabled {
+			continue
+		}
+		namespace := aws.ToString(d.ServiceConnectConfiguration.Namespace)
+		for _, scs := range d.ServiceConnectConfiguration.Services {
+			name := aws.ToString(scs.DiscoveryName)
+			if name == "" {
+				name = aws.ToString(scs.PortName)
+			}
+			// Without client aliases the service is discoverable but has no DNS name
+			if len(scs.ClientAliases) == 0 {
+				endpoints = append(endpoints, model.DiscoveryEndpoint{
+					Source:    model.DiscoveryServiceConnect,
+					Namespace: namespace,
+					Name:      name,
+				})
+				continue
+			}
+			for _, alias := range scs.ClientAliases {
+				endpoints = append(endpoints, model.DiscoveryEndpoint{
+					Source:    model.DiscoveryServiceConnect,
+					Namespace: namespace,
+					Name:      name,
+					DNSName:   aws.ToString(alias.DnsName),
+					Port:      int(aws.ToInt32(alias.Port)),
+				})
+			}
+		}
+	}
+
+	for _, reg := range svc.ServiceRegistries {
+		port := int(aws.ToInt32(reg.ContainerPort))
+		if port == 0 {
+			port = int(aws.ToInt32(reg.Port))
+		}
+		endpoints = append(endpoints, model.DiscoveryEndpoint{
+			Source:      model.DiscoveryCloudMap,
+			Port:        port,
+			RegistryARN: aws.ToString(reg.RegistryArn),
+		})
+	}
+
+	return endpoints
+}
+
 // ListTasksForService returns running tasks for a service.
 func (c *Client) ListTasksForService(ctx context.Context, clusterARN, serviceName string) ([]model.Task, error) {
 	log.Debug("Listing tasks for service: %s in cluster %s", serviceName, clusterARN)
@@ -248,14 +322,49 @@ func (c *Client) ListTasksForService(ctx context.Context, clusterARN, serviceNam
 		return nil, fmt.Errorf("failed to list tasks: %w", err)
 	}
 
-	if len(listOut.TaskArns) == 0 {
+	tasks, err := c.describeTasks(ctx, clusterARN, listOut.TaskArns)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Found %d tasks for service %s", len(tasks), serviceName)
+	return tasks, nil
+}
+
+// ListStoppedTasksForService returns the tasks of a service that stopped
+// recently; ECS keeps them for about an hour.
+func (c *Client) ListStoppedTasksForService(ctx context.Context, clusterARN, serviceName string) ([]model.Task, error) {
+	log.Debug("Listing stopped tasks for service: %s in cluster %s", serviceName, clusterARN)
+
+	listOut, err := c.ecs.ListTasks(ctx, &ecs.ListTasksInput{
+		Cluster:       aws.String(clusterARN),
+		ServiceName:   aws.String(serviceName),
+		DesiredStatus: ecstypes.DesiredStatusStopped,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stopped tasks: %w", err)
+	}
+
+	tasks, err := c.describeTasks(ctx, clusterARN, listOut.TaskArns)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Found %d stopped tasks for service %s", len(tasks), serviceName)
+	return tasks, nil
+}
+
+// describeTasks describes tasks of a cluster with their containers, adding
+// the port mappings and container settings of their task definitions.
+func (c *Client) describeTasks(ctx context.Context, clusterARN string, taskARNs []string) ([]model.Task, error) {
+	if len(taskARNs) == 0 {
 		return nil, nil
 	}
 
 	// Describe tasks to get details
 	descOut, err := c.ecs.DescribeTasks(ctx, &ecs.DescribeTasksInput{
 		Cluster: aws.String(clusterARN),
-		Tasks:   listOut.TaskArns,
+		Tasks:   taskARNs,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to describe tasks: %w", err)
@@ -274,6 +383,20 @@ func (c *Client) ListTasksForService(ctx context.Context, clusterARN, serviceNam
 			DesiredStatus:     aws.ToString(t.DesiredStatus),
 			LaunchType:        string(t.LaunchType),
 			StartedAt:         aws.ToTime(t.StartedAt),
+			AvailabilityZone:  aws.ToString(t.AvailabilityZone),
+			HealthStatus:      string(t.HealthStatus),
+			CPU:               aws.ToString(t.Cpu),
+			Memory:            aws.ToString(t.Memory),
+			Group:             aws.ToString(t.Group),
+			StopCode:          string(t.StopCode),
+			StoppedReason:     aws.ToString(t.StoppedReason),
+			CreatedAt:         aws.ToTime(t.CreatedAt),
+			PullStartedAt:     aws.ToTime(t.PullStartedAt),
+			PullStoppedAt:     aws.ToTime(t.PullStoppedAt),
+			ConnectivityAt:    aws.ToTime(t.ConnectivityAt),
+			StoppingAt:        aws.ToTime(t.StoppingAt),
+			ExecutionStopAt:   aws.ToTime(t.ExecutionStoppedAt),
+			StoppedAt:         aws.ToTime(t.StoppedAt),
 		}
 
 		// Extract task ID from ARN
@@ -282,6 +405,21 @@ func (c *Client) ListTasksForService(ctx context.Context, clusterARN, serviceNam
 			task.TaskID = parts[len(parts)-1]
 		}
 
+		// awsvpc tasks have their own network interface
+		for _, att := range t.Attachments {
+			if aws.ToString(att.Type) != "ElasticNetworkInterface" {
+				continue
+			}
+			for _, d := range att.Details {
+				switch aws.ToString(d.Name) {
+				case "privateIPv4Address":
+					task.PrivateIP = aws.ToString(d.Value)
+				case "subnetId":
+					task.SubnetID = aws.ToString(d.Value)
+				}
+			}
+		}
+
 		// Get port mappings from task definition (for Fargate/awsvpc networking)
 		taskDefARN := aws.ToString(t.TaskDefinitionArn)
 		containerDefs, ok := taskDefCache[taskDefARN]
@@ -292,8 +430,11 @@ func (c *Client) ListTasksForService(ctx context.Context, clusterARN, serviceNam
 
 		// Build a map of container name -> port mappings from task definition
 		containerPortMap := make(map[string][]model.PortMapping)
+		essential := make(map[string]bool)
 		for _, cd := range containerDefs {
 			name := aws.ToString(cd.Name)
+			// Containers are essential unless the definition says otherwise
+			essential[name] = cd.Essential == nil || *cd.Essential
 			for _, pm := range cd.PortMappings {
 				containerPortMap[name] = append(containerPortMap[name], model.PortMapping{
 					ContainerPort: int(aws.ToInt32(pm.ContainerPort)),
@@ -312,6 +453,18 @@ func (c *Client) ListTasksForService(ctx context.Context, clusterARN, serviceNam
 				RuntimeID:    aws.ToString(cont.RuntimeId),
 				LastStatus:   aws.ToString(cont.LastStatus),
 				Image:        aws.ToString(cont.Image),
+				HealthStatus: string(cont.HealthStatus),
+				Reason:       aws.ToString(cont.Reason),
+				Essential:    true,
+				CPU:          aws.ToString(cont.Cpu),
+				Memory:       aws.ToString(cont.Memory),
+			}
+			if e, ok := essential[container.Name]; ok {
+				container.Essential = e
+			}
+			if cont.ExitCode != nil {
+				code := int(*cont.ExitCode)
+				container.ExitCode = &code
 			}
 
 			// Add NetworkBindings (for EC2/bridge networking)
@@ -333,11 +486,93 @@ func (c *Client) ListTasksForService(ctx context.Context, clusterARN, serviceNam
 
 		tasks = append(tasks, task)
 	}
-
-	log.Info("Found %d tasks for service %s", len(tasks), serviceName)
 	return tasks, nil
 }
 
+// StopTasks stops tasks of a cluster. It tries every task and returns how
+// many were stopped, with the first error.
+func (c *Client) StopTasks(ctx context.Context, clusterARN string, taskARNs []string, reason string) (int, error) {
+	log.Info("Stopping %d tasks in cluster %s", len(taskARNs), clusterARN)
+
+	stopped := 0
+	var firstErr error
+	for _, arn := range taskARNs {
+		_, err := c.ecs.StopTask(ctx, &ecs.StopTaskInput{
+			Cluster: aws.String(clusterARN),
+			Task:    aws.String(arn),
+			Reason:  aws.String(reason),
+		})
+		if err != nil {
+			log.Warn("Failed to stop task %s: %v", arn, err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to stop task %s: %w", arn, err)
+			}
+			continue
+		}
+		stopped++
+	}
+	return stopped, firstErr
+}
+
+// maxProtectionTasks is how many tasks one task protection call takes.
+const maxProtectionTasks = 10
+
+// GetTaskProtection returns the scale-in protection of tasks of a cluster,
+// keyed by task ARN.
+func (c *Client) GetTaskProtection(ctx context.Context, clusterARN string, taskARNs []string) (map[string]model.TaskProtection, error) {
+	protection := make(map[string]model.TaskProtection, len(taskARNs))
+	for batch := range slices.Chunk(taskARNs, maxProtectionTasks) {
+		out, err := c.ecs.GetTaskProtection(ctx, &ecs.GetTaskProtectionInput{
+			Cluster: aws.String(clusterARN),
+			Tasks:   batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task protection: %w", err)
+		}
+		for _, p := range out.ProtectedTasks {
+			protection[aws.ToString(p.TaskArn)] = convertTaskProtection(p)
+		}
+	}
+	return protection, nil
+}
+
+// UpdateTaskProtection turns scale-in protection of tasks on, for
+// expiresInMinutes (1 to 2880), or off.
+func (c *Client) UpdateTaskProtection(ctx context.Context, clusterARN string, taskARNs []string, enabled bool, expiresInMinutes int) ([]model.TaskProtection, error) {
+	var protection []model.TaskProtection
+	for batch := range slices.Chunk(taskARNs, maxProtectionTasks) {
+		in := &ecs.UpdateTaskProtectionInput{
+			Cluster:           aws.String(clusterARN),
+			Tasks:             batch,
+			ProtectionEnabled: enabled,
+		}
+		if enabled {
+			in.ExpiresInMinutes = aws.Int32(int32(expiresInMinutes))
+		}
+		out, err := c.ecs.UpdateTaskProtection(ctx, in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update task protection: %w", err)
+		}
+		if len(out.Failures) > 0 {
+			f := out.Failures[0]
+			return nil, fmt.Errorf("failed to update protection of task %s: %s", aws.ToString(f.Arn), aws.ToString(f.Reason))
+		}
+		for _, p := range out.ProtectedTasks {
+			protection = append(protection, convertTaskProtection(p))
+		}
+	}
+	return protection, nil
+}
+
+// convertTaskProtection converts the protection of an ECS task to our model.
+func convertTaskProtection(p ecstypes.ProtectedTask) model.TaskProtection {
+	return model.TaskProtection{
+		TaskARN:   aws.ToString(p.TaskArn),
+		Enabled:   p.ProtectionEnabled,
+		ExpiresAt: aws.ToTime(p.ExpirationDate),
+	}
+}
+
 // getContainerDefinitions fetches container definitions from a task definition.
 func (c *Client) getContainerDefinitions(ctx context.Context, taskDefARN string) []ecstypes.ContainerDefinition {
 	out, err := c.ecs.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// clusterMetricsWindow is how far back the dashboard looks for the latest
+// ECS utilization data point. ECS publishes one a minute.
+const clusterMetricsWindow = 15 * time.Minute
+
+// metricQueriesPerCall is the most queries GetMetricData takes at once.
+const metricQueriesPerCall = 500
+
+// GetClusterDashboard sums up the services of a cluster: their task counts,
+// the ones whose deployment fails, the ones updated last, and how much of
+// the cluster's CPU and memory is reserved and used. The usage is left
+// unknown if CloudWatch can't be read.
+func (c *Client) GetClusterDashboard(ctx context.Context, cluster model.Cluster) (*model.ClusterDashboard, error) {
+	services, err := c.ListServices(ctx, cluster.ARN)
+	if err != nil {
+		return nil, err
+	}
+
+	d := model.SummarizeCluster(cluster, services)
+	if err := c.clusterUsage(ctx, d, services); err != nil {
+		log.Warn("Failed to get utilization of cluster %s: %v", cluster.Name, err)
+	}
+	return d, nil
+}
+
+// clusterUsage sets the CPU and memory usage of the dashboard from the
+// cluster's ECS metrics. Clusters without EC2 capacity publish no cluster
+// metrics, so their utilization is the average of their services', weighted
+// by running tasks, and their reservation stays unknown.
+func (c *Client) clusterUsage(ctx context.Context, d *model.ClusterDashboard, services []model.Service) error {
+	clusterDim := cwtypes.Dimension{Name: aws.String("ClusterName"), Value: aws.String(d.Cluster.Name)}
+	query := func(id, metric string, dims ...cwtypes.Dimension) cwtypes.MetricDataQuery {
+		return cwtypes.MetricDataQuery{
+			Id: aws.String(id),
+			MetricStat: &cwtypes.MetricStat{
+				Metric: &cwtypes.Metric{
+					Namespace:  aws.String("AWS/ECS"),
+					MetricName: aws.String(metric),
+					Dimensions: dims,
+				},
+				Period: aws.Int32(60),
+				Stat:   aws.String("Average"),
+			},
+		}
+	}
+
+	queries := []cwtypes.MetricDataQuery{
+		query("cpu_reserved", "CPUReservation", clusterDim),
+		query("cpu_used", "CPUUtilization", clusterDim),
+		query("mem_reserved", "MemoryReservation", clusterDim),
+		query("mem_used", "MemoryUtilization", clusterDim),
+	}
+	for i, svc := range services {
+		if svc.RunningCount == 0 {
+			continue
+		}
+		serviceDim := cwtypes.Dimension{Name: aws.String("ServiceName"), Value: aws.String(svc.Name)}
+		queries = append(queries,
+			query(fmt.Sprintf("svc%d_cpu", i), "CPUUtilization", clusterDim, serviceDim),
+			query(fmt.Sprintf("svc%d_mem", i), "MemoryUtilization", clusterDim, serviceDim))
+	}
+
+	latest := make(map[string]float64)
+	now := time.Now()
+	for start := 0; start < len(queries); start += metricQueriesPerCall {
+		batch := queries[start:min(start+metricQueriesPerCall, len(queries))]
+		paginator := cloudwatch.NewGetMetricDataPaginator(c.cw, &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: batch,
+			StartTime:         aws.Time(now.Add(-clusterMetricsWindow)),
+			EndTime:           aws.Time(now),
+			ScanBy:            cwtypes.ScanByTimestampDescending,
+		})
+		for paginator.HasMorePages() {
+			out, err := paginator.NextPage(ctx)
+			if err != nil {
+				return err
+			}
+			for _, r := range out.MetricDataResults {
+				id := aws.ToString(r.Id)
+				if _, ok := latest[id]; ok || len(r.Values) == 0 {
+					continue
+				}
+				latest[id] = r.Values[0]
+				if len(r.Timestamps) > 0 && r.Timestamps[0].After(d.MetricsAt) {
+					d.MetricsAt = r.Timestamps[0]
+				}
+			}
+		}
+	}
+
+	set := func(v *float64, id string) {
+		if value, ok := latest[id]; ok {
+			*v = value
+		}
+	}
+	set(&d.CPU.Reserved, "cpu_reserved")
+	set(&d.CPU.Used, "cpu_used")
+	set(&d.Memory.Reserved, "mem_reserved")
+	set(&d.Memory.Used, "mem_used")
+
+	if d.CPU.Used < 0 {
+		d.CPU.Used = weightedServiceUsage(services, latest, "cpu")
+	}
+	if d.Memory.Used < 0 {
+		d.Memory.Used = weightedServiceUsage(services, latest, "mem")
+	}
+	return nil
+}
+
+// weightedServiceUsage averages the utilization of the services with data,
+// weighted by their running tasks, or returns -1 if none has any.
+func weightedServiceUsage(services []model.Service, latest map[string]float64, kind string) float64 {
+	var sum float64
+	tasks := 0
+	for i, svc := range services {
+		value, ok := latest[fmt.Sprintf("svc%d_%s", i, kind)]
+		if !ok {
+			continue
+		}
+		sum += value * float64(svc.RunningCount)
+		tasks += svc.RunningCount
+	}
+	if tasks == 0 {
+		return -1
+	}
+	return sum / float64(tasks)
+}
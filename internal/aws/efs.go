@@ -0,0 +1,316 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// efsFileSystem is a file system of the EFS DescribeFileSystems response.
+type efsFileSystem struct {
+	FileSystemId                 string   `json:"FileSystemId"`
+	FileSystemArn                string   `json:"FileSystemArn"`
+	Name                         string   `json:"Name"`
+	LifeCycleState               string   `json:"LifeCycleState"`
+	PerformanceMode              string   `json:"PerformanceMode"`
+	ThroughputMode               string   `json:"ThroughputMode"`
+	ProvisionedThroughputInMibps float64  `json:"ProvisionedThroughputInMibps"`
+	Encrypted                    bool     `json:"Encrypted"`
+	NumberOfMountTargets         int      `json:"NumberOfMountTargets"`
+	CreationTime                 restTime `json:"CreationTime"`
+	SizeInBytes                  struct {
+		Value int64 `json:"Value"`
+	} `json:"SizeInBytes"`
+}
+
+// ListEFSFileSystems lists all EFS file systems.
+func (c *Client) ListEFSFileSystems(ctx context.Context) ([]model.EFSFileSystem, error) {
+	log.Debug("Listing EFS file systems...")
+
+	var fileSystems []model.EFSFileSystem
+	query := url.Values{"MaxItems": {"100"}}
+	for page := 1; ; page++ {
+		var out struct {
+			FileSystems []efsFileSystem `json:"FileSystems"`
+			NextMarker  string          `json:"NextMarker"`
+		}
+		if err := c.callREST(ctx, "elasticfilesystem", "GET", "/2015-02-01/file-systems", query, nil, &out); err != nil {
+			return nil, fmt.Errorf("failed to list EFS file systems: %w", err)
+		}
+		for _, fs := range out.FileSystems {
+			fileSystems = append(fileSystems, model.EFSFileSystem{
+				ID:               fs.FileSystemId,
+				Name:             fs.Name,
+				ARN:              fs.FileSystemArn,
+				State:            fs.LifeCycleState,
+				SizeBytes:        fs.SizeInBytes.Value,
+				PerformanceMode:  fs.PerformanceMode,
+				ThroughputMode:   fs.ThroughputMode,
+				ProvisionedMiBps: fs.ProvisionedThroughputInMibps,
+				Encrypted:        fs.Encrypted,
+				MountTargetCount: fs.NumberOfMountTargets,
+				CreatedAt:        fs.CreationTime.Time,
+			})
+		}
+		reportProgress(ctx, "DescribeFileSystems", "pages", page, 0)
+
+		if out.NextMarker == "" {
+			break
+		}
+		query.Set("Marker", out.NextMarker)
+	}
+
+	sort.Slice(fileSystems, func(i, j int) bool {
+		if fileSystems[i].Name != fileSystems[j].Name {
+			return fileSystems[i].Name < fileSystems[j].Name
+		}
+		return fileSystems[i].ID < fileSystems[j].ID
+	})
+
+	log.Info("Found %d EFS file systems", len(fileSystems))
+	return fileSystems, nil
+}
+
+// GetEFSDetails returns the mount targets and access points of a file
+// system, and the active task definitions and Lambda functions that mount
+// it. Task definitions and functions that cannot be read are skipped.
+func (c *Client) GetEFSDetails(ctx context.Context, fileSystemID string) (*model.EFSDetails, error) {
+	details := &model.EFSDetails{FileSystemID: fileSystemID}
+
+	query := url.Values{"FileSystemId": {fileSystemID}}
+	for {
+		var out struct {
+			MountTargets []struct {
+				MountTargetId        string `json:"MountTargetId"`
+				AvailabilityZoneName string `json:"AvailabilityZoneName"`
+				SubnetId             string `json:"SubnetId"`
+				IpAddress            string `json:"IpAddress"`
+				LifeCycleState       string `json:"LifeCycleState"`
+			} `json:"MountTargets"`
+			NextMarker string `json:"NextMarker"`
+		}
+		if err := c.callREST(ctx, "elasticfilesystem", "GET", "/2015-02-01/mount-targets", query, nil, &out); err != nil {
+			return nil, fmt.Errorf("failed to list mount targets: %w", err)
+		}
+		for _, mt := range out.MountTargets {
+			details.MountTargets = append(details.MountTargets, model.EFSMountTarget{
+				ID:       mt.MountTargetId,
+				AZ:       mt.AvailabilityZoneName,
+				SubnetID: mt.SubnetId,
+				IP:       mt.IpAddress,
+				State:    mt.LifeCycleState,
+			})
+		}
+		if out.NextMarker == "" {
+			break
+		}
+		query.Set("Marker", out.NextMarker)
+	}
+	sort.Slice(details.MountTargets, func(i, j int) bool {
+		return details.MountTargets[i].AZ < details.MountTargets[j].AZ
+	})
+
+	query = url.Values{"FileSystemId": {fileSystemID}, "MaxResults": {"100"}}
+	for {
+		var out struct {
+			AccessPoints []struct {
+				AccessPointId  string `json:"AccessPointId"`
+				AccessPointArn string `json:"AccessPointArn"`
+				Name           string `json:"Name"`
+				LifeCycleState string `json:"LifeCycleState"`
+				PosixUser      *struct {
+					Uid int64 `json:"Uid"`
+					Gid int64 `json:"Gid"`
+				} `json:"PosixUser"`
+				RootDirectory *struct {
+					Path string `json:"Path"`
+				} `json:"RootDirectory"`
+			} `json:"AccessPoints"`
+			NextToken string `json:"NextToken"`
+		}
+		if err := c.callREST(ctx, "elasticfilesystem", "GET", "/2015-02-01/access-points", query, nil, &out); err != nil {
+			return nil, fmt.Errorf("failed to list access points: %w", err)
+		}
+		for _, ap := range out.AccessPoints {
+			point := model.EFSAccessPoint{
+				ID:    ap.AccessPointId,
+				ARN:   ap.AccessPointArn,
+				Name:  ap.Name,
+				Path:  "/",
+				UID:   -1,
+				GID:   -1,
+				State: ap.LifeCycleState,
+			}
+			if ap.RootDirectory != nil && ap.RootDirectory.Path != "" {
+				point.Path = ap.RootDirectory.Path
+			}
+			if ap.PosixUser != nil {
+				point.UID, point.GID = ap.PosixUser.Uid, ap.PosixUser.Gid
+			}
+			details.AccessPoints = append(details.AccessPoints, point)
+		}
+		if out.NextToken == "" {
+			break
+		}
+		query.Set("NextToken", out.NextToken)
+	}
+
+	details.Mounts = append(c.findTaskDefinitionEFSMounts(ctx, fileSystemID), c.findFunctionEFSMounts(ctx, details.AccessPoints)...)
+	return details, nil
+}
+
+// findTaskDefinitionEFSMounts returns the containers of the latest active
+// revision of each task definition family that mount the file system.
+func (c *Client) findTaskDefinitionEFSMounts(ctx context.Context, fileSystemID string) []model.EFSMount {
+	var families []string
+	paginator := ecs.NewListTaskDefinitionFamiliesPaginator(c.ecs, &ecs.ListTaskDefinitionFamiliesInput{
+		Status: ecstypes.TaskDefinitionFamilyStatusActive,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Warn("Failed to list task definition families: %v", err)
+			return nil
+		}
+		families = append(families, page.Families...)
+	}
+
+	results := make(chan []model.EFSMount, len(families))
+	sem := c.slots(ConcurrencyECS)
+
+	var wg sync.WaitGroup
+	for _, family := range families {
+		wg.Add(1)
+		go func(family string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			out, err := c.ecs.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+				TaskDefinition: aws.String(family),
+			})
+			if err != nil || out.TaskDefinition == nil {
+				log.Debug("Failed to describe task definition %s: %v", family, err)
+				results <- nil
+				return
+			}
+			results <- taskDefinitionEFSMounts(*out.TaskDefinition, fileSystemID)
+		}(family)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var mounts []model.EFSMount
+	described := 0
+	reportProgress(ctx, "DescribeTaskDefinition", "families", 0, len(families))
+	for result := range results {
+		mounts = append(mounts, result...)
+		described++
+		reportProgress(ctx, "DescribeTaskDefinition", "families", described, len(families))
+	}
+
+	sort.Slice(mounts, func(i, j int) bool {
+		if mounts[i].Name != mounts[j].Name {
+			return mounts[i].Name < mounts[j].Name
+		}
+		return mounts[i].Container < mounts[j].Container
+	})
+	return mounts
+}
+
+// taskDefinitionEFSMounts returns the mount points of a task definition's
+// containers on volumes of the file system.
+func taskDefinitionEFSMounts(td ecstypes.TaskDefinition, fileSystemID string) []model.EFSMount {
+	accessPoints := make(map[string]string) // Volume name -> access point ID
+	for _, v := range td.Volumes {
+		efs := v.EfsVolumeConfiguration
+		if efs == nil || aws.ToString(efs.FileSystemId) != fileSystemID {
+			continue
+		}
+		accessPoint := ""
+		if efs.AuthorizationConfig != nil {
+			accessPoint = aws.ToString(efs.AuthorizationConfig.AccessPointId)
+		}
+		accessPoints[aws.ToString(v.Name)] = accessPoint
+	}
+	if len(accessPoints) == 0 {
+		return nil
+	}
+
+	name := fmt.Sprintf("%s:%d", aws.ToString(td.Family), td.Revision)
+	var mounts []model.EFSMount
+	for _, cd := range td.ContainerDefinitions {
+		for _, mp := range cd.MountPoints {
+			accessPoint, ok := accessPoints[aws.ToString(mp.SourceVolume)]
+			if !ok {
+				continue
+			}
+			mounts = append(mounts, model.EFSMount{
+				Kind:          "task definition",
+				Name:          name,
+				Container:     aws.ToString(cd.Name),
+				Path:          aws.ToString(mp.ContainerPath),
+				AccessPointID: accessPoint,
+			})
+		}
+	}
+	return mounts
+}
+
+// findFunctionEFSMounts returns the Lambda functions that mount one of the
+// access points. Functions always mount EFS through an access point.
+func (c *Client) findFunctionEFSMounts(ctx context.Context, accessPoints []model.EFSAccessPoint) []model.EFSMount {
+	if len(accessPoints) == 0 {
+		return nil
+	}
+	ids := make(map[string]bool, len(accessPoints))
+	for _, ap := range accessPoints {
+		ids[ap.ID] = true
+	}
+
+	var mounts []model.EFSMount
+	paginator := lambda.NewListFunctionsPaginator(c.lambda, &lambda.ListFunctionsInput{})
+	for page := 1; paginator.HasMorePages(); page++ {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Warn("Failed to list Lambda functions: %v", err)
+			break
+		}
+		for _, fn := range out.Functions {
+			for _, fsc := range fn.FileSystemConfigs {
+				// arn:aws:elasticfilesystem:region:account:access-point/fsap-...
+				arn := aws.ToString(fsc.Arn)
+				id := arn[strings.LastIndex(arn, "/")+1:]
+				if !ids[id] {
+					continue
+				}
+				mounts = append(mounts, model.EFSMount{
+					Kind:          "function",
+					Name:          aws.ToString(fn.FunctionName),
+					Path:          aws.ToString(fsc.LocalMountPath),
+					AccessPointID: id,
+				})
+			}
+		}
+		reportProgress(ctx, "ListFunctions", "pages", page, 0)
+	}
+
+	sort.Slice(mounts, func(i, j int) bool {
+		return mounts[i].Name < mounts[j].Name
+	})
+	return mounts
+}
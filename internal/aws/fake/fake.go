@@ -0,0 +1,1288 @@
+// Package fake provides an in-memory implementation of aws.API, so that UI
+// flows can be driven in tests without AWS credentials or network access.
+//
+// Fill the exported fields with the resources the test needs, set Errors to
+// make a method fail, and check Calls afterwards:
+//
+//	client := fake.New()
+//	client.Clusters = []model.Cluster{{Name: "prod", ARN: "arn:cluster/prod"}}
+//	client.Errors["ListServices"] = errors.New("access denied")
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"vaws/internal/aws"
+	"vaws/internal/model"
+)
+
+// Call is a method called on the fake client, with its arguments after the context.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Client is an in-memory aws.API. Methods return copies of the fields below
+// and never fail unless told to through Errors. It is safe for concurrent
+// use as long as the fields are not changed while the UI runs.
+type Client struct {
+	ProfileName string
+	RegionName  string
+
+	// CloudFormation, keyed by stack name where per stack
+	Stacks         []model.Stack
+	StackEvents    map[string][]model.StackEvent
+	StackTemplates map[string]string
+	StackServices  map[string][]model.Service
+	StackFunctions map[string][]string
+	StackQueues    map[string][]string
+	StackAlarms    map[string][]string
+	StackRestAPIs  map[string][]string
+	StackHttpAPIs  map[string][]string
+
+	// ECS, keyed by cluster ARN for services and by service name for tasks and images
+	Clusters        []model.Cluster
+	Services        map[string][]model.Service
+	Tasks           map[string][]model.Task
+	StoppedTasks    map[string][]model.Task
+	TaskDefinitions map[string]string // Task definition ARN -> JSON document
+	ContainerLogs   map[string][]model.ContainerLogConfig
+	ServiceImages   map[string][]model.ServiceImage
+	ImageScans      map[string][]model.ImageVulnerabilities
+	TaskProtection  map[string]model.TaskProtection // Task ARN -> protection
+	ClusterCPU      map[string]model.ClusterUsage   // Cluster ARN -> usage, unknown if missing
+	ClusterMemory   map[string]model.ClusterUsage
+
+	// Lambda; Invocations, Aliases, Versions and Performance are keyed by
+	// function name. Invocations default to a 200 echoing the payload
+	Functions   []model.Function
+	Invocations map[string]*model.InvocationResult
+	Aliases     map[string][]model.LambdaAlias
+	Versions    map[string][]string
+	Performance map[string]*model.LambdaPerformance
+
+	// API Gateway, stages, routes and deployments keyed by API ID
+	RestAPIs     []model.RestAPI
+	HttpAPIs     []model.HttpAPI
+	Stages       map[string][]model.APIStage
+	Routes       map[string][]model.APIRoute
+	Deployments  map[string][]model.APIDeployment
+	VpcEndpoints map[string]*model.VpcEndpoint
+
+	// SQS and DynamoDB; Messages are keyed by queue URL, Relations and
+	// Failures by queue ARN and Items by table name
+	Queues    []model.Queue
+	Messages  map[string][]model.QueueMessage
+	Relations map[string]*model.QueueRelations
+	Failures  map[string]*model.QueueFailures
+	Tables    []model.Table
+	Items     map[string][]model.DynamoDBItem
+
+	// EC2; regions without a latency count as unreachable
+	JumpHost  *model.EC2Instance
+	Instances []model.EC2Instance
+	SubnetVPC map[string]string
+	Regions   []string
+	Latencies map[string]time.Duration
+
+	// CloudWatch Logs, keyed by log group
+	LogEvents  map[string][]model.CloudWatchLogEntry
+	LogSources map[string][]model.LogSource // Stack name -> sources
+	LogHits    []model.LogSearchHit
+	LogGroups  []model.LogGroup
+
+	Alarms              []model.Alarm
+	AppRunnerServices   []model.AppRunnerService
+	AppRunnerOperations map[string][]model.AppRunnerOperation
+	DeliveryStreams     []model.DeliveryStream
+	UserPools           []model.UserPool
+	UserPoolDetails     map[string]*model.UserPoolDetails
+	CognitoUsers        map[string][]model.CognitoUser // Pool ID -> users
+	MSKClusters         []model.MSKCluster
+	MSKBrokers          map[string]*model.MSKBootstrapBrokers
+	MQBrokers           []model.MQBroker
+	Databases           []model.Database
+	FileSystems         []model.EFSFileSystem
+	FileSystemDetails   map[string]*model.EFSDetails // File system ID -> details
+	Schedules           []model.Schedule
+	Secrets             []model.Secret
+	SESAccount          *model.SESAccount
+	SESIdentities       []model.SESIdentity
+	SESConfigSets       []model.SESConfigurationSet
+	SESSuppressed       []model.SESSuppressedDestination
+	CloudResources      map[string][]model.CloudResource // Type name -> resources
+	Activity            []model.ActivityEvent
+	Health              *model.AccountHealth
+	Costs               *model.CostSummary
+	TerraformStates     map[string][]model.TerraformResource // Location -> resources
+	TaggedResources     map[string][]string                  // Tag filter as typed -> ARNs
+	OrgAccounts         []model.OrgAccount
+	Identity            *model.CallerIdentity
+
+	// Errors makes the named method fail, e.g. Errors["ListStacks"]
+	Errors map[string]error
+
+	// Account is the member account AssumeAccount switched to
+	Account string
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ aws.API = (*Client)(nil)
+
+// New returns an empty fake client for profile "fake" in us-east-1.
+func New() *Client {
+	return &Client{
+		ProfileName: "fake",
+		RegionName:  "us-east-1",
+		Errors:      make(map[string]error),
+	}
+}
+
+// Calls returns the methods called so far, in order.
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+// CallsTo returns the calls made to one method.
+func (c *Client) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range c.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// record logs a call and returns the error configured for the method.
+func (c *Client) record(method string, args ...any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+	return c.Errors[method]
+}
+
+// Profile returns the fake profile name.
+func (c *Client) Profile() string { return c.ProfileName }
+
+// Region returns the fake region.
+func (c *Client) Region() string { return c.RegionName }
+
+// ListStacks returns Stacks.
+func (c *Client) ListStacks(ctx context.Context) ([]model.Stack, error) {
+	if err := c.record("ListStacks"); err != nil {
+		return nil, err
+	}
+	return append([]model.Stack(nil), c.Stacks...), nil
+}
+
+// DescribeStack returns the stack of Stacks with the name, as described.
+func (c *Client) DescribeStack(ctx context.Context, stackName string) (*model.Stack, error) {
+	if err := c.record("DescribeStack", stackName); err != nil {
+		return nil, err
+	}
+	for _, s := range c.Stacks {
+		if s.Name == stackName {
+			s.Described = true
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("stack %s not found", stackName)
+}
+
+// GetStackEvents returns StackEvents of the stack.
+func (c *Client) GetStackEvents(ctx context.Context, stackName string) ([]model.StackEvent, error) {
+	if err := c.record("GetStackEvents", stackName); err != nil {
+		return nil, err
+	}
+	return append([]model.StackEvent(nil), c.StackEvents[stackName]...), nil
+}
+
+// GetTemplate returns StackTemplates of the stack.
+func (c *Client) GetTemplate(ctx context.Context, stackName string) (string, error) {
+	if err := c.record("GetTemplate", stackName); err != nil {
+		return "", err
+	}
+	return c.StackTemplates[stackName], nil
+}
+
+// SetTerminationProtection records the call and sets the protection of the
+// stack in Stacks.
+func (c *Client) SetTerminationProtection(ctx context.Context, stackName string, enabled bool) error {
+	if err := c.record("SetTerminationProtection", stackName, enabled); err != nil {
+		return err
+	}
+	for i := range c.Stacks {
+		if c.Stacks[i].Name == stackName {
+			c.Stacks[i].TerminationProtection = enabled
+		}
+	}
+	return nil
+}
+
+// SetStackPolicy records the call and sets the policy of the stack in
+// Stacks.
+func (c *Client) SetStackPolicy(ctx context.Context, stackName, policy string) error {
+	if err := c.record("SetStackPolicy", stackName, policy); err != nil {
+		return err
+	}
+	for i := range c.Stacks {
+		if c.Stacks[i].Name == stackName {
+			c.Stacks[i].StackPolicy = policy
+		}
+	}
+	return nil
+}
+
+// GetServicesForStack returns StackServices of the stack.
+func (c *Client) GetServicesForStack(ctx context.Context, stackName string) ([]model.Service, error) {
+	if err := c.record("GetServicesForStack", stackName); err != nil {
+		return nil, err
+	}
+	return append([]model.Service(nil), c.StackServices[stackName]...), nil
+}
+
+// GetLambdaFunctionsFromStack returns StackFunctions of the stack.
+func (c *Client) GetLambdaFunctionsFromStack(ctx context.Context, stackName string) ([]string, error) {
+	if err := c.record("GetLambdaFunctionsFromStack", stackName); err != nil {
+		return nil, err
+	}
+	return append([]string(nil), c.StackFunctions[stackName]...), nil
+}
+
+// GetQueuesFromStack returns StackQueues of the stack.
+func (c *Client) GetQueuesFromStack(ctx context.Context, stackName string) ([]string, error) {
+	if err := c.record("GetQueuesFromStack", stackName); err != nil {
+		return nil, err
+	}
+	return append([]string(nil), c.StackQueues[stackName]...), nil
+}
+
+// GetAlarmsFromStack returns StackAlarms of the stack.
+func (c *Client) GetAlarmsFromStack(ctx context.Context, stackName string) ([]string, error) {
+	if err := c.record("GetAlarmsFromStack", stackName); err != nil {
+		return nil, err
+	}
+	return append([]string(nil), c.StackAlarms[stackName]...), nil
+}
+
+// GetAPIGatewaysFromStack returns StackRestAPIs and StackHttpAPIs of the stack.
+func (c *Client) GetAPIGatewaysFromStack(ctx context.Context, stackName string) ([]string, []string, error) {
+	if err := c.record("GetAPIGatewaysFromStack", stackName); err != nil {
+		return nil, nil, err
+	}
+	return append([]string(nil), c.StackRestAPIs[stackName]...), append([]string(nil), c.StackHttpAPIs[stackName]...), nil
+}
+
+// ListClusters returns Clusters.
+func (c *Client) ListClusters(ctx context.Context) ([]model.Cluster, error) {
+	if err := c.record("ListClusters"); err != nil {
+		return nil, err
+	}
+	return append([]model.Cluster(nil), c.Clusters...), nil
+}
+
+// ListServices returns Services of the cluster.
+func (c *Client) ListServices(ctx context.Context, clusterARN string) ([]model.Service, error) {
+	if err := c.record("ListServices", clusterARN); err != nil {
+		return nil, err
+	}
+	return append([]model.Service(nil), c.Services[clusterARN]...), nil
+}
+
+// DescribeService returns the service of the cluster with the given name.
+func (c *Client) DescribeService(ctx context.Context, clusterARN, serviceName string) (*model.Service, error) {
+	if err := c.record("DescribeService", clusterARN, serviceName); err != nil {
+		return nil, err
+	}
+	for _, svc := range c.Services[clusterARN] {
+		if svc.Name == serviceName || svc.ARN == serviceName {
+			return &svc, nil
+		}
+	}
+	return nil, fmt.Errorf("service %s not found", serviceName)
+}
+
+// ListTasksForService returns Tasks of the service.
+func (c *Client) ListTasksForService(ctx context.Context, clusterARN, serviceName string) ([]model.Task, error) {
+	if err := c.record("ListTasksForService", clusterARN, serviceName); err != nil {
+		return nil, err
+	}
+	return append([]model.Task(nil), c.Tasks[serviceName]...), nil
+}
+
+// ListStoppedTasksForService returns StoppedTasks of the service.
+func (c *Client) ListStoppedTasksForService(ctx context.Context, clusterARN, serviceName string) ([]model.Task, error) {
+	if err := c.record("ListStoppedTasksForService", clusterARN, serviceName); err != nil {
+		return nil, err
+	}
+	return append([]model.Task(nil), c.StoppedTasks[serviceName]...), nil
+}
+
+// GetTaskDefinitionDocument returns TaskDefinitions of the task definition.
+func (c *Client) GetTaskDefinitionDocument(ctx context.Context, taskDef string) (string, error) {
+	if err := c.record("GetTaskDefinitionDocument", taskDef); err != nil {
+		return "", err
+	}
+	doc, ok := c.TaskDefinitions[taskDef]
+	if !ok {
+		return "", fmt.Errorf("task definition %s not found", taskDef)
+	}
+	return doc, nil
+}
+
+// GetContainerLogConfigs returns ContainerLogs of the task definition.
+func (c *Client) GetContainerLogConfigs(ctx context.Context, taskDefARN, taskID string) ([]model.ContainerLogConfig, error) {
+	if err := c.record("GetContainerLogConfigs", taskDefARN, taskID); err != nil {
+		return nil, err
+	}
+	return append([]model.ContainerLogConfig(nil), c.ContainerLogs[taskDefARN]...), nil
+}
+
+// GetServiceImages returns the ServiceImages of the services.
+func (c *Client) GetServiceImages(ctx context.Context, services []model.Service) ([]model.ServiceImage, error) {
+	names := make([]string, len(services))
+	for i, svc := range services {
+		names[i] = svc.Name
+	}
+	if err := c.record("GetServiceImages", names); err != nil {
+		return nil, err
+	}
+	var images []model.ServiceImage
+	for _, name := range names {
+		images = append(images, c.ServiceImages[name]...)
+	}
+	return images, nil
+}
+
+// GetServiceVulnerabilities returns the ImageScans of the service.
+func (c *Client) GetServiceVulnerabilities(ctx context.Context, svc model.Service) ([]model.ImageVulnerabilities, error) {
+	if err := c.record("GetServiceVulnerabilities", svc.Name); err != nil {
+		return nil, err
+	}
+	return c.ImageScans[svc.Name], nil
+}
+
+// GetClusterDashboard sums up the Services of the cluster, with the usage in
+// ClusterCPU and ClusterMemory.
+func (c *Client) GetClusterDashboard(ctx context.Context, cluster model.Cluster) (*model.ClusterDashboard, error) {
+	if err := c.record("GetClusterDashboard", cluster.ARN); err != nil {
+		return nil, err
+	}
+	d := model.SummarizeCluster(cluster, c.Services[cluster.ARN])
+	if u, ok := c.ClusterCPU[cluster.ARN]; ok {
+		d.CPU = u
+	}
+	if u, ok := c.ClusterMemory[cluster.ARN]; ok {
+		d.Memory = u
+	}
+	return d, nil
+}
+
+// StopTasks records the call and reports every task as stopped, leaving
+// Tasks unchanged.
+func (c *Client) StopTasks(ctx context.Context, clusterARN string, taskARNs []string, reason string) (int, error) {
+	if err := c.record("StopTasks", clusterARN, taskARNs, reason); err != nil {
+		return 0, err
+	}
+	return len(taskARNs), nil
+}
+
+// GetTaskProtection returns TaskProtection of the tasks listed there.
+func (c *Client) GetTaskProtection(ctx context.Context, clusterARN string, taskARNs []string) (map[string]model.TaskProtection, error) {
+	if err := c.record("GetTaskProtection", clusterARN, taskARNs); err != nil {
+		return nil, err
+	}
+	protection := make(map[string]model.TaskProtection)
+	for _, arn := range taskARNs {
+		if p, ok := c.TaskProtection[arn]; ok {
+			protection[arn] = p
+		}
+	}
+	return protection, nil
+}
+
+// UpdateTaskProtection records the call and returns the protection of the
+// tasks as updated, leaving TaskProtection unchanged.
+func (c *Client) UpdateTaskProtection(ctx context.Context, clusterARN string, taskARNs []string, enabled bool, expiresInMinutes int) ([]model.TaskProtection, error) {
+	if err := c.record("UpdateTaskProtection", clusterARN, taskARNs, enabled, expiresInMinutes); err != nil {
+		return nil, err
+	}
+	protection := make([]model.TaskProtection, len(taskARNs))
+	for i, arn := range taskARNs {
+		protection[i] = model.TaskProtection{TaskARN: arn, Enabled: enabled}
+		if enabled {
+			protection[i].ExpiresAt = time.Now().Add(time.Duration(expiresInMinutes) * time.Minute)
+		}
+	}
+	return protection, nil
+}
+
+// ListFunctionsPagedCallback passes Functions to callback in a single page.
+func (c *Client) ListFunctionsPagedCallback(ctx context.Context, callback func(functions []model.Function, hasMore bool) bool) error {
+	if err := c.record("ListFunctionsPagedCallback"); err != nil {
+		return err
+	}
+	callback(append([]model.Function(nil), c.Functions...), false)
+	return nil
+}
+
+// DescribeFunction returns the function with the given name.
+func (c *Client) DescribeFunction(ctx context.Context, functionName string) (*model.Function, error) {
+	if err := c.record("DescribeFunction", functionName); err != nil {
+		return nil, err
+	}
+	for _, fn := range c.Functions {
+		if fn.Name == functionName {
+			return &fn, nil
+		}
+	}
+	return nil, fmt.Errorf("function %s not found", functionName)
+}
+
+// DescribeFunctions returns the functions of Functions with the given names;
+// the others fail as not found.
+func (c *Client) DescribeFunctions(ctx context.Context, names []string) ([]model.Function, map[string]error) {
+	failed := make(map[string]error)
+	if err := c.record("DescribeFunctions", names); err != nil {
+		for _, name := range names {
+			failed[name] = err
+		}
+		return nil, failed
+	}
+	var functions []model.Function
+	for _, name := range names {
+		found := false
+		for _, fn := range c.Functions {
+			if fn.Name == name {
+				functions = append(functions, fn)
+				found = true
+				break
+			}
+		}
+		if !found {
+			failed[name] = fmt.Errorf("function %s not found", name)
+		}
+	}
+	return functions, failed
+}
+
+// ListAliases returns Aliases of the function.
+func (c *Client) ListAliases(ctx context.Context, functionName string) ([]model.LambdaAlias, error) {
+	if err := c.record("ListAliases", functionName); err != nil {
+		return nil, err
+	}
+	return append([]model.LambdaAlias(nil), c.Aliases[functionName]...), nil
+}
+
+// ListVersions returns Versions of the function.
+func (c *Client) ListVersions(ctx context.Context, functionName string) ([]string, error) {
+	if err := c.record("ListVersions", functionName); err != nil {
+		return nil, err
+	}
+	return append([]string(nil), c.Versions[functionName]...), nil
+}
+
+// ShiftAliasTraffic records the call and returns the alias of Aliases as
+// updated, leaving Aliases unchanged.
+func (c *Client) ShiftAliasTraffic(ctx context.Context, functionName, aliasName, version, routingVersion string, weight float64) (*model.LambdaAlias, error) {
+	if err := c.record("ShiftAliasTraffic", functionName, aliasName, version, routingVersion, weight); err != nil {
+		return nil, err
+	}
+	for _, a := range c.Aliases[functionName] {
+		if a.Name != aliasName {
+			continue
+		}
+		a.Version, a.RoutingVersion, a.RoutingWeight = version, "", 0
+		if weight > 0 {
+			a.RoutingVersion, a.RoutingWeight = routingVersion, weight
+		}
+		return &a, nil
+	}
+	return nil, fmt.Errorf("alias %s of %s not found", aliasName, functionName)
+}
+
+// GetLambdaPerformance returns Performance of the function, or none with no
+// invocations.
+func (c *Client) GetLambdaPerformance(ctx context.Context, fn model.Function, r model.TimeRange) (*model.LambdaPerformance, error) {
+	if err := c.record("GetLambdaPerformance", fn.Name, r); err != nil {
+		return nil, err
+	}
+	if p, ok := c.Performance[fn.Name]; ok {
+		return p, nil
+	}
+	return &model.LambdaPerformance{Function: fn.Name, Range: r, MemorySize: fn.MemorySize}, nil
+}
+
+// InvokeFunction returns Invocations of the function, or a 200 echoing payload.
+func (c *Client) InvokeFunction(ctx context.Context, functionName, payload string) (*model.InvocationResult, error) {
+	if err := c.record("InvokeFunction", functionName, payload); err != nil {
+		return nil, err
+	}
+	if result, ok := c.Invocations[functionName]; ok {
+		r := *result
+		return &r, nil
+	}
+	return &model.InvocationResult{
+		FunctionName:    functionName,
+		StatusCode:      200,
+		ExecutedVersion: "$LATEST",
+		Payload:         payload,
+		InvokedAt:       time.Now(),
+	}, nil
+}
+
+// ListRestAPIs returns RestAPIs.
+func (c *Client) ListRestAPIs(ctx context.Context) ([]model.RestAPI, error) {
+	if err := c.record("ListRestAPIs"); err != nil {
+		return nil, err
+	}
+	return append([]model.RestAPI(nil), c.RestAPIs...), nil
+}
+
+// GetRestAPI returns the REST API with the given ID.
+func (c *Client) GetRestAPI(ctx context.Context, apiID string) (*model.RestAPI, error) {
+	if err := c.record("GetRestAPI", apiID); err != nil {
+		return nil, err
+	}
+	for _, api := range c.RestAPIs {
+		if api.ID == apiID {
+			return &api, nil
+		}
+	}
+	return nil, fmt.Errorf("REST API %s not found", apiID)
+}
+
+// GetRestAPIStages returns Stages of the API.
+func (c *Client) GetRestAPIStages(ctx context.Context, apiID string) ([]model.APIStage, error) {
+	if err := c.record("GetRestAPIStages", apiID); err != nil {
+		return nil, err
+	}
+	return append([]model.APIStage(nil), c.Stages[apiID]...), nil
+}
+
+// ListRestAPIDeployments returns Deployments of the API.
+func (c *Client) ListRestAPIDeployments(ctx context.Context, apiID string) ([]model.APIDeployment, error) {
+	if err := c.record("ListRestAPIDeployments", apiID); err != nil {
+		return nil, err
+	}
+	return append([]model.APIDeployment(nil), c.Deployments[apiID]...), nil
+}
+
+// SetRestAPIStageDeployment records the call, leaving Stages unchanged.
+func (c *Client) SetRestAPIStageDeployment(ctx context.Context, apiID, stageName, deploymentID string) error {
+	return c.record("SetRestAPIStageDeployment", apiID, stageName, deploymentID)
+}
+
+// ListHttpAPIs returns HttpAPIs.
+func (c *Client) ListHttpAPIs(ctx context.Context) ([]model.HttpAPI, error) {
+	if err := c.record("ListHttpAPIs"); err != nil {
+		return nil, err
+	}
+	return append([]model.HttpAPI(nil), c.HttpAPIs...), nil
+}
+
+// GetHttpAPI returns the HTTP API with the given ID.
+func (c *Client) GetHttpAPI(ctx context.Context, apiID string) (*model.HttpAPI, error) {
+	if err := c.record("GetHttpAPI", apiID); err != nil {
+		return nil, err
+	}
+	for _, api := range c.HttpAPIs {
+		if api.ID == apiID {
+			return &api, nil
+		}
+	}
+	return nil, fmt.Errorf("HTTP API %s not found", apiID)
+}
+
+// GetHttpAPIStages returns Stages of the API.
+func (c *Client) GetHttpAPIStages(ctx context.Context, apiID string) ([]model.APIStage, error) {
+	if err := c.record("GetHttpAPIStages", apiID); err != nil {
+		return nil, err
+	}
+	return append([]model.APIStage(nil), c.Stages[apiID]...), nil
+}
+
+// GetRestAPIRoutes returns Routes of the API.
+func (c *Client) GetRestAPIRoutes(ctx context.Context, apiID string) ([]model.APIRoute, error) {
+	if err := c.record("GetRestAPIRoutes", apiID); err != nil {
+		return nil, err
+	}
+	return append([]model.APIRoute(nil), c.Routes[apiID]...), nil
+}
+
+// GetHttpAPIRoutes returns Routes of the API.
+func (c *Client) GetHttpAPIRoutes(ctx context.Context, apiID string) ([]model.APIRoute, error) {
+	if err := c.record("GetHttpAPIRoutes", apiID); err != nil {
+		return nil, err
+	}
+	return append([]model.APIRoute(nil), c.Routes[apiID]...), nil
+}
+
+// ListAPIGatewayVpcEndpoints returns VpcEndpoints.
+func (c *Client) ListAPIGatewayVpcEndpoints(ctx context.Context) (map[string]*model.VpcEndpoint, error) {
+	if err := c.record("ListAPIGatewayVpcEndpoints"); err != nil {
+		return nil, err
+	}
+	endpoints := make(map[string]*model.VpcEndpoint, len(c.VpcEndpoints))
+	for id, ep := range c.VpcEndpoints {
+		endpoints[id] = ep
+	}
+	return endpoints, nil
+}
+
+// ListQueuesPagedCallback passes Queues to callback in a single page.
+func (c *Client) ListQueuesPagedCallback(ctx context.Context, callback func(queues []model.Queue, hasMore bool) bool) error {
+	if err := c.record("ListQueuesPagedCallback"); err != nil {
+		return err
+	}
+	callback(append([]model.Queue(nil), c.Queues...), false)
+	return nil
+}
+
+// GetQueueAttributes returns the queue with the given URL.
+func (c *Client) GetQueueAttributes(ctx context.Context, queueURL string) (*model.Queue, error) {
+	if err := c.record("GetQueueAttributes", queueURL); err != nil {
+		return nil, err
+	}
+	for _, q := range c.Queues {
+		if q.URL == queueURL {
+			return &q, nil
+		}
+	}
+	return nil, fmt.Errorf("queue %s not found", queueURL)
+}
+
+// ReceiveMessages returns up to 10 Messages of the queue, leaving them in place.
+func (c *Client) ReceiveMessages(ctx context.Context, queueURL string, visibilityTimeout int32) ([]model.QueueMessage, error) {
+	if err := c.record("ReceiveMessages", queueURL, visibilityTimeout); err != nil {
+		return nil, err
+	}
+	messages := c.Messages[queueURL]
+	return append([]model.QueueMessage(nil), messages[:min(len(messages), 10)]...), nil
+}
+
+// SendMessage appends the message to Messages of the queue, with an ID
+// counting them.
+func (c *Client) SendMessage(ctx context.Context, queueURL, body, groupID, dedupID string) (string, error) {
+	if err := c.record("SendMessage", queueURL, body, groupID, dedupID); err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Messages == nil {
+		c.Messages = make(map[string][]model.QueueMessage)
+	}
+	id := fmt.Sprintf("message-%d", len(c.Messages[queueURL])+1)
+	c.Messages[queueURL] = append(c.Messages[queueURL], model.QueueMessage{ID: id, Body: body, SentAt: time.Now()})
+	return id, nil
+}
+
+// GetQueueRelations returns Relations of the queue, or none.
+func (c *Client) GetQueueRelations(ctx context.Context, queue model.Queue) (*model.QueueRelations, error) {
+	if err := c.record("GetQueueRelations", queue.ARN); err != nil {
+		return nil, err
+	}
+	if rel, ok := c.Relations[queue.ARN]; ok {
+		return rel, nil
+	}
+	return &model.QueueRelations{}, nil
+}
+
+// GetQueueFailures returns Failures of the queue, or its DLQ Messages with
+// no errors.
+func (c *Client) GetQueueFailures(ctx context.Context, queue model.Queue) (*model.QueueFailures, error) {
+	if err := c.record("GetQueueFailures", queue.ARN); err != nil {
+		return nil, err
+	}
+	if f, ok := c.Failures[queue.ARN]; ok {
+		return f, nil
+	}
+	f := &model.QueueFailures{Queue: queue, Since: time.Now().Add(-model.QueueFailureWindow)}
+	for _, msg := range c.Messages[queue.DLQURL] {
+		f.Messages = append(f.Messages, model.FailedMessage{Message: msg})
+	}
+	return f, nil
+}
+
+// ListTablesPagedCallback passes Tables to callback in a single page.
+func (c *Client) ListTablesPagedCallback(ctx context.Context, callback func(tables []model.Table, hasMore bool) bool) error {
+	if err := c.record("ListTablesPagedCallback"); err != nil {
+		return err
+	}
+	callback(append([]model.Table(nil), c.Tables...), false)
+	return nil
+}
+
+// QueryTable returns the Items of the table whose partition key matches.
+func (c *Client) QueryTable(ctx context.Context, params model.QueryParams, lastKey map[string]interface{}) (*model.QueryResult, error) {
+	if err := c.record("QueryTable", params, lastKey); err != nil {
+		return nil, err
+	}
+	var items []model.DynamoDBItem
+	for _, item := range c.Items[params.TableName] {
+		if fmt.Sprint(item.Raw[params.PartitionKeyName]) == params.PartitionKeyVal {
+			items = append(items, item)
+		}
+	}
+	return pageOf(items, params.Limit), nil
+}
+
+// ScanTable returns the Items of the table.
+func (c *Client) ScanTable(ctx context.Context, params model.ScanParams, lastKey map[string]interface{}) (*model.QueryResult, error) {
+	if err := c.record("ScanTable", params, lastKey); err != nil {
+		return nil, err
+	}
+	return pageOf(c.Items[params.TableName], params.Limit), nil
+}
+
+// PutItem records the call.
+func (c *Client) PutItem(ctx context.Context, tableName, item string) error {
+	return c.record("PutItem", tableName, item)
+}
+
+// DeleteItem records the call.
+func (c *Client) DeleteItem(ctx context.Context, tableName, key string) error {
+	return c.record("DeleteItem", tableName, key)
+}
+
+// pageOf returns items as a single page, cut at limit if set.
+func pageOf(items []model.DynamoDBItem, limit int32) *model.QueryResult {
+	scanned := len(items)
+	if limit > 0 && len(items) > int(limit) {
+		items = items[:limit]
+	}
+	return &model.QueryResult{
+		Items:        append([]model.DynamoDBItem(nil), items...),
+		Count:        len(items),
+		ScannedCount: scanned,
+	}
+}
+
+// FindJumpHost returns JumpHost, failing like the real client when there is none.
+func (c *Client) FindJumpHost(ctx context.Context, vpcID string, jumpHostConfig, jumpHostTagConfig string, defaultTags, defaultNames []string, preferredVPCs ...string) (*model.EC2Instance, error) {
+	if err := c.record("FindJumpHost", vpcID, jumpHostConfig, jumpHostTagConfig); err != nil {
+		return nil, err
+	}
+	if c.JumpHost == nil {
+		return nil, fmt.Errorf("no suitable jump host found in VPC %s", vpcID)
+	}
+	host := *c.JumpHost
+	return &host, nil
+}
+
+// ListInstances returns Instances.
+func (c *Client) ListInstances(ctx context.Context) ([]model.EC2Instance, error) {
+	if err := c.record("ListInstances"); err != nil {
+		return nil, err
+	}
+	return append([]model.EC2Instance(nil), c.Instances...), nil
+}
+
+// ListSSMManagedInstances returns Instances.
+func (c *Client) ListSSMManagedInstances(ctx context.Context) ([]model.EC2Instance, error) {
+	if err := c.record("ListSSMManagedInstances"); err != nil {
+		return nil, err
+	}
+	return append([]model.EC2Instance(nil), c.Instances...), nil
+}
+
+// GetSubnetVPC returns SubnetVPC of the subnet.
+func (c *Client) GetSubnetVPC(ctx context.Context, subnetID string) (string, error) {
+	if err := c.record("GetSubnetVPC", subnetID); err != nil {
+		return "", err
+	}
+	return c.SubnetVPC[subnetID], nil
+}
+
+// ListRegions returns Regions.
+func (c *Client) ListRegions(ctx context.Context) ([]string, error) {
+	if err := c.record("ListRegions"); err != nil {
+		return nil, err
+	}
+	return append([]string(nil), c.Regions...), nil
+}
+
+// RegionLatencies returns the Latencies of the regions.
+func (c *Client) RegionLatencies(ctx context.Context, regions []string) map[string]time.Duration {
+	latencies := make(map[string]time.Duration)
+	if err := c.record("RegionLatencies", regions); err != nil {
+		return latencies
+	}
+	for _, r := range regions {
+		if d, ok := c.Latencies[r]; ok {
+			latencies[r] = d
+		}
+	}
+	return latencies
+}
+
+// FetchLogs returns the LogEvents of the group from startTime on.
+func (c *Client) FetchLogs(ctx context.Context, logGroup, logStream string, startTime int64, limit int32) ([]model.CloudWatchLogEntry, int64, error) {
+	if err := c.record("FetchLogs", logGroup, logStream, startTime); err != nil {
+		return nil, startTime, err
+	}
+	return c.logsSince(logGroup, startTime, limit)
+}
+
+// FetchLogStreams returns the LogEvents of the group in the streams from
+// startTime on.
+func (c *Client) FetchLogStreams(ctx context.Context, logGroup string, logStreams []string, startTime int64, limit int32) ([]model.CloudWatchLogEntry, int64, error) {
+	if err := c.record("FetchLogStreams", logGroup, logStreams, startTime); err != nil {
+		return nil, startTime, err
+	}
+	entries, next, err := c.logsSince(logGroup, startTime, 0)
+	var inStreams []model.CloudWatchLogEntry
+	for _, e := range entries {
+		if slices.Contains(logStreams, e.LogStreamName) && (limit <= 0 || len(inStreams) < int(limit)) {
+			inStreams = append(inStreams, e)
+		}
+	}
+	return inStreams, next, err
+}
+
+// FetchLambdaLogs returns the LogEvents of the group from startTime on.
+func (c *Client) FetchLambdaLogs(ctx context.Context, logGroup string, startTime int64, limit int32) ([]model.CloudWatchLogEntry, int64, error) {
+	if err := c.record("FetchLambdaLogs", logGroup, startTime); err != nil {
+		return nil, startTime, err
+	}
+	return c.logsSince(logGroup, startTime, limit)
+}
+
+// logsSince returns the events of a group at or after start, and the time
+// to continue from.
+func (c *Client) logsSince(logGroup string, start int64, limit int32) ([]model.CloudWatchLogEntry, int64, error) {
+	var entries []model.CloudWatchLogEntry
+	next := start
+	for _, e := range c.LogEvents[logGroup] {
+		ts := e.Timestamp.UnixMilli()
+		if ts < start || (limit > 0 && len(entries) >= int(limit)) {
+			continue
+		}
+		entries = append(entries, e)
+		if ts+1 > next {
+			next = ts + 1
+		}
+	}
+	return entries, next, nil
+}
+
+// StackLogSources returns LogSources of the stack.
+func (c *Client) StackLogSources(ctx context.Context, stackName string) ([]model.LogSource, error) {
+	if err := c.record("StackLogSources", stackName); err != nil {
+		return nil, err
+	}
+	return append([]model.LogSource(nil), c.LogSources[stackName]...), nil
+}
+
+// SearchLogGroups returns the LogHits whose message contains pattern.
+func (c *Client) SearchLogGroups(ctx context.Context, sources []model.LogSource, pattern string, r model.TimeRange, limit int) ([]model.LogSearchHit, error) {
+	if err := c.record("SearchLogGroups", sources, pattern, r); err != nil {
+		return nil, err
+	}
+	var hits []model.LogSearchHit
+	for _, h := range c.LogHits {
+		if strings.Contains(h.Message, pattern) && len(hits) < limit {
+			hits = append(hits, h)
+		}
+	}
+	return hits, nil
+}
+
+// ListLogGroups returns LogGroups.
+func (c *Client) ListLogGroups(ctx context.Context) ([]model.LogGroup, error) {
+	if err := c.record("ListLogGroups"); err != nil {
+		return nil, err
+	}
+	return append([]model.LogGroup(nil), c.LogGroups...), nil
+}
+
+// SetLogGroupRetention records the call.
+func (c *Client) SetLogGroupRetention(ctx context.Context, name string, days int32) error {
+	return c.record("SetLogGroupRetention", name, days)
+}
+
+// DeleteLogGroup records the call.
+func (c *Client) DeleteLogGroup(ctx context.Context, name string) error {
+	return c.record("DeleteLogGroup", name)
+}
+
+// ListAlarms returns Alarms.
+func (c *Client) ListAlarms(ctx context.Context) ([]model.Alarm, error) {
+	if err := c.record("ListAlarms"); err != nil {
+		return nil, err
+	}
+	return append([]model.Alarm(nil), c.Alarms...), nil
+}
+
+// ListAppRunnerServices returns AppRunnerServices.
+func (c *Client) ListAppRunnerServices(ctx context.Context) ([]model.AppRunnerService, error) {
+	if err := c.record("ListAppRunnerServices"); err != nil {
+		return nil, err
+	}
+	return append([]model.AppRunnerService(nil), c.AppRunnerServices...), nil
+}
+
+// ListAppRunnerOperations returns AppRunnerOperations of the service.
+func (c *Client) ListAppRunnerOperations(ctx context.Context, serviceARN string, limit int) ([]model.AppRunnerOperation, error) {
+	if err := c.record("ListAppRunnerOperations", serviceARN); err != nil {
+		return nil, err
+	}
+	ops := c.AppRunnerOperations[serviceARN]
+	if limit > 0 && len(ops) > limit {
+		ops = ops[:limit]
+	}
+	return append([]model.AppRunnerOperation(nil), ops...), nil
+}
+
+// PauseAppRunnerService records the call.
+func (c *Client) PauseAppRunnerService(ctx context.Context, serviceARN string) error {
+	return c.record("PauseAppRunnerService", serviceARN)
+}
+
+// ResumeAppRunnerService records the call.
+func (c *Client) ResumeAppRunnerService(ctx context.Context, serviceARN string) error {
+	return c.record("ResumeAppRunnerService", serviceARN)
+}
+
+// StartAppRunnerDeployment records the call and returns a fixed operation ID.
+func (c *Client) StartAppRunnerDeployment(ctx context.Context, serviceARN string) (string, error) {
+	if err := c.record("StartAppRunnerDeployment", serviceARN); err != nil {
+		return "", err
+	}
+	return "fake-operation", nil
+}
+
+// ListDeliveryStreams returns DeliveryStreams.
+func (c *Client) ListDeliveryStreams(ctx context.Context) ([]model.DeliveryStream, error) {
+	if err := c.record("ListDeliveryStreams"); err != nil {
+		return nil, err
+	}
+	return append([]model.DeliveryStream(nil), c.DeliveryStreams...), nil
+}
+
+// FetchDeliveryErrors returns no errors.
+func (c *Client) FetchDeliveryErrors(ctx context.Context, stream *model.DeliveryStream, since time.Time, limit int32) ([]model.CloudWatchLogEntry, error) {
+	return nil, c.record("FetchDeliveryErrors", stream.Name, since)
+}
+
+// PutTestRecord records the call and returns a fixed record ID.
+func (c *Client) PutTestRecord(ctx context.Context, streamName, testID string) (string, error) {
+	if err := c.record("PutTestRecord", streamName, testID); err != nil {
+		return "", err
+	}
+	return "fake-record", nil
+}
+
+// ListUserPools returns UserPools.
+func (c *Client) ListUserPools(ctx context.Context) ([]model.UserPool, error) {
+	if err := c.record("ListUserPools"); err != nil {
+		return nil, err
+	}
+	return append([]model.UserPool(nil), c.UserPools...), nil
+}
+
+// GetUserPoolDetails returns UserPoolDetails of the pool.
+func (c *Client) GetUserPoolDetails(ctx context.Context, poolID string) (*model.UserPoolDetails, error) {
+	if err := c.record("GetUserPoolDetails", poolID); err != nil {
+		return nil, err
+	}
+	if d, ok := c.UserPoolDetails[poolID]; ok {
+		details := *d
+		return &details, nil
+	}
+	return &model.UserPoolDetails{PoolID: poolID}, nil
+}
+
+// SearchUsers returns the CognitoUsers of the pool whose username contains query.
+func (c *Client) SearchUsers(ctx context.Context, poolID, query string) ([]model.CognitoUser, error) {
+	if err := c.record("SearchUsers", poolID, query); err != nil {
+		return nil, err
+	}
+	var users []model.CognitoUser
+	for _, u := range c.CognitoUsers[poolID] {
+		if strings.Contains(u.Username, query) || strings.Contains(u.Email, query) {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+// ConfirmUser records the call.
+func (c *Client) ConfirmUser(ctx context.Context, poolID, username string) error {
+	return c.record("ConfirmUser", poolID, username)
+}
+
+// SetUserEnabled records the call.
+func (c *Client) SetUserEnabled(ctx context.Context, poolID, username string, enabled bool) error {
+	return c.record("SetUserEnabled", poolID, username, enabled)
+}
+
+// ListMSKClusters returns MSKClusters.
+func (c *Client) ListMSKClusters(ctx context.Context) ([]model.MSKCluster, error) {
+	if err := c.record("ListMSKClusters"); err != nil {
+		return nil, err
+	}
+	return append([]model.MSKCluster(nil), c.MSKClusters...), nil
+}
+
+// GetMSKBootstrapBrokers returns MSKBrokers of the cluster.
+func (c *Client) GetMSKBootstrapBrokers(ctx context.Context, clusterARN string) (*model.MSKBootstrapBrokers, error) {
+	if err := c.record("GetMSKBootstrapBrokers", clusterARN); err != nil {
+		return nil, err
+	}
+	if b, ok := c.MSKBrokers[clusterARN]; ok {
+		brokers := *b
+		return &brokers, nil
+	}
+	return nil, fmt.Errorf("cluster %s not found", clusterARN)
+}
+
+// ListDatabases returns Databases.
+func (c *Client) ListDatabases(ctx context.Context) ([]model.Database, error) {
+	if err := c.record("ListDatabases"); err != nil {
+		return nil, err
+	}
+	return append([]model.Database(nil), c.Databases...), nil
+}
+
+// ListMQBrokers returns MQBrokers.
+func (c *Client) ListMQBrokers(ctx context.Context) ([]model.MQBroker, error) {
+	if err := c.record("ListMQBrokers"); err != nil {
+		return nil, err
+	}
+	return append([]model.MQBroker(nil), c.MQBrokers...), nil
+}
+
+// ListEFSFileSystems returns FileSystems.
+func (c *Client) ListEFSFileSystems(ctx context.Context) ([]model.EFSFileSystem, error) {
+	if err := c.record("ListEFSFileSystems"); err != nil {
+		return nil, err
+	}
+	return append([]model.EFSFileSystem(nil), c.FileSystems...), nil
+}
+
+// GetEFSDetails returns FileSystemDetails of the file system, or empty details.
+func (c *Client) GetEFSDetails(ctx context.Context, fileSystemID string) (*model.EFSDetails, error) {
+	if err := c.record("GetEFSDetails", fileSystemID); err != nil {
+		return nil, err
+	}
+	if d, ok := c.FileSystemDetails[fileSystemID]; ok {
+		details := *d
+		return &details, nil
+	}
+	return &model.EFSDetails{FileSystemID: fileSystemID}, nil
+}
+
+// ListSchedules returns Schedules.
+func (c *Client) ListSchedules(ctx context.Context) ([]model.Schedule, error) {
+	if err := c.record("ListSchedules"); err != nil {
+		return nil, err
+	}
+	return append([]model.Schedule(nil), c.Schedules...), nil
+}
+
+// SetScheduleState records the call.
+func (c *Client) SetScheduleState(ctx context.Context, group, name string, state model.ScheduleState) error {
+	return c.record("SetScheduleState", group, name, state)
+}
+
+// RunScheduleNow records the call and returns the name of the one-time schedule.
+func (c *Client) RunScheduleNow(ctx context.Context, group, name string) (string, error) {
+	if err := c.record("RunScheduleNow", group, name); err != nil {
+		return "", err
+	}
+	return name + "-run", nil
+}
+
+// ListSecrets returns Secrets.
+func (c *Client) ListSecrets(ctx context.Context) ([]model.Secret, error) {
+	if err := c.record("ListSecrets"); err != nil {
+		return nil, err
+	}
+	return append([]model.Secret(nil), c.Secrets...), nil
+}
+
+// RotateSecret records the call.
+func (c *Client) RotateSecret(ctx context.Context, secretARN string) error {
+	return c.record("RotateSecret", secretARN)
+}
+
+// GetSESAccount returns SESAccount, or an empty account.
+func (c *Client) GetSESAccount(ctx context.Context) (*model.SESAccount, error) {
+	if err := c.record("GetSESAccount"); err != nil {
+		return nil, err
+	}
+	if c.SESAccount == nil {
+		return &model.SESAccount{}, nil
+	}
+	account := *c.SESAccount
+	return &account, nil
+}
+
+// ListSESIdentities returns SESIdentities.
+func (c *Client) ListSESIdentities(ctx context.Context) ([]model.SESIdentity, error) {
+	if err := c.record("ListSESIdentities"); err != nil {
+		return nil, err
+	}
+	return append([]model.SESIdentity(nil), c.SESIdentities...), nil
+}
+
+// ListSESConfigurationSets returns SESConfigSets.
+func (c *Client) ListSESConfigurationSets(ctx context.Context) ([]model.SESConfigurationSet, error) {
+	if err := c.record("ListSESConfigurationSets"); err != nil {
+		return nil, err
+	}
+	return append([]model.SESConfigurationSet(nil), c.SESConfigSets...), nil
+}
+
+// ListSuppressedDestinations returns SESSuppressed, never truncated.
+func (c *Client) ListSuppressedDestinations(ctx context.Context) ([]model.SESSuppressedDestination, bool, error) {
+	if err := c.record("ListSuppressedDestinations"); err != nil {
+		return nil, false, err
+	}
+	return append([]model.SESSuppressedDestination(nil), c.SESSuppressed...), false, nil
+}
+
+// RemoveSuppressedDestination records the call.
+func (c *Client) RemoveSuppressedDestination(ctx context.Context, email string) error {
+	return c.record("RemoveSuppressedDestination", email)
+}
+
+// SendTestEmail records the call and returns a fixed message ID.
+func (c *Client) SendTestEmail(ctx context.Context, from, to string) (string, error) {
+	if err := c.record("SendTestEmail", from, to); err != nil {
+		return "", err
+	}
+	return "fake-message", nil
+}
+
+// ListResources returns CloudResources of the type.
+func (c *Client) ListResources(ctx context.Context, typeName string) ([]model.CloudResource, error) {
+	if err := c.record("ListResources", typeName); err != nil {
+		return nil, err
+	}
+	return append([]model.CloudResource(nil), c.CloudResources[typeName]...), nil
+}
+
+// GetResource returns the resource of the type with the given identifier.
+func (c *Client) GetResource(ctx context.Context, typeName, identifier string) (*model.CloudResource, error) {
+	if err := c.record("GetResource", typeName, identifier); err != nil {
+		return nil, err
+	}
+	for _, r := range c.CloudResources[typeName] {
+		if r.Identifier == identifier {
+			return &r, nil
+		}
+	}
+	return nil, fmt.Errorf("%s %s not found", typeName, identifier)
+}
+
+// ListResourceActivity returns Activity.
+func (c *Client) ListResourceActivity(ctx context.Context, names []string, r model.TimeRange, limit int) ([]model.ActivityEvent, error) {
+	if err := c.record("ListResourceActivity", names, r); err != nil {
+		return nil, err
+	}
+	events := c.Activity
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return append([]model.ActivityEvent(nil), events...), nil
+}
+
+// GetCostSummary returns Costs, or a summary without charges or budgets.
+func (c *Client) GetCostSummary(ctx context.Context) (*model.CostSummary, error) {
+	if err := c.record("GetCostSummary"); err != nil {
+		return nil, err
+	}
+	if c.Costs == nil {
+		return &model.CostSummary{}, nil
+	}
+	costs := *c.Costs
+	return &costs, nil
+}
+
+// GetTaggedResources returns the TaggedResources of the filter.
+func (c *Client) GetTaggedResources(ctx context.Context, filter model.TagFilter) ([]string, error) {
+	if err := c.record("GetTaggedResources", filter.String()); err != nil {
+		return nil, err
+	}
+	return c.TaggedResources[filter.String()], nil
+}
+
+// LoadTerraformState returns the TerraformStates of the location, or fails
+// as a missing file would.
+func (c *Client) LoadTerraformState(ctx context.Context, location string) ([]model.TerraformResource, error) {
+	if err := c.record("LoadTerraformState", location); err != nil {
+		return nil, err
+	}
+	resources, ok := c.TerraformStates[location]
+	if !ok {
+		return nil, fmt.Errorf("open %s: no such file or directory", location)
+	}
+	return append([]model.TerraformResource(nil), resources...), nil
+}
+
+// GetCallerIdentity returns Identity, or an identity in account
+// 123456789012.
+func (c *Client) GetCallerIdentity(ctx context.Context) (*model.CallerIdentity, error) {
+	if err := c.record("GetCallerIdentity"); err != nil {
+		return nil, err
+	}
+	if c.Identity == nil {
+		return &model.CallerIdentity{Account: "123456789012", ARN: "arn:aws:iam::123456789012:user/fake", UserID: "AIDAFAKE"}, nil
+	}
+	identity := *c.Identity
+	return &identity, nil
+}
+
+// ListOrganizationAccounts returns OrgAccounts.
+func (c *Client) ListOrganizationAccounts(ctx context.Context) ([]model.OrgAccount, error) {
+	if err := c.record("ListOrganizationAccounts"); err != nil {
+		return nil, err
+	}
+	return append([]model.OrgAccount(nil), c.OrgAccounts...), nil
+}
+
+// AssumeAccount returns the same client, now in the account, so tests can
+// keep inspecting its calls.
+func (c *Client) AssumeAccount(ctx context.Context, accountID, roleName string) (aws.API, error) {
+	if err := c.record("AssumeAccount", accountID, roleName); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.Account = accountID
+	c.mu.Unlock()
+	return c, nil
+}
+
+// AssumedAccount returns Account.
+func (c *Client) AssumedAccount() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Account
+}
+
+// CLIEnv returns nothing; the fake starts no AWS CLI.
+func (c *Client) CLIEnv(ctx context.Context) ([]string, error) {
+	return nil, nil
+}
+
+// GetAccountHealth returns Health, or a summary with nothing to report.
+func (c *Client) GetAccountHealth(ctx context.Context) *model.AccountHealth {
+	_ = c.record("GetAccountHealth")
+	if c.Health == nil {
+		return &model.AccountHealth{Errors: map[string]string{}, CheckedAt: time.Now()}
+	}
+	h := *c.Health
+	return &h
+}
@@ -0,0 +1,321 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	firehosetypes "github.com/aws/aws-sdk-go-v2/service/firehose/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// ListDeliveryStreams lists all Firehose delivery streams with their destination and buffering details.
+func (c *Client) ListDeliveryStreams(ctx context.Context) ([]model.DeliveryStream, error) {
+	log.Debug("Listing Firehose delivery streams...")
+
+	// ListDeliveryStreams has no paginator, so page by the last name seen
+	var names []string
+	input := &firehose.ListDeliveryStreamsInput{}
+	for page := 1; ; page++ {
+		out, err := c.firehose.ListDeliveryStreams(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list delivery streams: %w", err)
+		}
+		names = append(names, out.DeliveryStreamNames...)
+		reportProgress(ctx, "ListDeliveryStreams", "pages", page, 0)
+
+		if !aws.ToBool(out.HasMoreDeliveryStreams) || len(out.DeliveryStreamNames) == 0 {
+			break
+		}
+		input.ExclusiveStartDeliveryStreamName = aws.String(names[len(names)-1])
+	}
+
+	if len(names) == 0 {
+		log.Info("No Firehose delivery streams found")
+		return nil, nil
+	}
+
+	type streamResult struct {
+		index  int
+		stream model.DeliveryStream
+	}
+
+	results := make(chan streamResult, len(names))
+	sem := c.slots(ConcurrencyFirehose)
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			stream, err := c.DescribeDeliveryStream(ctx, name)
+			if err != nil {
+				// Keep the stream listed even if it could not be described
+				log.Warn("Failed to describe delivery stream: %v", err)
+				results <- streamResult{index: idx, stream: model.DeliveryStream{Name: name}}
+				return
+			}
+			results <- streamResult{index: idx, stream: *stream}
+		}(i, name)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	streams := make([]model.DeliveryStream, len(names))
+	described := 0
+	reportProgress(ctx, "DescribeDeliveryStream", "streams", 0, len(names))
+	for result := range results {
+		streams[result.index] = result.stream
+		described++
+		reportProgress(ctx, "DescribeDeliveryStream", "streams", described, len(names))
+	}
+
+	sort.Slice(streams, func(i, j int) bool {
+		return streams[i].Name < streams[j].Name
+	})
+
+	log.Info("Found %d Firehose delivery streams", len(streams))
+	return streams, nil
+}
+
+// DescribeDeliveryStream returns details for a single Firehose delivery stream.
+func (c *Client) DescribeDeliveryStream(ctx context.Context, name string) (*model.DeliveryStream, error) {
+	out, err := c.firehose.DescribeDeliveryStream(ctx, &firehose.DescribeDeliveryStreamInput{
+		DeliveryStreamName: aws.String(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe delivery stream %s: %w", name, err)
+	}
+
+	stream := convertDeliveryStream(out.DeliveryStreamDescription)
+	return &stream, nil
+}
+
+// FetchDeliveryErrors returns the delivery errors logged by a stream since the given time.
+// Firehose only logs errors when CloudWatch error logging is enabled on the destination.
+func (c *Client) FetchDeliveryErrors(ctx context.Context, stream *model.DeliveryStream, since time.Time, limit int32) ([]model.CloudWatchLogEntry, error) {
+	if !stream.HasErrorLogging() {
+		return nil, nil
+	}
+	log.Debug("Fetching delivery errors for %s from %s", stream.Name, stream.ErrorLogGroup)
+
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(stream.ErrorLogGroup),
+		StartTime:    aws.Int64(since.UnixMilli()),
+		Limit:        aws.Int32(limit),
+	}
+	if stream.ErrorLogStream != "" {
+		input.LogStreamNames = []string{stream.ErrorLogStream}
+	}
+
+	out, err := c.cwlogs.FilterLogEvents(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch delivery errors: %w", err)
+	}
+
+	entries := make([]model.CloudWatchLogEntry, 0, len(out.Events))
+	for _, event := range out.Events {
+		entries = append(entries, model.CloudWatchLogEntry{
+			Timestamp:     time.UnixMilli(aws.ToInt64(event.Timestamp)),
+			Message:       aws.ToString(event.Message),
+			IngestionTime: time.UnixMilli(aws.ToInt64(event.IngestionTime)),
+			LogStreamName: aws.ToString(event.LogStreamName),
+		})
+	}
+
+	// Newest first
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+	return entries, nil
+}
+
+// PutTestRecord sends a small JSON record to a delivery stream and returns its record ID.
+// testID is written into the payload so the record can be found at the destination.
+func (c *Client) PutTestRecord(ctx context.Context, streamName, testID string) (string, error) {
+	log.Info("Putting test record %s to delivery stream: %s", testID, streamName)
+
+	data, err := json.Marshal(map[string]interface{}{
+		"source":    "vaws",
+		"test":      true,
+		"test_id":   testID,
+		"stream":    streamName,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode test record: %w", err)
+	}
+
+	out, err := c.firehose.PutRecord(ctx, &firehose.PutRecordInput{
+		DeliveryStreamName: aws.String(streamName),
+		// Newline-delimited so the record stays separable in S3 objects
+		Record: &firehosetypes.Record{Data: append(data, '\n')},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to put test record: %w", err)
+	}
+	return aws.ToString(out.RecordId), nil
+}
+
+// convertDeliveryStream converts an SDK delivery stream description to our model.
+// Only the first destination is described; streams have a single destination.
+func convertDeliveryStream(d *firehosetypes.DeliveryStreamDescription) model.DeliveryStream {
+	stream := model.DeliveryStream{
+		Name:       aws.ToString(d.DeliveryStreamName),
+		ARN:        aws.ToString(d.DeliveryStreamARN),
+		Status:     model.DeliveryStreamStatus(d.DeliveryStreamStatus),
+		SourceType: string(d.DeliveryStreamType),
+		CreatedAt:  aws.ToTime(d.CreateTimestamp),
+		UpdatedAt:  aws.ToTime(d.LastUpdateTimestamp),
+	}
+	if d.FailureDescription != nil {
+		stream.FailureType = string(d.FailureDescription.Type)
+		stream.FailureDetails = aws.ToString(d.FailureDescription.Details)
+	}
+	if len(d.Destinations) == 0 {
+		return stream
+	}
+
+	dest := d.Destinations[0]
+	switch {
+	case dest.ExtendedS3DestinationDescription != nil:
+		s3 := dest.ExtendedS3DestinationDescription
+		stream.Destination = "S3"
+		stream.Target = s3BucketName(s3.BucketARN) + "/" + aws.ToString(s3.Prefix)
+		stream.Compression = string(s3.CompressionFormat)
+		if s3.BufferingHints != nil {
+			setBuffering(&stream, s3.BufferingHints.SizeInMBs, s3.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, s3.CloudWatchLoggingOptions)
+	case dest.S3DestinationDescription != nil:
+		s3 := dest.S3DestinationDescription
+		stream.Destination = "S3"
+		stream.Target = s3BucketName(s3.BucketARN) + "/" + aws.ToString(s3.Prefix)
+		stream.Compression = string(s3.CompressionFormat)
+		if s3.BufferingHints != nil {
+			setBuffering(&stream, s3.BufferingHints.SizeInMBs, s3.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, s3.CloudWatchLoggingOptions)
+	case dest.RedshiftDestinationDescription != nil:
+		rs := dest.RedshiftDestinationDescription
+		stream.Destination = "Redshift"
+		stream.Target = aws.ToString(rs.ClusterJDBCURL)
+		if rs.CopyCommand != nil {
+			stream.Target += " (" + aws.ToString(rs.CopyCommand.DataTableName) + ")"
+		}
+		// Redshift loads go through an intermediate S3 bucket, which sets the buffering
+		if s3 := rs.S3DestinationDescription; s3 != nil && s3.BufferingHints != nil {
+			setBuffering(&stream, s3.BufferingHints.SizeInMBs, s3.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, rs.CloudWatchLoggingOptions)
+	case dest.AmazonopensearchserviceDestinationDescription != nil:
+		domain := dest.AmazonopensearchserviceDestinationDescription
+		stream.Destination = "OpenSearch"
+		stream.Target = openSearchTarget(domain.DomainARN, domain.ClusterEndpoint, domain.IndexName)
+		if domain.BufferingHints != nil {
+			setBuffering(&stream, domain.BufferingHints.SizeInMBs, domain.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, domain.CloudWatchLoggingOptions)
+	case dest.AmazonOpenSearchServerlessDestinationDescription != nil:
+		domain := dest.AmazonOpenSearchServerlessDestinationDescription
+		stream.Destination = "OpenSearch Serverless"
+		stream.Target = openSearchTarget(nil, domain.CollectionEndpoint, domain.IndexName)
+		if domain.BufferingHints != nil {
+			setBuffering(&stream, domain.BufferingHints.SizeInMBs, domain.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, domain.CloudWatchLoggingOptions)
+	case dest.ElasticsearchDestinationDescription != nil:
+		es := dest.ElasticsearchDestinationDescription
+		stream.Destination = "Elasticsearch"
+		stream.Target = openSearchTarget(es.DomainARN, es.ClusterEndpoint, es.IndexName)
+		if es.BufferingHints != nil {
+			setBuffering(&stream, es.BufferingHints.SizeInMBs, es.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, es.CloudWatchLoggingOptions)
+	case dest.HttpEndpointDestinationDescription != nil:
+		endpoint := dest.HttpEndpointDestinationDescription
+		stream.Destination = "HTTP endpoint"
+		if ep := endpoint.EndpointConfiguration; ep != nil {
+			stream.Target = aws.ToString(ep.Url)
+			if name := aws.ToString(ep.Name); name != "" {
+				stream.Target = name + " (" + stream.Target + ")"
+			}
+		}
+		if endpoint.BufferingHints != nil {
+			setBuffering(&stream, endpoint.BufferingHints.SizeInMBs, endpoint.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, endpoint.CloudWatchLoggingOptions)
+	case dest.SplunkDestinationDescription != nil:
+		splunk := dest.SplunkDestinationDescription
+		stream.Destination = "Splunk"
+		stream.Target = aws.ToString(splunk.HECEndpoint)
+		if splunk.BufferingHints != nil {
+			setBuffering(&stream, splunk.BufferingHints.SizeInMBs, splunk.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, splunk.CloudWatchLoggingOptions)
+	case dest.SnowflakeDestinationDescription != nil:
+		sf := dest.SnowflakeDestinationDescription
+		stream.Destination = "Snowflake"
+		stream.Target = aws.ToString(sf.Database) + "." + aws.ToString(sf.Table)
+		if sf.BufferingHints != nil {
+			setBuffering(&stream, sf.BufferingHints.SizeInMBs, sf.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, sf.CloudWatchLoggingOptions)
+	case dest.IcebergDestinationDescription != nil:
+		ice := dest.IcebergDestinationDescription
+		stream.Destination = "Iceberg"
+		if ice.CatalogConfiguration != nil {
+			stream.Target = aws.ToString(ice.CatalogConfiguration.CatalogARN)
+		}
+		if ice.BufferingHints != nil {
+			setBuffering(&stream, ice.BufferingHints.SizeInMBs, ice.BufferingHints.IntervalInSeconds)
+		}
+		setErrorLogging(&stream, ice.CloudWatchLoggingOptions)
+	}
+
+	return stream
+}
+
+// setBuffering sets the stream's buffering hints.
+func setBuffering(stream *model.DeliveryStream, sizeMB, intervalSeconds *int32) {
+	stream.BufferSizeMB = int(aws.ToInt32(sizeMB))
+	stream.BufferInterval = int(aws.ToInt32(intervalSeconds))
+}
+
+// setErrorLogging sets where the stream logs delivery errors, if logging is enabled.
+func setErrorLogging(stream *model.DeliveryStream, opts *firehosetypes.CloudWatchLoggingOptions) {
+	if opts == nil || !aws.ToBool(opts.Enabled) {
+		return
+	}
+	stream.ErrorLogGroup = aws.ToString(opts.LogGroupName)
+	stream.ErrorLogStream = aws.ToString(opts.LogStreamName)
+}
+
+// s3BucketName returns the bucket name from an S3 bucket ARN.
+func s3BucketName(arn *string) string {
+	return strings.TrimPrefix(aws.ToString(arn), "arn:aws:s3:::")
+}
+
+// openSearchTarget describes an OpenSearch destination as domain/index.
+func openSearchTarget(domainARN, endpoint, index *string) string {
+	target := aws.ToString(endpoint)
+	if arn := aws.ToString(domainARN); arn != "" {
+		target = arn[strings.LastIndex(arn, "/")+1:]
+	}
+	return target + "/" + aws.ToString(index)
+}
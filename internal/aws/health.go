@@ -0,0 +1,148 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// CertificateExpiryWindow is how soon a certificate must expire to be
+// reported by the account health checks.
+const CertificateExpiryWindow = 30 * 24 * time.Hour
+
+// GetAccountHealth runs every account health check in parallel: failed
+// stacks, ECS services short of tasks, alarms firing, dead-letter queues
+// with messages and certificates about to expire. A check that fails, for
+// instance for lack of permissions, is recorded in Errors and the others
+// still report.
+func (c *Client) GetAccountHealth(ctx context.Context) *model.AccountHealth {
+	health := &model.AccountHealth{Errors: make(map[string]string), CheckedAt: time.Now()}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	checks := map[string]func(context.Context, *model.AccountHealth) error{
+		model.HealthStacks:       c.checkStacks,
+		model.HealthServices:     c.checkServices,
+		model.HealthAlarms:       c.checkAlarms,
+		model.HealthDLQs:         c.checkDLQs,
+		model.HealthCertificates: c.checkCertificates,
+	}
+	done := 0
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(context.Context, *model.AccountHealth) error) {
+			defer wg.Done()
+			var found model.AccountHealth
+			err := check(withoutProgress(ctx), &found)
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			reportProgress(ctx, "Checking account health", "checks", done, len(checks))
+			if err != nil {
+				log.Warn("Health check %s failed: %v", name, err)
+				health.Errors[name] = err.Error()
+				return
+			}
+			switch name {
+			case model.HealthStacks:
+				health.FailedStacks = found.FailedStacks
+			case model.HealthServices:
+				health.UnhealthyServices = found.UnhealthyServices
+			case model.HealthAlarms:
+				health.Alarms = found.Alarms
+			case model.HealthDLQs:
+				health.DLQs = found.DLQs
+			case model.HealthCertificates:
+				health.ExpiringCertificates = found.ExpiringCertificates
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return health
+}
+
+func (c *Client) checkStacks(ctx context.Context, h *model.AccountHealth) error {
+	stacks, err := c.ListStacks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range stacks {
+		if s.Status.IsFailed() {
+			h.FailedStacks = append(h.FailedStacks, s)
+		}
+	}
+	return nil
+}
+
+// checkServices lists the services of every cluster; clusters that can't
+// be listed are skipped so one of them doesn't hide the others.
+func (c *Client) checkServices(ctx context.Context, h *model.AccountHealth) error {
+	clusters, err := c.ListClusters(ctx)
+	if err != nil {
+		return err
+	}
+	for _, cluster := range clusters {
+		services, err := c.ListServices(ctx, cluster.ARN)
+		if err != nil {
+			log.Warn("Health check: failed to list services of cluster %s: %v", cluster.Name, err)
+			continue
+		}
+		for _, svc := range services {
+			if svc.RunningCount < svc.DesiredCount {
+				h.UnhealthyServices = append(h.UnhealthyServices, svc)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) checkAlarms(ctx context.Context, h *model.AccountHealth) error {
+	alarms, err := c.ListAlarms(ctx)
+	if err != nil {
+		return err
+	}
+	for _, a := range alarms {
+		if a.State == model.AlarmStateAlarm {
+			h.Alarms = append(h.Alarms, a)
+		}
+	}
+	return nil
+}
+
+func (c *Client) checkDLQs(ctx context.Context, h *model.AccountHealth) error {
+	queues, err := c.ListQueues(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range queues {
+		if queues[i].HasDLQMessages() {
+			h.DLQs = append(h.DLQs, queues[i])
+		}
+	}
+	return nil
+}
+
+// checkCertificates reports certificates expiring soon, soonest first. ACM
+// starts renewing eligible certificates 60 days before expiry, so one of
+// them showing up here means its renewal is stuck, usually on validation.
+func (c *Client) checkCertificates(ctx context.Context, h *model.AccountHealth) error {
+	certs, err := c.ListCertificates(ctx)
+	if err != nil {
+		return err
+	}
+	for _, cert := range certs {
+		if cert.ExpiresWithin(CertificateExpiryWindow) {
+			h.ExpiringCertificates = append(h.ExpiringCertificates, cert)
+		}
+	}
+	sort.Slice(h.ExpiringCertificates, func(i, j int) bool {
+		return h.ExpiringCertificates[i].NotAfter.Before(h.ExpiringCertificates[j].NotAfter)
+	})
+	return nil
+}
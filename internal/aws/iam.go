@@ -0,0 +1,198 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// iamAPIVersion is the version of the IAM Query API vaws speaks.
+const iamAPIVersion = "2010-05-08"
+
+// iamEndpoint returns the host of the IAM API and the region it signs for.
+// IAM is global, with one endpoint per partition.
+func (c *Client) iamEndpoint() (host, region string) {
+	switch {
+	case strings.HasPrefix(c.region, "cn-"):
+		return "iam.cn-north-1.amazonaws.com.cn", "cn-north-1"
+	case strings.HasPrefix(c.region, "us-gov-"):
+		return "iam.us-gov.amazonaws.com", "us-gov-west-1"
+	default:
+		return "iam.amazonaws.com", "us-east-1"
+	}
+}
+
+// callIAM sends action to the IAM Query API and decodes the XML response
+// into out. vaws has no IAM SDK client, and only reads role policies.
+func (c *Client) callIAM(ctx context.Context, action string, params url.Values, out any) error {
+	host, region := c.iamEndpoint()
+	return c.callQueryAt(ctx, host, "iam", region, iamAPIVersion, action, params, out)
+}
+
+// managedPolicies holds the documents of managed policies by ARN. They are
+// shared by many roles, so each is fetched once.
+type managedPolicies struct {
+	mu   sync.Mutex
+	docs map[string]string
+}
+
+func (p *managedPolicies) get(arn string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	doc, ok := p.docs[arn]
+	return doc, ok
+}
+
+func (p *managedPolicies) put(arn, doc string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.docs == nil {
+		p.docs = make(map[string]string)
+	}
+	p.docs[arn] = doc
+}
+
+// rolePolicies returns the policy documents of an IAM role: its inline
+// policies and the default versions of its attached managed policies.
+func (c *Client) rolePolicies(ctx context.Context, roleName string, managed *managedPolicies) ([]string, error) {
+	var docs []string
+
+	var inline struct {
+		Names []string `xml:"ListRolePoliciesResult>PolicyNames>member"`
+	}
+	if err := c.callIAM(ctx, "ListRolePolicies", url.Values{"RoleName": {roleName}}, &inline); err != nil {
+		return nil, err
+	}
+	for _, name := range inline.Names {
+		var out struct {
+			Document string `xml:"GetRolePolicyResult>PolicyDocument"`
+		}
+		if err := c.callIAM(ctx, "GetRolePolicy", url.Values{"RoleName": {roleName}, "PolicyName": {name}}, &out); err != nil {
+			return nil, err
+		}
+		docs = append(docs, out.Document)
+	}
+
+	var attached struct {
+		ARNs []string `xml:"ListAttachedRolePoliciesResult>AttachedPolicies>member>PolicyArn"`
+	}
+	if err := c.callIAM(ctx, "ListAttachedRolePolicies", url.Values{"RoleName": {roleName}}, &attached); err != nil {
+		return nil, err
+	}
+	for _, arn := range attached.ARNs {
+		if doc, ok := managed.get(arn); ok {
+			docs = append(docs, doc)
+			continue
+		}
+		var policy struct {
+			Version string `xml:"GetPolicyResult>Policy>DefaultVersionId"`
+		}
+		if err := c.callIAM(ctx, "GetPolicy", url.Values{"PolicyArn": {arn}}, &policy); err != nil {
+			return nil, err
+		}
+		var version struct {
+			Document string `xml:"GetPolicyVersionResult>PolicyVersion>Document"`
+		}
+		if err := c.callIAM(ctx, "GetPolicyVersion", url.Values{"PolicyArn": {arn}, "VersionId": {policy.Version}}, &version); err != nil {
+			return nil, err
+		}
+		managed.put(arn, version.Document)
+		docs = append(docs, version.Document)
+	}
+	return docs, nil
+}
+
+// policyStatement is a statement of an IAM policy document. Action,
+// Resource and Principal take a string or a list.
+type policyStatement struct {
+	Effect    string                           `json:"Effect"`
+	Action    stringList                       `json:"Action"`
+	Resource  stringList                       `json:"Resource"`
+	Principal json.RawMessage                  `json:"Principal"`
+	Condition map[string]map[string]stringList `json:"Condition"`
+}
+
+// stringList is a policy field that is either a string or a list of them.
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*l = stringList{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// policyStatements parses a policy document, URL-encoded as IAM returns it
+// or not. The Statement is a single statement or a list.
+func policyStatements(doc string) ([]policyStatement, error) {
+	if !strings.HasPrefix(strings.TrimSpace(doc), "{") {
+		unescaped, err := url.QueryUnescape(doc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy document: %w", err)
+		}
+		doc = unescaped
+	}
+	var raw struct {
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(doc), &raw); err != nil {
+		return nil, fmt.Errorf("invalid policy document: %w", err)
+	}
+	var statements []policyStatement
+	if err := json.Unmarshal(raw.Statement, &statements); err == nil {
+		return statements, nil
+	}
+	var one policyStatement
+	if err := json.Unmarshal(raw.Statement, &one); err != nil {
+		return nil, fmt.Errorf("invalid policy statement: %w", err)
+	}
+	return []policyStatement{one}, nil
+}
+
+// allows returns true if the statement allows action on resource. Deny
+// statements, NotAction and NotResource are not evaluated, so a true is a
+// likely permission rather than a proven one.
+func (s policyStatement) allows(action, resource string) bool {
+	if s.Effect != "Allow" {
+		return false
+	}
+	return matchesAny(s.Action, strings.ToLower(action), true) && (len(s.Resource) == 0 || matchesAny(s.Resource, resource, false))
+}
+
+// broad returns true if the statement covers resource only through "*",
+// as administrator and full access policies do, rather than naming it.
+func (s policyStatement) broad(resource string) bool {
+	for _, p := range s.Resource {
+		if p != "*" {
+			if ok, _ := path.Match(p, resource); ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesAny returns true if one of the IAM wildcard patterns matches s.
+// Actions compare without case, resources with.
+func matchesAny(patterns []string, s string, foldCase bool) bool {
+	for _, p := range patterns {
+		if foldCase {
+			p = strings.ToLower(p)
+		}
+		if ok, _ := path.Match(p, s); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -71,6 +72,48 @@ func (c *Client) DescribeFunction(ctx context.Context, functionName string) (*mo
 	return &fn, nil
 }
 
+// DescribeFunctions describes functions a few at a time, retrying those
+// throttled. Functions are returned in the order of names; those that still
+// couldn't be described are left out and returned by name with their error.
+func (c *Client) DescribeFunctions(ctx context.Context, names []string) ([]model.Function, map[string]error) {
+	type functionResult struct {
+		fn  *model.Function
+		err error
+	}
+
+	results := make([]functionResult, len(names))
+	sem := c.slots(ConcurrencyLambda)
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(idx int, functionName string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var r functionResult
+			r.err = withRetries(ctx, func() error {
+				r.fn, r.err = c.DescribeFunction(ctx, functionName)
+				return r.err
+			})
+			results[idx] = r
+		}(i, name)
+	}
+	wg.Wait()
+
+	var functions []model.Function
+	failed := make(map[string]error)
+	for i, r := range results {
+		if r.err != nil {
+			failed[names[i]] = r.err
+			continue
+		}
+		functions = append(functions, *r.fn)
+	}
+	return functions, failed
+}
+
 // InvokeFunction invokes a Lambda function with the given payload.
 // Returns the invocation result including response payload and execution metadata.
 func (c *Client) InvokeFunction(ctx context.Context, functionName, payload string) (*model.InvocationResult, error) {
@@ -113,6 +156,83 @@ func (c *Client) InvokeFunction(ctx context.Context, functionName, payload strin
 	return result, nil
 }
 
+// ListAliases returns the aliases of a Lambda function.
+func (c *Client) ListAliases(ctx context.Context, functionName string) ([]model.LambdaAlias, error) {
+	var aliases []model.LambdaAlias
+	paginator := lambda.NewListAliasesPaginator(c.lambda, &lambda.ListAliasesInput{
+		FunctionName: aws.String(functionName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list aliases of %s: %w", functionName, err)
+		}
+		for _, a := range page.Aliases {
+			aliases = append(aliases, convertAlias(a.Name, a.AliasArn, a.Description, a.FunctionVersion, a.RoutingConfig))
+		}
+	}
+	return aliases, nil
+}
+
+// ListVersions returns the published versions of a Lambda function, oldest
+// first, without $LATEST.
+func (c *Client) ListVersions(ctx context.Context, functionName string) ([]string, error) {
+	var versions []string
+	paginator := lambda.NewListVersionsByFunctionPaginator(c.lambda, &lambda.ListVersionsByFunctionInput{
+		FunctionName: aws.String(functionName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions of %s: %w", functionName, err)
+		}
+		for _, v := range page.Versions {
+			if version := aws.ToString(v.Version); version != "$LATEST" {
+				versions = append(versions, version)
+			}
+		}
+	}
+	return versions, nil
+}
+
+// ShiftAliasTraffic points an alias at version and sends weight (0 to 1) of
+// its invocations to routingVersion. A zero weight removes the routing, so
+// the alias sends everything to version.
+func (c *Client) ShiftAliasTraffic(ctx context.Context, functionName, aliasName, version, routingVersion string, weight float64) (*model.LambdaAlias, error) {
+	routing := &types.AliasRoutingConfiguration{AdditionalVersionWeights: map[string]float64{}}
+	if routingVersion != "" && weight > 0 {
+		routing.AdditionalVersionWeights[routingVersion] = weight
+	}
+	out, err := c.lambda.UpdateAlias(ctx, &lambda.UpdateAliasInput{
+		FunctionName:    aws.String(functionName),
+		Name:            aws.String(aliasName),
+		FunctionVersion: aws.String(version),
+		RoutingConfig:   routing,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update alias %s of %s: %w", aliasName, functionName, err)
+	}
+	alias := convertAlias(out.Name, out.AliasArn, out.Description, out.FunctionVersion, out.RoutingConfig)
+	return &alias, nil
+}
+
+// convertAlias converts the fields of an AWS Lambda alias to our model.
+// Lambda routes to at most one additional version.
+func convertAlias(name, arn, description, version *string, routing *types.AliasRoutingConfiguration) model.LambdaAlias {
+	alias := model.LambdaAlias{
+		Name:        aws.ToString(name),
+		ARN:         aws.ToString(arn),
+		Description: aws.ToString(description),
+		Version:     aws.ToString(version),
+	}
+	if routing != nil {
+		for v, w := range routing.AdditionalVersionWeights {
+			alias.RoutingVersion, alias.RoutingWeight = v, w
+		}
+	}
+	return alias
+}
+
 // convertFunction converts an AWS Lambda function configuration to our model.
 func convertFunction(fn types.FunctionConfiguration) model.Function {
 	return convertFunctionConfig(fn)
@@ -128,6 +248,7 @@ func convertFunctionConfig(fn types.FunctionConfiguration) model.Function {
 		MemorySize:  int(aws.ToInt32(fn.MemorySize)),
 		Timeout:     int(aws.ToInt32(fn.Timeout)),
 		CodeSize:    fn.CodeSize,
+		CodeSha256:  aws.ToString(fn.CodeSha256),
 		Description: aws.ToString(fn.Description),
 		Role:        aws.ToString(fn.Role),
 		PackageType: string(fn.PackageType),
@@ -0,0 +1,155 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+
+	"vaws/internal/model"
+)
+
+const (
+	// lambdaReportPattern matches the REPORT line the Lambda runtime logs
+	// after each invocation, in the text and the JSON log format.
+	lambdaReportPattern = `?"REPORT RequestId" ?"platform.report"`
+
+	// lambdaReportSample caps the REPORT lines read for cold starts and
+	// memory use.
+	lambdaReportSample = 2000
+)
+
+var (
+	// maxMemoryUsedPattern and initDurationPattern read a REPORT line, either
+	// "Max Memory Used: 70 MB" and "Init Duration: 152.31 ms" of the text
+	// format, or "maxMemoryUsedMB":70 and "initDurationMs":152.31 of JSON.
+	maxMemoryUsedPattern = regexp.MustCompile(`Max Memory Used: (\d+) MB|"maxMemoryUsedMB":\s*(\d+)`)
+	initDurationPattern  = regexp.MustCompile(`Init Duration: ([\d.]+) ms|"initDurationMs":\s*([\d.]+)`)
+)
+
+// GetLambdaPerformance returns the duration percentiles and invocations of a
+// function over a time range, and the cold starts and memory use of the
+// invocations whose REPORT lines it samples from the function's log group.
+// Sources that can't be read are reported in Warnings.
+func (c *Client) GetLambdaPerformance(ctx context.Context, fn model.Function, r model.TimeRange) (*model.LambdaPerformance, error) {
+	p := &model.LambdaPerformance{Function: fn.Name, Range: r, MemorySize: fn.MemorySize}
+	start, end := r.Bounds(time.Now())
+
+	if err := c.lambdaDurations(ctx, p, start, end); err != nil {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("Lambda metrics: %v", err))
+	}
+
+	src := model.LogSource{Resource: fn.Name, LogGroup: "/aws/lambda/" + fn.Name}
+	hits, err := c.searchLogGroup(ctx, src, lambdaReportPattern, start.UnixMilli(), end.UnixMilli(), lambdaReportSample)
+	if err != nil {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("REPORT lines of %s: %v", src.LogGroup, err))
+	}
+
+	var inits []float64
+	var memory []int
+	for _, hit := range hits {
+		mem, ok := submatch(maxMemoryUsedPattern, hit.Message)
+		if !ok {
+			continue
+		}
+		used, _ := strconv.Atoi(mem)
+		memory = append(memory, used)
+		if init, ok := submatch(initDurationPattern, hit.Message); ok {
+			d, _ := strconv.ParseFloat(init, 64)
+			inits = append(inits, d)
+		}
+	}
+
+	p.Sampled = len(memory)
+	p.ColdStarts = len(inits)
+	if len(inits) > 0 {
+		sort.Float64s(inits)
+		p.InitP50 = inits[percentileIndex(len(inits), 0.5)]
+		p.InitMax = inits[len(inits)-1]
+	}
+	if len(memory) > 0 {
+		sort.Ints(memory)
+		p.MemoryP95 = memory[percentileIndex(len(memory), 0.95)]
+		p.MemoryMax = memory[len(memory)-1]
+	}
+	return p, nil
+}
+
+// lambdaDurations sets the invocations and duration percentiles of a
+// function from start to end, in one datapoint each. The period is rounded
+// up to whole minutes, as CloudWatch requires.
+func (c *Client) lambdaDurations(ctx context.Context, p *model.LambdaPerformance, start, end time.Time) error {
+	minutes := max(int32((end.Sub(start)+time.Minute-1)/time.Minute), 1)
+	period := aws.Int32(minutes * 60)
+	metric := func(name string) *cwtypes.Metric {
+		return &cwtypes.Metric{
+			Namespace:  aws.String("AWS/Lambda"),
+			MetricName: aws.String(name),
+			Dimensions: []cwtypes.Dimension{{Name: aws.String("FunctionName"), Value: aws.String(p.Function)}},
+		}
+	}
+	queries := []cwtypes.MetricDataQuery{
+		{Id: aws.String("invocations"), MetricStat: &cwtypes.MetricStat{Metric: metric("Invocations"), Period: period, Stat: aws.String("Sum")}},
+	}
+	for _, stat := range []string{"p50", "p95", "p99"} {
+		queries = append(queries, cwtypes.MetricDataQuery{
+			Id:         aws.String(stat),
+			MetricStat: &cwtypes.MetricStat{Metric: metric("Duration"), Period: period, Stat: aws.String(stat)},
+		})
+	}
+
+	out, err := c.cw.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		MetricDataQueries: queries,
+		StartTime:         aws.Time(start),
+		EndTime:           aws.Time(end),
+	})
+	if err != nil {
+		return err
+	}
+	// The window may straddle two periods: invocations add up, percentiles
+	// are those of the latest, which comes first
+	for _, r := range out.MetricDataResults {
+		if len(r.Values) == 0 {
+			continue
+		}
+		v := r.Values[0]
+		switch aws.ToString(r.Id) {
+		case "invocations":
+			for _, v := range r.Values {
+				p.Invocations += v
+			}
+		case "p50":
+			p.P50, p.HasDurations = v, true
+		case "p95":
+			p.P95 = v
+		case "p99":
+			p.P99 = v
+		}
+	}
+	return nil
+}
+
+// submatch returns the first non-empty group of the first match of re in s.
+func submatch(re *regexp.Regexp, s string) (string, bool) {
+	m := re.FindStringSubmatch(s)
+	for _, g := range m[min(len(m), 1):] {
+		if g != "" {
+			return g, true
+		}
+	}
+	return "", false
+}
+
+// percentileIndex returns the index of the q quantile in n sorted values,
+// by the nearest-rank method.
+func percentileIndex(n int, q float64) int {
+	i := int(math.Ceil(float64(n)*q)) - 1
+	return max(0, min(i, n-1))
+}
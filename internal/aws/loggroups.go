@@ -0,0 +1,172 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// ListLogGroups lists the CloudWatch Logs log groups of the region, largest
+// first. Groups whose names follow the conventions of Lambda, API Gateway
+// execution logs or ECS Container Insights are matched to the resource that
+// writes to them, and marked orphaned if it no longer exists.
+func (c *Client) ListLogGroups(ctx context.Context) ([]model.LogGroup, error) {
+	log.Debug("Listing log groups...")
+
+	var groups []model.LogGroup
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(c.cwlogs, &cloudwatchlogs.DescribeLogGroupsInput{})
+	for page := 1; paginator.HasMorePages(); page++ {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list log groups: %w", err)
+		}
+		for _, g := range out.LogGroups {
+			groups = append(groups, convertLogGroup(g))
+		}
+		reportProgress(ctx, "DescribeLogGroups", "pages", page, 0)
+	}
+
+	c.markOrphanedLogGroups(ctx, groups)
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].StoredBytes != groups[j].StoredBytes {
+			return groups[i].StoredBytes > groups[j].StoredBytes
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	log.Info("Found %d log groups", len(groups))
+	return groups, nil
+}
+
+// convertLogGroup converts a CloudWatch Logs log group to a model.LogGroup.
+func convertLogGroup(g cwltypes.LogGroup) model.LogGroup {
+	group := model.LogGroup{
+		Name:              aws.ToString(g.LogGroupName),
+		ARN:               aws.ToString(g.LogGroupArn),
+		StoredBytes:       aws.ToInt64(g.StoredBytes),
+		RetentionDays:     aws.ToInt32(g.RetentionInDays),
+		Class:             string(g.LogGroupClass),
+		MetricFilters:     aws.ToInt32(g.MetricFilterCount),
+		DeletionProtected: aws.ToBool(g.DeletionProtectionEnabled),
+	}
+	if group.ARN == "" {
+		group.ARN = strings.TrimSuffix(aws.ToString(g.Arn), ":*")
+	}
+	if ms := aws.ToInt64(g.CreationTime); ms > 0 {
+		group.CreatedAt = time.UnixMilli(ms)
+	}
+	return group
+}
+
+// logGroupOwner returns the kind and name of the resource a log group's name
+// says writes to it, e.g. "lambda" and "orders" for /aws/lambda/orders, or
+// empty strings if the name follows no known convention.
+func logGroupOwner(name string) (kind, owner string) {
+	switch {
+	case strings.HasPrefix(name, "/aws/lambda/"):
+		return "lambda", strings.TrimPrefix(name, "/aws/lambda/")
+	case strings.HasPrefix(name, "API-Gateway-Execution-Logs_"):
+		api, _, _ := strings.Cut(strings.TrimPrefix(name, "API-Gateway-Execution-Logs_"), "/")
+		return "apigateway", api
+	case strings.HasPrefix(name, "/aws/ecs/containerinsights/"):
+		cluster, _, _ := strings.Cut(strings.TrimPrefix(name, "/aws/ecs/containerinsights/"), "/")
+		return "ecs", cluster
+	}
+	return "", ""
+}
+
+// markOrphanedLogGroups sets the owner of the groups that have one, and
+// marks them orphaned if it is gone. Owners whose resources can't be listed
+// are set but never marked, so a missing permission doesn't flag live
+// groups for deletion.
+func (c *Client) markOrphanedLogGroups(ctx context.Context, groups []model.LogGroup) {
+	kinds := make(map[string]bool)
+	for i := range groups {
+		kind, owner := logGroupOwner(groups[i].Name)
+		if kind == "" {
+			continue
+		}
+		kinds[kind] = true
+		groups[i].Owner = kind + " " + owner
+	}
+	if len(kinds) == 0 {
+		return
+	}
+
+	existing := make(map[string]map[string]bool) // Kind -> names, for kinds that could be listed
+	if kinds["lambda"] {
+		if functions, err := c.ListFunctions(ctx); err != nil {
+			log.Warn("Not checking Lambda log groups for orphans: %v", err)
+		} else {
+			existing["lambda"] = make(map[string]bool)
+			for _, fn := range functions {
+				existing["lambda"][fn.Name] = true
+			}
+		}
+	}
+	if kinds["apigateway"] {
+		if apis, err := c.ListRestAPIs(ctx); err != nil {
+			log.Warn("Not checking API Gateway log groups for orphans: %v", err)
+		} else {
+			existing["apigateway"] = make(map[string]bool)
+			for _, api := range apis {
+				existing["apigateway"][api.ID] = true
+			}
+		}
+	}
+	if kinds["ecs"] {
+		if clusters, err := c.ListClusters(ctx); err != nil {
+			log.Warn("Not checking Container Insights log groups for orphans: %v", err)
+		} else {
+			existing["ecs"] = make(map[string]bool)
+			for _, cl := range clusters {
+				existing["ecs"][cl.Name] = true
+			}
+		}
+	}
+
+	for i := range groups {
+		kind, owner := logGroupOwner(groups[i].Name)
+		if names, ok := existing[kind]; ok && !names[owner] {
+			groups[i].Orphaned = true
+		}
+	}
+}
+
+// SetLogGroupRetention sets how many days a log group keeps its events, one
+// of model.LogRetentionDays, or 0 to keep them forever.
+func (c *Client) SetLogGroupRetention(ctx context.Context, name string, days int32) error {
+	if days == 0 {
+		_, err := c.cwlogs.DeleteRetentionPolicy(ctx, &cloudwatchlogs.DeleteRetentionPolicyInput{LogGroupName: aws.String(name)})
+		if err != nil {
+			return fmt.Errorf("failed to remove retention of %s: %w", name, err)
+		}
+		return nil
+	}
+	_, err := c.cwlogs.PutRetentionPolicy(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
+		LogGroupName:    aws.String(name),
+		RetentionInDays: aws.Int32(days),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set retention of %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteLogGroup deletes a log group and all of its events.
+func (c *Client) DeleteLogGroup(ctx context.Context, name string) error {
+	log.Info("Deleting log group %s", name)
+	if _, err := c.cwlogs.DeleteLogGroup(ctx, &cloudwatchlogs.DeleteLogGroupInput{LogGroupName: aws.String(name)}); err != nil {
+		return fmt.Errorf("failed to delete log group %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+const (
+	// logSearchMaxPages caps the pages read per log group, since a pattern
+	// that matches little can make FilterLogEvents scan for a long time.
+	logSearchMaxPages = 10
+)
+
+// StackLogSources returns the log groups written to by a stack: the awslogs
+// groups of its ECS services' containers, the groups of its Lambda functions
+// and the log groups it defines itself. Each group is listed once.
+func (c *Client) StackLogSources(ctx context.Context, stackName string) ([]model.LogSource, error) {
+	var sources []model.LogSource
+	seen := make(map[string]bool)
+	add := func(resource, group string) {
+		if group == "" || seen[group] {
+			return
+		}
+		seen[group] = true
+		sources = append(sources, model.LogSource{Resource: resource, LogGroup: group})
+	}
+
+	services, err := c.GetServicesForStack(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range services {
+		if svc.TaskDefinition == "" {
+			continue
+		}
+		for _, cd := range c.getContainerDefinitions(ctx, svc.TaskDefinition) {
+			if cd.LogConfiguration == nil || cd.LogConfiguration.LogDriver != ecstypes.LogDriverAwslogs {
+				continue
+			}
+			add(svc.Name+"/"+aws.ToString(cd.Name), cd.LogConfiguration.Options["awslogs-group"])
+		}
+	}
+
+	functions, err := c.GetLambdaFunctionsFromStack(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range functions {
+		add(fn, "/aws/lambda/"+fn)
+	}
+
+	groups, err := c.GetStackResources(ctx, stackName, "AWS::Logs::LogGroup")
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range groups {
+		add(aws.ToString(r.LogicalResourceId), aws.ToString(r.PhysicalResourceId))
+	}
+
+	log.Debug("Found %d log groups in stack %s", len(sources), stackName)
+	return sources, nil
+}
+
+// SearchLogGroups runs a CloudWatch Logs filter pattern over a time range
+// of every source concurrently and returns the matches merged, newest first,
+// capped at limit. Groups that don't exist yet, such as those of functions
+// never invoked, are skipped; other per-group failures are logged, and an
+// error is only returned if no group could be searched.
+func (c *Client) SearchLogGroups(ctx context.Context, sources []model.LogSource, pattern string, r model.TimeRange, limit int) ([]model.LogSearchHit, error) {
+	from, to := r.Bounds(time.Now())
+	start, end := from.UnixMilli(), to.UnixMilli()
+
+	var (
+		mu       sync.Mutex
+		hits     []model.LogSearchHit
+		failures int
+		firstErr error
+		done     int
+		wg       sync.WaitGroup
+	)
+	sem := c.slots(ConcurrencyLogs)
+	reportProgress(ctx, "Searching log groups", "groups", 0, len(sources))
+
+	for _, src := range sources {
+		wg.Add(1)
+		go func(src model.LogSource) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			found, err := c.searchLogGroup(ctx, src, pattern, start, end, limit)
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			reportProgress(ctx, "Searching log groups", "groups", done, len(sources))
+			var notFound *cwltypes.ResourceNotFoundException
+			switch {
+			case errors.As(err, &notFound):
+				log.Debug("Log group %s does not exist, skipping", src.LogGroup)
+			case err != nil:
+				log.Warn("Failed to search log group %s: %v", src.LogGroup, err)
+				failures++
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+			hits = append(hits, found...)
+		}(src)
+	}
+	wg.Wait()
+
+	if failures > 0 && failures == len(sources) {
+		return nil, fmt.Errorf("failed to search log groups: %w", firstErr)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		return hits[i].Timestamp.After(hits[j].Timestamp)
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// searchLogGroup returns up to limit events of one log group matching pattern
+// from start, up to end unless it is 0.
+// Events come back oldest first, so on a busy group the matches at the end
+// of the range may be cut; narrowing the range or the pattern brings them in.
+func (c *Client) searchLogGroup(ctx context.Context, src model.LogSource, pattern string, start, end int64, limit int) ([]model.LogSearchHit, error) {
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(src.LogGroup),
+		StartTime:    aws.Int64(start),
+	}
+	if end > 0 {
+		input.EndTime = aws.Int64(end)
+	}
+	if pattern != "" {
+		input.FilterPattern = aws.String(pattern)
+	}
+
+	var hits []model.LogSearchHit
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(c.cwlogs, input)
+	for page := 0; paginator.HasMorePages() && page < logSearchMaxPages && len(hits) < limit; page++ {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return hits, err
+		}
+		for _, e := range out.Events {
+			hits = append(hits, model.LogSearchHit{
+				ID:            src.LogGroup + ":" + aws.ToString(e.EventId), // Event IDs are only unique within a group
+				Source:        src,
+				Timestamp:     time.UnixMilli(aws.ToInt64(e.Timestamp)),
+				Message:       aws.ToString(e.Message),
+				LogStreamName: aws.ToString(e.LogStreamName),
+			})
+		}
+	}
+	return hits, nil
+}
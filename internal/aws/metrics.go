@@ -0,0 +1,25 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+
+	"vaws/internal/metrics"
+)
+
+// addCallMetrics adds a middleware recording the duration of every SDK call
+// to the metrics registry. It goes after the service metadata is set, and
+// before the retry loop so that retries count towards the duration.
+func addCallMetrics(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("vawsCallMetrics", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+	) (middleware.InitializeOutput, middleware.Metadata, error) {
+		start := time.Now()
+		out, md, err := next.HandleInitialize(ctx, in)
+		metrics.Default().ObserveAWSCall(awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx), time.Since(start), err)
+		return out, md, err
+	}), middleware.After)
+}
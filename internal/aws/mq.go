@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// mqBrokerSummary is a broker of the Amazon MQ ListBrokers response.
+type mqBrokerSummary struct {
+	BrokerArn        string    `json:"brokerArn"`
+	BrokerID         string    `json:"brokerId"`
+	BrokerName       string    `json:"brokerName"`
+	BrokerState      string    `json:"brokerState"`
+	DeploymentMode   string    `json:"deploymentMode"`
+	EngineType       string    `json:"engineType"`
+	HostInstanceType string    `json:"hostInstanceType"`
+	Created          time.Time `json:"created"`
+}
+
+// mqBroker is the Amazon MQ DescribeBroker response.
+type mqBroker struct {
+	mqBrokerSummary
+	EngineVersion      string   `json:"engineVersion"`
+	PubliclyAccessible bool     `json:"publiclyAccessible"`
+	SubnetIds          []string `json:"subnetIds"`
+	SecurityGroups     []string `json:"securityGroups"`
+	BrokerInstances    []struct {
+		ConsoleURL string   `json:"consoleURL"`
+		Endpoints  []string `json:"endpoints"`
+		IPAddress  string   `json:"ipAddress"`
+	} `json:"brokerInstances"`
+}
+
+// ListMQBrokers lists all Amazon MQ brokers with their endpoints.
+func (c *Client) ListMQBrokers(ctx context.Context) ([]model.MQBroker, error) {
+	log.Debug("Listing Amazon MQ brokers...")
+
+	var summaries []mqBrokerSummary
+	query := url.Values{"maxResults": {"100"}}
+	for page := 1; ; page++ {
+		var out struct {
+			BrokerSummaries []mqBrokerSummary `json:"brokerSummaries"`
+			NextToken       string            `json:"nextToken"`
+		}
+		if err := c.callREST(ctx, "mq", "GET", "/v1/brokers", query, nil, &out); err != nil {
+			return nil, fmt.Errorf("failed to list MQ brokers: %w", err)
+		}
+		summaries = append(summaries, out.BrokerSummaries...)
+		reportProgress(ctx, "ListBrokers", "pages", page, 0)
+
+		if out.NextToken == "" {
+			break
+		}
+		query.Set("nextToken", out.NextToken)
+	}
+
+	if len(summaries) == 0 {
+		log.Info("No MQ brokers found")
+		return nil, nil
+	}
+
+	type brokerResult struct {
+		index  int
+		broker model.MQBroker
+	}
+
+	results := make(chan brokerResult, len(summaries))
+	sem := c.slots(ConcurrencyMQ)
+
+	var wg sync.WaitGroup
+	for i, summary := range summaries {
+		wg.Add(1)
+		go func(idx int, summary mqBrokerSummary) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var out mqBroker
+			if err := c.callREST(ctx, "mq", "GET", "/v1/brokers/"+url.PathEscape(summary.BrokerID), nil, nil, &out); err != nil {
+				// Fall back to the summary so the broker is still listed
+				log.Warn("Failed to describe MQ broker %s: %v", summary.BrokerName, err)
+				results <- brokerResult{index: idx, broker: convertMQBroker(mqBroker{mqBrokerSummary: summary})}
+				return
+			}
+			results <- brokerResult{index: idx, broker: convertMQBroker(out)}
+		}(i, summary)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	brokers := make([]model.MQBroker, len(summaries))
+	described := 0
+	reportProgress(ctx, "DescribeBroker", "brokers", 0, len(summaries))
+	for result := range results {
+		brokers[result.index] = result.broker
+		described++
+		reportProgress(ctx, "DescribeBroker", "brokers", described, len(summaries))
+	}
+
+	sort.Slice(brokers, func(i, j int) bool {
+		return brokers[i].Name < brokers[j].Name
+	})
+
+	log.Info("Found %d MQ brokers", len(brokers))
+	return brokers, nil
+}
+
+// convertMQBroker converts an Amazon MQ API broker to our model.
+func convertMQBroker(b mqBroker) model.MQBroker {
+	broker := model.MQBroker{
+		ID:             b.BrokerID,
+		Name:           b.BrokerName,
+		ARN:            b.BrokerArn,
+		State:          model.MQBrokerState(b.BrokerState),
+		Engine:         b.EngineType,
+		EngineVersion:  b.EngineVersion,
+		InstanceType:   b.HostInstanceType,
+		DeploymentMode: b.DeploymentMode,
+		Public:         b.PubliclyAccessible,
+		Subnets:        b.SubnetIds,
+		SecurityGroups: b.SecurityGroups,
+		CreatedAt:      b.Created,
+	}
+	for _, inst := range b.BrokerInstances {
+		broker.Instances = append(broker.Instances, model.MQBrokerInstance{
+			ConsoleURL: inst.ConsoleURL,
+			Endpoints:  inst.Endpoints,
+			IP:         inst.IPAddress,
+		})
+	}
+	return broker
+}
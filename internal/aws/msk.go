@@ -0,0 +1,159 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// mskCluster is a cluster of the MSK ListClustersV2 response.
+type mskCluster struct {
+	ClusterArn   string    `json:"clusterArn"`
+	ClusterName  string    `json:"clusterName"`
+	ClusterType  string    `json:"clusterType"`
+	State        string    `json:"state"`
+	CreationTime time.Time `json:"creationTime"`
+	Provisioned  *struct {
+		BrokerNodeGroupInfo struct {
+			ClientSubnets  []string `json:"clientSubnets"`
+			InstanceType   string   `json:"instanceType"`
+			SecurityGroups []string `json:"securityGroups"`
+		} `json:"brokerNodeGroupInfo"`
+		CurrentBrokerSoftwareInfo struct {
+			KafkaVersion string `json:"kafkaVersion"`
+		} `json:"currentBrokerSoftwareInfo"`
+		NumberOfBrokerNodes  int                  `json:"numberOfBrokerNodes"`
+		ClientAuthentication *mskClientAuthConfig `json:"clientAuthentication"`
+	} `json:"provisioned"`
+	Serverless *struct {
+		VpcConfigs []struct {
+			SubnetIds        []string `json:"subnetIds"`
+			SecurityGroupIds []string `json:"securityGroupIds"`
+		} `json:"vpcConfigs"`
+		ClientAuthentication *mskClientAuthConfig `json:"clientAuthentication"`
+	} `json:"serverless"`
+}
+
+// mskClientAuthConfig is the client authentication of an MSK cluster.
+type mskClientAuthConfig struct {
+	Sasl *struct {
+		Iam *struct {
+			Enabled bool `json:"enabled"`
+		} `json:"iam"`
+		Scram *struct {
+			Enabled bool `json:"enabled"`
+		} `json:"scram"`
+	} `json:"sasl"`
+	TLS *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"tls"`
+	Unauthenticated *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"unauthenticated"`
+}
+
+// ListMSKClusters lists all MSK clusters, provisioned and serverless.
+func (c *Client) ListMSKClusters(ctx context.Context) ([]model.MSKCluster, error) {
+	log.Debug("Listing MSK clusters...")
+
+	var clusters []model.MSKCluster
+	query := url.Values{"maxResults": {"100"}}
+	for page := 1; ; page++ {
+		var out struct {
+			ClusterInfoList []mskCluster `json:"clusterInfoList"`
+			NextToken       string       `json:"nextToken"`
+		}
+		if err := c.callREST(ctx, "kafka", "GET", "/api/v2/clusters", query, nil, &out); err != nil {
+			return nil, fmt.Errorf("failed to list MSK clusters: %w", err)
+		}
+		for _, cl := range out.ClusterInfoList {
+			clusters = append(clusters, convertMSKCluster(cl))
+		}
+		reportProgress(ctx, "ListClustersV2", "pages", page, 0)
+
+		if out.NextToken == "" {
+			break
+		}
+		query.Set("nextToken", out.NextToken)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	log.Info("Found %d MSK clusters", len(clusters))
+	return clusters, nil
+}
+
+// GetMSKBootstrapBrokers returns the bootstrap broker strings of a cluster.
+func (c *Client) GetMSKBootstrapBrokers(ctx context.Context, clusterARN string) (*model.MSKBootstrapBrokers, error) {
+	log.Debug("Getting bootstrap brokers for %s...", clusterARN)
+
+	var out struct {
+		BootstrapBrokerString          string `json:"bootstrapBrokerString"`
+		BootstrapBrokerStringTls       string `json:"bootstrapBrokerStringTls"`
+		BootstrapBrokerStringSaslScram string `json:"bootstrapBrokerStringSaslScram"`
+		BootstrapBrokerStringSaslIam   string `json:"bootstrapBrokerStringSaslIam"`
+	}
+	path := "/v1/clusters/" + url.PathEscape(clusterARN) + "/bootstrap-brokers"
+	if err := c.callREST(ctx, "kafka", "GET", path, nil, nil, &out); err != nil {
+		return nil, fmt.Errorf("failed to get bootstrap brokers: %w", err)
+	}
+
+	return &model.MSKBootstrapBrokers{
+		ClusterARN: clusterARN,
+		Plaintext:  out.BootstrapBrokerString,
+		TLS:        out.BootstrapBrokerStringTls,
+		SASLSCRAM:  out.BootstrapBrokerStringSaslScram,
+		SASLIAM:    out.BootstrapBrokerStringSaslIam,
+	}, nil
+}
+
+// convertMSKCluster converts an MSK API cluster to our model.
+func convertMSKCluster(cl mskCluster) model.MSKCluster {
+	cluster := model.MSKCluster{
+		Name:      cl.ClusterName,
+		ARN:       cl.ClusterArn,
+		Type:      cl.ClusterType,
+		State:     model.MSKClusterState(cl.State),
+		CreatedAt: cl.CreationTime,
+	}
+
+	var auth *mskClientAuthConfig
+	if p := cl.Provisioned; p != nil {
+		cluster.KafkaVersion = p.CurrentBrokerSoftwareInfo.KafkaVersion
+		cluster.InstanceType = p.BrokerNodeGroupInfo.InstanceType
+		cluster.BrokerCount = p.NumberOfBrokerNodes
+		cluster.ClientSubnets = p.BrokerNodeGroupInfo.ClientSubnets
+		cluster.SecurityGroups = p.BrokerNodeGroupInfo.SecurityGroups
+		auth = p.ClientAuthentication
+	}
+	if s := cl.Serverless; s != nil {
+		for _, vpc := range s.VpcConfigs {
+			cluster.ClientSubnets = append(cluster.ClientSubnets, vpc.SubnetIds...)
+			cluster.SecurityGroups = append(cluster.SecurityGroups, vpc.SecurityGroupIds...)
+		}
+		auth = s.ClientAuthentication
+	}
+
+	if auth != nil {
+		if auth.Sasl != nil && auth.Sasl.Iam != nil && auth.Sasl.Iam.Enabled {
+			cluster.Auth = append(cluster.Auth, "IAM")
+		}
+		if auth.Sasl != nil && auth.Sasl.Scram != nil && auth.Sasl.Scram.Enabled {
+			cluster.Auth = append(cluster.Auth, "SCRAM")
+		}
+		if auth.TLS != nil && auth.TLS.Enabled {
+			cluster.Auth = append(cluster.Auth, "TLS")
+		}
+		if auth.Unauthenticated != nil && auth.Unauthenticated.Enabled {
+			cluster.Auth = append(cluster.Auth, "Unauthenticated")
+		}
+	}
+	return cluster
+}
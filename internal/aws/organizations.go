@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// organizationsHost is the endpoint of AWS Organizations, which is global
+// and signs for us-east-1 in the commercial partition.
+const organizationsHost = "organizations.us-east-1.amazonaws.com"
+
+// orgAccountEntry is an account of ListAccounts.
+type orgAccountEntry struct {
+	ID              string   `json:"Id"`
+	Name            string   `json:"Name"`
+	Email           string   `json:"Email"`
+	Status          string   `json:"Status"`
+	JoinedTimestamp restTime `json:"JoinedTimestamp"`
+}
+
+// ListOrganizationAccounts lists the accounts of the organization, by name.
+// Only the management account and delegated administrators may list them.
+func (c *Client) ListOrganizationAccounts(ctx context.Context) ([]model.OrgAccount, error) {
+	var accounts []model.OrgAccount
+	body := map[string]any{}
+	for {
+		var out struct {
+			Accounts  []orgAccountEntry `json:"Accounts"`
+			NextToken string            `json:"NextToken"`
+		}
+		if err := c.callJSONAt(ctx, organizationsHost, "organizations", billingRegion, "AWSOrganizationsV20161128.ListAccounts", body, &out); err != nil {
+			return nil, err
+		}
+		for _, a := range out.Accounts {
+			accounts = append(accounts, model.OrgAccount{
+				ID:       a.ID,
+				Name:     a.Name,
+				Email:    a.Email,
+				Status:   a.Status,
+				JoinedAt: a.JoinedTimestamp.Time,
+			})
+		}
+		if out.NextToken == "" {
+			break
+		}
+		body["NextToken"] = out.NextToken
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return strings.ToLower(accounts[i].Name) < strings.ToLower(accounts[j].Name)
+	})
+	return accounts, nil
+}
+
+// AssumeAccount returns a client for a member account, whose credentials
+// come from assuming roleName there with the credentials of this client.
+// They are refreshed before they expire. The role is assumed once up front,
+// so a missing role or trust fails here rather than on the first call.
+func (c *Client) AssumeAccount(ctx context.Context, accountID, roleName string) (API, error) {
+	roleARN := fmt.Sprintf("arn:%s:iam::%s:role/%s", partition(c.region), accountID, roleName)
+	provider := stscreds.NewAssumeRoleProvider(c.sts, roleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = "vaws-" + c.profile
+		if c.profile == "" {
+			o.RoleSessionName = "vaws"
+		}
+	})
+
+	cfg := c.cfg.Copy()
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return nil, fmt.Errorf("failed to assume %s: %w", roleARN, err)
+	}
+	log.Debug("Assumed %s", roleARN)
+
+	client := newClientFromConfig(cfg, c.profile, c.region)
+	client.account = accountID
+	c.pool.mu.Lock()
+	client.pool.limits = c.pool.limits
+	c.pool.mu.Unlock()
+	return client, nil
+}
+
+// AssumedAccount returns the member account the client assumed a role in,
+// or "" if it uses the profile's own credentials.
+func (c *Client) AssumedAccount() string {
+	return c.account
+}
+
+// CLIEnv returns the environment that makes the AWS CLI use the client's
+// assumed-role credentials, or nil if the client uses the profile's own and
+// the CLI should get --profile instead.
+func (c *Client) CLIEnv(ctx context.Context) ([]string, error) {
+	if c.account == "" {
+		return nil, nil
+	}
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	return []string{
+		"AWS_ACCESS_KEY_ID=" + creds.AccessKeyID,
+		"AWS_SECRET_ACCESS_KEY=" + creds.SecretAccessKey,
+		"AWS_SESSION_TOKEN=" + creds.SessionToken,
+	}, nil
+}
+
+// partition returns the ARN partition of a region.
+func partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// GetCallerIdentity returns the account and principal of the client's credentials.
+func (c *Client) GetCallerIdentity(ctx context.Context) (*model.CallerIdentity, error) {
+	out, err := c.sts.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get caller identity: %w", err)
+	}
+	return &model.CallerIdentity{
+		Account: aws.ToString(out.Account),
+		ARN:     aws.ToString(out.Arn),
+		UserID:  aws.ToString(out.UserId),
+	}, nil
+}
+
+// serviceProbe is a cheap read call used to check that a service is reachable.
+type serviceProbe struct {
+	name string
+	call func(context.Context) error
+}
+
+// serviceProbes returns a probe for each service the TUI uses.
+func (c *Client) serviceProbes() []serviceProbe {
+	return []serviceProbe{
+		{"cloudformation", func(ctx context.Context) error {
+			_, err := c.cfn.ListStacks(ctx, &cloudformation.ListStacksInput{})
+			return err
+		}},
+		{"ecs", func(ctx context.Context) error {
+			_, err := c.ecs.ListClusters(ctx, &ecs.ListClustersInput{MaxResults: aws.Int32(1)})
+			return err
+		}},
+		{"lambda", func(ctx context.Context) error {
+			_, err := c.lambda.ListFunctions(ctx, &lambda.ListFunctionsInput{MaxItems: aws.Int32(1)})
+			return err
+		}},
+		{"apigateway", func(ctx context.Context) error {
+			_, err := c.apigw.GetRestApis(ctx, &apigateway.GetRestApisInput{Limit: aws.Int32(1)})
+			return err
+		}},
+		{"sqs", func(ctx context.Context) error {
+			_, err := c.sqs.ListQueues(ctx, &sqs.ListQueuesInput{MaxResults: aws.Int32(1)})
+			return err
+		}},
+		{"dynamodb", func(ctx context.Context) error {
+			_, err := c.dynamodb.ListTables(ctx, &dynamodb.ListTablesInput{Limit: aws.Int32(1)})
+			return err
+		}},
+		{"logs", func(ctx context.Context) error {
+			_, err := c.cwlogs.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{Limit: aws.Int32(1)})
+			return err
+		}},
+		{"ec2", func(ctx context.Context) error {
+			_, err := c.ec2.DescribeInstances(ctx, &ec2.DescribeInstancesInput{MaxResults: aws.Int32(5)})
+			return err
+		}},
+		{"ssm", func(ctx context.Context) error {
+			_, err := c.ssm.DescribeInstanceInformation(ctx, &ssm.DescribeInstanceInformationInput{MaxResults: aws.Int32(5)})
+			return err
+		}},
+	}
+}
+
+// CheckServices makes one read call against each service in parallel and
+// reports which ones the credentials can reach. Failures are recorded per
+// service rather than returned.
+func (c *Client) CheckServices(ctx context.Context) []model.ServiceCheck {
+	probes := c.serviceProbes()
+	checks := make([]model.ServiceCheck, len(probes))
+
+	var wg sync.WaitGroup
+	for i, p := range probes {
+		wg.Add(1)
+		go func(i int, p serviceProbe) {
+			defer wg.Done()
+			start := time.Now()
+			err := p.call(ctx)
+			check := model.ServiceCheck{Service: p.name, OK: err == nil, Latency: time.Since(start)}
+			if err != nil {
+				log.Debug("Service check %s failed: %v", p.name, err)
+				check.Error = err.Error()
+			}
+			checks[i] = check
+		}(i, p)
+	}
+	wg.Wait()
+
+	return checks
+}
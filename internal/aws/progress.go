@@ -0,0 +1,30 @@
+package aws
+
+import "context"
+
+// ProgressFunc receives progress updates from client methods that make
+// several API calls. total is 0 while the number of units is not known yet,
+// as with paginated list calls.
+type ProgressFunc func(call, unit string, done, total int)
+
+type progressKey struct{}
+
+// WithProgress returns a context that reports the progress of client calls
+// made with it to fn. fn may be called from the goroutine running the call,
+// so it must not block.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// reportProgress forwards a progress update to the context's ProgressFunc, if any.
+func reportProgress(ctx context.Context, call, unit string, done, total int) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(call, unit, done, total)
+	}
+}
+
+// withoutProgress returns a context whose calls don't report progress, for
+// calls made in parallel whose updates would interleave.
+func withoutProgress(ctx context.Context) context.Context {
+	return context.WithValue(ctx, progressKey{}, ProgressFunc(nil))
+}
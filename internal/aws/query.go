@@ -0,0 +1,87 @@
+package aws
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"vaws/internal/log"
+	"vaws/internal/metrics"
+)
+
+// queryError is the error body of the AWS Query APIs, such as IAM's and
+// RDS's.
+type queryError struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+// callQuery sends a SigV4-signed request for action to the Query API of
+// service (its signing name, e.g. rds) in the client's region and decodes
+// the XML response into out. version is the API version of the service,
+// e.g. 2014-10-31.
+func (c *Client) callQuery(ctx context.Context, service, version, action string, params url.Values, out any) error {
+	host := fmt.Sprintf("%s.%s.amazonaws.com", service, c.region)
+	return c.callQueryAt(ctx, host, service, c.region, version, action, params, out)
+}
+
+// callQueryAt is callQuery for services with a single global endpoint, such
+// as IAM, which sign for a fixed region.
+func (c *Client) callQueryAt(ctx context.Context, host, service, region, version, action string, params url.Values, out any) error {
+	form := url.Values{"Action": {action}, "Version": {version}}
+	for k, v := range params {
+		form[k] = v
+	}
+	payload := form.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+"/", strings.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, sha256Hex([]byte(payload)), service, region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	log.Debug("%s %s", strings.ToUpper(service), action)
+	start := time.Now()
+	resp, err := c.httpClient().Do(req)
+	callErr := err
+	if err == nil && resp.StatusCode >= 300 {
+		callErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	metrics.Default().ObserveAWSCall(service, action, time.Since(start), callErr)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr queryError
+		_ = xml.Unmarshal(data, &apiErr)
+		if apiErr.Code == "" {
+			return fmt.Errorf("%s: %s (HTTP %d)", action, http.StatusText(resp.StatusCode), resp.StatusCode)
+		}
+		return fmt.Errorf("%s: %s: %s (HTTP %d)", action, apiErr.Code, apiErr.Message, resp.StatusCode)
+	}
+	if err := xml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", action, err)
+	}
+	return nil
+}
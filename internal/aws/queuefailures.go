@@ -0,0 +1,201 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+const (
+	// queueFailureErrorPattern matches the lines the Lambda runtimes log for
+	// failed invocations.
+	queueFailureErrorPattern = `?ERROR ?Error ?Exception ?"Task timed out" ?"Runtime exited"`
+
+	// queueFailureMaxErrors caps the error lines read per consumer.
+	queueFailureMaxErrors = 200
+
+	// queueFailureVisibilityTimeout hides the sampled DLQ messages from other
+	// receivers for a short while only, since they are left in the queue.
+	queueFailureVisibilityTimeout = 30
+)
+
+// requestIDPattern matches Lambda request IDs, which the runtimes log on
+// every line of an invocation.
+var requestIDPattern = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// GetQueueFailures samples the messages in a queue's DLQ and ties them to
+// the errors of the Lambda functions consuming the queue. A message is
+// matched to the invocations that logged its ID, and through their request
+// IDs to their error lines, so only functions logging the message IDs they
+// receive get matches. Sources that can't be read are reported in Warnings.
+func (c *Client) GetQueueFailures(ctx context.Context, queue model.Queue) (*model.QueueFailures, error) {
+	if queue.ARN == "" {
+		return nil, fmt.Errorf("queue %s has no ARN", queue.Name)
+	}
+	if queue.DLQURL == "" {
+		return nil, fmt.Errorf("queue %s has no DLQ", queue.Name)
+	}
+
+	f := &model.QueueFailures{Queue: queue, Since: time.Now().Add(-model.QueueFailureWindow)}
+	mappings := lambda.NewListEventSourceMappingsPaginator(c.lambda, &lambda.ListEventSourceMappingsInput{
+		EventSourceArn: aws.String(queue.ARN),
+	})
+	for mappings.HasMorePages() {
+		page, err := mappings.NextPage(ctx)
+		if err != nil {
+			f.Warnings = append(f.Warnings, fmt.Sprintf("event source mappings: %v", err))
+			break
+		}
+		for _, m := range page.EventSourceMappings {
+			f.Consumers = append(f.Consumers, model.QueueConsumer{
+				Function:   functionNameFromARN(aws.ToString(m.FunctionArn)),
+				State:      aws.ToString(m.State),
+				LastResult: aws.ToString(m.LastProcessingResult),
+				BatchSize:  int(aws.ToInt32(m.BatchSize)),
+			})
+		}
+	}
+
+	messages, err := c.ReceiveMessages(ctx, queue.DLQURL, queueFailureVisibilityTimeout)
+	if err != nil {
+		f.Warnings = append(f.Warnings, fmt.Sprintf("DLQ messages: %v", err))
+	}
+	for _, msg := range messages {
+		f.Messages = append(f.Messages, model.FailedMessage{Message: msg})
+		// The logs of older messages go further back than the window
+		if !msg.SentAt.IsZero() && msg.SentAt.Before(f.Since) {
+			f.Since = msg.SentAt
+		}
+	}
+
+	for _, consumer := range f.Consumers {
+		src := model.LogSource{Resource: consumer.Function, LogGroup: "/aws/lambda/" + consumer.Function}
+		hits, err := c.searchLogGroup(ctx, src, queueFailureErrorPattern, f.Since.UnixMilli(), 0, queueFailureMaxErrors)
+		if err != nil {
+			f.Warnings = append(f.Warnings, fmt.Sprintf("errors of %s: %v", consumer.Function, err))
+		}
+		f.Errors = append(f.Errors, hits...)
+		if err := c.matchFailedMessages(ctx, src, f); err != nil {
+			f.Warnings = append(f.Warnings, fmt.Sprintf("message IDs in logs of %s: %v", consumer.Function, err))
+		}
+	}
+	sort.SliceStable(f.Errors, func(i, j int) bool { return f.Errors[i].Timestamp.After(f.Errors[j].Timestamp) })
+
+	for i := range f.Messages {
+		fm := &f.Messages[i]
+		for _, hit := range f.Errors {
+			if strings.Contains(hit.Message, fm.Message.ID) || containsAny(hit.Message, fm.RequestIDs) {
+				fm.Errors = append(fm.Errors, hit)
+			}
+		}
+	}
+
+	if len(f.Consumers) > 0 {
+		if err := c.consumerMetrics(ctx, f.Consumers); err != nil {
+			log.Warn("Failed to get metrics of the consumers of %s: %v", queue.Name, err)
+			f.Warnings = append(f.Warnings, fmt.Sprintf("Lambda metrics: %v", err))
+		}
+	}
+	return f, nil
+}
+
+// matchFailedMessages searches a consumer's logs for the IDs of the sampled
+// messages and keeps the request IDs of the invocations that logged them.
+func (c *Client) matchFailedMessages(ctx context.Context, src model.LogSource, f *model.QueueFailures) error {
+	if len(f.Messages) == 0 {
+		return nil
+	}
+	terms := make([]string, len(f.Messages))
+	for i, fm := range f.Messages {
+		terms[i] = `?"` + fm.Message.ID + `"`
+	}
+	hits, err := c.searchLogGroup(ctx, src, strings.Join(terms, " "), f.Since.UnixMilli(), 0, queueFailureMaxErrors)
+	for _, hit := range hits {
+		for i := range f.Messages {
+			fm := &f.Messages[i]
+			if !strings.Contains(hit.Message, fm.Message.ID) {
+				continue
+			}
+			for _, id := range requestIDPattern.FindAllString(hit.Message, -1) {
+				if id != fm.Message.ID && !slices.Contains(fm.RequestIDs, id) {
+					fm.RequestIDs = append(fm.RequestIDs, id)
+				}
+			}
+		}
+	}
+	return err
+}
+
+// consumerMetrics sets the invocations, errors and throttles of the
+// consumers over the failure window.
+func (c *Client) consumerMetrics(ctx context.Context, consumers []model.QueueConsumer) error {
+	now := time.Now()
+	metrics := []string{"Invocations", "Errors", "Throttles"}
+	var queries []cwtypes.MetricDataQuery
+	for i, consumer := range consumers {
+		for j, metric := range metrics {
+			queries = append(queries, cwtypes.MetricDataQuery{
+				Id: aws.String(fmt.Sprintf("fn%d_%d", i, j)),
+				MetricStat: &cwtypes.MetricStat{
+					Metric: &cwtypes.Metric{
+						Namespace:  aws.String("AWS/Lambda"),
+						MetricName: aws.String(metric),
+						Dimensions: []cwtypes.Dimension{{Name: aws.String("FunctionName"), Value: aws.String(consumer.Function)}},
+					},
+					Period: aws.Int32(int32(model.QueueFailureWindow.Seconds())),
+					Stat:   aws.String("Sum"),
+				},
+			})
+		}
+	}
+
+	sums := make(map[string]float64)
+	for start := 0; start < len(queries); start += metricQueriesPerCall {
+		paginator := cloudwatch.NewGetMetricDataPaginator(c.cw, &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries[start:min(start+metricQueriesPerCall, len(queries))],
+			StartTime:         aws.Time(now.Add(-model.QueueFailureWindow)),
+			EndTime:           aws.Time(now),
+		})
+		for paginator.HasMorePages() {
+			out, err := paginator.NextPage(ctx)
+			if err != nil {
+				return err
+			}
+			for _, r := range out.MetricDataResults {
+				for _, v := range r.Values {
+					sums[aws.ToString(r.Id)] += v
+				}
+			}
+		}
+	}
+
+	for i := range consumers {
+		consumers[i].Invocations = sums[fmt.Sprintf("fn%d_0", i)]
+		consumers[i].Errors = sums[fmt.Sprintf("fn%d_1", i)]
+		consumers[i].Throttles = sums[fmt.Sprintf("fn%d_2", i)]
+		consumers[i].HasMetrics = true
+	}
+	return nil
+}
+
+// containsAny returns true if s contains any of subs.
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
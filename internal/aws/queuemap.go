@@ -0,0 +1,317 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// queueWorkload is a Lambda function or ECS service whose role may grant it
+// access to a queue.
+type queueWorkload struct {
+	kind string // lambda or ecs
+	name string
+	role string // Role ARN
+}
+
+// GetQueueRelations maps who reads from and writes to a queue: Lambda
+// functions with an event source mapping on it, Lambda functions and ECS
+// services whose roles allow receiving from or sending to it, and the
+// principals its queue policy lets send. Sources that can't be read are
+// skipped and reported in Warnings.
+func (c *Client) GetQueueRelations(ctx context.Context, queue model.Queue) (*model.QueueRelations, error) {
+	if queue.ARN == "" {
+		return nil, fmt.Errorf("queue %s has no ARN", queue.Name)
+	}
+	rel := &model.QueueRelations{}
+	mapped := make(map[string]bool) // Functions consuming through a mapping
+
+	mappings := lambda.NewListEventSourceMappingsPaginator(c.lambda, &lambda.ListEventSourceMappingsInput{
+		EventSourceArn: aws.String(queue.ARN),
+	})
+	for mappings.HasMorePages() {
+		page, err := mappings.NextPage(ctx)
+		if err != nil {
+			rel.Warnings = append(rel.Warnings, fmt.Sprintf("event source mappings: %v", err))
+			break
+		}
+		for _, m := range page.EventSourceMappings {
+			name := functionNameFromARN(aws.ToString(m.FunctionArn))
+			mapped[name] = true
+			rel.Consumers = append(rel.Consumers, model.QueueRelation{
+				Kind:    "lambda",
+				Name:    name,
+				Via:     "event source mapping (" + strings.ToLower(aws.ToString(m.State)) + ")",
+				Certain: true,
+			})
+		}
+	}
+
+	producers, err := c.queuePolicyProducers(ctx, queue)
+	if err != nil {
+		rel.Warnings = append(rel.Warnings, fmt.Sprintf("queue policy: %v", err))
+	}
+	rel.Producers = append(rel.Producers, producers...)
+
+	workloads, warnings := c.queueWorkloads(ctx)
+	rel.Warnings = append(rel.Warnings, warnings...)
+	consumers, producers, warnings := c.roleQueueAccess(ctx, queue.ARN, workloads)
+	rel.Warnings = append(rel.Warnings, warnings...)
+	for _, r := range consumers {
+		if r.Kind == "lambda" && mapped[r.Name] {
+			continue
+		}
+		rel.Consumers = append(rel.Consumers, r)
+	}
+	rel.Producers = append(rel.Producers, producers...)
+
+	for _, list := range [][]model.QueueRelation{rel.Consumers, rel.Producers} {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Certain != list[j].Certain {
+				return list[i].Certain
+			}
+			return list[i].Name < list[j].Name
+		})
+	}
+	log.Info("Mapped %d consumers and %d producers of %s", len(rel.Consumers), len(rel.Producers), queue.Name)
+	return rel, nil
+}
+
+// queuePolicyProducers returns the principals the queue's resource policy
+// allows to send messages, named by the source ARN of the statement's
+// condition if it has one.
+func (c *Client) queuePolicyProducers(ctx context.Context, queue model.Queue) ([]model.QueueRelation, error) {
+	out, err := c.sqs.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queue.URL),
+		AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNamePolicy},
+	})
+	if err != nil {
+		return nil, err
+	}
+	doc := out.Attributes[string(sqstypes.QueueAttributeNamePolicy)]
+	if doc == "" {
+		return nil, nil
+	}
+	statements, err := policyStatements(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var producers []model.QueueRelation
+	for _, s := range statements {
+		if !s.allows("sqs:SendMessage", queue.ARN) {
+			continue
+		}
+		var sources []string
+		for op, values := range s.Condition {
+			if strings.HasPrefix(op, "Arn") || strings.HasPrefix(op, "String") {
+				for key, v := range values {
+					if strings.EqualFold(key, "aws:SourceArn") {
+						sources = append(sources, v...)
+					}
+				}
+			}
+		}
+		principals := policyPrincipals(s.Principal)
+		if len(sources) == 0 {
+			for _, p := range principals {
+				producers = append(producers, model.QueueRelation{Kind: "principal", Name: p, Via: "queue policy", Certain: true})
+			}
+			continue
+		}
+		for _, src := range sources {
+			kind, name := arnService(src)
+			producers = append(producers, model.QueueRelation{Kind: kind, Name: name, Via: "queue policy", Certain: true})
+		}
+	}
+	return producers, nil
+}
+
+// policyPrincipals returns the principals of a statement: "*", or the
+// services and accounts it names.
+func policyPrincipals(raw json.RawMessage) []string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}
+	}
+	var byType map[string]stringList
+	if err := json.Unmarshal(raw, &byType); err != nil {
+		return nil
+	}
+	var principals []string
+	for _, list := range byType {
+		principals = append(principals, list...)
+	}
+	sort.Strings(principals)
+	return principals
+}
+
+// arnService returns the service of an ARN and the name at its end, e.g.
+// "sns" and "orders-events" for a topic.
+func arnService(arn string) (service, name string) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 {
+		return "principal", arn
+	}
+	resource := parts[5]
+	if i := strings.LastIndexAny(resource, "/:"); i >= 0 && i < len(resource)-1 {
+		resource = resource[i+1:]
+	}
+	return parts[2], resource
+}
+
+// functionNameFromARN returns the function name of a Lambda ARN.
+func functionNameFromARN(arn string) string {
+	if _, rest, ok := strings.Cut(arn, ":function:"); ok {
+		name, _, _ := strings.Cut(rest, ":")
+		return name
+	}
+	return arn
+}
+
+// queueWorkloads lists the Lambda functions and ECS services of the region
+// with the roles they run as.
+func (c *Client) queueWorkloads(ctx context.Context) ([]queueWorkload, []string) {
+	var workloads []queueWorkload
+	var warnings []string
+
+	functions, err := c.ListFunctions(ctx)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Lambda functions: %v", err))
+	}
+	for _, fn := range functions {
+		if fn.Role != "" {
+			workloads = append(workloads, queueWorkload{kind: "lambda", name: fn.Name, role: fn.Role})
+		}
+	}
+
+	clusters, err := c.ListClusters(ctx)
+	if err != nil {
+		return workloads, append(warnings, fmt.Sprintf("ECS clusters: %v", err))
+	}
+	var services []model.Service
+	for _, cl := range clusters {
+		svcs, err := c.ListServices(ctx, cl.ARN)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("ECS services of %s: %v", cl.Name, err))
+			continue
+		}
+		services = append(services, svcs...)
+	}
+
+	// Task roles are on the task definitions, which services often share
+	seen := make(map[string]bool)
+	roles := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := c.slots(ConcurrencyECS)
+	for _, svc := range services {
+		if seen[svc.TaskDefinition] || svc.TaskDefinition == "" {
+			continue
+		}
+		seen[svc.TaskDefinition] = true
+		wg.Add(1)
+		go func(taskDef string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			out, err := c.ecs.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{TaskDefinition: aws.String(taskDef)})
+			if err != nil || out.TaskDefinition == nil {
+				log.Debug("Failed to describe task definition %s: %v", taskDef, err)
+				return
+			}
+			mu.Lock()
+			roles[taskDef] = aws.ToString(out.TaskDefinition.TaskRoleArn)
+			mu.Unlock()
+		}(svc.TaskDefinition)
+	}
+	wg.Wait()
+	for _, svc := range services {
+		if role := roles[svc.TaskDefinition]; role != "" {
+			workloads = append(workloads, queueWorkload{kind: "ecs", name: svc.Name + " (" + svc.ClusterName + ")", role: role})
+		}
+	}
+	return workloads, warnings
+}
+
+// roleQueueAccess reads the policies of the workloads' roles and returns
+// those allowed to receive from the queue as consumers and those allowed to
+// send to it as producers.
+func (c *Client) roleQueueAccess(ctx context.Context, queueARN string, workloads []queueWorkload) (consumers, producers []model.QueueRelation, warnings []string) {
+	type access struct {
+		receive, send bool
+		broad         bool // Only through policies covering every resource
+	}
+	byRole := make(map[string]access)
+	for _, w := range workloads {
+		byRole[w.role] = access{}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	managed := &managedPolicies{}
+	sem := c.slots(ConcurrencyIAM)
+	for role := range byRole {
+		wg.Add(1)
+		go func(role string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			docs, err := c.rolePolicies(ctx, role[strings.LastIndex(role, "/")+1:], managed)
+			a := access{broad: true}
+			for _, doc := range docs {
+				statements, perr := policyStatements(doc)
+				if perr != nil {
+					log.Debug("Skipping policy of %s: %v", role, perr)
+					continue
+				}
+				for _, s := range statements {
+					receive, send := s.allows("sqs:ReceiveMessage", queueARN), s.allows("sqs:SendMessage", queueARN)
+					if (receive || send) && !s.broad(queueARN) {
+						a.broad = false
+					}
+					a.receive = a.receive || receive
+					a.send = a.send || send
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			byRole[role] = a
+		}(role)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		warnings = append(warnings, fmt.Sprintf("IAM role policies: %v", firstErr))
+	}
+
+	for _, w := range workloads {
+		a := byRole[w.role]
+		via := "role " + w.role[strings.LastIndex(w.role, "/")+1:]
+		if a.broad {
+			via += " (all queues)"
+		}
+		if a.receive {
+			consumers = append(consumers, model.QueueRelation{Kind: w.kind, Name: w.name, Via: via})
+		}
+		if a.send {
+			producers = append(producers, model.QueueRelation{Kind: w.kind, Name: w.name, Via: via})
+		}
+	}
+	return consumers, producers, warnings
+}
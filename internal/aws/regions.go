@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"vaws/internal/log"
+)
+
+// ListRegions returns the regions enabled for the account: those enabled by
+// default and those it opted in to.
+func (c *Client) ListRegions(ctx context.Context) ([]string, error) {
+	out, err := c.ec2.DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe regions: %w", err)
+	}
+
+	regions := make([]string, 0, len(out.Regions))
+	for _, r := range out.Regions {
+		if name := aws.ToString(r.RegionName); name != "" {
+			regions = append(regions, name)
+		}
+	}
+	sort.Strings(regions)
+	return regions, nil
+}
+
+// RegionLatencies measures how long a cheap call to each region's STS
+// endpoint takes. Each region is called twice and the faster call counts, so
+// that setting up the connection doesn't. Regions that fail are left out.
+func (c *Client) RegionLatencies(ctx context.Context, regions []string) map[string]time.Duration {
+	latencies := make(map[string]time.Duration, len(regions))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := c.slots(ConcurrencyRegions)
+
+	for _, region := range regions {
+		wg.Add(1)
+		go func(region string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			client := sts.NewFromConfig(c.cfg, func(o *sts.Options) { o.Region = region })
+			var best time.Duration
+			for range 2 {
+				start := time.Now()
+				if _, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}); err != nil {
+					log.Debug("Region latency %s failed: %v", region, err)
+					return
+				}
+				if d := time.Since(start); best == 0 || d < best {
+					best = d
+				}
+			}
+			mu.Lock()
+			latencies[region] = best
+			mu.Unlock()
+		}(region)
+	}
+	wg.Wait()
+
+	return latencies
+}
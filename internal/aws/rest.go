@@ -0,0 +1,218 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"vaws/internal/log"
+	"vaws/internal/metrics"
+)
+
+// restError is the error body returned by AWS REST-JSON and JSON-RPC APIs.
+type restError struct {
+	Type         string `json:"__type"` // JSON-RPC only, e.g. ResourceNotFoundException
+	Message      string `json:"message"`
+	MessageUpper string `json:"Message"`
+}
+
+// restTime is a timestamp of a REST-JSON API, which most send as epoch
+// seconds and some as RFC 3339 strings.
+type restTime struct{ time.Time }
+
+func (t *restTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var secs float64
+	if err := json.Unmarshal(data, &secs); err == nil {
+		t.Time = time.Unix(0, int64(secs*float64(time.Second)))
+		return nil
+	}
+	return json.Unmarshal(data, &t.Time)
+}
+
+// callREST sends a SigV4-signed request to the REST-JSON API of service (its
+// signing name, e.g. kafka) and decodes the response into out. It covers the
+// few calls vaws makes to services it has no SDK client for. path must
+// already be escaped; body and out may be nil.
+func (c *Client) callREST(ctx context.Context, service, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return fmt.Errorf("invalid path %q: %w", path, err)
+	}
+	u := &url.URL{
+		Scheme:   "https",
+		Host:     fmt.Sprintf("%s.%s.amazonaws.com", service, c.region),
+		Path:     unescaped,
+		RawPath:  path,
+		RawQuery: query.Encode(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, sha256Hex(payload), service, c.region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	log.Debug("%s %s", method, u.Redacted())
+	// Paths carry resource names, so calls are only told apart by method
+	start := time.Now()
+	resp, err := c.httpClient().Do(req)
+	callErr := err
+	if err == nil && resp.StatusCode >= 300 {
+		callErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	metrics.Default().ObserveAWSCall(service, method, time.Since(start), callErr)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr restError
+		_ = json.Unmarshal(data, &apiErr)
+		msg := apiErr.Message
+		if msg == "" {
+			msg = apiErr.MessageUpper
+		}
+		if msg == "" {
+			msg = http.StatusText(resp.StatusCode)
+		}
+		if errType := resp.Header.Get("X-Amzn-Errortype"); errType != "" {
+			msg = errType + ": " + msg
+		}
+		return fmt.Errorf("%s (HTTP %d)", msg, resp.StatusCode)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// callJSON sends a SigV4-signed request to the JSON-RPC API of service (its
+// signing name, e.g. secretsmanager) and decodes the response into out.
+// target is the X-Amz-Target of the operation, e.g.
+// secretsmanager.ListSecrets; out may be nil.
+func (c *Client) callJSON(ctx context.Context, service, target string, body, out any) error {
+	host := fmt.Sprintf("%s.%s.amazonaws.com", service, c.region)
+	return c.callJSONAt(ctx, host, service, c.region, target, body, out)
+}
+
+// callJSONAt is callJSON for services with a single global endpoint, such as
+// Budgets, which sign for a fixed region.
+func (c *Client) callJSONAt(ctx context.Context, host, service, region, target string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+"/", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", target)
+
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, sha256Hex(payload), service, region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	operation := target[strings.LastIndex(target, ".")+1:]
+	log.Debug("%s %s", service, operation)
+	start := time.Now()
+	resp, err := c.httpClient().Do(req)
+	callErr := err
+	if err == nil && resp.StatusCode >= 300 {
+		callErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	metrics.Default().ObserveAWSCall(service, operation, time.Since(start), callErr)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr restError
+		_ = json.Unmarshal(data, &apiErr)
+		msg := apiErr.Message
+		if msg == "" {
+			msg = apiErr.MessageUpper
+		}
+		if msg == "" {
+			msg = http.StatusText(resp.StatusCode)
+		}
+		if apiErr.Type != "" {
+			msg = apiErr.Type[strings.LastIndex(apiErr.Type, "#")+1:] + ": " + msg
+		}
+		return fmt.Errorf("%s (HTTP %d)", msg, resp.StatusCode)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// sha256Hex returns the hex SHA-256 of a request payload, as SigV4 signs it.
+func sha256Hex(payload []byte) string {
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:])
+}
+
+// httpClient returns the HTTP client of the AWS config, which carries any
+// proxy or CA settings from the environment.
+func (c *Client) httpClient() interface {
+	Do(*http.Request) (*http.Response, error)
+} {
+	if c.cfg.HTTPClient != nil {
+		return c.cfg.HTTPClient
+	}
+	return http.DefaultClient
+}
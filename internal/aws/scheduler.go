@@ -0,0 +1,245 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// maxScheduleNameLength is the longest name Scheduler accepts.
+const maxScheduleNameLength = 64
+
+// schedule is the GetSchedule response.
+type schedule struct {
+	Arn                        string   `json:"Arn"`
+	Name                       string   `json:"Name"`
+	GroupName                  string   `json:"GroupName"`
+	State                      string   `json:"State"`
+	Description                string   `json:"Description"`
+	ScheduleExpression         string   `json:"ScheduleExpression"`
+	ScheduleExpressionTimezone string   `json:"ScheduleExpressionTimezone"`
+	StartDate                  restTime `json:"StartDate"`
+	EndDate                    restTime `json:"EndDate"`
+	CreationDate               restTime `json:"CreationDate"`
+	LastModificationDate       restTime `json:"LastModificationDate"`
+	FlexibleTimeWindow         struct {
+		Mode                   string `json:"Mode"`
+		MaximumWindowInMinutes int    `json:"MaximumWindowInMinutes"`
+	} `json:"FlexibleTimeWindow"`
+	Target struct {
+		Arn     string `json:"Arn"`
+		RoleArn string `json:"RoleArn"`
+		Input   string `json:"Input"`
+	} `json:"Target"`
+}
+
+// scheduleSummary is a schedule of the ListSchedules response.
+type scheduleSummary struct {
+	Name      string `json:"Name"`
+	GroupName string `json:"GroupName"`
+	State     string `json:"State"`
+	Arn       string `json:"Arn"`
+}
+
+// writableScheduleFields are the GetSchedule fields UpdateSchedule and
+// CreateSchedule take back.
+var writableScheduleFields = []string{
+	"ActionAfterCompletion", "Description", "EndDate", "FlexibleTimeWindow", "GroupName", "KmsKeyArn",
+	"ScheduleExpression", "ScheduleExpressionTimezone", "StartDate", "State", "Target",
+}
+
+// ListSchedules lists the EventBridge Scheduler schedules of all groups, with
+// their expressions and targets.
+func (c *Client) ListSchedules(ctx context.Context) ([]model.Schedule, error) {
+	log.Debug("Listing schedules...")
+
+	var summaries []scheduleSummary
+	query := url.Values{"MaxResults": {"100"}}
+	for page := 1; ; page++ {
+		var out struct {
+			Schedules []scheduleSummary `json:"Schedules"`
+			NextToken string            `json:"NextToken"`
+		}
+		if err := c.callREST(ctx, "scheduler", "GET", "/schedules", query, nil, &out); err != nil {
+			return nil, fmt.Errorf("failed to list schedules: %w", err)
+		}
+		summaries = append(summaries, out.Schedules...)
+		reportProgress(ctx, "ListSchedules", "pages", page, 0)
+
+		if out.NextToken == "" {
+			break
+		}
+		query.Set("NextToken", out.NextToken)
+	}
+
+	if len(summaries) == 0 {
+		log.Info("No schedules found")
+		return nil, nil
+	}
+
+	type scheduleResult struct {
+		index    int
+		schedule model.Schedule
+	}
+
+	results := make(chan scheduleResult, len(summaries))
+	sem := c.slots(ConcurrencyScheduler)
+
+	var wg sync.WaitGroup
+	for i, summary := range summaries {
+		wg.Add(1)
+		go func(idx int, summary scheduleSummary) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var out schedule
+			if err := c.getSchedule(ctx, summary.GroupName, summary.Name, &out); err != nil {
+				// Fall back to the summary so the schedule is still listed
+				log.Warn("Failed to get schedule %s: %v", summary.Name, err)
+				results <- scheduleResult{index: idx, schedule: model.Schedule{
+					Name:  summary.Name,
+					Group: summary.GroupName,
+					ARN:   summary.Arn,
+					State: model.ScheduleState(summary.State),
+				}}
+				return
+			}
+			results <- scheduleResult{index: idx, schedule: convertSchedule(out)}
+		}(i, summary)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	schedules := make([]model.Schedule, len(summaries))
+	fetched := 0
+	reportProgress(ctx, "GetSchedule", "schedules", 0, len(summaries))
+	for result := range results {
+		schedules[result.index] = result.schedule
+		fetched++
+		reportProgress(ctx, "GetSchedule", "schedules", fetched, len(summaries))
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].Group != schedules[j].Group {
+			return schedules[i].IsDefaultGroup() || (!schedules[j].IsDefaultGroup() && schedules[i].Group < schedules[j].Group)
+		}
+		return schedules[i].Name < schedules[j].Name
+	})
+
+	log.Info("Found %d schedules", len(schedules))
+	return schedules, nil
+}
+
+// SetScheduleState enables or disables a schedule, keeping the rest of its
+// configuration.
+func (c *Client) SetScheduleState(ctx context.Context, group, name string, state model.ScheduleState) error {
+	log.Info("Setting schedule %s to %s", name, state)
+
+	body, err := c.writableSchedule(ctx, group, name)
+	if err != nil {
+		return err
+	}
+	body["State"] = state
+
+	if err := c.callREST(ctx, "scheduler", "PUT", "/schedules/"+url.PathEscape(name), nil, body, nil); err != nil {
+		return fmt.Errorf("failed to update schedule %s: %w", name, err)
+	}
+	return nil
+}
+
+// RunScheduleNow invokes the target of a schedule once, through a one-time
+// schedule due in a minute which deletes itself after it runs. It returns
+// the name of that schedule.
+func (c *Client) RunScheduleNow(ctx context.Context, group, name string) (string, error) {
+	body, err := c.writableSchedule(ctx, group, name)
+	if err != nil {
+		return "", err
+	}
+	for _, field := range []string{"Description", "EndDate", "StartDate", "ScheduleExpressionTimezone"} {
+		delete(body, field)
+	}
+	now := time.Now().UTC()
+	body["ScheduleExpression"] = "at(" + now.Add(time.Minute).Format("2006-01-02T15:04:05") + ")"
+	body["FlexibleTimeWindow"] = map[string]string{"Mode": "OFF"}
+	body["ActionAfterCompletion"] = "DELETE"
+	body["State"] = model.ScheduleStateEnabled
+	body["Description"] = "vaws run of " + name
+
+	suffix := "-run-" + strconv.FormatInt(now.Unix(), 10)
+	runName := name
+	if len(runName)+len(suffix) > maxScheduleNameLength {
+		runName = runName[:maxScheduleNameLength-len(suffix)]
+	}
+	runName += suffix
+
+	log.Info("Running schedule %s now through %s", name, runName)
+	if err := c.callREST(ctx, "scheduler", "POST", "/schedules/"+url.PathEscape(runName), nil, body, nil); err != nil {
+		return "", fmt.Errorf("failed to create schedule %s: %w", runName, err)
+	}
+	return runName, nil
+}
+
+// getSchedule decodes the GetSchedule response of a schedule into out.
+func (c *Client) getSchedule(ctx context.Context, group, name string, out any) error {
+	var query url.Values
+	if group != "" {
+		query = url.Values{"groupName": {group}}
+	}
+	if err := c.callREST(ctx, "scheduler", "GET", "/schedules/"+url.PathEscape(name), query, nil, out); err != nil {
+		return fmt.Errorf("failed to get schedule %s: %w", name, err)
+	}
+	return nil
+}
+
+// writableSchedule returns a schedule's configuration as an UpdateSchedule
+// body, untouched so that fields vaws doesn't model survive the update.
+func (c *Client) writableSchedule(ctx context.Context, group, name string) (map[string]any, error) {
+	var raw map[string]json.RawMessage
+	if err := c.getSchedule(ctx, group, name, &raw); err != nil {
+		return nil, err
+	}
+	body := make(map[string]any)
+	for _, field := range writableScheduleFields {
+		if v, ok := raw[field]; ok && string(v) != "null" {
+			body[field] = v
+		}
+	}
+	return body, nil
+}
+
+// convertSchedule converts a Scheduler API schedule to our model.
+func convertSchedule(s schedule) model.Schedule {
+	out := model.Schedule{
+		Name:        s.Name,
+		Group:       s.GroupName,
+		ARN:         s.Arn,
+		State:       model.ScheduleState(s.State),
+		Expression:  s.ScheduleExpression,
+		Timezone:    s.ScheduleExpressionTimezone,
+		Description: s.Description,
+		TargetARN:   s.Target.Arn,
+		TargetRole:  s.Target.RoleArn,
+		TargetInput: strings.TrimSpace(s.Target.Input),
+		StartDate:   s.StartDate.Time,
+		EndDate:     s.EndDate.Time,
+		CreatedAt:   s.CreationDate.Time,
+		UpdatedAt:   s.LastModificationDate.Time,
+	}
+	if s.FlexibleTimeWindow.Mode == "FLEXIBLE" {
+		out.FlexibleWindow = s.FlexibleTimeWindow.MaximumWindowInMinutes
+	}
+	return out
+}
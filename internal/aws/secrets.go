@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// secretEntry is a secret of the ListSecrets response.
+type secretEntry struct {
+	ARN               string `json:"ARN"`
+	Name              string `json:"Name"`
+	Description       string `json:"Description"`
+	RotationEnabled   bool   `json:"RotationEnabled"`
+	RotationLambdaARN string `json:"RotationLambdaARN"`
+	RotationRules     struct {
+		AutomaticallyAfterDays int64  `json:"AutomaticallyAfterDays"`
+		ScheduleExpression     string `json:"ScheduleExpression"`
+	} `json:"RotationRules"`
+	OwningService    string   `json:"OwningService"`
+	LastRotatedDate  restTime `json:"LastRotatedDate"`
+	LastChangedDate  restTime `json:"LastChangedDate"`
+	LastAccessedDate restTime `json:"LastAccessedDate"`
+	NextRotationDate restTime `json:"NextRotationDate"`
+	CreatedDate      restTime `json:"CreatedDate"`
+}
+
+// ListSecrets lists the Secrets Manager secrets of the region with their
+// rotation settings. Secrets scheduled for deletion are left out.
+func (c *Client) ListSecrets(ctx context.Context) ([]model.Secret, error) {
+	log.Debug("Listing secrets...")
+
+	var secrets []model.Secret
+	body := map[string]any{"MaxResults": 100}
+	for page := 1; ; page++ {
+		var out struct {
+			SecretList []secretEntry `json:"SecretList"`
+			NextToken  string        `json:"NextToken"`
+		}
+		if err := c.callJSON(ctx, "secretsmanager", "secretsmanager.ListSecrets", body, &out); err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		for _, e := range out.SecretList {
+			secrets = append(secrets, convertSecret(e))
+		}
+		reportProgress(ctx, "ListSecrets", "pages", page, 0)
+
+		if out.NextToken == "" {
+			break
+		}
+		body["NextToken"] = out.NextToken
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+	log.Info("Found %d secrets", len(secrets))
+	return secrets, nil
+}
+
+// convertSecret converts a ListSecrets entry to a model.Secret.
+func convertSecret(e secretEntry) model.Secret {
+	return model.Secret{
+		Name:             e.Name,
+		ARN:              e.ARN,
+		Description:      e.Description,
+		RotationEnabled:  e.RotationEnabled,
+		RotationLambda:   e.RotationLambdaARN,
+		RotationSchedule: e.RotationRules.ScheduleExpression,
+		RotationDays:     e.RotationRules.AutomaticallyAfterDays,
+		OwningService:    e.OwningService,
+		LastRotated:      e.LastRotatedDate.Time,
+		LastChanged:      e.LastChangedDate.Time,
+		LastAccessed:     e.LastAccessedDate.Time,
+		NextRotation:     e.NextRotationDate.Time,
+		CreatedAt:        e.CreatedDate.Time,
+	}
+}
+
+// RotateSecret starts a rotation of a secret now, with the rotation function
+// and rules it is configured with. It returns once Secrets Manager has
+// accepted the rotation, while the rotation function still runs.
+func (c *Client) RotateSecret(ctx context.Context, secretARN string) error {
+	log.Info("Rotating secret %s", secretARN)
+	body := map[string]any{"SecretId": secretARN, "RotateImmediately": true}
+	if err := c.callJSON(ctx, "secretsmanager", "secretsmanager.RotateSecret", body, nil); err != nil {
+		return fmt.Errorf("failed to rotate secret: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/servicediscovery"
+	sdtypes "github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// cloudMapNamespace is the subset of a Cloud Map namespace needed to build a DNS name.
+type cloudMapNamespace struct {
+	name string
+	dns  bool // DNS namespaces resolve through Route 53; HTTP namespaces only via the API
+}
+
+// cloudMapService is the subset of a Cloud Map service needed to build a DNS name.
+type cloudMapService struct {
+	name        string
+	namespaceID string
+}
+
+// cloudMapCache keeps Cloud Map lookups for the lifetime of a Client, so
+// refreshing the service list does not repeat them. Namespaces and registries
+// rarely change, and a new Client is created on every profile or region switch.
+type cloudMapCache struct {
+	mu         sync.Mutex
+	namespaces map[string]cloudMapNamespace
+	registries map[string]cloudMapService
+}
+
+// namespace returns a cached namespace by ID or ARN.
+func (cc *cloudMapCache) namespace(idOrARN string) (cloudMapNamespace, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	ns, ok := cc.namespaces[idOrARN]
+	return ns, ok
+}
+
+// setNamespace caches a namespace by ID or ARN.
+func (cc *cloudMapCache) setNamespace(idOrARN string, ns cloudMapNamespace) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.namespaces == nil {
+		cc.namespaces = make(map[string]cloudMapNamespace)
+	}
+	cc.namespaces[idOrARN] = ns
+}
+
+// registry returns a cached Cloud Map service by ARN.
+func (cc *cloudMapCache) registry(arn string) (cloudMapService, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	reg, ok := cc.registries[arn]
+	return reg, ok
+}
+
+// setRegistry caches a Cloud Map service by ARN.
+func (cc *cloudMapCache) setRegistry(arn string, reg cloudMapService) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.registries == nil {
+		cc.registries = make(map[string]cloudMapService)
+	}
+	cc.registries[arn] = reg
+}
+
+// resolveDiscoveryEndpoints fills in namespace names and DNS names of the
+// services' discovery endpoints using Cloud Map. Lookups are cached on the
+// client since services usually share a namespace and the list is refreshed
+// often. Failures are logged and leave the endpoint as-is, so missing
+// servicediscovery permissions only hide names rather than failing the
+// service list; they are cached too, so a denied lookup is not retried on
+// every refresh.
+func (c *Client) resolveDiscoveryEndpoints(ctx context.Context, services []model.Service) {
+	getNamespace := func(idOrARN string) cloudMapNamespace {
+		if ns, ok := c.cloudMapCache.namespace(idOrARN); ok {
+			return ns
+		}
+		ns := cloudMapNamespace{name: idOrARN}
+		out, err := c.cloudmap.GetNamespace(ctx, &servicediscovery.GetNamespaceInput{Id: aws.String(idOrARN)})
+		if err != nil {
+			log.Debug("Failed to get Cloud Map namespace %s: %v", idOrARN, err)
+		} else if out.Namespace != nil {
+			ns.name = aws.ToString(out.Namespace.Name)
+			ns.dns = out.Namespace.Type != sdtypes.NamespaceTypeHttp
+		}
+		c.cloudMapCache.setNamespace(idOrARN, ns)
+		return ns
+	}
+
+	for i := range services {
+		for j := range services[i].DiscoveryEndpoints {
+			ep := &services[i].DiscoveryEndpoints[j]
+
+			switch ep.Source {
+			case model.DiscoveryServiceConnect:
+				// The namespace is configured either by name or by ARN. Client
+				// aliases resolve through the Service Connect proxy regardless of
+				// the namespace type.
+				if strings.HasPrefix(ep.Namespace, "arn:") {
+					ep.Namespace = getNamespace(ep.Namespace).name
+				}
+				if ep.DNSName == "" && ep.Port > 0 {
+					ep.DNSName = ep.Name + "." + ep.Namespace
+				}
+
+			case model.DiscoveryCloudMap:
+				reg, ok := c.cloudMapCache.registry(ep.RegistryARN)
+				if !ok {
+					out, err := c.cloudmap.GetService(ctx, &servicediscovery.GetServiceInput{Id: aws.String(ep.RegistryARN)})
+					if err != nil {
+						log.Debug("Failed to get Cloud Map service %s: %v", ep.RegistryARN, err)
+					} else if out.Service != nil {
+						reg = cloudMapService{
+							name:        aws.ToString(out.Service.Name),
+							namespaceID: aws.ToString(out.Service.NamespaceId),
+						}
+					}
+					c.cloudMapCache.setRegistry(ep.RegistryARN, reg)
+				}
+				if reg.name == "" {
+					continue
+				}
+				ep.Name = reg.name
+				if reg.namespaceID != "" {
+					ns := getNamespace(reg.namespaceID)
+					ep.Namespace = ns.name
+					if ns.dns {
+						ep.DNSName = ep.Name + "." + ns.name
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sestypes "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+const (
+	// maxSuppressedDestinations caps how many suppressed addresses are listed
+	maxSuppressedDestinations = 1000
+
+	// sesReputationWindow is how far back reputation metrics are looked up
+	sesReputationWindow = 24 * time.Hour
+)
+
+// GetSESAccount returns the sending status, quota and reputation of the account.
+// Reputation metrics are optional: they are only published once the account
+// has sent mail, and missing cloudwatch permissions just leave them out.
+func (c *Client) GetSESAccount(ctx context.Context) (*model.SESAccount, error) {
+	log.Debug("Getting SES account...")
+
+	out, err := c.ses.GetAccount(ctx, &sesv2.GetAccountInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SES account: %w", err)
+	}
+	reportProgress(ctx, "GetAccount", "calls", 1, 1)
+
+	account := &model.SESAccount{
+		SendingEnabled:    out.SendingEnabled,
+		ProductionAccess:  out.ProductionAccessEnabled,
+		EnforcementStatus: aws.ToString(out.EnforcementStatus),
+		ReputationPeriod:  "24h",
+	}
+	if q := out.SendQuota; q != nil {
+		account.Max24HourSend = q.Max24HourSend
+		account.MaxSendRate = q.MaxSendRate
+		account.SentLast24Hours = q.SentLast24Hours
+	}
+	if out.SuppressionAttributes != nil {
+		account.SuppressedReasons = suppressionReasons(out.SuppressionAttributes.SuppressedReasons)
+	}
+
+	bounce, okBounce := c.latestSESMetric(ctx, "Reputation.BounceRate")
+	complaint, okComplaint := c.latestSESMetric(ctx, "Reputation.ComplaintRate")
+	account.HasReputation = okBounce || okComplaint
+	account.BounceRate, account.ComplaintRate = bounce, complaint
+	return account, nil
+}
+
+// latestSESMetric returns the most recent value of an account-level SES metric.
+func (c *Client) latestSESMetric(ctx context.Context, name string) (float64, bool) {
+	now := time.Now()
+	out, err := c.cw.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/SES"),
+		MetricName: aws.String(name),
+		StartTime:  aws.Time(now.Add(-sesReputationWindow)),
+		EndTime:    aws.Time(now),
+		Period:     aws.Int32(3600),
+		Statistics: []cwtypes.Statistic{cwtypes.StatisticMaximum},
+	})
+	if err != nil {
+		log.Debug("Failed to get SES metric %s: %v", name, err)
+		return 0, false
+	}
+	if len(out.Datapoints) == 0 {
+		return 0, false
+	}
+
+	latest := out.Datapoints[0]
+	for _, dp := range out.Datapoints[1:] {
+		if aws.ToTime(dp.Timestamp).After(aws.ToTime(latest.Timestamp)) {
+			latest = dp
+		}
+	}
+	return aws.ToFloat64(latest.Maximum), true
+}
+
+// ListSESIdentities lists the email addresses and domains of the account.
+func (c *Client) ListSESIdentities(ctx context.Context) ([]model.SESIdentity, error) {
+	log.Debug("Listing SES identities...")
+
+	var identities []model.SESIdentity
+	paginator := sesv2.NewListEmailIdentitiesPaginator(c.ses, &sesv2.ListEmailIdentitiesInput{})
+	for page := 1; paginator.HasMorePages(); page++ {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SES identities: %w", err)
+		}
+		for _, id := range out.EmailIdentities {
+			identities = append(identities, model.SESIdentity{
+				Name:               aws.ToString(id.IdentityName),
+				Type:               string(id.IdentityType),
+				VerificationStatus: string(id.VerificationStatus),
+				SendingEnabled:     id.SendingEnabled,
+			})
+		}
+		reportProgress(ctx, "ListEmailIdentities", "pages", page, 0)
+	}
+
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i].Name < identities[j].Name
+	})
+	return identities, nil
+}
+
+// ListSESConfigurationSets lists configuration sets with their options and
+// event destinations.
+func (c *Client) ListSESConfigurationSets(ctx context.Context) ([]model.SESConfigurationSet, error) {
+	log.Debug("Listing SES configuration sets...")
+
+	var names []string
+	paginator := sesv2.NewListConfigurationSetsPaginator(c.ses, &sesv2.ListConfigurationSetsInput{})
+	for page := 1; paginator.HasMorePages(); page++ {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list configuration sets: %w", err)
+		}
+		names = append(names, out.ConfigurationSets...)
+		reportProgress(ctx, "ListConfigurationSets", "pages", page, 0)
+	}
+
+	sets := make([]model.SESConfigurationSet, len(names))
+	sem := c.slots(ConcurrencySES)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		done     int
+		firstErr error
+	)
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			set, err := c.describeConfigurationSet(ctx, name)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			sets[i] = set
+			done++
+			reportProgress(ctx, "GetConfigurationSet", "sets", done, len(names))
+		}(i, name)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].Name < sets[j].Name
+	})
+	return sets, nil
+}
+
+// describeConfigurationSet fetches a configuration set and its event destinations.
+func (c *Client) describeConfigurationSet(ctx context.Context, name string) (model.SESConfigurationSet, error) {
+	out, err := c.ses.GetConfigurationSet(ctx, &sesv2.GetConfigurationSetInput{
+		ConfigurationSetName: aws.String(name),
+	})
+	if err != nil {
+		return model.SESConfigurationSet{}, fmt.Errorf("failed to get configuration set %s: %w", name, err)
+	}
+
+	// Sending is enabled unless the set says otherwise
+	set := model.SESConfigurationSet{Name: name, SendingEnabled: true}
+	if out.SendingOptions != nil {
+		set.SendingEnabled = out.SendingOptions.SendingEnabled
+	}
+	if out.ReputationOptions != nil {
+		set.ReputationMetrics = out.ReputationOptions.ReputationMetricsEnabled
+		set.LastFreshStart = aws.ToTime(out.ReputationOptions.LastFreshStart)
+	}
+	if out.DeliveryOptions != nil {
+		set.TLSPolicy = string(out.DeliveryOptions.TlsPolicy)
+	}
+	if out.SuppressionOptions != nil {
+		set.SuppressedReasons = suppressionReasons(out.SuppressionOptions.SuppressedReasons)
+	}
+
+	dests, err := c.ses.GetConfigurationSetEventDestinations(ctx, &sesv2.GetConfigurationSetEventDestinationsInput{
+		ConfigurationSetName: aws.String(name),
+	})
+	if err != nil {
+		// Destinations are informational, keep the set without them
+		log.Debug("Failed to get event destinations of %s: %v", name, err)
+		return set, nil
+	}
+	for _, d := range dests.EventDestinations {
+		dest := model.SESEventDestination{
+			Name:    aws.ToString(d.Name),
+			Enabled: d.Enabled,
+			Target:  eventDestinationTarget(d),
+		}
+		for _, t := range d.MatchingEventTypes {
+			dest.EventTypes = append(dest.EventTypes, string(t))
+		}
+		set.EventDestinations = append(set.EventDestinations, dest)
+	}
+	return set, nil
+}
+
+// eventDestinationTarget describes where an event destination publishes to.
+func eventDestinationTarget(d sestypes.EventDestination) string {
+	switch {
+	case d.SnsDestination != nil:
+		return "SNS " + aws.ToString(d.SnsDestination.TopicArn)
+	case d.KinesisFirehoseDestination != nil:
+		return "Firehose " + aws.ToString(d.KinesisFirehoseDestination.DeliveryStreamArn)
+	case d.EventBridgeDestination != nil:
+		return "EventBridge " + aws.ToString(d.EventBridgeDestination.EventBusArn)
+	case d.CloudWatchDestination != nil:
+		return "CloudWatch"
+	case d.PinpointDestination != nil:
+		return "Pinpoint " + aws.ToString(d.PinpointDestination.ApplicationArn)
+	}
+	return ""
+}
+
+// suppressionReasons converts suppression list reasons to strings.
+func suppressionReasons(reasons []sestypes.SuppressionListReason) []string {
+	out := make([]string, len(reasons))
+	for i, r := range reasons {
+		out[i] = string(r)
+	}
+	return out
+}
+
+// ListSuppressedDestinations lists addresses on the account suppression list,
+// most recently suppressed first. Large lists are cut off at
+// maxSuppressedDestinations; the filter then only searches those.
+func (c *Client) ListSuppressedDestinations(ctx context.Context) ([]model.SESSuppressedDestination, bool, error) {
+	log.Debug("Listing SES suppressed destinations...")
+
+	var destinations []model.SESSuppressedDestination
+	truncated := false
+	paginator := sesv2.NewListSuppressedDestinationsPaginator(c.ses, &sesv2.ListSuppressedDestinationsInput{
+		PageSize: aws.Int32(100),
+	})
+	for page := 1; paginator.HasMorePages(); page++ {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to list suppressed destinations: %w", err)
+		}
+		for _, d := range out.SuppressedDestinationSummaries {
+			destinations = append(destinations, model.SESSuppressedDestination{
+				Email:       aws.ToString(d.EmailAddress),
+				Reason:      string(d.Reason),
+				LastUpdated: aws.ToTime(d.LastUpdateTime),
+			})
+		}
+		reportProgress(ctx, "ListSuppressedDestinations", "pages", page, 0)
+
+		if len(destinations) >= maxSuppressedDestinations {
+			truncated = paginator.HasMorePages() || len(destinations) > maxSuppressedDestinations
+			destinations = destinations[:min(len(destinations), maxSuppressedDestinations)]
+			break
+		}
+	}
+
+	sort.Slice(destinations, func(i, j int) bool {
+		return destinations[i].LastUpdated.After(destinations[j].LastUpdated)
+	})
+	return destinations, truncated, nil
+}
+
+// RemoveSuppressedDestination removes an address from the account suppression list.
+func (c *Client) RemoveSuppressedDestination(ctx context.Context, email string) error {
+	log.Info("Removing %s from the SES suppression list", email)
+
+	_, err := c.ses.DeleteSuppressedDestination(ctx, &sesv2.DeleteSuppressedDestinationInput{
+		EmailAddress: aws.String(email),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove %s from the suppression list: %w", email, err)
+	}
+	return nil
+}
+
+// SESTestSender returns the From address used for test emails sent from an
+// identity: the address itself, or a vaws-test address at a domain.
+func SESTestSender(identity model.SESIdentity) string {
+	if identity.IsEmail() {
+		return identity.Name
+	}
+	return "vaws-test@" + identity.Name
+}
+
+// SendTestEmail sends a short plain text email and returns its message ID.
+func (c *Client) SendTestEmail(ctx context.Context, from, to string) (string, error) {
+	log.Info("Sending SES test email from %s to %s", from, to)
+
+	body := fmt.Sprintf("Test email sent by vaws from %s in %s at %s.\n", from, c.region, time.Now().UTC().Format(time.RFC3339))
+	out, err := c.ses.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(from),
+		Destination:      &sestypes.Destination{ToAddresses: []string{to}},
+		Content: &sestypes.EmailContent{
+			Simple: &sestypes.Message{
+				Subject: &sestypes.Content{Data: aws.String("vaws test email")},
+				Body:    &sestypes.Body{Text: &sestypes.Content{Data: aws.String(body)}},
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send test email: %w", err)
+	}
+	return aws.ToString(out.MessageId), nil
+}
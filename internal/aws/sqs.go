@@ -18,9 +18,6 @@ import (
 	"vaws/internal/model"
 )
 
-// maxConcurrentSQSCalls limits concurrent API calls to avoid throttling
-const maxConcurrentSQSCalls = 10
-
 // redrivePolicy represents the JSON structure of SQS RedrivePolicy.
 type redrivePolicy struct {
 	DeadLetterTargetArn string `json:"deadLetterTargetArn"`
@@ -57,7 +54,7 @@ func (c *Client) ListQueues(ctx context.Context) ([]model.Queue, error) {
 	}
 
 	results := make(chan queueResult, len(queueURLs))
-	sem := make(chan struct{}, maxConcurrentSQSCalls) // Limit concurrency
+	sem := c.slots(ConcurrencySQS) // Limit concurrency
 
 	var wg sync.WaitGroup
 	for i, url := range queueURLs {
@@ -125,6 +122,66 @@ func (c *Client) GetQueueAttributes(ctx context.Context, queueURL string) (*mode
 	return convertQueueAttributes(queueURL, out.Attributes), nil
 }
 
+// ReceiveMessages receives up to 10 messages from a queue without deleting
+// them. They stay hidden from other consumers for visibilityTimeout seconds,
+// and each receive counts towards the queue's maxReceiveCount.
+func (c *Client) ReceiveMessages(ctx context.Context, queueURL string, visibilityTimeout int32) ([]model.QueueMessage, error) {
+	out, err := c.sqs.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:                    aws.String(queueURL),
+		MaxNumberOfMessages:         10,
+		VisibilityTimeout:           visibilityTimeout,
+		MessageSystemAttributeNames: []sqstypes.MessageSystemAttributeName{sqstypes.MessageSystemAttributeNameAll},
+		MessageAttributeNames:       []string{"All"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive messages: %w", err)
+	}
+
+	messages := make([]model.QueueMessage, 0, len(out.Messages))
+	for _, msg := range out.Messages {
+		m := model.QueueMessage{
+			ID:         aws.ToString(msg.MessageId),
+			Body:       aws.ToString(msg.Body),
+			Attributes: msg.Attributes,
+		}
+		if ms, err := strconv.ParseInt(msg.Attributes[string(sqstypes.MessageSystemAttributeNameSentTimestamp)], 10, 64); err == nil {
+			m.SentAt = time.UnixMilli(ms)
+		}
+		m.ReceiveCount, _ = strconv.Atoi(msg.Attributes[string(sqstypes.MessageSystemAttributeNameApproximateReceiveCount)])
+		for name, attr := range msg.MessageAttributes {
+			if attr.StringValue != nil {
+				if m.MessageAttributes == nil {
+					m.MessageAttributes = make(map[string]string)
+				}
+				m.MessageAttributes[name] = *attr.StringValue
+			}
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// SendMessage sends a message to a queue and returns its ID. The message
+// group and deduplication IDs are left out when empty, as for standard
+// queues.
+func (c *Client) SendMessage(ctx context.Context, queueURL, body, groupID, dedupID string) (string, error) {
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(queueURL),
+		MessageBody: aws.String(body),
+	}
+	if groupID != "" {
+		input.MessageGroupId = aws.String(groupID)
+	}
+	if dedupID != "" {
+		input.MessageDeduplicationId = aws.String(dedupID)
+	}
+	out, err := c.sqs.SendMessage(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to send message: %w", err)
+	}
+	return aws.ToString(out.MessageId), nil
+}
+
 // GetQueuesFromStack returns SQS queue URLs from a CloudFormation stack.
 func (c *Client) GetQueuesFromStack(ctx context.Context, stackName string) ([]string, error) {
 	log.Debug("Getting SQS queues from stack: %s", stackName)
@@ -158,10 +215,20 @@ func convertQueueAttributes(url string, attrs map[string]string) *model.Queue {
 		queue.ARN = arn
 	}
 
-	// Determine queue type from name (FIFO queues end with .fifo)
-	if strings.HasSuffix(queue.Name, ".fifo") {
+	// Determine queue type from the FifoQueue attribute, or the name if it is
+	// missing (FIFO queues end with .fifo)
+	if val, ok := attrs[string(sqstypes.QueueAttributeNameFifoQueue)]; ok {
+		if val == "true" {
+			queue.Type = model.QueueTypeFIFO
+		}
+	} else if strings.HasSuffix(queue.Name, ".fifo") {
 		queue.Type = model.QueueTypeFIFO
 	}
+	if queue.Type == model.QueueTypeFIFO {
+		queue.ContentBasedDeduplication = attrs[string(sqstypes.QueueAttributeNameContentBasedDeduplication)] == "true"
+		queue.DeduplicationScope = attrs[string(sqstypes.QueueAttributeNameDeduplicationScope)]
+		queue.FifoThroughputLimit = attrs[string(sqstypes.QueueAttributeNameFifoThroughputLimit)]
+	}
 
 	// Parse message counts
 	if val, ok := attrs[string(sqstypes.QueueAttributeNameApproximateNumberOfMessages)]; ok {
@@ -256,7 +323,7 @@ func (c *Client) fetchQueueAttributesBatch(ctx context.Context, queueURLs []stri
 	}
 
 	results := make(chan queueResult, len(queueURLs))
-	sem := make(chan struct{}, maxConcurrentSQSCalls)
+	sem := c.slots(ConcurrencySQS)
 
 	var wg sync.WaitGroup
 	for i, url := range queueURLs {
@@ -0,0 +1,69 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go-v2/service/ssooidc"
+
+	"vaws/internal/model"
+)
+
+// ssoCachedToken holds the fields of an SSO token cache file that tell
+// whether the token is still valid and can be refreshed.
+type ssoCachedToken struct {
+	AccessToken  string    `json:"accessToken"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	RefreshToken string    `json:"refreshToken"`
+	ClientID     string    `json:"clientId"`
+	ClientSecret string    `json:"clientSecret"`
+}
+
+// SSOTokenStatus reads the cached token an SSO profile signs in with, as
+// written by aws sso login to ~/.aws/sso/cache. A cache that can't be read
+// counts as no token.
+func SSOTokenStatus(p model.AWSProfile) (model.SSOToken, error) {
+	path, err := ssocreds.StandardCachedTokenFilepath(p.SSOSession)
+	if err != nil {
+		return model.SSOToken{}, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return model.SSOToken{}, nil
+	} else if err != nil {
+		return model.SSOToken{}, fmt.Errorf("failed to read SSO token cache: %w", err)
+	}
+
+	var cached ssoCachedToken
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return model.SSOToken{}, fmt.Errorf("failed to parse SSO token cache %s: %w", path, err)
+	}
+	return model.SSOToken{
+		Found:       cached.AccessToken != "",
+		ExpiresAt:   cached.ExpiresAt,
+		Refreshable: cached.RefreshToken != "" && cached.ClientID != "" && cached.ClientSecret != "",
+	}, nil
+}
+
+// RefreshSSOToken trades the refresh token of an expired SSO token for a new
+// one and stores it in the cache, so the profile signs in without a browser
+// login. Tokens of legacy profiles without an sso-session have no refresh
+// token and need aws sso login.
+func RefreshSSOToken(ctx context.Context, p model.AWSProfile) (model.SSOToken, error) {
+	if p.SSORegion == "" {
+		return model.SSOToken{}, fmt.Errorf("profile %s has no sso_region", p.Name)
+	}
+	path, err := ssocreds.StandardCachedTokenFilepath(p.SSOSession)
+	if err != nil {
+		return model.SSOToken{}, err
+	}
+	client := ssooidc.New(ssooidc.Options{Region: p.SSORegion})
+	if _, err := ssocreds.NewSSOTokenProvider(client, path).RetrieveBearerToken(ctx); err != nil {
+		return model.SSOToken{}, err
+	}
+	return SSOTokenStatus(p)
+}
@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"context"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// taggingResourcesPerPage is the most resources GetResources returns a page.
+const taggingResourcesPerPage = 100
+
+// GetTaggedResources returns the ARNs of the resources in the region that
+// carry the tag of filter, from the Resource Groups Tagging API. Resources
+// of every service are read in one go, however many views are scoped.
+func (c *Client) GetTaggedResources(ctx context.Context, filter model.TagFilter) ([]string, error) {
+	tagFilter := map[string]any{"Key": filter.Key}
+	if len(filter.Values) > 0 {
+		tagFilter["Values"] = filter.Values
+	}
+	body := map[string]any{
+		"TagFilters":       []map[string]any{tagFilter},
+		"ResourcesPerPage": taggingResourcesPerPage,
+	}
+
+	var arns []string
+	pages := 0
+	for {
+		var out struct {
+			ResourceTagMappingList []struct {
+				ResourceARN string `json:"ResourceARN"`
+			} `json:"ResourceTagMappingList"`
+			PaginationToken string `json:"PaginationToken"`
+		}
+		if err := c.callJSON(ctx, "tagging", "ResourceGroupsTaggingAPI_20170126.GetResources", body, &out); err != nil {
+			return nil, err
+		}
+		pages++
+		reportProgress(ctx, "GetResources", "pages", pages, 0)
+		for _, r := range out.ResourceTagMappingList {
+			arns = append(arns, r.ResourceARN)
+		}
+		if out.PaginationToken == "" {
+			break
+		}
+		body["PaginationToken"] = out.PaginationToken
+	}
+
+	log.Debug("Found %d resources tagged %s", len(arns), filter)
+	return arns, nil
+}
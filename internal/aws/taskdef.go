@@ -0,0 +1,116 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+
+	"vaws/internal/log"
+)
+
+// taskDefMetadataFields change with every registration and are left out of
+// task definition documents so diffs only show configuration changes.
+var taskDefMetadataFields = []string{
+	"TaskDefinitionArn", "Revision", "Status", "RegisteredAt", "RegisteredBy",
+	"DeregisteredAt", "RequiresAttributes", "Compatibilities",
+}
+
+// GetTaskDefinitionDocument returns a task definition as indented JSON with
+// registration metadata and empty fields removed. Keys are sorted, so two
+// revisions can be compared line by line.
+func (c *Client) GetTaskDefinitionDocument(ctx context.Context, taskDef string) (string, error) {
+	log.Debug("Describing task definition %s...", taskDef)
+
+	out, err := c.ecs.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(taskDef),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe task definition %s: %w", taskDef, err)
+	}
+	if out.TaskDefinition == nil {
+		return "", fmt.Errorf("task definition %s not found", taskDef)
+	}
+
+	raw, err := json.Marshal(out.TaskDefinition)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode task definition: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return "", fmt.Errorf("failed to encode task definition: %w", err)
+	}
+	for _, field := range taskDefMetadataFields {
+		delete(doc, field)
+	}
+
+	pretty, err := json.MarshalIndent(pruneEmpty(doc), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode task definition: %w", err)
+	}
+	return string(pretty), nil
+}
+
+// pruneEmpty removes nulls, empty strings and empty collections, which the
+// SDK emits for every unset field.
+func pruneEmpty(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			child = pruneEmpty(child)
+			if isEmptyJSON(child) {
+				delete(val, k)
+			} else {
+				val[k] = child
+			}
+		}
+		return val
+	case []interface{}:
+		out := val[:0]
+		for _, child := range val {
+			child = pruneEmpty(child)
+			if !isEmptyJSON(child) {
+				out = append(out, child)
+			}
+		}
+		return out
+	}
+	return v
+}
+
+// isEmptyJSON reports whether a decoded JSON value carries no information.
+func isEmptyJSON(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case string:
+		return val == ""
+	case map[string]interface{}:
+		return len(val) == 0
+	case []interface{}:
+		return len(val) == 0
+	}
+	return false
+}
+
+// PreviousTaskDefinition returns the family:revision identifier of the
+// revision before taskDefARN, e.g. "orders:11" for ".../orders:12".
+func PreviousTaskDefinition(taskDefARN string) (string, bool) {
+	name := taskDefARN
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	i := strings.LastIndex(name, ":")
+	if i < 0 {
+		return "", false
+	}
+	revision, err := strconv.Atoi(name[i+1:])
+	if err != nil || revision <= 1 {
+		return "", false
+	}
+	return fmt.Sprintf("%s:%d", name[:i], revision-1), true
+}
@@ -0,0 +1,188 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+
+	"vaws/internal/log"
+	"vaws/internal/metrics"
+	"vaws/internal/model"
+)
+
+// terraformState is the part of a Terraform state file vaws reads. Version 4
+// is written by Terraform 0.12 and later, and by OpenTofu.
+type terraformState struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"` // managed or data
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   any            `json:"index_key"` // Number for count, string for for_each
+			Attributes map[string]any `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// s3Error is the error body of S3.
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// LoadTerraformState reads a Terraform state from a local file or an
+// s3://bucket/key URL and returns its managed resources that have an ARN.
+// A state is only read, never locked, so it may be a moment behind a running
+// apply.
+func (c *Client) LoadTerraformState(ctx context.Context, location string) ([]model.TerraformResource, error) {
+	var data []byte
+	var err error
+	if bucket, key, ok := parseS3URL(location); ok {
+		data, err = c.getS3Object(ctx, bucket, key, c.region)
+	} else {
+		data, err = os.ReadFile(expandHome(location))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseTerraformState(data, location)
+}
+
+// parseTerraformState returns the managed resources of a state with the ARNs
+// in their attributes. Data sources are skipped, since they are owned
+// elsewhere.
+func parseTerraformState(data []byte, location string) ([]model.TerraformResource, error) {
+	var state terraformState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("not a Terraform state: %w", err)
+	}
+	if state.Version != 4 {
+		return nil, fmt.Errorf("unsupported state version %d (Terraform 0.12 and later write 4)", state.Version)
+	}
+
+	var resources []model.TerraformResource
+	for _, r := range state.Resources {
+		if r.Mode != "managed" {
+			continue
+		}
+		for _, inst := range r.Instances {
+			var arns []string
+			for _, attr := range []string{"arn", "id"} {
+				if v, ok := inst.Attributes[attr].(string); ok && strings.HasPrefix(v, "arn:") && (len(arns) == 0 || arns[0] != v) {
+					arns = append(arns, v)
+				}
+			}
+			if len(arns) == 0 {
+				continue
+			}
+			address := r.Type + "." + r.Name
+			switch key := inst.IndexKey.(type) {
+			case float64:
+				address += "[" + strconv.FormatFloat(key, 'f', -1, 64) + "]"
+			case string:
+				address += "[" + strconv.Quote(key) + "]"
+			}
+			if r.Module != "" {
+				address = r.Module + "." + address
+			}
+			resources = append(resources, model.TerraformResource{Address: address, Module: r.Module, ARNs: arns, State: location})
+		}
+	}
+	return resources, nil
+}
+
+// parseS3URL splits an s3://bucket/key URL.
+func parseS3URL(location string) (bucket, key string, ok bool) {
+	rest, ok := strings.CutPrefix(location, "s3://")
+	if !ok {
+		return "", "", false
+	}
+	bucket, key, _ = strings.Cut(rest, "/")
+	return bucket, key, bucket != "" && key != ""
+}
+
+// expandHome replaces a leading ~ of a path with the home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// getS3Object downloads an object with a SigV4-signed GET. vaws has no S3
+// client for the one object it reads. Buckets in another region answer with
+// a redirect naming theirs, which is followed once.
+func (c *Client) getS3Object(ctx context.Context, bucket, key, region string) ([]byte, error) {
+	var escaped []string
+	for _, part := range strings.Split(key, "/") {
+		escaped = append(escaped, url.PathEscape(part))
+	}
+	u := &url.URL{
+		Scheme:  "https",
+		Host:    fmt.Sprintf("s3.%s.amazonaws.com", region),
+		Path:    "/" + bucket + "/" + key,
+		RawPath: "/" + url.PathEscape(bucket) + "/" + strings.Join(escaped, "/"),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	payloadHash := sha256Hex(nil)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	// S3 signs the path as sent rather than escaping it again
+	signer := v4.NewSigner(func(o *v4.SignerOptions) { o.DisableURIPathEscaping = true })
+	if err := signer.SignHTTP(ctx, creds, req, payloadHash, "s3", region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	log.Debug("GET s3://%s/%s", bucket, key)
+	start := time.Now()
+	resp, err := c.httpClient().Do(req)
+	callErr := err
+	if err == nil && resp.StatusCode >= 300 {
+		callErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	metrics.Default().ObserveAWSCall("s3", "GetObject", time.Since(start), callErr)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read s3://%s/%s: %w", bucket, key, err)
+	}
+	if resp.StatusCode >= 300 {
+		if other := resp.Header.Get("X-Amz-Bucket-Region"); other != "" && other != region {
+			return c.getS3Object(ctx, bucket, key, other)
+		}
+		var apiErr s3Error
+		_ = xml.Unmarshal(data, &apiErr)
+		if apiErr.Code == "" {
+			return nil, fmt.Errorf("s3://%s/%s: %s (HTTP %d)", bucket, key, http.StatusText(resp.StatusCode), resp.StatusCode)
+		}
+		return nil, fmt.Errorf("s3://%s/%s: %s: %s (HTTP %d)", bucket, key, apiErr.Code, apiErr.Message, resp.StatusCode)
+	}
+	return data, nil
+}
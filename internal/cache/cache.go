@@ -0,0 +1,99 @@
+// Package cache keeps the resource lists of the last run on disk, per
+// profile and region, so the next run shows them at once while they load.
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"vaws/internal/model"
+)
+
+// MaxAge is how old a snapshot can be and still be shown. Older ones are
+// more likely to mislead than to help.
+const MaxAge = 7 * 24 * time.Hour
+
+// Snapshot is the read-only resource lists last loaded for a profile and
+// region. Only lists loaded in full are kept: services by the cluster or
+// stack they were listed for, functions only when not scoped to a stack.
+type Snapshot struct {
+	SavedAt   time.Time                  `json:"saved_at"`
+	Stacks    []model.Stack              `json:"stacks,omitempty"`
+	Clusters  []model.Cluster            `json:"clusters,omitempty"`
+	Services  map[string][]model.Service `json:"services,omitempty"`
+	Functions []model.Function           `json:"functions,omitempty"`
+	Tables    []model.Table              `json:"tables,omitempty"`
+}
+
+// Dir returns the directory of the snapshots.
+func Dir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".vaws", "cache")
+}
+
+// Path returns the file of the snapshot of a profile and region. account
+// is set for a member account assumed from the profile, which has resources
+// of its own.
+func Path(profile, region, account string) string {
+	name := region
+	if account != "" {
+		name += "-" + account
+	}
+	return filepath.Join(Dir(), safeName(profile), safeName(name)+".json")
+}
+
+// safeName keeps a profile name from leaving the cache directory.
+func safeName(s string) string {
+	s = strings.NewReplacer("/", "_", `\`, "_", "..", "_").Replace(s)
+	if s == "" {
+		return "_"
+	}
+	return s
+}
+
+// Load reads the snapshot of a profile and region. A missing, unreadable
+// or expired snapshot yields false.
+func Load(profile, region, account string) (Snapshot, bool) {
+	var snap Snapshot
+	data, err := os.ReadFile(Path(profile, region, account))
+	if err != nil {
+		return Snapshot{}, false
+	}
+	if err := json.Unmarshal(data, &snap); err != nil || time.Since(snap.SavedAt) > MaxAge {
+		return Snapshot{}, false
+	}
+	return snap, true
+}
+
+// Save writes the snapshot of a profile and region. It goes to a temporary
+// file first, so a run reading it meanwhile never sees half of it.
+func Save(profile, region, account string, snap Snapshot) error {
+	path := Path(profile, region, account)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
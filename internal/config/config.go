@@ -2,8 +2,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"gopkg.in/yaml.v3"
@@ -16,8 +18,100 @@ type Config struct {
 
 	// Defaults contains default settings applied to all profiles
 	Defaults DefaultConfig `yaml:"defaults"`
+
+	// Terminal controls the window title and notifications
+	Terminal TerminalConfig `yaml:"terminal,omitempty"`
+
+	// Display controls how timestamps and counts are shown
+	Display DisplayConfig `yaml:"display,omitempty"`
+
+	// Environments are named targets switched to at once with :env, keyed by name
+	Environments map[string]EnvironmentConfig `yaml:"environments,omitempty"`
+
+	// Macros are recorded key sequences replayed with :macro or their own key
+	Macros []MacroConfig `yaml:"macros,omitempty"`
+
+	// Highlights are rules styling list rows, keyed by kind of resource
+	// (e.g., services: ["running < desired -> red bold"])
+	Highlights map[string][]string `yaml:"highlights,omitempty"`
+
+	// Columns are the columns resource tables show after the name, in order,
+	// keyed by kind of resource (e.g., services: [launch_type, task_definition])
+	Columns map[string][]string `yaml:"columns,omitempty"`
 }
 
+// MacroConfig is a named sequence of keys replayed as if typed
+type MacroConfig struct {
+	// Name is what the macro is replayed by with :macro <name>
+	Name string `yaml:"name"`
+
+	// Key optionally binds the macro to a key (e.g., "f2" or "ctrl+o")
+	Key string `yaml:"key,omitempty"`
+
+	// Keys are the keys in the order they are replayed (e.g., "1", "enter", "/", "o")
+	Keys []string `yaml:"keys"`
+}
+
+// EnvironmentConfig is a named combination of profile, region and filters,
+// such as staging or production
+type EnvironmentConfig struct {
+	// Profile is the AWS profile to switch to
+	Profile string `yaml:"profile"`
+
+	// Region overrides the profile's region
+	Region string `yaml:"region,omitempty"`
+
+	// StackPattern limits the stacks listed to names matching a glob (e.g., "staging-*")
+	StackPattern string `yaml:"stack_pattern,omitempty"`
+
+	// JumpHostTag overrides the profile's jump host tag for tunnels
+	JumpHostTag string `yaml:"jump_host_tag,omitempty"`
+}
+
+// DisplayConfig controls how timestamps and counts are shown
+type DisplayConfig struct {
+	// Time is "relative" (3m ago, the default) or "absolute" (2006-01-02 15:04:05)
+	Time string `yaml:"time,omitempty"`
+
+	// Timezone is "local" (the default) or "utc" for absolute times
+	Timezone string `yaml:"timezone,omitempty"`
+
+	// Numbers is "compact" (1.2k, the default) or "full" (1,234)
+	Numbers string `yaml:"numbers,omitempty"`
+}
+
+// Display preference values
+const (
+	TimeRelative   = "relative"
+	TimeAbsolute   = "absolute"
+	TimezoneLocal  = "local"
+	TimezoneUTC    = "utc"
+	NumbersCompact = "compact"
+	NumbersFull    = "full"
+)
+
+// TerminalConfig controls integration with the terminal emulator and tmux
+type TerminalConfig struct {
+	// NoTitle stops vaws from setting the window (or tmux pane) title
+	NoTitle bool `yaml:"no_title,omitempty"`
+
+	// Notify selects the notification escape sequence (osc9, osc777 or off)
+	Notify string `yaml:"notify,omitempty"`
+
+	// ASCII replaces emoji, box drawing and other Unicode symbols with ASCII
+	ASCII bool `yaml:"ascii,omitempty"`
+
+	// StatusText adds OK/WARN/ERR tags to colored statuses
+	StatusText bool `yaml:"status_text,omitempty"`
+}
+
+// Terminal notification escape sequences
+const (
+	NotifyOSC9   = "osc9"   // iTerm2, WezTerm, Windows Terminal, ConEmu
+	NotifyOSC777 = "osc777" // urxvt, foot, Ghostty, VTE-based terminals
+	NotifyOff    = "off"
+)
+
 // ProfileConfig contains settings for a specific AWS profile
 type ProfileConfig struct {
 	// JumpHost is the EC2 instance name or ID to use for private API Gateway access
@@ -32,6 +126,155 @@ type ProfileConfig struct {
 	// VPCEndpointID is the VPC endpoint ID for cross-account private API Gateway access
 	// When set, uses URL format: https://<api-id>-<vpce-id>.execute-api.<region>.amazonaws.com
 	VPCEndpointID string `yaml:"vpc_endpoint_id,omitempty"`
+
+	// ProxyRules are request rules for API Gateway proxy tunnels, keyed by API name or ID
+	ProxyRules map[string]ProxyRulesConfig `yaml:"proxy_rules,omitempty"`
+
+	// ProxyTLS makes API Gateway proxies serve HTTPS using a local CA
+	ProxyTLS bool `yaml:"proxy_tls,omitempty"`
+
+	// Mocks are example responses of API Gateway mock servers, keyed by API
+	// name or ID, then by route (e.g., "GET /orders/{id}")
+	Mocks map[string]map[string]MockResponseConfig `yaml:"mocks,omitempty"`
+
+	// TunnelHealthPath is probed through tunnels of this profile (enables probing)
+	TunnelHealthPath string `yaml:"tunnel_health_path,omitempty"`
+
+	// TunnelStrategy is how ECS tunnels are established (auto, port_forward or exec)
+	TunnelStrategy string `yaml:"tunnel_strategy,omitempty"`
+
+	// Allow restricts which action categories are enabled (e.g., [read, tunnel])
+	// When empty, all actions are allowed
+	Allow []string `yaml:"allow,omitempty"`
+
+	// Monitor lists the panels of the monitor dashboard, in display order
+	Monitor []MonitorPanelConfig `yaml:"monitor,omitempty"`
+
+	// ResourceTypes are extra resource types browsed via Cloud Control (e.g., AWS::MSK::Cluster)
+	ResourceTypes []string `yaml:"resource_types,omitempty"`
+
+	// DLQExports are dead-letter queues whose new messages are saved to files while vaws runs
+	DLQExports []DLQExportConfig `yaml:"dlq_exports,omitempty"`
+
+	// Watches are conditions on services and queues that raise alerts while vaws runs
+	Watches []WatchConfig `yaml:"watches,omitempty"`
+
+	// Concurrency overrides the concurrency limits of the defaults for this profile
+	Concurrency map[string]int `yaml:"concurrency,omitempty"`
+
+	// TerraformStates are state files, local paths or s3:// URLs, whose addresses annotate resources
+	TerraformStates []string `yaml:"terraform_states,omitempty"`
+
+	// OrgRole is the role :accounts assumes in member accounts of the organization
+	OrgRole string `yaml:"org_role,omitempty"`
+}
+
+// DefaultOrgRole is the role assumed in member accounts, the one AWS
+// Organizations creates in the accounts it creates
+const DefaultOrgRole = "OrganizationAccountAccessRole"
+
+// Action categories that can be restricted per profile with allow
+const (
+	ActionRead   = "read"   // Browsing, logs, queries and scans (always allowed)
+	ActionTunnel = "tunnel" // Port forwarding and API Gateway proxies
+	ActionInvoke = "invoke" // Lambda invocation
+	ActionWrite  = "write"  // Actions that modify AWS resources
+	ActionShell  = "shell"  // Interactive shells via ECS Exec and Session Manager
+)
+
+// MonitorPanelConfig is a live panel of the monitor dashboard
+type MonitorPanelConfig struct {
+	// Kind is what the panel shows (tasks, logs, queue, lambda_logs or alarms)
+	Kind string `yaml:"kind"`
+
+	// Cluster and Service identify the ECS service of tasks and logs panels
+	Cluster string `yaml:"cluster,omitempty"`
+	Service string `yaml:"service,omitempty"`
+
+	// Queue is the URL of the SQS queue of queue panels
+	Queue string `yaml:"queue,omitempty"`
+
+	// Function is the Lambda function name of lambda_logs panels
+	Function string `yaml:"function,omitempty"`
+
+	// Interval overrides how often the panel refreshes (e.g., 30s)
+	Interval string `yaml:"interval,omitempty"`
+}
+
+// Monitor panel kinds
+const (
+	MonitorTasks      = "tasks"       // Running/desired task counts and deployments of an ECS service
+	MonitorLogs       = "logs"        // Log tail of an ECS service
+	MonitorQueue      = "queue"       // Message counts of an SQS queue
+	MonitorLambdaLogs = "lambda_logs" // Log tail of a Lambda function
+	MonitorAlarms     = "alarms"      // CloudWatch alarms, those firing first
+)
+
+// WatchConfig raises an alert when an ECS service or an SQS queue meets a
+// condition
+type WatchConfig struct {
+	// Name labels the alert, the resource and condition if empty
+	Name string `yaml:"name,omitempty"`
+
+	// Cluster and Service identify the ECS service watched
+	Cluster string `yaml:"cluster,omitempty"`
+	Service string `yaml:"service,omitempty"`
+
+	// Queue is the URL of the SQS queue watched
+	Queue string `yaml:"queue,omitempty"`
+
+	// When is the condition, as in highlight rules, optionally held for a
+	// while (e.g., "running < desired for 5m")
+	When string `yaml:"when"`
+
+	// Interval is how often the resource is checked (e.g., 1m), 30s if empty
+	Interval string `yaml:"interval,omitempty"`
+
+	// Notify sends a terminal notification when the alert is raised
+	Notify bool `yaml:"notify,omitempty"`
+}
+
+// DLQExportConfig saves the messages arriving in a dead-letter queue to a
+// JSONL file
+type DLQExportConfig struct {
+	// Queue is the URL of the queue; if it has a dead-letter queue, that one is exported
+	Queue string `yaml:"queue"`
+
+	// Interval is how often the queue is checked (e.g., 5m), 1m if empty
+	Interval string `yaml:"interval,omitempty"`
+
+	// Dir is where the files are written, ~/.vaws/dlq if empty
+	Dir string `yaml:"dir,omitempty"`
+
+	// Notify sends a desktop notification when new messages are saved
+	Notify bool `yaml:"notify,omitempty"`
+}
+
+// ProxyRulesConfig contains request rules applied by a local API Gateway proxy
+type ProxyRulesConfig struct {
+	// Headers are set on every forwarded request (e.g., x-api-key)
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// Rewrites replace a path prefix before the request is forwarded
+	Rewrites []RewriteConfig `yaml:"rewrites,omitempty"`
+}
+
+// MockResponseConfig is the example response a mock server gives for a route
+type MockResponseConfig struct {
+	// Status is the HTTP status code, 200 if 0
+	Status int `yaml:"status,omitempty"`
+
+	// Headers are set on the response (e.g., Content-Type)
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// Body is the response body, e.g. a JSON document
+	Body string `yaml:"body,omitempty"`
+}
+
+// RewriteConfig is a path prefix rewrite rule
+type RewriteConfig struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
 }
 
 // DefaultConfig contains default settings
@@ -43,8 +286,61 @@ type DefaultConfig struct {
 	// JumpHostNames are instance names to search for when auto-discovering
 	// Priority order: first match wins
 	JumpHostNames []string `yaml:"jump_host_names,omitempty"`
+
+	// ProxyTLS makes API Gateway proxies serve HTTPS for all profiles
+	ProxyTLS bool `yaml:"proxy_tls,omitempty"`
+
+	// TunnelHealthCheck probes an HTTP health path through tunnels once they start
+	TunnelHealthCheck bool `yaml:"tunnel_health_check,omitempty"`
+
+	// TunnelHealthPath is the path probed, /health if empty
+	TunnelHealthPath string `yaml:"tunnel_health_path,omitempty"`
+
+	// TunnelStrategy is how ECS tunnels are established for all profiles, auto if empty
+	TunnelStrategy string `yaml:"tunnel_strategy,omitempty"`
+
+	// ResourceTypes are resource types browsed via Cloud Control for all profiles
+	ResourceTypes []string `yaml:"resource_types,omitempty"`
+
+	// FavoriteRegions are pinned to the top of the region selector, with p
+	FavoriteRegions []string `yaml:"favorite_regions,omitempty"`
+
+	// StackGroupTag groups the stacks list by this tag key, or by name prefix if "prefix"
+	StackGroupTag string `yaml:"stack_group_tag,omitempty"`
+
+	// StartView is the view shown on start: "menu" (the default) or "health"
+	StartView string `yaml:"start_view,omitempty"`
+
+	// FastStart skips the splash and holds back the Terraform states and the
+	// check for the AWS CLI until a view is opened from the main menu
+	FastStart bool `yaml:"fast_start,omitempty"`
+
+	// NoWarmCache stops vaws from keeping the stacks, clusters, services,
+	// functions and tables of the last run in ~/.vaws/cache to show at start
+	NoWarmCache bool `yaml:"no_warm_cache,omitempty"`
+
+	// ScanWarnSizeMB asks before unfiltered scans of DynamoDB tables larger
+	// than this, 1024 if 0; a negative value never asks
+	ScanWarnSizeMB int64 `yaml:"scan_warn_size_mb,omitempty"`
+
+	// Concurrency limits the AWS calls a service's loaders make at once, keyed
+	// by service (e.g., sqs: 2); "default" applies to services not listed
+	Concurrency map[string]int `yaml:"concurrency,omitempty"`
 }
 
+// ECS tunnel strategies
+const (
+	TunnelStrategyAuto        = "auto"         // Port forwarding, or an ECS Exec relay if its documents are denied
+	TunnelStrategyPortForward = "port_forward" // SSM port forwarding documents only
+	TunnelStrategyExec        = "exec"         // Always relay through socat, ncat or nc in the container
+)
+
+// Start views
+const (
+	StartViewMenu   = "menu"
+	StartViewHealth = "health"
+)
+
 var (
 	globalConfig *Config
 	configOnce   sync.Once
@@ -141,6 +437,84 @@ func (c *Config) GetProfileConfig(profile string) ProfileConfig {
 	return ProfileConfig{}
 }
 
+// GetEnvironment returns the environment with the given name
+func (c *Config) GetEnvironment(name string) (EnvironmentConfig, bool) {
+	env, ok := c.Environments[name]
+	return env, ok
+}
+
+// EnvironmentNames returns the names of the configured environments, sorted
+func (c *Config) EnvironmentNames() []string {
+	names := make([]string, 0, len(c.Environments))
+	for name := range c.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetMacro returns the macro with the given name
+func (c *Config) GetMacro(name string) (MacroConfig, bool) {
+	for _, m := range c.Macros {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return MacroConfig{}, false
+}
+
+// GetMacroByKey returns the macro bound to a key
+func (c *Config) GetMacroByKey(key string) (MacroConfig, bool) {
+	for _, m := range c.Macros {
+		if m.Key != "" && m.Key == key {
+			return m, true
+		}
+	}
+	return MacroConfig{}, false
+}
+
+// SetMacro adds a macro, replacing the one with the same name
+func (c *Config) SetMacro(macro MacroConfig) {
+	for i, m := range c.Macros {
+		if m.Name == macro.Name {
+			c.Macros[i] = macro
+			return
+		}
+	}
+	c.Macros = append(c.Macros, macro)
+}
+
+// DeleteMacro removes a macro, returning false if there is none with that name
+func (c *Config) DeleteMacro(name string) bool {
+	for i, m := range c.Macros {
+		if m.Name == name {
+			c.Macros = append(c.Macros[:i], c.Macros[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetColumns returns the columns chosen for a kind of resource, and false if
+// none were, so the table shows its default columns
+func (c *Config) GetColumns(kind string) ([]string, bool) {
+	columns, ok := c.Columns[kind]
+	return columns, ok
+}
+
+// SetColumns sets the columns of a kind of resource, or restores its default
+// columns when columns is nil. An empty, non-nil list shows the name alone
+func (c *Config) SetColumns(kind string, columns []string) {
+	if columns == nil {
+		delete(c.Columns, kind)
+		return
+	}
+	if c.Columns == nil {
+		c.Columns = make(map[string][]string)
+	}
+	c.Columns[kind] = columns
+}
+
 // GetJumpHost returns the configured jump host for a profile
 // Returns empty string if not configured
 func (c *Config) GetJumpHost(profile string) string {
@@ -172,6 +546,270 @@ func (c *Config) GetVPCEndpointID(profile string) string {
 	return ""
 }
 
+// GetMockResponses returns the example responses of an API's mock server by
+// route, matched by ID first, then name
+func (c *Config) GetMockResponses(profile, apiID, apiName string) map[string]MockResponseConfig {
+	if pc, ok := c.Profiles[profile]; ok {
+		if mocks, ok := pc.Mocks[apiID]; ok {
+			return mocks
+		}
+		if mocks, ok := pc.Mocks[apiName]; ok {
+			return mocks
+		}
+	}
+	return nil
+}
+
+// GetProxyRules returns the configured proxy rules for an API, matched by ID first, then name
+func (c *Config) GetProxyRules(profile, apiID, apiName string) (ProxyRulesConfig, bool) {
+	if pc, ok := c.Profiles[profile]; ok {
+		if rules, ok := pc.ProxyRules[apiID]; ok {
+			return rules, true
+		}
+		if rules, ok := pc.ProxyRules[apiName]; ok {
+			return rules, true
+		}
+	}
+	return ProxyRulesConfig{}, false
+}
+
+// GetProxyTLS returns true if API Gateway proxies should serve HTTPS for a profile
+func (c *Config) GetProxyTLS(profile string) bool {
+	if pc, ok := c.Profiles[profile]; ok {
+		if pc.ProxyTLS {
+			return true
+		}
+	}
+	return c.Defaults.ProxyTLS
+}
+
+// DefaultTunnelHealthPath is probed through tunnels when no path is configured.
+const DefaultTunnelHealthPath = "/health"
+
+// GetTunnelHealthPath returns the path to probe through tunnels of a profile,
+// or "" if probing is off. Setting a path turns probing on.
+func (c *Config) GetTunnelHealthPath(profile string) string {
+	if pc, ok := c.Profiles[profile]; ok && pc.TunnelHealthPath != "" {
+		return pc.TunnelHealthPath
+	}
+	if c.Defaults.TunnelHealthPath != "" {
+		return c.Defaults.TunnelHealthPath
+	}
+	if c.Defaults.TunnelHealthCheck {
+		return DefaultTunnelHealthPath
+	}
+	return ""
+}
+
+// GetTunnelStrategy returns how ECS tunnels of a profile are established.
+// Empty or unknown values fall back to auto.
+func (c *Config) GetTunnelStrategy(profile string) string {
+	strategy := c.Defaults.TunnelStrategy
+	if pc, ok := c.Profiles[profile]; ok && pc.TunnelStrategy != "" {
+		strategy = pc.TunnelStrategy
+	}
+	switch strategy {
+	case TunnelStrategyPortForward, TunnelStrategyExec:
+		return strategy
+	default:
+		return TunnelStrategyAuto
+	}
+}
+
+// DefaultScanWarnSizeMB is the table size above which unfiltered scans ask
+// first when no threshold is configured.
+const DefaultScanWarnSizeMB = 1024
+
+// GetScanWarnBytes returns the table size in bytes above which unfiltered
+// scans ask first, or 0 if they never do.
+func (c *Config) GetScanWarnBytes() int64 {
+	switch mb := c.Defaults.ScanWarnSizeMB; {
+	case mb < 0:
+		return 0
+	case mb == 0:
+		return DefaultScanWarnSizeMB << 20
+	default:
+		return mb << 20
+	}
+}
+
+// GetConcurrency returns the concurrency limits of a profile: those of the
+// defaults, with the profile's own on top. Services not in the result keep
+// the limits built into vaws.
+func (c *Config) GetConcurrency(profile string) map[string]int {
+	limits := make(map[string]int, len(c.Defaults.Concurrency))
+	for service, n := range c.Defaults.Concurrency {
+		limits[service] = n
+	}
+	if pc, ok := c.Profiles[profile]; ok {
+		for service, n := range pc.Concurrency {
+			limits[service] = n
+		}
+	}
+	return limits
+}
+
+// IsActionAllowed returns true if the action category is enabled for a profile
+func (c *Config) IsActionAllowed(profile, action string) bool {
+	if action == ActionRead {
+		return true
+	}
+	pc, ok := c.Profiles[profile]
+	if !ok || len(pc.Allow) == 0 {
+		return true
+	}
+	for _, a := range pc.Allow {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// knownActions are the values accepted in allow
+var knownActions = map[string]bool{
+	ActionRead:   true,
+	ActionTunnel: true,
+	ActionInvoke: true,
+	ActionWrite:  true,
+	ActionShell:  true,
+}
+
+// UnknownActions describes allow entries that are not action categories, such
+// as typos, sorted by profile. Such entries enable nothing, so a misspelled
+// category stays blocked.
+func (c *Config) UnknownActions() []string {
+	var unknown []string
+	for profile, pc := range c.Profiles {
+		for _, a := range pc.Allow {
+			if !knownActions[a] {
+				unknown = append(unknown, fmt.Sprintf("%s: %q", profile, a))
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// GetMonitorPanels returns the monitor dashboard panels for a profile
+func (c *Config) GetMonitorPanels(profile string) []MonitorPanelConfig {
+	if pc, ok := c.Profiles[profile]; ok {
+		return pc.Monitor
+	}
+	return nil
+}
+
+// SetMonitorPanels sets the monitor dashboard panels for a profile
+func (c *Config) SetMonitorPanels(profile string, panels []MonitorPanelConfig) {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]ProfileConfig)
+	}
+	pc := c.Profiles[profile]
+	pc.Monitor = panels
+	c.Profiles[profile] = pc
+}
+
+// GetDLQExports returns the dead-letter queue exports for a profile
+func (c *Config) GetDLQExports(profile string) []DLQExportConfig {
+	if pc, ok := c.Profiles[profile]; ok {
+		return pc.DLQExports
+	}
+	return nil
+}
+
+// SetDLQExports sets the dead-letter queue exports for a profile
+func (c *Config) SetDLQExports(profile string, exports []DLQExportConfig) {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]ProfileConfig)
+	}
+	pc := c.Profiles[profile]
+	pc.DLQExports = exports
+	c.Profiles[profile] = pc
+}
+
+// GetWatches returns the watch expressions for a profile
+func (c *Config) GetWatches(profile string) []WatchConfig {
+	if pc, ok := c.Profiles[profile]; ok {
+		return pc.Watches
+	}
+	return nil
+}
+
+// SetWatches sets the watch expressions for a profile
+func (c *Config) SetWatches(profile string, watches []WatchConfig) {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]ProfileConfig)
+	}
+	pc := c.Profiles[profile]
+	pc.Watches = watches
+	c.Profiles[profile] = pc
+}
+
+// GetTerraformStates returns the Terraform state locations for a profile
+func (c *Config) GetTerraformStates(profile string) []string {
+	if pc, ok := c.Profiles[profile]; ok {
+		return pc.TerraformStates
+	}
+	return nil
+}
+
+// GetOrgRole returns the role assumed in member accounts for a profile
+func (c *Config) GetOrgRole(profile string) string {
+	if pc, ok := c.Profiles[profile]; ok && pc.OrgRole != "" {
+		return pc.OrgRole
+	}
+	return DefaultOrgRole
+}
+
+// DefaultDLQExportDir returns the default directory of dead-letter queue exports
+func DefaultDLQExportDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".vaws", "dlq")
+}
+
+// ProfilesDir returns the directory of the profiles written from :stats
+func ProfilesDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".vaws", "profiles")
+}
+
+// GetResourceTypes returns the Cloud Control resource types for a profile
+// Default types come first, followed by the profile's own
+func (c *Config) GetResourceTypes(profile string) []string {
+	seen := make(map[string]bool)
+	var types []string
+	add := func(list []string) {
+		for _, t := range list {
+			if !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+	}
+	add(c.Defaults.ResourceTypes)
+	if pc, ok := c.Profiles[profile]; ok {
+		add(pc.ResourceTypes)
+	}
+	return types
+}
+
+// GetNotifyMode returns the notification escape sequence to use
+// Empty or unknown values fall back to OSC 9
+func (c *Config) GetNotifyMode() string {
+	switch c.Terminal.Notify {
+	case NotifyOSC777, NotifyOff:
+		return c.Terminal.Notify
+	default:
+		return NotifyOSC9
+	}
+}
+
 // Save saves the configuration to disk
 func (c *Config) Save() error {
 	return c.SaveTo(configPath)
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Layout contains pane sizes chosen in the UI, keyed by view (e.g., services).
+// It is kept in its own state file so resizing panes never rewrites config.yaml.
+type Layout map[string]LayoutConfig
+
+// LayoutConfig contains the pane sizes of a view
+type LayoutConfig struct {
+	// ListRatio is the list pane's share of the width in two-pane mode (e.g., 0.4)
+	ListRatio float64 `json:"list_ratio,omitempty"`
+
+	// LogsHeight is the height of the logs panel in lines
+	LogsHeight int `json:"logs_height,omitempty"`
+}
+
+// DefaultLayoutPath returns the default layout state file path
+func DefaultLayoutPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".vaws", "layout.json")
+}
+
+// LoadLayout loads the saved pane sizes. A missing or unreadable file
+// yields an empty layout, so every view starts at its default size.
+func LoadLayout() Layout {
+	layout := make(Layout)
+	data, err := os.ReadFile(DefaultLayoutPath())
+	if err != nil {
+		return layout
+	}
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return make(Layout)
+	}
+	return layout
+}
+
+// Save writes the pane sizes to the layout state file
+func (l Layout) Save() error {
+	path := DefaultLayoutPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
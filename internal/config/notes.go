@@ -0,0 +1,83 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Note is text the user attached to a resource, kept on this machine only
+type Note struct {
+	// ARN identifies the resource
+	ARN string `yaml:"arn"`
+
+	// Text is the note as written
+	Text string `yaml:"text"`
+
+	// UpdatedAt is when the note was last saved
+	UpdatedAt time.Time `yaml:"updated_at"`
+}
+
+// NotesDir returns the directory notes are kept in, one file per resource
+func NotesDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".vaws", "notes")
+}
+
+// noteFile returns the file of the note of a resource. ARNs contain slashes,
+// so they are escaped into the file name.
+func noteFile(arn string) string {
+	return filepath.Join(NotesDir(), url.PathEscape(arn)+".yaml")
+}
+
+// LoadNotes loads all notes, keyed by ARN. Unreadable files are skipped.
+func LoadNotes() map[string]Note {
+	notes := make(map[string]Note)
+	entries, err := os.ReadDir(NotesDir())
+	if err != nil {
+		return notes
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".yaml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(NotesDir(), e.Name()))
+		if err != nil {
+			continue
+		}
+		var note Note
+		if err := yaml.Unmarshal(data, &note); err != nil || note.ARN == "" {
+			continue
+		}
+		notes[note.ARN] = note
+	}
+	return notes
+}
+
+// SaveNote writes the note of a resource, replacing any it had
+func SaveNote(note Note) error {
+	if err := os.MkdirAll(NotesDir(), 0700); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(note)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(noteFile(note.ARN), data, 0600)
+}
+
+// DeleteNote removes the note of a resource, if it has one
+func DeleteNote(arn string) error {
+	err := os.Remove(noteFile(arn))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxRecentQueries is how many recent queries and scans are kept per table.
+const MaxRecentQueries = 10
+
+// Queries contains recent and saved DynamoDB queries, keyed by table name.
+// Tables with the same name in other accounts or regions share them, which
+// suits tables deployed per environment.
+type Queries map[string]TableQueries
+
+// TableQueries contains the queries of a table
+type TableQueries struct {
+	// Recent are the last queries and scans run, most recent first
+	Recent []SavedQuery `yaml:"recent,omitempty"`
+
+	// Saved are named queries, kept until deleted
+	Saved []SavedQuery `yaml:"saved,omitempty"`
+}
+
+// SavedQuery contains the parameters of a query or scan
+type SavedQuery struct {
+	Name             string `yaml:"name,omitempty"`
+	Scan             bool   `yaml:"scan,omitempty"`
+	Index            string `yaml:"index,omitempty"`
+	PartitionKey     string `yaml:"pk,omitempty"`
+	SortKey          string `yaml:"sk,omitempty"`
+	SortKeyCondition string `yaml:"sk_condition,omitempty"`
+	FilterExpression string `yaml:"filter_expression,omitempty"`
+	FilterAttr       string `yaml:"filter_attr,omitempty"`
+	FilterValue      string `yaml:"filter_value,omitempty"`
+	Limit            int32  `yaml:"limit,omitempty"`
+}
+
+// DefaultQueriesPath returns the default queries file path
+func DefaultQueriesPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".vaws", "queries.yaml")
+}
+
+// LoadQueries loads the recent and saved queries. A missing or unreadable
+// file yields no queries.
+func LoadQueries() Queries {
+	queries := make(Queries)
+	data, err := os.ReadFile(DefaultQueriesPath())
+	if err != nil {
+		return queries
+	}
+	if err := yaml.Unmarshal(data, &queries); err != nil || queries == nil {
+		return make(Queries)
+	}
+	return queries
+}
+
+// Save writes the queries to the queries file
+func (q Queries) Save() error {
+	path := DefaultQueriesPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(q)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// AddRecent records a query run on a table, moving it to the front if it was
+// run before and dropping the oldest beyond MaxRecentQueries.
+func (q Queries) AddRecent(table string, query SavedQuery) {
+	query.Name = ""
+	tq := q[table]
+	tq.Recent = slices.DeleteFunc(tq.Recent, func(r SavedQuery) bool { return r == query })
+	tq.Recent = append([]SavedQuery{query}, tq.Recent...)
+	if len(tq.Recent) > MaxRecentQueries {
+		tq.Recent = tq.Recent[:MaxRecentQueries]
+	}
+	q[table] = tq
+}
+
+// SaveNamed keeps a query of a table under a name, replacing one with the
+// same name.
+func (q Queries) SaveNamed(table string, query SavedQuery) {
+	tq := q[table]
+	if i := slices.IndexFunc(tq.Saved, func(s SavedQuery) bool { return s.Name == query.Name }); i >= 0 {
+		tq.Saved[i] = query
+	} else {
+		tq.Saved = append(tq.Saved, query)
+	}
+	q[table] = tq
+}
+
+// DeleteNamed removes a saved query of a table, reporting whether it existed.
+func (q Queries) DeleteNamed(table, name string) bool {
+	tq, ok := q[table]
+	if !ok {
+		return false
+	}
+	n := len(tq.Saved)
+	tq.Saved = slices.DeleteFunc(tq.Saved, func(s SavedQuery) bool { return s.Name == name })
+	q[table] = tq
+	return len(tq.Saved) < n
+}
@@ -0,0 +1,335 @@
+// Package cron finds the next runs of EventBridge schedule expressions:
+// cron(...) with six fields, rate(...) and at(...).
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchYears bounds how far ahead the next run of a cron expression is
+// looked for.
+const searchYears = 5
+
+// Next returns the first run of expr after t, or the zero time if it never
+// runs again. Cron and at expressions are read in loc. Rate expressions run
+// every interval from start, their start date or creation time.
+func Next(expr string, loc *time.Location, start, t time.Time) (time.Time, error) {
+	kind, body, ok := strings.Cut(strings.TrimSpace(expr), "(")
+	if !ok || !strings.HasSuffix(body, ")") {
+		return time.Time{}, fmt.Errorf("invalid schedule expression %q", expr)
+	}
+	body = strings.TrimSpace(strings.TrimSuffix(body, ")"))
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	switch kind {
+	case "at":
+		at, err := time.ParseInLocation("2006-01-02T15:04:05", body, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid at expression %q", expr)
+		}
+		if !at.After(t) {
+			return time.Time{}, nil
+		}
+		return at, nil
+	case "rate":
+		every, err := parseRate(body)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid rate expression %q: %w", expr, err)
+		}
+		if start.After(t) {
+			return start, nil
+		}
+		return start.Add((t.Sub(start)/every + 1) * every), nil
+	case "cron":
+		s, err := parseCron(body)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		return s.next(t.In(loc)), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid schedule expression %q", expr)
+}
+
+// parseRate reads "5 minutes", "1 hour" or "2 days".
+func parseRate(s string) (time.Duration, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("expected a value and a unit")
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid value %q", fields[0])
+	}
+	switch strings.TrimSuffix(fields[1], "s") {
+	case "minute":
+		return time.Duration(n) * time.Minute, nil
+	case "hour":
+		return time.Duration(n) * time.Hour, nil
+	case "day":
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid unit %q", fields[1])
+}
+
+// cronSpec is a parsed cron expression. Day fields with ? match any day.
+type cronSpec struct {
+	minutes [60]bool
+	hours   [24]bool
+	months  [13]bool
+	years   map[int]bool // nil for any year
+
+	dom        [32]bool
+	domAny     bool
+	domLast    bool // L: last day of the month
+	domWeekday int  // nW: nearest weekday to day n, -1 for LW
+
+	dow       [8]bool // 1 is Sunday
+	dowAny    bool
+	dowLast   int // nL: last weekday n of the month
+	dowNth    int // n#k: weekday n ...
+	dowNthNum int // ... the kth of the month
+}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var dayNames = map[string]int{"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7}
+
+// parseCron reads the six fields of a cron expression: minutes, hours, day
+// of month, month, day of week and year.
+func parseCron(s string) (*cronSpec, error) {
+	f := strings.Fields(s)
+	if len(f) != 6 {
+		return nil, fmt.Errorf("expected 6 fields, got %d", len(f))
+	}
+	spec := &cronSpec{}
+	if err := parseField(f[0], 0, 59, nil, spec.minutes[:]); err != nil {
+		return nil, fmt.Errorf("minutes: %w", err)
+	}
+	if err := parseField(f[1], 0, 23, nil, spec.hours[:]); err != nil {
+		return nil, fmt.Errorf("hours: %w", err)
+	}
+	if err := parseField(f[3], 1, 12, monthNames, spec.months[:]); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if f[5] != "*" {
+		years := make([]bool, 2200)
+		if err := parseField(f[5], 1970, 2199, nil, years); err != nil {
+			return nil, fmt.Errorf("year: %w", err)
+		}
+		spec.years = make(map[int]bool)
+		for y, ok := range years {
+			if ok {
+				spec.years[y] = true
+			}
+		}
+	}
+
+	dom, dow := strings.ToUpper(f[2]), strings.ToUpper(f[4])
+	switch {
+	case dom == "?":
+		spec.domAny = true
+	case dom == "L":
+		spec.domLast = true
+	case dom == "LW":
+		spec.domWeekday = -1
+	case strings.HasSuffix(dom, "W"):
+		n, err := strconv.Atoi(strings.TrimSuffix(dom, "W"))
+		if err != nil || n < 1 || n > 31 {
+			return nil, fmt.Errorf("day of month: invalid %q", f[2])
+		}
+		spec.domWeekday = n
+	default:
+		if err := parseField(dom, 1, 31, nil, spec.dom[:]); err != nil {
+			return nil, fmt.Errorf("day of month: %w", err)
+		}
+	}
+
+	switch {
+	case dow == "?":
+		spec.dowAny = true
+	case strings.HasSuffix(dow, "L") && len(dow) > 1:
+		n, err := dayNumber(strings.TrimSuffix(dow, "L"))
+		if err != nil {
+			return nil, fmt.Errorf("day of week: %w", err)
+		}
+		spec.dowLast = n
+	case strings.Contains(dow, "#"):
+		day, nth, _ := strings.Cut(dow, "#")
+		n, err := dayNumber(day)
+		if err != nil {
+			return nil, fmt.Errorf("day of week: %w", err)
+		}
+		k, err := strconv.Atoi(nth)
+		if err != nil || k < 1 || k > 5 {
+			return nil, fmt.Errorf("day of week: invalid %q", f[4])
+		}
+		spec.dowNth, spec.dowNthNum = n, k
+	default:
+		if err := parseField(dow, 1, 7, dayNames, spec.dow[:]); err != nil {
+			return nil, fmt.Errorf("day of week: %w", err)
+		}
+	}
+	if !spec.domAny && !spec.dowAny {
+		return nil, fmt.Errorf("one of day of month and day of week must be ?")
+	}
+	return spec, nil
+}
+
+// dayNumber reads a day of week, 1 (SUN) to 7 (SAT).
+func dayNumber(s string) (int, error) {
+	if n, ok := dayNames[s]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > 7 {
+		return 0, fmt.Errorf("invalid day %q", s)
+	}
+	return n, nil
+}
+
+// parseField sets the values of a comma-separated field in set: *, single
+// values, ranges (a-b) and steps (*/n, a/n, a-b/n).
+func parseField(s string, lo, hi int, names map[string]int, set []bool) error {
+	value := func(v string) (int, error) {
+		if n, ok := names[strings.ToUpper(v)]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < lo || n > hi {
+			return 0, fmt.Errorf("invalid value %q", v)
+		}
+		return n, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		rng, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step %q", stepText)
+			}
+			step = n
+		}
+
+		from, to := lo, hi
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err error
+			if from, err = value(a); err != nil {
+				return err
+			}
+			if to, err = value(b); err != nil {
+				return err
+			}
+		default:
+			n, err := value(rng)
+			if err != nil {
+				return err
+			}
+			from, to = n, n
+			if hasStep {
+				to = hi
+			}
+		}
+		if from > to {
+			return fmt.Errorf("invalid range %q", rng)
+		}
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+// next returns the first run strictly after t, searching day by day, or the
+// zero time if there is none within searchYears.
+func (s *cronSpec) next(t time.Time) time.Time {
+	loc := t.Location()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	end := day.AddDate(searchYears, 0, 0)
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !s.matchDay(day) {
+			continue
+		}
+		for h := range 24 {
+			if !s.hours[h] {
+				continue
+			}
+			for m := range 60 {
+				if !s.minutes[m] {
+					continue
+				}
+				if run := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, loc); run.After(t) {
+					return run
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// matchDay returns true if the expression runs on day.
+func (s *cronSpec) matchDay(day time.Time) bool {
+	if s.years != nil && !s.years[day.Year()] {
+		return false
+	}
+	if !s.months[day.Month()] {
+		return false
+	}
+	last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+	weekday := int(day.Weekday()) + 1
+
+	switch {
+	case s.domAny:
+	case s.domLast:
+		return day.Day() == last
+	case s.domWeekday != 0:
+		target := s.domWeekday
+		if target < 0 || target > last {
+			target = last
+		}
+		return day.Day() == nearestWeekday(day, target, last)
+	default:
+		return s.dom[day.Day()]
+	}
+
+	switch {
+	case s.dowAny:
+		return true
+	case s.dowLast != 0:
+		return weekday == s.dowLast && day.Day()+7 > last
+	case s.dowNth != 0:
+		return weekday == s.dowNth && (day.Day()-1)/7+1 == s.dowNthNum
+	default:
+		return s.dow[weekday]
+	}
+}
+
+// nearestWeekday returns the weekday of the month of day nearest to its day
+// target, without leaving the month.
+func nearestWeekday(day time.Time, target, last int) int {
+	switch time.Date(day.Year(), day.Month(), target, 0, 0, 0, 0, day.Location()).Weekday() {
+	case time.Saturday:
+		if target == 1 {
+			return 3
+		}
+		return target - 1
+	case time.Sunday:
+		if target == last {
+			return target - 2
+		}
+		return target + 1
+	}
+	return target
+}
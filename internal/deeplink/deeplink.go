@@ -0,0 +1,125 @@
+// Package deeplink reads and writes vaws:// links, which open vaws at a
+// resource: vaws://open?profile=prod&arn=arn:aws:ecs:...:service/api/orders
+package deeplink
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Scheme is the URI scheme of links.
+const Scheme = "vaws"
+
+// Link points at a resource in the account of a profile.
+type Link struct {
+	Profile string // Empty to use the --profile flag, or ask
+	Region  string // Region of the resource, from its ARN
+	ARN     string
+}
+
+// Kind is the kind of resource a link opens.
+type Kind string
+
+// Resource kinds links can open
+const (
+	KindStack    Kind = "stack"
+	KindCluster  Kind = "cluster"
+	KindService  Kind = "service"
+	KindFunction Kind = "function"
+	KindQueue    Kind = "queue"
+	KindTable    Kind = "table"
+)
+
+// Resource is what a link's ARN names.
+type Resource struct {
+	Kind       Kind
+	Name       string
+	Cluster    string // Cluster of a service
+	ClusterARN string
+}
+
+// New returns the link to a resource. The region comes from the ARN.
+func New(profile, arn string) Link {
+	l := Link{Profile: profile, ARN: arn}
+	if parts := strings.SplitN(arn, ":", 6); len(parts) == 6 {
+		l.Region = parts[3]
+	}
+	return l
+}
+
+// Parse reads a vaws:// link, or a bare ARN.
+func Parse(s string) (Link, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "arn:") {
+		return checked(New("", s))
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return Link{}, fmt.Errorf("invalid link: %w", err)
+	}
+	if u.Scheme != Scheme || u.Host != "open" {
+		return Link{}, fmt.Errorf("invalid link %q: expected %s://open?arn=<arn> or an ARN", s, Scheme)
+	}
+	q := u.Query()
+	if q.Get("arn") == "" {
+		return Link{}, fmt.Errorf("invalid link %q: missing arn", s)
+	}
+	return checked(New(q.Get("profile"), q.Get("arn")))
+}
+
+// checked returns l if its ARN names a resource links can open.
+func checked(l Link) (Link, error) {
+	if _, err := l.Resource(); err != nil {
+		return Link{}, err
+	}
+	return l, nil
+}
+
+// String renders the link. The ARN is left unescaped so that it stays
+// readable; ARNs don't contain the characters that would need escaping.
+func (l Link) String() string {
+	s := Scheme + "://open?"
+	if l.Profile != "" {
+		s += "profile=" + url.QueryEscape(l.Profile) + "&"
+	}
+	return s + "arn=" + l.ARN
+}
+
+// Resource returns what the link's ARN names, or an error if vaws can't
+// open it.
+func (l Link) Resource() (Resource, error) {
+	// arn:partition:service:region:account:resource
+	parts := strings.SplitN(l.ARN, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return Resource{}, fmt.Errorf("invalid ARN %q", l.ARN)
+	}
+	service, resource := parts[2], parts[5]
+	kind, rest, _ := strings.Cut(resource, "/")
+
+	switch {
+	case service == "cloudformation" && kind == "stack":
+		name, _, _ := strings.Cut(rest, "/")
+		return Resource{Kind: KindStack, Name: name}, nil
+	case service == "ecs" && kind == "cluster" && rest != "":
+		return Resource{Kind: KindCluster, Name: rest}, nil
+	case service == "ecs" && kind == "service":
+		// Services have the new ARN format, with their cluster, since 2021
+		cluster, name, ok := strings.Cut(rest, "/")
+		if !ok {
+			return Resource{}, fmt.Errorf("service ARN %q has no cluster", l.ARN)
+		}
+		clusterARN := strings.Join(parts[:5], ":") + ":cluster/" + cluster
+		return Resource{Kind: KindService, Name: name, Cluster: cluster, ClusterARN: clusterARN}, nil
+	case service == "lambda" && strings.HasPrefix(resource, "function:"):
+		name, _, _ := strings.Cut(strings.TrimPrefix(resource, "function:"), ":")
+		return Resource{Kind: KindFunction, Name: name}, nil
+	case service == "sqs" && resource != "":
+		return Resource{Kind: KindQueue, Name: resource}, nil
+	case service == "dynamodb" && kind == "table" && rest != "":
+		name, _, _ := strings.Cut(rest, "/")
+		return Resource{Kind: KindTable, Name: name}, nil
+	}
+	return Resource{}, fmt.Errorf("cannot open %q: links support stacks, ECS clusters and services, Lambda functions, SQS queues and DynamoDB tables", l.ARN)
+}
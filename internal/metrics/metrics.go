@@ -0,0 +1,320 @@
+// Package metrics collects tunnel and AWS API metrics and serves them in the
+// Prometheus text format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"vaws/internal/log"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the AWS API call
+// duration histogram.
+var durationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Tunnel is the state of a tunnel as exported by the metrics endpoint.
+type Tunnel struct {
+	ID        string
+	Kind      string // ecs or apigateway
+	Target    string // Service, discovered host or API name
+	LocalPort int
+	Status    string // e.g. active or error
+	Up        bool
+	StartedAt time.Time
+
+	// Result of the last health probe, if the tunnel was probed
+	Probed       bool
+	Responding   bool
+	ProbeLatency time.Duration
+}
+
+// callKey identifies the AWS API calls of an operation.
+type callKey struct {
+	service   string
+	operation string
+}
+
+// callStats are the counts and durations of an operation's calls.
+type callStats struct {
+	calls   int
+	errors  int
+	sum     float64
+	buckets []int // Calls per duration bucket, not cumulative
+}
+
+// trafficStats are the connections, requests and bytes vaws relayed for a
+// tunnel.
+type trafficStats struct {
+	connections int
+	requests    map[int]int // HTTP status -> requests
+	sent        int64       // Bytes from local clients to the tunnel target
+	received    int64       // Bytes from the tunnel target to local clients
+}
+
+// Registry holds the metrics of a vaws session. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.Mutex
+	tunnels []Tunnel
+	calls   map[callKey]*callStats
+	traffic map[string]*trafficStats
+}
+
+var defaultRegistry = NewRegistry()
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		calls:   make(map[callKey]*callStats),
+		traffic: make(map[string]*trafficStats),
+	}
+}
+
+// Default returns the registry the rest of vaws records to.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// SetTunnels replaces the tunnels exported by the registry.
+func (r *Registry) SetTunnels(tunnels []Tunnel) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tunnels = append([]Tunnel(nil), tunnels...)
+}
+
+// ObserveAWSCall records an AWS API call and how long it took, retries
+// included.
+func (r *Registry) ObserveAWSCall(service, operation string, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := callKey{service: service, operation: operation}
+	stats, ok := r.calls[key]
+	if !ok {
+		stats = &callStats{buckets: make([]int, len(durationBuckets))}
+		r.calls[key] = stats
+	}
+	stats.calls++
+	if err != nil {
+		stats.errors++
+	}
+	secs := d.Seconds()
+	stats.sum += secs
+	for i, le := range durationBuckets {
+		if secs <= le {
+			stats.buckets[i]++
+			break
+		}
+	}
+}
+
+// AddConnection records a local connection relayed by a tunnel and the bytes
+// it carried each way.
+func (r *Registry) AddConnection(tunnelID string, sent, received int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.trafficOf(tunnelID)
+	stats.connections++
+	stats.sent += sent
+	stats.received += received
+}
+
+// AddRequest records a request forwarded by a local proxy, with its response
+// status and the bytes of its body and of the response body.
+func (r *Registry) AddRequest(tunnelID string, status int, sent, received int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.trafficOf(tunnelID)
+	if stats.requests == nil {
+		stats.requests = make(map[int]int)
+	}
+	stats.requests[status]++
+	stats.sent += sent
+	stats.received += received
+}
+
+// trafficOf returns the traffic stats of a tunnel. r.mu must be held.
+func (r *Registry) trafficOf(tunnelID string) *trafficStats {
+	stats, ok := r.traffic[tunnelID]
+	if !ok {
+		stats = &trafficStats{}
+		r.traffic[tunnelID] = stats
+	}
+	return stats
+}
+
+// Write writes the metrics in the Prometheus text exposition format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	family := func(name, kind, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+	sample := func(name string, value float64, labels ...string) {
+		b.WriteString(name)
+		if len(labels) > 0 {
+			b.WriteByte('{')
+			for i := 0; i+1 < len(labels); i += 2 {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				fmt.Fprintf(&b, "%s=%q", labels[i], escapeLabel(labels[i+1]))
+			}
+			b.WriteByte('}')
+		}
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+		b.WriteByte('\n')
+	}
+
+	tunnels := append([]Tunnel(nil), r.tunnels...)
+	sort.Slice(tunnels, func(i, j int) bool { return tunnels[i].ID < tunnels[j].ID })
+
+	family("vaws_tunnel_up", "gauge", "Whether the tunnel is active.")
+	for _, t := range tunnels {
+		sample("vaws_tunnel_up", boolValue(t.Up),
+			"tunnel", t.ID, "kind", t.Kind, "target", t.Target, "local_port", strconv.Itoa(t.LocalPort), "status", t.Status)
+	}
+	family("vaws_tunnel_start_time_seconds", "gauge", "When the tunnel was started, in Unix seconds.")
+	for _, t := range tunnels {
+		if !t.StartedAt.IsZero() {
+			sample("vaws_tunnel_start_time_seconds", float64(t.StartedAt.Unix()), "tunnel", t.ID)
+		}
+	}
+	family("vaws_tunnel_probe_success", "gauge", "Whether the application behind the tunnel answered its last health probe.")
+	for _, t := range tunnels {
+		if t.Probed {
+			sample("vaws_tunnel_probe_success", boolValue(t.Responding), "tunnel", t.ID)
+		}
+	}
+	family("vaws_tunnel_probe_duration_seconds", "gauge", "How long the last health probe of the tunnel took.")
+	for _, t := range tunnels {
+		if t.Probed {
+			sample("vaws_tunnel_probe_duration_seconds", t.ProbeLatency.Seconds(), "tunnel", t.ID)
+		}
+	}
+
+	ids := make([]string, 0, len(r.traffic))
+	for id := range r.traffic {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	family("vaws_tunnel_connections_total", "counter", "Local connections relayed by the tunnel.")
+	for _, id := range ids {
+		if r.traffic[id].connections > 0 {
+			sample("vaws_tunnel_connections_total", float64(r.traffic[id].connections), "tunnel", id)
+		}
+	}
+	family("vaws_tunnel_requests_total", "counter", "Requests forwarded by the tunnel's local proxy, by response status.")
+	for _, id := range ids {
+		codes := make([]int, 0, len(r.traffic[id].requests))
+		for code := range r.traffic[id].requests {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			sample("vaws_tunnel_requests_total", float64(r.traffic[id].requests[code]), "tunnel", id, "code", strconv.Itoa(code))
+		}
+	}
+	family("vaws_tunnel_sent_bytes_total", "counter", "Bytes sent from local clients through the tunnel.")
+	for _, id := range ids {
+		sample("vaws_tunnel_sent_bytes_total", float64(r.traffic[id].sent), "tunnel", id)
+	}
+	family("vaws_tunnel_received_bytes_total", "counter", "Bytes received through the tunnel by local clients.")
+	for _, id := range ids {
+		sample("vaws_tunnel_received_bytes_total", float64(r.traffic[id].received), "tunnel", id)
+	}
+
+	keys := make([]callKey, 0, len(r.calls))
+	for key := range r.calls {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].service != keys[j].service {
+			return keys[i].service < keys[j].service
+		}
+		return keys[i].operation < keys[j].operation
+	})
+
+	family("vaws_aws_api_calls_total", "counter", "AWS API calls made by vaws, by result.")
+	for _, key := range keys {
+		stats := r.calls[key]
+		sample("vaws_aws_api_calls_total", float64(stats.calls-stats.errors), "service", key.service, "operation", key.operation, "result", "success")
+		if stats.errors > 0 {
+			sample("vaws_aws_api_calls_total", float64(stats.errors), "service", key.service, "operation", key.operation, "result", "error")
+		}
+	}
+	family("vaws_aws_api_call_duration_seconds", "histogram", "Duration of AWS API calls, retries included.")
+	for _, key := range keys {
+		stats := r.calls[key]
+		cumulative := 0
+		for i, le := range durationBuckets {
+			cumulative += stats.buckets[i]
+			sample("vaws_aws_api_call_duration_seconds_bucket", float64(cumulative),
+				"service", key.service, "operation", key.operation, "le", strconv.FormatFloat(le, 'g', -1, 64))
+		}
+		sample("vaws_aws_api_call_duration_seconds_bucket", float64(stats.calls), "service", key.service, "operation", key.operation, "le", "+Inf")
+		sample("vaws_aws_api_call_duration_seconds_sum", stats.sum, "service", key.service, "operation", key.operation)
+		sample("vaws_aws_api_call_duration_seconds_count", float64(stats.calls), "service", key.service, "operation", key.operation)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ServeHTTP serves the metrics to a Prometheus scrape.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := r.Write(w); err != nil {
+		log.Debug("Failed to write metrics: %v", err)
+	}
+}
+
+// Serve listens on addr (e.g. :9095) and serves the default registry on
+// /metrics in the background. It returns once the address is bound, so that
+// a port in use is reported before vaws starts.
+func Serve(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for metrics on %s: %w", addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", defaultRegistry)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.Error("Metrics endpoint stopped: %v", err)
+		}
+	}()
+	log.Info("Serving metrics on http://%s/metrics", ln.Addr())
+	return nil
+}
+
+// escapeLabel drops the control characters other than newlines from a label
+// value. %q would write them as escapes the text format doesn't have.
+func escapeLabel(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' && r != '\n' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// boolValue returns 1 for true and 0 for false.
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+)
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// countingWriter records the status and counts the body bytes of a response.
+type countingWriter struct {
+	http.ResponseWriter
+	status int
+	n      int64
+}
+
+func (w *countingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the flusher of the underlying
+// writer, which the reverse proxy uses to stream responses.
+func (w *countingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// InstrumentProxy wraps the handler of a tunnel's local proxy so that its
+// requests and bytes are recorded to the default registry.
+func InstrumentProxy(tunnelID string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body := &countingReader{ReadCloser: req.Body}
+		if req.Body != nil && req.Body != http.NoBody {
+			req.Body = body
+		}
+		cw := &countingWriter{ResponseWriter: w}
+		h.ServeHTTP(cw, req)
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+		defaultRegistry.AddRequest(tunnelID, cw.status, body.n, cw.n)
+	})
+}
@@ -3,6 +3,7 @@
 package model
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -71,6 +72,17 @@ type Stack struct {
 	Tags         map[string]string
 	Outputs      []StackOutput
 	Parameters   []StackParameter
+
+	// Set by DescribeStack only: ListStacks returns summaries, without the
+	// fields above the drift status
+	Described      bool
+	DriftStatus    string // IN_SYNC, DRIFTED, NOT_CHECKED or UNKNOWN
+	DriftCheckedAt time.Time
+	// Safety settings: termination protection, and the stack policy JSON,
+	// empty if the stack has none
+	TerminationProtection bool
+	StackPolicy           string
+	StackPolicyError      string // Why the policy couldn't be read, e.g. access denied
 }
 
 // StackOutput represents a CloudFormation stack output.
@@ -87,6 +99,34 @@ type StackParameter struct {
 	Value string
 }
 
+// StackEvent is a status change of a stack or one of its resources.
+type StackEvent struct {
+	ID           string
+	Time         time.Time
+	LogicalID    string
+	PhysicalID   string
+	ResourceType string // e.g. AWS::ECS::Service, or AWS::CloudFormation::Stack for the stack itself
+	Status       string // e.g. CREATE_FAILED
+	StatusReason string
+}
+
+// Failed returns true if the event reports a failed operation.
+func (e StackEvent) Failed() bool {
+	return strings.HasSuffix(e.Status, "_FAILED")
+}
+
+// InProgress returns true if the operation the event reports is still
+// running.
+func (e StackEvent) InProgress() bool {
+	return strings.HasSuffix(e.Status, "_IN_PROGRESS")
+}
+
+// IsStack returns true if the event is of the stack named stackName itself
+// rather than of one of its resources.
+func (e StackEvent) IsStack(stackName string) bool {
+	return e.ResourceType == "AWS::CloudFormation::Stack" && e.LogicalID == stackName
+}
+
 // ServiceStatus represents the status of an ECS service.
 type ServiceStatus string
 
@@ -117,7 +157,32 @@ type Service struct {
 	CreatedAt            time.Time
 	Deployments          []Deployment
 	EnableExecuteCommand bool
-	ContainerPorts       []ContainerPort // Container name -> ports mapping
+	ContainerPorts       []ContainerPort     // Container name -> ports mapping
+	DiscoveryEndpoints   []DiscoveryEndpoint // Service Connect and Cloud Map endpoints
+}
+
+// Discovery endpoint sources.
+const (
+	DiscoveryServiceConnect = "Service Connect"
+	DiscoveryCloudMap       = "Cloud Map"
+)
+
+// DiscoveryEndpoint is an address other services in the VPC use to reach an ECS service.
+type DiscoveryEndpoint struct {
+	Source      string // DiscoveryServiceConnect or DiscoveryCloudMap
+	Namespace   string // Namespace name (or ARN if it could not be resolved)
+	Name        string // Discovery name or Cloud Map service name
+	DNSName     string
+	Port        int
+	RegistryARN string // Cloud Map service ARN (Cloud Map registries only)
+}
+
+// Address returns the endpoint as host:port, or an empty string if it is not reachable by DNS.
+func (e DiscoveryEndpoint) Address() string {
+	if e.DNSName == "" || e.Port == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", e.DNSName, e.Port)
 }
 
 // Task represents an ECS task.
@@ -131,6 +196,63 @@ type Task struct {
 	LaunchType        string
 	Containers        []Container
 	StartedAt         time.Time
+	PrivateIP         string // ENI address of awsvpc tasks
+	SubnetID          string // Subnet of the ENI
+	AvailabilityZone  string
+	HealthStatus      string // HEALTHY, UNHEALTHY or UNKNOWN, from the containers' health checks
+	CPU               string // Task-level CPU units, e.g. "256"
+	Memory            string // Task-level memory in MiB
+	Group             string // e.g. "service:web"
+	StopCode          string // Why ECS stopped the task, e.g. "EssentialContainerExited"
+	StoppedReason     string
+
+	// Times of the task's transitions, zero until it reached them
+	CreatedAt       time.Time
+	PullStartedAt   time.Time
+	PullStoppedAt   time.Time
+	ConnectivityAt  time.Time
+	StoppingAt      time.Time
+	ExecutionStopAt time.Time
+	StoppedAt       time.Time
+}
+
+// TaskTransition is a status a task went through and when.
+type TaskTransition struct {
+	Status string
+	At     time.Time
+}
+
+// Transitions returns the transitions the task went through, in order.
+// ECS only keeps the time of the last one of each kind.
+func (t Task) Transitions() []TaskTransition {
+	all := []TaskTransition{
+		{Status: "Created", At: t.CreatedAt},
+		{Status: "Pull started", At: t.PullStartedAt},
+		{Status: "Pull stopped", At: t.PullStoppedAt},
+		{Status: "Connected", At: t.ConnectivityAt},
+		{Status: "Started", At: t.StartedAt},
+		{Status: "Stopping", At: t.StoppingAt},
+		{Status: "Execution stopped", At: t.ExecutionStopAt},
+		{Status: "Stopped", At: t.StoppedAt},
+	}
+	var transitions []TaskTransition
+	for _, tr := range all {
+		if !tr.At.IsZero() {
+			transitions = append(transitions, tr)
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].At.Before(transitions[j].At)
+	})
+	return transitions
+}
+
+// TaskProtection is the scale-in protection of an ECS task: while enabled,
+// neither scale-in nor deployments stop it, until it expires.
+type TaskProtection struct {
+	TaskARN   string
+	Enabled   bool
+	ExpiresAt time.Time
 }
 
 // Container represents a container in an ECS task.
@@ -142,6 +264,12 @@ type Container struct {
 	Image           string
 	NetworkBindings []NetworkBinding
 	PortMappings    []PortMapping // Ports from task definition
+	HealthStatus    string        // HEALTHY, UNHEALTHY or UNKNOWN; UNKNOWN without a health check
+	ExitCode        *int          // Set once the container exited
+	Reason          string        // Why it stopped or failed to start
+	Essential       bool          // Stopping it stops the task
+	CPU             string        // CPU units reserved for the container
+	Memory          string        // Hard memory limit in MiB
 }
 
 // PortMapping represents a port mapping from the task definition.
@@ -256,11 +384,37 @@ type Tunnel struct {
 	ClusterName   string
 	TaskID        string
 	ContainerName string
+	RemoteHost    string // Set when forwarding to a discovered endpoint through the task
+	JumpHostID    string // Set when the SSM target is an EC2 jump host instead of a task
+	JumpHostName  string
+	Relay         string // Set when the tunnel relays through socat, ncat or nc over ECS Exec
 	Status        TunnelStatus
 	StartedAt     time.Time
 	Error         string
 }
 
+// TunnelProbe is the result of a health request through a tunnel. The SSM
+// session can be up while the application behind it does not respond, so
+// both are recorded.
+type TunnelProbe struct {
+	Path       string
+	SessionUp  bool // The local port accepted a connection
+	StatusCode int  // HTTP status of the response, 0 if there was none
+	Latency    time.Duration
+	Error      string
+	At         time.Time
+}
+
+// Responding returns true if the application answered the request.
+func (p TunnelProbe) Responding() bool {
+	return p.StatusCode > 0
+}
+
+// Healthy returns true if the application answered with a 2xx or 3xx status.
+func (p TunnelProbe) Healthy() bool {
+	return p.StatusCode >= 200 && p.StatusCode < 400
+}
+
 // TunnelStatus represents the status of a tunnel.
 type TunnelStatus string
 
@@ -276,18 +430,155 @@ func (s *Service) IsHealthy() bool {
 	return s.Status == ServiceStatusActive && s.RunningCount == s.DesiredCount
 }
 
+// Failing returns true if a deployment of the service failed its rollout, or
+// is rolling out while its tasks fail to start.
+func (s *Service) Failing() bool {
+	for _, d := range s.Deployments {
+		if d.RolloutState == "FAILED" || (d.RolloutState == "IN_PROGRESS" && d.FailedTasks > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// LastUpdated returns when a deployment of the service last changed, or when
+// the service was created if it has none.
+func (s *Service) LastUpdated() time.Time {
+	at := s.CreatedAt
+	for _, d := range s.Deployments {
+		if d.UpdatedAt.After(at) {
+			at = d.UpdatedAt
+		}
+	}
+	return at
+}
+
 // Deployment represents an ECS service deployment.
 type Deployment struct {
 	ID             string
 	Status         string
+	RolloutState   string // COMPLETED, IN_PROGRESS or FAILED
+	RolloutReason  string
 	DesiredCount   int
 	RunningCount   int
 	PendingCount   int
+	FailedTasks    int
 	TaskDefinition string
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
 
+// ClusterRecentServices is how many of the most recently updated services a
+// cluster dashboard lists.
+const ClusterRecentServices = 5
+
+// ClusterUsage is the share of a cluster's CPU or memory its tasks reserve
+// and use, in percent. Either is negative when CloudWatch has no data for it.
+type ClusterUsage struct {
+	Reserved float64
+	Used     float64
+}
+
+// ClusterDashboard sums up the services of an ECS cluster.
+type ClusterDashboard struct {
+	Cluster   Cluster
+	Services  int
+	Desired   int
+	Running   int
+	Pending   int
+	Failing   []Service // Services whose deployment fails
+	Recent    []Service // The ClusterRecentServices services updated last
+	CPU       ClusterUsage
+	Memory    ClusterUsage
+	MetricsAt time.Time // Zero when CloudWatch had no data
+}
+
+// SummarizeCluster adds up the task counts of a cluster's services and picks
+// out the failing and recently updated ones. The usage is left unknown.
+func SummarizeCluster(cluster Cluster, services []Service) *ClusterDashboard {
+	d := &ClusterDashboard{
+		Cluster:  cluster,
+		Services: len(services),
+		CPU:      ClusterUsage{Reserved: -1, Used: -1},
+		Memory:   ClusterUsage{Reserved: -1, Used: -1},
+	}
+	for _, svc := range services {
+		d.Desired += svc.DesiredCount
+		d.Running += svc.RunningCount
+		d.Pending += svc.PendingCount
+		if svc.Failing() {
+			d.Failing = append(d.Failing, svc)
+		}
+	}
+
+	recent := append([]Service(nil), services...)
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].LastUpdated().After(recent[j].LastUpdated())
+	})
+	d.Recent = recent[:min(len(recent), ClusterRecentServices)]
+	return d
+}
+
+// ServiceImage is the image a container of an ECS service runs, next to the
+// image pushed last to its ECR repository.
+type ServiceImage struct {
+	Service        string
+	ClusterName    string
+	Container      string
+	Image          string // As in the task definition
+	Repository     string // ECR repository, empty for other registries
+	Tag            string
+	Digest         string // Digest the running tasks pulled, or the tag's if none run
+	PushedAt       time.Time
+	LatestTag      string
+	LatestDigest   string
+	LatestPushedAt time.Time
+	DeployedAt     time.Time // Last update of the service's primary deployment
+	Error          string    // Why the image could not be compared
+}
+
+// Stale reports whether a newer image was pushed to the repository than the
+// one running.
+func (i ServiceImage) Stale() bool {
+	return i.Digest != "" && i.LatestDigest != "" && i.Digest != i.LatestDigest
+}
+
+// TagFilter selects resources by a tag, such as Environment=prod. Resources
+// with any value of the tag match when Values is empty.
+type TagFilter struct {
+	Key    string
+	Values []string
+}
+
+// String renders the filter as typed, e.g. Environment=prod,staging.
+func (f TagFilter) String() string {
+	if len(f.Values) == 0 {
+		return f.Key
+	}
+	return f.Key + "=" + strings.Join(f.Values, ",")
+}
+
+// VulnerabilitySeverities are the severities ECR scan findings are counted
+// by, most severe first.
+var VulnerabilitySeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFORMATIONAL", "UNDEFINED"}
+
+// ImageVulnerabilities is the last ECR scan of the image a container of an
+// ECS service runs.
+type ImageVulnerabilities struct {
+	Container  string
+	Image      string
+	ScanStatus string         // e.g. COMPLETE or IN_PROGRESS, empty if never scanned
+	ScanDetail string         // What ECR says of the status, e.g. why a scan failed
+	ScannedAt  time.Time      // Last completed scan
+	Counts     map[string]int // Findings by severity, nil without a completed scan
+	Error      string         // Why the scan could not be read
+}
+
+// Scanned reports whether the image has findings from a completed scan.
+func (v ImageVulnerabilities) Scanned() bool {
+	return v.Counts != nil
+}
+
 // Cluster represents an ECS cluster.
 type Cluster struct {
 	Name                              string
@@ -299,11 +590,30 @@ type Cluster struct {
 	RegisteredContainerInstancesCount int
 }
 
-// AWSProfile represents an AWS SSO profile.
+// AWSProfile represents a profile from the shared AWS config file.
 type AWSProfile struct {
-	Name      string
-	AccountID string
-	Region    string
+	Name          string
+	AccountID     string // SSO account, if configured
+	Region        string
+	SSO           bool   // Set when the profile signs in through IAM Identity Center
+	SSOSession    string // Its sso-session, or start URL for legacy profiles; keys the token cache
+	SSORegion     string // Region of the IAM Identity Center instance
+	RoleARN       string // Role assumed by the profile, if any
+	SourceProfile string // Profile whose credentials assume RoleARN
+}
+
+// SSOToken is the cached IAM Identity Center token an SSO profile signs in
+// with, shared by the profiles of its session.
+type SSOToken struct {
+	Found       bool // Without a cached token the profile needs aws sso login
+	ExpiresAt   time.Time
+	Refreshable bool // Has a refresh token, so a new one needs no browser login
+}
+
+// Expired reports whether the token has to be refreshed, or signed in again,
+// before the profile can be used.
+func (t SSOToken) Expired(now time.Time) bool {
+	return !t.Found || !now.Before(t.ExpiresAt)
 }
 
 // FunctionState represents the state of a Lambda function.
@@ -330,6 +640,7 @@ type Function struct {
 	MemorySize   int
 	Timeout      int
 	CodeSize     int64
+	CodeSha256   string // Hash of the deployment package or image digest
 	LastModified time.Time
 	Description  string
 	State        FunctionState
@@ -337,6 +648,107 @@ type Function struct {
 	PackageType  string // Zip or Image
 }
 
+// RuntimeSupport is how far a Lambda runtime is from losing support.
+type RuntimeSupport string
+
+const (
+	RuntimeDeprecated RuntimeSupport = "deprecated" // Past its deprecation date
+	RuntimeExpiring   RuntimeSupport = "expiring"   // Deprecated within RuntimeExpiringWithin
+	RuntimeSupported  RuntimeSupport = "supported"
+	RuntimeUnknown    RuntimeSupport = "unknown" // Not in LambdaRuntimeDeprecations, or a container image
+)
+
+// RuntimeExpiringWithin is how close to its deprecation date a runtime
+// counts as expiring.
+const RuntimeExpiringWithin = 180 * 24 * time.Hour
+
+// LambdaRuntimeDeprecations are the dates AWS stops applying security
+// patches to each runtime, from the Lambda runtimes page. Runtimes released
+// since aren't listed and show as unknown.
+var LambdaRuntimeDeprecations = map[string]time.Time{
+	"nodejs24.x":      time.Date(2028, 4, 30, 0, 0, 0, 0, time.UTC),
+	"nodejs22.x":      time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC),
+	"nodejs20.x":      time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC),
+	"nodejs18.x":      time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+	"nodejs16.x":      time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC),
+	"nodejs14.x":      time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC),
+	"nodejs12.x":      time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC),
+	"nodejs10.x":      time.Date(2021, 7, 30, 0, 0, 0, 0, time.UTC),
+	"python3.14":      time.Date(2029, 6, 30, 0, 0, 0, 0, time.UTC),
+	"python3.13":      time.Date(2029, 6, 30, 0, 0, 0, 0, time.UTC),
+	"python3.12":      time.Date(2028, 10, 31, 0, 0, 0, 0, time.UTC),
+	"python3.11":      time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+	"python3.10":      time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+	"python3.9":       time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC),
+	"python3.8":       time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+	"python3.7":       time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC),
+	"java21":          time.Date(2029, 6, 30, 0, 0, 0, 0, time.UTC),
+	"java17":          time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+	"java11":          time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+	"java8.al2":       time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+	"java8":           time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+	"dotnet10":        time.Date(2028, 11, 14, 0, 0, 0, 0, time.UTC),
+	"dotnet8":         time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC),
+	"dotnet6":         time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC),
+	"dotnetcore3.1":   time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC),
+	"ruby3.4":         time.Date(2028, 3, 31, 0, 0, 0, 0, time.UTC),
+	"ruby3.3":         time.Date(2027, 3, 31, 0, 0, 0, 0, time.UTC),
+	"ruby3.2":         time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+	"ruby2.7":         time.Date(2023, 12, 7, 0, 0, 0, 0, time.UTC),
+	"provided.al2023": time.Date(2029, 6, 30, 0, 0, 0, 0, time.UTC),
+	"provided.al2":    time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+	"provided":        time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+	"go1.x":           time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+}
+
+// LambdaRuntimeSupport returns how far a runtime is from losing support at
+// now, and its deprecation date if known.
+func LambdaRuntimeSupport(runtime string, now time.Time) (RuntimeSupport, time.Time) {
+	date, ok := LambdaRuntimeDeprecations[runtime]
+	switch {
+	case !ok:
+		return RuntimeUnknown, time.Time{}
+	case !now.Before(date):
+		return RuntimeDeprecated, date
+	case date.Sub(now) <= RuntimeExpiringWithin:
+		return RuntimeExpiring, date
+	default:
+		return RuntimeSupported, date
+	}
+}
+
+// LambdaRuntimeUpgrade returns the listed runtime of the same language that
+// is supported the longest, or "" if runtime is unknown or already it.
+func LambdaRuntimeUpgrade(runtime string) string {
+	current, ok := LambdaRuntimeDeprecations[runtime]
+	if !ok {
+		return ""
+	}
+	best := ""
+	for r, date := range LambdaRuntimeDeprecations {
+		if runtimeFamily(r) == runtimeFamily(runtime) && date.After(current) &&
+			(best == "" || date.After(LambdaRuntimeDeprecations[best])) {
+			best = r
+		}
+	}
+	return best
+}
+
+// runtimeFamily returns the language of a runtime, e.g. "python" for
+// "python3.12". Go runs on the OS-only runtimes since go1.x was retired.
+func runtimeFamily(runtime string) string {
+	switch {
+	case strings.HasPrefix(runtime, "provided"), strings.HasPrefix(runtime, "go"):
+		return "provided"
+	case strings.HasPrefix(runtime, "dotnet"):
+		return "dotnet"
+	}
+	if i := strings.IndexAny(runtime, "0123456789"); i > 0 {
+		return runtime[:i]
+	}
+	return runtime
+}
+
 // InvocationResult represents the result of a Lambda function invocation.
 type InvocationResult struct {
 	FunctionName    string
@@ -349,6 +761,50 @@ type InvocationResult struct {
 	InvokedAt       time.Time
 }
 
+// LambdaAlias is an alias of a Lambda function. A weighted alias sends
+// RoutingWeight of its invocations to RoutingVersion and the rest to Version.
+type LambdaAlias struct {
+	Name           string
+	ARN            string
+	Description    string
+	Version        string  // Primary version
+	RoutingVersion string  // Additional version of a weighted alias
+	RoutingWeight  float64 // Share of invocations, 0 to 1, sent to RoutingVersion
+}
+
+// Weighted returns true if the alias splits traffic between two versions.
+func (a LambdaAlias) Weighted() bool {
+	return a.RoutingVersion != "" && a.RoutingWeight > 0
+}
+
+// LambdaPerformanceWindows are the windows the performance of a function can
+// be looked at over, shortest first.
+var LambdaPerformanceWindows = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour, 7 * 24 * time.Hour}
+
+// LambdaPerformance is how long the invocations of a function took over a
+// time range, how many were cold starts, and how much of its memory they used.
+// Durations come from CloudWatch metrics; cold starts and memory from the
+// REPORT lines of a sample of its invocations.
+type LambdaPerformance struct {
+	Function     string
+	Range        TimeRange
+	MemorySize   int // Configured, in MB
+	Invocations  float64
+	HasDurations bool
+	P50          float64 // Durations in ms
+	P95          float64
+	P99          float64
+
+	Sampled    int     // Invocations whose REPORT line was read
+	ColdStarts int     // Sampled invocations with an init duration
+	InitP50    float64 // Init durations of the cold starts, in ms
+	InitMax    float64
+	MemoryP95  int // Max memory used by the sampled invocations, in MB
+	MemoryMax  int
+
+	Warnings []string // Sources that couldn't be read
+}
+
 // RestAPI represents an API Gateway REST API (v1).
 type RestAPI struct {
 	ID             string
@@ -379,6 +835,17 @@ type APIStage struct {
 	CreatedDate  time.Time
 	LastUpdated  time.Time
 	InvokeURL    string
+
+	AccessLogGroup  string // CloudWatch log group access logs go to, if any
+	AccessLogFormat string
+}
+
+// APIDeployment is a deployment of a REST API, a snapshot of its routes
+// that stages point at.
+type APIDeployment struct {
+	ID          string
+	Description string
+	CreatedDate time.Time
 }
 
 // APIRoute represents a route in API Gateway HTTP API.
@@ -421,6 +888,7 @@ type APIGatewayTunnelType string
 const (
 	APIGatewayTunnelPublic  APIGatewayTunnelType = "PUBLIC"
 	APIGatewayTunnelPrivate APIGatewayTunnelType = "PRIVATE"
+	APIGatewayTunnelMock    APIGatewayTunnelType = "MOCK" // Local mock server, no AWS connection
 )
 
 // APIGatewayTunnel represents an active API Gateway port forwarding tunnel.
@@ -438,6 +906,43 @@ type APIGatewayTunnel struct {
 	Status      TunnelStatus
 	StartedAt   time.Time
 	Error       string
+	Rules       ProxyRules // Applied by the local proxy to every request
+	TLS         bool       // Local proxy serves HTTPS with the local CA
+	MockRoutes  int        // Routes a mock server answers
+}
+
+// LocalURL returns the URL clients use to reach the tunnel.
+func (t *APIGatewayTunnel) LocalURL() string {
+	if t.TLS {
+		return fmt.Sprintf("https://localhost:%d", t.LocalPort)
+	}
+	return fmt.Sprintf("http://localhost:%d", t.LocalPort)
+}
+
+// MockRoute is a route a local mock server answers, and its response: the
+// example configured for it, or an empty 200.
+type MockRoute struct {
+	RouteKey string // e.g. "GET /orders/{id}", "ANY /{proxy+}" or "$default"
+	Status   int
+	Headers  map[string]string
+	Body     string
+}
+
+// ProxyRules holds request modifications applied by a local API Gateway proxy.
+type ProxyRules struct {
+	Headers  map[string]string // Headers set on every forwarded request
+	Rewrites []RewriteRule     // Path rewrites, first matching prefix wins
+}
+
+// RewriteRule replaces a request path prefix before it is forwarded.
+type RewriteRule struct {
+	From string
+	To   string
+}
+
+// IsEmpty returns true if no headers or rewrites are configured.
+func (r ProxyRules) IsEmpty() bool {
+	return len(r.Headers) == 0 && len(r.Rewrites) == 0
 }
 
 // CloudWatchLogEntry represents a single CloudWatch log event.
@@ -465,6 +970,15 @@ const (
 	QueueTypeFIFO     QueueType = "FIFO"
 )
 
+// FIFO deduplication scopes and throughput limits. High throughput mode is
+// deduplication per message group with the limit per message group ID.
+const (
+	FIFODedupScopeQueue           = "queue"
+	FIFODedupScopeMessageGroup    = "messageGroup"
+	FIFOThroughputPerQueue        = "perQueue"
+	FIFOThroughputPerMessageGroup = "perMessageGroupId"
+)
+
 // Queue represents an SQS queue.
 type Queue struct {
 	Name                    string
@@ -484,6 +998,26 @@ type Queue struct {
 	DLQName         string
 	DLQMessageCount int
 	MaxReceiveCount int // Number of receives before message goes to DLQ
+	// FIFO settings
+	ContentBasedDeduplication bool   // Deduplication IDs default to a hash of the body
+	DeduplicationScope        string // FIFODedupScopeQueue or FIFODedupScopeMessageGroup
+	FifoThroughputLimit       string // FIFOThroughputPerQueue or FIFOThroughputPerMessageGroup
+}
+
+// QueueRelation is a workload or AWS service that reads from or writes to an
+// SQS queue.
+type QueueRelation struct {
+	Kind    string // lambda, ecs, or the principal of a queue policy
+	Name    string
+	Via     string // What ties it to the queue, e.g. "event source mapping"
+	Certain bool   // Configured to, rather than only permitted to
+}
+
+// QueueRelations are the likely consumers and producers of an SQS queue.
+type QueueRelations struct {
+	Consumers []QueueRelation
+	Producers []QueueRelation
+	Warnings  []string // Sources that couldn't be read, e.g. IAM access denied
 }
 
 // HasDLQMessages returns true if the queue has messages in its DLQ.
@@ -491,6 +1025,101 @@ func (q *Queue) HasDLQMessages() bool {
 	return q.HasDLQ && q.DLQMessageCount > 0
 }
 
+// IsHighThroughputFIFO returns true if the FIFO queue deduplicates and
+// limits throughput per message group rather than for the whole queue.
+func (q *Queue) IsHighThroughputFIFO() bool {
+	return q.Type == QueueTypeFIFO &&
+		q.DeduplicationScope == FIFODedupScopeMessageGroup &&
+		q.FifoThroughputLimit == FIFOThroughputPerMessageGroup
+}
+
+// ValidateSend checks the IDs a message sent to the queue needs. FIFO queues
+// require a message group ID, and a deduplication ID unless content-based
+// deduplication is on. Standard queues take neither.
+func (q *Queue) ValidateSend(groupID, dedupID string) error {
+	if q.Type != QueueTypeFIFO {
+		if groupID != "" || dedupID != "" {
+			return fmt.Errorf("%s is a standard queue; message group and deduplication IDs are only for FIFO queues", q.Name)
+		}
+		return nil
+	}
+	if strings.TrimSpace(groupID) == "" {
+		return fmt.Errorf("MessageGroupId is required for FIFO queue %s", q.Name)
+	}
+	if strings.TrimSpace(dedupID) == "" && !q.ContentBasedDeduplication {
+		return fmt.Errorf("MessageDeduplicationId is required: %s doesn't have content-based deduplication", q.Name)
+	}
+	// Both IDs are up to 128 printable ASCII characters, without spaces
+	for _, id := range []string{groupID, dedupID} {
+		if len(id) > 128 {
+			return fmt.Errorf("IDs are at most 128 characters, got %d", len(id))
+		}
+		for _, r := range id {
+			if r < '!' || r > '~' {
+				return fmt.Errorf("ID %q has %q; only letters, digits and punctuation are allowed", id, r)
+			}
+		}
+	}
+	return nil
+}
+
+// QueueMessage is a message received from an SQS queue.
+type QueueMessage struct {
+	ID                string            `json:"message_id"`
+	Body              string            `json:"body"`
+	SentAt            time.Time         `json:"sent_at"`
+	ReceiveCount      int               `json:"receive_count"`
+	Attributes        map[string]string `json:"attributes,omitempty"`         // System attributes, e.g. SenderId
+	MessageAttributes map[string]string `json:"message_attributes,omitempty"` // String and number values of the sender's attributes
+}
+
+// QueueFailureWindow is how far back the errors of a queue's consumers are
+// looked for.
+const QueueFailureWindow = time.Hour
+
+// QueueConsumer is a Lambda function reading from a queue through an event
+// source mapping, with its invocations over the failure window.
+type QueueConsumer struct {
+	Function    string
+	State       string // Of the mapping, e.g. Enabled
+	LastResult  string // Of the mapping's last poll, e.g. "OK" or an access error
+	BatchSize   int
+	Invocations float64
+	Errors      float64
+	Throttles   float64
+	HasMetrics  bool // False if CloudWatch couldn't be read
+}
+
+// FailedMessage is a message sampled from a DLQ, with the error lines logged
+// by the invocations that received it.
+type FailedMessage struct {
+	Message    QueueMessage
+	RequestIDs []string // Of the invocations that logged the message ID
+	Errors     []LogSearchHit
+}
+
+// QueueFailures ties the messages in a queue's DLQ to the errors and
+// throttles of the Lambda functions consuming the queue.
+type QueueFailures struct {
+	Queue     Queue
+	Since     time.Time // Start of the logs searched
+	Consumers []QueueConsumer
+	Messages  []FailedMessage
+	Errors    []LogSearchHit // Error lines of the consumers, newest first
+	Warnings  []string       // Sources that couldn't be read
+}
+
+// Matched returns the number of sampled messages with error lines.
+func (f *QueueFailures) Matched() int {
+	n := 0
+	for _, m := range f.Messages {
+		if len(m.Errors) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
 // TableStatus represents the status of a DynamoDB table.
 type TableStatus string
 
@@ -649,6 +1278,9 @@ type DynamoDBItem struct {
 	PartitionKeyValue string
 	// SortKeyValue is the SK value for quick display (may be empty)
 	SortKeyValue string
+	// Typed is the item in DynamoDB JSON, each value tagged with its type,
+	// which editing the item starts from
+	Typed string
 }
 
 // Preview returns a truncated preview of the item for list display.
@@ -668,3 +1300,820 @@ type QueryResult struct {
 	ConsumedCapacity  float64
 	HasMorePages      bool
 }
+
+// OnDemandReadPrice is the on-demand price of a million read request units
+// in us-east-1, in US dollars. Most other regions charge a little more.
+const OnDemandReadPrice = 0.125
+
+// ReadCost estimates what consumed read capacity units cost on demand.
+func ReadCost(units float64) float64 {
+	return units * OnDemandReadPrice / 1_000_000
+}
+
+// ScanReadUnits estimates the read capacity units an eventually consistent
+// scan of limit items consumes, or of the whole table if limit is 0. A scan
+// reads at most 1 MB per page, and is billed per 4 KB read in total. The
+// estimate goes by the table's size, which DynamoDB updates about every six
+// hours.
+func (t *Table) ScanReadUnits(limit int32) float64 {
+	bytes := t.SizeBytes
+	if limit > 0 && t.ItemCount > 0 {
+		bytes = min(bytes, int64(limit)*(t.SizeBytes/t.ItemCount+1), 1<<20)
+	}
+	return float64((bytes+4095)/4096) / 2
+}
+
+// AppRunnerStatus represents the status of an App Runner service.
+type AppRunnerStatus string
+
+const (
+	AppRunnerStatusCreateFailed AppRunnerStatus = "CREATE_FAILED"
+	AppRunnerStatusRunning      AppRunnerStatus = "RUNNING"
+	AppRunnerStatusDeleted      AppRunnerStatus = "DELETED"
+	AppRunnerStatusDeleteFailed AppRunnerStatus = "DELETE_FAILED"
+	AppRunnerStatusPaused       AppRunnerStatus = "PAUSED"
+	AppRunnerStatusInProgress   AppRunnerStatus = "OPERATION_IN_PROGRESS"
+)
+
+// IsHealthy returns true if the service is running.
+func (s AppRunnerStatus) IsHealthy() bool {
+	return s == AppRunnerStatusRunning
+}
+
+// IsFailed returns true if the last create or delete failed.
+func (s AppRunnerStatus) IsFailed() bool {
+	return s == AppRunnerStatusCreateFailed || s == AppRunnerStatusDeleteFailed
+}
+
+// AppRunnerService represents an App Runner service.
+type AppRunnerService struct {
+	Name       string
+	ARN        string
+	ServiceID  string
+	URL        string
+	Status     AppRunnerStatus
+	AutoDeploy bool
+	SourceType string // Image or Code
+	Source     string // image identifier or repository URL
+	CPU        string
+	Memory     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// AppRunnerOperation represents an operation on an App Runner service.
+type AppRunnerOperation struct {
+	ID        string
+	Type      string // START_DEPLOYMENT, PAUSE_SERVICE, ...
+	Status    string
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// AlarmState represents the state of a CloudWatch alarm.
+type AlarmState string
+
+const (
+	AlarmStateOK               AlarmState = "OK"
+	AlarmStateAlarm            AlarmState = "ALARM"
+	AlarmStateInsufficientData AlarmState = "INSUFFICIENT_DATA"
+)
+
+// Alarm represents a CloudWatch metric alarm.
+type Alarm struct {
+	Name      string
+	State     AlarmState
+	Reason    string
+	Metric    string
+	UpdatedAt time.Time
+}
+
+// DeliveryStreamStatus represents the status of a Firehose delivery stream.
+type DeliveryStreamStatus string
+
+const (
+	DeliveryStreamStatusCreating     DeliveryStreamStatus = "CREATING"
+	DeliveryStreamStatusCreateFailed DeliveryStreamStatus = "CREATING_FAILED"
+	DeliveryStreamStatusDeleting     DeliveryStreamStatus = "DELETING"
+	DeliveryStreamStatusDeleteFailed DeliveryStreamStatus = "DELETING_FAILED"
+	DeliveryStreamStatusActive       DeliveryStreamStatus = "ACTIVE"
+)
+
+// IsFailed returns true if creating or deleting the stream failed.
+func (s DeliveryStreamStatus) IsFailed() bool {
+	return s == DeliveryStreamStatusCreateFailed || s == DeliveryStreamStatusDeleteFailed
+}
+
+// DeliveryStream represents a Kinesis Data Firehose delivery stream.
+type DeliveryStream struct {
+	Name           string
+	ARN            string
+	Status         DeliveryStreamStatus
+	SourceType     string // DirectPut, KinesisStreamAsSource, MSKAsSource, ...
+	Destination    string // S3, Redshift, OpenSearch, HTTP endpoint, ...
+	Target         string // Bucket, cluster, domain or endpoint the stream delivers to
+	BufferSizeMB   int
+	BufferInterval int // Seconds
+	Compression    string
+	FailureType    string // Set when the stream is in a failed state
+	FailureDetails string
+	ErrorLogGroup  string // CloudWatch log group for delivery errors, if logging is enabled
+	ErrorLogStream string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// HasErrorLogging returns true if delivery errors are logged to CloudWatch.
+func (d *DeliveryStream) HasErrorLogging() bool {
+	return d.ErrorLogGroup != ""
+}
+
+// UserPool represents a Cognito user pool.
+type UserPool struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// UserPoolDetails holds the configuration and app clients of a Cognito user pool.
+type UserPoolDetails struct {
+	PoolID             string
+	ARN                string
+	EstimatedUsers     int
+	MFA                string // OFF, ON or OPTIONAL
+	Domain             string
+	UsernameAttributes []string // Attributes that can be used as username, e.g. email
+	Clients            []UserPoolClient
+}
+
+// UserPoolClient represents an app client of a Cognito user pool.
+type UserPoolClient struct {
+	ID           string
+	Name         string
+	HasSecret    bool
+	CallbackURLs []string
+	LogoutURLs   []string
+	OAuthScopes  []string
+	OAuthFlows   []string // code, implicit, client_credentials
+	AuthFlows    []string // ALLOW_USER_SRP_AUTH, ALLOW_REFRESH_TOKEN_AUTH, ...
+}
+
+// CognitoUserStatus represents the account status of a Cognito user.
+type CognitoUserStatus string
+
+const (
+	CognitoUserStatusUnconfirmed         CognitoUserStatus = "UNCONFIRMED"
+	CognitoUserStatusConfirmed           CognitoUserStatus = "CONFIRMED"
+	CognitoUserStatusExternalProvider    CognitoUserStatus = "EXTERNAL_PROVIDER"
+	CognitoUserStatusResetRequired       CognitoUserStatus = "RESET_REQUIRED"
+	CognitoUserStatusForceChangePassword CognitoUserStatus = "FORCE_CHANGE_PASSWORD"
+	CognitoUserStatusCompromised         CognitoUserStatus = "COMPROMISED"
+)
+
+// CognitoUser represents a user in a Cognito user pool.
+type CognitoUser struct {
+	Username      string
+	Email         string
+	EmailVerified bool
+	Status        CognitoUserStatus
+	Enabled       bool
+	Attributes    map[string]string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// CloudResource represents a resource listed through the Cloud Control API.
+type CloudResource struct {
+	TypeName   string // e.g., AWS::MSK::Cluster
+	Identifier string // Primary identifier, often an ARN or name
+	Name       string // Name-like property, if the resource has one
+	Properties string // Resource properties as JSON
+}
+
+// MSKClusterState represents the state of an MSK cluster.
+type MSKClusterState string
+
+const (
+	MSKClusterStateActive      MSKClusterState = "ACTIVE"
+	MSKClusterStateCreating    MSKClusterState = "CREATING"
+	MSKClusterStateUpdating    MSKClusterState = "UPDATING"
+	MSKClusterStateDeleting    MSKClusterState = "DELETING"
+	MSKClusterStateFailed      MSKClusterState = "FAILED"
+	MSKClusterStateHealing     MSKClusterState = "HEALING"
+	MSKClusterStateMaintenance MSKClusterState = "MAINTENANCE"
+)
+
+// MSKCluster represents an Amazon MSK (Kafka) cluster.
+type MSKCluster struct {
+	Name           string
+	ARN            string
+	Type           string // PROVISIONED or SERVERLESS
+	State          MSKClusterState
+	KafkaVersion   string // Empty for serverless clusters
+	InstanceType   string
+	BrokerCount    int
+	ClientSubnets  []string
+	SecurityGroups []string
+	Auth           []string // Client authentication methods: IAM, SCRAM, TLS, Unauthenticated
+	CreatedAt      time.Time
+}
+
+// IsServerless returns true for MSK Serverless clusters.
+func (c MSKCluster) IsServerless() bool {
+	return c.Type == "SERVERLESS"
+}
+
+// MSKBootstrapBrokers are the bootstrap broker strings of an MSK cluster, one
+// per client authentication method. Each is a comma-separated host:port list.
+type MSKBootstrapBrokers struct {
+	ClusterARN string
+	Plaintext  string
+	TLS        string
+	SASLSCRAM  string
+	SASLIAM    string
+}
+
+// Preferred returns the bootstrap brokers tunnels should use, preferring IAM,
+// then SCRAM, TLS and plaintext, together with the method's name.
+func (b MSKBootstrapBrokers) Preferred() (method, brokers string) {
+	switch {
+	case b.SASLIAM != "":
+		return "IAM", b.SASLIAM
+	case b.SASLSCRAM != "":
+		return "SCRAM", b.SASLSCRAM
+	case b.TLS != "":
+		return "TLS", b.TLS
+	default:
+		return "Plaintext", b.Plaintext
+	}
+}
+
+// SESAccount is the SES sending status and quota of the account in a region.
+type SESAccount struct {
+	SendingEnabled    bool
+	ProductionAccess  bool   // False while the account is in the sandbox
+	EnforcementStatus string // HEALTHY, PROBATION or SHUTDOWN
+	Max24HourSend     float64
+	MaxSendRate       float64 // Messages per second
+	SentLast24Hours   float64
+	SuppressedReasons []string // Reasons addresses are added to the account suppression list
+	HasReputation     bool     // Set when reputation metrics were found in CloudWatch
+	BounceRate        float64  // Fraction of sent messages, e.g. 0.02 for 2%
+	ComplaintRate     float64
+	ReputationPeriod  string // How far back the reputation metrics were looked up
+}
+
+// QuotaUsed returns the share of the 24 hour sending quota used so far.
+func (a SESAccount) QuotaUsed() float64 {
+	if a.Max24HourSend <= 0 {
+		return 0
+	}
+	return a.SentLast24Hours / a.Max24HourSend
+}
+
+// SESIdentity is a verified (or pending) SES email address or domain.
+type SESIdentity struct {
+	Name               string
+	Type               string // EMAIL_ADDRESS, DOMAIN or MANAGED_DOMAIN
+	VerificationStatus string // SUCCESS, PENDING, FAILED, TEMPORARY_FAILURE or NOT_STARTED
+	SendingEnabled     bool
+}
+
+// IsVerified returns true if mail can be sent from the identity.
+func (i SESIdentity) IsVerified() bool {
+	return i.VerificationStatus == "SUCCESS"
+}
+
+// IsEmail returns true for email address identities.
+func (i SESIdentity) IsEmail() bool {
+	return i.Type == "EMAIL_ADDRESS"
+}
+
+// SESConfigurationSet is a set of rules applied to emails sent with it.
+type SESConfigurationSet struct {
+	Name              string
+	SendingEnabled    bool
+	ReputationMetrics bool
+	LastFreshStart    time.Time
+	TLSPolicy         string // REQUIRE or OPTIONAL
+	SuppressedReasons []string
+	EventDestinations []SESEventDestination
+}
+
+// SESEventDestination is where a configuration set publishes sending events.
+type SESEventDestination struct {
+	Name       string
+	Enabled    bool
+	EventTypes []string // SEND, BOUNCE, COMPLAINT, DELIVERY, ...
+	Target     string   // e.g. "SNS arn:aws:sns:...", "CloudWatch"
+}
+
+// SESSuppressedDestination is an address on the account suppression list.
+type SESSuppressedDestination struct {
+	Email       string
+	Reason      string // BOUNCE or COMPLAINT
+	LastUpdated time.Time
+}
+
+// CallerIdentity is the account and principal the credentials belong to.
+type CallerIdentity struct {
+	Account string
+	ARN     string
+	UserID  string
+}
+
+// OrgAccount is a member account of an AWS organization.
+type OrgAccount struct {
+	ID       string
+	Name     string
+	Email    string
+	Status   string // ACTIVE, SUSPENDED or PENDING_CLOSURE
+	JoinedAt time.Time
+}
+
+// IsActive returns true if the account can be assumed into.
+func (a OrgAccount) IsActive() bool {
+	return a.Status == "ACTIVE"
+}
+
+// ServiceCheck is the result of a cheap read call against one service.
+type ServiceCheck struct {
+	Service string
+	OK      bool
+	Error   string
+	Latency time.Duration
+}
+
+// ActivityEvent is a CloudTrail management event that changed a resource.
+type ActivityEvent struct {
+	ID        string
+	Name      string // API call, e.g. UpdateService
+	Source    string // e.g. ecs.amazonaws.com
+	Time      time.Time
+	Username  string // IAM user or role session name
+	Principal string // ARN of the caller
+	SourceIP  string
+	ErrorCode string // Set when the call failed
+	Raw       string // Full event JSON
+}
+
+// Failed returns true if the call was rejected or failed.
+func (e ActivityEvent) Failed() bool {
+	return e.ErrorCode != ""
+}
+
+// LogSource is a log group searched on behalf of a stack resource.
+type LogSource struct {
+	Resource string // What writes to the group, e.g. "api/web" (service/container) or a function name
+	LogGroup string
+}
+
+// LogSearchHit is a log event matched by a search across log groups.
+type LogSearchHit struct {
+	ID            string
+	Source        LogSource
+	Timestamp     time.Time
+	Message       string
+	LogStreamName string
+}
+
+// TimeRange is the time a view reads over: the last Last up to now, or from
+// Start to End. A zero End runs up to now; a zero range is the view's own
+// default.
+type TimeRange struct {
+	Last  time.Duration
+	Start time.Time
+	End   time.Time
+}
+
+// LastRange returns the range of the last d up to now.
+func LastRange(d time.Duration) TimeRange {
+	return TimeRange{Last: d}
+}
+
+// IsZero returns true if no range was chosen.
+func (r TimeRange) IsZero() bool {
+	return r.Last == 0 && r.Start.IsZero()
+}
+
+// IsAbsolute returns true if the range starts at a fixed time rather than a
+// duration before now.
+func (r TimeRange) IsAbsolute() bool {
+	return !r.Start.IsZero()
+}
+
+// Bounds returns where the range starts and ends as of now.
+func (r TimeRange) Bounds(now time.Time) (start, end time.Time) {
+	if !r.IsAbsolute() {
+		return now.Add(-r.Last), now
+	}
+	end = r.End
+	if end.IsZero() {
+		end = now
+	}
+	return r.Start, end
+}
+
+// timeRangeLayouts are the layouts a custom range's times are read in, with
+// a date or as a time of today.
+var timeRangeLayouts = []string{"2006-01-02 15:04", "2006-01-02", "15:04"}
+
+// ParseTimeRange reads a custom range, e.g. "2024-05-01 09:00 to
+// 2024-05-01 12:30", "09:00 to 10:00" for today, or "2024-05-01 09:00" up to
+// now. Times are local.
+func ParseTimeRange(s string, now time.Time) (TimeRange, error) {
+	from, to, hasEnd := strings.Cut(strings.TrimSpace(s), " to ")
+	start, err := parseRangeTime(from, now)
+	if err != nil {
+		return TimeRange{}, err
+	}
+	r := TimeRange{Start: start}
+	if hasEnd && strings.TrimSpace(to) != "now" {
+		if r.End, err = parseRangeTime(to, now); err != nil {
+			return TimeRange{}, err
+		}
+		if !r.End.After(r.Start) {
+			return TimeRange{}, fmt.Errorf("the range ends before it starts")
+		}
+	}
+	if r.Start.After(now) {
+		return TimeRange{}, fmt.Errorf("the range starts in the future")
+	}
+	return r, nil
+}
+
+// parseRangeTime reads one end of a custom range.
+func parseRangeTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeRangeLayouts {
+		t, err := time.ParseInLocation(layout, s, now.Location())
+		if err != nil {
+			continue
+		}
+		if layout == "15:04" {
+			t = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("can't read %q as YYYY-MM-DD HH:MM or HH:MM", s)
+}
+
+// Certificate is an ACM certificate.
+type Certificate struct {
+	ARN        string
+	DomainName string
+	Status     string // e.g. ISSUED, EXPIRED
+	Type       string // AMAZON_ISSUED, IMPORTED or PRIVATE
+	NotAfter   time.Time
+	InUse      bool
+	Renewable  bool // ACM renews it automatically
+}
+
+// ExpiresWithin returns true if the certificate expired or expires within d.
+func (c Certificate) ExpiresWithin(d time.Duration) bool {
+	return !c.NotAfter.IsZero() && time.Until(c.NotAfter) < d
+}
+
+// Account health checks.
+const (
+	HealthStacks       = "stacks"
+	HealthServices     = "services"
+	HealthAlarms       = "alarms"
+	HealthDLQs         = "dlqs"
+	HealthCertificates = "certificates"
+)
+
+// AccountHealth lists what needs attention in an account and region.
+type AccountHealth struct {
+	FailedStacks         []Stack
+	UnhealthyServices    []Service // Fewer tasks running than desired
+	Alarms               []Alarm   // In ALARM state
+	DLQs                 []Queue   // Queues whose dead-letter queue has messages
+	ExpiringCertificates []Certificate
+	Errors               map[string]string // Check -> error, for checks that could not run
+	CheckedAt            time.Time
+}
+
+// ProblemCount returns the number of problems found by all checks.
+func (h AccountHealth) ProblemCount() int {
+	return len(h.FailedStacks) + len(h.UnhealthyServices) + len(h.Alarms) + len(h.DLQs) + len(h.ExpiringCertificates)
+}
+
+// ScheduleState is whether an EventBridge Scheduler schedule runs.
+type ScheduleState string
+
+const (
+	ScheduleStateEnabled  ScheduleState = "ENABLED"
+	ScheduleStateDisabled ScheduleState = "DISABLED"
+)
+
+// Schedule is an EventBridge Scheduler schedule.
+type Schedule struct {
+	Name           string
+	Group          string
+	ARN            string
+	State          ScheduleState
+	Expression     string // cron(...), rate(...) or at(...)
+	Timezone       string // IANA zone of cron and at expressions; UTC if empty
+	Description    string
+	TargetARN      string
+	TargetRole     string
+	TargetInput    string
+	FlexibleWindow int // Minutes a run may be delayed by; 0 runs on time
+	StartDate      time.Time
+	EndDate        time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsDefaultGroup returns true if the schedule is in the default group.
+func (s Schedule) IsDefaultGroup() bool {
+	return s.Group == "" || s.Group == "default"
+}
+
+// TargetService returns the service the schedule invokes, e.g. lambda, sqs
+// or states, and for universal targets the API action, e.g. ec2:stopInstances.
+func (s Schedule) TargetService() string {
+	// arn:aws:scheduler:::aws-sdk:service:apiAction for universal targets
+	parts := strings.SplitN(s.TargetARN, ":", 6)
+	if len(parts) < 6 {
+		return ""
+	}
+	if parts[2] == "scheduler" && strings.HasPrefix(parts[5], "aws-sdk:") {
+		return strings.TrimPrefix(parts[5], "aws-sdk:")
+	}
+	return parts[2]
+}
+
+// SecretStaleAfter is how long a secret may go unchanged without rotation
+// before it is flagged as stale.
+const SecretStaleAfter = 90 * 24 * time.Hour
+
+// SecretRotation is how a secret's rotation stands.
+type SecretRotation string
+
+const (
+	SecretRotationOK       SecretRotation = "ROTATING"
+	SecretRotationOverdue  SecretRotation = "OVERDUE"
+	SecretRotationNever    SecretRotation = "NEVER ROTATED"
+	SecretRotationStale    SecretRotation = "STALE"
+	SecretRotationDisabled SecretRotation = "NO ROTATION"
+)
+
+// Secret is a Secrets Manager secret and its rotation settings.
+type Secret struct {
+	Name             string
+	ARN              string
+	Description      string
+	RotationEnabled  bool
+	RotationLambda   string // ARN of the rotation function; empty for managed rotation
+	RotationSchedule string // schedule expression, e.g. rate(30 days)
+	RotationDays     int64  // AutomaticallyAfterDays of the rotation rules
+	OwningService    string // Service that manages the secret, e.g. rds
+	LastRotated      time.Time
+	LastChanged      time.Time
+	LastAccessed     time.Time // Day granularity
+	NextRotation     time.Time
+	CreatedAt        time.Time
+}
+
+// Rotation returns how the secret's rotation stands at now. Secrets with
+// rotation on are overdue once their next rotation has passed; secrets
+// without it are stale once unchanged for SecretStaleAfter.
+func (s Secret) Rotation(now time.Time) SecretRotation {
+	if s.RotationEnabled {
+		if !s.NextRotation.IsZero() && s.NextRotation.Before(now) {
+			return SecretRotationOverdue
+		}
+		if s.LastRotated.IsZero() && s.NextRotation.IsZero() {
+			return SecretRotationNever
+		}
+		return SecretRotationOK
+	}
+	changed := s.LastChanged
+	if changed.IsZero() {
+		changed = s.CreatedAt
+	}
+	if !changed.IsZero() && now.Sub(changed) > SecretStaleAfter {
+		if s.LastRotated.IsZero() {
+			return SecretRotationNever
+		}
+		return SecretRotationStale
+	}
+	return SecretRotationDisabled
+}
+
+// Flagged returns true if the secret's rotation needs attention at now.
+func (s Secret) Flagged(now time.Time) bool {
+	switch s.Rotation(now) {
+	case SecretRotationOverdue, SecretRotationNever, SecretRotationStale:
+		return true
+	}
+	return false
+}
+
+// LogRetentionDays are the retention periods CloudWatch Logs accepts, in
+// days. A log group without one keeps its events forever.
+var LogRetentionDays = []int32{1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653}
+
+// LogGroup is a CloudWatch Logs log group.
+type LogGroup struct {
+	Name              string
+	ARN               string
+	StoredBytes       int64
+	RetentionDays     int32  // 0 if events never expire
+	Class             string // STANDARD, INFREQUENT_ACCESS or DELIVERY
+	MetricFilters     int32
+	DeletionProtected bool
+	CreatedAt         time.Time
+	Owner             string // Resource the name says writes to it, e.g. "lambda orders"; empty if unknown
+	Orphaned          bool   // Owner no longer exists
+}
+
+// NeverExpires returns true if the group keeps its events forever.
+func (g LogGroup) NeverExpires() bool {
+	return g.RetentionDays == 0
+}
+
+// BudgetWarnPercent is the share of a budget spent, actually or by
+// forecast, from which it is highlighted.
+const BudgetWarnPercent = 80
+
+// Budget is an AWS Budgets budget and what has been spent against it in the
+// current period.
+type Budget struct {
+	Name     string
+	Type     string // COST, USAGE, RI_UTILIZATION...
+	TimeUnit string // MONTHLY, QUARTERLY, ANNUALLY or DAILY
+	Limit    float64
+	Actual   float64
+	Forecast float64 // 0 if Budgets has no forecast yet
+	Unit     string  // Currency, e.g. USD, or usage unit
+}
+
+// UsedPercent returns the share of the limit spent so far.
+func (b Budget) UsedPercent() float64 {
+	if b.Limit <= 0 {
+		return 0
+	}
+	return b.Actual / b.Limit * 100
+}
+
+// ForecastPercent returns the share of the limit forecast to be spent by the
+// end of the period.
+func (b Budget) ForecastPercent() float64 {
+	if b.Limit <= 0 {
+		return 0
+	}
+	return b.Forecast / b.Limit * 100
+}
+
+// CostSummary is the account's estimated charges for the month and its
+// budgets.
+type CostSummary struct {
+	Charges   float64   // Estimated charges so far this month
+	Currency  string    // Currency of Charges; empty if the billing metric has no data
+	ChargesAt time.Time // When CloudWatch last updated Charges
+	Budgets   []Budget
+	Warnings  []string // Sources that couldn't be read
+}
+
+// TerraformResource is a managed resource instance in a Terraform state.
+type TerraformResource struct {
+	Address string   // e.g. module.orders.aws_sqs_queue.main["eu"]
+	Module  string   // e.g. module.orders, or "" for the root module
+	ARNs    []string // Of the AWS resource, from its arn and id attributes
+	State   string   // Location of the state, as configured
+}
+
+// MQBrokerState represents the state of an Amazon MQ broker.
+type MQBrokerState string
+
+const (
+	MQBrokerStateRunning        MQBrokerState = "RUNNING"
+	MQBrokerStateCreating       MQBrokerState = "CREATION_IN_PROGRESS"
+	MQBrokerStateCreationFailed MQBrokerState = "CREATION_FAILED"
+	MQBrokerStateDeleting       MQBrokerState = "DELETION_IN_PROGRESS"
+	MQBrokerStateRebooting      MQBrokerState = "REBOOT_IN_PROGRESS"
+	MQBrokerStateActionRequired MQBrokerState = "CRITICAL_ACTION_REQUIRED"
+	MQBrokerStateReplica        MQBrokerState = "REPLICA"
+)
+
+// MQBroker represents an Amazon MQ broker running ActiveMQ or RabbitMQ.
+type MQBroker struct {
+	ID             string
+	Name           string
+	ARN            string
+	State          MQBrokerState
+	Engine         string // ACTIVEMQ or RABBITMQ
+	EngineVersion  string
+	InstanceType   string
+	DeploymentMode string // SINGLE_INSTANCE, ACTIVE_STANDBY_MULTI_AZ or CLUSTER_MULTI_AZ
+	Public         bool
+	Subnets        []string
+	SecurityGroups []string
+	Instances      []MQBrokerInstance
+	CreatedAt      time.Time
+}
+
+// MQBrokerInstance is a broker node, or the cluster endpoint of a RabbitMQ
+// cluster.
+type MQBrokerInstance struct {
+	ConsoleURL string
+	Endpoints  []string // e.g. amqps://b-1234.mq.eu-west-1.amazonaws.com:5671
+	IP         string
+}
+
+// IsRabbitMQ returns true for RabbitMQ brokers.
+func (b MQBroker) IsRabbitMQ() bool {
+	return b.Engine == "RABBITMQ"
+}
+
+// DatabaseKind is the kind of endpoint a Database is.
+type DatabaseKind string
+
+const (
+	DatabaseKindInstance    DatabaseKind = "RDS instance"
+	DatabaseKindCluster     DatabaseKind = "RDS cluster"
+	DatabaseKindReader      DatabaseKind = "RDS cluster reader"
+	DatabaseKindProxy       DatabaseKind = "RDS Proxy"
+	DatabaseKindProxyReader DatabaseKind = "RDS Proxy reader"
+	DatabaseKindRedshift    DatabaseKind = "Redshift"
+)
+
+// Database is an endpoint of a database that vaws tunnels to through a jump
+// host: an RDS instance, the writer or reader endpoint of an Aurora cluster,
+// an RDS Proxy endpoint or a Redshift cluster.
+type Database struct {
+	ID       string // e.g. arn:...:db:orders, or the ARN plus /reader for readers
+	Name     string
+	ARN      string
+	Kind     DatabaseKind
+	Engine   string // e.g. postgres, aurora-mysql, redshift
+	Status   string // e.g. available, as the service reports it
+	Endpoint string
+	Port     int
+	VPCID    string
+	Public   bool
+}
+
+// Available reports whether the database accepts connections.
+func (d Database) Available() bool {
+	return d.Status == "available"
+}
+
+// EFSFileSystem represents an Amazon EFS file system.
+type EFSFileSystem struct {
+	ID               string
+	Name             string
+	ARN              string
+	State            string // available, creating, updating, deleting, deleted or error
+	SizeBytes        int64  // Metered size, updated by EFS about once an hour
+	PerformanceMode  string // generalPurpose or maxIO
+	ThroughputMode   string // bursting, provisioned or elastic
+	ProvisionedMiBps float64
+	Encrypted        bool
+	MountTargetCount int
+	CreatedAt        time.Time
+}
+
+// EFSMountTarget is the network interface of a file system in a subnet.
+type EFSMountTarget struct {
+	ID       string
+	AZ       string
+	SubnetID string
+	IP       string
+	State    string
+}
+
+// EFSAccessPoint is an application entry point into a file system, with the
+// POSIX user and root directory it enforces.
+type EFSAccessPoint struct {
+	ID    string
+	ARN   string
+	Name  string
+	Path  string
+	UID   int64
+	GID   int64
+	State string
+}
+
+// EFSMount is an ECS task definition or Lambda function that mounts a file
+// system.
+type EFSMount struct {
+	Kind          string // "task definition" or "function"
+	Name          string // Task definition family:revision, or function name
+	Container     string // Container that mounts the volume, for task definitions
+	Path          string // Mount path in the container or function
+	AccessPointID string
+}
+
+// EFSDetails are the mount targets, access points and users of a file
+// system.
+type EFSDetails struct {
+	FileSystemID string
+	MountTargets []EFSMountTarget
+	AccessPoints []EFSAccessPoint
+	Mounts       []EFSMount
+}
@@ -0,0 +1,415 @@
+// Package report renders Markdown snapshots of a stack or an ECS cluster:
+// its services and images, functions, queue depths and alarms, for pasting
+// into an incident or handover doc.
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"vaws/internal/aws"
+	"vaws/internal/model"
+)
+
+// Kind is what a report covers.
+type Kind string
+
+// Scopes a report can cover
+const (
+	KindStack   Kind = "stack"
+	KindCluster Kind = "cluster"
+)
+
+// timeLayout renders the times of a report, always in UTC so readers in
+// other time zones get the same text.
+const timeLayout = "2006-01-02 15:04 UTC"
+
+// Scope is the stack or cluster a report covers.
+type Scope struct {
+	Kind Kind
+	Name string
+}
+
+// ParseKind reads the kind of a scope as typed, e.g. "stack".
+func ParseKind(s string) (Kind, error) {
+	switch k := Kind(strings.ToLower(s)); k {
+	case KindStack, KindCluster:
+		return k, nil
+	}
+	return "", fmt.Errorf("unknown report scope %q (use stack or cluster)", s)
+}
+
+// Report is the state of the resources of a scope at one point in time.
+type Report struct {
+	Scope       Scope
+	Profile     string
+	Region      string
+	Account     string
+	GeneratedAt time.Time
+
+	Stack     *model.Stack // Stack scopes only
+	Services  []model.Service
+	Images    map[string][]model.ServiceImage // Service name -> containers
+	Functions []model.Function
+	Queues    []model.Queue
+	Alarms    []model.Alarm
+
+	Warnings []string // Sections that couldn't be read in full
+}
+
+// Build reads the resources of a scope. Only a scope that can't be found
+// fails; resources that can't be read are left out and reported in
+// Warnings.
+func Build(ctx context.Context, client aws.API, scope Scope) (*Report, error) {
+	r := &Report{
+		Scope:       scope,
+		Profile:     client.Profile(),
+		Region:      client.Region(),
+		Account:     client.AssumedAccount(),
+		GeneratedAt: time.Now().UTC(),
+		Images:      make(map[string][]model.ServiceImage),
+	}
+	if r.Account == "" {
+		if identity, err := client.GetCallerIdentity(ctx); err == nil {
+			r.Account = identity.Account
+		}
+	}
+
+	switch scope.Kind {
+	case KindStack:
+		if err := r.readStack(ctx, client); err != nil {
+			return nil, err
+		}
+	case KindCluster:
+		if err := r.readCluster(ctx, client); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown report scope %q", scope.Kind)
+	}
+
+	if len(r.Services) > 0 {
+		images, err := client.GetServiceImages(ctx, r.Services)
+		if err != nil {
+			r.warn("images of the services: %v", err)
+		}
+		for _, img := range images {
+			r.Images[img.Service] = append(r.Images[img.Service], img)
+		}
+	}
+
+	sort.Slice(r.Services, func(i, j int) bool { return r.Services[i].Name < r.Services[j].Name })
+	sort.Slice(r.Functions, func(i, j int) bool { return r.Functions[i].Name < r.Functions[j].Name })
+	sort.Slice(r.Queues, func(i, j int) bool { return r.Queues[i].Name < r.Queues[j].Name })
+	return r, nil
+}
+
+// readStack reads the stack and the services, functions, queues and alarms
+// it defines.
+func (r *Report) readStack(ctx context.Context, client aws.API) error {
+	name := r.Scope.Name
+	stack, err := client.DescribeStack(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to describe stack %s: %w", name, err)
+	}
+	r.Stack = stack
+
+	if r.Services, err = client.GetServicesForStack(ctx, name); err != nil {
+		r.warn("services: %v", err)
+	}
+
+	functions, err := client.GetLambdaFunctionsFromStack(ctx, name)
+	if err != nil {
+		r.warn("functions: %v", err)
+	}
+	for _, fnName := range functions {
+		fn, err := client.DescribeFunction(ctx, fnName)
+		if err != nil {
+			r.warn("function %s: %v", fnName, err)
+			continue
+		}
+		r.Functions = append(r.Functions, *fn)
+	}
+
+	queues, err := client.GetQueuesFromStack(ctx, name)
+	if err != nil {
+		r.warn("queues: %v", err)
+	}
+	for _, url := range queues {
+		q, err := client.GetQueueAttributes(ctx, url)
+		if err != nil {
+			r.warn("queue %s: %v", url, err)
+			continue
+		}
+		r.Queues = append(r.Queues, *q)
+	}
+
+	names, err := client.GetAlarmsFromStack(ctx, name)
+	if err != nil {
+		r.warn("alarms: %v", err)
+		return nil
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	inStack := make(map[string]bool)
+	for _, n := range names {
+		inStack[n] = true
+	}
+	r.readAlarms(ctx, client, func(a model.Alarm) bool { return inStack[a.Name] })
+	return nil
+}
+
+// readCluster reads the services of the cluster, and the alarms named after
+// it or one of its services, since alarms of a cluster aren't recorded
+// anywhere.
+func (r *Report) readCluster(ctx context.Context, client aws.API) error {
+	clusters, err := client.ListClusters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+	var cluster *model.Cluster
+	for i := range clusters {
+		if clusters[i].Name == r.Scope.Name || clusters[i].ARN == r.Scope.Name {
+			cluster = &clusters[i]
+		}
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster %s not found in %s", r.Scope.Name, r.Region)
+	}
+	r.Scope.Name = cluster.Name
+
+	if r.Services, err = client.ListServices(ctx, cluster.ARN); err != nil {
+		r.warn("services: %v", err)
+	}
+
+	names := []string{cluster.Name}
+	for _, svc := range r.Services {
+		names = append(names, svc.Name)
+	}
+	r.readAlarms(ctx, client, func(a model.Alarm) bool {
+		for _, n := range names {
+			if strings.Contains(a.Name, n) {
+				return true
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+// readAlarms keeps the alarms of the region that belong to the scope.
+func (r *Report) readAlarms(ctx context.Context, client aws.API, belongs func(model.Alarm) bool) {
+	alarms, err := client.ListAlarms(ctx)
+	if err != nil {
+		r.warn("alarms: %v", err)
+		return
+	}
+	for _, a := range alarms {
+		if belongs(a) {
+			r.Alarms = append(r.Alarms, a)
+		}
+	}
+}
+
+// warn records a section that couldn't be read.
+func (r *Report) warn(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Markdown renders the report as a Markdown document.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	title := "Stack"
+	if r.Scope.Kind == KindCluster {
+		title = "ECS cluster"
+	}
+	fmt.Fprintf(&b, "# %s %s\n\n", title, r.Scope.Name)
+
+	header := []string{"Region `" + r.Region + "`"}
+	if r.Profile != "" {
+		header = append([]string{"Profile `" + r.Profile + "`"}, header...)
+	}
+	if r.Account != "" {
+		header = append(header, "Account `"+r.Account+"`")
+	}
+	header = append(header, "Generated "+r.GeneratedAt.Format(timeLayout)+" by vaws")
+	b.WriteString(strings.Join(header, " · ") + "\n\n")
+
+	b.WriteString("## Summary\n\n")
+	for _, line := range r.summary() {
+		b.WriteString("- " + line + "\n")
+	}
+
+	if len(r.Services) > 0 {
+		b.WriteString("\n## ECS services\n\n")
+		b.WriteString("| Service | Cluster | Running | Desired | Pending | Status | Task definition | Images |\n")
+		b.WriteString("|---|---|--:|--:|--:|---|---|---|\n")
+		for _, svc := range r.Services {
+			fmt.Fprintf(&b, "| %s | %s | %d | %d | %d | %s | %s | %s |\n",
+				cell(svc.Name), cell(svc.ClusterName), svc.RunningCount, svc.DesiredCount, svc.PendingCount,
+				cell(string(svc.Status)), cell(shortTaskDefinition(svc.TaskDefinition)), cell(r.imageList(svc.Name)))
+		}
+	}
+
+	if len(r.Functions) > 0 {
+		b.WriteString("\n## Lambda functions\n\n")
+		b.WriteString("| Function | Runtime | Memory (MB) | Timeout (s) | State | Last modified |\n")
+		b.WriteString("|---|---|--:|--:|---|---|\n")
+		for _, fn := range r.Functions {
+			runtime := fn.Runtime
+			if fn.PackageType == "Image" {
+				runtime = "container image"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %d | %d | %s | %s |\n",
+				cell(fn.Name), cell(runtime), fn.MemorySize, fn.Timeout, cell(string(fn.State)), formatTime(fn.LastModified))
+		}
+	}
+
+	if len(r.Queues) > 0 {
+		b.WriteString("\n## SQS queues\n\n")
+		b.WriteString("| Queue | Type | Messages | In flight | DLQ | DLQ messages |\n")
+		b.WriteString("|---|---|--:|--:|---|--:|\n")
+		for _, q := range r.Queues {
+			dlq, dlqMessages := "none", "-"
+			if q.HasDLQ {
+				dlq, dlqMessages = q.DLQName, fmt.Sprintf("%d", q.DLQMessageCount)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %d | %d | %s | %s |\n",
+				cell(q.Name), cell(string(q.Type)), q.ApproximateMessageCount, q.ApproximateInFlight, cell(dlq), dlqMessages)
+		}
+	}
+
+	if len(r.Alarms) > 0 {
+		b.WriteString("\n## Alarms\n\n")
+		b.WriteString("| Alarm | State | Metric | Since | Reason |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, a := range r.Alarms {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+				cell(a.Name), stateCell(a.State), cell(a.Metric), formatTime(a.UpdatedAt), cell(a.Reason))
+		}
+	}
+
+	if len(r.Warnings) > 0 {
+		b.WriteString("\n## Not read\n\n")
+		for _, w := range r.Warnings {
+			b.WriteString("- " + strings.ReplaceAll(w, "\n", " ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// summary returns the counts of the report that need attention first.
+func (r *Report) summary() []string {
+	var lines []string
+	if s := r.Stack; s != nil {
+		line := "Stack status: **" + string(s.Status) + "**"
+		if !s.UpdatedAt.IsZero() {
+			line += ", updated " + formatTime(s.UpdatedAt)
+		}
+		if s.DriftStatus == "DRIFTED" {
+			line += ", drifted"
+		}
+		lines = append(lines, line)
+	}
+
+	short, stale := 0, 0
+	for _, svc := range r.Services {
+		if svc.RunningCount < svc.DesiredCount {
+			short++
+		}
+		for _, img := range r.Images[svc.Name] {
+			if img.Stale() {
+				stale++
+				break
+			}
+		}
+	}
+	line := fmt.Sprintf("ECS services: %d", len(r.Services))
+	if short > 0 {
+		line += fmt.Sprintf(", **%d below desired count**", short)
+	}
+	if stale > 0 {
+		line += fmt.Sprintf(", %d running an image older than the last pushed", stale)
+	}
+	lines = append(lines, line)
+
+	if r.Scope.Kind == KindStack {
+		lines = append(lines, fmt.Sprintf("Lambda functions: %d", len(r.Functions)))
+
+		messages, dlq := 0, 0
+		for _, q := range r.Queues {
+			messages += q.ApproximateMessageCount
+			dlq += q.DLQMessageCount
+		}
+		line = fmt.Sprintf("SQS queues: %d, %d messages waiting", len(r.Queues), messages)
+		if dlq > 0 {
+			line += fmt.Sprintf(", **%d in DLQs**", dlq)
+		}
+		lines = append(lines, line)
+	}
+
+	states := make(map[model.AlarmState]int)
+	for _, a := range r.Alarms {
+		states[a.State]++
+	}
+	line = fmt.Sprintf("Alarms: %d", len(r.Alarms))
+	if n := states[model.AlarmStateAlarm]; n > 0 {
+		line += fmt.Sprintf(", **%d in ALARM**", n)
+	}
+	if n := states[model.AlarmStateInsufficientData]; n > 0 {
+		line += fmt.Sprintf(", %d with insufficient data", n)
+	}
+	return append(lines, line)
+}
+
+// imageList renders the images of a service's containers, marking stale
+// ones.
+func (r *Report) imageList(service string) string {
+	var images []string
+	for _, img := range r.Images[service] {
+		text := "`" + img.Image + "`"
+		switch {
+		case img.Stale() && img.LatestTag != "":
+			text += " (stale, latest " + img.LatestTag + ")"
+		case img.Stale():
+			text += " (stale)"
+		}
+		images = append(images, text)
+	}
+	return strings.Join(images, ", ")
+}
+
+// shortTaskDefinition returns the family and revision of a task definition
+// ARN, e.g. "orders:42".
+func shortTaskDefinition(arn string) string {
+	if i := strings.LastIndex(arn, "/"); i >= 0 {
+		return arn[i+1:]
+	}
+	return arn
+}
+
+// stateCell renders an alarm state, in bold when it is firing.
+func stateCell(state model.AlarmState) string {
+	if state == model.AlarmStateAlarm {
+		return "**" + string(state) + "**"
+	}
+	return string(state)
+}
+
+// formatTime renders a time of the report, or "-" if unknown.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(timeLayout)
+}
+
+// cell escapes text for a Markdown table cell.
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -2,6 +2,9 @@
 package state
 
 import (
+	"path"
+	"strings"
+
 	"vaws/internal/model"
 )
 
@@ -15,7 +18,7 @@ const (
 	ViewStackResources // Shows resource types available in a stack
 	ViewClusters
 	ViewServices
-	ViewTasks
+	ViewTasks // Running and recently stopped tasks of a service
 	ViewTunnels
 	ViewXRay
 	ViewLambda
@@ -30,6 +33,35 @@ const (
 	ViewDynamoDB        // DynamoDB tables view
 	ViewDynamoDBQuery   // DynamoDB query results view
 	ViewRegionSelect    // Region selection view
+	ViewAppRunner       // App Runner services view
+	ViewEndpointSelect  // Select discovered endpoint for port forwarding
+	ViewDiff            // Diff between two documents (e.g. task definition revisions)
+	ViewMonitor         // Dashboard of pinned live panels
+	ViewFirehose        // Firehose delivery streams view
+	ViewCognito         // Cognito user pools view
+	ViewCognitoUsers    // Users found by a Cognito user search
+	ViewResourceTypes   // Resource types configured for Cloud Control
+	ViewCloudResources  // Resources of a Cloud Control resource type
+	ViewMSK             // MSK (Kafka) clusters view
+	ViewMQ              // Amazon MQ brokers view
+	ViewEFS             // EFS file systems view
+	ViewSchedules       // EventBridge Scheduler schedules view
+	ViewSES             // SES account, identities and configuration sets
+	ViewSESSuppressions // Addresses on the SES account suppression list
+	ViewActivity        // CloudTrail events of the stack, service or table it was opened on
+	ViewLogSearch       // Log events matched across all log groups of a stack
+	ViewHealth          // Account summary of failed stacks, unhealthy services, alarms, DLQs and certificates
+	ViewLambdaRuntimes  // Lambda functions grouped by runtime, with the runtimes' deprecation dates
+	ViewImages          // Images the services of a cluster or stack run, against their ECR repositories
+	ViewAlerts          // Watch expressions of the profile and the alerts they raised
+	ViewStats           // vaws's own memory use, event loop timings and cache sizes
+	ViewSecrets         // Secrets Manager secrets and how their rotation stands
+	ViewLogGroups       // CloudWatch Logs log groups with their size and retention
+	ViewDatabases       // RDS, RDS Proxy and Redshift endpoints to tunnel to
+	ViewTimeline        // What was done in the session, with timestamps
+	ViewTaskContainers  // Containers of a task opened from the tasks view
+	ViewStackEvents     // Event timeline of a stack opened from the stacks view
+	ViewEC2             // EC2 instances to open a shell on or forward a port of
 )
 
 // State holds all application state.
@@ -42,6 +74,18 @@ type State struct {
 	Region   string
 	Profiles []string // Available AWS profiles
 
+	// Environment switched to with :env, if any
+	Environment  string
+	StackPattern string // Glob the stack names of the environment match
+
+	// Tag every list of resources is scoped to with :tag, and the resources
+	// carrying it; TaggedARNs is nil until read
+	TagFilter  model.TagFilter
+	TaggedARNs map[string]bool
+
+	// Member account switched to with :accounts, if any
+	Account string
+
 	// Stacks data
 	Stacks        []model.Stack
 	StacksLoading bool
@@ -50,6 +94,12 @@ type State struct {
 	// Selected stack
 	SelectedStack *model.Stack
 
+	// Stack events state
+	StackEventsStack   string // Stack whose events are shown
+	StackEvents        []model.StackEvent
+	StackEventsLoading bool
+	StackEventsError   error
+
 	// Clusters data
 	Clusters        []model.Cluster
 	ClustersLoading bool
@@ -101,6 +151,11 @@ type State struct {
 	EC2InstancesLoading bool
 	EC2InstancesError   error
 
+	// EC2 instances view, apart from the jump host selection
+	Instances        []model.EC2Instance
+	InstancesLoading bool
+	InstancesError   error
+
 	// Pending tunnel info (while selecting jump host)
 	PendingTunnelAPI       interface{}
 	PendingTunnelStage     *model.APIStage
@@ -111,6 +166,25 @@ type State struct {
 	PendingContainerTask    *model.Task
 	PendingContainers       []model.Container
 
+	// Pending endpoint selection for discovery tunnels
+	PendingEndpointService *model.Service
+	PendingEndpoints       []model.DiscoveryEndpoint
+
+	// Diff view state
+	DiffReturnView View // View to go back to when the diff is closed
+
+	// Monitor view state
+	MonitorReturnView View // View to go back to when the monitor is closed
+
+	// Alerts view state
+	AlertsReturnView View // View to go back to when the alerts are closed
+
+	// Diagnostics view state
+	StatsReturnView View // View to go back to when the diagnostics are closed
+
+	// Session timeline view state
+	TimelineReturnView View // View to go back to when the timeline is closed
+
 	// CloudWatch Logs state
 	CloudWatchLogs              []model.CloudWatchLogEntry
 	CloudWatchLogsLoading       bool
@@ -122,6 +196,7 @@ type State struct {
 	CloudWatchServiceContext    *model.Service
 	CloudWatchTaskContext       *model.Task
 	CloudWatchLambdaContext     *model.Function // For Lambda function logs
+	CloudWatchAccessLogContext  string          // API and stage whose access logs are tailed, e.g. "orders/prod"
 
 	// SQS Queues state
 	Queues        []model.Queue
@@ -144,6 +219,115 @@ type State struct {
 	DynamoDBLastKey      map[string]interface{} // For pagination
 	DynamoDBIsQuery      bool                   // true = query, false = scan
 
+	// App Runner state
+	AppRunnerServices         []model.AppRunnerService
+	AppRunnerLoading          bool
+	AppRunnerError            error
+	SelectedAppRunnerService  *model.AppRunnerService
+	AppRunnerOperations       []model.AppRunnerOperation // Recent operations of the selected service
+	AppRunnerOperationsLoaded string                     // ARN the operations belong to
+
+	// Firehose state
+	DeliveryStreams      []model.DeliveryStream
+	FirehoseLoading      bool
+	FirehoseError        error
+	DeliveryErrors       []model.CloudWatchLogEntry // Recent delivery errors of the selected stream
+	DeliveryErrorsLoaded string                     // Stream name the errors belong to
+
+	// Cognito state
+	UserPools           []model.UserPool
+	UserPoolsLoading    bool
+	UserPoolsError      error
+	UserPoolDetails     *model.UserPoolDetails // App clients of the pool last opened with enter
+	SelectedUserPool    *model.UserPool        // Pool the user search runs in
+	CognitoUsers        []model.CognitoUser
+	CognitoUsersLoading bool
+	CognitoUsersError   error
+	CognitoUserQuery    string
+
+	// MSK state
+	MSKClusters []model.MSKCluster
+	MSKLoading  bool
+	MSKError    error
+	MSKBrokers  *model.MSKBootstrapBrokers // Bootstrap brokers of the cluster last opened or tunneled to
+
+	// Amazon MQ state
+	MQBrokers []model.MQBroker
+	MQLoading bool
+	MQError   error
+
+	// Databases state
+	Databases        []model.Database
+	DatabasesLoading bool
+	DatabasesError   error
+
+	// EFS state
+	EFSFileSystems []model.EFSFileSystem
+	EFSLoading     bool
+	EFSError       error
+	EFSDetails     *model.EFSDetails // Mount targets, access points and mounts of the file system last opened
+
+	// EventBridge Scheduler state
+	Schedules        []model.Schedule
+	SchedulesLoading bool
+	SchedulesError   error
+
+	// Secrets Manager state
+	Secrets        []model.Secret
+	SecretsLoading bool
+	SecretsError   error
+
+	// CloudWatch Logs log groups state
+	LogGroups        []model.LogGroup
+	LogGroupsLoading bool
+	LogGroupsError   error
+
+	// SES state
+	SESAccount               *model.SESAccount
+	SESIdentities            []model.SESIdentity
+	SESConfigurationSets     []model.SESConfigurationSet
+	SESLoading               bool
+	SESError                 error
+	SESSuppressions          []model.SESSuppressedDestination
+	SESSuppressionsTruncated bool // More addresses exist than were listed
+	SESSuppressionsLoading   bool
+	SESSuppressionsError     error
+
+	// Activity feed state
+	ActivityResource   string   // Label of the resource, e.g. "service api"
+	ActivityNames      []string // Names the resource is recorded under in CloudTrail
+	ActivityReturnView View     // View the feed was opened from
+	ActivityEvents     []model.ActivityEvent
+	ActivityLoading    bool
+	ActivityError      error
+
+	// Stack log search state
+	LogSearchStack      string          // Stack whose log groups are searched
+	LogSearchPattern    string          // CloudWatch Logs filter pattern, empty for all events
+	LogSearchRange      model.TimeRange // When to search
+	LogSearchReturnView View            // View the search was started from
+	LogSearchSources    []model.LogSource
+	LogSearchHits       []model.LogSearchHit
+	LogSearchLoading    bool
+	LogSearchError      error
+
+	// Account health state
+	Health        *model.AccountHealth
+	HealthLoading bool
+
+	// Image freshness state
+	ImagesScope   string // Cluster or stack whose services are checked
+	Images        []model.ServiceImage
+	ImagesLoading bool
+	ImagesError   error
+
+	// Cloud Control state
+	ResourceTypes         []string // Types configured for the profile
+	CloudResourceType     string   // Type whose resources are listed
+	CloudResources        []model.CloudResource
+	CloudResourcesLoading bool
+	CloudResourcesError   error
+
 	// UI state
 	ShowLogs      bool
 	FilterText    string
@@ -161,6 +345,15 @@ func New() *State {
 	}
 }
 
+// IsLoading returns true while any list is being loaded.
+func (s *State) IsLoading() bool {
+	return s.StacksLoading || s.ClustersLoading || s.ServicesLoading || s.QueuesLoading ||
+		s.TablesLoading || s.FunctionsLoading || s.APIsLoading || s.EC2InstancesLoading ||
+		s.AppRunnerLoading || s.FirehoseLoading || s.UserPoolsLoading || s.CognitoUsersLoading ||
+		s.CloudResourcesLoading || s.MSKLoading || s.MQLoading || s.DatabasesLoading || s.EFSLoading || s.SchedulesLoading || s.SecretsLoading || s.LogGroupsLoading || s.SESLoading || s.SESSuppressionsLoading ||
+		s.ActivityLoading || s.LogSearchLoading || s.HealthLoading || s.ImagesLoading || s.StackEventsLoading || s.InstancesLoading
+}
+
 // ClearClusters clears cluster data.
 func (s *State) ClearClusters() {
 	s.Clusters = nil
@@ -191,12 +384,15 @@ func (s *State) SelectTask(task *model.Task) {
 
 // FilteredClusters returns clusters filtered by the current filter text.
 func (s *State) FilteredClusters() []model.Cluster {
-	if s.FilterText == "" {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
 		return s.Clusters
 	}
 
 	var filtered []model.Cluster
 	for _, cluster := range s.Clusters {
+		if !s.Tagged(cluster.ARN) {
+			continue
+		}
 		if containsIgnoreCase(cluster.Name, s.FilterText) {
 			filtered = append(filtered, cluster)
 		}
@@ -212,13 +408,33 @@ func (s *State) FilteredTasks() []model.Task {
 
 	var filtered []model.Task
 	for _, task := range s.Tasks {
-		if containsIgnoreCase(task.TaskID, s.FilterText) {
+		if containsIgnoreCase(task.TaskID, s.FilterText) || containsIgnoreCase(task.LastStatus, s.FilterText) ||
+			containsIgnoreCase(task.AvailabilityZone, s.FilterText) || containsIgnoreCase(task.StoppedReason, s.FilterText) {
 			filtered = append(filtered, task)
 		}
 	}
 	return filtered
 }
 
+// FilteredTaskContainers returns the containers of the selected task
+// filtered by the current filter text.
+func (s *State) FilteredTaskContainers() []model.Container {
+	if s.SelectedTask == nil {
+		return nil
+	}
+	if s.FilterText == "" {
+		return s.SelectedTask.Containers
+	}
+
+	var filtered []model.Container
+	for _, c := range s.SelectedTask.Containers {
+		if containsIgnoreCase(c.Name, s.FilterText) || containsIgnoreCase(c.Image, s.FilterText) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
 // ToggleAutoRefresh toggles auto-refresh.
 func (s *State) ToggleAutoRefresh() {
 	s.AutoRefresh = !s.AutoRefresh
@@ -261,6 +477,159 @@ func (s *State) ClearFunctions() {
 	s.SelectedFunction = nil
 }
 
+// ClearAppRunnerServices clears App Runner service data.
+func (s *State) ClearAppRunnerServices() {
+	s.AppRunnerServices = nil
+	s.AppRunnerLoading = false
+	s.AppRunnerError = nil
+	s.SelectedAppRunnerService = nil
+	s.AppRunnerOperations = nil
+	s.AppRunnerOperationsLoaded = ""
+}
+
+// ClearDeliveryStreams clears Firehose delivery stream data.
+func (s *State) ClearDeliveryStreams() {
+	s.DeliveryStreams = nil
+	s.FirehoseLoading = false
+	s.FirehoseError = nil
+	s.DeliveryErrors = nil
+	s.DeliveryErrorsLoaded = ""
+}
+
+// ClearUserPools clears Cognito user pool and user data.
+func (s *State) ClearUserPools() {
+	s.UserPools = nil
+	s.UserPoolsLoading = false
+	s.UserPoolsError = nil
+	s.UserPoolDetails = nil
+	s.SelectedUserPool = nil
+	s.CognitoUsers = nil
+	s.CognitoUsersLoading = false
+	s.CognitoUsersError = nil
+	s.CognitoUserQuery = ""
+}
+
+// ClearMSKClusters clears MSK cluster data.
+func (s *State) ClearMSKClusters() {
+	s.MSKClusters = nil
+	s.MSKLoading = false
+	s.MSKError = nil
+	s.MSKBrokers = nil
+}
+
+// ClearMQBrokers clears Amazon MQ broker data.
+func (s *State) ClearMQBrokers() {
+	s.MQBrokers = nil
+	s.MQLoading = false
+	s.MQError = nil
+}
+
+// ClearDatabases clears database data.
+func (s *State) ClearDatabases() {
+	s.Databases = nil
+	s.DatabasesLoading = false
+	s.DatabasesError = nil
+}
+
+// ClearEFS clears EFS file system data.
+func (s *State) ClearEFS() {
+	s.EFSFileSystems = nil
+	s.EFSLoading = false
+	s.EFSError = nil
+	s.EFSDetails = nil
+}
+
+// ClearSchedules clears EventBridge Scheduler data.
+func (s *State) ClearSchedules() {
+	s.Schedules = nil
+	s.SchedulesLoading = false
+	s.SchedulesError = nil
+}
+
+// ClearSecrets clears Secrets Manager data.
+func (s *State) ClearSecrets() {
+	s.Secrets = nil
+	s.SecretsLoading = false
+	s.SecretsError = nil
+}
+
+// ClearLogGroups clears CloudWatch Logs log group data.
+func (s *State) ClearLogGroups() {
+	s.LogGroups = nil
+	s.LogGroupsLoading = false
+	s.LogGroupsError = nil
+}
+
+// ClearSES clears SES account, identity and suppression list data.
+func (s *State) ClearSES() {
+	s.SESAccount = nil
+	s.SESIdentities = nil
+	s.SESConfigurationSets = nil
+	s.SESLoading = false
+	s.SESError = nil
+	s.SESSuppressions = nil
+	s.SESSuppressionsTruncated = false
+	s.SESSuppressionsLoading = false
+	s.SESSuppressionsError = nil
+}
+
+// ClearInstances clears the EC2 instances view.
+func (s *State) ClearInstances() {
+	s.Instances = nil
+	s.InstancesLoading = false
+	s.InstancesError = nil
+}
+
+// ClearStackEvents clears the stack event timeline.
+func (s *State) ClearStackEvents() {
+	s.StackEventsStack = ""
+	s.StackEvents = nil
+	s.StackEventsLoading = false
+	s.StackEventsError = nil
+}
+
+// ClearActivity clears the activity feed.
+func (s *State) ClearActivity() {
+	s.ActivityResource = ""
+	s.ActivityNames = nil
+	s.ActivityEvents = nil
+	s.ActivityLoading = false
+	s.ActivityError = nil
+}
+
+// ClearLogSearch clears the stack log search.
+func (s *State) ClearLogSearch() {
+	s.LogSearchStack = ""
+	s.LogSearchPattern = ""
+	s.LogSearchRange = model.TimeRange{}
+	s.LogSearchSources = nil
+	s.LogSearchHits = nil
+	s.LogSearchLoading = false
+	s.LogSearchError = nil
+}
+
+// ClearHealth clears the account health summary.
+func (s *State) ClearHealth() {
+	s.Health = nil
+	s.HealthLoading = false
+}
+
+// ClearImages clears the image freshness report.
+func (s *State) ClearImages() {
+	s.ImagesScope = ""
+	s.Images = nil
+	s.ImagesLoading = false
+	s.ImagesError = nil
+}
+
+// ClearCloudResources clears Cloud Control resource data.
+func (s *State) ClearCloudResources() {
+	s.CloudResourceType = ""
+	s.CloudResources = nil
+	s.CloudResourcesLoading = false
+	s.CloudResourcesError = nil
+}
+
 // ClearLambdaInvocation clears Lambda invocation state.
 func (s *State) ClearLambdaInvocation() {
 	s.LambdaInvocationResult = nil
@@ -316,6 +685,12 @@ func (s *State) ClearPendingContainer() {
 	s.PendingContainers = nil
 }
 
+// ClearPendingEndpoint clears pending discovery endpoint selection.
+func (s *State) ClearPendingEndpoint() {
+	s.PendingEndpointService = nil
+	s.PendingEndpoints = nil
+}
+
 // ClearCloudWatchLogs clears CloudWatch logs state.
 func (s *State) ClearCloudWatchLogs() {
 	s.CloudWatchLogs = nil
@@ -328,6 +703,7 @@ func (s *State) ClearCloudWatchLogs() {
 	s.CloudWatchServiceContext = nil
 	s.CloudWatchTaskContext = nil
 	s.CloudWatchLambdaContext = nil
+	s.CloudWatchAccessLogContext = ""
 }
 
 // ClearQueues clears SQS queue data.
@@ -433,12 +809,20 @@ func (s *State) ToggleLogs() {
 
 // FilteredStacks returns stacks filtered by the current filter text.
 func (s *State) FilteredStacks() []model.Stack {
-	if s.FilterText == "" {
+	if s.FilterText == "" && s.StackPattern == "" && s.TagFilter.Key == "" {
 		return s.Stacks
 	}
 
 	var filtered []model.Stack
 	for _, stack := range s.Stacks {
+		if !s.Tagged(stack.ID) {
+			continue
+		}
+		if s.StackPattern != "" {
+			if ok, _ := path.Match(s.StackPattern, stack.Name); !ok {
+				continue
+			}
+		}
 		if containsIgnoreCase(stack.Name, s.FilterText) {
 			filtered = append(filtered, stack)
 		}
@@ -448,12 +832,15 @@ func (s *State) FilteredStacks() []model.Stack {
 
 // FilteredServices returns services filtered by the current filter text.
 func (s *State) FilteredServices() []model.Service {
-	if s.FilterText == "" {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
 		return s.Services
 	}
 
 	var filtered []model.Service
 	for _, svc := range s.Services {
+		if !s.Tagged(svc.ARN) {
+			continue
+		}
 		if containsIgnoreCase(svc.Name, s.FilterText) {
 			filtered = append(filtered, svc)
 		}
@@ -463,12 +850,15 @@ func (s *State) FilteredServices() []model.Service {
 
 // FilteredFunctions returns Lambda functions filtered by the current filter text.
 func (s *State) FilteredFunctions() []model.Function {
-	if s.FilterText == "" {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
 		return s.Functions
 	}
 
 	var filtered []model.Function
 	for _, fn := range s.Functions {
+		if !s.Tagged(fn.ARN) {
+			continue
+		}
 		if containsIgnoreCase(fn.Name, s.FilterText) {
 			filtered = append(filtered, fn)
 		}
@@ -476,6 +866,394 @@ func (s *State) FilteredFunctions() []model.Function {
 	return filtered
 }
 
+// FilteredAppRunnerServices returns App Runner services filtered by the current filter text.
+func (s *State) FilteredAppRunnerServices() []model.AppRunnerService {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
+		return s.AppRunnerServices
+	}
+
+	var filtered []model.AppRunnerService
+	for _, svc := range s.AppRunnerServices {
+		if !s.Tagged(svc.ARN) {
+			continue
+		}
+		if containsIgnoreCase(svc.Name, s.FilterText) {
+			filtered = append(filtered, svc)
+		}
+	}
+	return filtered
+}
+
+// FilteredDeliveryStreams returns Firehose delivery streams filtered by the current filter text.
+func (s *State) FilteredDeliveryStreams() []model.DeliveryStream {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
+		return s.DeliveryStreams
+	}
+
+	var filtered []model.DeliveryStream
+	for _, stream := range s.DeliveryStreams {
+		if !s.Tagged(stream.ARN) {
+			continue
+		}
+		if containsIgnoreCase(stream.Name, s.FilterText) || containsIgnoreCase(stream.Destination, s.FilterText) {
+			filtered = append(filtered, stream)
+		}
+	}
+	return filtered
+}
+
+// FilteredUserPools returns Cognito user pools filtered by the current filter text.
+func (s *State) FilteredUserPools() []model.UserPool {
+	if s.FilterText == "" {
+		return s.UserPools
+	}
+
+	var filtered []model.UserPool
+	for _, pool := range s.UserPools {
+		if containsIgnoreCase(pool.Name, s.FilterText) || containsIgnoreCase(pool.ID, s.FilterText) {
+			filtered = append(filtered, pool)
+		}
+	}
+	return filtered
+}
+
+// FilteredCognitoUsers returns Cognito users filtered by the current filter text.
+func (s *State) FilteredCognitoUsers() []model.CognitoUser {
+	if s.FilterText == "" {
+		return s.CognitoUsers
+	}
+
+	var filtered []model.CognitoUser
+	for _, user := range s.CognitoUsers {
+		if containsIgnoreCase(user.Username, s.FilterText) || containsIgnoreCase(user.Email, s.FilterText) {
+			filtered = append(filtered, user)
+		}
+	}
+	return filtered
+}
+
+// FilteredMSKClusters returns MSK clusters filtered by the current filter text.
+func (s *State) FilteredMSKClusters() []model.MSKCluster {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
+		return s.MSKClusters
+	}
+
+	var filtered []model.MSKCluster
+	for _, c := range s.MSKClusters {
+		if !s.Tagged(c.ARN) {
+			continue
+		}
+		if containsIgnoreCase(c.Name, s.FilterText) || containsIgnoreCase(c.KafkaVersion, s.FilterText) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// FilteredMQBrokers returns MQ brokers filtered by the current filter text.
+func (s *State) FilteredMQBrokers() []model.MQBroker {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
+		return s.MQBrokers
+	}
+
+	var filtered []model.MQBroker
+	for _, b := range s.MQBrokers {
+		if !s.Tagged(b.ARN) {
+			continue
+		}
+		if containsIgnoreCase(b.Name, s.FilterText) || containsIgnoreCase(b.Engine, s.FilterText) {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered
+}
+
+// FilteredDatabases returns databases filtered by the current filter text.
+func (s *State) FilteredDatabases() []model.Database {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
+		return s.Databases
+	}
+
+	var filtered []model.Database
+	for _, d := range s.Databases {
+		if !s.Tagged(d.ARN) {
+			continue
+		}
+		if containsIgnoreCase(d.Name, s.FilterText) || containsIgnoreCase(d.Engine, s.FilterText) || containsIgnoreCase(string(d.Kind), s.FilterText) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// FilteredEFSFileSystems returns EFS file systems filtered by the current filter text.
+func (s *State) FilteredEFSFileSystems() []model.EFSFileSystem {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
+		return s.EFSFileSystems
+	}
+
+	var filtered []model.EFSFileSystem
+	for _, fs := range s.EFSFileSystems {
+		if !s.Tagged(fs.ARN) {
+			continue
+		}
+		if containsIgnoreCase(fs.Name, s.FilterText) || containsIgnoreCase(fs.ID, s.FilterText) {
+			filtered = append(filtered, fs)
+		}
+	}
+	return filtered
+}
+
+// FilteredSchedules returns schedules filtered by the current filter text.
+func (s *State) FilteredSchedules() []model.Schedule {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
+		return s.Schedules
+	}
+
+	var filtered []model.Schedule
+	for _, sc := range s.Schedules {
+		if !s.Tagged(sc.ARN) {
+			continue
+		}
+		if containsIgnoreCase(sc.Name, s.FilterText) || containsIgnoreCase(sc.Group, s.FilterText) ||
+			containsIgnoreCase(sc.TargetARN, s.FilterText) {
+			filtered = append(filtered, sc)
+		}
+	}
+	return filtered
+}
+
+// FilteredSecrets returns secrets filtered by the current filter text.
+func (s *State) FilteredSecrets() []model.Secret {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
+		return s.Secrets
+	}
+
+	var filtered []model.Secret
+	for _, sc := range s.Secrets {
+		if !s.Tagged(sc.ARN) {
+			continue
+		}
+		if containsIgnoreCase(sc.Name, s.FilterText) || containsIgnoreCase(sc.Description, s.FilterText) ||
+			containsIgnoreCase(sc.OwningService, s.FilterText) {
+			filtered = append(filtered, sc)
+		}
+	}
+	return filtered
+}
+
+// FilteredLogGroups returns log groups filtered by the current filter text.
+func (s *State) FilteredLogGroups() []model.LogGroup {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
+		return s.LogGroups
+	}
+
+	var filtered []model.LogGroup
+	for _, g := range s.LogGroups {
+		if !s.Tagged(g.ARN) {
+			continue
+		}
+		if containsIgnoreCase(g.Name, s.FilterText) || containsIgnoreCase(g.Owner, s.FilterText) {
+			filtered = append(filtered, g)
+		}
+	}
+	return filtered
+}
+
+// FilteredSESIdentities returns SES identities filtered by the current filter text.
+func (s *State) FilteredSESIdentities() []model.SESIdentity {
+	if s.FilterText == "" {
+		return s.SESIdentities
+	}
+
+	var filtered []model.SESIdentity
+	for _, id := range s.SESIdentities {
+		if containsIgnoreCase(id.Name, s.FilterText) {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+// FilteredSESConfigurationSets returns SES configuration sets filtered by the current filter text.
+func (s *State) FilteredSESConfigurationSets() []model.SESConfigurationSet {
+	if s.FilterText == "" {
+		return s.SESConfigurationSets
+	}
+
+	var filtered []model.SESConfigurationSet
+	for _, set := range s.SESConfigurationSets {
+		if containsIgnoreCase(set.Name, s.FilterText) {
+			filtered = append(filtered, set)
+		}
+	}
+	return filtered
+}
+
+// FilteredSESSuppressions returns suppressed addresses filtered by the current filter text.
+func (s *State) FilteredSESSuppressions() []model.SESSuppressedDestination {
+	if s.FilterText == "" {
+		return s.SESSuppressions
+	}
+
+	var filtered []model.SESSuppressedDestination
+	for _, d := range s.SESSuppressions {
+		if containsIgnoreCase(d.Email, s.FilterText) || containsIgnoreCase(d.Reason, s.FilterText) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// FilteredInstances returns the instances of the EC2 view filtered by the
+// current filter text.
+func (s *State) FilteredInstances() []model.EC2Instance {
+	if s.FilterText == "" {
+		return s.Instances
+	}
+
+	var filtered []model.EC2Instance
+	for _, inst := range s.Instances {
+		if containsIgnoreCase(inst.Name, s.FilterText) || containsIgnoreCase(inst.InstanceID, s.FilterText) ||
+			containsIgnoreCase(inst.PrivateIPAddress, s.FilterText) || containsIgnoreCase(inst.InstanceType, s.FilterText) ||
+			containsIgnoreCase(inst.State, s.FilterText) {
+			filtered = append(filtered, inst)
+		}
+	}
+	return filtered
+}
+
+// FilteredStackEvents returns stack events filtered by the current filter text.
+func (s *State) FilteredStackEvents() []model.StackEvent {
+	if s.FilterText == "" {
+		return s.StackEvents
+	}
+
+	var filtered []model.StackEvent
+	for _, e := range s.StackEvents {
+		if containsIgnoreCase(e.LogicalID, s.FilterText) || containsIgnoreCase(e.ResourceType, s.FilterText) ||
+			containsIgnoreCase(e.Status, s.FilterText) || containsIgnoreCase(e.StatusReason, s.FilterText) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// FilteredActivity returns activity events filtered by the current filter text.
+func (s *State) FilteredActivity() []model.ActivityEvent {
+	if s.FilterText == "" {
+		return s.ActivityEvents
+	}
+
+	var filtered []model.ActivityEvent
+	for _, e := range s.ActivityEvents {
+		if containsIgnoreCase(e.Name, s.FilterText) || containsIgnoreCase(e.Username, s.FilterText) ||
+			containsIgnoreCase(e.Principal, s.FilterText) || containsIgnoreCase(e.ErrorCode, s.FilterText) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// FilteredImages returns the images of the report filtered by the current filter text.
+func (s *State) FilteredImages() []model.ServiceImage {
+	if s.FilterText == "" {
+		return s.Images
+	}
+
+	var filtered []model.ServiceImage
+	for _, img := range s.Images {
+		if containsIgnoreCase(img.Service, s.FilterText) || containsIgnoreCase(img.Container, s.FilterText) ||
+			containsIgnoreCase(img.Image, s.FilterText) {
+			filtered = append(filtered, img)
+		}
+	}
+	return filtered
+}
+
+// FilteredLogSearchHits returns log search matches filtered by the current filter text.
+func (s *State) FilteredLogSearchHits() []model.LogSearchHit {
+	if s.FilterText == "" {
+		return s.LogSearchHits
+	}
+
+	var filtered []model.LogSearchHit
+	for _, h := range s.LogSearchHits {
+		if containsIgnoreCase(h.Message, s.FilterText) || containsIgnoreCase(h.Source.Resource, s.FilterText) ||
+			containsIgnoreCase(h.Source.LogGroup, s.FilterText) {
+			filtered = append(filtered, h)
+		}
+	}
+	return filtered
+}
+
+// FilteredHealth returns the account health summary with only the resources
+// matching the current filter text, or nil if it isn't loaded.
+func (s *State) FilteredHealth() *model.AccountHealth {
+	if s.Health == nil || s.FilterText == "" {
+		return s.Health
+	}
+
+	h := *s.Health
+	h.FailedStacks, h.UnhealthyServices, h.Alarms, h.DLQs, h.ExpiringCertificates = nil, nil, nil, nil, nil
+	for _, st := range s.Health.FailedStacks {
+		if containsIgnoreCase(st.Name, s.FilterText) {
+			h.FailedStacks = append(h.FailedStacks, st)
+		}
+	}
+	for _, svc := range s.Health.UnhealthyServices {
+		if containsIgnoreCase(svc.Name, s.FilterText) || containsIgnoreCase(svc.ClusterName, s.FilterText) {
+			h.UnhealthyServices = append(h.UnhealthyServices, svc)
+		}
+	}
+	for _, a := range s.Health.Alarms {
+		if containsIgnoreCase(a.Name, s.FilterText) {
+			h.Alarms = append(h.Alarms, a)
+		}
+	}
+	for _, q := range s.Health.DLQs {
+		if containsIgnoreCase(q.Name, s.FilterText) || containsIgnoreCase(q.DLQName, s.FilterText) {
+			h.DLQs = append(h.DLQs, q)
+		}
+	}
+	for _, c := range s.Health.ExpiringCertificates {
+		if containsIgnoreCase(c.DomainName, s.FilterText) {
+			h.ExpiringCertificates = append(h.ExpiringCertificates, c)
+		}
+	}
+	return &h
+}
+
+// FilteredResourceTypes returns configured resource types filtered by the current filter text.
+func (s *State) FilteredResourceTypes() []string {
+	if s.FilterText == "" {
+		return s.ResourceTypes
+	}
+
+	var filtered []string
+	for _, t := range s.ResourceTypes {
+		if containsIgnoreCase(t, s.FilterText) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// FilteredCloudResources returns Cloud Control resources filtered by the current filter text.
+func (s *State) FilteredCloudResources() []model.CloudResource {
+	if s.FilterText == "" {
+		return s.CloudResources
+	}
+
+	var filtered []model.CloudResource
+	for _, r := range s.CloudResources {
+		if containsIgnoreCase(r.Identifier, s.FilterText) || containsIgnoreCase(r.Name, s.FilterText) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
 // FilteredRestAPIs returns REST APIs filtered by the current filter text.
 func (s *State) FilteredRestAPIs() []model.RestAPI {
 	if s.FilterText == "" {
@@ -568,12 +1346,15 @@ func (s *State) FilteredContainers() []model.Container {
 
 // FilteredQueues returns SQS queues filtered by the current filter text.
 func (s *State) FilteredQueues() []model.Queue {
-	if s.FilterText == "" {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
 		return s.Queues
 	}
 
 	var filtered []model.Queue
 	for _, q := range s.Queues {
+		if !s.Tagged(q.ARN) {
+			continue
+		}
 		if containsIgnoreCase(q.Name, s.FilterText) {
 			filtered = append(filtered, q)
 		}
@@ -583,12 +1364,15 @@ func (s *State) FilteredQueues() []model.Queue {
 
 // FilteredTables returns DynamoDB tables filtered by the current filter text.
 func (s *State) FilteredTables() []model.Table {
-	if s.FilterText == "" {
+	if s.FilterText == "" && s.TagFilter.Key == "" {
 		return s.Tables
 	}
 
 	var filtered []model.Table
 	for _, t := range s.Tables {
+		if !s.Tagged(t.ARN) {
+			continue
+		}
 		if containsIgnoreCase(t.Name, s.FilterText) {
 			filtered = append(filtered, t)
 		}
@@ -596,6 +1380,17 @@ func (s *State) FilteredTables() []model.Table {
 	return filtered
 }
 
+// Tagged reports whether the resource with the ARN carries the tag of the
+// tag filter, or there is no tag filter. Until the tagged resources are read
+// no resource does, so lists don't show resources of other environments.
+func (s *State) Tagged(arn string) bool {
+	if s.TagFilter.Key == "" {
+		return true
+	}
+	// Log group ARNs end in :* outside the tagging API
+	return s.TaggedARNs[strings.TrimSuffix(arn, ":*")]
+}
+
 func containsIgnoreCase(s, substr string) bool {
 	return len(s) >= len(substr) && (substr == "" ||
 		findIgnoreCase(s, substr) >= 0)
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"vaws/internal/log"
+	"vaws/internal/metrics"
 	"vaws/internal/model"
 )
 
@@ -25,6 +26,8 @@ type APIGatewayManager struct {
 	tunnels map[string]*activeAPIGWTunnel
 	region  string
 	profile string
+	useTLS  bool   // Serve new proxies over HTTPS
+	cliEnv  CLIEnv // Credentials of an assumed role, instead of the profile
 }
 
 type activeAPIGWTunnel struct {
@@ -34,6 +37,7 @@ type activeAPIGWTunnel struct {
 	cancel    context.CancelFunc
 	stderrBuf *bytes.Buffer
 	stdoutBuf *bytes.Buffer
+	rules     *proxyRules // Read by the proxy director on every request
 }
 
 // NewAPIGatewayManager creates a new API Gateway tunnel manager.
@@ -106,6 +110,16 @@ func (m *APIGatewayManager) StartPublicTunnel(ctx context.Context, api interface
 		TunnelType: model.APIGatewayTunnelPublic,
 		Status:     model.TunnelStatusStarting,
 		StartedAt:  time.Now(),
+		TLS:        m.useTLS,
+	}
+
+	var tlsConfig *tls.Config
+	if m.useTLS {
+		var err error
+		tlsConfig, err = localhostTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up HTTPS for local proxy: %w", err)
+		}
 	}
 
 	// Parse the target URL
@@ -116,10 +130,12 @@ func (m *APIGatewayManager) StartPublicTunnel(ctx context.Context, api interface
 
 	// Create reverse proxy
 	proxy := httputil.NewSingleHostReverseProxy(targetURL)
+	rules := &proxyRules{}
 
 	// Customize the director to properly forward requests
 	originalDirector := proxy.Director
 	proxy.Director = func(req *http.Request) {
+		applyProxyRules(req, rules.get())
 		originalDirector(req)
 		req.Host = targetURL.Host
 		req.URL.Host = targetURL.Host
@@ -140,7 +156,7 @@ func (m *APIGatewayManager) StartPublicTunnel(ctx context.Context, api interface
 	// Create HTTP server
 	server := &http.Server{
 		Addr:    fmt.Sprintf("127.0.0.1:%d", localPort),
-		Handler: proxy,
+		Handler: metrics.InstrumentProxy(tunnelID, proxy),
 	}
 
 	// Create cancellable context
@@ -151,6 +167,7 @@ func (m *APIGatewayManager) StartPublicTunnel(ctx context.Context, api interface
 		APIGatewayTunnel: tunnel,
 		server:           server,
 		cancel:           cancel,
+		rules:            rules,
 	}
 	m.tunnels[tunnelID] = at
 
@@ -165,6 +182,9 @@ func (m *APIGatewayManager) StartPublicTunnel(ctx context.Context, api interface
 			log.Error("Failed to start proxy server: %v", err)
 			return
 		}
+		if tlsConfig != nil {
+			ln = tls.NewListener(ln, tlsConfig)
+		}
 
 		m.mu.Lock()
 		at.Status = model.TunnelStatusActive
@@ -172,7 +192,7 @@ func (m *APIGatewayManager) StartPublicTunnel(ctx context.Context, api interface
 		log.Info("API Gateway proxy started:")
 		log.Info("  Target URL: %s", stage.InvokeURL)
 		log.Info("  Local Port: localhost:%d", localPort)
-		log.Info("  Usage: curl http://localhost:%d/your-path", localPort)
+		log.Info("  Usage: curl %s/your-path", at.LocalURL())
 
 		go func() {
 			<-serverCtx.Done()
@@ -312,12 +332,21 @@ func (m *APIGatewayManager) StartPrivateTunnel(ctx context.Context, api interfac
 		APIID:       apiID,
 		APIType:     apiType,
 		StageName:   stage.Name,
-		InvokeURL:   fmt.Sprintf("http://localhost:%d", localPort), // Stage name is auto-prepended
 		TunnelType:  model.APIGatewayTunnelPrivate,
 		JumpHost:    jumpHost,
 		VpcEndpoint: vpcEndpoint,
 		Status:      model.TunnelStatusStarting,
 		StartedAt:   time.Now(),
+		TLS:         m.useTLS,
+	}
+	tunnel.InvokeURL = tunnel.LocalURL() // Stage name is auto-prepended
+
+	var tlsConfig *tls.Config
+	if m.useTLS {
+		tlsConfig, err = localhostTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up HTTPS for local proxy: %w", err)
+		}
 	}
 
 	// Build SSM port forwarding command to remote host
@@ -333,13 +362,13 @@ func (m *APIGatewayManager) StartPrivateTunnel(ctx context.Context, api interfac
 	if m.region != "" {
 		args = append(args, "--region", m.region)
 	}
-	if m.profile != "" {
+	if m.profile != "" && m.cliEnv == nil {
 		args = append(args, "--profile", m.profile)
 	}
 
 	// Create cancellable context for both SSM and proxy
 	cmdCtx, cancel := context.WithCancel(context.Background())
-	cmd := exec.CommandContext(cmdCtx, "aws", args...)
+	cmd := awsCommand(cmdCtx, m.cliEnv, args...)
 
 	// Set process group
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
@@ -368,7 +397,8 @@ func (m *APIGatewayManager) StartPrivateTunnel(ctx context.Context, api interfac
 	time.Sleep(2 * time.Second)
 
 	// Create HTTP reverse proxy that forwards to the SSM tunnel with proper TLS
-	proxy, err := m.createPrivateAPIProxy(remoteHost, ssmPort, stage.Name)
+	rules := &proxyRules{}
+	proxy, err := m.createPrivateAPIProxy(remoteHost, ssmPort, stage.Name, rules)
 	if err != nil {
 		cancel()
 		cmd.Process.Kill()
@@ -388,6 +418,9 @@ func (m *APIGatewayManager) StartPrivateTunnel(ctx context.Context, api interfac
 		cmd.Process.Kill()
 		return nil, fmt.Errorf("failed to start HTTP proxy: %w", err)
 	}
+	if tlsConfig != nil {
+		proxyListener = tls.NewListener(proxyListener, tlsConfig)
+	}
 
 	tunnel.Status = model.TunnelStatusActive
 
@@ -398,6 +431,7 @@ func (m *APIGatewayManager) StartPrivateTunnel(ctx context.Context, api interfac
 		cancel:           cancel,
 		stderrBuf:        &stderrBuf,
 		stdoutBuf:        &stdoutBuf,
+		rules:            rules,
 	}
 	m.tunnels[tunnelID] = at
 
@@ -419,13 +453,13 @@ func (m *APIGatewayManager) StartPrivateTunnel(ctx context.Context, api interfac
 
 	log.Info("Private API Gateway tunnel started!")
 	log.Info("  Stage: %s (automatically prepended to requests)", stage.Name)
-	log.Info("  Usage: curl http://localhost:%d/your-endpoint", localPort)
+	log.Info("  Usage: curl %s/your-endpoint", at.LocalURL())
 
 	return &at.APIGatewayTunnel, nil
 }
 
 // createPrivateAPIProxy creates a reverse proxy that forwards HTTP requests to the SSM tunnel as HTTPS.
-func (m *APIGatewayManager) createPrivateAPIProxy(remoteHost string, ssmPort int, stageName string) (http.Handler, error) {
+func (m *APIGatewayManager) createPrivateAPIProxy(remoteHost string, ssmPort int, stageName string, rules *proxyRules) (http.Handler, error) {
 	// Create a custom transport that:
 	// 1. Connects to the local SSM tunnel port
 	// 2. Uses TLS with the correct ServerName (SNI)
@@ -455,6 +489,7 @@ func (m *APIGatewayManager) createPrivateAPIProxy(remoteHost string, ssmPort int
 	// Customize director to set proper headers and prepend stage name
 	originalDirector := proxy.Director
 	proxy.Director = func(req *http.Request) {
+		applyProxyRules(req, rules.get())
 		originalDirector(req)
 		req.Host = remoteHost
 		req.URL.Host = remoteHost
@@ -700,6 +735,21 @@ func (m *APIGatewayManager) SetRegion(region string) {
 	m.region = region
 }
 
+// SetTLS sets whether new local proxies serve HTTPS using the local CA.
+// Running tunnels keep the scheme they were started with.
+func (m *APIGatewayManager) SetTLS(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.useTLS = enabled
+}
+
+// TLSEnabled returns true if new local proxies serve HTTPS.
+func (m *APIGatewayManager) TLSEnabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.useTLS
+}
+
 // SetProfile updates the profile for the manager.
 func (m *APIGatewayManager) SetProfile(profile string) {
 	m.mu.Lock()
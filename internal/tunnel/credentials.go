@@ -0,0 +1,38 @@
+package tunnel
+
+import (
+	"context"
+	"os"
+	"os/exec"
+)
+
+// CLIEnv returns the environment variables that give the AWS CLI its
+// credentials, read when a session starts so refreshed ones are picked up.
+type CLIEnv func() []string
+
+// SetCLIEnv makes the manager's sessions use the credentials of env instead
+// of --profile, for clients that assumed a role in another account. Call it
+// before starting tunnels.
+func (m *Manager) SetCLIEnv(env CLIEnv) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cliEnv = env
+}
+
+// SetCLIEnv makes the manager's SSM sessions use the credentials of env
+// instead of --profile. Call it before starting tunnels.
+func (m *APIGatewayManager) SetCLIEnv(env CLIEnv) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cliEnv = env
+}
+
+// awsCommand returns an AWS CLI command, with the credentials of env on top
+// of the environment of vaws if env is set.
+func awsCommand(ctx context.Context, env CLIEnv, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "aws", args...)
+	if env != nil {
+		cmd.Env = append(os.Environ(), env()...)
+	}
+	return cmd
+}
@@ -23,6 +23,10 @@ type Manager struct {
 	tunnels map[string]*activeTunnel
 	region  string
 	profile string
+
+	strategy       Strategy
+	deniedClusters map[string]bool // Clusters where port forwarding was denied
+	cliEnv         CLIEnv          // Credentials of an assumed role, instead of the profile
 }
 
 type activeTunnel struct {
@@ -36,9 +40,11 @@ type activeTunnel struct {
 // NewManager creates a new tunnel manager.
 func NewManager(profile, region string) *Manager {
 	m := &Manager{
-		tunnels: make(map[string]*activeTunnel),
-		region:  region,
-		profile: profile,
+		tunnels:        make(map[string]*activeTunnel),
+		region:         region,
+		profile:        profile,
+		strategy:       StrategyAuto,
+		deniedClusters: make(map[string]bool),
 	}
 
 	// Load tunnels from previous session
@@ -51,40 +57,32 @@ func NewManager(profile, region string) *Manager {
 
 // StartTunnel starts a new port forwarding tunnel.
 func (m *Manager) StartTunnel(ctx context.Context, service model.Service, task model.Task, container model.Container, remotePort, localPort int) (*model.Tunnel, error) {
+	return m.startTunnel(service, task, container, "", remotePort, localPort)
+}
+
+// StartRemoteHostTunnel starts a tunnel to remoteHost:remotePort, using the
+// task's container as the SSM target. This reaches endpoints that only resolve
+// inside the VPC, such as Service Connect or Cloud Map DNS names.
+func (m *Manager) StartRemoteHostTunnel(ctx context.Context, service model.Service, task model.Task, container model.Container, remoteHost string, remotePort, localPort int) (*model.Tunnel, error) {
+	return m.startTunnel(service, task, container, remoteHost, remotePort, localPort)
+}
+
+func (m *Manager) startTunnel(service model.Service, task model.Task, container model.Container, remoteHost string, remotePort, localPort int) (*model.Tunnel, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Check if requested port is already in use by an active tunnel
-	if localPort != 0 {
-		for _, t := range m.tunnels {
-			if t.LocalPort == localPort && (t.Status == model.TunnelStatusActive || t.Status == model.TunnelStatusStarting) {
-				return nil, fmt.Errorf("port %d is already in use by tunnel '%s'. Stop it first or use a different port", localPort, t.ID)
-			}
-		}
-	}
-
-	// Find a free local port if not specified
-	if localPort == 0 {
-		var err error
-		localPort, err = m.findFreePortExcludingActive()
-		if err != nil {
-			return nil, fmt.Errorf("failed to find free port: %w", err)
-		}
+	localPort, err := m.reserveLocalPort(localPort)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create tunnel ID
 	tunnelID := fmt.Sprintf("%s-%s-%d", service.Name, task.TaskID[:8], localPort)
 
-	// Check if tunnel already exists
-	if _, exists := m.tunnels[tunnelID]; exists {
-		return nil, fmt.Errorf("tunnel %s already exists", tunnelID)
-	}
-
 	// Build SSM target
 	// Format: ecs:<cluster-name>_<task-id>_<runtime-id>
 	target := fmt.Sprintf("ecs:%s_%s_%s", service.ClusterName, task.TaskID, container.RuntimeID)
 
-	// Create the tunnel model
 	tunnel := model.Tunnel{
 		ID:            tunnelID,
 		LocalPort:     localPort,
@@ -94,29 +92,88 @@ func (m *Manager) StartTunnel(ctx context.Context, service model.Service, task m
 		ClusterName:   service.ClusterName,
 		TaskID:        task.TaskID,
 		ContainerName: container.Name,
-		Status:        model.TunnelStatusStarting,
-		StartedAt:     time.Now(),
+		RemoteHost:    remoteHost,
+	}
+	if m.useRelay(service.ClusterName) {
+		return m.launchRelayTunnel(tunnel, m.strategy != StrategyExec)
+	}
+	return m.launchTunnel(tunnel, target)
+}
+
+// StartJumpHostTunnel starts a tunnel to remoteHost:remotePort through an EC2
+// jump host. name labels the tunnel, e.g. the cluster the host belongs to.
+func (m *Manager) StartJumpHostTunnel(ctx context.Context, jumpHost model.EC2Instance, name, remoteHost string, remotePort, localPort int) (*model.Tunnel, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	localPort, err := m.reserveLocalPort(localPort)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.launchTunnel(model.Tunnel{
+		ID:           fmt.Sprintf("%s-%d", name, localPort),
+		LocalPort:    localPort,
+		RemotePort:   remotePort,
+		ServiceName:  name,
+		RemoteHost:   remoteHost,
+		JumpHostID:   jumpHost.InstanceID,
+		JumpHostName: jumpHost.Name,
+	}, jumpHost.InstanceID)
+}
+
+// reserveLocalPort checks that localPort is not used by an active tunnel, or
+// picks a free port if it is 0. Must be called with m.mu held.
+func (m *Manager) reserveLocalPort(localPort int) (int, error) {
+	// Check if requested port is already in use by an active tunnel
+	if localPort != 0 {
+		for _, t := range m.tunnels {
+			if t.LocalPort == localPort && (t.Status == model.TunnelStatusActive || t.Status == model.TunnelStatusStarting) {
+				return 0, fmt.Errorf("port %d is already in use by tunnel '%s'. Stop it first or use a different port", localPort, t.ID)
+			}
+		}
+		return localPort, nil
 	}
 
+	// Find a free local port if not specified
+	port, err := m.findFreePortExcludingActive()
+	if err != nil {
+		return 0, fmt.Errorf("failed to find free port: %w", err)
+	}
+	return port, nil
+}
+
+// launchTunnel starts the SSM session of tunnel against target and tracks it.
+// Must be called with m.mu held.
+func (m *Manager) launchTunnel(tunnel model.Tunnel, target string) (*model.Tunnel, error) {
+	// Check if tunnel already exists
+	if _, exists := m.tunnels[tunnel.ID]; exists {
+		return nil, fmt.Errorf("tunnel %s already exists", tunnel.ID)
+	}
+
+	tunnel.Status = model.TunnelStatusStarting
+	tunnel.StartedAt = time.Now()
+
 	// Build AWS SSM command
+	remoteHost, remotePort, localPort := tunnel.RemoteHost, tunnel.RemotePort, tunnel.LocalPort
+	document := "AWS-StartPortForwardingSession"
+	params := fmt.Sprintf(`{"portNumber":["%d"],"localPortNumber":["%d"]}`, remotePort, localPort)
+	if remoteHost != "" {
+		document = "AWS-StartPortForwardingSessionToRemoteHost"
+		params = fmt.Sprintf(`{"host":["%s"],"portNumber":["%d"],"localPortNumber":["%d"]}`, remoteHost, remotePort, localPort)
+	}
 	args := []string{
 		"ssm", "start-session",
 		"--target", target,
-		"--document-name", "AWS-StartPortForwardingSession",
-		"--parameters", fmt.Sprintf(`{"portNumber":["%d"],"localPortNumber":["%d"]}`, remotePort, localPort),
-	}
-
-	if m.region != "" {
-		args = append(args, "--region", m.region)
-	}
-	if m.profile != "" {
-		args = append(args, "--profile", m.profile)
+		"--document-name", document,
+		"--parameters", params,
 	}
+	args = m.withProfileArgs(args)
 
 	// Create cancellable context for the process
 	// Use Background context so the tunnel isn't killed when the caller's context times out
 	cmdCtx, cancel := context.WithCancel(context.Background())
-	cmd := exec.CommandContext(cmdCtx, "aws", args...)
+	cmd := awsCommand(cmdCtx, m.cliEnv, args...)
 
 	// Set process group so we can kill all child processes (session-manager-plugin)
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
@@ -126,7 +183,11 @@ func (m *Manager) StartTunnel(ctx context.Context, service model.Service, task m
 	cmd.Stderr = &stderrBuf
 
 	// Start the tunnel process
-	log.Info("Starting tunnel: %s -> localhost:%d (remote port %d)", target, localPort, remotePort)
+	if remoteHost != "" {
+		log.Info("Starting tunnel: %s:%d via %s -> localhost:%d", remoteHost, remotePort, target, localPort)
+	} else {
+		log.Info("Starting tunnel: %s -> localhost:%d (remote port %d)", target, localPort, remotePort)
+	}
 	log.Debug("Running: aws %v", args)
 	if err := cmd.Start(); err != nil {
 		cancel()
@@ -144,12 +205,12 @@ func (m *Manager) StartTunnel(ctx context.Context, service model.Service, task m
 		cancel:    cancel,
 		stderrBuf: &stderrBuf,
 	}
-	m.tunnels[tunnelID] = at
+	m.tunnels[tunnel.ID] = at
 
 	// Monitor the process in background
-	go m.monitorTunnel(tunnelID, at)
+	go m.monitorTunnel(tunnel.ID, at)
 
-	log.Info("Tunnel started: %s on localhost:%d", tunnelID, localPort)
+	log.Info("Tunnel started: %s on localhost:%d", tunnel.ID, localPort)
 
 	// Save tunnels to disk for persistence
 	go func() {
@@ -169,6 +230,7 @@ func (m *Manager) monitorTunnel(id string, at *activeTunnel) {
 
 	if t, exists := m.tunnels[id]; exists {
 		if err != nil {
+			running := t.Status == model.TunnelStatusActive && t.cmd == at.cmd
 			t.Status = model.TunnelStatusError
 			// Include stderr output in error message for better debugging
 			errMsg := err.Error()
@@ -180,6 +242,11 @@ func (m *Manager) monitorTunnel(id string, at *activeTunnel) {
 			}
 			t.Error = errMsg
 			log.Error("Tunnel %s exited with error: %s", id, errMsg)
+
+			// Accounts may deny the port forwarding documents but allow ECS Exec
+			if running && m.strategy == StrategyAuto && t.TaskID != "" && t.JumpHostID == "" && portForwardDenied(errMsg) {
+				m.fallBackToRelay(t, errMsg)
+			}
 		} else {
 			t.Status = model.TunnelStatusTerminated
 			log.Info("Tunnel %s terminated normally", id)
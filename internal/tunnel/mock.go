@@ -0,0 +1,273 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"vaws/internal/log"
+	"vaws/internal/metrics"
+	"vaws/internal/model"
+)
+
+// mockRoute is a route of a mock server, split for matching.
+type mockRoute struct {
+	model.MockRoute
+	method   string   // Upper case, "ANY" for every method
+	segments []string // Path segments, e.g. ["orders", "{id}"]
+	fallback bool     // $default: answers what no other route does
+}
+
+// parseMockRoute splits a route key such as "GET /orders/{id}".
+func parseMockRoute(r model.MockRoute) (mockRoute, error) {
+	if r.RouteKey == "$default" {
+		return mockRoute{MockRoute: r, fallback: true}, nil
+	}
+	method, path, ok := strings.Cut(strings.TrimSpace(r.RouteKey), " ")
+	path = strings.TrimSpace(path)
+	if !ok || !strings.HasPrefix(path, "/") {
+		return mockRoute{}, fmt.Errorf("invalid route %q, expected e.g. \"GET /orders/{id}\"", r.RouteKey)
+	}
+	return mockRoute{MockRoute: r, method: strings.ToUpper(method), segments: splitPath(path)}, nil
+}
+
+// splitPath returns the segments of a path, none for "/".
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// match reports whether the route answers a request, and how specific it
+// is: literal segments count most, then a method named rather than ANY.
+// Greedy {proxy+} segments match one or more segments.
+func (r mockRoute) match(method string, segments []string) (int, bool) {
+	if r.method != "ANY" && r.method != method {
+		return 0, false
+	}
+	score := 1
+	if r.method != "ANY" {
+		score++
+	}
+	for i, seg := range r.segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "+}") {
+			return score, i == len(r.segments)-1 && len(segments) > i
+		}
+		if i >= len(segments) {
+			return 0, false
+		}
+		switch {
+		case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+			score += 10
+		case seg == segments[i]:
+			score += 100
+		default:
+			return 0, false
+		}
+	}
+	return score, len(r.segments) == len(segments)
+}
+
+// mockHandler answers requests from the routes of an API, as API Gateway
+// would route them. Responses allow any origin, so a frontend served from
+// another port can call it.
+type mockHandler struct {
+	routes []mockRoute
+	stage  string
+}
+
+// find returns the most specific route answering a request, also trying the
+// path without the stage prefix REST API URLs carry. $default answers only
+// when no other route does.
+func (h *mockHandler) find(method, path string) (mockRoute, bool) {
+	paths := []string{path}
+	if prefix := "/" + h.stage; h.stage != "" && (path == prefix || strings.HasPrefix(path, prefix+"/")) {
+		paths = append(paths, strings.TrimPrefix(path, prefix))
+	}
+	fallback := -1
+	for _, p := range paths {
+		segments := splitPath(p)
+		best, bestScore := -1, 0
+		for i, r := range h.routes {
+			if r.fallback {
+				fallback = i
+				continue
+			}
+			if score, ok := r.match(method, segments); ok && (best < 0 || score > bestScore) {
+				best, bestScore = i, score
+			}
+		}
+		if best >= 0 {
+			return h.routes[best], true
+		}
+	}
+	if fallback >= 0 {
+		return h.routes[fallback], true
+	}
+	return mockRoute{}, false
+}
+
+func (h *mockHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	route, ok := h.find(req.Method, req.URL.Path)
+	if !ok && req.Method == http.MethodOptions {
+		// CORS preflight of a route without an OPTIONS route of its own
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		if headers := req.Header.Get("Access-Control-Request-Headers"); headers != "" {
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !ok {
+		log.Debug("Mock %s %s: no route", req.Method, req.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+		return
+	}
+
+	for name, value := range route.Headers {
+		w.Header().Set(name, value)
+	}
+	if route.Body != "" && w.Header().Get("Content-Type") == "" {
+		if body := strings.TrimSpace(route.Body); strings.HasPrefix(body, "{") || strings.HasPrefix(body, "[") {
+			w.Header().Set("Content-Type", "application/json")
+		} else {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+	}
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	log.Debug("Mock %s %s -> %d (%s)", req.Method, req.URL.Path, status, route.RouteKey)
+	w.WriteHeader(status)
+	fmt.Fprint(w, route.Body)
+}
+
+// apiIdentity returns the name, ID and type (REST or HTTP) of an API.
+func apiIdentity(api interface{}) (name, id, apiType string, err error) {
+	switch a := api.(type) {
+	case model.RestAPI:
+		return a.Name, a.ID, "REST", nil
+	case *model.RestAPI:
+		return a.Name, a.ID, "REST", nil
+	case model.HttpAPI:
+		return a.Name, a.ID, "HTTP", nil
+	case *model.HttpAPI:
+		return a.Name, a.ID, "HTTP", nil
+	}
+	return "", "", "", fmt.Errorf("unsupported API type: %T", api)
+}
+
+// StartMockServer serves the routes of an API stage on a local port without
+// connecting to AWS, for when the backend is down. Each route answers with
+// its configured example or an empty 200; other paths get API Gateway's 404.
+func (m *APIGatewayManager) StartMockServer(api interface{}, stage model.APIStage, routes []model.MockRoute, localPort int) (*model.APIGatewayTunnel, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	apiName, apiID, apiType, err := apiIdentity(api)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := &mockHandler{stage: stage.Name}
+	for _, r := range routes {
+		route, err := parseMockRoute(r)
+		if err != nil {
+			return nil, err
+		}
+		handler.routes = append(handler.routes, route)
+	}
+	if len(handler.routes) == 0 {
+		return nil, fmt.Errorf("API %s has no routes to mock; add examples under mocks in the config", apiName)
+	}
+	sort.SliceStable(handler.routes, func(i, j int) bool { return handler.routes[i].RouteKey < handler.routes[j].RouteKey })
+
+	if localPort == 0 {
+		localPort, err = m.findFreePort()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find free port: %w", err)
+		}
+	}
+	for _, t := range m.tunnels {
+		if t.LocalPort == localPort && (t.Status == model.TunnelStatusActive || t.Status == model.TunnelStatusStarting) {
+			return nil, fmt.Errorf("port %d is already in use by tunnel '%s'", localPort, t.ID)
+		}
+	}
+
+	tunnelID := fmt.Sprintf("mock-%s-%s-%d", apiID, stage.Name, localPort)
+	if t, exists := m.tunnels[tunnelID]; exists && t.Status != model.TunnelStatusTerminated {
+		return nil, fmt.Errorf("tunnel %s already exists", tunnelID)
+	}
+
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", localPort))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	if m.useTLS {
+		tlsConfig, err := localhostTLSConfig()
+		if err != nil {
+			ln.Close()
+			return nil, fmt.Errorf("failed to set up HTTPS for mock server: %w", err)
+		}
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+
+	server := &http.Server{
+		Handler:           metrics.InstrumentProxy(tunnelID, handler),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	serverCtx, cancel := context.WithCancel(context.Background())
+	at := &activeAPIGWTunnel{
+		APIGatewayTunnel: model.APIGatewayTunnel{
+			ID:         tunnelID,
+			LocalPort:  localPort,
+			APIName:    apiName,
+			APIID:      apiID,
+			APIType:    apiType,
+			StageName:  stage.Name,
+			InvokeURL:  stage.InvokeURL,
+			TunnelType: model.APIGatewayTunnelMock,
+			Status:     model.TunnelStatusActive,
+			StartedAt:  time.Now(),
+			TLS:        m.useTLS,
+			MockRoutes: len(handler.routes),
+		},
+		server: server,
+		cancel: cancel,
+		rules:  &proxyRules{},
+	}
+	m.tunnels[tunnelID] = at
+
+	go func() {
+		<-serverCtx.Done()
+		server.Close()
+	}()
+	go func() {
+		if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			m.mu.Lock()
+			at.Status = model.TunnelStatusError
+			at.Error = err.Error()
+			m.mu.Unlock()
+			log.Error("Mock server error: %v", err)
+			return
+		}
+		m.mu.Lock()
+		at.Status = model.TunnelStatusTerminated
+		m.mu.Unlock()
+	}()
+
+	log.Info("Mock server for %s/%s answering %d routes on %s", apiName, stage.Name, len(handler.routes), at.LocalURL())
+	return &at.APIGatewayTunnel, nil
+}
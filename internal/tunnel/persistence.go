@@ -22,6 +22,9 @@ type persistedTunnel struct {
 	ClusterName   string             `json:"cluster_name"`
 	TaskID        string             `json:"task_id"`
 	ContainerName string             `json:"container_name"`
+	RemoteHost    string             `json:"remote_host,omitempty"`
+	JumpHostID    string             `json:"jump_host_id,omitempty"`
+	JumpHostName  string             `json:"jump_host_name,omitempty"`
 	StartedAt     time.Time          `json:"started_at"`
 	Status        model.TunnelStatus `json:"status"`
 	Error         string             `json:"error,omitempty"`
@@ -59,6 +62,9 @@ func (m *Manager) saveTunnels() error {
 			ClusterName:   t.ClusterName,
 			TaskID:        t.TaskID,
 			ContainerName: t.ContainerName,
+			RemoteHost:    t.RemoteHost,
+			JumpHostID:    t.JumpHostID,
+			JumpHostName:  t.JumpHostName,
 			StartedAt:     t.StartedAt,
 			Status:        t.Status,
 			Error:         t.Error,
@@ -129,6 +135,9 @@ func (m *Manager) loadTunnels() error {
 			ClusterName:   pt.ClusterName,
 			TaskID:        pt.TaskID,
 			ContainerName: pt.ContainerName,
+			RemoteHost:    pt.RemoteHost,
+			JumpHostID:    pt.JumpHostID,
+			JumpHostName:  pt.JumpHostName,
 			StartedAt:     pt.StartedAt,
 			Error:         pt.Error,
 		}
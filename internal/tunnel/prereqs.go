@@ -0,0 +1,117 @@
+package tunnel
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Programs SSM sessions run: the AWS CLI starts a session and
+// session-manager-plugin carries its traffic.
+const (
+	AWSCLIBinary = "aws"
+	PluginBinary = "session-manager-plugin"
+)
+
+// versionTimeout bounds how long a program may take to print its version.
+// The AWS CLI v1 loads Python first, which can take a second or two.
+const versionTimeout = 5 * time.Second
+
+// Binary is an external program tunnels and shells depend on.
+type Binary struct {
+	Name    string
+	Path    string // Where it was found on PATH, empty if it wasn't
+	Version string
+	Err     error // Why it can't be used, nil if it can
+}
+
+// OK returns true if the program was found and ran.
+func (b Binary) OK() bool {
+	return b.Err == nil
+}
+
+// Prerequisites are the programs port forwarding, API Gateway proxies and
+// shells need.
+type Prerequisites struct {
+	AWSCLI Binary
+	Plugin Binary
+}
+
+// OK returns true if every program is usable.
+func (p Prerequisites) OK() bool {
+	return p.AWSCLI.OK() && p.Plugin.OK()
+}
+
+// Missing returns the programs that can't be used.
+func (p Prerequisites) Missing() []Binary {
+	var missing []Binary
+	for _, b := range []Binary{p.AWSCLI, p.Plugin} {
+		if !b.OK() {
+			missing = append(missing, b)
+		}
+	}
+	return missing
+}
+
+// CheckPrerequisites looks for the AWS CLI and session-manager-plugin on PATH
+// and reads their versions.
+func CheckPrerequisites() Prerequisites {
+	return Prerequisites{
+		AWSCLI: checkBinary(AWSCLIBinary, "--version", func(out string) string {
+			// aws-cli/2.15.0 Python/3.11.6 Darwin/23.1.0 exe/x86_64
+			version, _, _ := strings.Cut(out, " ")
+			return strings.TrimPrefix(version, "aws-cli/")
+		}),
+		Plugin: checkBinary(PluginBinary, "--version", strings.TrimSpace),
+	}
+}
+
+// checkBinary finds a program and runs it with versionFlag, parsing its
+// output with version.
+func checkBinary(name, versionFlag string, version func(string) string) Binary {
+	b := Binary{Name: name}
+	path, err := exec.LookPath(name)
+	if err != nil {
+		b.Err = fmt.Errorf("%s not found on PATH", name)
+		return b
+	}
+	b.Path = path
+
+	ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, versionFlag).CombinedOutput()
+	if err != nil {
+		b.Err = fmt.Errorf("%s %s failed: %w", name, versionFlag, err)
+		return b
+	}
+	b.Version = version(strings.TrimSpace(string(out)))
+	return b
+}
+
+// InstallHint returns how to install a program on this OS.
+func InstallHint(name string) string {
+	switch name {
+	case AWSCLIBinary:
+		switch runtime.GOOS {
+		case "darwin":
+			return "brew install awscli"
+		case "windows":
+			return "winget install Amazon.AWSCLI"
+		default:
+			return "see https://docs.aws.amazon.com/cli/latest/userguide/getting-started-install.html"
+		}
+	case PluginBinary:
+		switch runtime.GOOS {
+		case "darwin":
+			return "brew install --cask session-manager-plugin"
+		case "windows":
+			return "winget install Amazon.SessionManagerPlugin"
+		default:
+			return "see https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"vaws/internal/model"
+)
+
+// probeTimeout bounds each step of a probe, connecting and the request.
+const probeTimeout = 5 * time.Second
+
+// Probe checks a tunnel on localPort in two steps: that the local port
+// accepts connections, which means the SSM session is up, then that an HTTP
+// GET of path through it gets a response from the application. useTLS sends
+// the request over HTTPS.
+func Probe(ctx context.Context, localPort int, path string, useTLS bool) model.TunnelProbe {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	probe := model.TunnelProbe{Path: path, At: time.Now()}
+	addr := fmt.Sprintf("localhost:%d", localPort)
+
+	dialer := net.Dialer{Timeout: probeTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		probe.Error = "session not ready"
+		return probe
+	}
+	conn.Close()
+	probe.SessionUp = true
+
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+"://"+addr+path, nil)
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	client := &http.Client{
+		Timeout: probeTimeout,
+		Transport: &http.Transport{
+			// The certificate is for the service's name, not localhost
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		// A redirect is an answer too
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		probe.Error = "no response"
+		if ctx.Err() == nil && strings.Contains(err.Error(), "Client.Timeout") {
+			probe.Error = "timed out"
+		}
+		return probe
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	probe.Latency = time.Since(start)
+	probe.StatusCode = resp.StatusCode
+	return probe
+}
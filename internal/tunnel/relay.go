@@ -0,0 +1,320 @@
+package tunnel
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"vaws/internal/log"
+	"vaws/internal/metrics"
+	"vaws/internal/model"
+)
+
+// Strategy selects how tunnels to ECS containers are established. The values
+// match the tunnel_strategy config setting.
+type Strategy string
+
+// Tunnel strategies
+const (
+	// StrategyAuto uses port forwarding and falls back to a relay when the
+	// port forwarding documents are denied.
+	StrategyAuto Strategy = "auto"
+	// StrategyPortForward only uses the SSM port forwarding documents.
+	StrategyPortForward Strategy = "port_forward"
+	// StrategyExec relays every connection through socat, ncat or nc run in
+	// the container with ECS Exec.
+	StrategyExec Strategy = "exec"
+)
+
+// relayReady is printed in the container once its terminal passes bytes
+// through unchanged. Output before it is Session Manager's own.
+const relayReady = "VAWS-RELAY-READY"
+
+// relaySessionTimeout bounds how long an ECS Exec session may take to start.
+const relaySessionTimeout = 30 * time.Second
+
+// relays are the programs looked for in the container, preferred first.
+var relays = []string{"socat", "ncat", "nc"}
+
+// SetStrategy sets how new ECS tunnels are established. Running tunnels keep
+// the way they were started.
+func (m *Manager) SetStrategy(strategy Strategy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.strategy = strategy
+}
+
+// useRelay returns true if a tunnel to a container of the cluster should
+// relay through ECS Exec. Must be called with m.mu held.
+func (m *Manager) useRelay(clusterName string) bool {
+	switch m.strategy {
+	case StrategyExec:
+		return true
+	case StrategyPortForward:
+		return false
+	default:
+		return m.deniedClusters[clusterName]
+	}
+}
+
+// portForwardDenied returns true if a start-session error says the session,
+// or its port forwarding document, is not permitted by IAM or an SCP.
+func portForwardDenied(errMsg string) bool {
+	return strings.Contains(errMsg, "ssm:StartSession") &&
+		(strings.Contains(errMsg, "AccessDenied") || strings.Contains(errMsg, "not authorized"))
+}
+
+// fallBackToRelay restarts a tunnel whose port forwarding session was denied
+// as a relay on the same local port. Tunnels to the cluster relay from then
+// on. Must be called with m.mu held.
+func (m *Manager) fallBackToRelay(at *activeTunnel, denied string) {
+	log.Warn("Port forwarding is not permitted for tunnel %s, falling back to ECS Exec: %s", at.ID, denied)
+	m.deniedClusters[at.ClusterName] = true
+
+	delete(m.tunnels, at.ID)
+	if _, err := m.launchRelayTunnel(at.Tunnel, true); err != nil {
+		at.Status = model.TunnelStatusError
+		at.Error = fmt.Sprintf("port forwarding is not permitted, and the ECS Exec fallback failed: %v", err)
+		m.tunnels[at.ID] = at
+		log.Error("Tunnel %s: %s", at.ID, at.Error)
+	}
+}
+
+// launchRelayTunnel listens on the tunnel's local port and relays each
+// connection over its own ECS Exec session in the tunnel's container. The
+// tunnel stays starting until a relay program is found in the container.
+// fallback marks a tunnel whose port forwarding was denied. Must be called
+// with m.mu held.
+func (m *Manager) launchRelayTunnel(tunnel model.Tunnel, fallback bool) (*model.Tunnel, error) {
+	if _, exists := m.tunnels[tunnel.ID]; exists {
+		return nil, fmt.Errorf("tunnel %s already exists", tunnel.ID)
+	}
+
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", tunnel.LocalPort))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on localhost:%d: %w", tunnel.LocalPort, err)
+	}
+
+	tunnel.Status = model.TunnelStatusStarting
+	tunnel.StartedAt = time.Now()
+	tunnel.Error = ""
+
+	ctx, cancel := context.WithCancel(context.Background())
+	at := &activeTunnel{
+		Tunnel: tunnel,
+		cancel: func() {
+			cancel()
+			ln.Close()
+		},
+	}
+	m.tunnels[tunnel.ID] = at
+
+	log.Info("Starting tunnel: %s:%d in %s -> localhost:%d (ECS Exec relay)", relayHost(tunnel), tunnel.RemotePort, tunnel.ContainerName, tunnel.LocalPort)
+	go m.serveRelay(ctx, at, ln, fallback)
+
+	return &tunnel, nil
+}
+
+// serveRelay finds a relay program in the container, then accepts
+// connections until the tunnel is stopped.
+func (m *Manager) serveRelay(ctx context.Context, at *activeTunnel, ln net.Listener, fallback bool) {
+	m.mu.RLock()
+	tunnel := at.Tunnel
+	m.mu.RUnlock()
+
+	relay, err := m.detectRelay(ctx, tunnel)
+
+	m.mu.Lock()
+	if at.Status != model.TunnelStatusStarting {
+		// Stopped while looking
+		m.mu.Unlock()
+		return
+	}
+	if err != nil {
+		ln.Close()
+		at.Status = model.TunnelStatusError
+		if fallback {
+			at.Error = fmt.Sprintf("port forwarding is not permitted, and the ECS Exec fallback failed: %v", err)
+		} else {
+			at.Error = fmt.Sprintf("ECS Exec relay failed: %v", err)
+		}
+		log.Error("Tunnel %s: %s", at.ID, at.Error)
+	} else {
+		at.Status = model.TunnelStatusActive
+		at.Relay = relay
+		tunnel = at.Tunnel
+		log.Info("Tunnel started: %s on localhost:%d via %s", at.ID, tunnel.LocalPort, relay)
+	}
+	m.mu.Unlock()
+
+	if err := m.saveTunnels(); err != nil {
+		log.Debug("Failed to save tunnels: %v", err)
+	}
+	if tunnel.Relay == "" {
+		return
+	}
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() == nil {
+				m.mu.Lock()
+				at.Status = model.TunnelStatusError
+				at.Error = err.Error()
+				m.mu.Unlock()
+				log.Error("Tunnel %s stopped accepting connections: %v", at.ID, err)
+			}
+			return
+		}
+		go m.relayConn(ctx, tunnel, conn)
+	}
+}
+
+// detectRelay returns the first of the relay programs installed in the
+// tunnel's container. Running it is also what tells whether ECS Exec is
+// permitted and enabled for the task.
+func (m *Manager) detectRelay(ctx context.Context, tunnel model.Tunnel) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, relaySessionTimeout)
+	defer cancel()
+
+	script := fmt.Sprintf("for r in %s; do command -v $r >/dev/null && echo %s $r && exit 0; done; echo %s none",
+		strings.Join(relays, " "), relayReady, relayReady)
+	cmd := m.relayCommand(ctx, tunnel, script)
+
+	// Session Manager ends the session when its input closes, so keep it open
+	stdin, stdinW := io.Pipe()
+	defer stdinW.Close()
+	cmd.Stdin = stdin
+
+	out, err := cmd.CombinedOutput()
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != relayReady {
+			continue
+		}
+		if fields[1] == "none" {
+			return "", fmt.Errorf("none of %s is installed in container %s", strings.Join(relays, ", "), tunnel.ContainerName)
+		}
+		return fields[1], nil
+	}
+
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return "", fmt.Errorf("%s", lastLine(msg))
+	}
+	if ctx.Err() != nil {
+		return "", fmt.Errorf("ECS Exec session did not start within %s", relaySessionTimeout)
+	}
+	return "", fmt.Errorf("ECS Exec session ended: %v", err)
+}
+
+// relayConn relays one local connection over an ECS Exec session running the
+// tunnel's relay program. The session ends with the connection.
+func (m *Manager) relayConn(ctx context.Context, tunnel model.Tunnel, conn net.Conn) {
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(ctx)
+
+	// The terminal of the session must not echo or translate what it relays
+	script := fmt.Sprintf("stty raw -echo; echo %s; exec %s", relayReady, relayArgs(tunnel.Relay, relayHost(tunnel), tunnel.RemotePort))
+	cmd := m.relayCommand(ctx, tunnel, script)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		cancel()
+		return
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		cancel()
+		log.Warn("Tunnel %s: failed to start relay session: %v", tunnel.ID, err)
+		return
+	}
+	defer func() {
+		cancel()
+		cmd.Wait()
+	}()
+
+	timer := time.AfterFunc(relaySessionTimeout, cancel)
+	r := bufio.NewReader(stdout)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			timer.Stop()
+			msg := strings.TrimSpace(stderr.String())
+			if msg == "" {
+				msg = err.Error()
+			}
+			log.Warn("Tunnel %s: relay session failed: %s", tunnel.ID, lastLine(msg))
+			return
+		}
+		if strings.TrimSpace(line) == relayReady {
+			break
+		}
+	}
+	timer.Stop()
+
+	sent := make(chan int64, 1)
+	go func() {
+		n, _ := io.Copy(stdin, conn)
+		cancel()
+		sent <- n
+	}()
+	received, _ := io.Copy(conn, r)
+	conn.Close()
+	metrics.Default().AddConnection(tunnel.ID, <-sent, received)
+}
+
+// relayCommand returns the ECS Exec session running script in the tunnel's
+// container. Cancelling ctx kills the session plugin along with the CLI.
+func (m *Manager) relayCommand(ctx context.Context, tunnel model.Tunnel, script string) *exec.Cmd {
+	args := m.withProfileArgs([]string{
+		"ecs", "execute-command",
+		"--cluster", tunnel.ClusterName,
+		"--task", tunnel.TaskID,
+		"--container", tunnel.ContainerName,
+		"--interactive",
+		"--command", "/bin/sh -c '" + script + "'",
+	})
+	cmd := awsCommand(ctx, m.cliEnv, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	return cmd
+}
+
+// relayHost is the host the relay connects to from the container.
+func relayHost(tunnel model.Tunnel) string {
+	if tunnel.RemoteHost != "" {
+		return tunnel.RemoteHost
+	}
+	return "127.0.0.1"
+}
+
+// relayArgs returns the command line connecting a relay program's standard
+// input and output to host:port.
+func relayArgs(relay, host string, port int) string {
+	if relay == "socat" {
+		return "socat - TCP:" + net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	return fmt.Sprintf("%s %s %d", relay, host, port)
+}
+
+// lastLine returns the last line of multi-line CLI output, which is where
+// the AWS CLI puts its error.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
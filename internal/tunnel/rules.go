@@ -0,0 +1,119 @@
+package tunnel
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"vaws/internal/log"
+	"vaws/internal/model"
+)
+
+// applyProxyRules rewrites the request path and injects headers before the
+// request is forwarded to API Gateway.
+func applyProxyRules(req *http.Request, rules model.ProxyRules) {
+	for _, rw := range rules.Rewrites {
+		if rw.From != "" && strings.HasPrefix(req.URL.Path, rw.From) {
+			req.URL.Path = rw.To + strings.TrimPrefix(req.URL.Path, rw.From)
+			req.URL.RawPath = ""
+			break
+		}
+	}
+
+	for name, value := range rules.Headers {
+		req.Header.Set(name, value)
+	}
+}
+
+// proxyRules holds the rules read by a proxy's director. It is read on every
+// request without taking the manager lock.
+type proxyRules struct {
+	current atomic.Pointer[model.ProxyRules]
+}
+
+// get returns the current rules, or empty rules if none were set.
+func (r *proxyRules) get() model.ProxyRules {
+	if p := r.current.Load(); p != nil {
+		return *p
+	}
+	return model.ProxyRules{}
+}
+
+// SetTunnelRules replaces the proxy rules of a tunnel. The new rules apply to
+// the next request, so the tunnel does not need to be restarted.
+func (m *APIGatewayManager) SetTunnelRules(id string, rules model.ProxyRules) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, exists := m.tunnels[id]
+	if !exists {
+		return fmt.Errorf("tunnel %s not found", id)
+	}
+
+	// Copy the headers so callers can't mutate them while requests are in flight
+	headers := make(map[string]string, len(rules.Headers))
+	for k, v := range rules.Headers {
+		headers[k] = v
+	}
+	t.Rules = model.ProxyRules{
+		Headers:  headers,
+		Rewrites: append([]model.RewriteRule(nil), rules.Rewrites...),
+	}
+	snapshot := t.Rules
+	t.rules.current.Store(&snapshot)
+
+	log.Info("Updated proxy rules for %s: %d header(s), %d rewrite(s)", id, len(t.Rules.Headers), len(t.Rules.Rewrites))
+	return nil
+}
+
+// ParseProxyRules parses rules in the form used by the rules dialog:
+// entries separated by ";", either "Header-Name: value" or "/from -> /to".
+func ParseProxyRules(spec string) (model.ProxyRules, error) {
+	rules := model.ProxyRules{Headers: make(map[string]string)}
+
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if from, to, ok := strings.Cut(entry, "->"); ok {
+			from = strings.TrimSpace(from)
+			to = strings.TrimSpace(to)
+			if !strings.HasPrefix(from, "/") {
+				return model.ProxyRules{}, fmt.Errorf("rewrite %q must start with /", entry)
+			}
+			rules.Rewrites = append(rules.Rewrites, model.RewriteRule{From: from, To: to})
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return model.ProxyRules{}, fmt.Errorf("invalid rule %q (use 'Header: value' or '/from -> /to')", entry)
+		}
+		rules.Headers[http.CanonicalHeaderKey(name)] = strings.TrimSpace(value)
+	}
+
+	return rules, nil
+}
+
+// FormatProxyRules formats rules so they can be edited and parsed back with ParseProxyRules.
+func FormatProxyRules(rules model.ProxyRules) string {
+	names := make([]string, 0, len(rules.Headers))
+	for name := range rules.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names)+len(rules.Rewrites))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s: %s", name, rules.Headers[name]))
+	}
+	for _, rw := range rules.Rewrites {
+		parts = append(parts, fmt.Sprintf("%s -> %s", rw.From, rw.To))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,125 @@
+package tunnel
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"vaws/internal/model"
+)
+
+// ProfilePlaceholder is written instead of the exporter's profile name.
+// Imports always use the importer's current profile.
+const ProfilePlaceholder = "<your-profile>"
+
+// Shared tunnel types.
+const (
+	SharedTunnelECS        = "ecs"
+	SharedTunnelAPIGateway = "apigateway"
+)
+
+// SharedTunnel is a portable tunnel definition that teammates can import to
+// reproduce a tunnel under their own profile.
+type SharedTunnel struct {
+	Type       string                  `yaml:"type"`
+	Profile    string                  `yaml:"profile"`
+	Region     string                  `yaml:"region,omitempty"`
+	LocalPort  int                     `yaml:"local_port,omitempty"`
+	ECS        *SharedECSTarget        `yaml:"ecs,omitempty"`
+	APIGateway *SharedAPIGatewayTarget `yaml:"api_gateway,omitempty"`
+}
+
+// SharedECSTarget identifies an ECS container port by name rather than ARN,
+// so it resolves in the importer's account.
+type SharedECSTarget struct {
+	Cluster    string `yaml:"cluster"`
+	Service    string `yaml:"service"`
+	Container  string `yaml:"container,omitempty"`
+	RemoteHost string `yaml:"remote_host,omitempty"` // Discovered endpoint reached through the task
+	RemotePort int    `yaml:"remote_port"`
+}
+
+// SharedAPIGatewayTarget identifies an API Gateway stage.
+type SharedAPIGatewayTarget struct {
+	APIID       string `yaml:"api_id,omitempty"`
+	APIName     string `yaml:"api_name"`
+	APIType     string `yaml:"api_type"` // REST or HTTP
+	Stage       string `yaml:"stage"`
+	Private     bool   `yaml:"private,omitempty"`
+	JumpHostTag string `yaml:"jump_host_tag,omitempty"`
+}
+
+// ExportECSTunnel builds a shareable definition for an ECS tunnel.
+func ExportECSTunnel(t model.Tunnel, region string) SharedTunnel {
+	return SharedTunnel{
+		Type:      SharedTunnelECS,
+		Profile:   ProfilePlaceholder,
+		Region:    region,
+		LocalPort: t.LocalPort,
+		ECS: &SharedECSTarget{
+			Cluster:    t.ClusterName,
+			Service:    t.ServiceName,
+			Container:  t.ContainerName,
+			RemoteHost: t.RemoteHost,
+			RemotePort: t.RemotePort,
+		},
+	}
+}
+
+// ExportAPIGatewayTunnel builds a shareable definition for an API Gateway tunnel.
+// jumpHostTag is used for private APIs; when empty, the jump host's Name tag is used.
+func ExportAPIGatewayTunnel(t model.APIGatewayTunnel, region, jumpHostTag string) SharedTunnel {
+	target := &SharedAPIGatewayTarget{
+		APIID:   t.APIID,
+		APIName: t.APIName,
+		APIType: t.APIType,
+		Stage:   t.StageName,
+		Private: t.TunnelType == model.APIGatewayTunnelPrivate,
+	}
+	if target.Private {
+		target.JumpHostTag = jumpHostTag
+		if target.JumpHostTag == "" && t.JumpHost != nil && t.JumpHost.Name != "" {
+			target.JumpHostTag = "Name=" + t.JumpHost.Name
+		}
+	}
+
+	return SharedTunnel{
+		Type:       SharedTunnelAPIGateway,
+		Profile:    ProfilePlaceholder,
+		Region:     region,
+		LocalPort:  t.LocalPort,
+		APIGateway: target,
+	}
+}
+
+// YAML returns the definition as a YAML snippet.
+func (s SharedTunnel) YAML() (string, error) {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode tunnel definition: %w", err)
+	}
+	return string(data), nil
+}
+
+// ParseSharedTunnel parses and validates a YAML tunnel definition.
+func ParseSharedTunnel(data []byte) (*SharedTunnel, error) {
+	var s SharedTunnel
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid tunnel definition: %w", err)
+	}
+
+	switch s.Type {
+	case SharedTunnelECS:
+		if s.ECS == nil || s.ECS.Cluster == "" || s.ECS.Service == "" || s.ECS.RemotePort == 0 {
+			return nil, fmt.Errorf("ecs tunnel definition needs cluster, service and remote_port")
+		}
+	case SharedTunnelAPIGateway:
+		if s.APIGateway == nil || (s.APIGateway.APIID == "" && s.APIGateway.APIName == "") || s.APIGateway.Stage == "" {
+			return nil, fmt.Errorf("apigateway tunnel definition needs api_id or api_name, and stage")
+		}
+	default:
+		return nil, fmt.Errorf("unknown tunnel type %q (expected %s or %s)", s.Type, SharedTunnelECS, SharedTunnelAPIGateway)
+	}
+
+	return &s, nil
+}
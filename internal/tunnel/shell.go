@@ -0,0 +1,47 @@
+package tunnel
+
+import (
+	"context"
+	"os/exec"
+)
+
+// DefaultShellCommand is run inside containers opened with ECS Exec. It prefers
+// bash and falls back to sh for minimal images.
+const DefaultShellCommand = `/bin/sh -c "command -v bash >/dev/null && exec bash || exec sh"`
+
+// ECSExecCommand returns the command that opens an interactive shell in an ECS
+// container via ECS Exec. The caller is expected to hand the terminal over to
+// it (e.g. with tea.ExecProcess).
+func (m *Manager) ECSExecCommand(clusterName, taskID, containerName string) *exec.Cmd {
+	args := []string{
+		"ecs", "execute-command",
+		"--cluster", clusterName,
+		"--task", taskID,
+		"--container", containerName,
+		"--interactive",
+		"--command", DefaultShellCommand,
+	}
+	return awsCommand(context.Background(), m.cliEnv, m.withProfileArgs(args)...)
+}
+
+// InstanceShellCommand returns the command that opens an interactive Session
+// Manager shell on an EC2 instance.
+func (m *Manager) InstanceShellCommand(instanceID string) *exec.Cmd {
+	args := []string{
+		"ssm", "start-session",
+		"--target", instanceID,
+	}
+	return awsCommand(context.Background(), m.cliEnv, m.withProfileArgs(args)...)
+}
+
+// withProfileArgs appends the manager's region and profile to AWS CLI args.
+// The profile is left out when the credentials come from an assumed role.
+func (m *Manager) withProfileArgs(args []string) []string {
+	if m.region != "" {
+		args = append(args, "--region", m.region)
+	}
+	if m.profile != "" && m.cliEnv == nil {
+		args = append(args, "--profile", m.profile)
+	}
+	return args
+}
@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/aws"
+	"vaws/internal/config"
+	"vaws/internal/log"
+	"vaws/internal/model"
+	"vaws/internal/ui/theme"
+)
+
+// accountsShown is how many accounts the accounts dialog lists at once; the
+// list scrolls with the cursor.
+const accountsShown = 12
+
+// memberAccount is the member account of the organization the client
+// assumed a role in with :accounts.
+type memberAccount struct {
+	id   string
+	name string
+	role string
+	base aws.API // Client of the profile, which assumes the role
+}
+
+// accountPicker is the dialog listing the accounts of the organization.
+type accountPicker struct {
+	accounts []model.OrgAccount
+	home     string // Account of the profile's own credentials
+	loading  bool
+	err      error
+	cursor   int
+}
+
+// orgAccountsLoadedMsg carries the accounts of the organization.
+type orgAccountsLoadedMsg struct {
+	accounts []model.OrgAccount
+	home     string
+	err      error
+}
+
+// accountSwitchedMsg carries the client of the account switched to. base is
+// the profile's client that assumed the role, or nil when switching back to
+// the profile's own account.
+type accountSwitchedMsg struct {
+	account model.OrgAccount
+	role    string
+	client  aws.API
+	base    aws.API
+	err     error
+}
+
+// profileClient returns the client with the profile's own credentials,
+// which lists the accounts and assumes roles in them.
+func (m *Model) profileClient() aws.API {
+	if m.account != nil {
+		return m.account.base
+	}
+	return m.client
+}
+
+// orgRole returns the role assumed in the member accounts of the profile.
+func (m *Model) orgRole() string {
+	if m.cfg == nil {
+		return config.DefaultOrgRole
+	}
+	return m.cfg.GetOrgRole(m.state.Profile)
+}
+
+// openAccounts opens the accounts dialog and lists the accounts of the
+// organization with the profile's credentials.
+func (m *Model) openAccounts() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	m.accounts = &accountPicker{loading: true}
+	client := m.profileClient()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		identity, err := client.GetCallerIdentity(ctx)
+		if err != nil {
+			return orgAccountsLoadedMsg{err: err}
+		}
+		accounts, err := client.ListOrganizationAccounts(ctx)
+		return orgAccountsLoadedMsg{accounts: accounts, home: identity.Account, err: err}
+	}
+}
+
+// handleOrgAccountsLoaded fills the accounts dialog, if it is still open,
+// with the cursor on the current account.
+func (m *Model) handleOrgAccountsLoaded(msg orgAccountsLoadedMsg) {
+	a := m.accounts
+	if a == nil {
+		return
+	}
+	a.loading = false
+	a.err = msg.err
+	a.accounts = msg.accounts
+	a.home = msg.home
+	current := m.currentAccount(a.home)
+	for i, acct := range a.accounts {
+		if acct.ID == current {
+			a.cursor = i
+		}
+	}
+}
+
+// currentAccount returns the account the client works in: the member
+// account switched to, or else the profile's own.
+func (m *Model) currentAccount(home string) string {
+	if m.account != nil {
+		return m.account.id
+	}
+	return home
+}
+
+// handleAccountsKey handles key messages while the accounts dialog is open.
+func (m *Model) handleAccountsKey(msg tea.KeyMsg) tea.Cmd {
+	a := m.accounts
+	switch msg.String() {
+	case "esc", "q":
+		m.accounts = nil
+	case "up", "k":
+		if a.cursor > 0 {
+			a.cursor--
+		}
+	case "down", "j":
+		if a.cursor < len(a.accounts)-1 {
+			a.cursor++
+		}
+	case "enter":
+		return m.switchAccount()
+	}
+	return nil
+}
+
+// switchAccount assumes the org role in the account under the cursor, or
+// goes back to the profile's own credentials for the profile's account.
+func (m *Model) switchAccount() tea.Cmd {
+	a := m.accounts
+	if a.loading || a.cursor >= len(a.accounts) {
+		return nil
+	}
+	target := a.accounts[a.cursor]
+	switch {
+	case target.ID == m.currentAccount(a.home):
+		m.logger.Warn("Already in account %s", target.Name)
+		return nil
+	case !target.IsActive():
+		m.logger.Warn("Account %s is %s", target.Name, strings.ToLower(target.Status))
+		return nil
+	}
+	m.accounts = nil
+
+	base := m.profileClient()
+	if target.ID == a.home {
+		m.logger.Info("Switching back to account %s of profile %s...", target.Name, m.state.Profile)
+		return func() tea.Msg { return accountSwitchedMsg{account: target, client: base} }
+	}
+
+	role := m.orgRole()
+	m.logger.Info("Assuming %s in account %s (%s)...", role, target.Name, target.ID)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		client, err := base.AssumeAccount(ctx, target.ID, role)
+		return accountSwitchedMsg{account: target, role: role, client: client, base: base, err: err}
+	}
+}
+
+// applyAccount re-targets the client, tunnel managers and cached data at the
+// account switched to. Tunnels of the previous account are stopped, as their
+// sessions run with its credentials.
+func (m *Model) applyAccount(msg accountSwitchedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Error("Failed to switch to account %s: %v", msg.account.Name, msg.err)
+		return nil
+	}
+
+	if stopped := m.stopAllTunnels(); stopped > 0 {
+		m.logger.Info("Stopped %d tunnels of the previous account", stopped)
+	}
+
+	m.client = msg.client
+	m.account = nil
+	m.state.Account = ""
+	if msg.base != nil {
+		m.account = &memberAccount{id: msg.account.ID, name: msg.account.Name, role: msg.role, base: msg.base}
+		m.state.Account = msg.account.Name
+	}
+	m.tunnelManager = newTunnelManager(m.cfg, m.state.Profile, m.state.Region)
+	m.apiGWManager = newAPIGatewayManager(m.cfg, m.state.Profile, m.state.Region)
+	m.useAccountCredentials()
+	m.term.tunnels = nil
+	m.clearCachedResources()
+	m.updateTunnelsPanel()
+
+	if m.account != nil {
+		m.logger.Info("Switched to account %s (%s) as %s", msg.account.Name, msg.account.ID, msg.role)
+		m.recordTimeline(timelineSession, "Switched to account %s (%s) as %s", msg.account.Name, msg.account.ID, msg.role)
+	} else {
+		m.logger.Info("Switched back to account %s of profile %s", msg.account.Name, m.state.Profile)
+		m.recordTimeline(timelineSession, "Switched back to account %s of profile %s", msg.account.Name, m.state.Profile)
+	}
+
+	// Views of the previous account can't be refreshed, so start over
+	return m.switchToMain()
+}
+
+// useAccountCredentials makes AWS CLI sessions of the tunnel managers run
+// with the credentials of the member account, when the client is in one.
+func (m *Model) useAccountCredentials() {
+	if m.account == nil || m.client == nil {
+		return
+	}
+	client := m.client
+	env := func() []string {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		env, err := client.CLIEnv(ctx)
+		if err != nil {
+			log.Warn("Failed to pass credentials of account %s to the AWS CLI: %v", client.AssumedAccount(), err)
+		}
+		return env
+	}
+	m.tunnelManager.SetCLIEnv(env)
+	m.apiGWManager.SetCLIEnv(env)
+}
+
+// regionClient creates the clients for a region change: the profile's, and
+// from it the member account's when one was switched to, so the switch
+// carries over to the new region.
+func (m *Model) regionClient(ctx context.Context, region string) (client, base aws.API, err error) {
+	profileClient, err := m.newClient(ctx, m.state.Profile, region)
+	if err != nil {
+		return nil, nil, err
+	}
+	if m.account == nil {
+		return profileClient, nil, nil
+	}
+	client, err = profileClient.AssumeAccount(ctx, m.account.id, m.account.role)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, profileClient, nil
+}
+
+// renderAccountsDialog renders the accounts of the organization, with the
+// profile's and the current one marked.
+func (m *Model) renderAccountsDialog() string {
+	a := m.accounts
+	dialogWidth := 90
+	if m.width < 100 {
+		dialogWidth = max(m.width-10, 40)
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Accounts of the organization")
+
+	switch {
+	case a.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Listing accounts..."))
+	case a.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(a.err.Error(), dialogWidth-6)) + "\n\n" +
+			s.Muted.Render("Only the management account and delegated administrators can list accounts") + "\n\n" + hintStyle.Render("esc to close"))
+	case len(a.accounts) == 0:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("No accounts") + "\n\n" + hintStyle.Render("esc to close"))
+	}
+
+	nameWidth := 0
+	for _, acct := range a.accounts {
+		nameWidth = max(nameWidth, len(acct.Name))
+	}
+	nameWidth = min(nameWidth, dialogWidth/3)
+	current := m.currentAccount(a.home)
+	start := max(0, min(a.cursor-accountsShown/2, len(a.accounts)-accountsShown))
+	end := min(start+accountsShown, len(a.accounts))
+	var lines []string
+	for i := start; i < end; i++ {
+		acct := a.accounts[i]
+		cursor := "  "
+		if i == a.cursor {
+			cursor = theme.Symbol("▶ ", "> ")
+		}
+		line := cursor + fmt.Sprintf("%-*s  %s  ", nameWidth, truncateString(acct.Name, nameWidth), acct.ID)
+		var marker string
+		switch {
+		case acct.ID == current:
+			marker = s.StatusHealthy.Render("current ")
+		case !acct.IsActive():
+			marker = s.StatusWarning.Render(strings.ToLower(acct.Status) + " ")
+		}
+		if acct.ID == a.home {
+			marker += s.Muted.Render("profile ")
+		}
+		email := truncateString(acct.Email, max(dialogWidth-6-lipgloss.Width(line)-lipgloss.Width(marker), 0))
+		lines = append(lines, line+marker+s.Muted.Render(email))
+	}
+
+	position := fmt.Sprintf("%d of %d", a.cursor+1, len(a.accounts))
+	content := title + "\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		hintStyle.Render(position+" · enter assumes "+m.orgRole()+" in the account · esc")
+	return dialogStyle.Render(content)
+}
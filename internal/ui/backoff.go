@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"time"
+
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+const (
+	// refreshPauseAfter is how many auto-refreshes of a view may fail in a
+	// row before auto-refresh of it stops until a manual refresh succeeds.
+	refreshPauseAfter = 5
+
+	// refreshBackoffMax caps the wait between auto-refreshes of a failing view.
+	refreshBackoffMax = 5 * time.Minute
+)
+
+// refreshFailure counts the auto-refreshes of a view that failed in a row.
+type refreshFailure struct {
+	count   int
+	retryAt time.Time // No auto-refresh before this
+	lastErr string
+}
+
+// refreshBackoff keeps auto-refresh from retrying views whose loads keep
+// failing, e.g. on expired credentials or missing permissions.
+type refreshBackoff struct {
+	failures map[state.View]*refreshFailure
+	auto     map[state.View]bool // Views whose load in flight was started by auto-refresh
+}
+
+// due reports whether auto-refresh may reload view now.
+func (b *refreshBackoff) due(view state.View, now time.Time) bool {
+	f := b.failures[view]
+	return f == nil || (f.count < refreshPauseAfter && !now.Before(f.retryAt))
+}
+
+// startAuto marks the load of view that was just started as an auto-refresh.
+func (b *refreshBackoff) startAuto(view state.View) {
+	if b.auto == nil {
+		b.auto = make(map[state.View]bool)
+	}
+	b.auto[view] = true
+}
+
+// status describes the backoff of view for the status bar, or is empty when
+// auto-refresh of it is running normally.
+func (b *refreshBackoff) status(view state.View, now time.Time) string {
+	f := b.failures[view]
+	switch {
+	case f == nil:
+		return ""
+	case f.count >= refreshPauseAfter:
+		return "refresh paused (r)"
+	case now.Before(f.retryAt):
+		return "refresh retry in " + format.Age(f.retryAt.Sub(now))
+	}
+	return ""
+}
+
+// refreshDelay is the wait after the nth auto-refresh failure in a row: the
+// refresh interval, doubled for each failure up to refreshBackoffMax.
+func refreshDelay(n int) time.Duration {
+	delay := components.DefaultRefreshInterval
+	for i := 0; i < n && delay < refreshBackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, refreshBackoffMax)
+}
+
+// noteRefresh records the outcome of a load of view and reports whether its
+// error is worth logging. Failed auto-refreshes back off and only log when
+// the error changes; a successful load, such as a manual refresh with r,
+// resumes auto-refresh.
+func (m *Model) noteRefresh(view state.View, err error) bool {
+	b := &m.backoff
+	auto := b.auto[view]
+	delete(b.auto, view)
+	name := layoutViewNames[view]
+
+	if err == nil {
+		if f := b.failures[view]; f != nil {
+			delete(b.failures, view)
+			m.logger.Info("Auto-refresh of %s resumed", name)
+		}
+		return false
+	}
+	if !auto {
+		return true
+	}
+
+	if b.failures == nil {
+		b.failures = make(map[state.View]*refreshFailure)
+	}
+	f := b.failures[view]
+	if f == nil {
+		f = &refreshFailure{}
+		b.failures[view] = f
+	}
+	f.count++
+	f.retryAt = time.Now().Add(refreshDelay(f.count))
+	changed := f.lastErr != err.Error()
+	f.lastErr = err.Error()
+
+	if f.count >= refreshPauseAfter {
+		m.logger.Warn("Auto-refresh of %s paused after %d failures, press r to retry: %v", name, f.count, err)
+		return false
+	}
+	if !changed {
+		m.logger.Debug("Auto-refresh of %s failed %d times, next try in %s", name, f.count, format.Age(refreshDelay(f.count)))
+	}
+	return changed
+}
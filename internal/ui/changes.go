@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// firstSeen is the version of a resource when it was first listed.
+type firstSeen struct {
+	fingerprint string
+	at          time.Time
+}
+
+// changeTracker remembers what version each resource was at when it was first
+// listed in the session, so resources deployed or updated since then can be
+// flagged. Resources are keyed by ARN, which is unique across profiles and
+// regions, so nothing needs resetting when either changes.
+type changeTracker struct {
+	seen map[string]firstSeen
+}
+
+// observe records the fingerprint of a resource the first time it is seen
+// and reports whether it differs from that one later on.
+func (c *changeTracker) observe(arn, fingerprint string) bool {
+	if arn == "" || fingerprint == "" {
+		return false
+	}
+	if c.seen == nil {
+		c.seen = make(map[string]firstSeen)
+	}
+	first, ok := c.seen[arn]
+	if !ok {
+		c.seen[arn] = firstSeen{fingerprint: fingerprint, at: time.Now()}
+		return false
+	}
+	return first.fingerprint != fingerprint
+}
+
+// first returns the fingerprint a resource had when first listed and when
+// that was.
+func (c *changeTracker) first(arn string) (firstSeen, bool) {
+	first, ok := c.seen[arn]
+	return first, ok
+}
+
+// stackFingerprint identifies a stack version by its last update time.
+func stackFingerprint(updatedAt time.Time) string {
+	return updatedAt.UTC().Format(time.RFC3339Nano)
+}
+
+// stackUpdate describes a stack fingerprint as its last update time.
+func stackUpdate(fingerprint string) string {
+	t, err := time.Parse(time.RFC3339Nano, fingerprint)
+	if err != nil || t.IsZero() {
+		return "never updated"
+	}
+	return "last updated " + format.Time(t)
+}
+
+// lambdaCode describes a Lambda fingerprint by the start of the code hash.
+func lambdaCode(fingerprint string) string {
+	return "code " + truncateString(fingerprint, 12)
+}
+
+// changeRows returns a details row saying what a resource was at when it was
+// first listed, if it changed since.
+func (m *Model) changeRows(arn, fingerprint string, describe func(string) string) []components.DetailRow {
+	first, ok := m.changes.first(arn)
+	if !ok || first.fingerprint == fingerprint || fingerprint == "" {
+		return nil
+	}
+	return []components.DetailRow{{
+		Label: "Changed",
+		Value: fmt.Sprintf("was %s when first listed %s", describe(first.fingerprint), format.Time(first.at)),
+		Style: lipgloss.NewStyle().Foreground(theme.Info).Bold(true),
+	}}
+}
@@ -0,0 +1,275 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/theme"
+)
+
+// chaosTasksShown is how many of the picked tasks the kill guard lists.
+const chaosTasksShown = 8
+
+// taskChaos is the dialog that stops a share of a service's running tasks
+// at random.
+type taskChaos struct {
+	service model.Service
+	tasks   []model.Task // Running tasks
+	loading bool
+	err     error
+	input   textinput.Model
+	guard   *taskKill // Tasks picked, stopped once the service name is typed
+}
+
+// taskKill is the set of tasks a chaos run stops.
+type taskKill struct {
+	cluster string
+	service string
+	percent int
+	running int
+	tasks   []model.Task
+}
+
+// chaosTasksLoadedMsg carries the tasks of a service for the chaos dialog.
+type chaosTasksLoadedMsg struct {
+	service string
+	tasks   []model.Task
+	err     error
+}
+
+// tasksStoppedMsg carries the result of a chaos run.
+type tasksStoppedMsg struct {
+	service string
+	stopped int
+	total   int
+	err     error
+}
+
+// openChaos opens the chaos dialog of the selected service and loads its
+// running tasks.
+func (m *Model) openChaos() tea.Cmd {
+	if m.state.View != state.ViewServices || m.client == nil {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+	svc := m.selectedService()
+	if svc == nil {
+		return nil
+	}
+
+	input := textinput.New()
+	input.Placeholder = "25 (stops 25% of running tasks)"
+	input.CharLimit = 4
+	input.Width = 40
+	input.Focus()
+	m.chaos = &taskChaos{service: *svc, loading: true, input: input}
+
+	client, cluster, name := m.client, svc.ClusterARN, svc.Name
+	return tea.Batch(textinput.Blink, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		tasks, err := client.ListTasksForService(ctx, cluster, name)
+		return chaosTasksLoadedMsg{service: name, tasks: tasks, err: err}
+	})
+}
+
+// handleChaosTasksLoaded fills the chaos dialog with the running tasks, if
+// it is still open on the service.
+func (m *Model) handleChaosTasksLoaded(msg chaosTasksLoadedMsg) {
+	c := m.chaos
+	if c == nil || c.service.Name != msg.service {
+		return
+	}
+	c.loading = false
+	c.err = msg.err
+	c.tasks = nil
+	for _, t := range msg.tasks {
+		if t.LastStatus == "RUNNING" {
+			c.tasks = append(c.tasks, t)
+		}
+	}
+}
+
+// handleChaosKey handles key messages while the chaos dialog is open.
+func (m *Model) handleChaosKey(msg tea.KeyMsg) tea.Cmd {
+	c := m.chaos
+	switch msg.String() {
+	case "esc":
+		if c.guard != nil {
+			c.guard = nil
+			c.input.SetValue("")
+			c.input.Placeholder = "25 (stops 25% of running tasks)"
+			return nil
+		}
+		m.chaos = nil
+		return nil
+	case "enter":
+		return m.submitChaos()
+	}
+
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+	return cmd
+}
+
+// submitChaos picks the tasks for the percent typed in the chaos dialog,
+// then stops them once the service name is typed.
+func (m *Model) submitChaos() tea.Cmd {
+	c := m.chaos
+	value := strings.TrimSpace(c.input.Value())
+
+	if c.guard != nil {
+		if value != c.guard.service {
+			m.logger.Warn("Type %s to stop %d of its tasks", c.guard.service, len(c.guard.tasks))
+			return nil
+		}
+		kill := *c.guard
+		m.chaos = nil
+		if !m.checkActionAllowed(config.ActionWrite) {
+			return nil
+		}
+		return m.stopTasks(kill)
+	}
+
+	if c.loading || len(c.tasks) == 0 || value == "" {
+		return nil
+	}
+	percent, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+	if err != nil || percent <= 0 || percent > 100 {
+		m.logger.Warn("Invalid percent %q: use a whole number from 1 to 100", value)
+		return nil
+	}
+
+	c.guard = &taskKill{
+		cluster: c.service.ClusterARN,
+		service: c.service.Name,
+		percent: percent,
+		running: len(c.tasks),
+		tasks:   pickTasks(c.tasks, percent),
+	}
+	c.input.SetValue("")
+	c.input.Placeholder = c.service.Name
+	return nil
+}
+
+// pickTasks returns percent of the tasks, rounded up, in random order. At
+// least one task is picked.
+func pickTasks(tasks []model.Task, percent int) []model.Task {
+	n := max((len(tasks)*percent+99)/100, 1)
+	picked := make([]model.Task, 0, n)
+	for _, i := range rand.Perm(len(tasks))[:n] {
+		picked = append(picked, tasks[i])
+	}
+	return picked
+}
+
+// stopTasks stops the tasks of a chaos run.
+func (m *Model) stopTasks(kill taskKill) tea.Cmd {
+	m.logger.Info("Stopping %d of %d running tasks of %s (%d%%)", len(kill.tasks), kill.running, kill.service, kill.percent)
+	arns := make([]string, len(kill.tasks))
+	for i, t := range kill.tasks {
+		arns[i] = t.TaskARN
+	}
+	reason := fmt.Sprintf("vaws chaos: stopping %d%% of %s tasks", kill.percent, kill.service)
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+		stopped, err := client.StopTasks(ctx, kill.cluster, arns, reason)
+		return tasksStoppedMsg{service: kill.service, stopped: stopped, total: len(arns), err: err}
+	}
+}
+
+// handleTasksStopped logs the result of a chaos run and reloads the
+// services, so their running counts show the tasks being replaced.
+func (m *Model) handleTasksStopped(msg tasksStoppedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Error("Stopped %d of %d tasks of %s: %v", msg.stopped, msg.total, msg.service, msg.err)
+	} else {
+		m.logger.Info("Stopped %d tasks of %s; ECS starts replacements to meet the desired count", msg.stopped, msg.service)
+	}
+	if m.state.View != state.ViewServices {
+		return nil
+	}
+	return m.refreshInPlace(m.serviceList, m.reloadServices)
+}
+
+// renderChaosDialog renders the chaos dialog: the running tasks and the
+// percent input, or the tasks picked and the name guard.
+func (m *Model) renderChaosDialog() string {
+	c := m.chaos
+	dialogWidth := 70
+	if m.width < 80 {
+		dialogWidth = m.width - 10
+		if dialogWidth < 40 {
+			dialogWidth = 40
+		}
+	}
+
+	border := theme.BorderFocus
+	if c.guard != nil {
+		border = theme.Error
+	}
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(border).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Stop tasks: " + truncateString(c.service.Name, dialogWidth-19))
+
+	switch {
+	case c.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Loading tasks..."))
+	case c.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(c.err.Error(), dialogWidth-6)) + "\n\n" + hintStyle.Render("esc to close"))
+	case len(c.tasks) == 0:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("No running tasks") + "\n\n" + hintStyle.Render("esc to close"))
+	}
+
+	if g := c.guard; g != nil {
+		warn := lipgloss.NewStyle().Foreground(theme.Error).Bold(true)
+		var lines []string
+		for i, t := range g.tasks {
+			if i == chaosTasksShown {
+				lines = append(lines, s.Muted.Render(fmt.Sprintf("  and %d more", len(g.tasks)-i)))
+				break
+			}
+			lines = append(lines, "  "+t.TaskID+"  "+s.Muted.Render(valueOrDash(t.PrivateIP)))
+		}
+		content := title + "\n\n" +
+			warn.Render(fmt.Sprintf("Stop %d of %d running tasks (%d%%), picked at random", len(g.tasks), g.running, g.percent)) + "\n\n" +
+			strings.Join(lines, "\n") + "\n\n" +
+			"Type " + g.service + " to confirm: " + c.input.View() + "\n\n" +
+			hintStyle.Render("esc to go back")
+		return dialogStyle.Render(content)
+	}
+
+	content := title + "\n\n" +
+		fmt.Sprintf("%d running tasks · desired %d", len(c.tasks), c.service.DesiredCount) + "\n\n" +
+		"Percent: " + c.input.View() + "\n\n" +
+		hintStyle.Render("Rounds up, at least one task · enter to pick tasks · esc")
+	return dialogStyle.Render(content)
+}
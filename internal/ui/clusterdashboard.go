@@ -0,0 +1,252 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// clusterUsageBarWidth is the width of the CPU and memory bars of the
+// cluster dashboard.
+const clusterUsageBarWidth = 20
+
+// clusterDashboard is the dialog summing up the services of a cluster.
+type clusterDashboard struct {
+	cluster   model.Cluster
+	dashboard *model.ClusterDashboard
+	loading   bool
+	err       error
+}
+
+// clusterDashboardLoadedMsg carries the summary of a cluster.
+type clusterDashboardLoadedMsg struct {
+	clusterARN string
+	dashboard  *model.ClusterDashboard
+	err        error
+}
+
+// openClusterDashboard opens the dashboard of the cluster under the cursor,
+// or of the cluster whose services are shown.
+func (m *Model) openClusterDashboard() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	var cluster *model.Cluster
+	switch m.state.View {
+	case state.ViewClusters:
+		if item := m.clustersList.SelectedItem(); item != nil {
+			for i := range m.state.Clusters {
+				if m.state.Clusters[i].Name == item.ID {
+					cluster = &m.state.Clusters[i]
+				}
+			}
+		}
+	case state.ViewServices:
+		cluster = m.state.SelectedCluster
+	}
+	if cluster == nil {
+		m.logger.Warn("Select a cluster or open its services first")
+		return nil
+	}
+	m.clusterDash = &clusterDashboard{cluster: *cluster}
+	return m.loadClusterDashboard()
+}
+
+// loadClusterDashboard sums up the cluster of the dashboard in the
+// background.
+func (m *Model) loadClusterDashboard() tea.Cmd {
+	cd := m.clusterDash
+	cd.loading = true
+	cd.err = nil
+	client, cluster := m.client, cd.cluster
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		dashboard, err := client.GetClusterDashboard(ctx, cluster)
+		return clusterDashboardLoadedMsg{clusterARN: cluster.ARN, dashboard: dashboard, err: err}
+	}
+}
+
+// handleClusterDashboardLoaded fills the dashboard if it is still open on
+// the cluster.
+func (m *Model) handleClusterDashboardLoaded(msg clusterDashboardLoadedMsg) {
+	if msg.err != nil {
+		m.logger.Error("Failed to sum up cluster: %v", msg.err)
+	}
+	cd := m.clusterDash
+	if cd == nil || cd.cluster.ARN != msg.clusterARN {
+		return
+	}
+	cd.loading = false
+	cd.err = msg.err
+	cd.dashboard = msg.dashboard
+}
+
+// handleClusterDashboardKey handles key messages while the cluster
+// dashboard is open. Enter opens the services of the cluster.
+func (m *Model) handleClusterDashboardKey(msg tea.KeyMsg) tea.Cmd {
+	cd := m.clusterDash
+	switch msg.String() {
+	case "esc", "q":
+		m.clusterDash = nil
+	case "r":
+		if !cd.loading {
+			return m.loadClusterDashboard()
+		}
+	case "enter":
+		m.clusterDash = nil
+		if m.state.View == state.ViewServices {
+			return nil
+		}
+		for i := range m.state.Clusters {
+			if m.state.Clusters[i].ARN == cd.cluster.ARN {
+				m.state.SelectCluster(&m.state.Clusters[i])
+				m.state.FilterText = ""
+				m.filterInput.SetValue("")
+				return m.loadServicesForCluster()
+			}
+		}
+	}
+	return nil
+}
+
+// usageBar draws a share of the cluster in percent as a bar, or blanks when
+// it is unknown.
+func usageBar(percent float64) string {
+	if percent < 0 {
+		return strings.Repeat(" ", clusterUsageBarWidth)
+	}
+	filled := min(int(percent/100*clusterUsageBarWidth+0.5), clusterUsageBarWidth)
+	return theme.Symbol(strings.Repeat("█", filled)+strings.Repeat("░", clusterUsageBarWidth-filled),
+		"["+strings.Repeat("#", filled)+strings.Repeat("-", clusterUsageBarWidth-filled)+"]")
+}
+
+// usagePercent renders a share of the cluster, e.g. " 45%", or "n/a".
+func usagePercent(percent float64) string {
+	if percent < 0 {
+		return " n/a"
+	}
+	return fmt.Sprintf("%3.0f%%", percent)
+}
+
+// renderClusterDashboard renders the task counts, usage, failing and recently
+// updated services of the cluster.
+func (m *Model) renderClusterDashboard() string {
+	cd := m.clusterDash
+	dialogWidth := 80
+	if m.width < 90 {
+		dialogWidth = max(m.width-10, 40)
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Cluster: " + truncateString(cd.cluster.Name, dialogWidth-16))
+
+	switch {
+	case cd.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Summing up the services and their metrics..."))
+	case cd.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(cd.err.Error(), dialogWidth-6)) + "\n\n" + hintStyle.Render("r to retry · esc to close"))
+	}
+
+	d := cd.dashboard
+	now := time.Now()
+	var lines []string
+
+	running := s.StatusHealthy
+	if d.Running < d.Desired {
+		running = s.StatusWarning
+	}
+	lines = append(lines, fmt.Sprintf("Services  %d", d.Services))
+	lines = append(lines, "Tasks     "+running.Render(fmt.Sprintf("%d/%d running", d.Running, d.Desired))+
+		s.Muted.Render(fmt.Sprintf(" · %d pending", d.Pending)))
+	lines = append(lines, "")
+
+	for _, u := range []struct {
+		name  string
+		usage model.ClusterUsage
+	}{{"CPU", d.CPU}, {"Memory", d.Memory}} {
+		lines = append(lines, fmt.Sprintf("%-8s  reserved %s %s", u.name, usagePercent(u.usage.Reserved), usageBar(u.usage.Reserved)))
+		lines = append(lines, fmt.Sprintf("%-8s  used     %s %s", "", usagePercent(u.usage.Used), usageBar(u.usage.Used)))
+	}
+	if d.MetricsAt.IsZero() {
+		lines = append(lines, s.Muted.Render("No ECS metrics in the last 15 minutes"))
+	} else {
+		lines = append(lines, s.Muted.Render("Metrics from "+format.Relative(now.Sub(d.MetricsAt))))
+	}
+	lines = append(lines, "")
+
+	if len(d.Failing) == 0 {
+		lines = append(lines, labelStyle.Render("Failing deployments")+" "+s.StatusHealthy.Render("none"))
+	} else {
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("Failing deployments (%d)", len(d.Failing))))
+		for _, svc := range d.Failing {
+			name := truncateString(svc.Name, 40)
+			line := "  " + s.StatusError.Render(name)
+			if reason := failingReason(svc); reason != "" {
+				line += s.Muted.Render("  " + truncateString(reason, dialogWidth-12-len(name)))
+			}
+			lines = append(lines, line)
+		}
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, labelStyle.Render("Updated last"))
+	if len(d.Recent) == 0 {
+		lines = append(lines, s.Muted.Render("  No services"))
+	}
+	for _, svc := range d.Recent {
+		counts := fmt.Sprintf("%d/%d", svc.RunningCount, svc.DesiredCount)
+		style := s.StatusHealthy
+		if !svc.IsHealthy() {
+			style = s.StatusWarning
+		}
+		lines = append(lines, fmt.Sprintf("  %-40s %s  %s", truncateString(svc.Name, 40), style.Render(fmt.Sprintf("%-7s", counts)),
+			s.Muted.Render(format.Relative(now.Sub(svc.LastUpdated())))))
+	}
+
+	hint := "enter services · r reload · esc"
+	if m.state.View == state.ViewServices {
+		hint = "r reload · esc"
+	}
+	content := title + "\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		hintStyle.Render(hint)
+	return dialogStyle.Render(content)
+}
+
+// failingReason describes why a service's deployment fails, e.g. its
+// rollout reason or how many tasks failed to start.
+func failingReason(svc model.Service) string {
+	for _, d := range svc.Deployments {
+		switch {
+		case d.RolloutState == "FAILED" && d.RolloutReason != "":
+			return d.RolloutReason
+		case d.FailedTasks > 0:
+			return fmt.Sprintf("%d tasks failed to start", d.FailedTasks)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,360 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// tableColumns lists the columns the column chooser offers for each kind of
+// resource, keyed as in the config and the highlight rules.
+var tableColumns = map[string][]components.Column{
+	"services": {
+		{Key: "cluster", Title: "CLUSTER", Width: 20},
+		{Key: "status", Title: "STATUS", Width: 8},
+		{Key: "running", Title: "RUNNING", Width: 7, Right: true},
+		{Key: "desired", Title: "DESIRED", Width: 7, Right: true},
+		{Key: "pending", Title: "PENDING", Width: 7, Right: true},
+		{Key: "launch_type", Title: "LAUNCH", Width: 8},
+		{Key: "task_definition", Title: "TASK DEF", Width: 24},
+		{Key: "terraform", Title: "TERRAFORM", Width: 32},
+	},
+	"lambda": {
+		{Key: "runtime", Title: "RUNTIME", Width: 12},
+		{Key: "handler", Title: "HANDLER", Width: 24},
+		{Key: "memory", Title: "MEMORY", Width: 8, Right: true},
+		{Key: "timeout", Title: "TIMEOUT", Width: 8, Right: true},
+		{Key: "code_size", Title: "CODE", Width: 9, Right: true},
+		{Key: "state", Title: "STATE", Width: 8},
+		{Key: "package_type", Title: "PACKAGE", Width: 7},
+		{Key: "terraform", Title: "TERRAFORM", Width: 32},
+	},
+	"queues": {
+		{Key: "type", Title: "TYPE", Width: 8},
+		{Key: "messages", Title: "MESSAGES", Width: 10, Right: true},
+		{Key: "in_flight", Title: "IN FLIGHT", Width: 12, Right: true},
+		{Key: "visibility", Title: "VISIBILITY", Width: 10, Right: true},
+		{Key: "retention", Title: "RETENTION", Width: 9, Right: true},
+		{Key: "delay", Title: "DELAY", Width: 7, Right: true},
+		{Key: "max_receives", Title: "MAX RECV", Width: 8, Right: true},
+		{Key: "terraform", Title: "TERRAFORM", Width: 32},
+	},
+	"tasks": {
+		{Key: "launch_type", Title: "LAUNCH", Width: 8},
+		{Key: "zone", Title: "ZONE", Width: 11},
+		{Key: "started", Title: "STARTED", Width: 8},
+		{Key: "health", Title: "HEALTHY", Width: 7},
+		{Key: "cpu", Title: "CPU", Width: 5, Right: true},
+		{Key: "memory", Title: "MEMORY", Width: 6, Right: true},
+		{Key: "task_definition", Title: "TASK DEF", Width: 24},
+	},
+}
+
+// defaultColumns are the columns of each kind of resource until others are
+// chosen.
+var defaultColumns = map[string][]string{
+	"services": nil,
+	"lambda":   nil,
+	"queues":   {"messages", "in_flight"},
+	"tasks":    {"launch_type", "zone", "started", "health"},
+}
+
+// columnKindTitles name the kinds of resource on the column chooser.
+var columnKindTitles = map[string]string{
+	"services": "ECS services",
+	"lambda":   "Lambda functions",
+	"queues":   "SQS queues",
+	"tasks":    "ECS tasks",
+}
+
+// columnChooser is the dialog picking and ordering the columns of a kind of
+// resource.
+type columnChooser struct {
+	kind    string
+	options []components.Column // Every column of the kind, the shown ones first in order
+	shown   map[string]bool
+	cursor  int
+	reset   bool // Defaults were restored and nothing changed since
+}
+
+// columnKind returns the kind of resource of the current view's table, or ""
+// if it has no columns to choose.
+func (m *Model) columnKind() string {
+	switch m.state.View {
+	case state.ViewServices:
+		return "services"
+	case state.ViewLambda:
+		return "lambda"
+	case state.ViewSQS:
+		return "queues"
+	case state.ViewTasks:
+		return "tasks"
+	}
+	return ""
+}
+
+// columnKeys returns the keys of the columns chosen for kind, or its default
+// ones.
+func (m *Model) columnKeys(kind string) []string {
+	if m.cfg != nil {
+		if keys, ok := m.cfg.GetColumns(kind); ok {
+			return keys
+		}
+	}
+	return defaultColumns[kind]
+}
+
+// columnsFor returns the columns shown for kind, in order. Unknown keys in the
+// config are skipped; loadColumns warns about them.
+func (m *Model) columnsFor(kind string) []components.Column {
+	var columns []components.Column
+	for _, key := range m.columnKeys(kind) {
+		if i := slices.IndexFunc(tableColumns[kind], func(c components.Column) bool { return c.Key == key }); i >= 0 {
+			columns = append(columns, tableColumns[kind][i])
+		}
+	}
+	return columns
+}
+
+// loadColumns warns about the columns of the config that can't be shown.
+func (m *Model) loadColumns() {
+	if m.cfg == nil {
+		return
+	}
+	for kind, keys := range m.cfg.Columns {
+		options, ok := tableColumns[kind]
+		if !ok {
+			m.logger.Warn("Unknown resource %q in columns (valid: services, lambda, queues, tasks)", kind)
+			continue
+		}
+		for _, key := range keys {
+			if !slices.ContainsFunc(options, func(c components.Column) bool { return c.Key == key }) {
+				m.logger.Warn("Ignoring column %q of %s: unknown field (valid: %s)", key, kind, strings.Join(columnOptionKeys(options), ", "))
+			}
+		}
+	}
+}
+
+// columnOptionKeys returns the keys of columns.
+func columnOptionKeys(columns []components.Column) []string {
+	keys := make([]string, len(columns))
+	for i, c := range columns {
+		keys[i] = c.Key
+	}
+	return keys
+}
+
+// serviceCells returns the values of the service columns.
+func serviceCells(s model.Service) map[string]string {
+	cells := serviceFields(s)
+	// Family and revision are what tell task definitions apart
+	if i := strings.LastIndex(s.TaskDefinition, "/"); i >= 0 {
+		cells["task_definition"] = s.TaskDefinition[i+1:]
+	}
+	return cells
+}
+
+// functionCells returns the values of the Lambda function columns.
+func functionCells(fn model.Function) map[string]string {
+	cells := functionFields(fn)
+	cells["memory"] = fmt.Sprintf("%d MB", fn.MemorySize)
+	cells["timeout"] = formatDuration(fn.Timeout)
+	cells["code_size"] = formatBytes(fn.CodeSize)
+	return cells
+}
+
+// queueCells returns the values of the SQS queue columns.
+func queueCells(q model.Queue) map[string]string {
+	cells := queueFields(q)
+	cells["messages"] = format.Count(int64(q.ApproximateMessageCount))
+	cells["in_flight"] = format.Count(int64(q.ApproximateInFlight))
+	cells["visibility"] = formatDuration(q.VisibilityTimeout)
+	cells["retention"] = formatDuration(q.MessageRetentionPeriod)
+	cells["delay"] = formatDuration(q.DelaySeconds)
+	if q.MaxReceiveCount == 0 {
+		cells["max_receives"] = ""
+	}
+	return cells
+}
+
+// taskCells returns the values of the task columns.
+func taskCells(t model.Task, now time.Time) map[string]string {
+	cells := map[string]string{
+		"launch_type":     t.LaunchType,
+		"zone":            t.AvailabilityZone,
+		"health":          taskHealthCell(t),
+		"cpu":             t.CPU,
+		"memory":          t.Memory,
+		"task_definition": shortTaskDefinition(t.TaskDefinitionARN),
+	}
+	if !t.StartedAt.IsZero() {
+		cells["started"] = format.Relative(now.Sub(t.StartedAt))
+	}
+	return cells
+}
+
+// openColumnChooser opens the column chooser of the current view's table.
+func (m *Model) openColumnChooser() tea.Cmd {
+	kind := m.columnKind()
+	if kind == "" {
+		m.logger.Warn("Columns can be chosen for ECS services and tasks, Lambda functions and SQS queues")
+		return nil
+	}
+	if m.cfg == nil {
+		m.logger.Warn("No config file to save columns in")
+		return nil
+	}
+
+	cc := &columnChooser{kind: kind, shown: make(map[string]bool)}
+	cc.options = m.columnsFor(kind)
+	for _, c := range cc.options {
+		cc.shown[c.Key] = true
+	}
+	for _, c := range tableColumns[kind] {
+		if !cc.shown[c.Key] {
+			cc.options = append(cc.options, c)
+		}
+	}
+	m.columns = cc
+	return nil
+}
+
+// handleColumnChooserKey handles key messages while the column chooser is
+// open: space shows or hides the column under the cursor, K and J move it.
+func (m *Model) handleColumnChooserKey(msg tea.KeyMsg) tea.Cmd {
+	cc := m.columns
+	switch msg.String() {
+	case "esc", "q":
+		m.columns = nil
+	case "up", "k":
+		cc.cursor = max(0, cc.cursor-1)
+	case "down", "j":
+		cc.cursor = min(len(cc.options)-1, cc.cursor+1)
+	case " ":
+		key := cc.options[cc.cursor].Key
+		cc.shown[key] = !cc.shown[key]
+		cc.reset = false
+	case "K", "shift+up":
+		if cc.cursor > 0 {
+			cc.options[cc.cursor-1], cc.options[cc.cursor] = cc.options[cc.cursor], cc.options[cc.cursor-1]
+			cc.cursor--
+			cc.reset = false
+		}
+	case "J", "shift+down":
+		if cc.cursor < len(cc.options)-1 {
+			cc.options[cc.cursor+1], cc.options[cc.cursor] = cc.options[cc.cursor], cc.options[cc.cursor+1]
+			cc.cursor++
+			cc.reset = false
+		}
+	case "D":
+		cc.options = cc.options[:0]
+		clear(cc.shown)
+		for _, key := range defaultColumns[cc.kind] {
+			cc.shown[key] = true
+		}
+		for _, c := range tableColumns[cc.kind] {
+			if cc.shown[c.Key] {
+				cc.options = append(cc.options, c)
+			}
+		}
+		for _, c := range tableColumns[cc.kind] {
+			if !cc.shown[c.Key] {
+				cc.options = append(cc.options, c)
+			}
+		}
+		cc.cursor = 0
+		cc.reset = true
+	case "enter":
+		m.saveColumns()
+	}
+	return nil
+}
+
+// saveColumns saves the columns of the chooser to the config and shows them.
+func (m *Model) saveColumns() {
+	cc := m.columns
+	m.columns = nil
+
+	if cc.reset {
+		m.cfg.SetColumns(cc.kind, nil)
+	} else {
+		keys := []string{}
+		for _, c := range cc.options {
+			if cc.shown[c.Key] {
+				keys = append(keys, c.Key)
+			}
+		}
+		m.cfg.SetColumns(cc.kind, keys)
+	}
+	if err := m.cfg.Save(); err != nil {
+		m.logger.Warn("Failed to save columns: %v", err)
+	}
+	m.updateCurrentList()
+}
+
+// renderColumnChooser renders the columns of the chooser, the shown ones
+// numbered in order.
+func (m *Model) renderColumnChooser() string {
+	cc := m.columns
+	dialogWidth := 56
+	if m.width < 66 {
+		dialogWidth = max(m.width-10, 40)
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	var lines []string
+	n := 0
+	for i, c := range cc.options {
+		cursor := "  "
+		if i == cc.cursor {
+			cursor = theme.Symbol("▸ ", "> ")
+		}
+		check, position := "[ ]", "  "
+		if cc.shown[c.Key] {
+			n++
+			check, position = "[x]", fmt.Sprintf("%-2s", strconv.Itoa(n))
+		}
+		line := fmt.Sprintf("%s%s %s %-12s", cursor, check, position, c.Title)
+		if i == cc.cursor {
+			line = labelStyle.Render(line)
+		}
+		lines = append(lines, line+s.Muted.Render(" "+c.Key))
+	}
+	if n == 0 {
+		lines = append(lines, "", s.Muted.Render("Only the name is shown"))
+	}
+	if cc.reset {
+		lines = append(lines, "", s.Muted.Render("Default columns restored"))
+	}
+
+	content := labelStyle.Render("Columns: "+columnKindTitles[cc.kind]) + "\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		hintStyle.Render("space show/hide · K/J move · D defaults") + "\n" +
+		hintStyle.Render("enter to save · esc to cancel")
+	return dialogStyle.Render(content)
+}
@@ -4,6 +4,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"vaws/internal/state"
+	"vaws/internal/tunnel"
 	"vaws/internal/ui/components"
 )
 
@@ -12,6 +13,9 @@ func (m *Model) executeCommand(result *components.CommandResult) tea.Cmd {
 	if result == nil {
 		return nil
 	}
+	if result.Resource != nil {
+		return m.openPaletteResource(result.Resource)
+	}
 
 	m.logger.Debug("Executing command: %s", result.Command)
 
@@ -38,17 +42,174 @@ func (m *Model) executeCommand(result *components.CommandResult) tea.Cmd {
 	case "dynamodb", "ddb", "tables":
 		return m.switchToDynamoDB()
 
+	case "apprunner":
+		return m.switchToAppRunner()
+
+	case "firehose":
+		return m.switchToFirehose()
+
+	case "cognito":
+		return m.switchToCognito()
+
+	case "msk":
+		return m.switchToMSK()
+
+	case "mq":
+		return m.switchToMQ()
+
+	case "databases":
+		return m.switchToDatabases()
+
+	case "ec2":
+		return m.switchToEC2()
+
+	case "efs":
+		return m.switchToEFS()
+
+	case "schedules":
+		return m.switchToSchedules()
+
+	case "secrets":
+		return m.switchToSecrets()
+
+	case "loggroups":
+		return m.switchToLogGroups()
+
+	case "ses":
+		return m.switchToSES()
+
+	case "resources":
+		if len(result.Args) > 0 {
+			return m.openResourceType(result.Args[0])
+		}
+		return m.switchToResourceTypes()
+
 	// Other views
+	case "tasks":
+		return m.openServiceTasks()
+
 	case "tunnels":
 		m.showTunnelsView()
 		return nil
 
+	case "export":
+		path := ""
+		if len(result.Args) > 0 {
+			path = result.Args[0]
+		}
+		switch m.state.View {
+		case state.ViewLambdaRuntimes:
+			m.exportRuntimes(path)
+			return nil
+		case state.ViewCloudWatchLogs:
+			m.toggleLogExport(path)
+			return nil
+		}
+		return m.handleExportTunnel(path)
+
+	case "link":
+		m.copyLink()
+		return nil
+
+	case "note":
+		return m.handleNote(result.Args)
+
+	case "import":
+		path := ""
+		if len(result.Args) > 0 {
+			path = result.Args[0]
+		}
+		return m.handleImportTunnel(path)
+
+	case "health":
+		return m.openHealth()
+
+	case "costs":
+		return m.openCosts()
+
+	case "cluster":
+		return m.openClusterDashboard()
+
+	case "columns":
+		return m.openColumnChooser()
+
+	case "runtimes":
+		return m.openRuntimes()
+
+	case "images":
+		return m.openImages()
+
+	case "alerts":
+		return m.openAlerts()
+
+	case "stats":
+		return m.openStats()
+
+	case "timeline":
+		return m.openTimeline()
+
+	case "watch":
+		return m.handleWatchCommand(result.Args)
+
+	case "macro":
+		return m.handleMacroCommand(result.Args)
+
+	case "query":
+		return m.handleQueryCommand(result.Args)
+
+	case "monitor":
+		return m.handleMonitorCommand(result.Args)
+
+	case "dlqexport":
+		return m.handleDLQExportCommand()
+
+	case "failures":
+		return m.openQueueFailures()
+
+	case "perf":
+		return m.openLambdaPerformance()
+
+	case "report":
+		return m.handleReportCommand(result.Args)
+
+	case "terraform":
+		return m.handleTerraformCommand()
+
+	case "group":
+		m.handleGroupCommand(result.Args)
+		return nil
+
 	// Settings
 	case "region":
 		// Show region picker - save current view to return to it
-		m.viewBeforeRegionSelect = m.state.View
-		m.regionSelector.SetCurrentRegion(m.state.Region)
-		m.state.View = state.ViewRegionSelect
+		return m.openRegionSelect()
+
+	case "env":
+		return m.handleEnvCommand(result.Args)
+
+	case "tag":
+		m.handleTagCommand(result.Args)
+		return nil
+
+	case "accounts":
+		return m.openAccounts()
+
+	case "time":
+		return m.handleTimeCommand(result.Args)
+
+	case "numbers":
+		return m.handleNumbersCommand(result.Args)
+
+	case "https":
+		if m.apiGWManager == nil {
+			return nil
+		}
+		m.apiGWManager.SetTLS(!m.apiGWManager.TLSEnabled())
+		if m.apiGWManager.TLSEnabled() {
+			m.logger.Info("New API Gateway proxies will serve HTTPS (trust %s)", tunnel.LocalCACertPath())
+		} else {
+			m.logger.Info("New API Gateway proxies will serve plain HTTP")
+		}
 		return nil
 
 	// Actions
@@ -161,3 +322,215 @@ func (m *Model) switchToStacks() tea.Cmd {
 	m.updateStacksList()
 	return nil
 }
+
+// switchToAppRunner switches to the App Runner services view.
+func (m *Model) switchToAppRunner() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewAppRunner
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("7")
+	// Only load if not already loaded
+	if len(m.state.AppRunnerServices) == 0 && !m.state.AppRunnerLoading {
+		return m.loadAppRunnerServices()
+	}
+	m.updateAppRunnerList()
+	return nil
+}
+
+// switchToFirehose switches to the Firehose delivery streams view.
+func (m *Model) switchToFirehose() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewFirehose
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.DeliveryStreams) == 0 && !m.state.FirehoseLoading {
+		return m.loadDeliveryStreams()
+	}
+	m.updateFirehoseList()
+	return nil
+}
+
+// switchToCognito switches to the Cognito user pools view.
+func (m *Model) switchToCognito() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewCognito
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.UserPools) == 0 && !m.state.UserPoolsLoading {
+		return m.loadUserPools()
+	}
+	m.updateUserPoolList()
+	return nil
+}
+
+// switchToResourceTypes switches to the list of resource types configured for
+// Cloud Control.
+func (m *Model) switchToResourceTypes() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewResourceTypes
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	if m.cfg != nil {
+		m.state.ResourceTypes = m.cfg.GetResourceTypes(m.state.Profile)
+	}
+	m.updateResourceTypeList()
+	return nil
+}
+
+// openResourceType lists the resources of a Cloud Control resource type.
+func (m *Model) openResourceType(typeName string) tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewCloudResources
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Keep resources cached when reopening the same type
+	if typeName == m.state.CloudResourceType && len(m.state.CloudResources) > 0 {
+		m.updateCloudResourceList()
+		return nil
+	}
+	m.state.ClearCloudResources()
+	m.state.CloudResourceType = typeName
+	return m.loadCloudResources()
+}
+
+// switchToDatabases switches to the databases view.
+func (m *Model) switchToDatabases() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewDatabases
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.Databases) == 0 && !m.state.DatabasesLoading {
+		return m.loadDatabases()
+	}
+	m.updateDatabaseList()
+	return nil
+}
+
+// switchToEC2 switches to the EC2 instances view.
+func (m *Model) switchToEC2() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewEC2
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.Instances) == 0 && !m.state.InstancesLoading {
+		return m.loadInstances()
+	}
+	m.updateInstanceList()
+	return nil
+}
+
+// switchToMQ switches to the Amazon MQ brokers view.
+func (m *Model) switchToMQ() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewMQ
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.MQBrokers) == 0 && !m.state.MQLoading {
+		return m.loadMQBrokers()
+	}
+	m.updateMQList()
+	return nil
+}
+
+// switchToEFS switches to the EFS file systems view.
+func (m *Model) switchToEFS() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewEFS
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.EFSFileSystems) == 0 && !m.state.EFSLoading {
+		return m.loadEFSFileSystems()
+	}
+	m.updateEFSList()
+	return nil
+}
+
+// switchToSchedules switches to the EventBridge Scheduler schedules view.
+func (m *Model) switchToSchedules() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewSchedules
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.Schedules) == 0 && !m.state.SchedulesLoading {
+		return m.loadSchedules()
+	}
+	m.updateSchedulesList()
+	return nil
+}
+
+// switchToSecrets switches to the Secrets Manager secrets view.
+func (m *Model) switchToSecrets() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewSecrets
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.Secrets) == 0 && !m.state.SecretsLoading {
+		return m.loadSecrets()
+	}
+	m.updateSecretsList()
+	return nil
+}
+
+// switchToLogGroups switches to the CloudWatch Logs log groups view.
+func (m *Model) switchToLogGroups() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewLogGroups
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.LogGroups) == 0 && !m.state.LogGroupsLoading {
+		return m.loadLogGroups()
+	}
+	m.updateLogGroupsList()
+	return nil
+}
+
+// switchToMSK switches to the MSK clusters view.
+func (m *Model) switchToMSK() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewMSK
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if len(m.state.MSKClusters) == 0 && !m.state.MSKLoading {
+		return m.loadMSKClusters()
+	}
+	m.updateMSKList()
+	return nil
+}
+
+// switchToSES switches to the SES view.
+func (m *Model) switchToSES() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewSES
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	// Only load if not already loaded
+	if m.state.SESAccount == nil && !m.state.SESLoading {
+		return m.loadSES()
+	}
+	m.updateSESList()
+	return nil
+}
@@ -0,0 +1,105 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/theme"
+)
+
+// clfRe matches the common log format API Gateway offers for access logs:
+// ip caller user [time] "method path protocol" status length requestId
+var clfRe = regexp.MustCompile(`^(\S+) (\S+) (\S+) \[[^\]]*\] "(\S+) (\S+)[^"]*" (\d{3}) \S+`)
+
+// accessLogRecord is the fields of an access log line shown as columns.
+type accessLogRecord struct {
+	status  string
+	latency string // Milliseconds, "-" if the format has none
+	method  string
+	path    string
+	caller  string
+}
+
+// parseAccessLog reads an access log line in the common log format or as
+// JSON with the usual $context names, such as status, responseLatency and
+// routeKey. Other formats, XML and CSV, are shown as they are.
+func parseAccessLog(line string) (accessLogRecord, bool) {
+	if m := clfRe.FindStringSubmatch(line); m != nil {
+		caller := m[2]
+		if caller == "-" {
+			caller = m[1]
+		}
+		return accessLogRecord{status: m[6], latency: "-", method: m[4], path: m[5], caller: caller}, true
+	}
+
+	var fields map[string]any
+	if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &fields) != nil {
+		return accessLogRecord{}, false
+	}
+	get := func(names ...string) string {
+		for _, name := range names {
+			for k, v := range fields {
+				if strings.EqualFold(k, name) && v != nil && fmt.Sprint(v) != "-" && fmt.Sprint(v) != "" {
+					return fmt.Sprint(v)
+				}
+			}
+		}
+		return ""
+	}
+
+	r := accessLogRecord{
+		status:  get("status", "statusCode"),
+		latency: get("responseLatency", "latency", "integrationLatency"),
+		method:  get("httpMethod", "method"),
+		path:    get("path", "resourcePath", "resource"),
+		caller:  get("caller", "user", "userArn", "ip", "sourceIp"),
+	}
+	if route := get("routeKey"); r.path == "" && route != "" {
+		r.method, r.path, _ = strings.Cut(route, " ")
+		if r.path == "" {
+			r.method, r.path = "", route
+		}
+	}
+	if r.status == "" {
+		return accessLogRecord{}, false
+	}
+	if r.latency == "" {
+		r.latency = "-"
+	}
+	return r, true
+}
+
+// renderAccessLog renders an access log line as aligned status, latency,
+// method, path and caller columns, or false if it can't be parsed.
+func renderAccessLog(line string, width int) (string, bool) {
+	r, ok := parseAccessLog(line)
+	if !ok {
+		return "", false
+	}
+
+	var statusStyle lipgloss.Style
+	switch r.status[0] {
+	case '2', '3':
+		statusStyle = lipgloss.NewStyle().Foreground(theme.Success)
+	case '4':
+		statusStyle = lipgloss.NewStyle().Foreground(theme.Warning)
+	default:
+		statusStyle = lipgloss.NewStyle().Foreground(theme.Error)
+	}
+	latency := r.latency
+	if latency != "-" {
+		latency += "ms"
+	}
+	pathWidth := max(10, width-42)
+	return fmt.Sprintf("%s %8s %-7s %-*s %s",
+		statusStyle.Render(fmt.Sprintf("%-3s", r.status)),
+		latency,
+		truncate(r.method, 7),
+		pathWidth, truncate(r.path, pathWidth),
+		truncate(r.caller, 20),
+	), true
+}
@@ -39,6 +39,15 @@ type CloudWatchLogsPanel struct {
 	spinnerFrame int
 	serviceName  string
 	taskID       string
+	accessLog    bool     // Entries are API Gateway access logs, shown as columns
+	timeRange    string   // Label of the chosen time range, "" without one
+	tasks        []string // IDs of the tasks whose streams are merged, nil unless they are
+
+	// Search state
+	searchQuery   string
+	searchMatches []int                    // Indices of matching entries of the selected tab
+	searchIndex   int                      // Current match
+	searchCurrent model.CloudWatchLogEntry // Entry of the current match, kept as entries come and go
 }
 
 // NewCloudWatchLogsPanel creates a new CloudWatch logs panel.
@@ -67,6 +76,7 @@ func (p *CloudWatchLogsPanel) SetEntries(entries []model.CloudWatchLogEntry) {
 	if len(p.entries) > maxCloudWatchEntries {
 		p.entries = p.entries[len(p.entries)-maxCloudWatchEntries:]
 	}
+	p.updateSearchMatchesLocked()
 	if p.autoScroll {
 		p.scrollToBottomLocked()
 	}
@@ -80,6 +90,7 @@ func (p *CloudWatchLogsPanel) AppendEntries(entries []model.CloudWatchLogEntry)
 	if len(p.entries) > maxCloudWatchEntries {
 		p.entries = p.entries[len(p.entries)-maxCloudWatchEntries:]
 	}
+	p.updateSearchMatchesLocked()
 	if p.autoScroll {
 		p.scrollToBottomLocked()
 	}
@@ -109,6 +120,7 @@ func (p *CloudWatchLogsPanel) SelectNextTab() {
 	if len(p.containers) > 0 {
 		p.selectedTab = (p.selectedTab + 1) % len(p.containers)
 	}
+	p.updateSearchMatchesLocked()
 }
 
 // SelectPrevTab moves to previous container tab.
@@ -118,6 +130,7 @@ func (p *CloudWatchLogsPanel) SelectPrevTab() {
 	if len(p.containers) > 0 {
 		p.selectedTab = (p.selectedTab - 1 + len(p.containers)) % len(p.containers)
 	}
+	p.updateSearchMatchesLocked()
 }
 
 // SetContext sets service/task context info.
@@ -128,6 +141,14 @@ func (p *CloudWatchLogsPanel) SetContext(serviceName, taskID string) {
 	p.taskID = taskID
 }
 
+// SetAccessLog sets whether entries are API Gateway access logs, shown as
+// status, latency, method, path and caller columns where they parse.
+func (p *CloudWatchLogsPanel) SetAccessLog(accessLog bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.accessLog = accessLog
+}
+
 // SetSize sets panel dimensions.
 func (p *CloudWatchLogsPanel) SetSize(width, height int) {
 	p.mu.Lock()
@@ -136,6 +157,23 @@ func (p *CloudWatchLogsPanel) SetSize(width, height int) {
 	p.height = height
 }
 
+// SetMergedTasks merges the streams of the tasks into one tail, prefixing
+// each line with its task's ID in a color of its own, or shows a single
+// task's streams again if tasks is nil.
+func (p *CloudWatchLogsPanel) SetMergedTasks(tasks []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tasks = tasks
+}
+
+// SetRange sets the label of the time range the logs are read over, shown
+// in the header.
+func (p *CloudWatchLogsPanel) SetRange(label string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeRange = label
+}
+
 // SetStreaming sets streaming state.
 func (p *CloudWatchLogsPanel) SetStreaming(streaming bool) {
 	p.mu.Lock()
@@ -226,32 +264,56 @@ func (p *CloudWatchLogsPanel) scrollToBottomLocked() {
 }
 
 func (p *CloudWatchLogsPanel) maxScrollLocked() int {
-	// Account for header line (streaming indicator + container info) and scroll indicator
-	// Header: 1 line if streaming or has containers, else 0
-	// Footer: 1 line for scroll indicator
-	headerLines := 1 // scroll indicator at bottom
-	if p.streaming || len(p.containers) > 0 {
-		headerLines++ // streaming/container header
-	}
-	visibleLines := p.height - headerLines
-	if visibleLines < 1 {
-		visibleLines = 1
-	}
-
 	filteredCount := p.filteredEntriesCountLocked()
-	maxScroll := filteredCount - visibleLines
+	maxScroll := filteredCount - p.visibleLinesLocked()
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
 	return maxScroll
 }
 
+// visibleLinesLocked returns how many entries fit below the header and above
+// the scroll indicator.
+func (p *CloudWatchLogsPanel) visibleLinesLocked() int {
+	// Header: 1 line if streaming, searching or has containers, else 0
+	// Footer: 1 line for scroll indicator
+	headerLines := 1 // scroll indicator at bottom
+	if p.hasHeaderLocked() {
+		headerLines++ // streaming/container/search header
+	}
+	return max(p.height-headerLines, 1)
+}
+
+// hasHeaderLocked returns true if the panel shows a header line.
+func (p *CloudWatchLogsPanel) hasHeaderLocked() bool {
+	return p.streaming || len(p.containers) > 0 || p.searchQuery != "" || p.timeRange != ""
+}
+
+// filteredEntriesLocked returns the entries of the selected container, or
+// all of them if it reads the whole group.
+func (p *CloudWatchLogsPanel) filteredEntriesLocked() []model.CloudWatchLogEntry {
+	if p.tasks != nil || len(p.containers) == 0 || p.selectedTab >= len(p.containers) || p.containers[p.selectedTab].LogStreamName == "" {
+		return p.entries
+	}
+	selectedStream := p.containers[p.selectedTab].LogStreamName
+	var filtered []model.CloudWatchLogEntry
+	for _, e := range p.entries {
+		if e.LogStreamName == selectedStream {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
 func (p *CloudWatchLogsPanel) filteredEntriesCountLocked() int {
-	if len(p.containers) == 0 || p.selectedTab >= len(p.containers) {
+	if p.tasks != nil || len(p.containers) == 0 || p.selectedTab >= len(p.containers) {
 		return len(p.entries)
 	}
 
 	selectedStream := p.containers[p.selectedTab].LogStreamName
+	if selectedStream == "" {
+		return len(p.entries)
+	}
 	count := 0
 	for _, e := range p.entries {
 		if e.LogStreamName == selectedStream {
@@ -267,6 +329,117 @@ func (p *CloudWatchLogsPanel) Clear() {
 	defer p.mu.Unlock()
 	p.entries = p.entries[:0]
 	p.scroll = 0
+	p.updateSearchMatchesLocked()
+}
+
+// SetSearchQuery highlights the entries containing query, ignoring case, and
+// scrolls to the first of them.
+func (p *CloudWatchLogsPanel) SetSearchQuery(query string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.searchQuery = query
+	p.searchIndex = 0
+	p.searchCurrent = model.CloudWatchLogEntry{}
+	p.updateSearchMatchesLocked()
+	if len(p.searchMatches) > 0 {
+		p.selectMatchLocked(0)
+	}
+}
+
+// ClearSearch clears the search query and matches.
+func (p *CloudWatchLogsPanel) ClearSearch() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.searchQuery = ""
+	p.searchMatches = nil
+	p.searchIndex = 0
+	p.searchCurrent = model.CloudWatchLogEntry{}
+}
+
+// SearchQuery returns the current search query.
+func (p *CloudWatchLogsPanel) SearchQuery() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.searchQuery
+}
+
+// MatchCount returns the number of search matches.
+func (p *CloudWatchLogsPanel) MatchCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.searchMatches)
+}
+
+// NextMatch moves to the next, newer, search match.
+func (p *CloudWatchLogsPanel) NextMatch() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.searchMatches) > 0 {
+		p.selectMatchLocked((p.searchIndex + 1) % len(p.searchMatches))
+	}
+}
+
+// PrevMatch moves to the previous, older, search match.
+func (p *CloudWatchLogsPanel) PrevMatch() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.searchMatches) > 0 {
+		p.selectMatchLocked((p.searchIndex - 1 + len(p.searchMatches)) % len(p.searchMatches))
+	}
+}
+
+// selectMatchLocked makes the match at index i the current one and scrolls
+// to it. Auto-scroll is turned off so new entries don't move it out of view.
+func (p *CloudWatchLogsPanel) selectMatchLocked(i int) {
+	p.searchIndex = i
+	idx := p.searchMatches[i]
+	p.searchCurrent = p.filteredEntriesLocked()[idx]
+	p.autoScroll = false
+	if visible := p.visibleLinesLocked(); idx < p.scroll || idx >= p.scroll+visible {
+		p.scroll = max(0, min(idx-visible/2, p.maxScrollLocked()))
+	}
+}
+
+// updateSearchMatchesLocked finds the entries of the selected tab matching
+// the query, keeping the current match on its entry while it is buffered.
+func (p *CloudWatchLogsPanel) updateSearchMatchesLocked() {
+	p.searchMatches = nil
+	if p.searchQuery == "" {
+		return
+	}
+	query := strings.ToLower(p.searchQuery)
+	current := 0
+	for i, e := range p.filteredEntriesLocked() {
+		if !strings.Contains(strings.ToLower(e.Message), query) {
+			continue
+		}
+		if e == p.searchCurrent {
+			current = len(p.searchMatches)
+		}
+		p.searchMatches = append(p.searchMatches, i)
+	}
+	p.searchIndex = current
+}
+
+// highlightMatches highlights the occurrences of query in message, ignoring
+// case.
+func highlightMatches(message, query string, style lipgloss.Style) string {
+	lower := strings.ToLower(message)
+	if query == "" || len(lower) != len(message) {
+		return message
+	}
+	query = strings.ToLower(query)
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			b.WriteString(message)
+			return b.String()
+		}
+		b.WriteString(message[:i])
+		b.WriteString(style.Render(message[i : i+len(query)]))
+		message, lower = message[i+len(query):], lower[i+len(query):]
+	}
 }
 
 // TickCmd returns command for polling interval.
@@ -299,18 +472,40 @@ func (p *CloudWatchLogsPanel) View() string {
 
 	if p.streaming {
 		streamingStyle := lipgloss.NewStyle().Foreground(theme.Success)
-		spinnerChar := spinnerFrames[p.spinnerFrame]
+		spinnerChar := spinnerFrame(p.spinnerFrame)
 		headerParts = append(headerParts, streamingStyle.Render(fmt.Sprintf("%s STREAMING", spinnerChar)))
 	}
 
 	// Container tabs (if multiple containers)
-	if len(p.containers) > 1 {
+	if p.accessLog {
+		columnsStyle := lipgloss.NewStyle().Foreground(theme.TextMuted)
+		headerParts = append(headerParts, columnsStyle.Render("Access log: status, latency, method, path, caller"))
+	} else if len(p.containers) > 1 {
 		headerParts = append(headerParts, p.renderTabsLocked())
 	} else if len(p.containers) == 1 {
 		containerStyle := lipgloss.NewStyle().Foreground(theme.TextMuted)
 		headerParts = append(headerParts, containerStyle.Render("Container: "+p.containers[0].ContainerName))
 	}
 
+	if p.tasks != nil {
+		tasksStyle := lipgloss.NewStyle().Foreground(theme.TextMuted)
+		headerParts = append(headerParts, tasksStyle.Render(fmt.Sprintf("Tasks: %d merged", len(p.tasks))))
+	}
+
+	if p.timeRange != "" {
+		rangeStyle := lipgloss.NewStyle().Foreground(theme.TextMuted)
+		headerParts = append(headerParts, rangeStyle.Render("Range: "+p.timeRange))
+	}
+
+	if p.searchQuery != "" {
+		current := 0
+		if len(p.searchMatches) > 0 {
+			current = p.searchIndex + 1
+		}
+		searchStyle := lipgloss.NewStyle().Foreground(theme.Primary)
+		headerParts = append(headerParts, searchStyle.Render(fmt.Sprintf("Search: \"%s\" (%d/%d)  n/N", p.searchQuery, current, len(p.searchMatches))))
+	}
+
 	if len(headerParts) > 0 {
 		b.WriteString(strings.Join(headerParts, "  "))
 		b.WriteString("\n")
@@ -319,33 +514,14 @@ func (p *CloudWatchLogsPanel) View() string {
 	// Log entries
 	st := theme.DefaultStyles()
 
-	// Filter entries for selected container
-	var filteredEntries []model.CloudWatchLogEntry
-	if len(p.containers) > 0 && p.selectedTab < len(p.containers) {
-		selectedStream := p.containers[p.selectedTab].LogStreamName
-		for _, e := range p.entries {
-			if e.LogStreamName == selectedStream {
-				filteredEntries = append(filteredEntries, e)
-			}
-		}
-	} else {
-		filteredEntries = p.entries
-	}
+	// Filter entries for selected container, unless it reads the whole group
+	filteredEntries := p.filteredEntriesLocked()
 
 	if len(filteredEntries) == 0 {
 		b.WriteString(st.Muted.Render("No log entries. Waiting for logs..."))
 	} else {
 		// Calculate visible range
-		// Header: 1 line if streaming or has containers, else 0
-		// Footer: 1 line for scroll indicator
-		headerLines := 1 // scroll indicator
-		if p.streaming || len(p.containers) > 0 {
-			headerLines++ // streaming/container header
-		}
-		maxVisible := p.height - headerLines
-		if maxVisible < 1 {
-			maxVisible = 1
-		}
+		maxVisible := p.visibleLinesLocked()
 
 		start := p.scroll
 		end := start + maxVisible
@@ -356,13 +532,34 @@ func (p *CloudWatchLogsPanel) View() string {
 			start = len(filteredEntries)
 		}
 
-		timeStyle := st.Muted
+		// Search matches get their time and the matched text highlighted
+		matchStyle := lipgloss.NewStyle().Background(theme.PrimaryMuted)
+		currentStyle := lipgloss.NewStyle().Background(theme.Primary).Foreground(lipgloss.Color("#FFFFFF"))
+		matches := make(map[int]bool, len(p.searchMatches))
+		for _, idx := range p.searchMatches {
+			matches[idx] = true
+		}
 
 		for i := start; i < end; i++ {
 			entry := filteredEntries[i]
 			timeStr := entry.Timestamp.Format("15:04:05.000")
 			message := strings.TrimSpace(entry.Message)
 
+			timeStyle := st.Muted
+			var highlight *lipgloss.Style
+			switch {
+			case len(p.searchMatches) > 0 && p.searchMatches[p.searchIndex] == i:
+				timeStyle, highlight = currentStyle, &currentStyle
+			case matches[i]:
+				timeStyle, highlight = matchStyle, &matchStyle
+			}
+
+			// Merged tasks prefix their ID
+			if p.tasks != nil {
+				timeStr = timeStyle.Render(timeStr) + " " + p.taskPrefixLocked(entry.LogStreamName)
+				timeStyle = lipgloss.NewStyle()
+			}
+
 			// Calculate available width for message (after timestamp)
 			timestampWidth := lipgloss.Width(timeStr) + 1 // +1 for space
 			availableWidth := p.width - 6 - timestampWidth // -6 for padding
@@ -371,6 +568,16 @@ func (p *CloudWatchLogsPanel) View() string {
 				availableWidth = 20
 			}
 
+			if p.accessLog {
+				if columns, ok := renderAccessLog(message, availableWidth); ok {
+					b.WriteString(timeStyle.Render(timeStr) + " " + columns)
+					if i < end-1 {
+						b.WriteString("\n")
+					}
+					continue
+				}
+			}
+
 			// Truncate very long messages to keep logs readable
 			// Show first line, and if message is longer, add indicator
 			maxDisplayLen := availableWidth * 2 // Allow up to ~2 lines worth
@@ -380,6 +587,9 @@ func (p *CloudWatchLogsPanel) View() string {
 				truncated = true
 			}
 
+			if highlight != nil {
+				message = highlightMatches(message, p.searchQuery, *highlight)
+			}
 			line := fmt.Sprintf("%s %s", timeStyle.Render(timeStr), message)
 
 			// Add truncation indicator
@@ -414,6 +624,29 @@ func (p *CloudWatchLogsPanel) View() string {
 	return containerStyle.Render(b.String())
 }
 
+// taskColors tell the lines of merged tasks apart.
+var taskColors = []lipgloss.TerminalColor{
+	theme.Info,
+	theme.Success,
+	theme.Warning,
+	theme.Primary,
+	lipgloss.AdaptiveColor{Light: "#0E7490", Dark: "#22D3EE"},
+	lipgloss.AdaptiveColor{Light: "#BE185D", Dark: "#F472B6"},
+}
+
+// taskPrefixLocked returns the short ID of the task a stream belongs to,
+// in the color of the task. Stream names end with the task ID.
+func (p *CloudWatchLogsPanel) taskPrefixLocked(stream string) string {
+	id := stream[strings.LastIndex(stream, "/")+1:]
+	style := lipgloss.NewStyle().Foreground(theme.TextDim)
+	for i, t := range p.tasks {
+		if t == id {
+			style = lipgloss.NewStyle().Foreground(taskColors[i%len(taskColors)])
+		}
+	}
+	return style.Render(fmt.Sprintf("%-8s", id[:min(8, len(id))]))
+}
+
 func (p *CloudWatchLogsPanel) renderTabsLocked() string {
 	tabStyle := lipgloss.NewStyle().
 		Padding(0, 1).
@@ -0,0 +1,59 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Column is a column of a resource table after the name, chosen with the
+// column chooser.
+type Column struct {
+	Key   string // As in the config, e.g. launch_type
+	Title string
+	Width int
+	Right bool // Right-aligned, for numbers
+}
+
+// columnsWidth returns the width the columns take after the name, with the
+// two spaces before each.
+func columnsWidth(columns []Column) int {
+	w := 0
+	for _, c := range columns {
+		w += 2 + c.Width
+	}
+	return w
+}
+
+// renderColumnTitles renders the titles of the columns, padded to their
+// widths.
+func renderColumnTitles(columns []Column) string {
+	var b strings.Builder
+	for _, c := range columns {
+		b.WriteString("  ")
+		b.WriteString(pad(c, c.Title))
+	}
+	return b.String()
+}
+
+// renderCells renders a row's values in the columns, padded to their widths.
+// Missing values are shown as "-".
+func renderCells(columns []Column, cells map[string]string) string {
+	var b strings.Builder
+	for _, c := range columns {
+		value, ok := cells[c.Key]
+		if !ok || value == "" {
+			value = "-"
+		}
+		b.WriteString("  ")
+		b.WriteString(pad(c, truncate(value, c.Width)))
+	}
+	return b.String()
+}
+
+// pad aligns a value in its column.
+func pad(c Column, value string) string {
+	if c.Right {
+		return fmt.Sprintf("%*s", c.Width, value)
+	}
+	return fmt.Sprintf("%-*s", c.Width, value)
+}
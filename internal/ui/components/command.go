@@ -1,6 +1,7 @@
 package components
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -27,16 +28,59 @@ var AvailableCommands = []Command{
 	{Name: "apigateway", Aliases: []string{"apigw", "api", "gw", "4"}, Description: "API Gateway [4]"},
 	{Name: "stacks", Aliases: []string{"st", "stack", "cfn", "5"}, Description: "CloudFormation stacks [5]"},
 	{Name: "dynamodb", Aliases: []string{"ddb", "tables", "dynamo", "6"}, Description: "DynamoDB tables [6]"},
+	{Name: "apprunner", Aliases: []string{"ar", "runner", "7"}, Description: "App Runner services [7]"},
+	{Name: "firehose", Aliases: []string{"fh", "delivery"}, Description: "Firehose delivery streams"},
+	{Name: "cognito", Aliases: []string{"cog", "userpools", "users"}, Description: "Cognito user pools"},
+	{Name: "msk", Aliases: []string{"kafka"}, Description: "MSK (Kafka) clusters"},
+	{Name: "mq", Aliases: []string{"amazonmq", "rabbitmq", "activemq"}, Description: "Amazon MQ brokers"},
+	{Name: "databases", Aliases: []string{"db", "rds", "redshift"}, Description: "RDS, RDS Proxy and Redshift endpoints to tunnel to"},
+	{Name: "ec2", Aliases: []string{"instances", "ssm"}, Description: "EC2 instances to open a shell on or forward a port of"},
+	{Name: "efs", Aliases: []string{"filesystems", "nfs"}, Description: "EFS file systems"},
+	{Name: "schedules", Aliases: []string{"scheduler", "cron"}, Description: "EventBridge Scheduler schedules"},
+	{Name: "secrets", Aliases: []string{"secretsmanager", "sm", "rotation"}, Description: "Secrets Manager secrets and rotation"},
+	{Name: "loggroups", Aliases: []string{"lg", "log-groups", "retention"}, Description: "CloudWatch Logs log groups and retention"},
+	{Name: "ses", Aliases: []string{"email", "mail"}, Description: "SES sending and suppression list"},
+	{Name: "resources", Aliases: []string{"res", "cc", "cloudcontrol"}, Description: "Cloud Control resources [type]"},
 
 	// Other views
+	{Name: "tasks", Aliases: []string{"task", "ps"}, Description: "Running and recently stopped tasks of the selected ECS service (enter)"},
 	{Name: "tunnels", Aliases: []string{"tun", "tunnel", "pf"}, Description: "Port forward tunnels"},
+	{Name: "export", Aliases: []string{"share"}, Description: "Export selected tunnel as YAML, the runtime report as CSV, or the loaded CloudWatch logs [file]"},
+	{Name: "import", Aliases: []string{"load"}, Description: "Import tunnel from YAML <file>"},
+	{Name: "images", Aliases: []string{"freshness"}, Description: "Compare the images of the cluster's or stack's services with ECR"},
+	{Name: "runtimes", Aliases: []string{"eol"}, Description: "Lambda functions grouped by runtime with deprecation dates (:export <file> for CSV)"},
+	{Name: "health", Aliases: []string{"status", "overview"}, Description: "Account health: failed stacks, services, alarms, DLQs, certificates"},
+	{Name: "costs", Aliases: []string{"budgets", "billing"}, Description: "Estimated charges of the month and budget status"},
+	{Name: "cluster", Aliases: []string{"clusterdash", "cdash"}, Description: "Dashboard of the selected or open ECS cluster (V)"},
+	{Name: "columns", Aliases: []string{"cols", "layout"}, Description: "Choose and order the columns of the services, tasks, Lambda or SQS table (Z)"},
+	{Name: "macro", Aliases: []string{"macros"}, Description: "Replay, save or delete macros (Q to record) [name|save <name> [key]|delete <name>]"},
+	{Name: "query", Aliases: []string{"queries", "qry"}, Description: "Run, save or delete saved DynamoDB queries of the table [name|save <name>|delete <name>]"},
+	{Name: "monitor", Aliases: []string{"mon", "dash"}, Description: "Monitor dashboard [tasks|logs|queue|alarms to pin]"},
+	{Name: "group", Aliases: []string{"groupby"}, Description: "Group stacks by a tag key or name prefix [tag key|prefix|off]"},
+	{Name: "link", Aliases: []string{"deeplink", "url"}, Description: "Copy a vaws:// link to the selected resource (open with vaws open <link>)"},
+	{Name: "note", Aliases: []string{"notes", "annotate"}, Description: "Edit the local note of the selected resource in $EDITOR [text|clear]"},
+	{Name: "alerts", Aliases: []string{"alert", "watches"}, Description: "Watch expressions of the profile and the alerts they raised"},
+	{Name: "watch", Aliases: []string{"when"}, Description: "Watch the selected service or queue, e.g. :watch running < desired for 5m [condition|off]"},
+	{Name: "dlqexport", Aliases: []string{"dlqwatch"}, Description: "Toggle saving new DLQ messages of the selected queue to ~/.vaws/dlq"},
+	{Name: "perf", Aliases: []string{"coldstarts", "durations"}, Description: "Duration percentiles, cold starts and memory use of the selected Lambda function (m)"},
+	{Name: "failures", Aliases: []string{"whyfail", "dlqwhy"}, Description: "Why the selected queue's DLQ messages fail: consumer errors and throttles"},
+	{Name: "report", Aliases: []string{"snapshot", "handover"}, Description: "Write a Markdown report of the selected stack or cluster and copy it [path]"},
+	{Name: "terraform", Aliases: []string{"tf", "tfstate"}, Description: "Read the profile's Terraform states again to show resources' addresses"},
 
 	// Settings
 	{Name: "region", Aliases: []string{"reg"}, Description: "Change AWS region"},
+	{Name: "env", Aliases: []string{"environment"}, Description: "Switch profile, region and stack filter at once [name]"},
+	{Name: "tag", Aliases: []string{"tags", "scope"}, Description: "Scope every list to resources with a tag, toggles without one [Key=value|clear]"},
+	{Name: "accounts", Aliases: []string{"org", "orgs"}, Description: "Switch to a member account of the organization by assuming a role"},
+	{Name: "https", Aliases: []string{"tls"}, Description: "Toggle HTTPS for new API proxies"},
+	{Name: "time", Aliases: []string{"tz", "clock"}, Description: "Toggle relative/absolute times [relative|absolute|local|utc]"},
+	{Name: "numbers", Aliases: []string{"num"}, Description: "Toggle compact/full numbers [compact|full]"},
 
 	// Actions
 	{Name: "refresh", Aliases: []string{"reload"}, Description: "Refresh current view"},
 	{Name: "logs", Aliases: []string{"log", "l"}, Description: "Toggle logs panel"},
+	{Name: "stats", Aliases: []string{"diag", "diagnostics"}, Description: "vaws's own memory use, event loop timings and cache sizes (w writes pprof profiles)"},
+	{Name: "timeline", Aliases: []string{"breadcrumbs", "history"}, Description: "Views, resources, actions and tunnels of the session with timestamps (Y copies as text)"},
 	{Name: "help", Aliases: []string{"h", "?"}, Description: "Show help"},
 	{Name: "quit", Aliases: []string{"q", "exit"}, Description: "Quit application"},
 }
@@ -45,6 +89,50 @@ var AvailableCommands = []Command{
 type CommandResult struct {
 	Command string
 	Args    []string
+
+	// Resource is set instead of Command when a resource was picked
+	Resource *PaletteResource
+}
+
+// PaletteResource is a resource the palette offers next to the commands.
+// Picking it opens the resource by its ARN.
+type PaletteResource struct {
+	Kind    string // e.g. service
+	Name    string
+	Context string // Where it lives, e.g. cluster prod
+	ARN     string
+	Recent  bool // Viewed this session, ranked above cached resources
+}
+
+// Label returns how the resource is listed, e.g. "service payments-api
+// (cluster prod)".
+func (r PaletteResource) Label() string {
+	label := r.Kind + " " + r.Name
+	if r.Context != "" {
+		label += " (" + r.Context + ")"
+	}
+	return label
+}
+
+// maxResourceSuggestions caps the resources offered for one query.
+const maxResourceSuggestions = 50
+
+// suggestion is a command or a resource offered by the palette.
+type suggestion struct {
+	cmd *Command
+	res *PaletteResource
+}
+
+// text returns the name and description a suggestion is listed with.
+func (s suggestion) text() (string, string) {
+	if s.res != nil {
+		desc := "open"
+		if s.res.Recent {
+			desc = "recent"
+		}
+		return s.res.Label(), desc
+	}
+	return s.cmd.Name, s.cmd.Description
 }
 
 // CommandPalette provides k9s-style command input
@@ -52,7 +140,9 @@ type CommandPalette struct {
 	input       textinput.Model
 	active      bool
 	width       int
-	suggestions []Command
+	suggestions []suggestion
+	cursor      int // Suggestion picked with up/down
+	resources   []PaletteResource
 }
 
 // NewCommandPalette creates a new command palette
@@ -63,9 +153,8 @@ func NewCommandPalette() *CommandPalette {
 	ti.Width = 30
 
 	return &CommandPalette{
-		input:       ti,
-		active:      false,
-		suggestions: []Command{},
+		input:  ti,
+		active: false,
 	}
 }
 
@@ -75,6 +164,12 @@ func (c *CommandPalette) SetWidth(width int) {
 	c.input.Width = min(50, width-10)
 }
 
+// SetResources sets the resources offered next to the commands, recently
+// viewed ones first.
+func (c *CommandPalette) SetResources(resources []PaletteResource) {
+	c.resources = resources
+}
+
 // Activate shows the command palette
 func (c *CommandPalette) Activate() tea.Cmd {
 	c.active = true
@@ -89,7 +184,8 @@ func (c *CommandPalette) Deactivate() {
 	c.active = false
 	c.input.Blur()
 	c.input.SetValue("")
-	c.suggestions = []Command{}
+	c.suggestions = nil
+	c.cursor = 0
 }
 
 // IsActive returns whether the palette is active
@@ -107,19 +203,35 @@ func (c *CommandPalette) Update(msg tea.Msg) (*CommandResult, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
-			// Execute command
+			// Open the resource picked, or execute the command typed
 			result := c.parseCommand()
+			if c.cursor < len(c.suggestions) && c.suggestions[c.cursor].res != nil {
+				res := *c.suggestions[c.cursor].res
+				result = &CommandResult{Resource: &res}
+			}
 			c.Deactivate()
 			return result, nil
 
+		case "up", "ctrl+p":
+			if c.cursor > 0 {
+				c.cursor--
+			}
+			return nil, nil
+
+		case "down", "ctrl+n":
+			if c.cursor < len(c.suggestions)-1 {
+				c.cursor++
+			}
+			return nil, nil
+
 		case "esc":
 			c.Deactivate()
 			return nil, nil
 
 		case "tab":
-			// Auto-complete first suggestion
-			if len(c.suggestions) > 0 {
-				c.input.SetValue(c.suggestions[0].Name)
+			// Auto-complete the command picked
+			if c.cursor < len(c.suggestions) && c.suggestions[c.cursor].cmd != nil {
+				c.input.SetValue(c.suggestions[c.cursor].cmd.Name)
 				c.input.CursorEnd()
 				c.updateSuggestions()
 			}
@@ -135,30 +247,93 @@ func (c *CommandPalette) Update(msg tea.Msg) (*CommandResult, tea.Cmd) {
 	return nil, cmd
 }
 
-// updateSuggestions updates command suggestions based on input
+// updateSuggestions updates the suggestions based on input: the commands
+// whose name or alias starts with it, then the resources whose name matches
+// it fuzzily, best match first.
 func (c *CommandPalette) updateSuggestions() {
 	query := strings.ToLower(strings.TrimSpace(c.input.Value()))
-	c.suggestions = []Command{}
+	c.suggestions = nil
+	c.cursor = 0
 
-	if query == "" {
-		c.suggestions = AvailableCommands
+	for i := range AvailableCommands {
+		cmd := &AvailableCommands[i]
+		if query == "" || matchesCommand(*cmd, query) {
+			c.suggestions = append(c.suggestions, suggestion{cmd: cmd})
+		}
+	}
+
+	// Arguments follow a space, so only a single word names a resource
+	if query == "" || strings.Contains(query, " ") {
 		return
 	}
+	type scored struct {
+		res   *PaletteResource
+		score int
+	}
+	var matches []scored
+	for i := range c.resources {
+		res := &c.resources[i]
+		if score, ok := fuzzyScore(query, strings.ToLower(res.Name)); ok {
+			if res.Recent {
+				score += recentBonus
+			}
+			matches = append(matches, scored{res: res, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	for i, match := range matches {
+		if i == maxResourceSuggestions {
+			break
+		}
+		c.suggestions = append(c.suggestions, suggestion{res: match.res})
+	}
+}
+
+// matchesCommand returns true if the name or an alias of cmd starts with query.
+func matchesCommand(cmd Command, query string) bool {
+	if strings.HasPrefix(strings.ToLower(cmd.Name), query) {
+		return true
+	}
+	for _, alias := range cmd.Aliases {
+		if strings.HasPrefix(strings.ToLower(alias), query) {
+			return true
+		}
+	}
+	return false
+}
 
-	for _, cmd := range AvailableCommands {
-		// Check name
-		if strings.HasPrefix(strings.ToLower(cmd.Name), query) {
-			c.suggestions = append(c.suggestions, cmd)
+// recentBonus ranks recently viewed resources above equally good matches.
+const recentBonus = 10
+
+// fuzzyScore returns whether the letters of query appear in s in order, and
+// how well: runs of consecutive letters and matches at the start of s or of
+// a word in it score higher. Both are lowercase.
+func fuzzyScore(query, s string) (int, bool) {
+	score, qi, prev := 0, 0, -2
+	for si := 0; si < len(s) && qi < len(query); si++ {
+		if s[si] != query[qi] {
 			continue
 		}
-		// Check aliases
-		for _, alias := range cmd.Aliases {
-			if strings.HasPrefix(strings.ToLower(alias), query) {
-				c.suggestions = append(c.suggestions, cmd)
-				break
-			}
+		switch {
+		case si == 0:
+			score += 8
+		case si == prev+1:
+			score += 5
+		case strings.ContainsRune("-_./: ", rune(s[si-1])):
+			score += 4
+		default:
+			score++
 		}
+		prev = si
+		qi++
+	}
+	if qi < len(query) {
+		return 0, false
 	}
+	// Of two names matching alike, the shorter is the closer match
+	return score*4 - len(s)/8, true
 }
 
 // parseCommand parses the current input into a command result
@@ -207,7 +382,7 @@ func (c *CommandPalette) View() string {
 		Bold(true)
 
 	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BorderStyle()).
 		BorderForeground(theme.BorderFocus).
 		Padding(0, 1).
 		Width(min(60, c.width-4))
@@ -232,21 +407,23 @@ func (c *CommandPalette) View() string {
 	// Suggestions
 	if len(c.suggestions) > 0 {
 		content.WriteString("\n")
+		// Scroll so that the suggestion picked stays in view
 		maxShow := min(6, len(c.suggestions))
-		for i := 0; i < maxShow; i++ {
-			cmd := c.suggestions[i]
-			if i == 0 {
-				content.WriteString(selectedSuggestionStyle.Render(cmd.Name))
+		start := max(0, c.cursor-maxShow+1)
+		for i := start; i < start+maxShow; i++ {
+			name, desc := c.suggestions[i].text()
+			if i == c.cursor {
+				content.WriteString(selectedSuggestionStyle.Render(name))
 			} else {
-				content.WriteString(suggestionStyle.Render(cmd.Name))
+				content.WriteString(suggestionStyle.Render(name))
 			}
 			content.WriteString(" ")
-			content.WriteString(descStyle.Render(cmd.Description))
-			if i < maxShow-1 {
+			content.WriteString(descStyle.Render(desc))
+			if i < start+maxShow-1 {
 				content.WriteString("\n")
 			}
 		}
-		if len(c.suggestions) > maxShow {
+		if len(c.suggestions) > start+maxShow {
 			content.WriteString("\n")
 			content.WriteString(descStyle.Render("...and more"))
 		}
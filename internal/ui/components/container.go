@@ -27,6 +27,7 @@ type Container struct {
 	loading   bool
 	err       error
 	spinner   *Spinner
+	focused   bool // Content has focus rather than another panel
 }
 
 // NewContainer creates a new Container component.
@@ -36,11 +37,21 @@ func NewContainer() *Container {
 	}
 }
 
+// SetFocused sets whether the content has focus, which colors the border.
+func (c *Container) SetFocused(focused bool) {
+	c.focused = focused
+}
+
 // SetTitle sets the container title (shown in top-left of border).
 func (c *Container) SetTitle(title string) {
 	c.title = title
 }
 
+// Title returns the container title.
+func (c *Container) Title() string {
+	return c.title
+}
+
 // SetContext sets the context string (shown in top-right of border).
 func (c *Container) SetContext(context string) {
 	c.context = context
@@ -152,9 +163,13 @@ func (c *Container) View() string {
 	}
 
 	// Use lipgloss border for proper styling
+	borderColor := theme.Border
+	if c.focused {
+		borderColor = theme.BorderFocus
+	}
 	borderStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(theme.Border).
+		Border(theme.BorderStyle()).
+		BorderForeground(borderColor).
 		Width(c.width - 2).
 		Height(contentHeight)
 
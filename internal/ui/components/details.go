@@ -13,6 +13,9 @@ type DetailRow struct {
 	Label string
 	Value string
 	Style lipgloss.Style
+	// Syntax is set for a line of source, e.g. SyntaxYAML: the value is shown
+	// highlighted in full width, without a label
+	Syntax string
 }
 
 // Details is a component that displays key-value details.
@@ -28,11 +31,16 @@ type Details struct {
 	searchQuery   string
 	searchMatches []int // indices of matching rows
 	searchIndex   int   // current match index
+
+	// JSON content shown as a tree below the rows
+	jsonLabel string
+	jsonTree  *JSONTree
+	showJSON  bool
 }
 
 // NewDetails creates a new Details component.
 func NewDetails() *Details {
-	return &Details{}
+	return &Details{jsonTree: NewJSONTree()}
 }
 
 // SetTitle sets the details title.
@@ -44,6 +52,29 @@ func (d *Details) SetTitle(title string) {
 func (d *Details) SetRows(rows []DetailRow) {
 	d.rows = rows
 	d.scrollOffset = 0 // Reset scroll when content changes
+	d.showJSON = false
+}
+
+// SetJSON shows raw as a collapsible tree below the rows, under label. It must
+// be called after SetRows, and returns false (showing nothing) if raw isn't
+// valid JSON. Setting the same document again keeps its expansion state.
+func (d *Details) SetJSON(label, raw string) bool {
+	if err := d.jsonTree.SetJSON(raw); err != nil {
+		d.showJSON = false
+		return false
+	}
+	d.jsonLabel = label
+	d.showJSON = true
+	return true
+}
+
+// JSONTree returns the tree shown below the rows, or nil if there is none.
+// While a tree is shown, scrolling and search act on the tree.
+func (d *Details) JSONTree() *JSONTree {
+	if !d.showJSON {
+		return nil
+	}
+	return d.jsonTree
 }
 
 // SetSize sets the component dimensions.
@@ -63,6 +94,10 @@ func (d *Details) visibleRows() int {
 
 // ScrollUp scrolls up by one row.
 func (d *Details) ScrollUp() {
+	if d.showJSON {
+		d.jsonTree.Up()
+		return
+	}
 	if d.scrollOffset > 0 {
 		d.scrollOffset--
 	}
@@ -70,6 +105,10 @@ func (d *Details) ScrollUp() {
 
 // ScrollDown scrolls down by one row.
 func (d *Details) ScrollDown() {
+	if d.showJSON {
+		d.jsonTree.Down()
+		return
+	}
 	maxOffset := max(0, len(d.rows)-d.visibleRows())
 	if d.scrollOffset < maxOffset {
 		d.scrollOffset++
@@ -78,6 +117,10 @@ func (d *Details) ScrollDown() {
 
 // ScrollHalfPageDown scrolls down by half a page.
 func (d *Details) ScrollHalfPageDown() {
+	if d.showJSON {
+		d.jsonTree.HalfPageDown()
+		return
+	}
 	halfPage := d.visibleRows() / 2
 	if halfPage < 1 {
 		halfPage = 1
@@ -88,6 +131,10 @@ func (d *Details) ScrollHalfPageDown() {
 
 // ScrollHalfPageUp scrolls up by half a page.
 func (d *Details) ScrollHalfPageUp() {
+	if d.showJSON {
+		d.jsonTree.HalfPageUp()
+		return
+	}
 	halfPage := d.visibleRows() / 2
 	if halfPage < 1 {
 		halfPage = 1
@@ -97,6 +144,10 @@ func (d *Details) ScrollHalfPageUp() {
 
 // ScrollPageDown scrolls down by a full page.
 func (d *Details) ScrollPageDown() {
+	if d.showJSON {
+		d.jsonTree.PageDown()
+		return
+	}
 	page := d.visibleRows()
 	maxOffset := max(0, len(d.rows)-d.visibleRows())
 	d.scrollOffset = min(d.scrollOffset+page, maxOffset)
@@ -104,17 +155,29 @@ func (d *Details) ScrollPageDown() {
 
 // ScrollPageUp scrolls up by a full page.
 func (d *Details) ScrollPageUp() {
+	if d.showJSON {
+		d.jsonTree.PageUp()
+		return
+	}
 	page := d.visibleRows()
 	d.scrollOffset = max(0, d.scrollOffset-page)
 }
 
 // ScrollToTop scrolls to the top.
 func (d *Details) ScrollToTop() {
+	if d.showJSON {
+		d.jsonTree.Top()
+		return
+	}
 	d.scrollOffset = 0
 }
 
 // ScrollToBottom scrolls to the bottom.
 func (d *Details) ScrollToBottom() {
+	if d.showJSON {
+		d.jsonTree.Bottom()
+		return
+	}
 	d.scrollOffset = max(0, len(d.rows)-d.visibleRows())
 }
 
@@ -136,6 +199,10 @@ func (d *Details) ResetScroll() {
 // SetSearchQuery sets the search query and updates matches.
 func (d *Details) SetSearchQuery(query string) {
 	d.searchQuery = query
+	if d.showJSON {
+		d.jsonTree.SetSearchQuery(query)
+		return
+	}
 	d.updateSearchMatches()
 }
 
@@ -144,6 +211,7 @@ func (d *Details) ClearSearch() {
 	d.searchQuery = ""
 	d.searchMatches = nil
 	d.searchIndex = 0
+	d.jsonTree.ClearSearch()
 }
 
 // updateSearchMatches finds all rows matching the search query.
@@ -176,6 +244,10 @@ func (d *Details) scrollToMatch(idx int) {
 
 // NextMatch moves to the next search match.
 func (d *Details) NextMatch() {
+	if d.showJSON {
+		d.jsonTree.NextMatch()
+		return
+	}
 	if len(d.searchMatches) == 0 {
 		return
 	}
@@ -185,6 +257,10 @@ func (d *Details) NextMatch() {
 
 // PrevMatch moves to the previous search match.
 func (d *Details) PrevMatch() {
+	if d.showJSON {
+		d.jsonTree.PrevMatch()
+		return
+	}
 	if len(d.searchMatches) == 0 {
 		return
 	}
@@ -202,11 +278,17 @@ func (d *Details) SearchQuery() string {
 
 // MatchCount returns the number of search matches.
 func (d *Details) MatchCount() int {
+	if d.showJSON {
+		return d.jsonTree.MatchCount()
+	}
 	return len(d.searchMatches)
 }
 
 // CurrentMatchIndex returns the current match index (1-based for display).
 func (d *Details) CurrentMatchIndex() int {
+	if d.showJSON {
+		return d.jsonTree.CurrentMatchIndex()
+	}
 	if len(d.searchMatches) == 0 {
 		return 0
 	}
@@ -249,6 +331,10 @@ func (d *Details) View() string {
 		b.WriteString("\n")
 	}
 
+	if d.showJSON {
+		return d.viewWithJSON(s, &b)
+	}
+
 	maxRows := d.visibleRows()
 
 	// Clamp scroll offset
@@ -272,11 +358,19 @@ func (d *Details) View() string {
 		isMatch := d.isMatchRow(i)
 		isCurrent := d.isCurrentMatch(i)
 
+		if row.Syntax != "" {
+			b.WriteString(d.sourceLine(row, isMatch, isCurrent))
+			if i < endIdx-1 {
+				b.WriteString("\n")
+			}
+			continue
+		}
+
 		label := s.DetailLabel.Render(row.Label + ":")
 		value := row.Value
 
 		if row.Style.String() != "" {
-			value = row.Style.Render(value)
+			value = row.Style.Render(theme.Tagged(row.Style, value))
 		} else {
 			value = s.DetailValue.Render(value)
 		}
@@ -314,7 +408,7 @@ func (d *Details) View() string {
 		b.WriteString(s.Muted.Render(searchInfo))
 	} else if len(d.rows) > maxRows {
 		// Scroll indicator (only show if content overflows and not searching)
-		indicator := fmt.Sprintf("\n\n↑↓ %d-%d of %d", d.scrollOffset+1, endIdx, len(d.rows))
+		indicator := fmt.Sprintf("\n\n%s %d-%d of %d", theme.Symbol("↑↓", "^v"), d.scrollOffset+1, endIdx, len(d.rows))
 		b.WriteString(s.Muted.Render(indicator))
 	}
 
@@ -323,6 +417,60 @@ func (d *Details) View() string {
 		Render(b.String())
 }
 
+// sourceLine renders a row holding a line of source, truncated to the
+// pane's width.
+func (d *Details) sourceLine(row DetailRow, isMatch, isCurrent bool) string {
+	line := row.Value
+	if maxWidth := d.width - 8; maxWidth > 0 && lipgloss.Width(line) > maxWidth {
+		line = truncate(line, maxWidth)
+	}
+	switch {
+	case isCurrent:
+		return lipgloss.NewStyle().
+			Background(theme.Primary).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Render("> " + line)
+	case isMatch:
+		return lipgloss.NewStyle().
+			Background(theme.PrimaryMuted).
+			Render("  " + line)
+	}
+	return "  " + HighlightSource(row.Syntax, line)
+}
+
+// viewWithJSON renders all rows followed by the JSON tree in the remaining height.
+func (d *Details) viewWithJSON(s theme.Styles, b *strings.Builder) string {
+	for _, row := range d.rows {
+		if row.Label == "" && row.Value == "" {
+			b.WriteString("\n")
+			continue
+		}
+		value := row.Value
+		if row.Style.String() != "" {
+			value = row.Style.Render(theme.Tagged(row.Style, value))
+		} else {
+			value = s.DetailValue.Render(value)
+		}
+		if maxValueWidth := d.width - 18; lipgloss.Width(value) > maxValueWidth && maxValueWidth > 0 {
+			value = truncate(value, maxValueWidth)
+		}
+		b.WriteString(s.DetailLabel.Render(row.Label+":") + " " + value + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(s.DetailLabel.Render(d.jsonLabel + ":"))
+	b.WriteString("\n")
+
+	// Title, rows, spacer and label above; border and padding around
+	treeHeight := max(3, d.height-6-len(d.rows)-2)
+	d.jsonTree.SetSize(d.width-6, treeHeight)
+	b.WriteString(d.jsonTree.View())
+
+	return s.Content.
+		Width(d.width - 4).
+		Render(b.String())
+}
+
 // PlainTextView returns the details content as plain text for copy mode.
 func (d *Details) PlainTextView() string {
 	var b strings.Builder
@@ -335,8 +483,16 @@ func (d *Details) PlainTextView() string {
 			b.WriteString("\n")
 			continue
 		}
+		if row.Syntax != "" {
+			b.WriteString(row.Value + "\n")
+			continue
+		}
 		b.WriteString(row.Label + ": " + row.Value + "\n")
 	}
+	if d.showJSON {
+		b.WriteString("\n" + d.jsonLabel + ":\n")
+		b.WriteString(d.jsonTree.PrettyJSON() + "\n")
+	}
 	return b.String()
 }
 
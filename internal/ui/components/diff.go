@@ -0,0 +1,567 @@
+package components
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"vaws/internal/ui/theme"
+)
+
+// DiffMode selects how a Diff is laid out.
+type DiffMode int
+
+const (
+	DiffUnified    DiffMode = iota // Removed and added lines interleaved in one column
+	DiffSideBySide                 // Old text on the left, new text on the right
+)
+
+// diffContext is the number of unchanged lines kept around each change.
+const diffContext = 3
+
+// maxDiffCells bounds the LCS table size; larger inputs are diffed as a
+// single replaced block instead.
+const maxDiffCells = 4_000_000
+
+// diffKind is the kind of a row in a diff.
+type diffKind int
+
+const (
+	diffEqual diffKind = iota
+	diffChange
+	diffDelete
+	diffInsert
+	diffFold // Collapsed run of unchanged lines
+)
+
+// diffSegment is a run of text, marked if it differs from the other side.
+type diffSegment struct {
+	text    string
+	changed bool
+}
+
+// diffSide is one side of a diff row.
+type diffSide struct {
+	no       int // 1-based line number
+	segments []diffSegment
+}
+
+// diffRow pairs an old line with a new line.
+type diffRow struct {
+	kind   diffKind
+	left   *diffSide
+	right  *diffSide
+	folded int // Number of unchanged lines hidden by a fold row
+}
+
+// Diff is a scrollable diff view with unified and side-by-side layouts and
+// word-level highlighting of changed lines.
+type Diff struct {
+	leftTitle  string
+	rightTitle string
+	rows       []diffRow
+	mode       DiffMode
+	width      int
+	height     int
+	scroll     int
+	changes    int        // Number of changed hunks
+	lines      []diffLine // Rendered rows for the current mode and width
+}
+
+// NewDiff creates a new Diff in unified mode.
+func NewDiff() *Diff {
+	return &Diff{}
+}
+
+// SetTexts computes the diff between left (old) and right (new).
+func (d *Diff) SetTexts(leftTitle, left, rightTitle, right string) {
+	d.leftTitle = leftTitle
+	d.rightTitle = rightTitle
+	d.rows = foldDiffRows(buildDiffRows(splitDiffLines(left), splitDiffLines(right)))
+	d.scroll = 0
+
+	d.changes = 0
+	inHunk := false
+	for _, r := range d.rows {
+		changed := r.kind != diffEqual && r.kind != diffFold
+		if changed && !inHunk {
+			d.changes++
+		}
+		inHunk = changed
+	}
+	d.lines = d.renderLines()
+}
+
+// Clear removes the diff content.
+func (d *Diff) Clear() {
+	d.rows = nil
+	d.lines = nil
+	d.scroll = 0
+	d.changes = 0
+}
+
+// SetSize sets the component dimensions. Lines are rendered again only when
+// the width changes, since the height just moves the scroll window.
+func (d *Diff) SetSize(width, height int) {
+	widthChanged := width != d.width
+	d.width = width
+	d.height = height
+	if widthChanged {
+		d.lines = d.renderLines()
+	}
+}
+
+// SetMode sets the layout.
+func (d *Diff) SetMode(mode DiffMode) {
+	d.mode = mode
+	d.scroll = 0
+	d.lines = d.renderLines()
+}
+
+// ToggleMode switches between unified and side-by-side layouts.
+func (d *Diff) ToggleMode() {
+	if d.mode == DiffUnified {
+		d.SetMode(DiffSideBySide)
+	} else {
+		d.SetMode(DiffUnified)
+	}
+}
+
+// Mode returns the current layout.
+func (d *Diff) Mode() DiffMode {
+	return d.mode
+}
+
+// ChangeCount returns the number of changed hunks.
+func (d *Diff) ChangeCount() int {
+	return d.changes
+}
+
+// visibleLines returns the number of diff lines that fit below the header.
+func (d *Diff) visibleLines() int {
+	return max(1, d.height-3)
+}
+
+// maxScroll returns the largest valid scroll offset.
+func (d *Diff) maxScroll() int {
+	return max(0, len(d.lines)-d.visibleLines())
+}
+
+// ScrollUp scrolls up by one line.
+func (d *Diff) ScrollUp() {
+	d.scroll = max(0, d.scroll-1)
+}
+
+// ScrollDown scrolls down by one line.
+func (d *Diff) ScrollDown() {
+	d.scroll = min(d.scroll+1, d.maxScroll())
+}
+
+// HalfPageUp scrolls up by half a page.
+func (d *Diff) HalfPageUp() {
+	d.scroll = max(0, d.scroll-max(1, d.visibleLines()/2))
+}
+
+// HalfPageDown scrolls down by half a page.
+func (d *Diff) HalfPageDown() {
+	d.scroll = min(d.scroll+max(1, d.visibleLines()/2), d.maxScroll())
+}
+
+// Top scrolls to the beginning.
+func (d *Diff) Top() {
+	d.scroll = 0
+}
+
+// Bottom scrolls to the end.
+func (d *Diff) Bottom() {
+	d.scroll = d.maxScroll()
+}
+
+// NextChange scrolls to the next changed hunk.
+func (d *Diff) NextChange() {
+	for _, start := range d.hunkStarts() {
+		if start > d.scroll {
+			d.scroll = min(start, d.maxScroll())
+			return
+		}
+	}
+}
+
+// PrevChange scrolls to the previous changed hunk.
+func (d *Diff) PrevChange() {
+	starts := d.hunkStarts()
+	for i := len(starts) - 1; i >= 0; i-- {
+		if starts[i] < d.scroll {
+			d.scroll = starts[i]
+			return
+		}
+	}
+}
+
+// hunkStarts returns the rendered line index of each changed hunk.
+func (d *Diff) hunkStarts() []int {
+	var starts []int
+	for i, l := range d.lines {
+		if l.hunkStart {
+			starts = append(starts, i)
+		}
+	}
+	return starts
+}
+
+// splitDiffLines splits text into lines, ignoring a trailing newline.
+func splitDiffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// lcsPairs returns the index pairs of a longest common subsequence of a and b.
+func lcsPairs(a, b []string) [][2]int {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 || n*m > maxDiffCells {
+		return nil
+	}
+
+	// lengths[i][j] is the LCS length of a[i:] and b[j:]
+	lengths := make([][]int32, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var pairs [][2]int
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case a[i] == b[j]:
+			pairs = append(pairs, [2]int{i, j})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+// buildDiffRows diffs old and new lines, pairing removed lines with added
+// lines of the same hunk so they can be compared word by word.
+func buildDiffRows(oldLines, newLines []string) []diffRow {
+	// Trim the common prefix and suffix so the LCS table stays small
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	var rows []diffRow
+	equal := func(i, j int) {
+		rows = append(rows, diffRow{
+			kind:  diffEqual,
+			left:  &diffSide{no: i + 1, segments: []diffSegment{{text: oldLines[i]}}},
+			right: &diffSide{no: j + 1, segments: []diffSegment{{text: newLines[j]}}},
+		})
+	}
+	hunk := func(oi, oj, ni, nj int) {
+		dels, ins := oldLines[oi:oj], newLines[ni:nj]
+		for k := 0; k < max(len(dels), len(ins)); k++ {
+			switch {
+			case k < len(dels) && k < len(ins):
+				l, r := wordDiff(dels[k], ins[k])
+				rows = append(rows, diffRow{
+					kind:  diffChange,
+					left:  &diffSide{no: oi + k + 1, segments: l},
+					right: &diffSide{no: ni + k + 1, segments: r},
+				})
+			case k < len(dels):
+				rows = append(rows, diffRow{
+					kind: diffDelete,
+					left: &diffSide{no: oi + k + 1, segments: []diffSegment{{text: dels[k]}}},
+				})
+			default:
+				rows = append(rows, diffRow{
+					kind:  diffInsert,
+					right: &diffSide{no: ni + k + 1, segments: []diffSegment{{text: ins[k]}}},
+				})
+			}
+		}
+	}
+
+	for i := 0; i < prefix; i++ {
+		equal(i, i)
+	}
+
+	oldMid, newMid := oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix]
+	i, j := 0, 0
+	for _, p := range lcsPairs(oldMid, newMid) {
+		hunk(prefix+i, prefix+p[0], prefix+j, prefix+p[1])
+		equal(prefix+p[0], prefix+p[1])
+		i, j = p[0]+1, p[1]+1
+	}
+	hunk(prefix+i, prefix+len(oldMid), prefix+j, prefix+len(newMid))
+
+	for k := 0; k < suffix; k++ {
+		equal(len(oldLines)-suffix+k, len(newLines)-suffix+k)
+	}
+	return rows
+}
+
+// foldDiffRows collapses unchanged runs, keeping diffContext lines around changes.
+func foldDiffRows(rows []diffRow) []diffRow {
+	var out []diffRow
+	for i := 0; i < len(rows); {
+		if rows[i].kind != diffEqual {
+			out = append(out, rows[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(rows) && rows[j].kind == diffEqual {
+			j++
+		}
+
+		keepBefore, keepAfter := diffContext, diffContext
+		if i == 0 {
+			keepBefore = 0
+		}
+		if j == len(rows) {
+			keepAfter = 0
+		}
+		if j-i > keepBefore+keepAfter+1 {
+			out = append(out, rows[i:i+keepBefore]...)
+			out = append(out, diffRow{kind: diffFold, folded: j - i - keepBefore - keepAfter})
+			out = append(out, rows[j-keepAfter:j]...)
+		} else {
+			out = append(out, rows[i:j]...)
+		}
+		i = j
+	}
+	return out
+}
+
+// diffTokenPattern splits lines into words, runs of whitespace and punctuation.
+var diffTokenPattern = regexp.MustCompile(`\w+|\s+|[^\w\s]`)
+
+// wordDiff compares two lines token by token and marks the differing runs.
+func wordDiff(oldLine, newLine string) (left, right []diffSegment) {
+	a := diffTokenPattern.FindAllString(oldLine, -1)
+	b := diffTokenPattern.FindAllString(newLine, -1)
+	pairs := lcsPairs(a, b)
+	if pairs == nil && len(a) > 0 && len(b) > 0 {
+		return []diffSegment{{text: oldLine, changed: true}}, []diffSegment{{text: newLine, changed: true}}
+	}
+
+	appendSeg := func(segs []diffSegment, text string, changed bool) []diffSegment {
+		if text == "" {
+			return segs
+		}
+		if n := len(segs); n > 0 && segs[n-1].changed == changed {
+			segs[n-1].text += text
+			return segs
+		}
+		return append(segs, diffSegment{text: text, changed: changed})
+	}
+
+	i, j := 0, 0
+	for _, p := range append(pairs, [2]int{len(a), len(b)}) {
+		left = appendSeg(left, strings.Join(a[i:p[0]], ""), true)
+		right = appendSeg(right, strings.Join(b[j:p[1]], ""), true)
+		if p[0] < len(a) {
+			left = appendSeg(left, a[p[0]], false)
+			right = appendSeg(right, b[p[1]], false)
+		}
+		i, j = p[0]+1, p[1]+1
+	}
+	return left, right
+}
+
+// diffLine is a rendered line of the diff.
+type diffLine struct {
+	text      string
+	hunkStart bool
+}
+
+// diffStyles holds the styles used to render a diff.
+type diffStyles struct {
+	del, ins, delWord, insWord, equal, dim lipgloss.Style
+}
+
+func newDiffStyles() diffStyles {
+	return diffStyles{
+		del:     lipgloss.NewStyle().Foreground(theme.Error),
+		ins:     lipgloss.NewStyle().Foreground(theme.Success),
+		delWord: lipgloss.NewStyle().Foreground(theme.Error).Reverse(true),
+		insWord: lipgloss.NewStyle().Foreground(theme.Success).Reverse(true),
+		equal:   lipgloss.NewStyle().Foreground(theme.Text),
+		dim:     lipgloss.NewStyle().Foreground(theme.TextDim),
+	}
+}
+
+// renderSide renders a diff side's segments within width, padding to width if pad is set.
+// Widths are measured in terminal cells so wide characters keep the columns aligned.
+func renderSide(side *diffSide, width int, base, word lipgloss.Style, pad bool) string {
+	var b strings.Builder
+	used := 0
+	if side != nil {
+		for _, seg := range side.segments {
+			// Tabs would break column alignment
+			text := ansi.Truncate(strings.ReplaceAll(seg.text, "\t", "    "), max(0, width-used), "")
+			if text == "" {
+				continue
+			}
+			style := base
+			if seg.changed {
+				style = word
+			}
+			b.WriteString(style.Render(text))
+			used += ansi.StringWidth(text)
+		}
+	}
+	if pad && used < width {
+		b.WriteString(strings.Repeat(" ", width-used))
+	}
+	return b.String()
+}
+
+// lineNo formats a line number column.
+func lineNo(side *diffSide) string {
+	if side == nil {
+		return "    "
+	}
+	return fmt.Sprintf("%4d", side.no)
+}
+
+// renderLines renders every diff line for the current mode and width.
+func (d *Diff) renderLines() []diffLine {
+	st := newDiffStyles()
+	var lines []diffLine
+
+	if d.mode == DiffSideBySide {
+		half := max(10, (d.width-3)/2)
+		textWidth := max(1, half-6) // Line number and marker
+		prevChanged := false
+		for _, r := range d.rows {
+			changed := r.kind != diffEqual && r.kind != diffFold
+			if r.kind == diffFold {
+				lines = append(lines, diffLine{text: st.dim.Render(fmt.Sprintf("  ⋯ %d unchanged lines", r.folded))})
+				prevChanged = false
+				continue
+			}
+
+			leftBase, leftWord, rightBase, rightWord := st.equal, st.equal, st.equal, st.equal
+			leftMark, rightMark := " ", " "
+			if r.kind == diffChange || r.kind == diffDelete {
+				leftBase, leftWord, leftMark = st.del, st.delWord, "-"
+			}
+			if r.kind == diffChange || r.kind == diffInsert {
+				rightBase, rightWord, rightMark = st.ins, st.insWord, "+"
+			}
+
+			left := st.dim.Render(lineNo(r.left)) + " " + leftBase.Render(leftMark) + " " +
+				renderSide(r.left, textWidth, leftBase, leftWord, true)
+			right := st.dim.Render(lineNo(r.right)) + " " + rightBase.Render(rightMark) + " " +
+				renderSide(r.right, textWidth, rightBase, rightWord, false)
+			lines = append(lines, diffLine{
+				text:      left + st.dim.Render(theme.Symbol(" │ ", " | ")) + right,
+				hunkStart: changed && !prevChanged,
+			})
+			prevChanged = changed
+		}
+		return lines
+	}
+
+	// Unified: each hunk's removed lines come before its added lines
+	textWidth := max(1, d.width-12)
+	var pendingAdds []diffLine
+	flush := func() {
+		lines = append(lines, pendingAdds...)
+		pendingAdds = nil
+	}
+	prevChanged := false
+	for _, r := range d.rows {
+		changed := r.kind != diffEqual && r.kind != diffFold
+		if !changed {
+			flush()
+		}
+		switch r.kind {
+		case diffFold:
+			lines = append(lines, diffLine{text: st.dim.Render(fmt.Sprintf("  ⋯ %d unchanged lines", r.folded))})
+		case diffEqual:
+			lines = append(lines, diffLine{
+				text: st.dim.Render(lineNo(r.left)+" "+lineNo(r.right)) + "   " +
+					renderSide(r.left, textWidth, st.equal, st.equal, false),
+			})
+		default:
+			if r.left != nil {
+				lines = append(lines, diffLine{
+					text: st.dim.Render(lineNo(r.left)+"     ") + st.del.Render("- ") +
+						renderSide(r.left, textWidth, st.del, st.delWord, false),
+					hunkStart: !prevChanged,
+				})
+			}
+			if r.right != nil {
+				add := diffLine{
+					text: st.dim.Render("     "+lineNo(r.right)) + st.ins.Render("+ ") +
+						renderSide(r.right, textWidth, st.ins, st.insWord, false),
+				}
+				if r.left == nil && !prevChanged && len(pendingAdds) == 0 {
+					// Pure insertion hunk starts here
+					add.hunkStart = true
+					lines = append(lines, add)
+				} else {
+					pendingAdds = append(pendingAdds, add)
+				}
+			}
+		}
+		prevChanged = changed
+	}
+	flush()
+	return lines
+}
+
+// View renders the diff.
+func (d *Diff) View() string {
+	st := newDiffStyles()
+	headerStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(st.del.Render("--- "+d.leftTitle) + "\n")
+	b.WriteString(st.ins.Render("+++ "+d.rightTitle) + "\n")
+
+	if d.changes == 0 {
+		b.WriteString(headerStyle.Render("No differences"))
+		return b.String()
+	}
+
+	lines := d.lines
+	d.scroll = min(d.scroll, max(0, len(lines)-d.visibleLines()))
+	end := min(d.scroll+d.visibleLines(), len(lines))
+	for i := d.scroll; i < end; i++ {
+		b.WriteString(lines[i].text + "\n")
+	}
+
+	mode := "unified"
+	if d.mode == DiffSideBySide {
+		mode = "side-by-side"
+	}
+	b.WriteString(st.dim.Render(fmt.Sprintf("%d change(s) • %s • lines %d-%d of %d", d.changes, mode, d.scroll+1, end, len(lines))))
+	return b.String()
+}
@@ -0,0 +1,360 @@
+package components
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/theme"
+)
+
+// maxColumnWidth caps the width of a column in the column view.
+const maxColumnWidth = 30
+
+// ToggleColumnView switches between the item list with JSON details and the
+// column view, which shows top-level attributes side by side.
+func (r *DynamoDBQueryResults) ToggleColumnView() {
+	r.columnView = !r.columnView
+}
+
+// IsColumnView returns whether the column view is shown.
+func (r *DynamoDBQueryResults) IsColumnView() bool {
+	return r.columnView
+}
+
+// ColumnLeft moves the column cursor to the previous visible column.
+func (r *DynamoDBQueryResults) ColumnLeft() {
+	if r.colCursor > 0 {
+		r.colCursor--
+	}
+}
+
+// ColumnRight moves the column cursor to the next visible column.
+func (r *DynamoDBQueryResults) ColumnRight() {
+	if r.colCursor < len(r.visibleColumns())-1 {
+		r.colCursor++
+	}
+}
+
+// SelectedColumn returns the attribute under the column cursor.
+func (r *DynamoDBQueryResults) SelectedColumn() string {
+	cols := r.visibleColumns()
+	if r.colCursor >= 0 && r.colCursor < len(cols) {
+		return cols[r.colCursor]
+	}
+	return ""
+}
+
+// HideColumn hides the column under the cursor. The partition key stays, so
+// rows can always be told apart.
+func (r *DynamoDBQueryResults) HideColumn() {
+	col := r.SelectedColumn()
+	if col == "" || col == r.pkName {
+		return
+	}
+	r.hidden[col] = true
+	r.colCursor = min(r.colCursor, len(r.visibleColumns())-1)
+}
+
+// ShowAllColumns shows the hidden columns again.
+func (r *DynamoDBQueryResults) ShowAllColumns() {
+	clear(r.hidden)
+}
+
+// HiddenColumns returns the number of hidden columns.
+func (r *DynamoDBQueryResults) HiddenColumns() int {
+	return len(r.hidden)
+}
+
+// CycleSort sorts the loaded items by the column under the cursor: ascending,
+// then descending, then back to the order DynamoDB returned them in. The
+// selected item stays selected.
+func (r *DynamoDBQueryResults) CycleSort() {
+	col := r.SelectedColumn()
+	if col == "" {
+		return
+	}
+	switch {
+	case r.sortCol != col:
+		r.sortCol, r.sortDesc = col, false
+	case !r.sortDesc:
+		r.sortDesc = true
+	default:
+		r.sortCol, r.sortDesc = "", false
+	}
+
+	selected := -1
+	if r.cursor >= 0 && r.cursor < len(r.order) {
+		selected = r.order[r.cursor]
+	}
+	r.applySort()
+	if i := slices.Index(r.order, selected); i >= 0 {
+		r.cursor = i
+		visibleRows := max(1, r.height-4)
+		r.scrollOffset = max(0, min(r.scrollOffset, r.cursor), r.cursor-visibleRows+1)
+	}
+}
+
+// SortDescription describes the current sort, e.g. "createdAt ↓", or is
+// empty when the items are in the order DynamoDB returned them in.
+func (r *DynamoDBQueryResults) SortDescription() string {
+	if r.sortCol == "" {
+		return ""
+	}
+	return r.sortCol + " " + r.sortArrow()
+}
+
+// sortArrow shows the direction of the sort.
+func (r *DynamoDBQueryResults) sortArrow() string {
+	if r.sortDesc {
+		return theme.Symbol("↓", "v")
+	}
+	return theme.Symbol("↑", "^")
+}
+
+// indexColumns lists the top-level attributes of the items: the keys first,
+// then the others by how many items have them, most common first.
+func (r *DynamoDBQueryResults) indexColumns() {
+	counts := make(map[string]int)
+	for _, item := range r.items {
+		for name := range item.Raw {
+			counts[name]++
+		}
+	}
+
+	r.columns = r.columns[:0]
+	for _, key := range []string{r.pkName, r.skName} {
+		if key != "" {
+			r.columns = append(r.columns, key)
+			delete(counts, key)
+		}
+	}
+	rest := make([]string, 0, len(counts))
+	for name := range counts {
+		rest = append(rest, name)
+	}
+	slices.SortFunc(rest, func(a, b string) int {
+		return cmp.Or(counts[b]-counts[a], strings.Compare(a, b))
+	})
+	r.columns = append(r.columns, rest...)
+
+	if !slices.Contains(r.columns, r.sortCol) {
+		r.sortCol, r.sortDesc = "", false
+	}
+	r.colCursor = min(r.colCursor, max(0, len(r.visibleColumns())-1))
+}
+
+// applySort orders r.order by the sort column. Items missing the attribute
+// go last either way, and numbers compare as numbers.
+func (r *DynamoDBQueryResults) applySort() {
+	r.order = r.order[:0]
+	for i := range r.items {
+		r.order = append(r.order, i)
+	}
+	if r.sortCol == "" {
+		return
+	}
+	slices.SortStableFunc(r.order, func(a, b int) int {
+		va, oka := r.items[a].Raw[r.sortCol]
+		vb, okb := r.items[b].Raw[r.sortCol]
+		switch {
+		case !oka && !okb:
+			return 0
+		case !oka:
+			return 1
+		case !okb:
+			return -1
+		}
+		c := compareValues(va, vb)
+		if r.sortDesc {
+			c = -c
+		}
+		return c
+	})
+}
+
+// compareValues compares attribute values, numerically when both are numbers.
+// DynamoDB numbers arrive as strings to keep their precision.
+func compareValues(a, b interface{}) int {
+	sa, sb := cellValue(a), cellValue(b)
+	fa, erra := strconv.ParseFloat(sa, 64)
+	fb, errb := strconv.ParseFloat(sb, 64)
+	if erra == nil && errb == nil {
+		return cmp.Compare(fa, fb)
+	}
+	return strings.Compare(sa, sb)
+}
+
+// cellValue renders an attribute value on one line. Maps and lists are shown
+// as compact JSON.
+func cellValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strings.ReplaceAll(v, "\n", " ")
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// visibleColumns returns the columns that are not hidden.
+func (r *DynamoDBQueryResults) visibleColumns() []string {
+	cols := make([]string, 0, len(r.columns))
+	for _, c := range r.columns {
+		if !r.hidden[c] {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+// columnWidths sizes each column to its header and the values on screen.
+func (r *DynamoDBQueryResults) columnWidths(cols []string, start, end int) []int {
+	widths := make([]int, len(cols))
+	for i, col := range cols {
+		w := lipgloss.Width(col) + 2 // Room for the sort arrow
+		for _, idx := range r.order[start:end] {
+			if v, ok := r.items[idx].Raw[col]; ok {
+				w = max(w, lipgloss.Width(cellValue(v)))
+			}
+		}
+		widths[i] = min(max(w, 4), maxColumnWidth)
+	}
+	return widths
+}
+
+// renderColumns renders the items as a table with one column per attribute.
+// The partition key column stays in place while the others scroll
+// horizontally to keep the column cursor in view.
+func (r *DynamoDBQueryResults) renderColumns() string {
+	var b strings.Builder
+
+	statusStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
+	headerStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	columnCursorStyle := headerStyle.Underline(true)
+	selectedStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
+
+	cols := r.visibleColumns()
+	if len(cols) == 0 {
+		return r.renderList(r.width)
+	}
+	visibleRows := max(1, r.height-4)
+	endIdx := min(r.scrollOffset+visibleRows, len(r.order))
+	widths := r.columnWidths(cols, r.scrollOffset, endIdx)
+
+	// Keep the cursor column in view, scrolling columns after the first
+	fits := func(offset int) bool {
+		used := 2 + widths[0]
+		for i := offset; i <= r.colCursor; i++ {
+			used += 2 + widths[i]
+		}
+		return used <= r.width
+	}
+	r.colOffset = max(1, min(r.colOffset, r.colCursor))
+	for r.colOffset < r.colCursor && !fits(r.colOffset) {
+		r.colOffset++
+	}
+	shown := []int{0}
+	used := 2 + widths[0]
+	for i := r.colOffset; i < len(cols); i++ {
+		if used+2+widths[i] > r.width {
+			break
+		}
+		shown = append(shown, i)
+		used += 2 + widths[i]
+	}
+
+	// Status line
+	status := fmt.Sprintf("Items: %d", r.count)
+	if r.hasMorePages {
+		status += " (more available)"
+	}
+	status += fmt.Sprintf(" | %d columns", len(cols))
+	if len(shown) < len(cols) {
+		status += fmt.Sprintf(", %d off screen", len(cols)-len(shown))
+	}
+	if len(r.hidden) > 0 {
+		status += fmt.Sprintf(" (%d hidden)", len(r.hidden))
+	}
+	if sort := r.SortDescription(); sort != "" {
+		status += " | Sorted by " + sort
+	}
+	capacity := r.renderCapacity()
+	b.WriteString(statusStyle.Render(truncate(status, max(4, r.width-lipgloss.Width(capacity)))))
+	b.WriteString(capacity)
+	b.WriteString("\n")
+
+	// Column header, with the column under the cursor underlined
+	b.WriteString("  ")
+	for n, i := range shown {
+		name := cols[i]
+		if name == r.sortCol {
+			name += " " + r.sortArrow()
+		}
+		cell := fmt.Sprintf("%-*s", widths[i], truncate(name, widths[i]))
+		if i == r.colCursor {
+			b.WriteString(columnCursorStyle.Render(cell))
+		} else {
+			b.WriteString(headerStyle.Render(cell))
+		}
+		if n < len(shown)-1 {
+			b.WriteString("  ")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(strings.Repeat(theme.Symbol("─", "-"), r.width)))
+	b.WriteString("\n")
+
+	// Rows
+	for row := r.scrollOffset; row < endIdx; row++ {
+		item := r.items[r.order[row]]
+		isSelected := row == r.cursor
+
+		var line strings.Builder
+		if isSelected {
+			line.WriteString("> ")
+		} else {
+			line.WriteString("  ")
+		}
+		for n, i := range shown {
+			value := "-"
+			if v, ok := item.Raw[cols[i]]; ok {
+				value = cellValue(v)
+			}
+			line.WriteString(fmt.Sprintf("%-*s", widths[i], truncate(value, widths[i])))
+			if n < len(shown)-1 {
+				line.WriteString("  ")
+			}
+		}
+
+		if isSelected {
+			b.WriteString(selectedStyle.Render(line.String()))
+		} else {
+			b.WriteString(normalStyle.Render(line.String()))
+		}
+		if row < endIdx-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	// Pad remaining space
+	for i := endIdx - r.scrollOffset + 3; i < r.height; i++ {
+		b.WriteString("\n")
+	}
+
+	return lipgloss.NewStyle().Width(r.width).Render(b.String())
+}
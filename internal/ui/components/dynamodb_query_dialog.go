@@ -30,6 +30,18 @@ type DynamoDBQueryDialog struct {
 	filterValInput  textinput.Model
 	skCondition     int // Index into skConditions
 	filterCondition int // Index into filterConditions
+	history         []QueryHistoryEntry
+	picking         bool // Showing the history picker instead of the form
+	pickCursor      int
+}
+
+// QueryHistoryEntry is a recent or saved query offered by the dialog's
+// history picker.
+type QueryHistoryEntry struct {
+	Label       string
+	Saved       bool
+	QueryParams *model.QueryParams
+	ScanParams  *model.ScanParams
 }
 
 var skConditions = []struct {
@@ -110,6 +122,8 @@ func (d *DynamoDBQueryDialog) Activate(tableName, pkName, skName string, isQuery
 	d.focusIndex = 0
 	d.skCondition = 0
 	d.filterCondition = 0
+	d.picking = false
+	d.pickCursor = 0
 
 	// Reset inputs
 	d.pkInput.SetValue("")
@@ -128,6 +142,13 @@ func (d *DynamoDBQueryDialog) Activate(tableName, pkName, skName string, isQuery
 	return textinput.Blink
 }
 
+// SetHistory sets the recent and saved queries of the table, offered with
+// ctrl+r. Call it after Activate.
+func (d *DynamoDBQueryDialog) SetHistory(entries []QueryHistoryEntry) {
+	d.history = entries
+	d.pickCursor = 0
+}
+
 // Deactivate hides the dialog.
 func (d *DynamoDBQueryDialog) Deactivate() {
 	d.active = false
@@ -161,9 +182,19 @@ func (d *DynamoDBQueryDialog) Update(msg tea.Msg) (*QueryDialogResult, tea.Cmd)
 		return nil, nil
 	}
 
+	if key, ok := msg.(tea.KeyMsg); ok && d.picking {
+		return d.updatePicker(key), nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
+		case "ctrl+r":
+			if len(d.history) > 0 {
+				d.picking = true
+			}
+			return nil, nil
+
 		case "enter":
 			// Execute query/scan
 			result := d.buildResult()
@@ -237,6 +268,70 @@ func (d *DynamoDBQueryDialog) Update(msg tea.Msg) (*QueryDialogResult, tea.Cmd)
 	return nil, cmd
 }
 
+// updatePicker handles keys in the history picker: enter runs the selected
+// query, tab loads it into the form to edit first.
+func (d *DynamoDBQueryDialog) updatePicker(msg tea.KeyMsg) *QueryDialogResult {
+	switch msg.String() {
+	case "up", "k", "shift+tab":
+		if d.pickCursor > 0 {
+			d.pickCursor--
+		}
+	case "down", "j":
+		if d.pickCursor < len(d.history)-1 {
+			d.pickCursor++
+		}
+	case "tab":
+		d.fill(d.history[d.pickCursor])
+		d.picking = false
+	case "enter":
+		d.fill(d.history[d.pickCursor])
+		d.picking = false
+		result := d.buildResult()
+		d.Deactivate()
+		return result
+	case "esc", "ctrl+r":
+		d.picking = false
+	}
+	return nil
+}
+
+// fill loads a query from the history into the form.
+func (d *DynamoDBQueryDialog) fill(entry QueryHistoryEntry) {
+	var pk, sk, filterExpr, filterAttr, filterVal string
+	var skCond model.SortKeyCondition
+	var limit int32
+	if q := entry.QueryParams; q != nil {
+		d.isQuery = true
+		pk, sk, skCond = q.PartitionKeyVal, q.SortKeyVal, q.SortKeyCondition
+		filterExpr, filterAttr, filterVal, limit = q.FilterExpression, q.FilterAttrName, q.FilterAttrValue, q.Limit
+	} else if s := entry.ScanParams; s != nil {
+		d.isQuery = false
+		filterExpr, filterAttr, filterVal, limit = s.FilterExpression, s.FilterAttrName, s.FilterAttrValue, s.Limit
+	}
+
+	d.pkInput.SetValue(pk)
+	d.skInput.SetValue(sk)
+	d.limitInput.SetValue("")
+	if limit > 0 {
+		d.limitInput.SetValue(strconv.Itoa(int(limit)))
+	}
+	d.filterAttrInput.SetValue(filterAttr)
+	d.filterValInput.SetValue(filterVal)
+
+	d.skCondition = 0
+	for i, c := range skConditions {
+		if c.value == skCond {
+			d.skCondition = i
+		}
+	}
+	d.filterCondition = 0
+	for i, c := range filterConditions {
+		if fmt.Sprintf(c.expr, "#filterAttr", ":filterVal") == filterExpr {
+			d.filterCondition = i
+		}
+	}
+}
+
 // Field layout for Query with SK:
 // 0: PK, 1: SK, 2: SK condition, 3: Limit, 4: Filter attr, 5: Filter condition, 6: Filter value
 // Field layout for Query without SK:
@@ -384,7 +479,7 @@ func (d *DynamoDBQueryDialog) View() string {
 	}
 
 	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BorderStyle()).
 		BorderForeground(theme.BorderFocus).
 		Padding(1, 2).
 		Width(dialogWidth)
@@ -411,6 +506,10 @@ func (d *DynamoDBQueryDialog) View() string {
 
 	var b strings.Builder
 
+	if d.picking {
+		return boxStyle.Render(d.renderPicker(dialogWidth - 6))
+	}
+
 	// Title
 	if d.isQuery {
 		b.WriteString(titleStyle.Render(fmt.Sprintf("Query: %s", d.tableName)))
@@ -463,7 +562,7 @@ func (d *DynamoDBQueryDialog) View() string {
 	sectionStyle := lipgloss.NewStyle().
 		Foreground(theme.TextDim).
 		Bold(true)
-	b.WriteString(sectionStyle.Render("── Filter (optional) ──"))
+	b.WriteString(sectionStyle.Render(theme.Symbol("── Filter (optional) ──", "-- Filter (optional) --")))
 	b.WriteString("\n\n")
 
 	// Filter attribute input
@@ -496,6 +595,10 @@ func (d *DynamoDBQueryDialog) View() string {
 
 	// Hints
 	b.WriteString(hintStyle.Render("Tab: next field | Enter: execute | Esc: cancel"))
+	if len(d.history) > 0 {
+		b.WriteString("\n")
+		b.WriteString(hintStyle.Render(fmt.Sprintf("Ctrl+R: recent and saved queries (%d)", len(d.history))))
+	}
 	if d.isOnSKCondition() || d.isOnFilterCondition() {
 		b.WriteString("\n")
 		b.WriteString(hintStyle.Render("Left/Right: change condition"))
@@ -503,3 +606,43 @@ func (d *DynamoDBQueryDialog) View() string {
 
 	return boxStyle.Render(b.String())
 }
+
+// renderPicker renders the recent and saved queries of the table.
+func (d *DynamoDBQueryDialog) renderPicker(width int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	savedStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	normalStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	hintStyle := lipgloss.NewStyle().Foreground(theme.TextDim).Italic(true)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Queries: " + d.tableName))
+	b.WriteString("\n\n")
+
+	// Keep the cursor in view
+	rows := max(3, d.height-14)
+	start := max(0, d.pickCursor-rows+1)
+	end := min(len(d.history), start+rows)
+	for i := start; i < end; i++ {
+		entry := d.history[i]
+		prefix := "  "
+		if i == d.pickCursor {
+			prefix = theme.Symbol("▸ ", "> ")
+		}
+		marker := "  "
+		if entry.Saved {
+			marker = savedStyle.Render(theme.Symbol("★ ", "* "))
+		}
+		label := truncate(entry.Label, width-4)
+		if i == d.pickCursor {
+			b.WriteString(prefix + marker + selectedStyle.Render(label))
+		} else {
+			b.WriteString(prefix + marker + normalStyle.Render(label))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("Enter: run | Tab: edit first | Esc: back"))
+	return b.String()
+}
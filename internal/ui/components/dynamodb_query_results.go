@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"vaws/internal/model"
+	"vaws/internal/ui/format"
 	"vaws/internal/ui/theme"
 )
 
@@ -20,18 +21,31 @@ type DynamoDBQueryResults struct {
 	hasMorePages bool
 	count        int
 	scannedCount int
-	capacity     float64
+	capacity     float64 // Read capacity consumed by the page shown
+	total        float64 // Read capacity consumed by all pages loaded
+	pages        int
 	tableName    string
 	pkName       string
 	skName       string
 	loading      bool
 	err          error
-	jsonScroll   int // Scroll offset for JSON panel
+	jsonScroll   int       // Scroll offset for JSON panel when the item isn't valid JSON
+	jsonTree     *JSONTree // Tree view of the selected item
+
+	// Column view
+	columnView bool
+	columns    []string        // Top-level attributes, keys first
+	hidden     map[string]bool // Columns hidden with x
+	colCursor  int             // Index into the visible columns
+	colOffset  int             // First scrolled column shown after the partition key
+	sortCol    string          // Attribute the items are sorted by, empty for DynamoDB's order
+	sortDesc   bool
+	order      []int // Display order of items, as indexes into items
 }
 
 // NewDynamoDBQueryResults creates a new results panel.
 func NewDynamoDBQueryResults() *DynamoDBQueryResults {
-	return &DynamoDBQueryResults{}
+	return &DynamoDBQueryResults{jsonTree: NewJSONTree(), hidden: make(map[string]bool)}
 }
 
 // SetSize sets the panel size.
@@ -42,11 +56,17 @@ func (r *DynamoDBQueryResults) SetSize(width, height int) {
 
 // SetResult sets the query result.
 func (r *DynamoDBQueryResults) SetResult(result *model.QueryResult, tableName, pkName, skName string) {
+	if tableName != r.tableName {
+		clear(r.hidden)
+		r.colCursor = 0
+	}
 	r.items = result.Items
 	r.hasMorePages = result.HasMorePages
 	r.count = result.Count
 	r.scannedCount = result.ScannedCount
 	r.capacity = result.ConsumedCapacity
+	r.total += result.ConsumedCapacity
+	r.pages++
 	r.tableName = tableName
 	r.pkName = pkName
 	r.skName = skName
@@ -55,6 +75,8 @@ func (r *DynamoDBQueryResults) SetResult(result *model.QueryResult, tableName, p
 	r.jsonScroll = 0
 	r.loading = false
 	r.err = nil
+	r.indexColumns()
+	r.applySort()
 }
 
 // SetLoading sets the loading state.
@@ -71,6 +93,7 @@ func (r *DynamoDBQueryResults) SetError(err error) {
 // Clear clears the results.
 func (r *DynamoDBQueryResults) Clear() {
 	r.items = nil
+	r.order = nil
 	r.cursor = 0
 	r.scrollOffset = 0
 	r.jsonScroll = 0
@@ -78,6 +101,8 @@ func (r *DynamoDBQueryResults) Clear() {
 	r.count = 0
 	r.scannedCount = 0
 	r.capacity = 0
+	r.total = 0
+	r.pages = 0
 }
 
 // Up moves the cursor up.
@@ -124,36 +149,57 @@ func (r *DynamoDBQueryResults) Bottom() {
 	}
 }
 
-// ScrollJSONUp scrolls the JSON panel up.
+// ScrollJSONUp moves up in the JSON panel.
 func (r *DynamoDBQueryResults) ScrollJSONUp() {
+	r.JSONTree().Up()
 	if r.jsonScroll > 0 {
 		r.jsonScroll--
 	}
 }
 
-// ScrollJSONDown scrolls the JSON panel down.
+// ScrollJSONDown moves down in the JSON panel.
 func (r *DynamoDBQueryResults) ScrollJSONDown() {
+	r.JSONTree().Down()
 	r.jsonScroll++
 }
 
-// ScrollJSONHalfPageUp scrolls the JSON panel up by half a page.
+// ScrollJSONHalfPageUp moves up in the JSON panel by half a page.
 func (r *DynamoDBQueryResults) ScrollJSONHalfPageUp() {
 	halfPage := (r.height - 10) / 2
 	if halfPage < 1 {
 		halfPage = 1
 	}
+	r.JSONTree().HalfPageUp()
 	r.jsonScroll = max(0, r.jsonScroll-halfPage)
 }
 
-// ScrollJSONHalfPageDown scrolls the JSON panel down by half a page.
+// ScrollJSONHalfPageDown moves down in the JSON panel by half a page.
 func (r *DynamoDBQueryResults) ScrollJSONHalfPageDown() {
 	halfPage := (r.height - 10) / 2
 	if halfPage < 1 {
 		halfPage = 1
 	}
+	r.JSONTree().HalfPageDown()
 	r.jsonScroll += halfPage
 }
 
+// JSONTree returns the tree view of the selected item.
+func (r *DynamoDBQueryResults) JSONTree() *JSONTree {
+	r.syncJSONTree()
+	return r.jsonTree
+}
+
+// syncJSONTree loads the selected item into the JSON tree. It reports false
+// if the item can't be shown as a tree.
+func (r *DynamoDBQueryResults) syncJSONTree() bool {
+	item := r.SelectedItem()
+	if item == nil {
+		r.jsonTree.Clear()
+		return false
+	}
+	return r.jsonTree.SetJSON(item.JSON) == nil
+}
+
 // SelectedJSON returns the JSON representation of the selected item.
 func (r *DynamoDBQueryResults) SelectedJSON() string {
 	item := r.SelectedItem()
@@ -165,8 +211,8 @@ func (r *DynamoDBQueryResults) SelectedJSON() string {
 
 // SelectedItem returns the currently selected item.
 func (r *DynamoDBQueryResults) SelectedItem() *model.DynamoDBItem {
-	if r.cursor >= 0 && r.cursor < len(r.items) {
-		return &r.items[r.cursor]
+	if r.cursor >= 0 && r.cursor < len(r.order) {
+		return &r.items[r.order[r.cursor]]
 	}
 	return nil
 }
@@ -195,6 +241,9 @@ func (r *DynamoDBQueryResults) View() string {
 		return r.renderEmpty()
 	}
 
+	if r.columnView {
+		return r.renderColumns()
+	}
 	return r.renderResults()
 }
 
@@ -238,7 +287,7 @@ func (r *DynamoDBQueryResults) renderResults() string {
 
 	// Separator
 	separatorStyle := lipgloss.NewStyle().Foreground(theme.Border)
-	separator := separatorStyle.Render(strings.Repeat("│\n", r.height))
+	separator := separatorStyle.Render(strings.Repeat(theme.Symbol("│", "|")+"\n", r.height))
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, listView, separator, jsonView)
 }
@@ -259,10 +308,8 @@ func (r *DynamoDBQueryResults) renderList(width int) string {
 	if r.hasMorePages {
 		status += " (more available)"
 	}
-	if r.capacity > 0 {
-		status += fmt.Sprintf(" | RCU: %.2f", r.capacity)
-	}
 	b.WriteString(statusStyle.Render(status))
+	b.WriteString(r.renderCapacity())
 	b.WriteString("\n")
 
 	// Column header
@@ -283,7 +330,7 @@ func (r *DynamoDBQueryResults) renderList(width int) string {
 	b.WriteString("\n")
 
 	dimStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
-	b.WriteString(dimStyle.Render(strings.Repeat("─", width)))
+	b.WriteString(dimStyle.Render(strings.Repeat(theme.Symbol("─", "-"), width)))
 	b.WriteString("\n")
 
 	// Items
@@ -305,7 +352,7 @@ func (r *DynamoDBQueryResults) renderList(width int) string {
 	}
 
 	for i := r.scrollOffset; i < endIdx; i++ {
-		item := r.items[i]
+		item := r.items[r.order[i]]
 		isSelected := i == r.cursor
 
 		// Cursor
@@ -373,9 +420,16 @@ func (r *DynamoDBQueryResults) renderJSON(width int) string {
 	b.WriteString("\n")
 
 	dimStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
-	b.WriteString(dimStyle.Render(strings.Repeat("─", width)))
+	b.WriteString(dimStyle.Render(strings.Repeat(theme.Symbol("─", "-"), width)))
 	b.WriteString("\n")
 
+	// Collapsible tree when the item parses, plain highlighted lines otherwise
+	if r.syncJSONTree() {
+		r.jsonTree.SetSize(width-1, r.height-2)
+		b.WriteString(r.jsonTree.View())
+		return lipgloss.NewStyle().Width(width).Render(b.String())
+	}
+
 	// JSON content with syntax highlighting
 	jsonLines := strings.Split(item.JSON, "\n")
 
@@ -507,3 +561,35 @@ func highlightJSONLine(line string, keyStyle, stringStyle, numberStyle, boolStyl
 
 	return result.String()
 }
+
+// renderCapacity renders the read capacity consumed by the page shown and
+// what it costs on demand, with the total once more pages are loaded.
+func (r *DynamoDBQueryResults) renderCapacity() string {
+	if r.capacity <= 0 {
+		return ""
+	}
+	capacityStyle := lipgloss.NewStyle().Foreground(theme.Info).Bold(true)
+	text := fmt.Sprintf("%s RCU %s", FormatReadUnits(r.capacity), FormatReadCost(r.capacity))
+	if r.pages > 1 {
+		text += fmt.Sprintf(", %s RCU %s over %d pages", FormatReadUnits(r.total), FormatReadCost(r.total), r.pages)
+	}
+	return lipgloss.NewStyle().Foreground(theme.TextDim).Render(" | ") + capacityStyle.Render(text)
+}
+
+// FormatReadUnits renders read capacity units, e.g. "12.5" or "1.2M".
+func FormatReadUnits(units float64) string {
+	if units < 1000 {
+		return fmt.Sprintf("%.1f", units)
+	}
+	return format.Count(int64(units))
+}
+
+// FormatReadCost renders what read capacity units cost on demand, e.g.
+// "(≈ $0.19)". Costs below a cent are shown as such.
+func FormatReadCost(units float64) string {
+	cost := model.ReadCost(units)
+	if cost < 0.01 {
+		return "(< $0.01)"
+	}
+	return fmt.Sprintf("(%s $%.2f)", theme.Symbol("≈", "~"), cost)
+}
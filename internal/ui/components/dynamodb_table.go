@@ -7,18 +7,23 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"vaws/internal/model"
+	"vaws/internal/ui/format"
 	"vaws/internal/ui/theme"
 )
 
 // DynamoDBTable displays DynamoDB tables in a simple table format.
 type DynamoDBTable struct {
-	width   int
-	height  int
-	tables  []model.Table
-	cursor  int
-	loading bool
-	err     error
-	spinner *Spinner
+	width      int
+	height     int
+	tables     []model.Table
+	cursor     int
+	loading    bool
+	refreshing bool // Keep showing the current rows while loading
+	err        error
+	spinner    *Spinner
+
+	selected string // Name of the table the user last moved to
+	filter   string // Filter being typed, underlined in table names
 }
 
 // NewDynamoDBTable creates a new DynamoDBTable.
@@ -28,25 +33,62 @@ func NewDynamoDBTable() *DynamoDBTable {
 	}
 }
 
+// SetFilterHighlight sets the text underlined where it appears in table
+// names, or clears it when empty.
+func (t *DynamoDBTable) SetFilterHighlight(query string) {
+	t.filter = query
+}
+
 // SetSize sets the table dimensions.
 func (t *DynamoDBTable) SetSize(width, height int) {
 	t.width = width
 	t.height = height
 }
 
-// SetTables sets the table list.
+// SetTables sets the table list. The cursor stays on the selected table if it is
+// still present.
 func (t *DynamoDBTable) SetTables(tables []model.Table) {
+	selected := t.selected
+	if selected == "" {
+		if cur := t.SelectedTable(); cur != nil {
+			selected = cur.Name
+		}
+	}
+
 	t.tables = tables
+	for i := range tables {
+		if selected != "" && tables[i].Name == selected {
+			t.cursor = i
+			return
+		}
+	}
 	if t.cursor >= len(tables) {
 		t.cursor = max(0, len(tables)-1)
 	}
 }
 
+// rememberSelection records the table under the cursor after the user moves it.
+func (t *DynamoDBTable) rememberSelection() {
+	t.selected = ""
+	if cur := t.SelectedTable(); cur != nil {
+		t.selected = cur.Name
+	}
+}
+
 // SetLoading sets the loading state.
 func (t *DynamoDBTable) SetLoading(loading bool) {
+	if !loading {
+		t.refreshing = false
+	}
 	t.loading = loading
 }
 
+// SetRefreshing marks the next load as a refresh of the current rows, which
+// stay visible while it runs. It has no effect on an empty table.
+func (t *DynamoDBTable) SetRefreshing(refreshing bool) {
+	t.refreshing = refreshing && len(t.tables) > 0
+}
+
 // SetError sets the error state.
 func (t *DynamoDBTable) SetError(err error) {
 	t.err = err
@@ -74,6 +116,7 @@ func (t *DynamoDBTable) SelectedTable() *model.Table {
 func (t *DynamoDBTable) Up() {
 	if t.cursor > 0 {
 		t.cursor--
+		t.rememberSelection()
 	}
 }
 
@@ -81,12 +124,14 @@ func (t *DynamoDBTable) Up() {
 func (t *DynamoDBTable) Down() {
 	if t.cursor < len(t.tables)-1 {
 		t.cursor++
+		t.rememberSelection()
 	}
 }
 
 // Top moves the cursor to the top.
 func (t *DynamoDBTable) Top() {
 	t.cursor = 0
+	t.rememberSelection()
 }
 
 // Bottom moves the cursor to the bottom.
@@ -94,6 +139,7 @@ func (t *DynamoDBTable) Bottom() {
 	if len(t.tables) > 0 {
 		t.cursor = len(t.tables) - 1
 	}
+	t.rememberSelection()
 }
 
 // TableCount returns the number of tables.
@@ -103,7 +149,7 @@ func (t *DynamoDBTable) TableCount() int {
 
 // View renders the DynamoDB table.
 func (t *DynamoDBTable) View() string {
-	if t.loading {
+	if t.loading && !t.refreshing {
 		return t.renderLoading()
 	}
 
@@ -193,7 +239,7 @@ func (t *DynamoDBTable) renderTable() string {
 	)
 	b.WriteString(headerStyle.Render(header))
 	b.WriteString("\n")
-	b.WriteString(dimStyle.Render(strings.Repeat("─", totalWidth+2)))
+	b.WriteString(dimStyle.Render(strings.Repeat(theme.Symbol("─", "-"), totalWidth+2)))
 	b.WriteString("\n")
 
 	// Calculate visible rows (accounting for top margin, header, separator)
@@ -245,7 +291,7 @@ func (t *DynamoDBTable) renderTable() string {
 		}
 
 		// Items count
-		itemsStr := formatCount(tbl.ItemCount)
+		itemsStr := format.Count(tbl.ItemCount)
 
 		// Size
 		sizeStr := formatSize(tbl.SizeBytes)
@@ -261,7 +307,7 @@ func (t *DynamoDBTable) renderTable() string {
 		paddedName := fmt.Sprintf("%-*s", nameWidth, name)
 
 		if isSelected {
-			b.WriteString(selectedStyle.Render(cursor + paddedName))
+			b.WriteString(selectedStyle.Render(cursor) + highlightMatch(paddedName, t.filter, selectedStyle))
 			// Render remaining columns without selection styling
 			rest := fmt.Sprintf("  %s  %*s  %*s  %-*s",
 				statusStr,
@@ -273,7 +319,7 @@ func (t *DynamoDBTable) renderTable() string {
 		} else {
 			row := fmt.Sprintf("%s%s  %s  %*s  %*s  %-*s",
 				cursor,
-				paddedName,
+				highlightMatch(paddedName, t.filter, lipgloss.NewStyle()),
 				statusStr,
 				itemsWidth, itemsStr,
 				sizeWidth, sizeStr,
@@ -290,17 +336,6 @@ func (t *DynamoDBTable) renderTable() string {
 	return b.String()
 }
 
-// formatCount formats large numbers with K/M suffixes.
-func formatCount(n int64) string {
-	if n >= 1_000_000 {
-		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
-	}
-	if n >= 1_000 {
-		return fmt.Sprintf("%.1fK", float64(n)/1_000)
-	}
-	return fmt.Sprintf("%d", n)
-}
-
 // formatSize formats bytes into human-readable sizes.
 func formatSize(bytes int64) string {
 	const (
@@ -0,0 +1,226 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// ImagesTable displays the images of ECS services next to the last image
+// pushed to their repositories.
+type ImagesTable struct {
+	width      int
+	height     int
+	images     []model.ServiceImage
+	cursor     int
+	loading    bool
+	refreshing bool // Keep showing the current rows while loading
+	err        error
+	spinner    *Spinner
+}
+
+// NewImagesTable creates a new ImagesTable.
+func NewImagesTable() *ImagesTable {
+	return &ImagesTable{
+		spinner: NewSpinner(),
+	}
+}
+
+// SetSize sets the table dimensions.
+func (t *ImagesTable) SetSize(width, height int) {
+	t.width = width
+	t.height = height
+}
+
+// SetImages sets the rows. The cursor stays on the selected container if it
+// is still present.
+func (t *ImagesTable) SetImages(images []model.ServiceImage) {
+	var service, container string
+	if cur := t.SelectedImage(); cur != nil {
+		service, container = cur.Service, cur.Container
+	}
+
+	t.images = images
+	for i := range images {
+		if images[i].Service == service && images[i].Container == container {
+			t.cursor = i
+			return
+		}
+	}
+	if t.cursor >= len(images) {
+		t.cursor = max(0, len(images)-1)
+	}
+}
+
+// SetLoading sets the loading state.
+func (t *ImagesTable) SetLoading(loading bool) {
+	if !loading {
+		t.refreshing = false
+	}
+	t.loading = loading
+}
+
+// SetRefreshing marks the next load as a refresh of the current rows, which
+// stay visible while it runs. It has no effect on an empty table.
+func (t *ImagesTable) SetRefreshing(refreshing bool) {
+	t.refreshing = refreshing && len(t.images) > 0
+}
+
+// SetError sets the error state.
+func (t *ImagesTable) SetError(err error) {
+	t.err = err
+}
+
+// Spinner returns the spinner for loading animation.
+func (t *ImagesTable) Spinner() *Spinner {
+	return t.spinner
+}
+
+// SelectedImage returns the row under the cursor.
+func (t *ImagesTable) SelectedImage() *model.ServiceImage {
+	if t.cursor >= 0 && t.cursor < len(t.images) {
+		return &t.images[t.cursor]
+	}
+	return nil
+}
+
+// Up moves the cursor up.
+func (t *ImagesTable) Up() {
+	if t.cursor > 0 {
+		t.cursor--
+	}
+}
+
+// Down moves the cursor down.
+func (t *ImagesTable) Down() {
+	if t.cursor < len(t.images)-1 {
+		t.cursor++
+	}
+}
+
+// Top moves the cursor to the top.
+func (t *ImagesTable) Top() {
+	t.cursor = 0
+}
+
+// Bottom moves the cursor to the bottom.
+func (t *ImagesTable) Bottom() {
+	t.cursor = max(0, len(t.images)-1)
+}
+
+// ImageStatus describes how a running image compares with its repository:
+// "stale", "current", or why it couldn't be compared.
+func ImageStatus(img model.ServiceImage) string {
+	switch {
+	case img.Stale():
+		return "stale"
+	case img.Error != "":
+		return "unknown"
+	case img.Digest == "" || img.LatestDigest == "":
+		return "-"
+	default:
+		return "current"
+	}
+}
+
+// View renders the images table.
+func (t *ImagesTable) View() string {
+	box := lipgloss.NewStyle().
+		Width(t.width).
+		Height(t.height).
+		Align(lipgloss.Center, lipgloss.Center)
+
+	switch {
+	case t.loading && !t.refreshing:
+		return box.Render(lipgloss.NewStyle().Foreground(theme.Primary).Render(t.spinner.View() + " Checking images..."))
+	case t.err != nil:
+		return box.Render(lipgloss.NewStyle().Foreground(theme.Error).Render("Error: " + t.err.Error()))
+	case len(t.images) == 0:
+		return box.Render(lipgloss.NewStyle().Foreground(theme.TextDim).Render("No containers found"))
+	}
+	return t.renderTable()
+}
+
+func (t *ImagesTable) renderTable() string {
+	var b strings.Builder
+	b.WriteString("\n")
+
+	tagWidth := 20
+	statusWidth := 8
+	ageWidth := 10
+	deployWidth := 10
+	nameWidth := min(max(t.width-tagWidth-statusWidth-ageWidth-deployWidth-12, 20), 60)
+	totalWidth := nameWidth + tagWidth + statusWidth + ageWidth + deployWidth + 8
+
+	headerStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
+	selectedStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	staleStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	currentStyle := lipgloss.NewStyle().Foreground(theme.Success)
+
+	header := fmt.Sprintf("  %-*s  %-*s  %-*s  %*s  %*s",
+		nameWidth, "SERVICE / CONTAINER",
+		tagWidth, "TAG",
+		statusWidth, "STATUS",
+		ageWidth, "IMAGE AGE",
+		deployWidth, "DEPLOYED",
+	)
+	b.WriteString(headerStyle.Render(header))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(strings.Repeat(theme.Symbol("─", "-"), totalWidth+2)))
+	b.WriteString("\n")
+
+	maxRows := max(1, t.height-4)
+	startIdx := 0
+	if t.cursor >= maxRows {
+		startIdx = t.cursor - maxRows + 1
+	}
+	endIdx := min(startIdx+maxRows, len(t.images))
+
+	age := func(at time.Time) string {
+		if at.IsZero() {
+			return "-"
+		}
+		return format.Age(time.Since(at))
+	}
+	for i := startIdx; i < endIdx; i++ {
+		img := t.images[i]
+		cursor := "  "
+		if i == t.cursor {
+			cursor = "> "
+		}
+		tag := img.Tag
+		if tag == "" {
+			tag = "-"
+		}
+
+		left := fmt.Sprintf("%s%-*s  %-*s  ", cursor,
+			nameWidth, truncate(img.Service+"/"+img.Container, nameWidth),
+			tagWidth, truncate(tag, tagWidth),
+		)
+		status := fmt.Sprintf("%-*s", statusWidth, ImageStatus(img))
+		right := fmt.Sprintf("  %*s  %*s", ageWidth, age(img.PushedAt), deployWidth, age(img.DeployedAt))
+
+		switch {
+		case i == t.cursor:
+			b.WriteString(selectedStyle.Render(left + status + right))
+		case img.Stale():
+			b.WriteString(left + staleStyle.Render(status) + right)
+		case ImageStatus(img) == "current":
+			b.WriteString(left + currentStyle.Render(status) + right)
+		default:
+			b.WriteString(left + dimStyle.Render(status) + right)
+		}
+		if i < endIdx-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
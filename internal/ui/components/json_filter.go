@@ -0,0 +1,72 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/itchyny/gojq"
+)
+
+const (
+	// jsonFilterTimeout bounds a run of a jq expression. Expressions run on
+	// every keystroke, so one that never ends mustn't hang the UI.
+	jsonFilterTimeout = 500 * time.Millisecond
+
+	// jsonFilterMaxResults caps the outputs of an expression that are shown,
+	// e.g. of repeat(.).
+	jsonFilterMaxResults = 10000
+)
+
+// filterJSON runs the jq expression expr on the JSON document raw and
+// returns its output as JSON: the output itself if there is one, or an
+// array of the outputs otherwise.
+func filterJSON(raw, expr string) (string, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return "", fmt.Errorf("invalid expression: %w", err)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+	var input any
+	if err := dec.Decode(&input); err != nil {
+		return "", fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jsonFilterTimeout)
+	defer cancel()
+
+	results := []any{}
+	iter := query.RunWithContext(ctx, input)
+	for len(results) < jsonFilterMaxResults {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			var halt *gojq.HaltError
+			if errors.As(err, &halt) && halt.Value() == nil {
+				break
+			}
+			if errors.Is(err, context.DeadlineExceeded) {
+				return "", fmt.Errorf("expression ran longer than %s", jsonFilterTimeout)
+			}
+			return "", err
+		}
+		results = append(results, v)
+	}
+
+	var out any = results
+	if len(results) == 1 {
+		out = results[0]
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode output: %w", err)
+	}
+	return string(b), nil
+}
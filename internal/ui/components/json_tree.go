@@ -0,0 +1,654 @@
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/theme"
+)
+
+// jsonKind is the type of a node in a JSONTree.
+type jsonKind int
+
+const (
+	jsonObject jsonKind = iota
+	jsonArray
+	jsonString
+	jsonNumber
+	jsonBool
+	jsonNull
+)
+
+// jsonTreeDefaultDepth is how many levels are expanded when JSON is loaded.
+const jsonTreeDefaultDepth = 3
+
+// jsonNode is a single value in a JSONTree.
+type jsonNode struct {
+	key      string // Object field name; empty for array elements and the root
+	index    int    // Array index, or -1 if not an array element
+	kind     jsonKind
+	value    string // Rendered scalar value
+	children []*jsonNode
+	parent   *jsonNode
+	depth    int
+	expanded bool
+}
+
+// isContainer returns true for objects and arrays.
+func (n *jsonNode) isContainer() bool {
+	return n.kind == jsonObject || n.kind == jsonArray
+}
+
+// path returns the node's JSONPath, e.g. $.items[3].id.
+func (n *jsonNode) path() string {
+	if n.parent == nil {
+		return "$"
+	}
+	p := n.parent.path()
+	if n.index >= 0 {
+		return fmt.Sprintf("%s[%d]", p, n.index)
+	}
+	if isJSONIdentifier(n.key) {
+		return p + "." + n.key
+	}
+	return p + "[" + strconv.Quote(n.key) + "]"
+}
+
+// isJSONIdentifier reports whether a key can be written in dot notation.
+func isJSONIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			continue
+		}
+		if i > 0 && r >= '0' && r <= '9' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// JSONTree is a collapsible tree view of a JSON document.
+type JSONTree struct {
+	raw          string
+	root         *jsonNode
+	visible      []*jsonNode // Nodes shown with the current expansion state
+	cursor       int
+	scrollOffset int
+	width        int
+	height       int
+
+	// Search state
+	searchQuery   string
+	searchMatches []*jsonNode
+	searchIndex   int
+
+	// Filter state: the jq expression the document is reduced by, the JSON
+	// the tree shows (raw if unfiltered) and why the expression last failed
+	filter    string
+	shown     string
+	filterErr error
+}
+
+// NewJSONTree creates a new JSONTree.
+func NewJSONTree() *JSONTree {
+	return &JSONTree{}
+}
+
+// SetJSON parses raw JSON into the tree. The expansion state is kept if the
+// document is unchanged, so re-rendering the same content doesn't collapse it.
+func (t *JSONTree) SetJSON(raw string) error {
+	if raw == t.raw && t.root != nil {
+		return nil
+	}
+
+	root, err := parseJSONTree(raw)
+	if err != nil {
+		return err
+	}
+
+	t.raw = raw
+	t.load(raw, root)
+	if t.filter != "" {
+		t.applyFilter()
+	}
+	return nil
+}
+
+// load shows the document doc, parsed as root, from the top.
+func (t *JSONTree) load(doc string, root *jsonNode) {
+	t.shown = doc
+	t.root = root
+	t.cursor = 0
+	t.scrollOffset = 0
+	t.searchQuery = ""
+	t.searchMatches = nil
+	t.searchIndex = 0
+	t.rebuild()
+}
+
+// Raw returns the JSON the tree was built from, before any filter.
+func (t *JSONTree) Raw() string {
+	return t.raw
+}
+
+// SetFilter reduces the document to the output of the jq expression expr,
+// or shows it whole if expr is empty. The filter stays set for documents
+// loaded after, such as the next query result. An expression that doesn't
+// parse or fails on the document leaves the tree showing what it did, the
+// whole document if it was just loaded; FilterError tells why.
+func (t *JSONTree) SetFilter(expr string) {
+	t.filter = strings.TrimSpace(expr)
+	t.filterErr = nil
+	if t.raw == "" {
+		return
+	}
+	if t.filter == "" {
+		if t.shown != t.raw {
+			root, _ := parseJSONTree(t.raw)
+			t.load(t.raw, root)
+		}
+		return
+	}
+	t.applyFilter()
+}
+
+// applyFilter shows the output of the filter on the document.
+func (t *JSONTree) applyFilter() {
+	out, err := filterJSON(t.raw, t.filter)
+	if err != nil {
+		t.filterErr = err
+		return
+	}
+	if out == t.shown {
+		return
+	}
+	root, err := parseJSONTree(out)
+	if err != nil {
+		t.filterErr = err
+		return
+	}
+	t.load(out, root)
+}
+
+// Filter returns the jq expression the document is reduced by, or "".
+func (t *JSONTree) Filter() string {
+	return t.filter
+}
+
+// FilterError returns why the filter last failed, or nil.
+func (t *JSONTree) FilterError() error {
+	return t.filterErr
+}
+
+// Clear removes the document from the tree.
+func (t *JSONTree) Clear() {
+	*t = JSONTree{width: t.width, height: t.height}
+}
+
+// SetSize sets the tree dimensions.
+func (t *JSONTree) SetSize(width, height int) {
+	t.width = width
+	t.height = height
+}
+
+// parseJSONTree parses raw JSON keeping object keys in document order.
+func parseJSONTree(raw string) (*jsonNode, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	root, err := parseJSONNode(dec, tok, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after top-level value")
+	}
+	return root, nil
+}
+
+// parseJSONNode builds the node that starts with tok.
+func parseJSONNode(dec *json.Decoder, tok json.Token, parent *jsonNode, depth int) (*jsonNode, error) {
+	n := &jsonNode{index: -1, parent: parent, depth: depth, expanded: depth < jsonTreeDefaultDepth}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			n.kind = jsonObject
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyTok.(string)
+				valTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				child, err := parseJSONNode(dec, valTok, n, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				child.key = key
+				n.children = append(n.children, child)
+			}
+		case '[':
+			n.kind = jsonArray
+			for i := 0; dec.More(); i++ {
+				valTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				child, err := parseJSONNode(dec, valTok, n, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				child.index = i
+				n.children = append(n.children, child)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q", v)
+		}
+		// Consume the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		n.kind = jsonString
+		n.value = strconv.Quote(v)
+	case json.Number:
+		n.kind = jsonNumber
+		n.value = v.String()
+	case bool:
+		n.kind = jsonBool
+		n.value = strconv.FormatBool(v)
+	case nil:
+		n.kind = jsonNull
+		n.value = "null"
+	}
+	return n, nil
+}
+
+// rebuild recomputes the visible nodes after expansion changes.
+func (t *JSONTree) rebuild() {
+	t.visible = t.visible[:0]
+	if t.root == nil {
+		return
+	}
+	var walk func(n *jsonNode)
+	walk = func(n *jsonNode) {
+		t.visible = append(t.visible, n)
+		if n.isContainer() && n.expanded {
+			for _, c := range n.children {
+				walk(c)
+			}
+		}
+	}
+	walk(t.root)
+
+	if t.cursor >= len(t.visible) {
+		t.cursor = max(0, len(t.visible)-1)
+	}
+	t.ensureVisible()
+}
+
+// visibleLines returns the number of node lines that fit, leaving room for the path line.
+func (t *JSONTree) visibleLines() int {
+	return max(1, t.height-1)
+}
+
+// ensureVisible adjusts the scroll offset so the cursor is on screen.
+func (t *JSONTree) ensureVisible() {
+	lines := t.visibleLines()
+	if t.cursor < t.scrollOffset {
+		t.scrollOffset = t.cursor
+	}
+	if t.cursor >= t.scrollOffset+lines {
+		t.scrollOffset = t.cursor - lines + 1
+	}
+}
+
+// selected returns the node under the cursor.
+func (t *JSONTree) selected() *jsonNode {
+	if t.cursor >= 0 && t.cursor < len(t.visible) {
+		return t.visible[t.cursor]
+	}
+	return nil
+}
+
+// Up moves the cursor up.
+func (t *JSONTree) Up() {
+	if t.cursor > 0 {
+		t.cursor--
+		t.ensureVisible()
+	}
+}
+
+// Down moves the cursor down.
+func (t *JSONTree) Down() {
+	if t.cursor < len(t.visible)-1 {
+		t.cursor++
+		t.ensureVisible()
+	}
+}
+
+// Top moves the cursor to the first node.
+func (t *JSONTree) Top() {
+	t.cursor = 0
+	t.ensureVisible()
+}
+
+// Bottom moves the cursor to the last visible node.
+func (t *JSONTree) Bottom() {
+	t.cursor = max(0, len(t.visible)-1)
+	t.ensureVisible()
+}
+
+// HalfPageUp moves the cursor up by half a page.
+func (t *JSONTree) HalfPageUp() {
+	t.cursor = max(0, t.cursor-max(1, t.visibleLines()/2))
+	t.ensureVisible()
+}
+
+// HalfPageDown moves the cursor down by half a page.
+func (t *JSONTree) HalfPageDown() {
+	t.cursor = min(max(0, len(t.visible)-1), t.cursor+max(1, t.visibleLines()/2))
+	t.ensureVisible()
+}
+
+// PageUp moves the cursor up by a full page.
+func (t *JSONTree) PageUp() {
+	t.cursor = max(0, t.cursor-t.visibleLines())
+	t.ensureVisible()
+}
+
+// PageDown moves the cursor down by a full page.
+func (t *JSONTree) PageDown() {
+	t.cursor = min(max(0, len(t.visible)-1), t.cursor+t.visibleLines())
+	t.ensureVisible()
+}
+
+// Toggle expands or collapses the object or array under the cursor.
+// On a scalar it collapses the parent instead.
+func (t *JSONTree) Toggle() {
+	n := t.selected()
+	if n == nil {
+		return
+	}
+	if !n.isContainer() {
+		if n.parent == nil {
+			return
+		}
+		n = n.parent
+	}
+	n.expanded = !n.expanded
+	t.moveTo(n)
+}
+
+// ExpandAll expands every node.
+func (t *JSONTree) ExpandAll() {
+	t.setExpandedAll(true)
+}
+
+// CollapseAll collapses everything below the root.
+func (t *JSONTree) CollapseAll() {
+	t.setExpandedAll(false)
+	if t.root != nil {
+		t.root.expanded = true
+	}
+	t.cursor = 0
+	t.rebuild()
+}
+
+func (t *JSONTree) setExpandedAll(expanded bool) {
+	sel := t.selected()
+	var walk func(n *jsonNode)
+	walk = func(n *jsonNode) {
+		n.expanded = expanded
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	if t.root != nil {
+		walk(t.root)
+	}
+	t.moveTo(sel)
+}
+
+// moveTo rebuilds the visible nodes and puts the cursor on n, expanding its ancestors.
+func (t *JSONTree) moveTo(n *jsonNode) {
+	for p := n; p != nil && p.parent != nil; p = p.parent {
+		p.parent.expanded = true
+	}
+	t.rebuild()
+	for i, v := range t.visible {
+		if v == n {
+			t.cursor = i
+			break
+		}
+	}
+	t.ensureVisible()
+}
+
+// SelectedPath returns the JSONPath of the node under the cursor.
+func (t *JSONTree) SelectedPath() string {
+	n := t.selected()
+	if n == nil {
+		return ""
+	}
+	return n.path()
+}
+
+// SetSearchQuery highlights nodes whose key or value contains query and jumps to the first.
+func (t *JSONTree) SetSearchQuery(query string) {
+	t.searchQuery = query
+	t.searchMatches = nil
+	t.searchIndex = 0
+	if query == "" || t.root == nil {
+		return
+	}
+
+	q := strings.ToLower(query)
+	var walk func(n *jsonNode)
+	walk = func(n *jsonNode) {
+		if strings.Contains(strings.ToLower(n.key), q) || strings.Contains(strings.ToLower(n.value), q) {
+			t.searchMatches = append(t.searchMatches, n)
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(t.root)
+
+	if len(t.searchMatches) > 0 {
+		t.moveTo(t.searchMatches[0])
+	}
+}
+
+// SearchQuery returns the current search query.
+func (t *JSONTree) SearchQuery() string {
+	return t.searchQuery
+}
+
+// ClearSearch clears the search state.
+func (t *JSONTree) ClearSearch() {
+	t.SetSearchQuery("")
+}
+
+// NextMatch jumps to the next search match.
+func (t *JSONTree) NextMatch() {
+	if len(t.searchMatches) == 0 {
+		return
+	}
+	t.searchIndex = (t.searchIndex + 1) % len(t.searchMatches)
+	t.moveTo(t.searchMatches[t.searchIndex])
+}
+
+// PrevMatch jumps to the previous search match.
+func (t *JSONTree) PrevMatch() {
+	if len(t.searchMatches) == 0 {
+		return
+	}
+	t.searchIndex = (t.searchIndex - 1 + len(t.searchMatches)) % len(t.searchMatches)
+	t.moveTo(t.searchMatches[t.searchIndex])
+}
+
+// MatchCount returns the number of search matches.
+func (t *JSONTree) MatchCount() int {
+	return len(t.searchMatches)
+}
+
+// CurrentMatchIndex returns the 1-based index of the current match, or 0 if none.
+func (t *JSONTree) CurrentMatchIndex() int {
+	if len(t.searchMatches) == 0 {
+		return 0
+	}
+	return t.searchIndex + 1
+}
+
+// isMatch returns true if n is a search match.
+func (t *JSONTree) isMatch(n *jsonNode) bool {
+	for _, m := range t.searchMatches {
+		if m == n {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeCount returns the number of visible lines in the tree.
+func (t *JSONTree) NodeCount() int {
+	return len(t.visible)
+}
+
+// nodeLabel renders a node as plain text without styling.
+func nodeLabel(n *jsonNode) (marker, key, value string) {
+	marker = "  "
+	if n.isContainer() {
+		marker = theme.Symbol("▾ ", "- ")
+		if !n.expanded {
+			marker = theme.Symbol("▸ ", "+ ")
+		}
+	}
+
+	switch {
+	case n.parent == nil:
+		key = "$"
+	case n.index >= 0:
+		key = fmt.Sprintf("[%d]", n.index)
+	default:
+		key = n.key
+	}
+
+	switch n.kind {
+	case jsonObject:
+		value = fmt.Sprintf("{%d}", len(n.children))
+	case jsonArray:
+		value = fmt.Sprintf("[%d]", len(n.children))
+	default:
+		value = n.value
+	}
+	return marker, key, value
+}
+
+// View renders the tree.
+func (t *JSONTree) View() string {
+	if t.root == nil {
+		return ""
+	}
+
+	keyStyle := lipgloss.NewStyle().Foreground(theme.Primary)
+	indexStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
+	stringStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	numberStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	boolStyle := lipgloss.NewStyle().Foreground(theme.Info)
+	nullStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
+	cursorStyle := lipgloss.NewStyle().Background(theme.Primary).Foreground(lipgloss.Color("#FFFFFF"))
+	matchStyle := lipgloss.NewStyle().Background(theme.PrimaryMuted)
+
+	var b strings.Builder
+	lines := t.visibleLines()
+	end := min(t.scrollOffset+lines, len(t.visible))
+
+	for i := t.scrollOffset; i < end; i++ {
+		n := t.visible[i]
+		marker, key, value := nodeLabel(n)
+		indent := strings.Repeat("  ", n.depth)
+		plain := truncate(indent+marker+key+": "+value, t.width)
+
+		switch {
+		case i == t.cursor:
+			b.WriteString(cursorStyle.Render(plain))
+		case t.isMatch(n):
+			b.WriteString(matchStyle.Render(plain))
+		default:
+			if lipgloss.Width(plain) < lipgloss.Width(indent+marker+key+": "+value) {
+				// Truncated lines lose their structure, render them dim
+				b.WriteString(dimStyle.Render(plain))
+				break
+			}
+			ks := keyStyle
+			if n.index >= 0 || n.parent == nil {
+				ks = indexStyle
+			}
+			var vs lipgloss.Style
+			switch n.kind {
+			case jsonString:
+				vs = stringStyle
+			case jsonNumber:
+				vs = numberStyle
+			case jsonBool:
+				vs = boolStyle
+			default:
+				vs = nullStyle
+			}
+			b.WriteString(indent + dimStyle.Render(marker) + ks.Render(key) + ": " + vs.Render(value))
+		}
+		b.WriteString("\n")
+	}
+
+	// Path of the selected node, or search progress while searching, after
+	// the filter if one is set
+	footer := t.SelectedPath()
+	if t.searchQuery != "" {
+		footer = fmt.Sprintf("Search: %q (%d/%d) %s", t.searchQuery, t.CurrentMatchIndex(), t.MatchCount(), footer)
+	} else if len(t.visible) > lines {
+		footer = fmt.Sprintf("%s  (%d/%d)", footer, t.cursor+1, len(t.visible))
+	}
+	switch {
+	case t.filterErr != nil:
+		errStyle := lipgloss.NewStyle().Foreground(theme.Error)
+		b.WriteString(errStyle.Render(truncate("jq: "+t.filterErr.Error(), t.width)))
+		return b.String()
+	case t.filter != "":
+		footer = "jq " + t.filter + "  " + footer
+	}
+	b.WriteString(dimStyle.Render(truncate(footer, t.width)))
+
+	return b.String()
+}
+
+// PrettyJSON returns the document as shown, filtered if a filter is set,
+// indented for copying.
+func (t *JSONTree) PrettyJSON() string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(t.shown), "", "  "); err != nil {
+		return t.shown
+	}
+	return out.String()
+}
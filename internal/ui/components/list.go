@@ -16,7 +16,15 @@ type ListItem struct {
 	Status      string
 	StatusStyle lipgloss.Style
 	Extra       string
-	IsHeader    bool // Non-selectable category header
+	IsHeader    bool              // Non-selectable category header
+	Group       bool              // Selectable header of a group of the items below it
+	Collapsed   bool              // The group's items are hidden
+	Icon        bool              // Status is a decorative icon: never tagged, hidden in ASCII-only mode
+	Changed     bool              // The resource was deployed or updated since it was first listed
+	Highlight   *lipgloss.Style   // Set by a highlight rule of the config, styles the name
+	Noted       bool              // The resource has a local note
+	Marked      bool              // Picked for a bulk action
+	Cells       map[string]string // Values of the list's columns, by column key
 }
 
 // List is a scrollable, selectable list component.
@@ -32,6 +40,23 @@ type List struct {
 	errMsg    string
 	emptyMsg  string
 	spinner   *Spinner
+	progress  *LoadingProgress
+
+	// selectedID is the ID of the item the user last moved to. The cursor
+	// follows it when items are replaced, so refreshes and filtering never
+	// leave the cursor on a different item at the same index.
+	selectedID string
+
+	// A refresh keeps the current items on screen and flags the ones that
+	// differ once the new items arrive.
+	refreshing bool
+	changed    map[string]bool
+
+	// highlight is the filter being typed, underlined where it matches names
+	highlight string
+
+	// columns are shown after the names, below a row of their titles
+	columns []Column
 }
 
 // NewList creates a new List component.
@@ -41,6 +66,7 @@ func NewList(title string) *List {
 		showTitle: false, // Title is shown in Container border now
 		emptyMsg:  "No items found",
 		spinner:   NewSpinner(),
+		progress:  NewLoadingProgress(),
 	}
 }
 
@@ -54,20 +80,92 @@ func (l *List) Spinner() *Spinner {
 	return l.spinner
 }
 
+// Progress returns the per-call breakdown shown while the list is loading.
+func (l *List) Progress() *LoadingProgress {
+	return l.progress
+}
+
 // SetTitle sets the list title.
 func (l *List) SetTitle(title string) {
 	l.title = title
 }
 
-// SetItems sets the list items.
+// SetItems sets the list items. The cursor stays on the selected item if it
+// is still present; otherwise it keeps its position.
 func (l *List) SetItems(items []ListItem) {
+	selectedID := l.selectedID
+	if selectedID == "" {
+		if item := l.SelectedItem(); item != nil {
+			selectedID = item.ID
+		}
+	}
+	if l.refreshing {
+		l.changed = changedItems(l.items, items)
+	}
+
 	l.items = items
+	// IDs are not always unique (e.g. services in two clusters), so only look
+	// the item up if the cursor is not already on it
+	if l.cursor >= len(items) || items[l.cursor].ID != selectedID {
+		if i := l.indexOf(selectedID); i >= 0 {
+			l.cursor = i
+		}
+	}
 	if l.cursor >= len(items) {
 		l.cursor = max(0, len(items)-1)
 	}
 	l.clampOffset()
 }
 
+// indexOf returns the index of the selectable item with the given ID, or -1.
+func (l *List) indexOf(id string) int {
+	if id == "" {
+		return -1
+	}
+	for i, item := range l.items {
+		if item.ID == id && !item.IsHeader {
+			return i
+		}
+	}
+	return -1
+}
+
+// rememberSelection records the item under the cursor after the user moves it.
+func (l *List) rememberSelection() {
+	l.selectedID = ""
+	if item := l.SelectedItem(); item != nil {
+		l.selectedID = item.ID
+	}
+}
+
+// countItems returns the number of items, leaving out group headers.
+func (l *List) countItems() int {
+	n := 0
+	for _, item := range l.items {
+		if !item.Group {
+			n++
+		}
+	}
+	return n
+}
+
+// ItemCount returns the number of selectable items, leaving out headers.
+func (l *List) ItemCount() int {
+	n := 0
+	for _, item := range l.items {
+		if !item.IsHeader && !item.Group {
+			n++
+		}
+	}
+	return n
+}
+
+// SetFilterHighlight sets the text underlined where it appears in item
+// names, or clears it when empty.
+func (l *List) SetFilterHighlight(query string) {
+	l.highlight = query
+}
+
 // SetSize sets the list dimensions.
 func (l *List) SetSize(width, height int) {
 	l.width = width
@@ -77,9 +175,48 @@ func (l *List) SetSize(width, height int) {
 
 // SetLoading sets the loading state.
 func (l *List) SetLoading(loading bool) {
+	if loading && !l.loading {
+		l.progress.Reset()
+		if !l.refreshing {
+			l.changed = nil
+			l.selectedID = ""
+		}
+	}
+	if !loading {
+		l.refreshing = false
+	}
 	l.loading = loading
 }
 
+// SetRefreshing marks the next load as a refresh of the current items: they
+// stay visible while it runs and items that changed are flagged. It has no
+// effect on an empty list.
+func (l *List) SetRefreshing(refreshing bool) {
+	l.refreshing = refreshing && len(l.items) > 0
+}
+
+// changedItems returns the IDs of items that are new or differ from the
+// item with the same ID before.
+func changedItems(before, after []ListItem) map[string]bool {
+	previous := make(map[string]ListItem, len(before))
+	for _, item := range before {
+		previous[item.ID] = item
+	}
+
+	changed := make(map[string]bool)
+	for _, item := range after {
+		if item.IsHeader {
+			continue
+		}
+		old, ok := previous[item.ID]
+		if !ok || old.Title != item.Title || old.Description != item.Description ||
+			old.Status != item.Status || old.Extra != item.Extra || old.Changed != item.Changed {
+			changed[item.ID] = true
+		}
+	}
+	return changed
+}
+
 // SetError sets the error message.
 func (l *List) SetError(err error) {
 	if err != nil {
@@ -94,6 +231,11 @@ func (l *List) SetEmptyMessage(msg string) {
 	l.emptyMsg = msg
 }
 
+// SetColumns sets the columns shown after the item names, or none.
+func (l *List) SetColumns(columns []Column) {
+	l.columns = columns
+}
+
 // Cursor returns the current cursor position.
 func (l *List) Cursor() int {
 	return l.cursor
@@ -113,6 +255,7 @@ func (l *List) Up() {
 		l.cursor--
 		l.skipHeadersUp()
 		l.clampOffset()
+		l.rememberSelection()
 	}
 }
 
@@ -122,6 +265,7 @@ func (l *List) Down() {
 		l.cursor++
 		l.skipHeadersDown()
 		l.clampOffset()
+		l.rememberSelection()
 	}
 }
 
@@ -130,6 +274,7 @@ func (l *List) Top() {
 	l.cursor = 0
 	l.skipHeadersDown()
 	l.offset = 0
+	l.rememberSelection()
 }
 
 // Bottom moves the cursor to the last selectable item.
@@ -137,6 +282,7 @@ func (l *List) Bottom() {
 	l.cursor = max(0, len(l.items)-1)
 	l.skipHeadersUp()
 	l.clampOffset()
+	l.rememberSelection()
 }
 
 // skipHeadersDown moves cursor down to skip any headers.
@@ -179,11 +325,15 @@ func (l *List) clampOffset() {
 }
 
 func (l *List) visibleItemCount() int {
-	// Account for title line if shown, plus some padding
+	// Account for title line if shown, the column titles, plus some padding
+	rows := l.height - 2
 	if l.showTitle {
-		return max(1, l.height-4)
+		rows -= 2
+	}
+	if len(l.columns) > 0 {
+		rows--
 	}
-	return max(1, l.height-2)
+	return max(1, rows)
 }
 
 // View renders the list.
@@ -198,24 +348,28 @@ func (l *List) View() string {
 	// Title with count (only if showTitle is true)
 	if l.showTitle {
 		titleText := l.title
-		if len(l.items) > 0 {
-			titleText = fmt.Sprintf("%s (%d)", l.title, len(l.items))
+		if n := l.countItems(); n > 0 {
+			titleText = fmt.Sprintf("%s (%d)", l.title, n)
 		}
 		b.WriteString(s.SidebarTitle.Render(titleText))
 		b.WriteString("\n")
 	}
 
-	// Loading state
-	if l.loading {
+	// Loading state (a refresh keeps showing the current items)
+	if l.loading && !l.refreshing {
 		loadingText := l.spinner.View() + " " + s.Muted.Render("Loading...")
 		b.WriteString(loadingText)
+		if l.progress.Len() > 0 {
+			b.WriteString("\n\n")
+			b.WriteString(l.progress.View(l.spinner))
+		}
 		return containerStyle.Render(b.String())
 	}
 
 	// Error state
 	if l.errMsg != "" {
 		errStyle := s.StatusError.Copy().Width(l.width - 6)
-		b.WriteString(errStyle.Render("✗ " + l.errMsg))
+		b.WriteString(errStyle.Render(theme.Symbol("✗ ", "x ") + l.errMsg))
 		return containerStyle.Render(b.String())
 	}
 
@@ -226,7 +380,7 @@ func (l *List) View() string {
 	}
 
 	// Calculate column widths
-	nameWidth := l.width - 30
+	nameWidth := l.width - 30 - columnsWidth(l.columns)
 	if nameWidth < 20 {
 		nameWidth = 20
 	}
@@ -239,6 +393,15 @@ func (l *List) View() string {
 	headerStyle := lipgloss.NewStyle().
 		Foreground(theme.TextMuted).
 		Bold(true)
+	changedStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	changedBadgeStyle := lipgloss.NewStyle().Foreground(theme.Info).Bold(true)
+	noteBadgeStyle := lipgloss.NewStyle().Foreground(theme.Warning)
+	markedBadgeStyle := lipgloss.NewStyle().Foreground(theme.Success).Bold(true)
+
+	if len(l.columns) > 0 {
+		b.WriteString(headerStyle.Render(fmt.Sprintf("  %-*s", nameWidth, "NAME") + renderColumnTitles(l.columns)))
+		b.WriteString("\n")
+	}
 
 	for i := l.offset; i < end; i++ {
 		item := l.items[i]
@@ -248,7 +411,11 @@ func (l *List) View() string {
 
 		// Handle header items (non-selectable category separators)
 		if item.IsHeader {
-			line.WriteString(headerStyle.Render(item.Title))
+			title := item.Title
+			if theme.ASCII() {
+				title = strings.ReplaceAll(title, "─", "-")
+			}
+			line.WriteString(headerStyle.Render(title))
 			b.WriteString(line.String())
 			if i < end-1 {
 				b.WriteString("\n")
@@ -258,11 +425,29 @@ func (l *List) View() string {
 
 		// Cursor indicator
 		if isSelected {
-			line.WriteString(s.SidebarCursor.Render("▸ "))
+			line.WriteString(s.SidebarCursor.Render(theme.Symbol("▸ ", "> ")))
 		} else {
 			line.WriteString("  ")
 		}
 
+		// Group headers show whether they are folded
+		if item.Group {
+			fold := theme.Symbol("▾ ", "- ")
+			if item.Collapsed {
+				fold = theme.Symbol("▹ ", "+ ")
+			}
+			line.WriteString(headerStyle.Render(fold + truncate(item.Title, max(4, nameWidth))))
+			if item.Status != "" {
+				line.WriteString(" ")
+				line.WriteString(item.StatusStyle.Render(item.Status))
+			}
+			b.WriteString(line.String())
+			if i < end-1 {
+				b.WriteString("\n")
+			}
+			continue
+		}
+
 		// Item name (truncated if needed)
 		name := item.Title
 		if len(name) > nameWidth {
@@ -270,16 +455,39 @@ func (l *List) View() string {
 		}
 		namePadded := fmt.Sprintf("%-*s", nameWidth, name)
 
-		if isSelected {
-			line.WriteString(s.SidebarSelected.Render(namePadded))
-		} else {
-			line.WriteString(s.SidebarItem.Render(namePadded))
+		nameStyle := s.SidebarItem
+		switch {
+		case isSelected:
+			nameStyle = s.SidebarSelected
+		case item.Highlight != nil:
+			nameStyle = *item.Highlight
+		}
+		line.WriteString(highlightMatch(namePadded, l.highlight, nameStyle))
+		if len(l.columns) > 0 {
+			line.WriteString(nameStyle.Render(renderCells(l.columns, item.Cells)))
 		}
 
 		// Status with styling
-		if item.Status != "" {
+		if item.Icon && item.Status != "" {
+			if !theme.ASCII() {
+				line.WriteString(" ")
+				line.WriteString(item.StatusStyle.Render(item.Status))
+			}
+		} else if item.Status != "" {
 			line.WriteString(" ")
-			line.WriteString(item.StatusStyle.Render(item.Status))
+			line.WriteString(item.StatusStyle.Render(theme.Tagged(item.StatusStyle, item.Status)))
+		}
+		if item.Changed {
+			line.WriteString(changedBadgeStyle.Render(" changed"))
+		}
+		if item.Noted {
+			line.WriteString(noteBadgeStyle.Render(" note"))
+		}
+		if item.Marked {
+			line.WriteString(markedBadgeStyle.Render(theme.Symbol(" ✓ marked", " [x] marked")))
+		}
+		if l.changed[item.ID] {
+			line.WriteString(changedStyle.Render(theme.Symbol(" •", " *")))
 		}
 
 		b.WriteString(line.String())
@@ -291,9 +499,29 @@ func (l *List) View() string {
 	// Scroll indicator
 	if len(l.items) > visibleCount {
 		b.WriteString("\n")
-		scrollText := fmt.Sprintf("↑↓ %d-%d of %d", l.offset+1, end, len(l.items))
+		scrollText := fmt.Sprintf("%s %d-%d of %d", theme.Symbol("↑↓", "^v"), l.offset+1, end, len(l.items))
 		b.WriteString(s.Muted.Render(scrollText))
 	}
 
+	if l.loading && l.refreshing {
+		b.WriteString("\n")
+		b.WriteString(l.shimmer())
+	}
+
 	return containerStyle.Render(b.String())
 }
+
+// shimmer renders a thin bar with a highlight that moves with the spinner,
+// shown below the items while they are being refreshed.
+func (l *List) shimmer() string {
+	width := max(10, l.width-4)
+	glow := min(8, width)
+	pos := l.spinner.frame * (width - glow) / max(1, len(spinnerFrames)-1)
+
+	dim := lipgloss.NewStyle().Foreground(theme.Border)
+	bright := lipgloss.NewStyle().Foreground(theme.Primary)
+	rule := theme.Symbol("─", "-")
+	return dim.Render(strings.Repeat(rule, pos)) +
+		bright.Render(strings.Repeat(rule, glow)) +
+		dim.Render(strings.Repeat(rule, width-pos-glow))
+}
@@ -0,0 +1,129 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/theme"
+)
+
+// loadingStep is one API call a loader is working through.
+type loadingStep struct {
+	call     string
+	unit     string
+	done     int
+	total    int
+	started  time.Time
+	finished time.Time
+}
+
+// complete reports whether the step has processed all of its units.
+func (s *loadingStep) complete() bool {
+	return s.total > 0 && s.done >= s.total
+}
+
+// LoadingProgress tracks the API calls made by the current load so the
+// loading indicator can show what is being waited on.
+type LoadingProgress struct {
+	steps []*loadingStep
+	gen   atomic.Int64 // Incremented by Reset; read by loaders running in the background
+}
+
+// NewLoadingProgress creates an empty LoadingProgress.
+func NewLoadingProgress() *LoadingProgress {
+	return &LoadingProgress{}
+}
+
+// Reset forgets all steps, e.g. when a new load starts.
+func (p *LoadingProgress) Reset() {
+	p.steps = nil
+	p.gen.Add(1)
+}
+
+// Generation identifies the current load. Updates tagged with an earlier
+// generation come from a load that has since been replaced.
+func (p *LoadingProgress) Generation() int64 {
+	return p.gen.Load()
+}
+
+// Update records that call has processed done of total units. A total of 0
+// means the total is not known yet. Progress on any other call marks calls
+// with an unknown total as finished, since paginated calls stop reporting once
+// their last page is fetched.
+func (p *LoadingProgress) Update(call, unit string, done, total int) {
+	now := time.Now()
+
+	var step *loadingStep
+	for _, s := range p.steps {
+		if s.call == call {
+			step = s
+		} else if s.total == 0 && s.finished.IsZero() {
+			s.finished = now
+		}
+	}
+	if step == nil {
+		step = &loadingStep{call: call, started: now}
+		p.steps = append(p.steps, step)
+	} else if done < step.done || (done == step.done && !step.finished.IsZero()) {
+		// The call is being repeated, e.g. for the next cluster of a stack
+		step.finished = time.Time{}
+	}
+
+	step.unit = unit
+	step.done = done
+	step.total = total
+	if step.complete() && step.finished.IsZero() {
+		step.finished = now
+	}
+}
+
+// Len returns the number of steps reported so far.
+func (p *LoadingProgress) Len() int {
+	return len(p.steps)
+}
+
+// View renders one line per step, e.g. "⠋ DescribeServices 3/7 batches 1.2s".
+func (p *LoadingProgress) View(spinner *Spinner) string {
+	if len(p.steps) == 0 {
+		return ""
+	}
+
+	s := theme.DefaultStyles()
+	doneStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	now := time.Now()
+
+	lines := make([]string, 0, len(p.steps))
+	for _, step := range p.steps {
+		marker := spinner.View()
+		end := now
+		if !step.finished.IsZero() {
+			marker = doneStyle.Render(theme.Symbol("✓", "+"))
+			end = step.finished
+		}
+
+		count := fmt.Sprintf("%d %s", step.done, step.unit)
+		if step.total > 0 {
+			count = fmt.Sprintf("%d/%d %s", step.done, step.total, step.unit)
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %s %s %s",
+			marker,
+			step.call,
+			s.Muted.Render(count),
+			s.Muted.Render(formatElapsed(end.Sub(step.started))),
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatElapsed formats a step duration with one decimal below a minute.
+func formatElapsed(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+	return d.Truncate(time.Second).String()
+}
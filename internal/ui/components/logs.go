@@ -20,14 +20,16 @@ type LogEntry struct {
 	Message string
 }
 
-// Logs displays log messages in the UI.
+// Logs displays log messages in the UI, below a rule that shows whether
+// the panel has focus.
 type Logs struct {
-	mu       sync.RWMutex
-	entries  []LogEntry
-	width    int
-	height   int
-	scroll   int
-	logger   *log.Logger
+	mu      sync.RWMutex
+	entries []LogEntry
+	width   int
+	height  int
+	scroll  int
+	focused bool
+	logger  *log.Logger
 }
 
 // NewLogs creates a new Logs component.
@@ -54,15 +56,27 @@ func (l *Logs) Write(level, message string) {
 		Message: message,
 	}
 
+	following := l.scroll >= l.maxScrollLocked()
 	l.entries = append(l.entries, entry)
 
 	// Trim if too many entries
 	if len(l.entries) > maxLogEntries {
-		l.entries = l.entries[len(l.entries)-maxLogEntries:]
+		trimmed := len(l.entries) - maxLogEntries
+		l.entries = l.entries[trimmed:]
+		l.scroll = max(0, l.scroll-trimmed)
 	}
 
-	// Auto-scroll to bottom
-	l.scrollToBottomLocked()
+	// Auto-scroll to bottom, unless scrolled back to read older entries
+	if following {
+		l.scrollToBottomLocked()
+	}
+}
+
+// Len returns the number of entries kept.
+func (l *Logs) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.entries)
 }
 
 // SetSize sets the component dimensions.
@@ -86,15 +100,46 @@ func (l *Logs) ScrollUp() {
 func (l *Logs) ScrollDown() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	maxScroll := len(l.entries) - l.height
-	if maxScroll < 0 {
-		maxScroll = 0
-	}
-	if l.scroll < maxScroll {
+	if l.scroll < l.maxScrollLocked() {
 		l.scroll++
 	}
 }
 
+// PageUp scrolls the log view up by a page.
+func (l *Logs) PageUp() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scroll = max(0, l.scroll-l.visibleLinesLocked())
+}
+
+// PageDown scrolls the log view down by a page.
+func (l *Logs) PageDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scroll = min(l.maxScrollLocked(), l.scroll+l.visibleLinesLocked())
+}
+
+// HalfPageUp scrolls the log view up by half a page.
+func (l *Logs) HalfPageUp() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scroll = max(0, l.scroll-max(1, l.visibleLinesLocked()/2))
+}
+
+// HalfPageDown scrolls the log view down by half a page.
+func (l *Logs) HalfPageDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scroll = min(l.maxScrollLocked(), l.scroll+max(1, l.visibleLinesLocked()/2))
+}
+
+// ScrollToTop scrolls to the oldest entry kept.
+func (l *Logs) ScrollToTop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scroll = 0
+}
+
 // ScrollToBottom scrolls to the bottom of the log.
 func (l *Logs) ScrollToBottom() {
 	l.mu.Lock()
@@ -103,11 +148,23 @@ func (l *Logs) ScrollToBottom() {
 }
 
 func (l *Logs) scrollToBottomLocked() {
-	maxScroll := len(l.entries) - l.height
-	if maxScroll < 0 {
-		maxScroll = 0
-	}
-	l.scroll = maxScroll
+	l.scroll = l.maxScrollLocked()
+}
+
+// SetFocused sets whether the panel has focus, which colors its rule.
+func (l *Logs) SetFocused(focused bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.focused = focused
+}
+
+// visibleLinesLocked returns the number of entries shown below the rule.
+func (l *Logs) visibleLinesLocked() int {
+	return max(1, l.height-1)
+}
+
+func (l *Logs) maxScrollLocked() int {
+	return max(0, len(l.entries)-l.visibleLinesLocked())
 }
 
 // View renders the logs component.
@@ -129,10 +186,11 @@ func (l *Logs) View() string {
 	debugStyle := st.Muted
 
 	var lines []string
+	lines = append(lines, l.ruleLocked())
 
 	// Calculate visible range
 	start := l.scroll
-	end := start + l.height
+	end := start + l.visibleLinesLocked()
 	if end > len(l.entries) {
 		end = len(l.entries)
 	}
@@ -194,6 +252,33 @@ func (l *Logs) View() string {
 	return strings.Join(lines, "\n")
 }
 
+// ruleLocked renders the rule above the entries: the panel's title, and how
+// many newer entries are below when scrolled back.
+func (l *Logs) ruleLocked() string {
+	color := theme.Border
+	if l.focused {
+		color = theme.BorderFocus
+	}
+	style := lipgloss.NewStyle().Foreground(color)
+	dash := theme.Symbol("─", "-")
+
+	title := " Logs "
+	if l.focused {
+		title = " Logs (focused) "
+	}
+	rule := dash + title
+	if newer := l.maxScrollLocked() - l.scroll; newer > 0 {
+		extra := fmt.Sprintf(" %d newer ", newer)
+		if fill := l.width - lipgloss.Width(rule) - lipgloss.Width(extra) - 1; fill > 0 {
+			return style.Render(rule + strings.Repeat(dash, fill) + extra + dash)
+		}
+	}
+	if fill := l.width - lipgloss.Width(rule); fill > 0 {
+		rule += strings.Repeat(dash, fill)
+	}
+	return style.Render(rule)
+}
+
 // Clear clears all log entries.
 func (l *Logs) Clear() {
 	l.mu.Lock()
@@ -0,0 +1,182 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/theme"
+)
+
+// MonitorLine is one line of a monitor panel.
+type MonitorLine struct {
+	Text  string
+	Style lipgloss.Style
+}
+
+// MonitorTile is the content of one monitor panel.
+type MonitorTile struct {
+	Title     string
+	Lines     []MonitorLine
+	Err       error
+	Loading   bool
+	Interval  time.Duration
+	UpdatedAt time.Time
+}
+
+// Monitor tiles live panels in a grid, like a terminal dashboard.
+type Monitor struct {
+	tiles    []MonitorTile
+	selected int
+	width    int
+	height   int
+}
+
+// NewMonitor creates a new Monitor.
+func NewMonitor() *Monitor {
+	return &Monitor{}
+}
+
+// SetTiles sets the panels to display.
+func (m *Monitor) SetTiles(tiles []MonitorTile) {
+	m.tiles = tiles
+	m.selected = min(m.selected, max(0, len(tiles)-1))
+}
+
+// SetSize sets the dimensions of the grid.
+func (m *Monitor) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Selected returns the index of the selected panel, or -1 if there are none.
+func (m *Monitor) Selected() int {
+	if len(m.tiles) == 0 {
+		return -1
+	}
+	return m.selected
+}
+
+// columns returns how many panels fit side by side.
+func (m *Monitor) columns() int {
+	cols := 1
+	switch {
+	case m.width >= 180:
+		cols = 3
+	case m.width >= 90:
+		cols = 2
+	}
+	return max(1, min(cols, len(m.tiles)))
+}
+
+// Left selects the panel to the left.
+func (m *Monitor) Left() {
+	if m.selected > 0 {
+		m.selected--
+	}
+}
+
+// Right selects the panel to the right.
+func (m *Monitor) Right() {
+	if m.selected < len(m.tiles)-1 {
+		m.selected++
+	}
+}
+
+// Up selects the panel above.
+func (m *Monitor) Up() {
+	if m.selected-m.columns() >= 0 {
+		m.selected -= m.columns()
+	}
+}
+
+// Down selects the panel below.
+func (m *Monitor) Down() {
+	if m.selected+m.columns() < len(m.tiles) {
+		m.selected += m.columns()
+	}
+}
+
+// View renders the grid.
+func (m *Monitor) View() string {
+	s := theme.DefaultStyles()
+	if len(m.tiles) == 0 {
+		return s.Muted.Render("No panels yet. Press M on a service, queue or Lambda function to pin it,\n" +
+			"or use :monitor logs on a service and :monitor alarms for CloudWatch alarms.")
+	}
+
+	cols := m.columns()
+	rows := (len(m.tiles) + cols - 1) / cols
+	tileWidth := m.width / cols
+	tileHeight := max(4, m.height/rows)
+
+	var gridRows []string
+	for r := 0; r < rows; r++ {
+		var row []string
+		for c := 0; c < cols; c++ {
+			i := r*cols + c
+			if i >= len(m.tiles) {
+				break
+			}
+			row = append(row, m.renderTile(m.tiles[i], i == m.selected, tileWidth, tileHeight))
+		}
+		gridRows = append(gridRows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, gridRows...)
+}
+
+// renderTile renders a bordered panel. The newest lines are kept when the
+// content does not fit, so log tails show their latest entries.
+func (m *Monitor) renderTile(tile MonitorTile, selected bool, width, height int) string {
+	s := theme.DefaultStyles()
+	innerWidth := max(10, width-4)
+	innerHeight := max(1, height-3) // Border and title
+
+	borderColor := theme.Border
+	if selected {
+		borderColor = theme.BorderFocus
+	}
+
+	status := ""
+	switch {
+	case tile.Loading && tile.UpdatedAt.IsZero():
+		status = "loading..."
+	case !tile.UpdatedAt.IsZero():
+		status = fmt.Sprintf("%s ago", time.Since(tile.UpdatedAt).Truncate(time.Second))
+	}
+	if tile.Interval > 0 {
+		status = fmt.Sprintf("%s %s %s %s", theme.Symbol("↻", "every"), tile.Interval, theme.Symbol("·", "-"), status)
+	}
+	title := s.SidebarTitle.MarginBottom(0).Render(truncate(tile.Title, max(1, innerWidth-lipgloss.Width(status)-1)))
+	gap := max(1, innerWidth-lipgloss.Width(title)-lipgloss.Width(status))
+	header := title + strings.Repeat(" ", gap) + s.Muted.Render(status)
+
+	lines := tile.Lines
+	if len(lines) > innerHeight {
+		lines = lines[len(lines)-innerHeight:]
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	if tile.Err != nil {
+		b.WriteString("\n" + s.StatusError.Render(truncate(theme.Symbol("✗ ", "x ")+tile.Err.Error(), innerWidth)))
+		innerHeight--
+		if len(lines) > innerHeight {
+			lines = lines[len(lines)-max(0, innerHeight):]
+		}
+	}
+	for _, line := range lines {
+		b.WriteString("\n" + line.Style.Render(truncate(theme.Tagged(line.Style, line.Text), innerWidth)))
+	}
+
+	return lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(borderColor).
+		Width(innerWidth+2).
+		Height(height-2).
+		MaxHeight(height).
+		Padding(0, 1).
+		Render(b.String())
+}
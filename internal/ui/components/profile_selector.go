@@ -3,14 +3,23 @@ package components
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"vaws/internal/model"
+	"vaws/internal/ui/format"
 	"vaws/internal/ui/theme"
 )
 
+// ssoExpiringWithin is how soon before it expires an SSO token is shown as
+// expiring.
+const ssoExpiringWithin = time.Hour
+
 // ProfileSelector allows users to select an AWS profile.
 type ProfileSelector struct {
 	profiles []string
+	tokens   map[string]model.SSOToken // SSO profile -> its cached token
+	status   string                    // Outcome of the last token refresh or login
 	cursor   int
 	width    int
 	height   int
@@ -32,6 +41,17 @@ func (p *ProfileSelector) SetProfiles(profiles []string) {
 	}
 }
 
+// SetSSOTokens sets the cached tokens of the SSO profiles, by profile name.
+// Profiles without an entry don't sign in through SSO.
+func (p *ProfileSelector) SetSSOTokens(tokens map[string]model.SSOToken) {
+	p.tokens = tokens
+}
+
+// SetStatus sets the line shown under the profiles.
+func (p *ProfileSelector) SetStatus(status string) {
+	p.status = status
+}
+
 // SetSize sets the component dimensions.
 func (p *ProfileSelector) SetSize(width, height int) {
 	p.width = width
@@ -69,7 +89,7 @@ func (p *ProfileSelector) View() string {
 	}
 
 	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BorderStyle()).
 		BorderForeground(theme.Primary).
 		Padding(1, 2)
 
@@ -103,17 +123,27 @@ func (p *ProfileSelector) View() string {
 		end = len(p.profiles)
 	}
 
+	nameWidth := 0
+	for _, profile := range p.profiles {
+		nameWidth = max(nameWidth, lipgloss.Width(profile))
+	}
+
 	// Render profile list
+	now := time.Now()
 	for i := offset; i < end; i++ {
 		profile := p.profiles[i]
 		isSelected := i == p.cursor
+		padding := strings.Repeat(" ", nameWidth-lipgloss.Width(profile))
 
 		var line string
 		if isSelected {
-			line = s.SidebarCursor.Render("▸ ") + s.SidebarSelected.Render(profile)
+			line = s.SidebarCursor.Render(theme.Symbol("▸ ", "> ")) + s.SidebarSelected.Render(profile)
 		} else {
 			line = "  " + s.SidebarItem.Render(profile)
 		}
+		if token, ok := p.tokens[profile]; ok {
+			line += padding + "  " + ssoTokenStatus(token, now, s)
+		}
 
 		b.WriteString(line)
 		if i < end-1 {
@@ -124,13 +154,21 @@ func (p *ProfileSelector) View() string {
 	// Scroll indicator
 	if len(p.profiles) > maxVisible {
 		b.WriteString("\n")
-		scrollText := fmt.Sprintf("  ↑↓ %d of %d profiles", p.cursor+1, len(p.profiles))
+		scrollText := fmt.Sprintf("  %s %d of %d profiles", theme.Symbol("↑↓", "^v"), p.cursor+1, len(p.profiles))
 		b.WriteString(s.Muted.Render(scrollText))
 	}
 
+	if p.status != "" {
+		b.WriteString("\n\n" + p.status)
+	}
+
 	// Hint
 	b.WriteString("\n\n")
-	b.WriteString(s.Muted.Render("Press Enter to select, q to quit"))
+	hint := "Press Enter to select, q to quit"
+	if len(p.tokens) > 0 {
+		hint = "Enter select · r refresh SSO token · l aws sso login · q quit"
+	}
+	b.WriteString(s.Muted.Render(hint))
 
 	content := boxStyle.Render(b.String())
 
@@ -142,3 +180,19 @@ func (p *ProfileSelector) View() string {
 		content,
 	)
 }
+
+// ssoTokenStatus renders whether the SSO token of a profile is valid, and
+// if not, what signing in takes.
+func ssoTokenStatus(token model.SSOToken, now time.Time, s theme.Styles) string {
+	switch {
+	case !token.Found:
+		return s.StatusError.Render("SSO not signed in")
+	case token.Expired(now) && token.Refreshable:
+		return s.StatusWarning.Render("SSO expired " + format.Relative(now.Sub(token.ExpiresAt)) + ", refreshable")
+	case token.Expired(now):
+		return s.StatusError.Render("SSO expired " + format.Relative(now.Sub(token.ExpiresAt)) + ", needs login")
+	case token.ExpiresAt.Sub(now) < ssoExpiringWithin:
+		return s.StatusWarning.Render("SSO expires " + format.Relative(now.Sub(token.ExpiresAt)))
+	}
+	return s.StatusSuccess.Render("SSO valid, expires " + format.Relative(now.Sub(token.ExpiresAt)))
+}
@@ -24,7 +24,7 @@ type QuickBar struct {
 	width       int
 	resourceKeys []QuickKey
 	actionKeys   []QuickKey
-	mode         string // Current mode: "", "filter", "command"
+	mode         string // Current mode: "", "filter", "command", "search" or "jq"
 	filterText   string // Current filter text (if in filter mode)
 }
 
@@ -60,7 +60,8 @@ func (q *QuickBar) SetActiveResourceByLabel(label string) {
 	}
 }
 
-// SetMode sets the current mode (empty, "filter", or "command").
+// SetMode sets the current mode (empty, "filter", "command", "search" or
+// "jq").
 func (q *QuickBar) SetMode(mode string) {
 	q.mode = mode
 }
@@ -127,17 +128,21 @@ func (q *QuickBar) View() string {
 	dimLabelStyle := lipgloss.NewStyle().
 		Foreground(theme.TextDim)
 
+	disabledStyle := lipgloss.NewStyle().
+		Foreground(theme.TextMuted).
+		Strikethrough(true)
+
 	separatorStyle := lipgloss.NewStyle().
 		Foreground(theme.Border)
 
 	filterStyle := lipgloss.NewStyle().
 		Foreground(theme.Info)
 
-	separator := separatorStyle.Render(" │ ")
+	separator := separatorStyle.Render(theme.Symbol(" │ ", " | "))
 
 	// Handle special modes
 	if q.mode == "filter" {
-		filterPrompt := filterStyle.Render("Filter: " + q.filterText + "█")
+		filterPrompt := filterStyle.Render("Filter: " + q.filterText + theme.Symbol("█", "_"))
 		hint := dimLabelStyle.Render("  (Enter to apply, Esc to cancel)")
 		content := filterPrompt + hint
 		return bgStyle.Padding(0, 1).Render(content)
@@ -150,8 +155,15 @@ func (q *QuickBar) View() string {
 		return bgStyle.Padding(0, 1).Render(content)
 	}
 
+	if q.mode == "jq" {
+		jqPrompt := filterStyle.Render("jq: " + q.filterText + theme.Symbol("█", "_"))
+		hint := dimLabelStyle.Render("  (Enter to keep, Esc to clear)")
+		content := jqPrompt + hint
+		return bgStyle.Padding(0, 1).Render(content)
+	}
+
 	if q.mode == "search" {
-		searchPrompt := filterStyle.Render("Search: " + q.filterText + "█")
+		searchPrompt := filterStyle.Render("Search: " + q.filterText + theme.Symbol("█", "_"))
 		hint := dimLabelStyle.Render("  (Enter to accept, Esc to clear, n/N to navigate)")
 		content := searchPrompt + hint
 		return bgStyle.Padding(0, 1).Render(content)
@@ -181,8 +193,14 @@ func (q *QuickBar) View() string {
 	// Action keys
 	var actionParts []string
 	for _, ak := range q.actionKeys {
-		keyStr := keyStyle.Render(ak.Key)
-		labelStr := dimLabelStyle.Render(ak.Label)
+		var keyStr, labelStr string
+		if ak.Disabled {
+			keyStr = disabledStyle.Render(ak.Key)
+			labelStr = disabledStyle.Render(ak.Label)
+		} else {
+			keyStr = keyStyle.Render(ak.Key)
+			labelStr = dimLabelStyle.Render(ak.Label)
+		}
 		actionParts = append(actionParts, keyStr+labelStr)
 	}
 	parts = append(parts, strings.Join(actionParts, "  "))
@@ -201,5 +219,6 @@ func DefaultResourceKeys() []QuickKey {
 		{Key: "4", Label: "DynamoDB"},
 		{Key: "5", Label: "API"},
 		{Key: "6", Label: "Stacks"},
+		{Key: "7", Label: "AppRunner"},
 	}
 }
@@ -91,30 +91,38 @@ func (r *RefreshIndicator) View() string {
 	if !r.enabled {
 		return lipgloss.NewStyle().
 			Foreground(theme.TextDim).
-			Render("⏸")
+			Render(theme.Symbol("⏸", "||"))
 	}
 
 	if r.refreshing {
 		spinnerStyle := lipgloss.NewStyle().
 			Foreground(theme.Primary)
-		return spinnerStyle.Render(r.spinnerChars[r.frame])
+		return spinnerStyle.Render(r.spinnerChar())
 	}
 
 	// Show time since last refresh
 	elapsed := r.TimeSinceRefresh()
 	var indicator string
 	if elapsed < 5*time.Second {
-		indicator = "●" // Just refreshed
+		indicator = theme.Symbol("●", "*") // Just refreshed
 	} else if elapsed < r.interval/2 {
-		indicator = "◐"
+		indicator = theme.Symbol("◐", "o")
 	} else {
-		indicator = "○"
+		indicator = theme.Symbol("○", ".")
 	}
 
 	style := lipgloss.NewStyle().Foreground(theme.Success)
 	return style.Render(indicator)
 }
 
+// spinnerChar returns the current frame of the refreshing animation.
+func (r *RefreshIndicator) spinnerChar() string {
+	if theme.ASCII() {
+		return asciiSpinnerFrames[r.frame%len(asciiSpinnerFrames)]
+	}
+	return r.spinnerChars[r.frame]
+}
+
 // StatusView returns a more detailed status for the header
 func (r *RefreshIndicator) StatusView() string {
 	if !r.enabled {
@@ -125,7 +133,7 @@ func (r *RefreshIndicator) StatusView() string {
 
 	if r.refreshing {
 		spinnerStyle := lipgloss.NewStyle().Foreground(theme.Primary)
-		return spinnerStyle.Render(r.spinnerChars[r.frame] + " refreshing...")
+		return spinnerStyle.Render(r.spinnerChar() + " refreshing...")
 	}
 
 	elapsed := r.TimeSinceRefresh()
@@ -1,11 +1,19 @@
 package components
 
 import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/lipgloss"
+
 	"vaws/internal/ui/theme"
 )
 
-// AWSRegions lists common AWS regions grouped by geography
+// AWSRegions lists common AWS regions grouped by geography. It names the
+// regions the account lists, and is shown as is until they are listed.
 var AWSRegions = []RegionGroup{
 	{
 		Name: "US",
@@ -23,7 +31,10 @@ var AWSRegions = []RegionGroup{
 			{Code: "eu-west-2", Name: "London"},
 			{Code: "eu-west-3", Name: "Paris"},
 			{Code: "eu-central-1", Name: "Frankfurt"},
+			{Code: "eu-central-2", Name: "Zurich"},
 			{Code: "eu-north-1", Name: "Stockholm"},
+			{Code: "eu-south-1", Name: "Milan"},
+			{Code: "eu-south-2", Name: "Spain"},
 		},
 	},
 	{
@@ -31,9 +42,16 @@ var AWSRegions = []RegionGroup{
 		Regions: []Region{
 			{Code: "ap-southeast-1", Name: "Singapore"},
 			{Code: "ap-southeast-2", Name: "Sydney"},
+			{Code: "ap-southeast-3", Name: "Jakarta"},
+			{Code: "ap-southeast-4", Name: "Melbourne"},
+			{Code: "ap-southeast-5", Name: "Malaysia"},
+			{Code: "ap-southeast-7", Name: "Thailand"},
 			{Code: "ap-northeast-1", Name: "Tokyo"},
 			{Code: "ap-northeast-2", Name: "Seoul"},
+			{Code: "ap-northeast-3", Name: "Osaka"},
 			{Code: "ap-south-1", Name: "Mumbai"},
+			{Code: "ap-south-2", Name: "Hyderabad"},
+			{Code: "ap-east-1", Name: "Hong Kong"},
 		},
 	},
 	{
@@ -41,12 +59,19 @@ var AWSRegions = []RegionGroup{
 		Regions: []Region{
 			{Code: "sa-east-1", Name: "Sao Paulo"},
 			{Code: "ca-central-1", Name: "Canada"},
+			{Code: "ca-west-1", Name: "Calgary"},
+			{Code: "mx-central-1", Name: "Mexico"},
 			{Code: "me-south-1", Name: "Bahrain"},
+			{Code: "me-central-1", Name: "UAE"},
+			{Code: "il-central-1", Name: "Tel Aviv"},
 			{Code: "af-south-1", Name: "Cape Town"},
 		},
 	},
 }
 
+// fastestRegions is how many of the regions that answered fastest are marked.
+const fastestRegions = 3
+
 // Region represents an AWS region
 type Region struct {
 	Code string // e.g., "us-east-1"
@@ -66,24 +91,162 @@ type RegionSelector struct {
 	cursor        int
 	offset        int
 	currentRegion string
-	flatRegions   []Region // Flattened list for navigation
+	flatRegions   []Region // Flattened list for navigation, pinned regions first
+	regions       []string // Regions the account lists, nil until listed
+	favorites     []string // Pinned regions, in the order they were pinned
+	latencies     map[string]time.Duration
+	measuring     bool
 }
 
 // NewRegionSelector creates a new RegionSelector
 func NewRegionSelector() *RegionSelector {
-	rs := &RegionSelector{
-		flatRegions: flattenRegions(),
-	}
+	rs := &RegionSelector{}
+	rs.rebuild()
 	return rs
 }
 
-// flattenRegions creates a flat list of all regions
-func flattenRegions() []Region {
-	var regions []Region
+// regionGroup returns the index of the geography a region belongs to in
+// AWSRegions, going by its prefix for regions it doesn't list.
+func regionGroup(code string) int {
+	for gi, group := range AWSRegions {
+		for _, r := range group.Regions {
+			if r.Code == code {
+				return gi
+			}
+		}
+	}
+	switch {
+	case strings.HasPrefix(code, "us-"):
+		return 0
+	case strings.HasPrefix(code, "eu-"):
+		return 1
+	case strings.HasPrefix(code, "ap-"):
+		return 2
+	}
+	return len(AWSRegions) - 1
+}
+
+// regionName returns the name of a region, or "" if AWSRegions doesn't list it.
+func regionName(code string) string {
+	for _, group := range AWSRegions {
+		for _, r := range group.Regions {
+			if r.Code == code {
+				return r.Name
+			}
+		}
+	}
+	return ""
+}
+
+// rebuild lays out the regions: pinned ones first, then the account's
+// regions by geography, or AWSRegions until they are listed. The cursor
+// stays on the region it was on.
+func (r *RegionSelector) rebuild() {
+	selected := r.SelectedRegion()
+
+	var codes []string
+	if r.regions != nil {
+		codes = slices.Clone(r.regions)
+	} else {
+		for _, group := range AWSRegions {
+			for _, reg := range group.Regions {
+				codes = append(codes, reg.Code)
+			}
+		}
+	}
+	// Geography first, then the order of AWSRegions, then by code
+	order := make(map[string]int)
 	for _, group := range AWSRegions {
-		regions = append(regions, group.Regions...)
+		for i, reg := range group.Regions {
+			order[reg.Code] = i
+		}
+	}
+	slices.SortStableFunc(codes, func(a, b string) int {
+		if c := cmp.Compare(regionGroup(a), regionGroup(b)); c != 0 {
+			return c
+		}
+		oa, knownA := order[a]
+		ob, knownB := order[b]
+		switch {
+		case knownA && knownB:
+			return cmp.Compare(oa, ob)
+		case knownA:
+			return -1
+		case knownB:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+
+	r.flatRegions = r.flatRegions[:0]
+	for _, code := range r.favorites {
+		r.flatRegions = append(r.flatRegions, Region{Code: code, Name: regionName(code)})
+	}
+	for _, code := range codes {
+		if !slices.Contains(r.favorites, code) {
+			r.flatRegions = append(r.flatRegions, Region{Code: code, Name: regionName(code)})
+		}
 	}
-	return regions
+
+	if i := slices.IndexFunc(r.flatRegions, func(reg Region) bool { return reg.Code == selected }); i >= 0 {
+		r.cursor = i
+	}
+	r.cursor = min(r.cursor, max(0, len(r.flatRegions)-1))
+	r.clampOffset()
+}
+
+// SetRegions sets the regions the account lists.
+func (r *RegionSelector) SetRegions(regions []string) {
+	r.regions = regions
+	r.rebuild()
+}
+
+// SetFavorites sets the pinned regions, shown first.
+func (r *RegionSelector) SetFavorites(favorites []string) {
+	r.favorites = slices.Clone(favorites)
+	r.rebuild()
+}
+
+// ToggleFavorite pins or unpins the selected region and returns the pinned
+// regions.
+func (r *RegionSelector) ToggleFavorite() []string {
+	code := r.SelectedRegion()
+	if code == "" {
+		return r.favorites
+	}
+	if i := slices.Index(r.favorites, code); i >= 0 {
+		r.favorites = slices.Delete(r.favorites, i, i+1)
+	} else {
+		r.favorites = append(r.favorites, code)
+	}
+	r.rebuild()
+	return slices.Clone(r.favorites)
+}
+
+// SetLatencies sets how long each region took to answer. Regions missing
+// from latencies did not answer.
+func (r *RegionSelector) SetLatencies(latencies map[string]time.Duration) {
+	r.latencies = latencies
+	r.measuring = false
+}
+
+// SetMeasuring marks that latencies are being measured.
+func (r *RegionSelector) SetMeasuring(measuring bool) {
+	r.measuring = measuring
+}
+
+// fastest returns the regions that answered fastest.
+func (r *RegionSelector) fastest() map[string]bool {
+	codes := make([]string, 0, len(r.latencies))
+	for code := range r.latencies {
+		codes = append(codes, code)
+	}
+	slices.SortFunc(codes, func(a, b string) int { return cmp.Compare(r.latencies[a], r.latencies[b]) })
+	fastest := make(map[string]bool)
+	for _, code := range codes[:min(len(codes), fastestRegions)] {
+		fastest[code] = true
+	}
+	return fastest
 }
 
 // SetSize sets the selector dimensions
@@ -170,22 +333,39 @@ func (r *RegionSelector) View() string {
 		Foreground(theme.TextMuted).
 		Width(16)
 
+	pinStyle := lipgloss.NewStyle().
+		Foreground(theme.Warning)
+
+	latencyStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim)
+
+	fastestStyle := lipgloss.NewStyle().
+		Foreground(theme.Success).
+		Bold(true)
+
 	hintStyle := lipgloss.NewStyle().
 		Foreground(theme.TextDim).
 		Italic(true)
 
+	boxWidth := min(60, r.width-4)
 	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BorderStyle()).
 		BorderForeground(theme.BorderFocus).
 		Padding(1, 2).
-		Width(min(50, r.width-4))
+		Width(boxWidth)
 
 	var content string
 	content += titleStyle.Render("Select AWS Region") + "\n"
-	content += subtitleStyle.Render("Current: "+r.currentRegion) + "\n\n"
+	subtitle := "Current: " + r.currentRegion
+	if r.measuring {
+		subtitle += theme.Symbol(" · ", " - ") + "measuring latency..."
+	}
+	content += subtitleStyle.Render(subtitle) + "\n\n"
 
+	fastest := r.fastest()
 	visible := r.visibleCount()
 	end := min(r.offset+visible, len(r.flatRegions))
+	lineWidth := boxWidth - 4 // Padding
 
 	for i := r.offset; i < end; i++ {
 		region := r.flatRegions[i]
@@ -194,7 +374,12 @@ func (r *RegionSelector) View() string {
 
 		var line string
 		if isSelected {
-			line += selectedStyle.Render("▸ ")
+			line += selectedStyle.Render(theme.Symbol("▸ ", "> "))
+		} else {
+			line += "  "
+		}
+		if slices.Contains(r.favorites, region.Code) {
+			line += pinStyle.Render(theme.Symbol("★ ", "* "))
 		} else {
 			line += "  "
 		}
@@ -203,18 +388,33 @@ func (r *RegionSelector) View() string {
 
 		var name string
 		if isCurrent {
-			name = currentStyle.Render(region.Name + " (current)")
+			name = currentStyle.Render(strings.TrimSpace(region.Name + " (current)"))
 		} else if isSelected {
 			name = selectedStyle.Render(region.Name)
 		} else {
 			name = normalStyle.Render(region.Name)
 		}
 
+		var latency string
+		if d, ok := r.latencies[region.Code]; ok {
+			latency = fmt.Sprintf("%dms", d.Milliseconds())
+			if fastest[region.Code] {
+				latency = fastestStyle.Render(theme.Symbol("⚡", "*") + latency)
+			} else {
+				latency = latencyStyle.Render(latency)
+			}
+		} else if r.latencies != nil {
+			latency = latencyStyle.Render("-")
+		}
+
 		line += code + name
-		content += line + "\n"
+		if gap := lineWidth - lipgloss.Width(line) - lipgloss.Width(latency); gap > 0 {
+			line += strings.Repeat(" ", gap)
+		}
+		content += line + latency + "\n"
 	}
 
-	content += "\n" + hintStyle.Render("↑↓ navigate • Enter select • Esc cancel")
+	content += "\n" + hintStyle.Render("↑↓ navigate • Enter select • p pin • Esc cancel")
 
 	return lipgloss.Place(
 		r.width,
@@ -12,6 +12,17 @@ import (
 // Spinner frames for animation
 var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
+// asciiSpinnerFrames replace spinnerFrames in ASCII-only mode
+var asciiSpinnerFrames = []string{"|", "/", "-", "\\"}
+
+// spinnerFrame returns frame i of the spinner animation.
+func spinnerFrame(i int) string {
+	if theme.ASCII() {
+		return asciiSpinnerFrames[i%len(asciiSpinnerFrames)]
+	}
+	return spinnerFrames[i]
+}
+
 // Alternative spinner styles:
 // var spinnerFrames = []string{"◐", "◓", "◑", "◒"}
 // var spinnerFrames = []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"}
@@ -42,7 +53,7 @@ func (s *Spinner) Tick() {
 // View returns the current spinner frame.
 func (s *Spinner) View() string {
 	spinnerStyle := lipgloss.NewStyle().Foreground(theme.Primary)
-	return spinnerStyle.Render(spinnerFrames[s.frame])
+	return spinnerStyle.Render(spinnerFrame(s.frame))
 }
 
 // TickCmd returns a command that sends SpinnerTickMsg at the spinner's interval.
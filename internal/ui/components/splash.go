@@ -19,6 +19,24 @@ var logoLines = []string{
 	"   ╚═══╝  ╚═╝  ╚═╝ ╚══╝╚══╝ ╚══════╝",
 }
 
+// asciiLogoLines replace logoLines in ASCII-only mode
+var asciiLogoLines = []string{
+	"                          ",
+	"__   ____ ___      _____  ",
+	"\\ \\ / / _` \\ \\ /\\ / / __| ",
+	" \\ V / (_| |\\ V  V /\\__ \\ ",
+	"  \\_/ \\__,_| \\_/\\_/ |___/ ",
+	"                          ",
+}
+
+// logo returns the lines of the splash logo.
+func logo() []string {
+	if theme.ASCII() {
+		return asciiLogoLines
+	}
+	return logoLines
+}
+
 const (
 	animationFPS  = 30
 	frameInterval = time.Second / animationFPS
@@ -129,7 +147,7 @@ func (s *Splash) View() string {
 	// Decorative line
 	decorLine := lipgloss.NewStyle().
 		Foreground(theme.PrimaryMuted).
-		Render("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		Render(strings.Repeat(theme.Symbol("━", "="), 65))
 
 	var lines []string
 
@@ -138,6 +156,7 @@ func (s *Splash) View() string {
 		lines = append(lines, "")
 	}
 
+	logoLines := logo()
 	for i := 0; i < s.revealed && i < len(logoLines); i++ {
 		lines = append(lines, logoStyle.Render(logoLines[i]))
 	}
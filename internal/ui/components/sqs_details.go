@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"vaws/internal/model"
+	"vaws/internal/ui/format"
 	"vaws/internal/ui/theme"
 )
 
@@ -104,10 +105,10 @@ func (d *SQSDetails) renderDetails() string {
 	content.WriteString(sectionStyle.Render("Message Statistics"))
 	content.WriteString("\n")
 	content.WriteString(labelStyle.Render("Messages:"))
-	content.WriteString(valueStyle.Render(fmt.Sprintf("%d", q.ApproximateMessageCount)))
+	content.WriteString(valueStyle.Render(format.Count(int64(q.ApproximateMessageCount))))
 	content.WriteString("\n")
 	content.WriteString(labelStyle.Render("In Flight:"))
-	content.WriteString(valueStyle.Render(fmt.Sprintf("%d", q.ApproximateInFlight)))
+	content.WriteString(valueStyle.Render(format.Count(int64(q.ApproximateInFlight))))
 	content.WriteString("\n\n")
 
 	// Configuration
@@ -124,7 +125,7 @@ func (d *SQSDetails) renderDetails() string {
 	content.WriteString("\n")
 	if !q.CreatedAt.IsZero() {
 		content.WriteString(labelStyle.Render("Created:"))
-		content.WriteString(valueStyle.Render(q.CreatedAt.Format("2006-01-02")))
+		content.WriteString(valueStyle.Render(format.Date(q.CreatedAt)))
 		content.WriteString("\n")
 	}
 	content.WriteString("\n")
@@ -138,7 +139,7 @@ func (d *SQSDetails) renderDetails() string {
 		content.WriteString("\n")
 		content.WriteString(labelStyle.Render("DLQ Messages:"))
 		if q.DLQMessageCount > 0 {
-			content.WriteString(warningStyle.Render(fmt.Sprintf("%d", q.DLQMessageCount)))
+			content.WriteString(warningStyle.Render(format.Count(int64(q.DLQMessageCount))))
 		} else {
 			content.WriteString(valueStyle.Render("0"))
 		}
@@ -172,7 +173,7 @@ func (d *SQSDetails) renderDetails() string {
 
 	// Create bordered container
 	containerStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BorderStyle()).
 		BorderForeground(theme.Border).
 		Padding(1, 2).
 		Width(containerWidth)
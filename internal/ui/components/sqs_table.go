@@ -7,51 +7,121 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"vaws/internal/model"
+	"vaws/internal/ui/format"
 	"vaws/internal/ui/theme"
 )
 
 // SQSTable displays SQS queues in a simple table format.
 type SQSTable struct {
-	width   int
-	height  int
-	queues  []model.Queue
-	cursor  int
-	loading bool
-	err     error
-	spinner *Spinner
+	width      int
+	height     int
+	queues     []model.Queue
+	cursor     int
+	loading    bool
+	refreshing bool // Keep showing the current rows while loading
+	err        error
+	spinner    *Spinner
+
+	selected   string                    // URL of the queue the user last moved to
+	highlights map[string]lipgloss.Style // Row styles set by highlight rules, by queue URL
+	filter     string                    // Filter being typed, underlined in queue names
+
+	columns []Column                            // Shown after the name
+	cells   func(model.Queue) map[string]string // Values of the columns of a queue
 }
 
-// NewSQSTable creates a new SQSTable.
+// NewSQSTable creates a new SQSTable showing the messages and messages in
+// flight of each queue.
 func NewSQSTable() *SQSTable {
 	return &SQSTable{
 		spinner: NewSpinner(),
+		columns: []Column{
+			{Key: "messages", Title: "MESSAGES", Width: 10, Right: true},
+			{Key: "in_flight", Title: "IN FLIGHT", Width: 12, Right: true},
+		},
+		cells: func(q model.Queue) map[string]string {
+			return map[string]string{
+				"messages":  format.Count(int64(q.ApproximateMessageCount)),
+				"in_flight": format.Count(int64(q.ApproximateInFlight)),
+			}
+		},
 	}
 }
 
+// SetColumns sets the columns shown after the queue names, with the values
+// cells returns for each queue.
+func (t *SQSTable) SetColumns(columns []Column, cells func(model.Queue) map[string]string) {
+	t.columns = columns
+	t.cells = cells
+}
+
 // SetSize sets the table dimensions.
 func (t *SQSTable) SetSize(width, height int) {
 	t.width = width
 	t.height = height
 }
 
-// SetQueues sets the queue list.
+// SetQueues sets the queue list. The cursor stays on the selected queue if it is
+// still present.
 func (t *SQSTable) SetQueues(queues []model.Queue) {
+	selected := t.selected
+	if selected == "" {
+		if cur := t.SelectedQueue(); cur != nil {
+			selected = cur.URL
+		}
+	}
+
 	t.queues = queues
+	for i := range queues {
+		if selected != "" && queues[i].URL == selected {
+			t.cursor = i
+			return
+		}
+	}
 	if t.cursor >= len(queues) {
 		t.cursor = max(0, len(queues)-1)
 	}
 }
 
+// rememberSelection records the queue under the cursor after the user moves it.
+func (t *SQSTable) rememberSelection() {
+	t.selected = ""
+	if cur := t.SelectedQueue(); cur != nil {
+		t.selected = cur.URL
+	}
+}
+
 // SetLoading sets the loading state.
 func (t *SQSTable) SetLoading(loading bool) {
+	if !loading {
+		t.refreshing = false
+	}
 	t.loading = loading
 }
 
+// SetRefreshing marks the next load as a refresh of the current rows, which
+// stay visible while it runs. It has no effect on an empty table.
+func (t *SQSTable) SetRefreshing(refreshing bool) {
+	t.refreshing = refreshing && len(t.queues) > 0
+}
+
 // SetError sets the error state.
 func (t *SQSTable) SetError(err error) {
 	t.err = err
 }
 
+// SetHighlights sets the styles of rows matched by highlight rules, keyed by
+// queue URL.
+func (t *SQSTable) SetHighlights(highlights map[string]lipgloss.Style) {
+	t.highlights = highlights
+}
+
+// SetFilterHighlight sets the text underlined where it appears in queue
+// names, or clears it when empty.
+func (t *SQSTable) SetFilterHighlight(query string) {
+	t.filter = query
+}
+
 // Spinner returns the spinner for loading animation.
 func (t *SQSTable) Spinner() *Spinner {
 	return t.spinner
@@ -74,6 +144,7 @@ func (t *SQSTable) SelectedQueue() *model.Queue {
 func (t *SQSTable) Up() {
 	if t.cursor > 0 {
 		t.cursor--
+		t.rememberSelection()
 	}
 }
 
@@ -81,12 +152,14 @@ func (t *SQSTable) Up() {
 func (t *SQSTable) Down() {
 	if t.cursor < len(t.queues)-1 {
 		t.cursor++
+		t.rememberSelection()
 	}
 }
 
 // Top moves the cursor to the top.
 func (t *SQSTable) Top() {
 	t.cursor = 0
+	t.rememberSelection()
 }
 
 // Bottom moves the cursor to the bottom.
@@ -94,6 +167,7 @@ func (t *SQSTable) Bottom() {
 	if len(t.queues) > 0 {
 		t.cursor = len(t.queues) - 1
 	}
+	t.rememberSelection()
 }
 
 // QueueCount returns the number of queues.
@@ -103,7 +177,7 @@ func (t *SQSTable) QueueCount() int {
 
 // View renders the SQS table.
 func (t *SQSTable) View() string {
-	if t.loading {
+	if t.loading && !t.refreshing {
 		return t.renderLoading()
 	}
 
@@ -154,12 +228,9 @@ func (t *SQSTable) renderTable() string {
 	// Add top margin
 	b.WriteString("\n")
 
-	// Fixed column widths - compact
-	msgWidth := 10
-	flightWidth := 12
-
-	// NAME gets remaining space but with reasonable limit
-	availableForName := t.width - msgWidth - flightWidth - 8
+	// NAME gets the space the columns leave, but with reasonable limit
+	colsWidth := columnsWidth(t.columns)
+	availableForName := t.width - colsWidth - 4
 	nameWidth := availableForName
 	if nameWidth > 80 {
 		nameWidth = 80
@@ -169,7 +240,7 @@ func (t *SQSTable) renderTable() string {
 	}
 
 	// Total used width
-	totalWidth := nameWidth + msgWidth + flightWidth + 4
+	totalWidth := nameWidth + colsWidth
 
 	// Styles
 	headerStyle := lipgloss.NewStyle().
@@ -180,14 +251,10 @@ func (t *SQSTable) renderTable() string {
 	selectedStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
 
 	// Header
-	header := fmt.Sprintf("  %-*s  %*s  %*s",
-		nameWidth, "NAME",
-		msgWidth, "MESSAGES",
-		flightWidth, "IN FLIGHT",
-	)
+	header := fmt.Sprintf("  %-*s", nameWidth, "NAME") + renderColumnTitles(t.columns)
 	b.WriteString(headerStyle.Render(header))
 	b.WriteString("\n")
-	b.WriteString(dimStyle.Render(strings.Repeat("─", totalWidth+2)))
+	b.WriteString(dimStyle.Render(strings.Repeat(theme.Symbol("─", "-"), totalWidth+2)))
 	b.WriteString("\n")
 
 	// Calculate visible rows (accounting for top margin, header, separator)
@@ -225,19 +292,16 @@ func (t *SQSTable) renderTable() string {
 		}
 
 		// Build row with consistent spacing
-		row := fmt.Sprintf("%s%-*s  %*d  %*d",
-			cursor,
-			nameWidth, name,
-			msgWidth, q.ApproximateMessageCount,
-			flightWidth, q.ApproximateInFlight,
-		)
+		rest := renderCells(t.columns, t.cells(q))
 
 		// Apply style
+		style := lipgloss.NewStyle()
 		if isSelected {
-			b.WriteString(selectedStyle.Render(row))
-		} else {
-			b.WriteString(row)
+			style = selectedStyle
+		} else if highlight, ok := t.highlights[q.URL]; ok {
+			style = highlight
 		}
+		b.WriteString(style.Render(cursor) + highlightMatch(fmt.Sprintf("%-*s", nameWidth, name), t.filter, style) + style.Render(rest))
 
 		if i < endIdx-1 {
 			b.WriteString("\n")
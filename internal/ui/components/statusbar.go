@@ -18,7 +18,13 @@ type StatusBar struct {
 	version       string
 	profile       string
 	region        string
+	tagFilter     string
 	activeTunnels int
+	macro         string
+	refresh       string
+	degraded      string
+	alerts        int
+	alertsBlink   bool
 }
 
 // NewStatusBar creates a new StatusBar component.
@@ -48,11 +54,42 @@ func (s *StatusBar) SetRegion(region string) {
 	s.region = region
 }
 
+// SetTagFilter sets the tag filter scoping every list, such as
+// "Environment=prod", or clears it when empty.
+func (s *StatusBar) SetTagFilter(filter string) {
+	s.tagFilter = filter
+}
+
 // SetActiveTunnels sets the number of active tunnels.
 func (s *StatusBar) SetActiveTunnels(count int) {
 	s.activeTunnels = count
 }
 
+// SetMacro sets the macro indicator, such as "REC" while recording, or
+// clears it when empty.
+func (s *StatusBar) SetMacro(macro string) {
+	s.macro = macro
+}
+
+// SetRefresh sets the auto-refresh warning, such as "refresh paused (r)", or
+// clears it when empty.
+func (s *StatusBar) SetRefresh(refresh string) {
+	s.refresh = refresh
+}
+
+// SetDegraded sets the warning for features turned off because a program
+// they run is missing, such as "SSM off", or clears it when empty.
+func (s *StatusBar) SetDegraded(degraded string) {
+	s.degraded = degraded
+}
+
+// SetAlerts sets the number of alerts raised by watch expressions. blink
+// shows the badge inverted, for flashing it until the alerts are seen.
+func (s *StatusBar) SetAlerts(count int, blink bool) {
+	s.alerts = count
+	s.alertsBlink = blink
+}
+
 // View renders the status bar.
 func (s *StatusBar) View() string {
 	// Styles
@@ -77,13 +114,34 @@ func (s *StatusBar) View() string {
 	regionStyle := lipgloss.NewStyle().
 		Foreground(theme.Info)
 
+	tagFilterStyle := lipgloss.NewStyle().
+		Foreground(theme.BgSubtle).
+		Background(theme.Primary).
+		Bold(true)
+
 	tunnelStyle := lipgloss.NewStyle().
 		Foreground(theme.Warning)
 
+	macroStyle := lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Bold(true)
+
+	refreshStyle := lipgloss.NewStyle().
+		Foreground(theme.Warning)
+
+	alertStyle := lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Bold(true)
+	if s.alertsBlink {
+		alertStyle = alertStyle.
+			Foreground(theme.BgSubtle).
+			Background(theme.Error)
+	}
+
 	keyStyle := lipgloss.NewStyle().
 		Foreground(theme.TextMuted)
 
-	separator := separatorStyle.Render(" │ ")
+	separator := separatorStyle.Render(theme.Symbol(" │ ", " | "))
 
 	// Build left side: logo + version
 	left := logoStyle.Render("vaws") + " " + versionStyle.Render(s.version)
@@ -92,21 +150,45 @@ func (s *StatusBar) View() string {
 	var middleParts []string
 
 	if s.profile != "" {
-		middleParts = append(middleParts, profileStyle.Render("◉ "+s.profile))
+		middleParts = append(middleParts, profileStyle.Render(theme.Symbol("◉ ", "")+s.profile))
 	}
 
 	if s.region != "" {
 		middleParts = append(middleParts, regionStyle.Render(s.region))
 	}
 
+	if s.tagFilter != "" {
+		middleParts = append(middleParts, tagFilterStyle.Render(" "+theme.Symbol("⚑ ", "tag ")+s.tagFilter+" "))
+	}
+
 	if s.activeTunnels > 0 {
-		tunnelText := fmt.Sprintf("⚡%d tunnel", s.activeTunnels)
+		tunnelText := fmt.Sprintf("%s%d tunnel", theme.Symbol("⚡", ""), s.activeTunnels)
 		if s.activeTunnels > 1 {
 			tunnelText += "s"
 		}
 		middleParts = append(middleParts, tunnelStyle.Render(tunnelText))
 	}
 
+	if s.macro != "" {
+		middleParts = append(middleParts, macroStyle.Render(theme.Symbol("● ", "")+s.macro))
+	}
+
+	if s.alerts > 0 {
+		alertText := fmt.Sprintf("%s%d alert", theme.Symbol("▲ ", "! "), s.alerts)
+		if s.alerts > 1 {
+			alertText += "s"
+		}
+		middleParts = append(middleParts, alertStyle.Render(alertText))
+	}
+
+	if s.refresh != "" {
+		middleParts = append(middleParts, refreshStyle.Render(theme.Symbol("⏸ ", "")+s.refresh))
+	}
+
+	if s.degraded != "" {
+		middleParts = append(middleParts, refreshStyle.Render(theme.Symbol("⚠ ", "! ")+s.degraded))
+	}
+
 	middle := strings.Join(middleParts, separator)
 
 	// Build right side: shortcuts
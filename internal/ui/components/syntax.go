@@ -0,0 +1,217 @@
+package components
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/theme"
+)
+
+// Syntaxes of source lines the details pane highlights.
+const (
+	SyntaxJSON = "json"
+	SyntaxYAML = "yaml"
+)
+
+// sourceStyles color the tokens of a highlighted line of source.
+type sourceStyles struct {
+	plain    lipgloss.Style
+	key      lipgloss.Style
+	str      lipgloss.Style
+	literal  lipgloss.Style // Numbers, booleans and null
+	function lipgloss.Style // CloudFormation intrinsic functions, e.g. Ref or !Sub
+	comment  lipgloss.Style
+}
+
+func newSourceStyles() sourceStyles {
+	return sourceStyles{
+		plain:    lipgloss.NewStyle().Foreground(theme.Text),
+		key:      lipgloss.NewStyle().Foreground(theme.Primary),
+		str:      lipgloss.NewStyle().Foreground(theme.Success),
+		literal:  lipgloss.NewStyle().Foreground(theme.Warning),
+		function: lipgloss.NewStyle().Foreground(theme.Info).Bold(true),
+		comment:  lipgloss.NewStyle().Foreground(theme.TextDim).Italic(true),
+	}
+}
+
+// HighlightSource renders a line of JSON or YAML with its keys, strings,
+// literals, comments and CloudFormation functions colored. Other syntaxes
+// are rendered plain.
+func HighlightSource(syntax, line string) string {
+	st := newSourceStyles()
+	switch syntax {
+	case SyntaxJSON:
+		return highlightJSON(st, line)
+	case SyntaxYAML:
+		return highlightYAML(st, line)
+	}
+	return st.plain.Render(line)
+}
+
+// isFunctionKey reports whether a mapping key is an intrinsic function in
+// its long form, e.g. "Fn::GetAtt".
+func isFunctionKey(key string) bool {
+	return key == "Ref" || key == "Condition" || strings.HasPrefix(key, "Fn::")
+}
+
+// isLiteral reports whether a bare word is a number, boolean or null.
+func isLiteral(word string) bool {
+	switch strings.ToLower(word) {
+	case "true", "false", "null", "~", "yes", "no":
+		return true
+	}
+	if word == "" {
+		return false
+	}
+	for i, r := range word {
+		if !unicode.IsDigit(r) && r != '.' && !(i == 0 && (r == '-' || r == '+')) {
+			return false
+		}
+	}
+	return word != "-" && word != "+"
+}
+
+func highlightJSON(st sourceStyles, line string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case c == '"':
+			end := quotedEnd(line, i)
+			token := line[i:end]
+			rest := strings.TrimLeft(line[end:], " ")
+			style := st.str
+			if strings.HasPrefix(rest, ":") {
+				style = st.key
+				if isFunctionKey(strings.Trim(token, `"`)) {
+					style = st.function
+				}
+			}
+			b.WriteString(style.Render(token))
+			i = end
+		case c == '-' || (c >= '0' && c <= '9') || unicode.IsLetter(rune(c)):
+			end := i + 1
+			for end < len(line) && (unicode.IsLetter(rune(line[end])) || unicode.IsDigit(rune(line[end])) || strings.ContainsRune(".eE+-", rune(line[end]))) {
+				end++
+			}
+			word := line[i:end]
+			if isLiteral(word) {
+				b.WriteString(st.literal.Render(word))
+			} else {
+				b.WriteString(st.plain.Render(word))
+			}
+			i = end
+		default:
+			end := i + 1
+			for end < len(line) && !strings.ContainsRune(`"-0123456789`, rune(line[end])) && !unicode.IsLetter(rune(line[end])) {
+				end++
+			}
+			b.WriteString(st.plain.Render(line[i:end]))
+			i = end
+		}
+	}
+	return b.String()
+}
+
+// quotedEnd returns the index just past the string quoted at line[start],
+// or the end of the line if it isn't closed there.
+func quotedEnd(line string, start int) int {
+	quote := line[start]
+	for i := start + 1; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && quote == '"':
+			i++
+		case line[i] == quote:
+			return i + 1
+		}
+	}
+	return len(line)
+}
+
+func highlightYAML(st sourceStyles, line string) string {
+	var b strings.Builder
+	rest := strings.TrimLeft(line, " ")
+	b.WriteString(line[:len(line)-len(rest)])
+	if strings.HasPrefix(rest, "#") {
+		return b.String() + st.comment.Render(rest)
+	}
+	for strings.HasPrefix(rest, "- ") || rest == "-" {
+		b.WriteString(st.plain.Render(rest[:min(2, len(rest))]))
+		rest = rest[min(2, len(rest)):]
+	}
+
+	if key, value, ok := yamlKey(rest); ok {
+		style := st.key
+		if isFunctionKey(strings.Trim(key, `"'`)) {
+			style = st.function
+		}
+		b.WriteString(style.Render(key))
+		b.WriteString(st.plain.Render(":"))
+		rest = value
+	}
+	b.WriteString(highlightYAMLValue(st, rest))
+	return b.String()
+}
+
+// yamlKey splits "key: value" into the key and what follows the colon. A
+// colon only ends a key if a space or the end of the line follows it.
+func yamlKey(s string) (key, value string, ok bool) {
+	if s == "" || strings.HasPrefix(s, "!") || strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+		return "", "", false
+	}
+	end := 0
+	if s[0] == '"' || s[0] == '\'' {
+		end = quotedEnd(s, 0)
+	}
+	for i := end; i < len(s); i++ {
+		if s[i] == '#' && i > 0 && s[i-1] == ' ' {
+			return "", "", false
+		}
+		if s[i] == ':' && (i == len(s)-1 || s[i+1] == ' ') {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+func highlightYAMLValue(st sourceStyles, s string) string {
+	var b strings.Builder
+	value := strings.TrimLeft(s, " ")
+	b.WriteString(s[:len(s)-len(value)])
+
+	// Short form functions, e.g. !GetAtt Queue.Arn
+	if strings.HasPrefix(value, "!") {
+		tag, after, _ := strings.Cut(value, " ")
+		b.WriteString(st.function.Render(tag))
+		if after != "" {
+			b.WriteString(" " + highlightYAMLValue(st, after))
+		}
+		return b.String()
+	}
+
+	comment := ""
+	if value != "" && value[0] != '"' && value[0] != '\'' {
+		if i := strings.Index(value, " #"); i >= 0 {
+			value, comment = value[:i], value[i:]
+		}
+	}
+	switch {
+	case value == "":
+	case value[0] == '"' || value[0] == '\'':
+		end := quotedEnd(value, 0)
+		b.WriteString(st.str.Render(value[:end]))
+		if end < len(value) {
+			b.WriteString(st.plain.Render(value[end:]))
+		}
+	case isLiteral(strings.TrimSpace(value)):
+		b.WriteString(st.literal.Render(value))
+	default:
+		b.WriteString(st.str.Render(value))
+	}
+	if comment != "" {
+		b.WriteString(st.comment.Render(comment))
+	}
+	return b.String()
+}
@@ -0,0 +1,227 @@
+package components
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// TimeRangePresets are the ranges every view offers, before the longer ones
+// some add.
+var TimeRangePresets = []time.Duration{15 * time.Minute, time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+// TimeRangePicker is a dialog choosing the time range a view reads over:
+// the last of a preset duration, or a custom absolute range.
+type TimeRangePicker struct {
+	title   string
+	presets []time.Duration
+	width   int
+	height  int
+	active  bool
+	cursor  int  // Index into presets, or len(presets) for the custom range
+	editing bool // Typing the custom range
+	input   textinput.Model
+	err     string
+}
+
+// TimeRangeResult is the range chosen in the picker.
+type TimeRangeResult struct {
+	Cancelled bool
+	Range     model.TimeRange
+}
+
+// NewTimeRangePicker creates a new time range picker.
+func NewTimeRangePicker() *TimeRangePicker {
+	input := textinput.New()
+	input.Placeholder = "2006-01-02 15:04 to 2006-01-02 18:00"
+	input.CharLimit = 64
+	input.Width = 40
+	return &TimeRangePicker{input: input}
+}
+
+// SetSize sets the dialog size.
+func (p *TimeRangePicker) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// Activate shows the picker with the current range selected.
+func (p *TimeRangePicker) Activate(title string, presets []time.Duration, current model.TimeRange) {
+	p.title = title
+	p.presets = presets
+	p.active = true
+	p.editing = false
+	p.err = ""
+	p.input.Blur()
+	p.input.SetValue("")
+
+	p.cursor = 0
+	for i, d := range presets {
+		if !current.IsAbsolute() && d == current.Last {
+			p.cursor = i
+		}
+	}
+	if current.IsAbsolute() {
+		p.cursor = len(presets)
+		p.input.SetValue(RangeInput(current))
+	}
+}
+
+// Deactivate hides the picker.
+func (p *TimeRangePicker) Deactivate() {
+	p.active = false
+	p.editing = false
+	p.input.Blur()
+}
+
+// IsActive returns whether the picker is shown.
+func (p *TimeRangePicker) IsActive() bool {
+	return p.active
+}
+
+// Update handles keys, returning the chosen range once picked.
+func (p *TimeRangePicker) Update(msg tea.Msg) (*TimeRangeResult, tea.Cmd) {
+	if !p.active {
+		return nil, nil
+	}
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil, nil
+	}
+
+	if p.editing {
+		switch key.String() {
+		case "enter":
+			r, err := model.ParseTimeRange(p.input.Value(), time.Now())
+			if err != nil {
+				p.err = err.Error()
+				return nil, nil
+			}
+			p.Deactivate()
+			return &TimeRangeResult{Range: r}, nil
+		case "esc":
+			p.editing = false
+			p.err = ""
+			p.input.Blur()
+			return nil, nil
+		}
+		var cmd tea.Cmd
+		p.input, cmd = p.input.Update(msg)
+		return nil, cmd
+	}
+
+	switch key.String() {
+	case "up", "k", "shift+tab":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j", "tab":
+		if p.cursor < len(p.presets) {
+			p.cursor++
+		}
+	case "enter":
+		if p.cursor < len(p.presets) {
+			p.Deactivate()
+			return &TimeRangeResult{Range: model.LastRange(p.presets[p.cursor])}, nil
+		}
+		p.editing = true
+		p.input.Focus()
+		return nil, textinput.Blink
+	case "esc", "q":
+		p.Deactivate()
+		return &TimeRangeResult{Cancelled: true}, nil
+	}
+	return nil, nil
+}
+
+// View renders the picker.
+func (p *TimeRangePicker) View() string {
+	if !p.active {
+		return ""
+	}
+
+	dialogWidth := min(60, max(p.width-10, 40))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	titleStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	selectedStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(theme.Text)
+	hintStyle := lipgloss.NewStyle().Foreground(theme.TextDim).Italic(true)
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(truncate("Time range: "+p.title, dialogWidth-6)))
+	b.WriteString("\n\n")
+
+	labels := make([]string, 0, len(p.presets)+1)
+	for _, d := range p.presets {
+		labels = append(labels, "Last "+format.Age(d))
+	}
+	labels = append(labels, "Custom range")
+	for i, label := range labels {
+		if i == p.cursor {
+			b.WriteString(theme.Symbol("▸ ", "> ") + selectedStyle.Render(label))
+		} else {
+			b.WriteString("  " + normalStyle.Render(label))
+		}
+		b.WriteString("\n")
+	}
+
+	if p.cursor == len(p.presets) {
+		b.WriteString("\n")
+		b.WriteString("From:  " + p.input.View())
+		b.WriteString("\n")
+		if p.err != "" {
+			b.WriteString(errorStyle.Render(truncate(p.err, dialogWidth-6)))
+			b.WriteString("\n")
+		}
+		b.WriteString(hintStyle.Render("Local YYYY-MM-DD HH:MM, or HH:MM today; without \"to\" up to now"))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if p.editing {
+		b.WriteString(hintStyle.Render("Enter: apply | Esc: back"))
+	} else {
+		b.WriteString(hintStyle.Render("Enter: choose | Esc: cancel"))
+	}
+	return boxStyle.Render(b.String())
+}
+
+// RangeInput writes an absolute range the way the custom range input reads
+// it, e.g. "2024-05-01 09:00 to 2024-05-01 12:30".
+func RangeInput(r model.TimeRange) string {
+	s := r.Start.Local().Format("2006-01-02 15:04")
+	if !r.End.IsZero() {
+		s += " to " + r.End.Local().Format("2006-01-02 15:04")
+	}
+	return s
+}
+
+// RangeLabel describes a range for a header, e.g. "last 1h" or
+// "from 2024-05-01 09:00 to 12:30".
+func RangeLabel(r model.TimeRange) string {
+	switch {
+	case !r.IsAbsolute():
+		return "last " + format.Age(r.Last)
+	case r.End.IsZero():
+		return "since " + r.Start.Local().Format("2006-01-02 15:04")
+	}
+	start, end := r.Start.Local(), r.End.Local()
+	if start.Format("2006-01-02") == end.Format("2006-01-02") {
+		return "from " + start.Format("2006-01-02 15:04") + " to " + end.Format("15:04")
+	}
+	return "from " + RangeInput(r)
+}
@@ -17,7 +17,9 @@ type TunnelsPanel struct {
 	height       int
 	tunnels      []model.Tunnel
 	apiGWTunnels []model.APIGatewayTunnel
+	probes       map[string]model.TunnelProbe // Tunnel ID -> last health probe
 	cursor       int
+	selectedID   string // ID of the tunnel under the cursor, kept across updates
 }
 
 // NewTunnelsPanel creates a new TunnelsPanel.
@@ -34,19 +36,48 @@ func (t *TunnelsPanel) SetSize(width, height int) {
 // SetTunnels sets the ECS tunnel list.
 func (t *TunnelsPanel) SetTunnels(tunnels []model.Tunnel) {
 	t.tunnels = tunnels
-	totalCount := len(tunnels) + len(t.apiGWTunnels)
-	if t.cursor >= totalCount {
-		t.cursor = max(0, totalCount-1)
-	}
+	t.restoreCursor()
 }
 
 // SetAPIGatewayTunnels sets the API Gateway tunnel list.
 func (t *TunnelsPanel) SetAPIGatewayTunnels(tunnels []model.APIGatewayTunnel) {
 	t.apiGWTunnels = tunnels
-	totalCount := len(t.tunnels) + len(tunnels)
+	t.restoreCursor()
+}
+
+// SetProbes sets the last health probe of each tunnel.
+func (t *TunnelsPanel) SetProbes(probes map[string]model.TunnelProbe) {
+	t.probes = probes
+}
+
+// idAt returns the ID of the tunnel at index i across both lists.
+func (t *TunnelsPanel) idAt(i int) string {
+	if i >= 0 && i < len(t.tunnels) {
+		return t.tunnels[i].ID
+	}
+	if j := i - len(t.tunnels); j >= 0 && j < len(t.apiGWTunnels) {
+		return t.apiGWTunnels[j].ID
+	}
+	return ""
+}
+
+// restoreCursor moves the cursor back to the selected tunnel after the lists
+// change, since tunnels are listed in map order and shift around between
+// updates. If the tunnel is gone the cursor stays in range.
+func (t *TunnelsPanel) restoreCursor() {
+	totalCount := len(t.tunnels) + len(t.apiGWTunnels)
+	if t.selectedID != "" {
+		for i := 0; i < totalCount; i++ {
+			if t.idAt(i) == t.selectedID {
+				t.cursor = i
+				return
+			}
+		}
+	}
 	if t.cursor >= totalCount {
 		t.cursor = max(0, totalCount-1)
 	}
+	t.selectedID = t.idAt(t.cursor)
 }
 
 // Cursor returns the current cursor position.
@@ -76,6 +107,7 @@ func (t *TunnelsPanel) Up() {
 	if t.cursor > 0 {
 		t.cursor--
 	}
+	t.selectedID = t.idAt(t.cursor)
 }
 
 // Down moves the cursor down.
@@ -84,6 +116,7 @@ func (t *TunnelsPanel) Down() {
 	if t.cursor < totalCount-1 {
 		t.cursor++
 	}
+	t.selectedID = t.idAt(t.cursor)
 }
 
 // View renders the tunnels panel.
@@ -91,10 +124,7 @@ func (t *TunnelsPanel) View() string {
 	s := theme.DefaultStyles()
 
 	// Adaptive styles for tunnels
-	tunnelActiveStyle := lipgloss.NewStyle().Foreground(theme.Success).Bold(true)
-	tunnelStartingStyle := lipgloss.NewStyle().Foreground(theme.Warning)
 	tunnelErrorStyle := lipgloss.NewStyle().Foreground(theme.Error)
-	tunnelTerminatedStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
 	tunnelPortStyle := lipgloss.NewStyle().Foreground(theme.Info).Bold(true)
 	tunnelServiceStyle := lipgloss.NewStyle().Foreground(theme.Text)
 	tunnelHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Primary).PaddingBottom(1)
@@ -139,29 +169,14 @@ func (t *TunnelsPanel) View() string {
 		itemIndex++
 
 		// Status indicator
-		var statusIcon string
-		var statusStyle lipgloss.Style
-		switch tun.Status {
-		case model.TunnelStatusActive:
-			statusIcon = "●"
-			statusStyle = tunnelActiveStyle
-		case model.TunnelStatusStarting:
-			statusIcon = "◐"
-			statusStyle = tunnelStartingStyle
-		case model.TunnelStatusError:
-			statusIcon = "✗"
-			statusStyle = tunnelErrorStyle
-		case model.TunnelStatusTerminated:
-			statusIcon = "○"
-			statusStyle = tunnelTerminatedStyle
-		}
+		statusIcon, statusStyle := tunnelStatusIcon(tun.Status)
 
 		// Build line
 		var line strings.Builder
 
 		// Cursor
 		if isSelected {
-			line.WriteString(s.SidebarCursor.Render("▸ "))
+			line.WriteString(s.SidebarCursor.Render(theme.Symbol("▸ ", "> ")))
 		} else {
 			line.WriteString("  ")
 		}
@@ -171,22 +186,35 @@ func (t *TunnelsPanel) View() string {
 		line.WriteString(" ")
 
 		// Type indicator
-		line.WriteString(tunnelTypeStyle.Render("[ECS] "))
+		if tun.JumpHostID != "" {
+			line.WriteString(tunnelTypeStyle.Render("[EC2] "))
+		} else {
+			line.WriteString(tunnelTypeStyle.Render("[ECS] "))
+		}
 
 		// Port info
 		portInfo := tunnelPortStyle.Render(fmt.Sprintf("localhost:%d", tun.LocalPort))
 		line.WriteString(portInfo)
-		line.WriteString(" → ")
-		line.WriteString(fmt.Sprintf(":%d", tun.RemotePort))
+		line.WriteString(theme.Symbol(" → ", " -> "))
+		line.WriteString(fmt.Sprintf("%s:%d", tun.RemoteHost, tun.RemotePort))
 		line.WriteString("  ")
 
-		// Service name
+		// Service name (the jump task for discovered endpoints, the label for jump host tunnels)
 		line.WriteString(tunnelServiceStyle.Render(tun.ServiceName))
+		if tun.Relay != "" {
+			line.WriteString(s.Muted.Render(" via " + tun.Relay))
+		}
 
 		// Duration
 		if tun.Status == model.TunnelStatusActive {
 			duration := time.Since(tun.StartedAt).Truncate(time.Second)
 			line.WriteString(s.Muted.Render(fmt.Sprintf("  (%s)", duration)))
+
+			// Health probe, which tells a session that is up from an application that answers
+			if probe, ok := t.probes[tun.ID]; ok {
+				line.WriteString("  ")
+				line.WriteString(tunnelProbeView(probe))
+			}
 		}
 
 		// Error message
@@ -216,29 +244,14 @@ func (t *TunnelsPanel) View() string {
 		itemIndex++
 
 		// Status indicator
-		var statusIcon string
-		var statusStyle lipgloss.Style
-		switch tun.Status {
-		case model.TunnelStatusActive:
-			statusIcon = "●"
-			statusStyle = tunnelActiveStyle
-		case model.TunnelStatusStarting:
-			statusIcon = "◐"
-			statusStyle = tunnelStartingStyle
-		case model.TunnelStatusError:
-			statusIcon = "✗"
-			statusStyle = tunnelErrorStyle
-		case model.TunnelStatusTerminated:
-			statusIcon = "○"
-			statusStyle = tunnelTerminatedStyle
-		}
+		statusIcon, statusStyle := tunnelStatusIcon(tun.Status)
 
 		// Build line
 		var line strings.Builder
 
 		// Cursor
 		if isSelected {
-			line.WriteString(s.SidebarCursor.Render("▸ "))
+			line.WriteString(s.SidebarCursor.Render(theme.Symbol("▸ ", "> ")))
 		} else {
 			line.WriteString("  ")
 		}
@@ -249,15 +262,22 @@ func (t *TunnelsPanel) View() string {
 
 		// Type indicator
 		tunnelTypeLabel := "[APIGW]"
-		if tun.TunnelType == model.APIGatewayTunnelPrivate {
+		switch tun.TunnelType {
+		case model.APIGatewayTunnelPrivate:
 			tunnelTypeLabel = "[APIGW-VPC]"
+		case model.APIGatewayTunnelMock:
+			tunnelTypeLabel = "[MOCK]"
 		}
 		line.WriteString(tunnelTypeStyle.Render(tunnelTypeLabel + " "))
 
 		// Port info
-		portInfo := tunnelPortStyle.Render(fmt.Sprintf("localhost:%d", tun.LocalPort))
+		portLabel := fmt.Sprintf("localhost:%d", tun.LocalPort)
+		if tun.TLS {
+			portLabel = "https://" + portLabel
+		}
+		portInfo := tunnelPortStyle.Render(portLabel)
 		line.WriteString(portInfo)
-		line.WriteString(" → ")
+		line.WriteString(theme.Symbol(" → ", " -> "))
 		line.WriteString(tunnelServiceStyle.Render(fmt.Sprintf("%s/%s", tun.APIName, tun.StageName)))
 
 		// Duration
@@ -266,6 +286,13 @@ func (t *TunnelsPanel) View() string {
 			line.WriteString(s.Muted.Render(fmt.Sprintf("  (%s)", duration)))
 		}
 
+		// Routes of mock servers, proxy rules of the others
+		if tun.TunnelType == model.APIGatewayTunnelMock {
+			line.WriteString(s.Muted.Render(fmt.Sprintf("  [%d routes]", tun.MockRoutes)))
+		} else if !tun.Rules.IsEmpty() {
+			line.WriteString(s.Muted.Render(fmt.Sprintf("  [%d hdr, %d rw]", len(tun.Rules.Headers), len(tun.Rules.Rewrites))))
+		}
+
 		// Error message
 		if tun.Status == model.TunnelStatusError && tun.Error != "" {
 			errText := tun.Error
@@ -289,3 +316,45 @@ func (t *TunnelsPanel) View() string {
 
 	return tunnelContainerStyle.Render(b.String())
 }
+
+// tunnelProbeView renders a health probe: the status and latency of the
+// answer, or why there was none.
+func tunnelProbeView(p model.TunnelProbe) string {
+	switch {
+	case !p.SessionUp:
+		return lipgloss.NewStyle().Foreground(theme.TextDim).Render(p.Error)
+	case p.Healthy():
+		text := fmt.Sprintf("%s %d %s", p.Path, p.StatusCode, p.Latency.Round(time.Millisecond))
+		return lipgloss.NewStyle().Foreground(theme.Success).Render(text)
+	case p.Responding():
+		text := fmt.Sprintf("%s %d %s", p.Path, p.StatusCode, p.Latency.Round(time.Millisecond))
+		return lipgloss.NewStyle().Foreground(theme.Warning).Render(text)
+	default:
+		return lipgloss.NewStyle().Foreground(theme.Error).Render(p.Path + " " + p.Error)
+	}
+}
+
+// tunnelStatusIcon returns the icon and style of a tunnel status. With status
+// text on the icon is spelled out, so states don't depend on color alone.
+func tunnelStatusIcon(status model.TunnelStatus) (string, lipgloss.Style) {
+	var icon, ascii, tag string
+	var style lipgloss.Style
+	switch status {
+	case model.TunnelStatusActive:
+		icon, ascii, tag = "●", "*", theme.TagOK
+		style = lipgloss.NewStyle().Foreground(theme.Success).Bold(true)
+	case model.TunnelStatusStarting:
+		icon, ascii, tag = "◐", "o", theme.TagWarn
+		style = lipgloss.NewStyle().Foreground(theme.Warning)
+	case model.TunnelStatusError:
+		icon, ascii, tag = "✗", "x", theme.TagErr
+		style = lipgloss.NewStyle().Foreground(theme.Error)
+	case model.TunnelStatusTerminated:
+		icon, ascii, tag = "○", ".", "OFF"
+		style = lipgloss.NewStyle().Foreground(theme.TextDim)
+	}
+	if theme.StatusText() {
+		return tag, style
+	}
+	return theme.Symbol(icon, ascii), style
+}
@@ -1,6 +1,12 @@
 package components
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/theme"
+)
 
 // truncate truncates a string to the specified maximum width.
 func truncate(s string, maxLen int) string {
@@ -17,3 +23,25 @@ func truncate(s string, maxLen int) string {
 	}
 	return s
 }
+
+// highlightMatch renders s with base, and the first case-insensitive match of
+// query in it underlined, for showing what a filter matched.
+func highlightMatch(s, query string, base lipgloss.Style) string {
+	lower, q := strings.ToLower(s), strings.ToLower(query)
+	i := strings.Index(lower, q)
+	// Lowercasing can change the length of some non-ASCII text, which would
+	// put the match at the wrong place
+	if query == "" || i < 0 || len(lower) != len(s) || len(q) != len(query) {
+		return base.Render(s)
+	}
+	j := i + len(q)
+	// The pieces are rendered apart, so the padding goes around all of them
+	left := strings.Repeat(" ", base.GetPaddingLeft())
+	right := strings.Repeat(" ", base.GetPaddingRight())
+	base = base.UnsetPadding()
+	match := base.Underline(true).Bold(true)
+	if _, ok := base.GetBackground().(lipgloss.NoColor); ok {
+		match = match.Foreground(theme.Warning)
+	}
+	return base.Render(left+s[:i]) + match.Render(s[i:j]) + base.Render(s[j:]+right)
+}
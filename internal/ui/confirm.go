@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/theme"
+)
+
+// confirmPrompt is a pending action that runs only once the user answers y.
+type confirmPrompt struct {
+	title   string
+	details []string // Lines naming what the action affects
+	run     func() tea.Cmd
+}
+
+// askConfirm shows a y/n dialog for an action that changes AWS resources.
+func (m *Model) askConfirm(title string, details []string, run func() tea.Cmd) tea.Cmd {
+	m.pendingConfirm = &confirmPrompt{title: title, details: details, run: run}
+	return nil
+}
+
+// handleConfirmKey handles key messages while a confirm dialog is open. Only
+// y runs the action; n or esc dismisses the dialog.
+func (m *Model) handleConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	prompt := m.pendingConfirm
+	switch msg.String() {
+	case "y", "Y":
+		m.pendingConfirm = nil
+		m.recordConfirmed(prompt)
+		return prompt.run()
+	case "n", "N", "esc", "q":
+		m.pendingConfirm = nil
+		m.logger.Info("Cancelled: %s", prompt.title)
+	}
+	return nil
+}
+
+// renderConfirmDialog renders the pending confirm dialog.
+func (m *Model) renderConfirmDialog() string {
+	dialogWidth := 70
+	if m.width < 80 {
+		dialogWidth = m.width - 10
+		if dialogWidth < 40 {
+			dialogWidth = 40
+		}
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.Warning).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Warning).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	var details []string
+	for _, line := range m.pendingConfirm.details {
+		details = append(details, truncateString(line, dialogWidth-6))
+	}
+
+	dialogContent := labelStyle.Render(m.pendingConfirm.title+"?") + "\n\n" +
+		strings.Join(details, "\n") + "\n\n" +
+		hintStyle.Render("y to confirm, n or esc to cancel")
+
+	return dialogStyle.Render(dialogContent)
+}
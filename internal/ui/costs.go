@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// costsPanel is the dialog showing the month's estimated charges and the
+// budgets of the account.
+type costsPanel struct {
+	summary *model.CostSummary
+	loading bool
+	err     error
+}
+
+// costsLoadedMsg carries the cost summary of the account.
+type costsLoadedMsg struct {
+	summary *model.CostSummary
+	err     error
+}
+
+// openCosts opens the costs panel and loads the charges and budgets.
+func (m *Model) openCosts() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	m.costs = &costsPanel{}
+	return m.loadCosts()
+}
+
+// loadCosts loads the cost summary of the panel in the background.
+func (m *Model) loadCosts() tea.Cmd {
+	m.costs.loading = true
+	m.costs.err = nil
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		summary, err := client.GetCostSummary(ctx)
+		return costsLoadedMsg{summary: summary, err: err}
+	}
+}
+
+// handleCostsLoaded fills the costs panel, if it is still open, and logs
+// the budgets that need attention.
+func (m *Model) handleCostsLoaded(msg costsLoadedMsg) {
+	if msg.err != nil {
+		m.logger.Error("Failed to load costs: %v", msg.err)
+	} else {
+		for _, w := range msg.summary.Warnings {
+			m.logger.Warn("Costs incomplete: %s", w)
+		}
+		for _, b := range msg.summary.Budgets {
+			if b.UsedPercent() >= 100 {
+				m.logger.Warn("Budget %s is over its limit: %s of %s", b.Name, formatMoney(b.Actual, b.Unit), formatMoney(b.Limit, b.Unit))
+			}
+		}
+	}
+	if m.costs == nil {
+		return
+	}
+	m.costs.loading = false
+	m.costs.err = msg.err
+	m.costs.summary = msg.summary
+}
+
+// handleCostsKey handles key messages while the costs panel is open.
+func (m *Model) handleCostsKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "q":
+		m.costs = nil
+	case "r":
+		if !m.costs.loading {
+			return m.loadCosts()
+		}
+	}
+	return nil
+}
+
+// formatMoney renders an amount, e.g. "$1234.56" for USD or "12.00 GB" for
+// usage budgets.
+func formatMoney(amount float64, unit string) string {
+	if unit == "USD" || unit == "" {
+		return fmt.Sprintf("$%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, unit)
+}
+
+// budgetStyle returns the style of a share of a budget: an error once over
+// it, a warning from model.BudgetWarnPercent.
+func budgetStyle(percent float64) lipgloss.Style {
+	s := GetStyles()
+	switch {
+	case percent >= 100:
+		return s.StatusError
+	case percent >= model.BudgetWarnPercent:
+		return s.StatusWarning
+	default:
+		return s.StatusHealthy
+	}
+}
+
+// renderCostsDialog renders the month's estimated charges and the budgets
+// with what has been spent against them.
+func (m *Model) renderCostsDialog() string {
+	c := m.costs
+	dialogWidth := 80
+	if m.width < 90 {
+		dialogWidth = max(m.width-10, 40)
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Costs")
+
+	switch {
+	case c.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Reading the billing metric and budgets..."))
+	case c.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(c.err.Error(), dialogWidth-6)) + "\n\n" + hintStyle.Render("r to retry · esc to close"))
+	}
+
+	sum := c.summary
+	var lines []string
+	if sum.Currency != "" {
+		lines = append(lines, "Estimated charges this month: "+lipgloss.NewStyle().Bold(true).Render(formatMoney(sum.Charges, sum.Currency))+
+			s.Muted.Render(" (updated "+format.Relative(time.Since(sum.ChargesAt))+")"))
+	} else {
+		lines = append(lines, s.Muted.Render("No estimated charges: turn on billing alerts in the Billing preferences"))
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, labelStyle.Render(fmt.Sprintf("Budgets (%d)", len(sum.Budgets))))
+	if len(sum.Budgets) == 0 {
+		lines = append(lines, s.Muted.Render("  No budgets"))
+	}
+	nameWidth := 0
+	for _, b := range sum.Budgets {
+		nameWidth = max(nameWidth, min(len(b.Name), 30))
+	}
+	for _, b := range sum.Budgets {
+		used := b.UsedPercent()
+		line := fmt.Sprintf("  %-*s  %s / %s  ", nameWidth, truncateString(b.Name, nameWidth), formatMoney(b.Actual, b.Unit), formatMoney(b.Limit, b.Unit))
+		line += budgetStyle(used).Render(fmt.Sprintf("%3.0f%%", used))
+		if b.Forecast > 0 {
+			forecast := b.ForecastPercent()
+			line += s.Muted.Render("  forecast ") + budgetStyle(forecast).Render(fmt.Sprintf("%.0f%%", forecast))
+		}
+		line += s.Muted.Render("  " + strings.ToLower(b.TimeUnit))
+		lines = append(lines, line)
+	}
+
+	if len(sum.Warnings) > 0 {
+		lines = append(lines, "")
+		for _, w := range sum.Warnings {
+			lines = append(lines, s.StatusWarning.Render(truncateString("! "+w, dialogWidth-6)))
+		}
+	}
+
+	content := title + "\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		hintStyle.Render(fmt.Sprintf("Yellow from %d%% spent or forecast · r reload · esc", model.BudgetWarnPercent))
+	return dialogStyle.Render(content)
+}
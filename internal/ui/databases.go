@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+	"vaws/internal/ui/components"
+)
+
+// databaseColumns are the columns of the databases list; the endpoint is in
+// the details, as it takes more room than the list has.
+var databaseColumns = []components.Column{
+	{Key: "kind", Title: "KIND", Width: 18},
+	{Key: "engine", Title: "ENGINE", Width: 17},
+	{Key: "port", Title: "PORT", Width: 5, Right: true},
+}
+
+// selectedDatabase returns the database under the cursor.
+func (m *Model) selectedDatabase() *model.Database {
+	item := m.databaseList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.Databases {
+		if m.state.Databases[i].ID == item.ID {
+			return &m.state.Databases[i]
+		}
+	}
+	return nil
+}
+
+// handleDatabasePortForward opens the port dialog for the selected
+// database, with the database's own port as the local one, so clients
+// configured for it work against localhost unchanged.
+func (m *Model) handleDatabasePortForward() tea.Cmd {
+	db := m.selectedDatabase()
+	if db == nil {
+		return nil
+	}
+	if db.Endpoint == "" || db.Port == 0 {
+		m.logger.Warn("Database %s has no endpoint yet", db.Name)
+		return nil
+	}
+	if !db.Available() {
+		m.logger.Warn("Database %s is %s; the tunnel may not connect until it is available", db.Name, db.Status)
+	}
+	m.pendingDatabase = db
+	m.enteringPort = true
+	m.portInput.SetValue(strconv.Itoa(db.Port))
+	m.portInput.CursorEnd()
+	m.portInput.Focus()
+	return textinput.Blink
+}
+
+// findDatabaseTunnelTarget finds a jump host in the VPC of a database, or
+// any jump host if the VPC isn't known, as for Aurora Serverless v1
+// clusters, which have no instances to read it from.
+func (m *Model) findDatabaseTunnelTarget(db model.Database, localPort int) tea.Cmd {
+	jumpHostConfig := ""
+	jumpHostTagConfig := m.jumpHostTag()
+	if m.cfg != nil {
+		jumpHostConfig = m.cfg.GetJumpHost(m.state.Profile)
+	}
+	defaultTags, defaultNames := m.jumpHostDefaults()
+	m.logger.Info("Finding a jump host for %s...", db.Name)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		var preferred []string
+		if db.VPCID != "" {
+			preferred = append(preferred, db.VPCID)
+		}
+		jumpHost, err := m.client.FindJumpHost(ctx, db.VPCID, jumpHostConfig, jumpHostTagConfig, defaultTags, defaultNames, preferred...)
+		if err != nil {
+			return databaseTunnelTargetMsg{database: db, err: fmt.Errorf("failed to find jump host: %w", err)}
+		}
+		return databaseTunnelTargetMsg{database: db, jumpHost: *jumpHost, localPort: localPort}
+	}
+}
+
+// startDatabaseTunnel opens the tunnel to a database endpoint and logs the
+// local address to connect to.
+func (m *Model) startDatabaseTunnel(db model.Database, jumpHost model.EC2Instance, localPort int) tea.Cmd {
+	m.logger.Info("Tunneling to %s (%s:%d) via %s (%s)", db.Name, db.Endpoint, db.Port, jumpHost.Name, jumpHost.InstanceID)
+	m.logger.Info("TLS hostname checks (e.g. sslmode=verify-full) need %s mapped to 127.0.0.1", db.Endpoint)
+	name := "db-" + strings.ReplaceAll(db.Name, "/", "-")
+	if db.Kind == model.DatabaseKindReader {
+		name += "-reader"
+	}
+	return m.startJumpHostTunnel(jumpHost, name, db.Endpoint, db.Port, localPort)
+}
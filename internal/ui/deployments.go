@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// deploymentsShown is how many deployments the deployments dialog lists at
+// once; the list scrolls with the cursor.
+const deploymentsShown = 10
+
+// stageDeployments is the deployment history dialog of a REST API stage.
+type stageDeployments struct {
+	apiID       string
+	apiName     string
+	stage       string
+	current     string // Deployment the stage serves
+	deployments []model.APIDeployment
+	loading     bool
+	err         error
+	cursor      int
+}
+
+// deploymentsLoadedMsg carries the deployments of a REST API.
+type deploymentsLoadedMsg struct {
+	apiID       string
+	deployments []model.APIDeployment
+	err         error
+}
+
+// stageDeploymentSetMsg reports a stage pointed at another deployment.
+type stageDeploymentSetMsg struct {
+	apiID      string
+	apiName    string
+	stage      string
+	deployment model.APIDeployment
+	err        error
+}
+
+// openDeployments opens the deployment history of the selected stage of a
+// REST API and loads the API's deployments.
+func (m *Model) openDeployments() tea.Cmd {
+	if m.state.View != state.ViewAPIStages || m.client == nil {
+		return nil
+	}
+	api := m.state.SelectedRestAPI
+	if api == nil {
+		m.logger.Warn("Deployment history is only available for REST APIs")
+		return nil
+	}
+	item := m.apiStagesList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	d := &stageDeployments{apiID: api.ID, apiName: api.Name, stage: item.ID, loading: true}
+	for _, s := range m.state.APIStages {
+		if s.Name == item.ID {
+			d.current = s.DeploymentID
+		}
+	}
+	m.deployments = d
+
+	client, apiID := m.client, api.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		deployments, err := client.ListRestAPIDeployments(ctx, apiID)
+		return deploymentsLoadedMsg{apiID: apiID, deployments: deployments, err: err}
+	}
+}
+
+// handleDeploymentsLoaded fills the deployments dialog, if it is still open
+// on the API, with the cursor on the deployment the stage serves.
+func (m *Model) handleDeploymentsLoaded(msg deploymentsLoadedMsg) {
+	d := m.deployments
+	if d == nil || d.apiID != msg.apiID {
+		return
+	}
+	d.loading = false
+	d.err = msg.err
+	d.deployments = msg.deployments
+	for i, dep := range d.deployments {
+		if dep.ID == d.current {
+			d.cursor = i
+		}
+	}
+}
+
+// handleDeploymentsKey handles key messages while the deployments dialog is
+// open.
+func (m *Model) handleDeploymentsKey(msg tea.KeyMsg) tea.Cmd {
+	d := m.deployments
+	switch msg.String() {
+	case "esc", "q":
+		m.deployments = nil
+	case "up", "k":
+		if d.cursor > 0 {
+			d.cursor--
+		}
+	case "down", "j":
+		if d.cursor < len(d.deployments)-1 {
+			d.cursor++
+		}
+	case "enter":
+		return m.submitDeployment()
+	}
+	return nil
+}
+
+// submitDeployment asks to point the stage at the deployment under the
+// cursor.
+func (m *Model) submitDeployment() tea.Cmd {
+	d := m.deployments
+	if d.loading || d.cursor >= len(d.deployments) {
+		return nil
+	}
+	target := d.deployments[d.cursor]
+	if target.ID == d.current {
+		m.logger.Warn("Stage %s already serves deployment %s", d.stage, target.ID)
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	title := "Roll back stage " + d.stage
+	now := "Now: " + d.current
+	for i, dep := range d.deployments {
+		if dep.ID != d.current {
+			continue
+		}
+		now = "Now: " + deploymentText(dep)
+		if i > d.cursor {
+			title = "Roll forward stage " + d.stage
+		}
+	}
+	m.deployments = nil
+	apiID, apiName, stage := d.apiID, d.apiName, d.stage
+	return m.askConfirm(title, []string{
+		"API: " + apiName,
+		now,
+		"After: " + deploymentText(target),
+	}, func() tea.Cmd {
+		return m.setStageDeployment(apiID, apiName, stage, target)
+	})
+}
+
+// deploymentText describes a deployment for the confirm dialog, e.g.
+// "abc123 (3d ago) fix auth".
+func deploymentText(d model.APIDeployment) string {
+	text := d.ID + " (" + format.Relative(time.Since(d.CreatedDate)) + ")"
+	if d.Description != "" {
+		text += " " + d.Description
+	}
+	return text
+}
+
+// setStageDeployment points a stage at a deployment.
+func (m *Model) setStageDeployment(apiID, apiName, stage string, deployment model.APIDeployment) tea.Cmd {
+	m.logger.Info("Pointing stage %s of %s at deployment %s...", stage, apiName, deployment.ID)
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		err := client.SetRestAPIStageDeployment(ctx, apiID, stage, deployment.ID)
+		return stageDeploymentSetMsg{apiID: apiID, apiName: apiName, stage: stage, deployment: deployment, err: err}
+	}
+}
+
+// handleStageDeploymentSet logs the deployment a stage now serves and
+// reloads the stages if they are still shown.
+func (m *Model) handleStageDeploymentSet(msg stageDeploymentSetMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Error("Failed to update stage: %v", msg.err)
+		return nil
+	}
+	m.logger.Info("Stage %s of %s now serves deployment %s", msg.stage, msg.apiName, msg.deployment.ID)
+	if m.state.View == state.ViewAPIStages && m.state.SelectedRestAPI != nil && m.state.SelectedRestAPI.ID == msg.apiID {
+		return m.loadAPIStages()
+	}
+	return nil
+}
+
+// renderDeploymentsDialog renders the deployments dialog: the deployments
+// of the API, newest first, with the one the stage serves marked.
+func (m *Model) renderDeploymentsDialog() string {
+	d := m.deployments
+	dialogWidth := 80
+	if m.width < 90 {
+		dialogWidth = max(m.width-10, 40)
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Deployments: " + truncateString(d.apiName+" / "+d.stage, dialogWidth-20))
+
+	switch {
+	case d.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Loading deployments..."))
+	case d.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(d.err.Error(), dialogWidth-6)) + "\n\n" + hintStyle.Render("esc to close"))
+	case len(d.deployments) == 0:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("No deployments") + "\n\n" + hintStyle.Render("esc to close"))
+	}
+
+	idWidth := 0
+	for _, dep := range d.deployments {
+		idWidth = max(idWidth, len(dep.ID))
+	}
+	start := max(0, min(d.cursor-deploymentsShown/2, len(d.deployments)-deploymentsShown))
+	end := min(start+deploymentsShown, len(d.deployments))
+	var lines []string
+	for i := start; i < end; i++ {
+		dep := d.deployments[i]
+		cursor := "  "
+		if i == d.cursor {
+			cursor = theme.Symbol("▶ ", "> ")
+		}
+		line := cursor + fmt.Sprintf("%-*s  %s  ", idWidth, dep.ID, format.Absolute(dep.CreatedDate))
+		marker := ""
+		if dep.ID == d.current {
+			marker = s.StatusHealthy.Render("current ")
+		}
+		desc := truncateString(dep.Description, max(dialogWidth-6-lipgloss.Width(line)-lipgloss.Width(marker), 0))
+		lines = append(lines, line+marker+s.Muted.Render(desc))
+	}
+
+	position := fmt.Sprintf("%d of %d", d.cursor+1, len(d.deployments))
+	content := title + "\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		hintStyle.Render(position+" · ↑/↓ deployment · enter points the stage at it · esc")
+	return dialogStyle.Render(content)
+}
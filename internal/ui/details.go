@@ -1,20 +1,29 @@
 package ui
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
+	"vaws/internal/aws"
+	"vaws/internal/model"
 	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
 	"vaws/internal/ui/theme"
 )
 
 // updateStackDetails updates the details panel with stack information.
 func (m *Model) updateStackDetails() {
+	if m.showsStackTemplate() {
+		return
+	}
 	item := m.stacksList.SelectedItem()
-	if item == nil {
+	if item == nil || item.Group {
 		m.details.SetRows(nil)
 		return
 	}
@@ -25,11 +34,16 @@ func (m *Model) updateStackDetails() {
 			rows := components.StackDetails(
 				s.Name,
 				string(s.Status),
-				s.CreatedAt.Format("2006-01-02 15:04:05"),
-				s.UpdatedAt.Format("2006-01-02 15:04:05"),
+				format.Time(s.CreatedAt),
+				format.Time(s.UpdatedAt),
 				s.Description,
 				StatusStyle(string(s.Status)),
 			)
+			rows = append(rows, m.stackDescriptionRows(s)...)
+			rows = append(rows, m.changeRows(s.ID, stackFingerprint(s.UpdatedAt), stackUpdate)...)
+			rows = append(rows, m.terraformRows(s.ID)...)
+			m.highlightDetails("stacks", stackFields(s), rows)
+			rows = append(m.noteRows(s.ID), rows...)
 			m.details.SetTitle("Stack Details")
 			m.details.SetRows(rows)
 			return
@@ -74,6 +88,12 @@ func (m *Model) updateServiceDetails() {
 				containerPortsStr,
 				ServiceStatusStyle(s.RunningCount, s.DesiredCount),
 			)
+			rows = append(rows, m.changeRows(s.ARN, s.TaskDefinition, shortTaskDefinition)...)
+			rows = append(rows, discoveryRows(s.DiscoveryEndpoints)...)
+			rows = append(rows, m.vulnerabilityRows(s)...)
+			rows = append(rows, m.terraformRows(s.ARN)...)
+			m.highlightDetails("services", serviceFields(s), rows)
+			rows = append(m.noteRows(s.ARN), rows...)
 			m.details.SetTitle("Service Details")
 			m.details.SetRows(rows)
 			return
@@ -81,6 +101,33 @@ func (m *Model) updateServiceDetails() {
 	}
 }
 
+// discoveryRows formats Service Connect and Cloud Map endpoints, grouped by namespace.
+func discoveryRows(endpoints []model.DiscoveryEndpoint) []components.DetailRow {
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	rows := []components.DetailRow{
+		{Label: "", Value: ""}, // Spacer
+		{Label: "Discovery", Value: fmt.Sprintf("%d endpoint(s), press d to tunnel", len(endpoints))},
+	}
+	lastNamespace := ""
+	for _, ep := range endpoints {
+		if ep.Namespace != lastNamespace {
+			rows = append(rows, components.DetailRow{Label: "Namespace", Value: ep.Namespace})
+			lastNamespace = ep.Namespace
+		}
+		value := ep.Address()
+		style := lipgloss.NewStyle().Foreground(theme.Success)
+		if value == "" {
+			value = ep.Name + " (not resolvable by DNS)"
+			style = lipgloss.NewStyle().Foreground(theme.TextDim)
+		}
+		rows = append(rows, components.DetailRow{Label: "  " + ep.Source, Value: value, Style: style})
+	}
+	return rows
+}
+
 // updateLambdaDetails updates the details panel with Lambda function information.
 func (m *Model) updateLambdaDetails() {
 	item := m.lambdaList.SelectedItem()
@@ -101,9 +148,13 @@ func (m *Model) updateLambdaDetails() {
 				{Label: "Code Size", Value: formatBytes(fn.CodeSize)},
 				{Label: "State", Value: string(fn.State), Style: FunctionStatusStyle(fn.State)},
 				{Label: "Package Type", Value: fn.PackageType},
-				{Label: "Last Modified", Value: fn.LastModified.Format("2006-01-02 15:04:05")},
+				{Label: "Last Modified", Value: format.Time(fn.LastModified)},
 				{Label: "Description", Value: fn.Description},
 			}
+			rows = append(rows, m.changeRows(fn.ARN, fn.CodeSha256, lambdaCode)...)
+			rows = append(rows, m.terraformRows(fn.ARN)...)
+			m.highlightDetails("lambda", functionFields(fn), rows)
+			rows = append(m.noteRows(fn.ARN), rows...)
 
 			// Add invocation state if available
 			if m.state.LambdaInvocationLoading {
@@ -143,19 +194,24 @@ func (m *Model) updateLambdaDetails() {
 					})
 				}
 
-				// Show truncated response
-				response := result.Payload
-				if len(response) > 100 {
-					response = response[:100] + "..."
+				// JSON responses are shown as a tree below the rows
+				if !json.Valid([]byte(result.Payload)) {
+					response := result.Payload
+					if len(response) > 100 {
+						response = response[:100] + "..."
+					}
+					rows = append(rows, components.DetailRow{
+						Label: "Response",
+						Value: response,
+					})
 				}
-				rows = append(rows, components.DetailRow{
-					Label: "Response",
-					Value: response,
-				})
 			}
 
 			m.details.SetTitle("Lambda Function Details")
 			m.details.SetRows(rows)
+			if result := m.state.LambdaInvocationResult; result != nil && !m.state.LambdaInvocationLoading && m.state.LambdaInvocationError == nil {
+				m.details.SetJSON("Response", result.Payload)
+			}
 			return
 		}
 	}
@@ -189,7 +245,7 @@ func (m *Model) updateAPIGatewayDetails() {
 					{Label: "Endpoint Type", Value: api.EndpointType},
 					{Label: "Endpoint URL", Value: endpointURL},
 					{Label: "Version", Value: api.Version},
-					{Label: "Created", Value: api.CreatedDate.Format("2006-01-02 15:04:05")},
+					{Label: "Created", Value: format.Time(api.CreatedDate)},
 					{Label: "Description", Value: api.Description},
 				}
 				m.details.SetTitle("REST API Details")
@@ -208,7 +264,7 @@ func (m *Model) updateAPIGatewayDetails() {
 					{Label: "Protocol", Value: api.ProtocolType},
 					{Label: "Endpoint", Value: api.ApiEndpoint},
 					{Label: "Version", Value: api.Version},
-					{Label: "Created", Value: api.CreatedDate.Format("2006-01-02 15:04:05")},
+					{Label: "Created", Value: format.Time(api.CreatedDate)},
 					{Label: "Description", Value: api.Description},
 				}
 				m.details.SetTitle("HTTP API Details")
@@ -234,9 +290,10 @@ func (m *Model) updateAPIStageDetails() {
 				{Label: "Stage Name", Value: stage.Name},
 				{Label: "Deployment ID", Value: stage.DeploymentID},
 				{Label: "Invoke URL", Value: stage.InvokeURL},
-				{Label: "Created", Value: stage.CreatedDate.Format("2006-01-02 15:04:05")},
-				{Label: "Last Updated", Value: stage.LastUpdated.Format("2006-01-02 15:04:05")},
+				{Label: "Created", Value: format.Time(stage.CreatedDate)},
+				{Label: "Last Updated", Value: format.Time(stage.LastUpdated)},
 				{Label: "Description", Value: stage.Description},
+				{Label: "Access Logs", Value: valueOrDash(stage.AccessLogGroup)},
 			}
 			m.details.SetTitle("API Stage Details")
 			m.details.SetRows(rows)
@@ -258,8 +315,8 @@ func (m *Model) updateQueueDetails() {
 		{Label: "Name", Value: q.Name},
 		{Label: "Type", Value: string(q.Type)},
 		{Label: "", Value: ""}, // Spacer
-		{Label: "Messages", Value: fmt.Sprintf("%d", q.ApproximateMessageCount)},
-		{Label: "In Flight", Value: fmt.Sprintf("%d", q.ApproximateInFlight)},
+		{Label: "Messages", Value: format.Count(int64(q.ApproximateMessageCount))},
+		{Label: "In Flight", Value: format.Count(int64(q.ApproximateInFlight))},
 		{Label: "", Value: ""}, // Spacer
 		{Label: "Visibility", Value: fmt.Sprintf("%ds", q.VisibilityTimeout)},
 		{Label: "Retention", Value: formatDuration(q.MessageRetentionPeriod)},
@@ -267,7 +324,10 @@ func (m *Model) updateQueueDetails() {
 	}
 
 	if !q.CreatedAt.IsZero() {
-		rows = append(rows, components.DetailRow{Label: "Created", Value: q.CreatedAt.Format("2006-01-02")})
+		rows = append(rows, components.DetailRow{Label: "Created", Value: format.Date(q.CreatedAt)})
+	}
+	if q.Type == model.QueueTypeFIFO {
+		rows = append(rows, fifoQueueRows(q)...)
 	}
 
 	// Add DLQ info if present
@@ -285,11 +345,1019 @@ func (m *Model) updateQueueDetails() {
 	rows = append(rows, components.DetailRow{Label: "", Value: ""}) // Spacer
 	rows = append(rows, components.DetailRow{Label: "URL", Value: q.URL})
 	rows = append(rows, components.DetailRow{Label: "ARN", Value: q.ARN})
+	rows = append(rows, m.terraformRows(q.ARN)...)
+	m.highlightDetails("queues", queueFields(*q), rows)
+	rows = append(m.noteRows(q.ARN), rows...)
 
 	m.details.SetTitle("SQS Queue Details")
 	m.details.SetRows(rows)
 }
 
+// fifoQueueRows describes how a FIFO queue deduplicates and how fast it can
+// go. SQS doesn't count messages per group, but each in-flight message holds
+// its group back until it is deleted or visible again, so the in-flight
+// count bounds how many groups are blocked.
+func fifoQueueRows(q *model.Queue) []components.DetailRow {
+	dim := lipgloss.NewStyle().Foreground(theme.TextDim)
+
+	dedup := "MessageDeduplicationId required"
+	if q.ContentBasedDeduplication {
+		dedup = "Content-based (SHA-256 of the body)"
+	}
+	scope := "Queue"
+	if q.DeduplicationScope == model.FIFODedupScopeMessageGroup {
+		scope = "Message group"
+	}
+	throughput := "Standard (limit per queue)"
+	if q.IsHighThroughputFIFO() {
+		throughput = "High (limit per message group)"
+	} else if q.FifoThroughputLimit == model.FIFOThroughputPerMessageGroup {
+		throughput = "Per message group, but dedup is per queue"
+	}
+
+	held := components.DetailRow{Label: "Groups Held", Value: "None", Style: dim}
+	if q.ApproximateInFlight > 0 {
+		held = components.DetailRow{Label: "Groups Held", Value: fmt.Sprintf("~1 to %s (by in-flight messages)", format.Count(int64(q.ApproximateInFlight)))}
+	}
+	return []components.DetailRow{
+		{Label: "", Value: ""}, // Spacer
+		{Label: "Dedup", Value: dedup},
+		{Label: "Dedup Scope", Value: scope},
+		{Label: "Throughput", Value: throughput},
+		held,
+	}
+}
+
+// updateAppRunnerDetails updates the details panel with App Runner service information.
+func (m *Model) updateAppRunnerDetails() {
+	item := m.appRunnerList.SelectedItem()
+	if item == nil {
+		m.details.SetTitle("App Runner Service")
+		m.details.SetRows(nil)
+		return
+	}
+
+	for _, svc := range m.state.AppRunnerServices {
+		if svc.ARN != item.ID {
+			continue
+		}
+
+		autoDeploy := "Disabled"
+		if svc.AutoDeploy {
+			autoDeploy = "Enabled"
+		}
+
+		rows := []components.DetailRow{
+			{Label: "Name", Value: svc.Name},
+			{Label: "Status", Value: string(svc.Status), Style: AppRunnerStatusStyle(svc.Status)},
+		}
+		// The default domain is empty until the service has been created
+		if svc.URL != "" {
+			rows = append(rows, components.DetailRow{Label: "URL", Value: "https://" + svc.URL})
+		}
+		rows = append(rows, []components.DetailRow{
+			{Label: "Auto Deploy", Value: autoDeploy},
+			{Label: "", Value: ""}, // Spacer
+			{Label: "Source", Value: svc.SourceType},
+			{Label: "Image/Repo", Value: svc.Source},
+			{Label: "CPU", Value: svc.CPU},
+			{Label: "Memory", Value: svc.Memory},
+			{Label: "", Value: ""}, // Spacer
+			{Label: "Created", Value: format.Time(svc.CreatedAt)},
+			{Label: "Updated", Value: format.Time(svc.UpdatedAt)},
+			{Label: "ARN", Value: svc.ARN},
+		}...)
+
+		// Recent operations are loaded on enter
+		rows = append(rows, components.DetailRow{Label: "", Value: ""}) // Spacer
+		if m.state.AppRunnerOperationsLoaded != svc.ARN {
+			rows = append(rows, components.DetailRow{
+				Label: "Operations",
+				Value: "Press enter to load recent operations",
+				Style: lipgloss.NewStyle().Foreground(theme.TextDim),
+			})
+		} else if len(m.state.AppRunnerOperations) == 0 {
+			rows = append(rows, components.DetailRow{Label: "Operations", Value: "None"})
+		} else {
+			rows = append(rows, components.DetailRow{Label: "Operations", Value: fmt.Sprintf("%d recent", len(m.state.AppRunnerOperations))})
+			for _, op := range m.state.AppRunnerOperations {
+				rows = append(rows, components.DetailRow{
+					Label: "  " + format.Time(op.StartedAt),
+					Value: fmt.Sprintf("%s %s", op.Type, op.Status),
+					Style: StatusStyle(op.Status),
+				})
+			}
+		}
+
+		m.details.SetTitle("App Runner Service")
+		m.details.SetRows(rows)
+		return
+	}
+}
+
+// updateFirehoseDetails updates the details panel with Firehose delivery stream information.
+func (m *Model) updateFirehoseDetails() {
+	stream := m.selectedDeliveryStream()
+	if stream == nil {
+		m.details.SetTitle("Delivery Stream")
+		m.details.SetRows(nil)
+		return
+	}
+
+	buffering := "-"
+	if stream.BufferSizeMB > 0 || stream.BufferInterval > 0 {
+		buffering = fmt.Sprintf("%d MB or %ds", stream.BufferSizeMB, stream.BufferInterval)
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Name", Value: stream.Name},
+		{Label: "Status", Value: string(stream.Status), Style: DeliveryStreamStatusStyle(stream.Status)},
+		{Label: "Source", Value: stream.SourceType},
+		{Label: "", Value: ""}, // Spacer
+		{Label: "Destination", Value: stream.Destination},
+		{Label: "Target", Value: stream.Target},
+		{Label: "Buffering", Value: buffering},
+	}
+	if stream.Compression != "" {
+		rows = append(rows, components.DetailRow{Label: "Compression", Value: stream.Compression})
+	}
+	if stream.FailureType != "" {
+		rows = append(rows,
+			components.DetailRow{Label: "", Value: ""}, // Spacer
+			components.DetailRow{Label: "Failure", Value: stream.FailureType, Style: GetStyles().StatusError},
+			components.DetailRow{Label: "Details", Value: stream.FailureDetails},
+		)
+	}
+	rows = append(rows,
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+		components.DetailRow{Label: "Created", Value: format.Time(stream.CreatedAt)},
+		components.DetailRow{Label: "ARN", Value: stream.ARN},
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+	)
+
+	// Delivery errors are loaded on enter
+	dim := lipgloss.NewStyle().Foreground(theme.TextDim)
+	switch {
+	case !stream.HasErrorLogging():
+		rows = append(rows, components.DetailRow{Label: "Errors", Value: "Error logging is not enabled", Style: dim})
+	case m.state.DeliveryErrorsLoaded != stream.Name:
+		rows = append(rows, components.DetailRow{Label: "Errors", Value: "Press enter to load delivery errors", Style: dim})
+		rows = append(rows, components.DetailRow{Label: "Log Group", Value: stream.ErrorLogGroup})
+	case len(m.state.DeliveryErrors) == 0:
+		rows = append(rows, components.DetailRow{Label: "Errors", Value: "None in the last hour", Style: GetStyles().StatusHealthy})
+	default:
+		rows = append(rows, components.DetailRow{
+			Label: "Errors",
+			Value: fmt.Sprintf("%d in the last hour", len(m.state.DeliveryErrors)),
+			Style: GetStyles().StatusError,
+		})
+		for _, e := range m.state.DeliveryErrors {
+			rows = append(rows, components.DetailRow{
+				Label: "  " + e.Timestamp.Format("15:04:05"),
+				Value: e.Message,
+			})
+		}
+	}
+
+	m.details.SetTitle("Delivery Stream")
+	m.details.SetRows(rows)
+}
+
+// updateUserPoolDetails updates the details panel with Cognito user pool information.
+func (m *Model) updateUserPoolDetails() {
+	pool := m.selectedUserPool()
+	if pool == nil {
+		m.details.SetTitle("User Pool")
+		m.details.SetRows(nil)
+		return
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Name", Value: pool.Name},
+		{Label: "ID", Value: pool.ID},
+		{Label: "Created", Value: format.Time(pool.CreatedAt)},
+		{Label: "Modified", Value: format.Time(pool.UpdatedAt)},
+		{Label: "", Value: ""}, // Spacer
+	}
+
+	// Pool configuration and app clients are loaded on enter
+	details := m.state.UserPoolDetails
+	if details == nil || details.PoolID != pool.ID {
+		rows = append(rows, components.DetailRow{
+			Label: "App Clients",
+			Value: "Press enter to load app clients, U to search users",
+			Style: lipgloss.NewStyle().Foreground(theme.TextDim),
+		})
+		m.details.SetTitle("User Pool")
+		m.details.SetRows(rows)
+		return
+	}
+
+	usernameAttrs := "username"
+	if len(details.UsernameAttributes) > 0 {
+		usernameAttrs = strings.Join(details.UsernameAttributes, ", ")
+	}
+	rows = append(rows,
+		components.DetailRow{Label: "Users", Value: "~" + format.Count(int64(details.EstimatedUsers))},
+		components.DetailRow{Label: "Sign-in With", Value: usernameAttrs},
+		components.DetailRow{Label: "MFA", Value: details.MFA},
+		components.DetailRow{Label: "Domain", Value: valueOrDash(details.Domain)},
+		components.DetailRow{Label: "ARN", Value: details.ARN},
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+		components.DetailRow{Label: "App Clients", Value: fmt.Sprintf("%d", len(details.Clients))},
+	)
+	for _, client := range details.Clients {
+		secret := "public"
+		if client.HasSecret {
+			secret = "confidential"
+		}
+		rows = append(rows,
+			components.DetailRow{Label: "", Value: ""}, // Spacer
+			components.DetailRow{Label: client.Name, Value: client.ID + " (" + secret + ")", Style: lipgloss.NewStyle().Bold(true)},
+			components.DetailRow{Label: "  OAuth Flows", Value: joinOrDash(client.OAuthFlows)},
+			components.DetailRow{Label: "  Scopes", Value: joinOrDash(client.OAuthScopes)},
+			components.DetailRow{Label: "  Auth Flows", Value: joinOrDash(client.AuthFlows)},
+		)
+		for i, url := range client.CallbackURLs {
+			label := ""
+			if i == 0 {
+				label = "  Callbacks"
+			}
+			rows = append(rows, components.DetailRow{Label: label, Value: url})
+		}
+		for i, url := range client.LogoutURLs {
+			label := ""
+			if i == 0 {
+				label = "  Logout URLs"
+			}
+			rows = append(rows, components.DetailRow{Label: label, Value: url})
+		}
+	}
+
+	m.details.SetTitle("User Pool")
+	m.details.SetRows(rows)
+}
+
+// updateCognitoUserDetails updates the details panel with Cognito user information.
+func (m *Model) updateCognitoUserDetails() {
+	user := m.selectedCognitoUser()
+	if user == nil {
+		m.details.SetTitle("User")
+		m.details.SetRows(nil)
+		return
+	}
+
+	enabled := "Yes"
+	if !user.Enabled {
+		enabled = "No"
+	}
+	verified := "No"
+	if user.EmailVerified {
+		verified = "Yes"
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Username", Value: user.Username},
+		{Label: "Email", Value: valueOrDash(user.Email)},
+		{Label: "Verified", Value: verified},
+		{Label: "Status", Value: string(user.Status), Style: CognitoUserStatusStyle(*user)},
+		{Label: "Enabled", Value: enabled, Style: CognitoUserStatusStyle(*user)},
+		{Label: "Created", Value: format.Time(user.CreatedAt)},
+		{Label: "Modified", Value: format.Time(user.UpdatedAt)},
+		{Label: "", Value: ""}, // Spacer
+	}
+
+	// Remaining attributes, sorted for a stable layout
+	names := make([]string, 0, len(user.Attributes))
+	for name := range user.Attributes {
+		if name != "email" && name != "email_verified" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		rows = append(rows, components.DetailRow{Label: name, Value: user.Attributes[name]})
+	}
+
+	m.details.SetTitle("User")
+	m.details.SetRows(rows)
+}
+
+// updateSESDetails updates the details panel with the selected SES account row,
+// identity or configuration set.
+func (m *Model) updateSESDetails() {
+	item := m.sesList.SelectedItem()
+	if item == nil {
+		m.details.SetTitle("SES")
+		m.details.SetRows(nil)
+		return
+	}
+	if set := m.selectedSESConfigurationSet(); set != nil {
+		m.updateSESConfigurationSetDetails(*set)
+		return
+	}
+	if identity := m.selectedSESIdentity(); identity != nil {
+		m.updateSESIdentityDetails(*identity)
+		return
+	}
+
+	account := m.state.SESAccount
+	m.details.SetTitle("SES Account")
+	if account == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	sending := "Enabled"
+	if !account.SendingEnabled {
+		sending = "Paused"
+	}
+	access := "Production"
+	if !account.ProductionAccess {
+		access = "Sandbox (verified recipients only)"
+	}
+	rows := []components.DetailRow{
+		{Label: "Sending", Value: sending},
+		{Label: "Access", Value: access},
+		{Label: "Enforcement", Value: valueOrDash(account.EnforcementStatus), Style: SESEnforcementStyle(*account)},
+		{Label: "", Value: ""}, // Spacer
+		{Label: "Sent (24h)", Value: fmt.Sprintf("%s of %s (%.1f%%)", format.Count(int64(account.SentLast24Hours)), format.Count(int64(account.Max24HourSend)), account.QuotaUsed()*100)},
+		{Label: "Max Rate", Value: fmt.Sprintf("%.0f/s", account.MaxSendRate)},
+		{Label: "", Value: ""}, // Spacer
+	}
+	if account.HasReputation {
+		rows = append(rows,
+			components.DetailRow{Label: "Bounce Rate", Value: fmt.Sprintf("%.2f%% (review at %.0f%%)", account.BounceRate*100, sesBounceReviewRate*100), Style: SESRateStyle(account.BounceRate, sesBounceReviewRate)},
+			components.DetailRow{Label: "Complaint Rate", Value: fmt.Sprintf("%.3f%% (review at %.1f%%)", account.ComplaintRate*100, sesComplaintReviewRate*100), Style: SESRateStyle(account.ComplaintRate, sesComplaintReviewRate)},
+		)
+	} else {
+		rows = append(rows, components.DetailRow{
+			Label: "Reputation",
+			Value: "No metrics in the last " + account.ReputationPeriod,
+			Style: lipgloss.NewStyle().Foreground(theme.TextDim),
+		})
+	}
+	rows = append(rows,
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+		components.DetailRow{Label: "Suppression", Value: joinOrDash(account.SuppressedReasons)},
+	)
+	if item.ID == "suppression" {
+		rows = append(rows, components.DetailRow{
+			Label: "",
+			Value: "Press enter to search and remove suppressed addresses",
+			Style: lipgloss.NewStyle().Foreground(theme.TextDim),
+		})
+	}
+	m.details.SetRows(rows)
+}
+
+// updateSESIdentityDetails shows an SES identity in the details panel.
+func (m *Model) updateSESIdentityDetails(identity model.SESIdentity) {
+	m.details.SetTitle("SES Identity")
+	sending := "Enabled"
+	if !identity.SendingEnabled {
+		sending = "Disabled"
+	}
+	rows := []components.DetailRow{
+		{Label: "Identity", Value: identity.Name},
+		{Label: "Type", Value: identity.Type},
+		{Label: "Verification", Value: identity.VerificationStatus, Style: SESVerificationStyle(identity)},
+		{Label: "Sending", Value: sending},
+	}
+	if identity.IsVerified() {
+		rows = append(rows,
+			components.DetailRow{Label: "", Value: ""}, // Spacer
+			components.DetailRow{
+				Label: "",
+				Value: "Press T to send a test email from " + aws.SESTestSender(identity),
+				Style: lipgloss.NewStyle().Foreground(theme.TextDim),
+			},
+		)
+	}
+	m.details.SetRows(rows)
+}
+
+// updateSESConfigurationSetDetails shows an SES configuration set in the details panel.
+func (m *Model) updateSESConfigurationSetDetails(set model.SESConfigurationSet) {
+	m.details.SetTitle("Configuration Set")
+	sending := "Enabled"
+	if !set.SendingEnabled {
+		sending = "Paused"
+	}
+	reputation := "Off"
+	if set.ReputationMetrics {
+		reputation = "On"
+	}
+	freshStart := "-"
+	if !set.LastFreshStart.IsZero() {
+		freshStart = format.Time(set.LastFreshStart)
+	}
+	suppression := "Account default"
+	if set.SuppressedReasons != nil {
+		suppression = joinOrDash(set.SuppressedReasons)
+	}
+	rows := []components.DetailRow{
+		{Label: "Name", Value: set.Name},
+		{Label: "Sending", Value: sending},
+		{Label: "Reputation", Value: reputation},
+		{Label: "Fresh Start", Value: freshStart},
+		{Label: "TLS", Value: valueOrDash(set.TLSPolicy)},
+		{Label: "Suppression", Value: suppression},
+		{Label: "", Value: ""}, // Spacer
+	}
+	if len(set.EventDestinations) == 0 {
+		rows = append(rows, components.DetailRow{Label: "Destinations", Value: "None"})
+	}
+	for _, d := range set.EventDestinations {
+		style := GetStyles().StatusHealthy
+		if !d.Enabled {
+			style = GetStyles().Muted
+		}
+		rows = append(rows,
+			components.DetailRow{Label: "Destination", Value: d.Name, Style: style},
+			components.DetailRow{Label: "  Target", Value: valueOrDash(d.Target)},
+			components.DetailRow{Label: "  Events", Value: joinOrDash(d.EventTypes)},
+		)
+	}
+	m.details.SetRows(rows)
+}
+
+// updateSESSuppressionDetails shows the selected suppressed address in the details panel.
+func (m *Model) updateSESSuppressionDetails() {
+	dest := m.selectedSESSuppression()
+	m.details.SetTitle("Suppressed Address")
+	if dest == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Address", Value: dest.Email},
+		{Label: "Reason", Value: dest.Reason, Style: SESSuppressionReasonStyle(dest.Reason)},
+		{Label: "Suppressed", Value: format.Time(dest.LastUpdated)},
+		{Label: "", Value: ""}, // Spacer
+		{
+			Label: "",
+			Value: "Press X to remove it so SES delivers to it again",
+			Style: lipgloss.NewStyle().Foreground(theme.TextDim),
+		},
+	}
+	if m.state.SESSuppressionsTruncated {
+		rows = append(rows, components.DetailRow{
+			Label: "",
+			Value: fmt.Sprintf("Only the %d most recent addresses are listed", len(m.state.SESSuppressions)),
+			Style: GetStyles().StatusWarning,
+		})
+	}
+	m.details.SetRows(rows)
+}
+
+// updateMQDetails updates the details panel with MQ broker information.
+func (m *Model) updateMQDetails() {
+	broker := m.selectedMQBroker()
+	m.details.SetTitle("MQ Broker")
+	if broker == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Name", Value: broker.Name},
+		{Label: "State", Value: string(broker.State), Style: MQBrokerStateStyle(broker.State)},
+		{Label: "Engine", Value: strings.TrimSpace(broker.Engine + " " + broker.EngineVersion)},
+		{Label: "Instance Type", Value: valueOrDash(broker.InstanceType)},
+		{Label: "Deployment", Value: valueOrDash(broker.DeploymentMode)},
+		{Label: "Public", Value: fmt.Sprintf("%v", broker.Public)},
+		{Label: "Subnets", Value: joinOrDash(broker.Subnets)},
+		{Label: "Security Groups", Value: joinOrDash(broker.SecurityGroups)},
+		{Label: "Created", Value: format.Time(broker.CreatedAt)},
+		{Label: "ARN", Value: broker.ARN},
+	}
+
+	for i, inst := range broker.Instances {
+		rows = append(rows,
+			components.DetailRow{Label: "", Value: ""}, // Spacer
+			components.DetailRow{Label: fmt.Sprintf("Instance %d", i+1), Value: valueOrDash(inst.IP)},
+			components.DetailRow{Label: "  Console", Value: valueOrDash(inst.ConsoleURL)},
+		)
+		for _, e := range inst.Endpoints {
+			rows = append(rows, components.DetailRow{Label: "  Endpoint", Value: e})
+		}
+	}
+
+	if endpoints := mqTunnelEndpoints(*broker); len(endpoints) > 0 && !broker.Public {
+		rows = append(rows,
+			components.DetailRow{Label: "", Value: ""}, // Spacer
+			components.DetailRow{Label: "Local Ports", Value: "press p to tunnel", Style: GetStyles().Muted},
+		)
+		for _, e := range endpoints {
+			rows = append(rows, components.DetailRow{
+				Label: fmt.Sprintf("  %d %s", e.instance, e.label),
+				Value: e.localURL(),
+			})
+		}
+	}
+	m.details.SetRows(rows)
+}
+
+// updateDatabaseDetails updates the details panel with database information.
+func (m *Model) updateDatabaseDetails() {
+	db := m.selectedDatabase()
+	m.details.SetTitle("Database")
+	if db == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Name", Value: db.Name},
+		{Label: "Kind", Value: string(db.Kind)},
+		{Label: "Status", Value: valueOrDash(db.Status), Style: DatabaseStatusStyle(db.Status)},
+		{Label: "Engine", Value: valueOrDash(db.Engine)},
+		{Label: "Endpoint", Value: valueOrDash(db.Endpoint)},
+		{Label: "Port", Value: fmt.Sprintf("%d", db.Port)},
+		{Label: "VPC", Value: valueOrDash(db.VPCID)},
+		{Label: "Public", Value: fmt.Sprintf("%v", db.Public)},
+		{Label: "ARN", Value: valueOrDash(db.ARN)},
+	}
+	if db.Endpoint != "" && db.Port > 0 {
+		rows = append(rows,
+			components.DetailRow{Label: "", Value: ""}, // Spacer
+			components.DetailRow{Label: "Local Port", Value: fmt.Sprintf("localhost:%d (press p to tunnel)", db.Port), Style: GetStyles().Muted},
+		)
+	}
+	m.details.SetRows(rows)
+}
+
+// updateEFSDetails updates the details panel with EFS file system
+// information, and its mount targets, access points and mounts once loaded.
+func (m *Model) updateEFSDetails() {
+	fs := m.selectedEFSFileSystem()
+	m.details.SetTitle("EFS File System")
+	if fs == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	throughput := fs.ThroughputMode
+	if fs.ThroughputMode == "provisioned" {
+		throughput = fmt.Sprintf("provisioned (%g MiB/s)", fs.ProvisionedMiBps)
+	}
+	rows := []components.DetailRow{
+		{Label: "ID", Value: fs.ID},
+		{Label: "Name", Value: valueOrDash(fs.Name)},
+		{Label: "State", Value: fs.State, Style: EFSStateStyle(fs.State)},
+		{Label: "Size", Value: formatBytes(fs.SizeBytes)},
+		{Label: "Performance", Value: fs.PerformanceMode},
+		{Label: "Throughput", Value: throughput},
+		{Label: "Encrypted", Value: fmt.Sprintf("%v", fs.Encrypted)},
+		{Label: "Created", Value: format.Time(fs.CreatedAt)},
+		{Label: "ARN", Value: fs.ARN},
+		{Label: "", Value: ""}, // Spacer
+	}
+
+	// Mount targets, access points and mounts are loaded on enter
+	details := m.state.EFSDetails
+	if details == nil || details.FileSystemID != fs.ID {
+		rows = append(rows, components.DetailRow{
+			Label: "Mounts",
+			Value: "press enter to load mount targets, access points and mounts",
+			Style: GetStyles().Muted,
+		})
+		m.details.SetRows(rows)
+		return
+	}
+
+	rows = append(rows, components.DetailRow{Label: "Mount Targets", Value: fmt.Sprintf("%d", len(details.MountTargets))})
+	for _, mt := range details.MountTargets {
+		rows = append(rows, components.DetailRow{
+			Label: "  " + mt.AZ,
+			Value: fmt.Sprintf("%s  %s  %s", valueOrDash(mt.IP), mt.SubnetID, mt.State),
+			Style: EFSStateStyle(mt.State),
+		})
+	}
+
+	rows = append(rows,
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+		components.DetailRow{Label: "Access Points", Value: fmt.Sprintf("%d", len(details.AccessPoints))},
+	)
+	for _, ap := range details.AccessPoints {
+		user := "any user"
+		if ap.UID >= 0 {
+			user = fmt.Sprintf("uid %d gid %d", ap.UID, ap.GID)
+		}
+		label := ap.ID
+		if ap.Name != "" {
+			label = ap.Name
+		}
+		rows = append(rows, components.DetailRow{
+			Label: "  " + label,
+			Value: fmt.Sprintf("%s  (%s)", ap.Path, user),
+			Style: EFSStateStyle(ap.State),
+		})
+	}
+
+	rows = append(rows,
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+		components.DetailRow{Label: "Mounted By", Value: fmt.Sprintf("%d", len(details.Mounts))},
+	)
+	if len(details.Mounts) == 0 {
+		rows = append(rows, components.DetailRow{
+			Label: "",
+			Value: "No active task definition or Lambda function mounts it",
+			Style: GetStyles().StatusWarning,
+		})
+	}
+	for _, mount := range details.Mounts {
+		who := mount.Name
+		if mount.Container != "" {
+			who += " / " + mount.Container
+		}
+		value := mount.Path
+		if mount.AccessPointID != "" {
+			value += " via " + mount.AccessPointID
+		}
+		rows = append(rows, components.DetailRow{Label: "  " + mount.Kind, Value: who + "  " + value})
+	}
+	m.details.SetRows(rows)
+}
+
+// updateScheduleDetails updates the details panel with schedule information.
+func (m *Model) updateScheduleDetails() {
+	s := m.selectedSchedule()
+	m.details.SetTitle("Schedule")
+	if s == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	next, err := scheduleNextRun(*s)
+	nextRun := format.Absolute(next)
+	switch {
+	case err != nil:
+		nextRun = "unknown: " + err.Error()
+	case s.State == model.ScheduleStateDisabled:
+		nextRun = "- (disabled)"
+	case !next.IsZero():
+		nextRun += " (" + format.Relative(time.Since(next)) + ")"
+	}
+	window := "off"
+	if s.FlexibleWindow > 0 {
+		window = fmt.Sprintf("up to %d min", s.FlexibleWindow)
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Name", Value: s.Name},
+		{Label: "Group", Value: valueOrDash(s.Group)},
+		{Label: "State", Value: string(s.State), Style: ScheduleStateStyle(s.State)},
+		{Label: "Expression", Value: valueOrDash(s.Expression)},
+		{Label: "Timezone", Value: valueOrDash(s.Timezone)},
+		{Label: "Next Run", Value: nextRun},
+		{Label: "Flexible Window", Value: window},
+		{Label: "", Value: ""}, // Spacer
+		{Label: "Target", Value: valueOrDash(scheduleTarget(*s))},
+		{Label: "Target ARN", Value: valueOrDash(s.TargetARN)},
+		{Label: "Role", Value: valueOrDash(s.TargetRole)},
+	}
+	if s.TargetInput != "" {
+		rows = append(rows, components.DetailRow{Label: "Input", Value: truncateString(s.TargetInput, 200)})
+	}
+	rows = append(rows,
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+		components.DetailRow{Label: "Description", Value: valueOrDash(s.Description)},
+		components.DetailRow{Label: "Start", Value: format.Absolute(s.StartDate)},
+		components.DetailRow{Label: "End", Value: format.Absolute(s.EndDate)},
+		components.DetailRow{Label: "Created", Value: format.Time(s.CreatedAt)},
+		components.DetailRow{Label: "Modified", Value: format.Time(s.UpdatedAt)},
+		components.DetailRow{Label: "ARN", Value: s.ARN},
+	)
+	m.details.SetRows(rows)
+}
+
+// updateSecretDetails updates the details panel with secret rotation
+// information.
+func (m *Model) updateSecretDetails() {
+	s := m.selectedSecret()
+	m.details.SetTitle("Secret")
+	if s == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	now := time.Now()
+	rotation := s.Rotation(now)
+	next := format.Absolute(s.NextRotation)
+	if !s.NextRotation.IsZero() {
+		next += " (" + format.Relative(now.Sub(s.NextRotation)) + ")"
+	}
+	lambda := "-"
+	if s.RotationLambda != "" {
+		lambda = functionNameFromARN(s.RotationLambda)
+	} else if s.RotationEnabled {
+		lambda = "managed"
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Name", Value: s.Name},
+		{Label: "Status", Value: string(rotation), Style: SecretRotationStyle(rotation)},
+		{Label: "Rotation", Value: secretRotationRule(*s)},
+		{Label: "Rotation Function", Value: lambda},
+		{Label: "Last Rotated", Value: format.Time(s.LastRotated)},
+		{Label: "Next Rotation", Value: next},
+		{Label: "", Value: ""}, // Spacer
+		{Label: "Last Changed", Value: format.Time(s.LastChanged)},
+		{Label: "Last Accessed", Value: format.Date(s.LastAccessed)},
+		{Label: "Created", Value: format.Time(s.CreatedAt)},
+		{Label: "Managed By", Value: valueOrDash(s.OwningService)},
+		{Label: "Description", Value: valueOrDash(s.Description)},
+		{Label: "ARN", Value: s.ARN},
+	}
+	m.details.SetRows(rows)
+}
+
+// updateMSKDetails updates the details panel with MSK cluster information.
+func (m *Model) updateMSKDetails() {
+	cluster := m.selectedMSKCluster()
+	m.details.SetTitle("MSK Cluster")
+	if cluster == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Name", Value: cluster.Name},
+		{Label: "State", Value: string(cluster.State), Style: MSKClusterStateStyle(cluster.State)},
+		{Label: "Type", Value: cluster.Type},
+	}
+	if !cluster.IsServerless() {
+		rows = append(rows,
+			components.DetailRow{Label: "Kafka", Value: cluster.KafkaVersion},
+			components.DetailRow{Label: "Brokers", Value: fmt.Sprintf("%d × %s", cluster.BrokerCount, cluster.InstanceType)},
+		)
+	}
+	rows = append(rows,
+		components.DetailRow{Label: "Auth", Value: joinOrDash(cluster.Auth)},
+		components.DetailRow{Label: "Subnets", Value: joinOrDash(cluster.ClientSubnets)},
+		components.DetailRow{Label: "Security Groups", Value: joinOrDash(cluster.SecurityGroups)},
+		components.DetailRow{Label: "Created", Value: format.Time(cluster.CreatedAt)},
+		components.DetailRow{Label: "ARN", Value: cluster.ARN},
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+	)
+
+	// Bootstrap brokers are loaded on enter
+	brokers := m.state.MSKBrokers
+	if brokers == nil || brokers.ClusterARN != cluster.ARN {
+		rows = append(rows, components.DetailRow{
+			Label: "Brokers",
+			Value: "Press enter to load bootstrap brokers, p to tunnel to them",
+			Style: lipgloss.NewStyle().Foreground(theme.TextDim),
+		})
+		m.details.SetRows(rows)
+		return
+	}
+
+	for _, b := range []struct{ label, value string }{
+		{"IAM", brokers.SASLIAM},
+		{"SCRAM", brokers.SASLSCRAM},
+		{"TLS", brokers.TLS},
+		{"Plaintext", brokers.Plaintext},
+	} {
+		for i, endpoint := range splitBrokers(b.value) {
+			label := ""
+			if i == 0 {
+				label = b.label
+			}
+			rows = append(rows, components.DetailRow{Label: label, Value: endpoint})
+		}
+	}
+
+	// Local ports tunnels use, so client configs can be written up front
+	method, preferred := brokers.Preferred()
+	if endpoints := splitBrokers(preferred); len(endpoints) > 0 {
+		rows = append(rows,
+			components.DetailRow{Label: "", Value: ""}, // Spacer
+			components.DetailRow{Label: "Tunnel Ports", Value: method, Style: lipgloss.NewStyle().Bold(true)},
+		)
+		for i, endpoint := range endpoints {
+			host, port, err := splitHostPort(endpoint)
+			if err != nil {
+				continue
+			}
+			rows = append(rows, components.DetailRow{
+				Label: fmt.Sprintf("  localhost:%d", mskLocalPort(port, brokerNumber(host, i))),
+				Value: endpoint,
+			})
+		}
+	}
+
+	m.details.SetRows(rows)
+}
+
+// updateResourceTypeDetails updates the details panel with the selected resource type.
+func (m *Model) updateResourceTypeDetails() {
+	item := m.resourceTypeList.SelectedItem()
+	m.details.SetTitle("Resource Type")
+	if item == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Type", Value: item.ID},
+		{Label: "", Value: ""}, // Spacer
+		{
+			Label: "Resources",
+			Value: "Press enter to list resources via Cloud Control",
+			Style: lipgloss.NewStyle().Foreground(theme.TextDim),
+		},
+	}
+	if item.ID == m.state.CloudResourceType && !m.state.CloudResourcesLoading && m.state.CloudResourcesError == nil {
+		rows[2].Value = fmt.Sprintf("%d loaded", len(m.state.CloudResources))
+		rows[2].Style = lipgloss.NewStyle()
+	}
+	m.details.SetRows(rows)
+}
+
+// updateCloudResourceDetails updates the details panel with the properties of
+// the selected Cloud Control resource.
+func (m *Model) updateCloudResourceDetails() {
+	resource := m.selectedCloudResource()
+	m.details.SetTitle("Resource")
+	if resource == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Type", Value: resource.TypeName},
+		{Label: "Identifier", Value: resource.Identifier},
+		{Label: "Name", Value: valueOrDash(resource.Name)},
+		{Label: "", Value: ""}, // Spacer
+	}
+	m.details.SetRows(rows)
+	if !m.details.SetJSON("Properties", resource.Properties) {
+		m.details.SetRows(append(rows, components.DetailRow{
+			Label: "Properties",
+			Value: "Not returned by list, press enter to load",
+			Style: lipgloss.NewStyle().Foreground(theme.TextDim),
+		}))
+	}
+}
+
+// updateActivityDetails updates the details panel with the selected CloudTrail event.
+func (m *Model) updateActivityDetails() {
+	e := m.selectedActivityEvent()
+	m.details.SetTitle("CloudTrail Event")
+	if e == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Event", Value: e.Name},
+		{Label: "Source", Value: e.Source},
+		{Label: "Time", Value: format.Absolute(e.Time)},
+		{Label: "User", Value: valueOrDash(e.Username)},
+		{Label: "Principal", Value: valueOrDash(e.Principal)},
+		{Label: "Source IP", Value: valueOrDash(e.SourceIP)},
+	}
+	if e.Failed() {
+		rows = append(rows, components.DetailRow{Label: "Error", Value: e.ErrorCode, Style: GetStyles().StatusError})
+	}
+	rows = append(rows, components.DetailRow{Label: "", Value: ""}) // Spacer
+	m.details.SetRows(rows)
+	m.details.SetJSON("Event", e.Raw)
+}
+
+// updateHealthDetails updates the details panel with the selected problem,
+// or with the summary of all checks when none is selected.
+func (m *Model) updateHealthDetails() {
+	s := GetStyles()
+	h := m.state.Health
+	m.details.SetTitle("Account Health")
+	if h == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	check, key := m.selectedHealthItem()
+	var rows []components.DetailRow
+	switch check {
+	case model.HealthStacks:
+		for _, st := range h.FailedStacks {
+			if st.Name == key {
+				rows = []components.DetailRow{
+					{Label: "Stack", Value: st.Name},
+					{Label: "Status", Value: string(st.Status), Style: StatusStyle(string(st.Status))},
+					{Label: "Reason", Value: valueOrDash(st.StatusReason)},
+					{Label: "Updated", Value: format.Time(st.UpdatedAt)},
+				}
+			}
+		}
+	case model.HealthServices:
+		for _, svc := range h.UnhealthyServices {
+			if svc.ARN == key {
+				rows = []components.DetailRow{
+					{Label: "Service", Value: svc.Name},
+					{Label: "Cluster", Value: svc.ClusterName},
+					{Label: "Running", Value: fmt.Sprintf("%d of %d desired", svc.RunningCount, svc.DesiredCount), Style: s.StatusError},
+					{Label: "Pending", Value: strconv.Itoa(svc.PendingCount)},
+					{Label: "Deployments", Value: strconv.Itoa(len(svc.Deployments))},
+				}
+			}
+		}
+	case model.HealthAlarms:
+		for _, a := range h.Alarms {
+			if a.Name == key {
+				rows = []components.DetailRow{
+					{Label: "Alarm", Value: a.Name},
+					{Label: "State", Value: string(a.State), Style: s.StatusError},
+					{Label: "Metric", Value: valueOrDash(a.Metric)},
+					{Label: "Since", Value: format.Time(a.UpdatedAt)},
+					{Label: "Reason", Value: valueOrDash(a.Reason)},
+				}
+			}
+		}
+	case model.HealthDLQs:
+		for _, q := range h.DLQs {
+			if q.URL == key {
+				rows = []components.DetailRow{
+					{Label: "Queue", Value: q.Name},
+					{Label: "DLQ", Value: q.DLQName},
+					{Label: "DLQ Messages", Value: format.Count(int64(q.DLQMessageCount)), Style: s.StatusWarning},
+					{Label: "Max Receives", Value: strconv.Itoa(q.MaxReceiveCount)},
+				}
+			}
+		}
+	case model.HealthCertificates:
+		for _, c := range h.ExpiringCertificates {
+			if c.ARN == key {
+				rows = []components.DetailRow{
+					{Label: "Domain", Value: c.DomainName},
+					{Label: "Status", Value: c.Status},
+					{Label: "Type", Value: c.Type},
+					{Label: "Expires", Value: format.Absolute(c.NotAfter), Style: s.StatusWarning},
+					{Label: "In Use", Value: fmt.Sprintf("%t", c.InUse)},
+					{Label: "Auto-renews", Value: fmt.Sprintf("%t", c.Renewable)},
+					{Label: "ARN", Value: c.ARN},
+				}
+			}
+		}
+	}
+	if rows != nil {
+		rows = append(rows, components.DetailRow{Label: "", Value: ""}) // Spacer
+		rows = append(rows, components.DetailRow{Label: "Enter", Value: "Open in its view"})
+		m.details.SetRows(rows)
+		return
+	}
+
+	rows = []components.DetailRow{
+		{Label: "Checked", Value: format.Time(h.CheckedAt)},
+		{Label: "Profile", Value: m.state.Profile},
+		{Label: "Region", Value: m.state.Region},
+		{Label: "Problems", Value: strconv.Itoa(h.ProblemCount())},
+	}
+	checks := make([]string, 0, len(h.Errors))
+	for c := range h.Errors {
+		checks = append(checks, c)
+	}
+	sort.Strings(checks)
+	if len(checks) > 0 {
+		rows = append(rows, components.DetailRow{Label: "", Value: ""}) // Spacer
+		rows = append(rows, components.DetailRow{Label: "Failed Checks", Value: ""})
+	}
+	for _, c := range checks {
+		rows = append(rows, components.DetailRow{Label: "  " + c, Value: h.Errors[c], Style: s.StatusWarning})
+	}
+	m.details.SetRows(rows)
+}
+
+// updateLogSearchDetails updates the details panel with the selected log event.
+func (m *Model) updateLogSearchDetails() {
+	h := m.selectedLogSearchHit()
+	m.details.SetTitle("Log Event")
+	if h == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Time", Value: format.Absolute(h.Timestamp)},
+		{Label: "Source", Value: h.Source.Resource},
+		{Label: "Log Group", Value: h.Source.LogGroup},
+		{Label: "Stream", Value: valueOrDash(h.LogStreamName)},
+	}
+
+	// Structured logs are easier to read as a tree
+	message := strings.TrimSpace(h.Message)
+	if json.Valid([]byte(message)) {
+		m.details.SetRows(append(rows, components.DetailRow{Label: "", Value: ""})) // Spacer
+		m.details.SetJSON("Message", message)
+		return
+	}
+	m.details.SetRows(append(rows, components.DetailRow{Label: "Message", Value: message}))
+}
+
 // updateTableDetails updates the details panel with DynamoDB table information.
 func (m *Model) updateTableDetails() {
 	t := m.dynamodbTable.SelectedTable()
@@ -327,7 +1395,7 @@ func (m *Model) updateTableDetails() {
 	rows = append(rows, components.DetailRow{Label: "", Value: ""}) // Spacer
 
 	// Stats
-	rows = append(rows, components.DetailRow{Label: "Items", Value: fmt.Sprintf("%d", t.ItemCount)})
+	rows = append(rows, components.DetailRow{Label: "Items", Value: format.Count(t.ItemCount)})
 	rows = append(rows, components.DetailRow{Label: "Size", Value: formatBytes(t.SizeBytes)})
 
 	// Indexes
@@ -362,9 +1430,50 @@ func (m *Model) updateTableDetails() {
 
 	if !t.CreatedAt.IsZero() {
 		rows = append(rows, components.DetailRow{Label: "", Value: ""}) // Spacer
-		rows = append(rows, components.DetailRow{Label: "Created", Value: t.CreatedAt.Format("2006-01-02 15:04:05")})
+		rows = append(rows, components.DetailRow{Label: "Created", Value: format.Time(t.CreatedAt)})
 	}
+	rows = append(rows, m.terraformRows(t.ARN)...)
 
+	rows = append(m.noteRows(t.ARN), rows...)
 	m.details.SetTitle("DynamoDB Table Details")
 	m.details.SetRows(rows)
 }
+
+// updateLogGroupDetails updates the details panel with log group information.
+func (m *Model) updateLogGroupDetails() {
+	g := m.selectedLogGroup()
+	m.details.SetTitle("Log Group")
+	if g == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	st := GetStyles()
+	retentionStyle := st.Muted
+	if g.NeverExpires() {
+		retentionStyle = st.StatusWarning
+	}
+	owner := valueOrDash(g.Owner)
+	ownerStyle := lipgloss.NewStyle()
+	if g.Orphaned {
+		owner += " (gone)"
+		ownerStyle = st.StatusError
+	}
+	protection := "off"
+	if g.DeletionProtected {
+		protection = "on"
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Name", Value: g.Name},
+		{Label: "Stored", Value: formatBytes(g.StoredBytes)},
+		{Label: "Retention", Value: retentionText(g.RetentionDays), Style: retentionStyle},
+		{Label: "Class", Value: valueOrDash(g.Class)},
+		{Label: "Written By", Value: owner, Style: ownerStyle},
+		{Label: "Metric Filters", Value: fmt.Sprintf("%d", g.MetricFilters)},
+		{Label: "Deletion Protection", Value: protection},
+		{Label: "Created", Value: format.Time(g.CreatedAt)},
+		{Label: "ARN", Value: valueOrDash(g.ARN)},
+	}
+	m.details.SetRows(rows)
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/aws"
+	"vaws/internal/state"
+)
+
+// openDiff shows old and new side by side in the diff view. Esc returns to
+// the view the diff was opened from.
+func (m *Model) openDiff(oldTitle, oldText, newTitle, newText string) {
+	m.diffViewer.SetTexts(oldTitle, oldText, newTitle, newText)
+	if m.state.View != state.ViewDiff {
+		m.state.DiffReturnView = m.state.View
+	}
+	m.state.View = state.ViewDiff
+}
+
+// handleTaskDefDiff compares the selected service's task definition with the
+// one it replaces: the previous deployment's while a rollout is in progress,
+// otherwise the previous revision.
+func (m *Model) handleTaskDefDiff() tea.Cmd {
+	if m.state.View != state.ViewServices {
+		return nil
+	}
+	item := m.serviceList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+
+	for _, svc := range m.state.Services {
+		if svc.Name != item.ID {
+			continue
+		}
+
+		newTaskDef := svc.TaskDefinition
+		oldTaskDef := ""
+		for _, d := range svc.Deployments {
+			if d.Status == "PRIMARY" {
+				newTaskDef = d.TaskDefinition
+			} else if oldTaskDef == "" && d.TaskDefinition != "" {
+				oldTaskDef = d.TaskDefinition
+			}
+		}
+		if oldTaskDef == "" || oldTaskDef == newTaskDef {
+			prev, ok := aws.PreviousTaskDefinition(newTaskDef)
+			if !ok {
+				m.logger.Warn("No earlier task definition revision to compare for '%s'", svc.Name)
+				return nil
+			}
+			oldTaskDef = prev
+		}
+
+		m.logger.Info("Comparing task definitions %s -> %s", shortTaskDefinition(oldTaskDef), shortTaskDefinition(newTaskDef))
+		serviceName := svc.Name
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			msg := taskDefDiffLoadedMsg{serviceName: serviceName, oldTaskDef: oldTaskDef, newTaskDef: newTaskDef}
+			msg.oldDoc, msg.err = m.client.GetTaskDefinitionDocument(ctx, oldTaskDef)
+			if msg.err == nil {
+				msg.newDoc, msg.err = m.client.GetTaskDefinitionDocument(ctx, newTaskDef)
+			}
+			return msg
+		}
+	}
+	return nil
+}
+
+// handleDiffKey handles key presses in the diff view.
+func (m *Model) handleDiffKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c":
+		m.tunnelManager.StopAllTunnels()
+		return tea.Quit
+	case "esc", "backspace", "q":
+		m.state.View = m.state.DiffReturnView
+		m.diffViewer.Clear()
+		m.updateCurrentList()
+	case "up", "k":
+		m.diffViewer.ScrollUp()
+	case "down", "j":
+		m.diffViewer.ScrollDown()
+	case "ctrl+u", "pgup":
+		m.diffViewer.HalfPageUp()
+	case "ctrl+d", "pgdown":
+		m.diffViewer.HalfPageDown()
+	case "g":
+		m.diffViewer.Top()
+	case "G":
+		m.diffViewer.Bottom()
+	case "n", "]":
+		m.diffViewer.NextChange()
+	case "N", "[":
+		m.diffViewer.PrevChange()
+	case "tab", "m":
+		m.diffViewer.ToggleMode()
+	case "l":
+		m.state.ToggleLogs()
+		m.updateComponentSizes()
+	case "?":
+		m.showHelp()
+	}
+	return nil
+}
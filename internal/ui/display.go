@@ -0,0 +1,117 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// applyDisplayConfig applies the display preferences of the config file:
+// time and number formats, ASCII-only mode and status text. ASCII-only mode
+// may already be on from the command line.
+func applyDisplayConfig(cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	if cfg.Terminal.ASCII {
+		theme.SetASCII(true)
+	}
+	theme.SetStatusText(cfg.Terminal.StatusText)
+	format.Set(format.Options{
+		Absolute:    cfg.Display.Time == config.TimeAbsolute,
+		UTC:         cfg.Display.Timezone == config.TimezoneUTC,
+		FullNumbers: cfg.Display.Numbers == config.NumbersFull,
+	})
+}
+
+// handleTimeCommand switches between relative and absolute times, or
+// between local time and UTC.
+func (m *Model) handleTimeCommand(args []string) tea.Cmd {
+	opts := format.Current()
+	arg := ""
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	switch arg {
+	case "":
+		opts.Absolute = !opts.Absolute
+	case config.TimeRelative:
+		opts.Absolute = false
+	case config.TimeAbsolute:
+		opts.Absolute = true
+	case config.TimezoneLocal:
+		opts.UTC = false
+	case config.TimezoneUTC:
+		opts.Absolute, opts.UTC = true, true
+	default:
+		m.logger.Warn("Unknown time format %q (use relative, absolute, local or utc)", arg)
+		return nil
+	}
+
+	switch {
+	case !opts.Absolute:
+		m.logger.Info("Showing relative times")
+	case opts.UTC:
+		m.logger.Info("Showing absolute times in UTC")
+	default:
+		m.logger.Info("Showing absolute times in local time")
+	}
+	m.setDisplayOptions(opts)
+	return nil
+}
+
+// handleNumbersCommand switches between compact and full numbers.
+func (m *Model) handleNumbersCommand(args []string) tea.Cmd {
+	opts := format.Current()
+	arg := ""
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	switch arg {
+	case "":
+		opts.FullNumbers = !opts.FullNumbers
+	case config.NumbersCompact:
+		opts.FullNumbers = false
+	case config.NumbersFull:
+		opts.FullNumbers = true
+	default:
+		m.logger.Warn("Unknown number format %q (use compact or full)", arg)
+		return nil
+	}
+
+	if opts.FullNumbers {
+		m.logger.Info("Showing full numbers")
+	} else {
+		m.logger.Info("Showing compact numbers")
+	}
+	m.setDisplayOptions(opts)
+	return nil
+}
+
+// setDisplayOptions applies new display preferences, redraws the current
+// view with them and saves them to the config file.
+func (m *Model) setDisplayOptions(opts format.Options) {
+	format.Set(opts)
+	m.updateCurrentList()
+
+	if m.cfg == nil {
+		return
+	}
+	m.cfg.Display.Time = config.TimeRelative
+	if opts.Absolute {
+		m.cfg.Display.Time = config.TimeAbsolute
+	}
+	m.cfg.Display.Timezone = config.TimezoneLocal
+	if opts.UTC {
+		m.cfg.Display.Timezone = config.TimezoneUTC
+	}
+	m.cfg.Display.Numbers = config.NumbersCompact
+	if opts.FullNumbers {
+		m.cfg.Display.Numbers = config.NumbersFull
+	}
+	if err := m.cfg.Save(); err != nil {
+		m.logger.Warn("Failed to save display preferences: %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/aws"
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/format"
+)
+
+const (
+	// dlqExportInterval is how often a queue is checked when no interval is configured.
+	dlqExportInterval = time.Minute
+
+	// dlqExportBatches bounds the receives of a check, 10 messages each.
+	// Messages beyond it are saved on the next checks.
+	dlqExportBatches = 10
+
+	// dlqVisibilityTimeout hides the messages received during a check, so
+	// the next receives return others. They reappear afterwards.
+	dlqVisibilityTimeout = 30
+)
+
+// dlqExportedMsg carries the result of checking a dead-letter queue.
+type dlqExportedMsg struct {
+	queue string // Queue URL of the export
+	dlq   string // Name of the dead-letter queue
+	path  string
+	saved int
+	seen  map[string]bool // IDs of all messages in the file
+	err   error
+}
+
+// dlqExports holds what the dead-letter queue exports have saved.
+type dlqExports struct {
+	lastCheck map[string]time.Time       // Queue URL -> last check started
+	seen      map[string]map[string]bool // Queue URL -> IDs of messages saved
+	lastErr   map[string]string          // Queue URL -> last error logged
+	inFlight  map[string]bool
+}
+
+// dlqRecord is a line of an export file.
+type dlqRecord struct {
+	ExportedAt time.Time `json:"exported_at"`
+	Queue      string    `json:"queue"`
+	model.QueueMessage
+}
+
+// exportDLQs starts checking the dead-letter queue exports of the profile
+// that are due.
+func (m *Model) exportDLQs() tea.Cmd {
+	if m.cfg == nil || m.client == nil {
+		return nil
+	}
+	exports := m.cfg.GetDLQExports(m.state.Profile)
+	if len(exports) == 0 {
+		return nil
+	}
+	if m.dlq.lastCheck == nil {
+		m.dlq.lastCheck = make(map[string]time.Time)
+		m.dlq.seen = make(map[string]map[string]bool)
+		m.dlq.lastErr = make(map[string]string)
+		m.dlq.inFlight = make(map[string]bool)
+	}
+
+	var cmds []tea.Cmd
+	for _, exp := range exports {
+		interval := dlqExportInterval
+		if d, err := time.ParseDuration(exp.Interval); err == nil && d >= 10*time.Second {
+			interval = d
+		}
+		if exp.Queue == "" || m.dlq.inFlight[exp.Queue] || time.Since(m.dlq.lastCheck[exp.Queue]) < interval {
+			continue
+		}
+		m.dlq.inFlight[exp.Queue] = true
+		m.dlq.lastCheck[exp.Queue] = time.Now()
+
+		// The check owns the set until it reports back
+		client, exp, seen := m.client, exp, m.dlq.seen[exp.Queue]
+		cmds = append(cmds, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			return exportDLQ(ctx, client, exp, seen)
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// exportDLQ appends the messages of a dead-letter queue that aren't in its
+// file yet. Messages are read, not deleted, so redriving or purging the queue
+// is still up to you. seen is nil on the first check, and then loaded from
+// the file.
+func exportDLQ(ctx context.Context, client aws.API, exp config.DLQExportConfig, seen map[string]bool) dlqExportedMsg {
+	result := dlqExportedMsg{queue: exp.Queue, seen: seen}
+
+	q, err := client.GetQueueAttributes(ctx, exp.Queue)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	if q.HasDLQ {
+		if q, err = client.GetQueueAttributes(ctx, dlqURL(q.URL, q.DLQArn)); err != nil {
+			result.err = err
+			return result
+		}
+	}
+	result.dlq = q.Name
+
+	dir := expandHome(exp.Dir)
+	if dir == "" {
+		dir = config.DefaultDLQExportDir()
+	}
+	result.path = filepath.Join(dir, q.Name+".jsonl")
+	if result.seen == nil {
+		result.seen = readExportedIDs(result.path)
+	}
+	if q.ApproximateMessageCount == 0 {
+		return result
+	}
+
+	var records []dlqRecord
+	received := make(map[string]bool)
+	for range dlqExportBatches {
+		messages, err := client.ReceiveMessages(ctx, q.URL, dlqVisibilityTimeout)
+		if err != nil {
+			result.err = err
+			break
+		}
+		if len(messages) == 0 {
+			break
+		}
+		for _, msg := range messages {
+			if !result.seen[msg.ID] && !received[msg.ID] {
+				received[msg.ID] = true
+				records = append(records, dlqRecord{ExportedAt: time.Now(), Queue: q.Name, QueueMessage: msg})
+			}
+		}
+	}
+	if len(records) == 0 {
+		return result
+	}
+
+	if err := appendRecords(result.path, records); err != nil {
+		result.err = err
+		return result
+	}
+	for _, r := range records {
+		result.seen[r.ID] = true
+	}
+	result.saved = len(records)
+	return result
+}
+
+// dlqURL returns the URL of a dead-letter queue from its ARN. It is in the
+// same account and region as the queue, so only the name differs.
+func dlqURL(queueURL, dlqARN string) string {
+	name := dlqARN[strings.LastIndex(dlqARN, ":")+1:]
+	return queueURL[:strings.LastIndex(queueURL, "/")+1] + name
+}
+
+// readExportedIDs returns the IDs of the messages in an export file.
+func readExportedIDs(path string) map[string]bool {
+	ids := make(map[string]bool)
+	f, err := os.Open(path)
+	if err != nil {
+		return ids
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20) // Message bodies go up to 256 KB
+	for scanner.Scan() {
+		var r struct {
+			ID string `json:"message_id"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &r) == nil && r.ID != "" {
+			ids[r.ID] = true
+		}
+	}
+	return ids
+}
+
+// appendRecords appends records to an export file, one JSON object per line.
+func appendRecords(path string, records []dlqRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// handleDLQExported records a check, logging what was saved and errors that
+// changed since the last check.
+func (m *Model) handleDLQExported(msg dlqExportedMsg) tea.Cmd {
+	delete(m.dlq.inFlight, msg.queue)
+	if msg.seen != nil {
+		m.dlq.seen[msg.queue] = msg.seen
+	}
+
+	if msg.err != nil {
+		if m.dlq.lastErr[msg.queue] != msg.err.Error() {
+			m.dlq.lastErr[msg.queue] = msg.err.Error()
+			m.logger.Warn("DLQ export of %s failed: %v", queueNameFromURL(msg.queue), msg.err)
+		}
+	} else {
+		delete(m.dlq.lastErr, msg.queue)
+	}
+	if msg.saved == 0 {
+		return nil
+	}
+
+	m.logger.Warn("Saved %d new messages from %s to %s", msg.saved, msg.dlq, msg.path)
+	exports := m.cfg.GetDLQExports(m.state.Profile)
+	if i := slices.IndexFunc(exports, func(e config.DLQExportConfig) bool { return e.Queue == msg.queue }); i < 0 || !exports[i].Notify {
+		return nil
+	}
+	return m.notify("vaws dead-letter messages", fmt.Sprintf("%s: %d new, saved to %s", msg.dlq, msg.saved, msg.path))
+}
+
+// handleDLQExportCommand turns the export of the selected queue's
+// dead-letter queue on or off for the profile, saving it to the config.
+func (m *Model) handleDLQExportCommand() tea.Cmd {
+	if m.cfg == nil {
+		return nil
+	}
+	q := m.sqsTable.SelectedQueue()
+	if m.state.View != state.ViewSQS || q == nil {
+		m.logger.Warn("Select a queue in the SQS view first")
+		return nil
+	}
+
+	exports := m.cfg.GetDLQExports(m.state.Profile)
+	if i := slices.IndexFunc(exports, func(e config.DLQExportConfig) bool { return e.Queue == q.URL }); i >= 0 {
+		m.cfg.SetDLQExports(m.state.Profile, slices.Delete(slices.Clone(exports), i, i+1))
+		m.logger.Info("Stopped exporting messages of %s", q.Name)
+	} else {
+		m.cfg.SetDLQExports(m.state.Profile, append(slices.Clone(exports), config.DLQExportConfig{Queue: q.URL}))
+		target := q.Name
+		if q.HasDLQ {
+			target = queueNameFromURL(dlqURL(q.URL, q.DLQArn))
+		}
+		m.logger.Info("Exporting new messages of %s to %s every %s", target, filepath.Join(config.DefaultDLQExportDir(), target+".jsonl"), format.Age(dlqExportInterval))
+	}
+	if err := m.cfg.Save(); err != nil {
+		m.logger.Warn("Failed to save config: %v", err)
+	}
+	return m.exportDLQs()
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/aws"
+	"vaws/internal/config"
+	"vaws/internal/model"
+)
+
+// editedItem is an item of the query results open in $EDITOR, with the key
+// attributes of its table.
+type editedItem struct {
+	table string
+	keys  []string // The partition key, then the sort key if the table has one
+	item  model.DynamoDBItem
+}
+
+// itemWrittenMsg reports an item put into or deleted from a table.
+type itemWrittenMsg struct {
+	table   string
+	key     string
+	deleted bool
+	err     error
+}
+
+// selectedItemForWrite returns the item under the cursor of the query
+// results and the key attributes of its table, warning why if it can't be
+// written.
+func (m *Model) selectedItemForWrite() (*editedItem, bool) {
+	t := m.state.SelectedTable
+	item := m.dynamodbQueryResults.SelectedItem()
+	if t == nil || item == nil || m.client == nil {
+		return nil, false
+	}
+	if item.Typed == "" {
+		m.logger.Warn("The types of this item's attributes are not known; run the query again to edit it")
+		return nil, false
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil, false
+	}
+	return &editedItem{table: t.Name, keys: []string{t.PartitionKey(), t.SortKey()}, item: *item}, true
+}
+
+// editDynamoDBItem opens the selected item in $EDITOR as DynamoDB JSON, so
+// that numbers, binary values and sets are put back as they were.
+func (m *Model) editDynamoDBItem() tea.Cmd {
+	e, ok := m.selectedItemForWrite()
+	if !ok {
+		return nil
+	}
+	m.editingItem = e
+	return m.openEditor(editDynamoDBItem, prettyJSON(e.item.Typed), ".json")
+}
+
+// applyDynamoDBItem asks to put the item saved in the editor, unless it is
+// invalid or unchanged.
+func (m *Model) applyDynamoDBItem(text string) tea.Cmd {
+	e := m.editingItem
+	m.editingItem = nil
+	if e == nil {
+		return nil
+	}
+
+	item := strings.TrimSpace(text)
+	if item == "" {
+		m.logger.Info("Item left empty, nothing put; press D to delete it")
+		return nil
+	}
+	if err := aws.ValidateDynamoDBItem(item, e.keys...); err != nil {
+		m.logger.Error("Item not put into %s: %v", e.table, err)
+		return nil
+	}
+	if sameJSON(item, e.item.Typed) {
+		m.logger.Info("Item in %s unchanged", e.table)
+		return nil
+	}
+	key, _ := aws.DynamoDBItemKey(item, e.keys...)
+	oldKey, _ := aws.DynamoDBItemKey(e.item.Typed, e.keys...)
+
+	var attrs map[string]json.RawMessage
+	_ = json.Unmarshal([]byte(item), &attrs)
+	details := []string{
+		"Table: " + e.table,
+		"Key: " + key,
+		fmt.Sprintf("Attributes: %d", len(attrs)),
+	}
+	title := "Put item"
+	if !sameJSON(key, oldKey) {
+		title = "Put item with a new key"
+		details = append(details, "The key changed: a new item is written and "+oldKey+" is kept")
+	} else {
+		details = append(details, "The item is replaced as a whole")
+	}
+	return m.askConfirm(title, details, func() tea.Cmd {
+		client := m.client
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := client.PutItem(ctx, e.table, item)
+			return itemWrittenMsg{table: e.table, key: key, err: err}
+		}
+	})
+}
+
+// deleteDynamoDBItem asks to delete the selected item.
+func (m *Model) deleteDynamoDBItem() tea.Cmd {
+	e, ok := m.selectedItemForWrite()
+	if !ok {
+		return nil
+	}
+	key, err := aws.DynamoDBItemKey(e.item.Typed, e.keys...)
+	if err != nil {
+		m.logger.Error("Can't delete item from %s: %v", e.table, err)
+		return nil
+	}
+	return m.askConfirm("Delete item", []string{"Table: " + e.table, "Key: " + key}, func() tea.Cmd {
+		client := m.client
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := client.DeleteItem(ctx, e.table, key)
+			return itemWrittenMsg{table: e.table, key: key, deleted: true, err: err}
+		}
+	})
+}
+
+// handleItemWritten logs a put or deleted item and runs the query again to
+// show the table as it is now.
+func (m *Model) handleItemWritten(msg itemWrittenMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Error("%v", msg.err)
+		m.state.ShowLogs = true
+		m.updateComponentSizes()
+		return nil
+	}
+	if msg.deleted {
+		m.logger.Info("Deleted item %s from %s", msg.key, msg.table)
+		m.recordTimeline(timelineAction, "Deleted item %s from %s", msg.key, msg.table)
+	} else {
+		m.logger.Info("Put item %s into %s", msg.key, msg.table)
+		m.recordTimeline(timelineAction, "Put item %s into %s", msg.key, msg.table)
+	}
+	if m.state.SelectedTable == nil || m.state.SelectedTable.Name != msg.table {
+		return nil
+	}
+	return m.rerunDynamoDBQuery()
+}
+
+// rerunDynamoDBQuery runs the query or scan of the results again from the
+// first page.
+func (m *Model) rerunDynamoDBQuery() tea.Cmd {
+	if m.state.DynamoDBIsQuery && m.state.DynamoDBQueryParams != nil {
+		m.state.DynamoDBQueryLoading = true
+		m.state.DynamoDBLastKey = nil
+		m.dynamodbQueryResults.SetLoading(true)
+		m.dynamodbQueryResults.Clear()
+		return m.executeDynamoDBQuery(m.state.DynamoDBQueryParams)
+	} else if !m.state.DynamoDBIsQuery && m.state.DynamoDBScanParams != nil {
+		m.state.DynamoDBQueryLoading = true
+		m.state.DynamoDBLastKey = nil
+		m.dynamodbQueryResults.SetLoading(true)
+		m.dynamodbQueryResults.Clear()
+		return m.executeDynamoDBScan(m.state.DynamoDBScanParams)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+// instanceColumns are the columns of the EC2 instances list.
+var instanceColumns = []components.Column{
+	{Key: "type", Title: "TYPE", Width: 12},
+	{Key: "ip", Title: "PRIVATE IP", Width: 15},
+	{Key: "ssm", Title: "SSM", Width: 6},
+}
+
+// selectedInstance returns the instance under the cursor of the EC2 view.
+func (m *Model) selectedInstance() *model.EC2Instance {
+	item := m.instanceList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.Instances {
+		if m.state.Instances[i].InstanceID == item.ID {
+			return &m.state.Instances[i]
+		}
+	}
+	return nil
+}
+
+// instanceReachable reports whether Session Manager can reach an instance,
+// warning what stands in the way of the action if it can't.
+func (m *Model) instanceReachable(inst model.EC2Instance, action string) bool {
+	if inst.State != "running" {
+		m.logger.Warn("%s: instance %s is %s, not running", action, instanceLabel(inst), inst.State)
+		return false
+	}
+	if !inst.SSMManaged {
+		m.logger.Warn("%s: instance %s is not online in Session Manager (is the SSM agent running, with an instance profile allowing it?)", action, instanceLabel(inst))
+		return false
+	}
+	return true
+}
+
+// instanceLabel names an instance by its Name tag, or its ID without one.
+func instanceLabel(inst model.EC2Instance) string {
+	if inst.Name != "" {
+		return inst.Name
+	}
+	return inst.InstanceID
+}
+
+// handleInstancePortForward opens the port forward dialog for the selected
+// instance.
+func (m *Model) handleInstancePortForward() tea.Cmd {
+	inst := m.selectedInstance()
+	if inst == nil || !m.instanceReachable(*inst, "Port forward") {
+		return nil
+	}
+	m.pendingInstance = inst
+	m.enteringPort = true
+	m.portInput.CharLimit = len("65535:65535")
+	m.portInput.SetValue("")
+	m.portInput.Focus()
+	return textinput.Blink
+}
+
+// startInstanceTunnel forwards a port of an instance, as the port forward
+// dialog reads it.
+func (m *Model) startInstanceTunnel(inst model.EC2Instance, input string) tea.Cmd {
+	localPort, remotePort, err := parseInstancePorts(input)
+	if err != nil {
+		m.logger.Error("Port forward: %v", err)
+		return nil
+	}
+	m.logger.Info("Forwarding port %d of %s...", remotePort, instanceLabel(inst))
+	return m.startJumpHostTunnel(inst, instanceLabel(inst), "", remotePort, localPort)
+}
+
+// parseInstancePorts reads the ports of an instance port forward: a remote
+// port, forwarded to the same local one, or local:remote as ssh -L takes
+// them, with the local port left out for a random one.
+func parseInstancePorts(input string) (localPort, remotePort int, err error) {
+	local, remote, found := strings.Cut(strings.TrimSpace(input), ":")
+	if !found {
+		remote = local
+	}
+	remotePort, err = strconv.Atoi(strings.TrimSpace(remote))
+	if err != nil || remotePort < 1 || remotePort > 65535 {
+		return 0, 0, fmt.Errorf("invalid remote port %q", remote)
+	}
+	if local = strings.TrimSpace(local); local == "" {
+		return 0, remotePort, nil
+	}
+	localPort, err = strconv.Atoi(local)
+	if err != nil || localPort < 0 || localPort > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port %q", local)
+	}
+	return localPort, remotePort, nil
+}
+
+// updateInstanceList updates the EC2 instances list with current data.
+func (m *Model) updateInstanceList() {
+	instances := m.state.FilteredInstances()
+	items := make([]components.ListItem, len(instances))
+	for i, inst := range instances {
+		ssm := "-"
+		if inst.SSMManaged {
+			ssm = "online"
+		}
+		items[i] = components.ListItem{
+			ID:          inst.InstanceID,
+			Title:       instanceLabel(inst),
+			Status:      inst.State,
+			StatusStyle: InstanceStateStyle(inst.State),
+			Cells:       map[string]string{"type": inst.InstanceType, "ip": inst.PrivateIPAddress, "ssm": ssm},
+		}
+	}
+	m.instanceList.SetColumns(instanceColumns)
+	m.instanceList.SetItems(items)
+	m.instanceList.SetLoading(false)
+	m.instanceList.SetError(m.state.InstancesError)
+	m.instanceList.SetEmptyMessage("No EC2 instances found")
+	m.updateInstanceDetails()
+}
+
+// updateInstanceDetails updates the details panel with the selected
+// instance.
+func (m *Model) updateInstanceDetails() {
+	st := GetStyles()
+	inst := m.selectedInstance()
+	m.details.SetTitle("EC2 Instance")
+	if inst == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	ssm, ssmStyle := "Online", st.StatusHealthy
+	if !inst.SSMManaged {
+		ssm, ssmStyle = "Not online (no shell or port forward)", st.Muted
+	}
+	rows := []components.DetailRow{
+		{Label: "Name", Value: valueOrDash(inst.Name)},
+		{Label: "Instance ID", Value: inst.InstanceID},
+		{Label: "Type", Value: valueOrDash(inst.InstanceType)},
+		{Label: "State", Value: inst.State, Style: InstanceStateStyle(inst.State)},
+		{Label: "Session Manager", Value: ssm, Style: ssmStyle},
+		{Label: "Private IP", Value: valueOrDash(inst.PrivateIPAddress)},
+		{Label: "Public IP", Value: valueOrDash(inst.PublicIPAddress)},
+		{Label: "VPC", Value: valueOrDash(inst.VpcID)},
+		{Label: "Subnet", Value: valueOrDash(inst.SubnetID)},
+		{Label: "Launched", Value: format.Time(inst.LaunchTime)},
+	}
+	if inst.SSMManaged && inst.State == "running" {
+		rows = append(rows,
+			components.DetailRow{Label: "", Value: ""}, // Spacer
+			components.DetailRow{Label: "Actions", Value: "S to open a shell, p to forward a port", Style: st.Muted},
+		)
+	}
+	m.details.SetRows(rows)
+}
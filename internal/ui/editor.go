@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorTarget is the input that text edited in $EDITOR goes back to.
+type editorTarget int
+
+const (
+	editLambdaPayload editorTarget = iota
+	editNote
+	editStackPolicy
+	editQueueMessage
+	editDynamoDBItem
+)
+
+// editorFinishedMsg carries the text saved in the editor once it exits.
+type editorFinishedMsg struct {
+	target editorTarget
+	text   string
+	err    error
+}
+
+// editorCommand returns the user's editor: $VISUAL, then $EDITOR, then vi.
+// The variable may hold arguments too, e.g. "code --wait".
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if args := strings.Fields(os.Getenv(env)); len(args) > 0 {
+			return args
+		}
+	}
+	return []string{"vi"}
+}
+
+// openEditor suspends the UI and edits text in the user's editor, in a
+// temporary file named with ext so that the editor highlights it. The file
+// is read back and removed when the editor exits.
+func (m *Model) openEditor(target editorTarget, text, ext string) tea.Cmd {
+	f, err := os.CreateTemp("", "vaws-*"+ext)
+	if err != nil {
+		m.logger.Error("Failed to create file to edit: %v", err)
+		return nil
+	}
+	path := f.Name()
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		m.logger.Error("Failed to write file to edit: %v", err)
+		return nil
+	}
+
+	args := editorCommand()
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	m.logger.Debug("Running: %v", cmd.Args)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return editorFinishedMsg{target: target, err: fmt.Errorf("%s: %w", args[0], err)}
+		}
+		data, err := os.ReadFile(path)
+		return editorFinishedMsg{target: target, text: string(data), err: err}
+	})
+}
+
+// handleEditorFinished puts the edited text back into the input it came from.
+func (m *Model) handleEditorFinished(msg editorFinishedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Error("Editor failed: %v", msg.err)
+		return nil
+	}
+
+	switch msg.target {
+	case editLambdaPayload:
+		if !m.enteringPayload {
+			return nil
+		}
+		payload := strings.TrimSpace(msg.text)
+		// The input is a single line, so fold JSON onto one
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, []byte(payload)); err == nil {
+			payload = compact.String()
+		} else if payload != "" {
+			m.logger.Warn("Payload is not valid JSON: %v", err)
+		}
+		m.payloadInput.SetValue(payload)
+		m.payloadInput.CursorEnd()
+		m.logger.Info("Payload edited (%d bytes), press Enter to invoke", len(payload))
+
+	case editQueueMessage:
+		d := m.sendMessage
+		if d == nil {
+			return nil
+		}
+		// The body input is a single line too
+		body := strings.TrimRight(msg.text, "\n")
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, []byte(body)); err == nil {
+			body = compact.String()
+		}
+		d.inputs[0].SetValue(body)
+		d.inputs[0].CursorEnd()
+		m.logger.Info("Message edited (%d bytes), press Enter to send", len(body))
+
+	case editNote:
+		m.setNote(m.noteARN, m.noteName, msg.text)
+
+	case editStackPolicy:
+		return m.applyStackPolicy(msg.text)
+
+	case editDynamoDBItem:
+		return m.applyDynamoDBItem(msg.text)
+	}
+	return nil
+}
+
+// prettyJSON indents text for editing if it is JSON, and returns it unchanged
+// otherwise.
+func prettyJSON(text string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(text), "", "  "); err != nil {
+		return text
+	}
+	return out.String() + "\n"
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+)
+
+// handleEnvCommand switches to the named environment, or lists the
+// environments of the config file when no name is given.
+func (m *Model) handleEnvCommand(args []string) tea.Cmd {
+	if m.cfg == nil || len(m.cfg.Environments) == 0 {
+		m.logger.Warn("No environments configured (add them under environments: in %s)", config.DefaultConfigPath())
+		return nil
+	}
+	if len(args) == 0 {
+		names := m.cfg.EnvironmentNames()
+		for i, name := range names {
+			if name == m.state.Environment {
+				names[i] = name + " (current)"
+			}
+		}
+		m.logger.Info("Environments: %s", strings.Join(names, ", "))
+		return nil
+	}
+
+	name := args[0]
+	env, ok := m.cfg.GetEnvironment(name)
+	if !ok {
+		m.logger.Warn("Unknown environment %q (configured: %s)", name, strings.Join(m.cfg.EnvironmentNames(), ", "))
+		return nil
+	}
+	if env.Profile == "" {
+		m.logger.Error("Environment %s has no profile", name)
+		return nil
+	}
+	if _, err := path.Match(env.StackPattern, ""); err != nil {
+		m.logger.Error("Environment %s has an invalid stack_pattern %q: %v", name, env.StackPattern, err)
+		return nil
+	}
+
+	region := env.Region
+	if region == "" {
+		region = m.cfg.GetProfileConfig(env.Profile).Region
+	}
+
+	m.logger.Info("Switching to environment %s (profile %s)...", name, env.Profile)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		client, err := m.newClient(ctx, env.Profile, region)
+		return environmentChangedMsg{name: name, client: client, err: err}
+	}
+}
+
+// applyEnvironment re-targets the client, tunnel managers and cached data at
+// a new environment. Tunnels of the previous one are stopped, as their
+// processes hold credentials of the old profile and could no longer be
+// managed once the managers are replaced.
+func (m *Model) applyEnvironment(msg environmentChangedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Error("Failed to switch to environment %s: %v", msg.name, msg.err)
+		return nil
+	}
+	env, _ := m.cfg.GetEnvironment(msg.name)
+
+	if stopped := m.stopAllTunnels(); stopped > 0 {
+		m.logger.Info("Stopped %d tunnels of the previous environment", stopped)
+	}
+
+	m.client = msg.client
+	m.account = nil
+	m.state.Account = ""
+	m.state.Profile = msg.client.Profile()
+	m.state.Region = msg.client.Region()
+	m.state.Environment = msg.name
+	m.state.StackPattern = env.StackPattern
+	m.tunnelManager = newTunnelManager(m.cfg, m.state.Profile, m.state.Region)
+	m.apiGWManager = newAPIGatewayManager(m.cfg, m.state.Profile, m.state.Region)
+	m.term.tunnels = nil
+	m.clearCachedResources()
+	m.resetTerraform()
+	m.updateTunnelsPanel()
+	m.warnUnknownActions()
+
+	m.logger.Info("Switched to environment %s: %s/%s", msg.name, m.state.Profile, m.state.Region)
+	m.recordTimeline(timelineSession, "Switched to environment %s: %s/%s", msg.name, m.state.Profile, m.state.Region)
+
+	// Views of the previous account can't be refreshed, so start over
+	if env.StackPattern != "" {
+		return tea.Batch(m.switchToStacks(), m.loadTerraform())
+	}
+	return tea.Batch(m.switchToMain(), m.loadTerraform())
+}
+
+// stopAllTunnels stops the tunnels and API proxies of both managers and
+// returns how many there were.
+func (m *Model) stopAllTunnels() int {
+	stopped := 0
+	if m.tunnelManager != nil {
+		stopped += len(m.tunnelManager.GetTunnels())
+		m.tunnelManager.StopAllTunnels()
+	}
+	if m.apiGWManager != nil {
+		stopped += len(m.apiGWManager.GetTunnels())
+		m.apiGWManager.StopAllTunnels()
+	}
+	return stopped
+}
+
+// jumpHostTag returns the tag used to find jump hosts: the one of the
+// current environment, or else the one of the profile.
+func (m *Model) jumpHostTag() string {
+	if m.cfg == nil {
+		return ""
+	}
+	if env, ok := m.cfg.GetEnvironment(m.state.Environment); ok && env.JumpHostTag != "" {
+		return env.JumpHostTag
+	}
+	return m.cfg.GetJumpHostTag(m.state.Profile)
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+)
+
+// filterDebounce is how long typing has to pause before the filter applies.
+const filterDebounce = 150 * time.Millisecond
+
+// filterMaxDelay is how long the filter may lag behind while typing goes on
+// without a pause, so that long lists still narrow down as you type.
+const filterMaxDelay = 500 * time.Millisecond
+
+// filterDebounceMsg applies the filter being typed, unless more was typed
+// since it was scheduled.
+type filterDebounceMsg struct {
+	seq int
+}
+
+// scheduleFilter applies the filter being typed once typing pauses, or at
+// once if it has lagged behind for filterMaxDelay.
+func (m *Model) scheduleFilter() tea.Cmd {
+	if m.filterInput.Value() == m.state.FilterText {
+		return nil
+	}
+	m.filterSeq++
+	if m.filterPendingSince.IsZero() {
+		m.filterPendingSince = time.Now()
+	}
+	if time.Since(m.filterPendingSince) >= filterMaxDelay {
+		m.applyFilter()
+		return nil
+	}
+	seq := m.filterSeq
+	return tea.Tick(filterDebounce, func(time.Time) tea.Msg {
+		return filterDebounceMsg{seq: seq}
+	})
+}
+
+// handleFilterDebounce applies the filter if nothing was typed since the
+// tick was scheduled.
+func (m *Model) handleFilterDebounce(msg filterDebounceMsg) {
+	if msg.seq != m.filterSeq || !m.filtering {
+		return
+	}
+	m.applyFilter()
+}
+
+// applyFilter filters the current list by the text typed so far.
+func (m *Model) applyFilter() {
+	m.filterSeq++ // Pending ticks are stale now
+	m.filterPendingSince = time.Time{}
+	if m.state.FilterText == m.filterInput.Value() {
+		return
+	}
+	m.state.FilterText = m.filterInput.Value()
+	m.updateCurrentList()
+}
+
+// viewList returns the list of the current view, or nil if the view shows a
+// table or no list.
+func (m *Model) viewList() *components.List {
+	switch m.state.View {
+	case state.ViewMain:
+		return m.mainMenuList
+	case state.ViewStacks:
+		return m.stacksList
+	case state.ViewStackResources:
+		return m.stackResourcesList
+	case state.ViewStackEvents:
+		return m.stackEventsList
+	case state.ViewClusters:
+		return m.clustersList
+	case state.ViewServices:
+		return m.serviceList
+	case state.ViewLambda:
+		return m.lambdaList
+	case state.ViewAppRunner:
+		return m.appRunnerList
+	case state.ViewFirehose:
+		return m.firehoseList
+	case state.ViewCognito:
+		return m.userPoolList
+	case state.ViewCognitoUsers:
+		return m.cognitoUserList
+	case state.ViewResourceTypes:
+		return m.resourceTypeList
+	case state.ViewMSK:
+		return m.mskList
+	case state.ViewMQ:
+		return m.mqList
+	case state.ViewDatabases:
+		return m.databaseList
+	case state.ViewEC2:
+		return m.instanceList
+	case state.ViewEFS:
+		return m.efsList
+	case state.ViewSchedules:
+		return m.schedulesList
+	case state.ViewSecrets:
+		return m.secretsList
+	case state.ViewLogGroups:
+		return m.logGroupsList
+	case state.ViewSES:
+		return m.sesList
+	case state.ViewSESSuppressions:
+		return m.sesSuppressionList
+	case state.ViewActivity:
+		return m.activityList
+	case state.ViewLogSearch:
+		return m.logSearchList
+	case state.ViewHealth:
+		return m.healthList
+	case state.ViewLambdaRuntimes:
+		return m.runtimesList
+	case state.ViewAlerts:
+		return m.alertsList
+	case state.ViewStats:
+		return m.statsList
+	case state.ViewTimeline:
+		return m.timelineList
+	case state.ViewCloudResources:
+		return m.cloudResourceList
+	case state.ViewAPIGateway:
+		return m.apiGatewayList
+	case state.ViewAPIStages:
+		return m.apiStagesList
+	case state.ViewJumpHostSelect:
+		return m.ec2List
+	case state.ViewContainerSelect:
+		return m.containerList
+	case state.ViewTasks:
+		return m.tasksList
+	case state.ViewTaskContainers:
+		return m.taskContainersList
+	case state.ViewEndpointSelect:
+		return m.endpointList
+	}
+	return nil
+}
+
+// highlightFilter underlines the applied filter in the names of the current
+// view's rows.
+func (m *Model) highlightFilter() {
+	if l := m.viewList(); l != nil {
+		l.SetFilterHighlight(m.state.FilterText)
+	}
+	switch m.state.View {
+	case state.ViewSQS:
+		m.sqsTable.SetFilterHighlight(m.state.FilterText)
+	case state.ViewDynamoDB:
+		m.dynamodbTable.SetFilterHighlight(m.state.FilterText)
+	}
+}
+
+// filterMatchesText returns how many rows of the current view the filter
+// leaves, such as "12 matches", or "" if the view can't tell.
+func (m *Model) filterMatchesText() string {
+	n := 0
+	switch l := m.viewList(); {
+	case l != nil:
+		n = l.ItemCount()
+	case m.state.View == state.ViewSQS:
+		n = m.sqsTable.QueueCount()
+	case m.state.View == state.ViewDynamoDB:
+		n = m.dynamodbTable.TableCount()
+	default:
+		return ""
+	}
+	if n == 1 {
+		return "1 match"
+	}
+	return fmt.Sprintf("%d matches", n)
+}
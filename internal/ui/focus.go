@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/state"
+)
+
+// focusPane is a panel that scrolling keys apply to.
+type focusPane int
+
+const (
+	focusMain    focusPane = iota // The list, or the panel of a full-width view such as tunnels or CloudWatch logs
+	focusDetails                  // The details pane beside the list
+	focusLogs                     // The logs panel at the bottom
+)
+
+// scrollMove is a movement of the focused panel.
+type scrollMove int
+
+const (
+	scrollLineUp scrollMove = iota
+	scrollLineDown
+	scrollTop
+	scrollBottom
+	scrollHalfPageUp
+	scrollHalfPageDown
+	scrollPageUp
+	scrollPageDown
+)
+
+// scrollMoves maps keys to the movements of the focused panel.
+var scrollMoves = map[string]scrollMove{
+	"up":     scrollLineUp,
+	"k":      scrollLineUp,
+	"down":   scrollLineDown,
+	"j":      scrollLineDown,
+	"g":      scrollTop,
+	"home":   scrollTop,
+	"G":      scrollBottom,
+	"end":    scrollBottom,
+	"ctrl+u": scrollHalfPageUp,
+	"ctrl+d": scrollHalfPageDown,
+	"ctrl+b": scrollPageUp,
+	"pgup":   scrollPageUp,
+	"ctrl+f": scrollPageDown,
+	"pgdown": scrollPageDown,
+}
+
+// hasDetailsPane reports whether the view shows the details pane beside its
+// list. Full-width views and narrow terminals show the list alone.
+func (m *Model) hasDetailsPane() bool {
+	if m.getLayoutMode() != layoutFull {
+		return false
+	}
+	switch m.state.View {
+	case state.ViewTunnels, state.ViewCloudWatchLogs, state.ViewDynamoDBQuery, state.ViewDiff, state.ViewMonitor:
+		return false
+	}
+	return true
+}
+
+// focusablePanes returns the panels on screen in the order focus cycles
+// through them. A zoomed layout keeps both panes, so cycling swaps which one
+// fills the screen.
+func (m *Model) focusablePanes() []focusPane {
+	panes := []focusPane{focusMain}
+	if m.hasDetailsPane() {
+		panes = append(panes, focusDetails)
+	}
+	if m.shouldShowLogs() {
+		panes = append(panes, focusLogs)
+	}
+	return panes
+}
+
+// focusedPane returns the panel with focus. Focus left on a panel that is no
+// longer shown, e.g. the logs after l hid them, falls back to the main one.
+func (m *Model) focusedPane() focusPane {
+	if slices.Contains(m.focusablePanes(), m.focus) {
+		return m.focus
+	}
+	return focusMain
+}
+
+// setFocus moves focus to a panel and marks it with a focus border.
+func (m *Model) setFocus(p focusPane) {
+	m.focus = p
+	m.syncFocus()
+	if m.paneZoomed {
+		m.updateComponentSizes()
+	}
+}
+
+// syncFocus colors the borders of the panels by which one has focus. The
+// content border is only colored while the logs panel is there to contrast.
+func (m *Model) syncFocus() {
+	focused := m.focusedPane()
+	m.details.SetFocused(focused == focusDetails)
+	m.logs.SetFocused(focused == focusLogs)
+	m.container.SetFocused(focused != focusLogs && m.shouldShowLogs())
+}
+
+// cycleFocus moves focus step panels on, wrapping around.
+func (m *Model) cycleFocus(step int) {
+	panes := m.focusablePanes()
+	i := slices.Index(panes, m.focusedPane())
+	m.setFocus(panes[(i+step+len(panes))%len(panes)])
+}
+
+// viewOwnsTab reports whether the view uses tab itself, e.g. to switch
+// containers, leaving ctrl+w to cycle focus.
+func (m *Model) viewOwnsTab() bool {
+	switch m.state.View {
+	case state.ViewCloudWatchLogs, state.ViewDynamoDBQuery, state.ViewDiff:
+		return true
+	}
+	return false
+}
+
+// handleFocusKey cycles focus, and scrolls the logs panel while it has
+// focus, in every view before the view's own keys. It reports whether the
+// key was consumed.
+func (m *Model) handleFocusKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	tab := msg.String() == "tab" || msg.String() == "shift+tab"
+	switch {
+	case matchKey(msg, m.keys.FocusNext) && !(tab && m.viewOwnsTab()):
+		m.cycleFocus(1)
+		return nil, true
+	case matchKey(msg, m.keys.FocusPrev) && !m.viewOwnsTab():
+		m.cycleFocus(-1)
+		return nil, true
+	}
+
+	if m.focusedPane() != focusLogs {
+		return nil, false
+	}
+	if msg.String() == "esc" {
+		m.setFocus(focusMain)
+		return nil, true
+	}
+	move, ok := scrollMoves[msg.String()]
+	if !ok {
+		return nil, false
+	}
+	m.scrollFocused(move)
+	return nil, true
+}
+
+// scrollFocused applies a movement to the focused panel: it scrolls the
+// details or logs, or moves the cursor of the list.
+func (m *Model) scrollFocused(move scrollMove) {
+	switch m.focusedPane() {
+	case focusDetails:
+		switch move {
+		case scrollLineUp:
+			m.details.ScrollUp()
+		case scrollLineDown:
+			m.details.ScrollDown()
+		case scrollTop:
+			m.details.ScrollToTop()
+		case scrollBottom:
+			m.details.ScrollToBottom()
+		case scrollHalfPageUp:
+			m.details.ScrollHalfPageUp()
+		case scrollHalfPageDown:
+			m.details.ScrollHalfPageDown()
+		case scrollPageUp:
+			m.details.ScrollPageUp()
+		case scrollPageDown:
+			m.details.ScrollPageDown()
+		}
+	case focusLogs:
+		switch move {
+		case scrollLineUp:
+			m.logs.ScrollUp()
+		case scrollLineDown:
+			m.logs.ScrollDown()
+		case scrollTop:
+			m.logs.ScrollToTop()
+		case scrollBottom:
+			m.logs.ScrollToBottom()
+		case scrollHalfPageUp:
+			m.logs.HalfPageUp()
+		case scrollHalfPageDown:
+			m.logs.HalfPageDown()
+		case scrollPageUp:
+			m.logs.PageUp()
+		case scrollPageDown:
+			m.logs.PageDown()
+		}
+	default:
+		switch move {
+		case scrollLineUp:
+			m.moveCursorUp()
+		case scrollLineDown:
+			m.moveCursorDown()
+		case scrollTop:
+			m.moveCursorTop()
+		case scrollBottom:
+			m.moveCursorBottom()
+		}
+	}
+}
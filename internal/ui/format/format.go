@@ -0,0 +1,149 @@
+// Package format renders timestamps and counts the way the user prefers:
+// relative or absolute times, local time or UTC, compact or full numbers.
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Options are the display preferences.
+type Options struct {
+	Absolute    bool // Show "2006-01-02 15:04:05" instead of "3m ago"
+	UTC         bool // Show absolute times in UTC instead of local time
+	FullNumbers bool // Show "12,345" instead of "12.3k"
+}
+
+var (
+	current     Options
+	currentLock sync.RWMutex
+)
+
+// Current returns the active display preferences.
+func Current() Options {
+	currentLock.RLock()
+	defer currentLock.RUnlock()
+	return current
+}
+
+// Set replaces the display preferences.
+func Set(o Options) {
+	currentLock.Lock()
+	defer currentLock.Unlock()
+	current = o
+}
+
+// Time renders a timestamp, "-" if it is zero.
+func Time(t time.Time) string {
+	return render(t, "2006-01-02 15:04:05")
+}
+
+// Date renders a timestamp where the time of day doesn't matter.
+func Date(t time.Time) string {
+	return render(t, "2006-01-02")
+}
+
+// Absolute renders a timestamp as a date and time whatever the preference,
+// for places where a relative time would be ambiguous, such as a list of
+// events side by side.
+func Absolute(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return absolute(t, "2006-01-02 15:04:05", Current().UTC)
+}
+
+func render(t time.Time, layout string) string {
+	if t.IsZero() {
+		return "-"
+	}
+	o := Current()
+	if o.Absolute {
+		return absolute(t, layout, o.UTC)
+	}
+	return Relative(time.Since(t))
+}
+
+func absolute(t time.Time, layout string, utc bool) string {
+	if utc {
+		return t.UTC().Format(layout) + " UTC"
+	}
+	return t.Local().Format(layout)
+}
+
+// Relative renders how long ago something happened, e.g. "3m ago". A
+// negative d is in the future: "in 3m".
+func Relative(d time.Duration) string {
+	if d < 0 {
+		return "in " + Age(-d)
+	}
+	if d < time.Minute {
+		return "just now"
+	}
+	return Age(d) + " ago"
+}
+
+// Age renders a duration with its largest unit: "45s", "3m", "5h", "12d",
+// "4mo" or "2y".
+func Age(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < day:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 60*day:
+		return fmt.Sprintf("%dd", int(d/day))
+	case d < 365*day:
+		return fmt.Sprintf("%dmo", int(d/(30*day)))
+	default:
+		return fmt.Sprintf("%dy", int(d/(365*day)))
+	}
+}
+
+// Count renders a count, compact ("1.2k", "3.4M") unless full numbers are
+// preferred ("1,234").
+func Count(n int64) string {
+	if Current().FullNumbers {
+		return grouped(n)
+	}
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs >= 1_000_000_000:
+		return compact(n, 1_000_000_000, "B")
+	case abs >= 1_000_000:
+		return compact(n, 1_000_000, "M")
+	case abs >= 1_000:
+		return compact(n, 1_000, "k")
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+// compact divides n by unit with one decimal, dropping a trailing ".0".
+func compact(n, unit int64, suffix string) string {
+	s := strconv.FormatFloat(float64(n)/float64(unit), 'f', 1, 64)
+	if len(s) > 2 && s[len(s)-2:] == ".0" {
+		s = s[:len(s)-2]
+	}
+	return s + suffix
+}
+
+// grouped renders n with thousands separators.
+func grouped(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return sign + s
+}
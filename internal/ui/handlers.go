@@ -3,14 +3,21 @@ package ui
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"vaws/internal/aws"
+	"vaws/internal/config"
 	"vaws/internal/model"
 	"vaws/internal/state"
+	"vaws/internal/tunnel"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
 )
 
 // handleKeyMsg handles key messages when not in special input modes.
@@ -20,11 +27,21 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		return m.handleFilterKey(msg)
 	}
 
+	// Handle confirm dialog before anything else
+	if m.pendingConfirm != nil {
+		return m.handleConfirmKey(msg)
+	}
+
 	// Handle details search mode separately
 	if m.detailsSearching {
 		return m.handleDetailsSearchKey(msg)
 	}
 
+	// Handle the jq filter input of a JSON tree
+	if m.jsonFiltering {
+		return m.handleJSONFilterKey(msg)
+	}
+
 	// Handle port input mode separately
 	if m.enteringPort {
 		return m.handlePortInputKey(msg)
@@ -35,6 +52,102 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		return m.handlePayloadInputKey(msg)
 	}
 
+	// Handle proxy rules input mode separately
+	if m.editingProxyRules {
+		return m.handleProxyRulesInputKey(msg)
+	}
+
+	// Handle Cognito user search input mode separately
+	if m.searchingUsers {
+		return m.handleUserSearchInputKey(msg)
+	}
+
+	// Handle SES test email recipient input mode separately
+	if m.enteringSESRecipient {
+		return m.handleSESRecipientInputKey(msg)
+	}
+
+	// Handle the alias traffic dialog separately
+	if m.traffic != nil {
+		return m.handleTrafficKey(msg)
+	}
+
+	// Handle the stage deployments dialog separately
+	if m.deployments != nil {
+		return m.handleDeploymentsKey(msg)
+	}
+
+	// Handle the task protection dialog separately
+	if m.protection != nil {
+		return m.handleTaskProtectionKey(msg)
+	}
+
+	// Handle the task details separately
+	if m.taskDetails != nil {
+		return m.handleTaskDetailsKey(msg)
+	}
+
+	// Handle the queue map separately
+	if m.queueMap != nil {
+		return m.handleQueueMapKey(msg)
+	}
+
+	// Handle the queue failures panel separately
+	if m.queueFailures != nil {
+		return m.handleQueueFailuresKey(msg)
+	}
+
+	// Handle the send message dialog separately
+	if m.sendMessage != nil {
+		return m.handleSendMessageKey(msg)
+	}
+
+	// Handle the time range picker separately, before the performance panel
+	// it can be opened over
+	if m.timeRangePicker.IsActive() {
+		return m.handleTimeRangePickerKey(msg)
+	}
+
+	// Handle the Lambda performance panel separately
+	if m.lambdaPerformance != nil {
+		return m.handleLambdaPerformanceKey(msg)
+	}
+
+	// Handle the task chaos dialog separately
+	if m.chaos != nil {
+		return m.handleChaosKey(msg)
+	}
+
+	// Handle the log retention dialog separately
+	if m.retention != nil {
+		return m.handleRetentionKey(msg)
+	}
+
+	// Handle the costs panel separately
+	if m.costs != nil {
+		return m.handleCostsKey(msg)
+	}
+
+	// Handle the cluster dashboard separately
+	if m.clusterDash != nil {
+		return m.handleClusterDashboardKey(msg)
+	}
+
+	// Handle the accounts dialog separately
+	if m.accounts != nil {
+		return m.handleAccountsKey(msg)
+	}
+
+	// Handle the column chooser separately
+	if m.columns != nil {
+		return m.handleColumnChooserKey(msg)
+	}
+
+	// Handle stack log search input mode separately
+	if m.searchingLogs {
+		return m.handleLogSearchInputKey(msg)
+	}
+
 	// Handle DynamoDB query dialog
 	if m.dynamodbQueryDialog.IsActive() {
 		return m.handleDynamoDBQueryDialogKey(msg)
@@ -79,11 +192,26 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		return nil // Ignore other keys in copy mode
 	}
 
+	// Focus cycling, and scrolling a focused logs panel, in every view
+	if cmd, handled := m.handleFocusKey(msg); handled {
+		return cmd
+	}
+
 	// Handle DynamoDB query results navigation
 	if m.state.View == state.ViewDynamoDBQuery {
 		return m.handleDynamoDBQueryResultsKey(msg)
 	}
 
+	// Handle diff view navigation
+	if m.state.View == state.ViewDiff {
+		return m.handleDiffKey(msg)
+	}
+
+	// Handle monitor dashboard navigation
+	if m.state.View == state.ViewMonitor {
+		return m.handleMonitorKey(msg)
+	}
+
 	// Handle CloudWatch logs navigation
 	if m.state.View == state.ViewCloudWatchLogs {
 		if cmd, handled := m.handleCloudWatchLogsKey(msg); handled {
@@ -92,80 +220,40 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		// Fall through to main handler for shortcuts like 1,2,3,4
 	}
 
+	// Expand/collapse and path copy in a focused JSON tree
+	if m.handleJSONTreeKey(msg) {
+		return nil
+	}
+
+	// Keys bound to macros in the config
+	if cmd, handled := m.handleMacroKey(msg); handled {
+		return cmd
+	}
+
 	switch {
 	case matchKey(msg, m.keys.Quit):
 		m.tunnelManager.StopAllTunnels()
 		return tea.Quit
 
+	case matchKey(msg, m.keys.RecordMacro):
+		m.toggleMacroRecording()
+
+	case matchKey(msg, m.keys.ReplayMacro):
+		return m.playMacro("last recording", m.macro.recorded)
+
 	case msg.String() == "q":
 		// Query DynamoDB table
 		if m.state.View == state.ViewDynamoDB {
 			return m.handleDynamoDBQuery()
 		}
 
-	case matchKey(msg, m.keys.Up):
-		if m.details.IsFocused() {
-			m.details.ScrollUp()
-		} else {
-			m.moveCursorUp()
-		}
-
-	case matchKey(msg, m.keys.Down):
-		if m.details.IsFocused() {
-			m.details.ScrollDown()
-		} else {
-			m.moveCursorDown()
-		}
-
-	case matchKey(msg, m.keys.Top):
-		if m.details.IsFocused() {
-			m.details.ScrollToTop()
-		} else {
-			m.moveCursorTop()
-		}
-
-	case matchKey(msg, m.keys.Bottom):
-		if m.details.IsFocused() {
-			m.details.ScrollToBottom()
-		} else {
-			m.moveCursorBottom()
-		}
-
-	case msg.String() == "ctrl+d":
-		// Half page down (vim-like)
-		if m.details.IsFocused() {
-			m.details.ScrollHalfPageDown()
-		}
-
-	case msg.String() == "ctrl+u":
-		// Half page up (vim-like)
-		if m.details.IsFocused() {
-			m.details.ScrollHalfPageUp()
-		}
-
-	case msg.String() == "ctrl+f":
-		// Full page down (vim-like)
-		if m.details.IsFocused() {
-			m.details.ScrollPageDown()
-		}
-
-	case msg.String() == "ctrl+b":
-		// Full page up (vim-like)
-		if m.details.IsFocused() {
-			m.details.ScrollPageUp()
-		}
-
-	case msg.String() == "pgdown":
-		// Page down
-		if m.details.IsFocused() {
-			m.details.ScrollPageDown()
-		}
-
-	case msg.String() == "pgup":
-		// Page up
-		if m.details.IsFocused() {
-			m.details.ScrollPageUp()
-		}
+	case matchKey(msg, m.keys.Up), matchKey(msg, m.keys.Down),
+		matchKey(msg, m.keys.Top), matchKey(msg, m.keys.Bottom),
+		msg.String() == "ctrl+d", msg.String() == "ctrl+u",
+		msg.String() == "ctrl+f", msg.String() == "ctrl+b",
+		msg.String() == "pgdown", msg.String() == "pgup":
+		// Move the focused panel: the list's cursor, or scroll the details
+		m.scrollFocused(scrollMoves[msg.String()])
 
 	case matchKey(msg, m.keys.Enter), matchKey(msg, m.keys.Right):
 		return m.handleEnter()
@@ -176,7 +264,7 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 	case matchKey(msg, m.keys.Filter):
 		if m.state.View != state.ViewTunnels {
 			// Start details search when details is focused, otherwise list filter
-			if m.details.IsFocused() {
+			if m.focusedPane() == focusDetails {
 				m.startDetailsSearch()
 			} else {
 				m.startFiltering()
@@ -187,19 +275,177 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		m.state.ToggleLogs()
 		m.updateComponentSizes()
 
+	case matchKey(msg, m.keys.ShrinkList):
+		m.resizeListPane(-listPaneRatioStep)
+
+	case matchKey(msg, m.keys.GrowList):
+		m.resizeListPane(listPaneRatioStep)
+
+	case matchKey(msg, m.keys.ShrinkLogs):
+		m.resizeLogsPane(-2)
+
+	case matchKey(msg, m.keys.GrowLogs):
+		m.resizeLogsPane(2)
+
+	case matchKey(msg, m.keys.Zoom):
+		m.toggleZoom()
+
+	case matchKey(msg, m.keys.Pin):
+		m.handlePinToMonitor()
+
 	case matchKey(msg, m.keys.CloudWatchLogs):
+		switch m.state.View {
+		case state.ViewStacks, state.ViewStackResources, state.ViewLogSearch:
+			return m.startLogSearch()
+		}
 		return m.handleCloudWatchLogs()
 
 	case matchKey(msg, m.keys.PortForward):
 		return m.handlePortForward()
 
+	case matchKey(msg, m.keys.DiscoveryTunnel):
+		if m.state.View == state.ViewTasks || m.state.View == state.ViewTaskContainers {
+			return m.openTaskDetails()
+		}
+		return m.handleDiscoveryTunnel()
+
+	case matchKey(msg, m.keys.Shell):
+		return m.handleShell()
+
+	case matchKey(msg, m.keys.DiffTaskDef):
+		return m.handleTaskDefDiff()
+
 	case matchKey(msg, m.keys.LambdaInvoke):
+		if m.state.View == state.ViewSchedules {
+			return m.handleScheduleRunNow()
+		}
+		if m.state.View == state.ViewSecrets {
+			return m.handleSecretRotate()
+		}
 		return m.handleLambdaInvoke()
 
+	case matchKey(msg, m.keys.Runtimes):
+		if m.state.View == state.ViewLambda {
+			return m.openRuntimes()
+		}
+
+	case matchKey(msg, m.keys.Traffic):
+		if m.state.View == state.ViewLambda {
+			return m.openTraffic()
+		}
+
+	case matchKey(msg, m.keys.Performance):
+		if m.state.View == state.ViewLambda {
+			return m.openLambdaPerformance()
+		}
+		if m.state.View == state.ViewAPIStages {
+			return m.handleAPIGatewayMock()
+		}
+
+	case matchKey(msg, m.keys.Deployments):
+		if m.state.View == state.ViewAPIStages {
+			return m.openDeployments()
+		}
+
+	case matchKey(msg, m.keys.Protection):
+		if m.state.View == state.ViewServices {
+			return m.openTaskProtection()
+		}
+		if m.state.View == state.ViewStacks {
+			return m.toggleTerminationProtection()
+		}
+
+	case matchKey(msg, m.keys.QueueMap):
+		if m.state.View == state.ViewSQS {
+			return m.openQueueMap()
+		}
+
+	case matchKey(msg, m.keys.QueueFailures):
+		if m.state.View == state.ViewSQS {
+			return m.openQueueFailures()
+		}
+
+	case matchKey(msg, m.keys.Chaos):
+		if m.state.View == state.ViewServices {
+			return m.openChaos()
+		}
+
+	case matchKey(msg, m.keys.Columns):
+		if m.columnKind() != "" {
+			return m.openColumnChooser()
+		}
+
+	case matchKey(msg, m.keys.ClusterDash):
+		if m.state.View == state.ViewClusters || m.state.View == state.ViewServices {
+			return m.openClusterDashboard()
+		}
+
+	case matchKey(msg, m.keys.Images):
+		if m.state.View == state.ViewServices {
+			return m.openImages()
+		}
+
+	case matchKey(msg, m.keys.PauseResume):
+		if m.state.View == state.ViewSchedules {
+			return m.handleSchedulePauseResume()
+		}
+		if m.state.View == state.ViewStacks {
+			return m.editStackPolicy()
+		}
+		return m.handleAppRunnerPauseResume()
+
+	case matchKey(msg, m.keys.Deploy):
+		return m.handleAppRunnerDeploy()
+
+	case matchKey(msg, m.keys.TestPut):
+		switch m.state.View {
+		case state.ViewSES:
+			return m.startSESTestEmail()
+		case state.ViewStacks:
+			return m.toggleStackTemplate()
+		case state.ViewCloudWatchLogs, state.ViewLogSearch, state.ViewActivity:
+			return m.openTimeRangePicker()
+		}
+		return m.handleFirehoseTestPut()
+
+	case matchKey(msg, m.keys.SearchUsers):
+		return m.startUserSearch()
+
+	case matchKey(msg, m.keys.ConfirmUser):
+		switch m.state.View {
+		case state.ViewStacks, state.ViewServices, state.ViewDynamoDB:
+			return m.openActivity()
+		}
+		return m.handleConfirmCognitoUser()
+
+	case matchKey(msg, m.keys.ToggleUser):
+		switch m.state.View {
+		case state.ViewSESSuppressions:
+			return m.handleRemoveSuppression()
+		case state.ViewLogGroups:
+			return m.handleLogGroupDelete()
+		}
+		return m.handleToggleCognitoUser()
+
 	case msg.String() == "s":
-		// Scan DynamoDB table
-		if m.state.View == state.ViewDynamoDB {
+		switch m.state.View {
+		case state.ViewDynamoDB:
+			// Scan DynamoDB table
 			return m.handleDynamoDBScan()
+		case state.ViewLogGroups:
+			return m.openRetentionDialog()
+		case state.ViewTasks, state.ViewTaskContainers:
+			return m.handleShell()
+		}
+
+	case msg.String() == " ":
+		if m.state.View == state.ViewLogGroups {
+			m.toggleLogGroupMark()
+		}
+
+	case msg.String() == "o":
+		if m.state.View == state.ViewLogGroups {
+			m.markOrphanedLogGroups()
 		}
 
 	case matchKey(msg, m.keys.Tunnels):
@@ -227,10 +473,33 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			m.logger.Info("Cleared terminated tunnels")
 		}
 
+	case matchKey(msg, m.keys.ProxyRules):
+		if m.state.View == state.ViewStacks {
+			return m.openStackEvents()
+		}
+		return m.handleEditProxyRules()
+
+	case matchKey(msg, m.keys.ExportTunnel):
+		switch m.state.View {
+		case state.ViewTunnels:
+			return m.handleExportTunnel("")
+		case state.ViewLambdaRuntimes:
+			m.exportRuntimes("")
+		case state.ViewStats:
+			return m.writeProfiles()
+		case state.ViewSQS:
+			return m.openSendMessage()
+		}
+
+	case matchKey(msg, m.keys.ExpandAll), matchKey(msg, m.keys.CollapseAll):
+		// Fold or unfold all groups of a grouped stacks list
+		if m.state.View == state.ViewStacks {
+			m.setAllStackGroups(matchKey(msg, m.keys.CollapseAll))
+		}
+
 	case msg.String() == ":":
 		// Open command palette (k9s-style)
-		m.commandPalette.SetWidth(m.width)
-		return m.commandPalette.Activate()
+		return m.openCommandPalette()
 
 	case matchKey(msg, m.keys.Help):
 		// Show help
@@ -264,31 +533,6 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 			m.logs.ScrollToBottom()
 		}
 
-	case msg.String() == "tab":
-		// Switch to next container in CloudWatch logs view
-		if m.state.View == state.ViewCloudWatchLogs {
-			m.cloudWatchLogsPanel.SelectNextTab()
-			m.state.CloudWatchLastFetchTime = 0
-			m.state.CloudWatchLogs = nil
-			m.cloudWatchLogsPanel.Clear()
-			return m.fetchCloudWatchLogs()
-		}
-		// Toggle focus between list and details in split view
-		if m.getLayoutMode() == layoutFull && m.state.View != state.ViewTunnels &&
-			m.state.View != state.ViewDynamoDBQuery {
-			m.details.SetFocused(!m.details.IsFocused())
-		}
-
-	case msg.String() == "shift+tab":
-		// Switch to previous container in CloudWatch logs view
-		if m.state.View == state.ViewCloudWatchLogs {
-			m.cloudWatchLogsPanel.SelectPrevTab()
-			m.state.CloudWatchLastFetchTime = 0
-			m.state.CloudWatchLogs = nil
-			m.cloudWatchLogsPanel.Clear()
-			return m.fetchCloudWatchLogs()
-		}
-
 	case matchKey(msg, m.keys.CopyMode):
 		// Enter copy mode in full layout (split view)
 		if m.getLayoutMode() == layoutFull {
@@ -300,15 +544,17 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		}
 
 	case matchKey(msg, m.keys.YankClipboard):
-		// Yank details to system clipboard
-		if m.getLayoutMode() == layoutFull {
+		// Yank details to system clipboard, or the whole session timeline
+		if m.state.View == state.ViewTimeline {
+			m.copyTimeline()
+		} else if m.getLayoutMode() == layoutFull {
 			text := m.details.PlainTextView()
 			if text == "" {
 				m.logger.Warn("No details to copy")
 				return nil
 			}
 			if err := copyToClipboard(text); err != nil {
-				m.logger.Warn("Clipboard not available: " + err.Error())
+				m.logger.Warn("Clipboard not available: %v", err)
 				return nil
 			}
 			m.logger.Info("Details copied to clipboard")
@@ -329,16 +575,18 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 		return m.switchToAPIGateway()
 	case msg.String() == "6":
 		return m.switchToStacks()
+	case msg.String() == "7":
+		return m.switchToAppRunner()
 
 	case msg.String() == "n":
 		// Next search match in details (when details focused and has search)
-		if m.details.IsFocused() && m.details.MatchCount() > 0 {
+		if m.focusedPane() == focusDetails && m.details.MatchCount() > 0 {
 			m.details.NextMatch()
 		}
 
 	case msg.String() == "N":
 		// Previous search match in details (when details focused and has search)
-		if m.details.IsFocused() && m.details.MatchCount() > 0 {
+		if m.focusedPane() == focusDetails && m.details.MatchCount() > 0 {
 			m.details.PrevMatch()
 		}
 	}
@@ -350,10 +598,9 @@ func (m *Model) handleKeyMsg(msg tea.KeyMsg) tea.Cmd {
 func (m *Model) handleFilterKey(msg tea.KeyMsg) tea.Cmd {
 	switch {
 	case matchKey(msg, m.keys.FilterAccept):
-		m.state.FilterText = m.filterInput.Value()
 		m.filtering = false
 		m.filterInput.Blur()
-		m.updateCurrentList()
+		m.applyFilter()
 		return nil
 
 	case matchKey(msg, m.keys.FilterClear):
@@ -380,7 +627,11 @@ func (m *Model) handleDetailsSearchKey(msg tea.KeyMsg) tea.Cmd {
 	case matchKey(msg, m.keys.FilterClear):
 		// Clear search and exit
 		m.detailsSearchInput.SetValue("")
-		m.details.ClearSearch()
+		if m.state.View == state.ViewDynamoDBQuery || m.state.View == state.ViewCloudWatchLogs {
+			m.setDetailsSearchQuery("")
+		} else {
+			m.details.ClearSearch()
+		}
 		m.detailsSearching = false
 		m.detailsSearchInput.Blur()
 		return nil
@@ -390,7 +641,7 @@ func (m *Model) handleDetailsSearchKey(msg tea.KeyMsg) tea.Cmd {
 	var cmd tea.Cmd
 	m.detailsSearchInput, cmd = m.detailsSearchInput.Update(msg)
 	// Update search query in details component
-	m.details.SetSearchQuery(m.detailsSearchInput.Value())
+	m.setDetailsSearchQuery(m.detailsSearchInput.Value())
 	return cmd
 }
 
@@ -406,6 +657,12 @@ func (m *Model) handleProfileSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "down", "j":
 		m.profileSelector.Down()
 
+	case "r":
+		return m, m.refreshSSOToken()
+
+	case "l":
+		return m, m.ssoLogin()
+
 	case "enter":
 		// Select the profile and create AWS client
 		selectedProfile := m.profileSelector.SelectedProfile()
@@ -415,13 +672,14 @@ func (m *Model) handleProfileSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		m.logger.Info("Selected profile: %s", selectedProfile)
 		m.awaitingClientCreate = true
+		m.startup = newStartupTimer(time.Now(), !m.fastStart())
 
 		// Create AWS client asynchronously
 		return m, func() tea.Msg {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 
-			client, err := aws.NewClient(ctx, selectedProfile, m.pendingRegion)
+			client, err := m.newClient(ctx, selectedProfile, m.pendingRegion)
 			return clientCreatedMsg{client: client, err: err}
 		}
 	}
@@ -443,6 +701,9 @@ func (m *Model) handleRegionSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "down", "j":
 		m.regionSelector.Down()
 
+	case "p":
+		m.toggleFavoriteRegion()
+
 	case "enter":
 		// Select the region and create new AWS client
 		selectedRegion := m.regionSelector.SelectedRegion()
@@ -459,8 +720,8 @@ func (m *Model) handleRegionSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 
-			client, err := aws.NewClient(ctx, m.state.Profile, selectedRegion)
-			return regionChangedMsg{client: client, region: selectedRegion, err: err}
+			client, base, err := m.regionClient(ctx, selectedRegion)
+			return regionChangedMsg{client: client, base: base, region: selectedRegion, err: err}
 		}
 	}
 
@@ -469,6 +730,7 @@ func (m *Model) handleRegionSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleEnter handles the enter key press based on current view.
 func (m *Model) handleEnter() tea.Cmd {
+	m.recordRecent()
 	switch m.state.View {
 	case state.ViewMain:
 		item := m.mainMenuList.SelectedItem()
@@ -490,8 +752,121 @@ func (m *Model) handleEnter() tea.Cmd {
 			return m.switchToAPIGateway()
 		case "cloudformation-stacks":
 			return m.switchToStacks()
+		case "apprunner-services":
+			return m.switchToAppRunner()
+		case "firehose-streams":
+			return m.switchToFirehose()
+		case "cognito-pools":
+			return m.switchToCognito()
+		case "cloud-resources":
+			return m.switchToResourceTypes()
+		case "msk-clusters":
+			return m.switchToMSK()
+		case "mq-brokers":
+			return m.switchToMQ()
+		case "databases":
+			return m.switchToDatabases()
+		case "ec2-instances":
+			return m.switchToEC2()
+		case "efs-file-systems":
+			return m.switchToEFS()
+		case "schedules":
+			return m.switchToSchedules()
+		case "secrets":
+			return m.switchToSecrets()
+		case "log-groups":
+			return m.switchToLogGroups()
+		case "ses":
+			return m.switchToSES()
+		case "health":
+			return m.openHealth()
+		case "costs":
+			return m.openCosts()
+		}
+		return nil
+	case state.ViewHealth:
+		return m.handleHealthEnter()
+	case state.ViewAlerts:
+		return m.handleAlertsEnter()
+	case state.ViewLambdaRuntimes:
+		// Open the function in the Lambda view
+		item := m.runtimesList.SelectedItem()
+		if item == nil {
+			return nil
+		}
+		m.state.View = state.ViewLambda
+		m.state.FilterText = item.ID
+		m.filterInput.SetValue(item.ID)
+		m.updateLambdaList()
+		return nil
+	case state.ViewSES:
+		item := m.sesList.SelectedItem()
+		if item == nil || item.ID != "suppression" {
+			return nil
+		}
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewSESSuppressions
+		if m.state.SESSuppressions == nil && !m.state.SESSuppressionsLoading {
+			return m.loadSESSuppressions()
 		}
+		m.updateSESSuppressionList()
 		return nil
+	case state.ViewEFS:
+		fs := m.selectedEFSFileSystem()
+		if fs == nil {
+			return nil
+		}
+		m.logger.Info("Loading mount targets, access points and mounts of %s...", fs.ID)
+		return m.loadEFSDetails(fs.ID)
+	case state.ViewMSK:
+		cluster := m.selectedMSKCluster()
+		if cluster == nil {
+			return nil
+		}
+		m.logger.Info("Loading bootstrap brokers for %s", cluster.Name)
+		return m.loadMSKBrokers(cluster.ARN)
+	case state.ViewResourceTypes:
+		item := m.resourceTypeList.SelectedItem()
+		if item == nil {
+			return nil
+		}
+		return m.openResourceType(item.ID)
+	case state.ViewCloudResources:
+		resource := m.selectedCloudResource()
+		if resource == nil {
+			return nil
+		}
+		return m.loadCloudResource(*resource)
+	case state.ViewCognito:
+		pool := m.selectedUserPool()
+		if pool == nil {
+			return nil
+		}
+		m.logger.Info("Loading app clients for %s", pool.Name)
+		return m.loadUserPoolDetails(pool.ID)
+	case state.ViewFirehose:
+		stream := m.selectedDeliveryStream()
+		if stream == nil {
+			return nil
+		}
+		if !stream.HasErrorLogging() {
+			m.logger.Warn("Error logging is not enabled for %s", stream.Name)
+			return nil
+		}
+		m.logger.Info("Loading recent delivery errors for %s", stream.Name)
+		return m.loadDeliveryErrors(*stream)
+	case state.ViewAppRunner:
+		svc := m.selectedAppRunnerService()
+		if svc == nil {
+			return nil
+		}
+		m.logger.Info("Loading recent operations for %s", svc.Name)
+		return m.loadAppRunnerOperations(svc.ARN)
+	case state.ViewServices:
+		return m.openServiceTasks()
+	case state.ViewTasks:
+		return m.openTaskContainers()
 	case state.ViewClusters:
 		item := m.clustersList.SelectedItem()
 		if item == nil {
@@ -508,6 +883,9 @@ func (m *Model) handleEnter() tea.Cmd {
 		}
 		return nil
 	case state.ViewStacks:
+		if m.toggleStackGroup() {
+			return nil
+		}
 		item := m.stacksList.SelectedItem()
 		if item == nil {
 			return nil
@@ -614,6 +992,9 @@ func (m *Model) handleEnter() tea.Cmd {
 				}
 
 				remotePort := container.GetBestPort()
+				if m.pendingRemotePort > 0 {
+					remotePort = m.pendingRemotePort
+				}
 				localPort := m.pendingLocalPort
 				localPortStr := "random"
 				if localPort > 0 {
@@ -627,11 +1008,26 @@ func (m *Model) handleEnter() tea.Cmd {
 				tsk := *task
 				m.state.ClearPendingContainer()
 				m.pendingLocalPort = 0
+				m.pendingRemotePort = 0
 				m.state.View = state.ViewServices
 
 				return m.startTunnelWithPort(svc, tsk, container, remotePort, localPort)
 			}
 		}
+	case state.ViewEndpointSelect:
+		item := m.endpointList.SelectedItem()
+		if item == nil || m.state.PendingEndpointService == nil {
+			return nil
+		}
+		for i, ep := range m.state.PendingEndpoints {
+			if strconv.Itoa(i) == item.ID {
+				service := *m.state.PendingEndpointService
+				m.state.ClearPendingEndpoint()
+				m.state.View = state.ViewServices
+				m.updateServicesList()
+				return m.startDiscoveryTunnel(service, ep)
+			}
+		}
 	}
 	return nil
 }
@@ -640,6 +1036,10 @@ func (m *Model) handleEnter() tea.Cmd {
 func (m *Model) handleBack() {
 	switch m.state.View {
 	case state.ViewStacks:
+		if m.stackTemplate != nil {
+			m.closeStackTemplate()
+			return
+		}
 		// Go back to main menu
 		m.state.View = state.ViewMain
 		m.state.FilterText = ""
@@ -652,6 +1052,12 @@ func (m *Model) handleBack() {
 		m.state.FilterText = ""
 		m.filterInput.SetValue("")
 		m.updateStacksList()
+	case state.ViewStackEvents:
+		m.state.View = state.ViewStacks
+		m.state.ClearStackEvents()
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.updateStacksList()
 	case state.ViewServices:
 		m.state.FilterText = ""
 		m.filterInput.SetValue("")
@@ -703,6 +1109,93 @@ func (m *Model) handleBack() {
 		// Going back to main menu - keep tables cached
 		m.state.View = state.ViewMain
 		m.updateMainMenuList()
+	case state.ViewAppRunner:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		// Going back to main menu - keep services cached
+		m.state.View = state.ViewMain
+		m.updateMainMenuList()
+	case state.ViewFirehose:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		// Going back to main menu - keep streams cached
+		m.state.View = state.ViewMain
+		m.updateMainMenuList()
+	case state.ViewCognito:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		// Going back to main menu - keep pools cached
+		m.state.View = state.ViewMain
+		m.updateMainMenuList()
+	case state.ViewCognitoUsers:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewCognito
+		m.updateUserPoolList()
+	case state.ViewResourceTypes:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewMain
+		m.updateMainMenuList()
+	case state.ViewMSK:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		// Going back to main menu - keep clusters cached
+		m.state.View = state.ViewMain
+		m.updateMainMenuList()
+	case state.ViewMQ, state.ViewDatabases, state.ViewEC2, state.ViewEFS, state.ViewSchedules, state.ViewSecrets, state.ViewLogGroups:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewMain
+		m.updateMainMenuList()
+	case state.ViewSES:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		// Going back to main menu - keep account data cached
+		m.state.View = state.ViewMain
+		m.updateMainMenuList()
+	case state.ViewSESSuppressions:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewSES
+		m.updateSESList()
+	case state.ViewActivity:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = m.state.ActivityReturnView
+		m.state.ClearActivity()
+		m.updateCurrentList()
+	case state.ViewLogSearch:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = m.state.LogSearchReturnView
+		m.state.ClearLogSearch()
+		m.updateCurrentList()
+	case state.ViewHealth:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		// Going back to main menu - keep the summary cached
+		m.state.View = state.ViewMain
+		m.updateMainMenuList()
+	case state.ViewLambdaRuntimes:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewLambda
+		m.updateLambdaList()
+	case state.ViewImages:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewServices
+		m.updateServicesList()
+	case state.ViewAlerts:
+		m.closeAlerts()
+	case state.ViewStats:
+		m.closeStats()
+	case state.ViewTimeline:
+		m.closeTimeline()
+	case state.ViewCloudResources:
+		// Going back to the types - keep resources cached
+		m.switchToResourceTypes()
 	case state.ViewAPIStages:
 		m.state.GoBack()
 		m.state.FilterText = ""
@@ -723,19 +1216,42 @@ func (m *Model) handleBack() {
 		m.filterInput.SetValue("")
 		m.state.ClearPendingContainer()
 		m.pendingLocalPort = 0
+		m.pendingRemotePort = 0
+		m.updateServicesList()
+	case state.ViewEndpointSelect:
+		// Go back to services, clear pending endpoint info
+		m.state.View = state.ViewServices
+		m.state.ClearPendingEndpoint()
+		m.updateServicesList()
+	case state.ViewTasks:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewServices
+		m.state.ClearTasks()
 		m.updateServicesList()
+	case state.ViewTaskContainers:
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewTasks
+		m.updateTasksList()
 	case state.ViewCloudWatchLogs:
-		// Go back to the source view (Lambda or Services), stop streaming
+		// Go back to the source view (Lambda, API stages or Services), stop streaming
 		if m.state.CloudWatchLambdaContext != nil {
 			m.state.View = state.ViewLambda
 			m.updateLambdaList()
+		} else if m.state.CloudWatchAccessLogContext != "" {
+			m.state.View = state.ViewAPIStages
+			m.updateAPIStagesList()
 		} else {
 			m.state.View = state.ViewServices
 			m.updateServicesList()
 		}
+		m.stopLogExport("left the logs")
+		m.stopMergedTail()
 		m.state.CloudWatchLogsStreaming = false
 		m.state.ClearCloudWatchLogs()
 		m.cloudWatchLogsPanel.SetStreaming(false)
+		m.cloudWatchLogsPanel.SetAccessLog(false)
 		m.cloudWatchLogsPanel.Clear()
 	case state.ViewTunnels:
 		// Go back to previous view (stacks or services)
@@ -749,15 +1265,23 @@ func (m *Model) handleBack() {
 
 // handleRefresh handles the refresh key press based on current view.
 func (m *Model) handleRefresh() tea.Cmd {
+	// Resources tagged since are picked up too
+	m.tags.stale = m.state.TagFilter.Key != ""
 	switch m.state.View {
 	case state.ViewStacks:
-		return m.loadStacks()
+		return m.refreshInPlace(m.stacksList, m.loadStacks)
+	case state.ViewStackEvents:
+		return m.refreshInPlace(m.stackEventsList, m.loadStackEvents)
 	case state.ViewServices:
-		return m.loadServices()
+		return m.refreshInPlace(m.serviceList, m.reloadServices)
+	case state.ViewTasks, state.ViewTaskContainers:
+		return m.refreshInPlace(m.tasksList, m.loadTasks)
 	case state.ViewLambda:
-		return m.loadFunctions()
+		// Describe the functions of a stack again rather than reuse them
+		m.lambdas.forget()
+		return m.refreshInPlace(m.lambdaList, m.loadFunctions)
 	case state.ViewAPIGateway:
-		return m.loadAPIs()
+		return m.refreshInPlace(m.apiGatewayList, m.loadAPIs)
 	case state.ViewAPIStages:
 		return m.loadAPIStages()
 	case state.ViewJumpHostSelect:
@@ -765,9 +1289,57 @@ func (m *Model) handleRefresh() tea.Cmd {
 	case state.ViewTunnels:
 		m.updateTunnelsPanel()
 	case state.ViewSQS:
-		return m.loadQueues()
+		return m.refreshInPlace(m.sqsTable, m.loadQueues)
 	case state.ViewDynamoDB:
-		return m.loadTables()
+		return m.refreshInPlace(m.dynamodbTable, m.loadTables)
+	case state.ViewAppRunner:
+		return m.refreshInPlace(m.appRunnerList, m.loadAppRunnerServices)
+	case state.ViewFirehose:
+		return m.refreshInPlace(m.firehoseList, m.loadDeliveryStreams)
+	case state.ViewCognito:
+		return m.refreshInPlace(m.userPoolList, m.loadUserPools)
+	case state.ViewCognitoUsers:
+		return m.refreshInPlace(m.cognitoUserList, m.loadCognitoUsers)
+	case state.ViewMSK:
+		return m.refreshInPlace(m.mskList, m.loadMSKClusters)
+	case state.ViewMQ:
+		return m.refreshInPlace(m.mqList, m.loadMQBrokers)
+	case state.ViewDatabases:
+		return m.refreshInPlace(m.databaseList, m.loadDatabases)
+	case state.ViewEC2:
+		return m.refreshInPlace(m.instanceList, m.loadInstances)
+	case state.ViewEFS:
+		return m.refreshInPlace(m.efsList, m.loadEFSFileSystems)
+	case state.ViewSchedules:
+		return m.refreshInPlace(m.schedulesList, m.loadSchedules)
+	case state.ViewSecrets:
+		return m.refreshInPlace(m.secretsList, m.loadSecrets)
+	case state.ViewLogGroups:
+		return m.refreshInPlace(m.logGroupsList, m.loadLogGroups)
+	case state.ViewSES:
+		return m.refreshInPlace(m.sesList, m.loadSES)
+	case state.ViewSESSuppressions:
+		return m.refreshInPlace(m.sesSuppressionList, m.loadSESSuppressions)
+	case state.ViewActivity:
+		return m.refreshInPlace(m.activityList, m.loadActivity)
+	case state.ViewLogSearch:
+		return m.refreshInPlace(m.logSearchList, m.loadLogSearch)
+	case state.ViewHealth:
+		return m.refreshInPlace(m.healthList, m.loadHealth)
+	case state.ViewLambdaRuntimes:
+		return m.refreshInPlace(m.runtimesList, m.loadFunctions)
+	case state.ViewImages:
+		return m.refreshInPlace(m.imagesTable, m.loadImages)
+	case state.ViewAlerts:
+		return m.checkWatches(true)
+	case state.ViewStats:
+		m.resetStats()
+		return nil
+	case state.ViewResourceTypes:
+		// Pick up types added to the config file
+		return m.switchToResourceTypes()
+	case state.ViewCloudResources:
+		return m.refreshInPlace(m.cloudResourceList, m.loadCloudResources)
 	}
 	return nil
 }
@@ -779,8 +1351,13 @@ func (m *Model) handleCloudWatchLogs() tea.Cmd {
 		return m.handleLambdaCloudWatchLogs()
 	}
 
-	// Only works in Services view
-	if m.state.View != state.ViewServices {
+	// Handle API Gateway stages view
+	if m.state.View == state.ViewAPIStages {
+		return m.handleAccessLogs()
+	}
+
+	// Only works in Services view
+	if m.state.View != state.ViewServices {
 		m.logger.Debug("CloudWatch logs: only available in services view")
 		return nil
 	}
@@ -872,17 +1449,22 @@ func (m *Model) handleLambdaCloudWatchLogs() tea.Cmd {
 		LogStreamName: "", // Lambda logs query across all streams
 	}
 
+	m.stopLogExport("")
+	m.stopMergedTail()
 	m.state.ClearCloudWatchLogs()
 	m.state.CloudWatchLogConfigs = []model.ContainerLogConfig{config}
 	m.state.CloudWatchLambdaContext = &fn
 	m.state.View = state.ViewCloudWatchLogs
 	m.state.CloudWatchLogsStreaming = true
-	m.state.CloudWatchLastFetchTime = 0
+	m.state.CloudWatchLastFetchTime = m.cloudWatchStartTime(0)
+	m.cloudWatchLogsSeq++
 
 	m.cloudWatchLogsPanel.SetContainers([]model.ContainerLogConfig{config})
 	m.cloudWatchLogsPanel.SetContext(fn.Name, "Lambda")
+	m.cloudWatchLogsPanel.SetRange(m.cloudWatchRangeLabel())
 	m.cloudWatchLogsPanel.SetStreaming(true)
 	m.cloudWatchLogsPanel.Clear()
+	m.cloudWatchLogsPanel.ClearSearch()
 
 	// Start fetching logs and spinner animation
 	return tea.Batch(
@@ -892,12 +1474,102 @@ func (m *Model) handleLambdaCloudWatchLogs() tea.Cmd {
 	)
 }
 
+// accessLogWindow is how far back the access logs of a stage start without a
+// time range chosen for CloudWatch logs.
+const accessLogWindow = 15 * time.Minute
+
+// handleAccessLogs tails the access logs of the selected API Gateway stage,
+// starting accessLogWindow ago.
+func (m *Model) handleAccessLogs() tea.Cmd {
+	item := m.apiStagesList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	var stage *model.APIStage
+	for i := range m.state.APIStages {
+		if m.state.APIStages[i].Name == item.ID {
+			stage = &m.state.APIStages[i]
+		}
+	}
+	if stage == nil {
+		return nil
+	}
+	if stage.AccessLogGroup == "" {
+		m.logger.Warn("Stage %s has no access logging to CloudWatch", stage.Name)
+		return nil
+	}
+
+	api := ""
+	if m.state.SelectedRestAPI != nil {
+		api = m.state.SelectedRestAPI.Name
+	} else if m.state.SelectedHttpAPI != nil {
+		api = m.state.SelectedHttpAPI.Name
+	}
+	label := api + "/" + stage.Name
+	m.logger.Info("Tailing access logs of %s from %s", label, stage.AccessLogGroup)
+
+	config := model.ContainerLogConfig{
+		ContainerName: label,
+		LogGroup:      stage.AccessLogGroup,
+	}
+	m.stopLogExport("")
+	m.stopMergedTail()
+	m.state.ClearCloudWatchLogs()
+	m.state.CloudWatchLogConfigs = []model.ContainerLogConfig{config}
+	m.state.CloudWatchAccessLogContext = label
+	m.state.View = state.ViewCloudWatchLogs
+	m.state.CloudWatchLogsStreaming = true
+	m.state.CloudWatchLastFetchTime = m.cloudWatchStartTime(time.Now().Add(-accessLogWindow).UnixMilli())
+	m.cloudWatchLogsSeq++
+
+	m.cloudWatchLogsPanel.SetContainers([]model.ContainerLogConfig{config})
+	m.cloudWatchLogsPanel.SetContext(label, "access log")
+	m.cloudWatchLogsPanel.SetRange(m.cloudWatchRangeLabel())
+	m.cloudWatchLogsPanel.SetAccessLog(true)
+	m.cloudWatchLogsPanel.SetStreaming(true)
+	m.cloudWatchLogsPanel.Clear()
+	m.cloudWatchLogsPanel.ClearSearch()
+
+	return tea.Batch(
+		m.fetchLambdaCloudWatchLogs(stage.AccessLogGroup),
+		m.cloudWatchLogsPanel.TickCmd(),
+		m.cloudWatchLogsPanel.SpinnerTickCmd(),
+	)
+}
+
 // handlePortForward handles the port forward key press.
 func (m *Model) handlePortForward() tea.Cmd {
-	// Handle API Gateway stages view
+	// Public API Gateway proxies run in vaws; private ones check for the AWS
+	// CLI once the API is known to be private
 	if m.state.View == state.ViewAPIStages {
+		if !m.checkProfileAllows(config.ActionTunnel) {
+			return nil
+		}
 		return m.handleAPIGatewayPortForward()
 	}
+	if !m.checkActionAllowed(config.ActionTunnel) {
+		return nil
+	}
+
+	// Handle MSK clusters view
+	if m.state.View == state.ViewMSK {
+		return m.handleMSKPortForward()
+	}
+
+	// Handle MQ brokers view
+	if m.state.View == state.ViewMQ {
+		return m.handleMQPortForward()
+	}
+
+	// Handle databases view
+	if m.state.View == state.ViewDatabases {
+		return m.handleDatabasePortForward()
+	}
+
+	// Handle EC2 instances view
+	if m.state.View == state.ViewEC2 {
+		return m.handleInstancePortForward()
+	}
 
 	// From tunnels view, if we have services loaded, show port input for selected service
 	if m.state.View == state.ViewTunnels {
@@ -909,10 +1581,7 @@ func (m *Model) handlePortForward() tea.Cmd {
 					if m.state.Services[i].Name == item.ID {
 						selectedService := &m.state.Services[i]
 						if selectedService.ClusterARN != "" {
-							m.pendingPortForward = selectedService
-							m.enteringPort = true
-							m.portInput.SetValue("")
-							m.portInput.Focus()
+							m.startServicePortInput(selectedService)
 							return textinput.Blink
 						}
 						break
@@ -963,18 +1632,74 @@ func (m *Model) handlePortForward() tea.Cmd {
 	}
 
 	// Start port input mode
-	m.pendingPortForward = selectedService
-	m.enteringPort = true
-	m.portInput.SetValue("")
-	m.portInput.Focus()
+	m.startServicePortInput(selectedService)
 
 	return textinput.Blink
 }
 
+// handleDiscoveryTunnel starts a tunnel to one of the selected service's
+// Service Connect or Cloud Map endpoints, asking which one if there are several.
+func (m *Model) handleDiscoveryTunnel() tea.Cmd {
+	if m.state.View != state.ViewServices {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionTunnel) {
+		return nil
+	}
+
+	item := m.serviceList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+
+	var service *model.Service
+	for i := range m.state.Services {
+		if m.state.Services[i].Name == item.ID {
+			service = &m.state.Services[i]
+			break
+		}
+	}
+	if service == nil {
+		return nil
+	}
+
+	var endpoints []model.DiscoveryEndpoint
+	for _, ep := range service.DiscoveryEndpoints {
+		if ep.Address() != "" {
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	switch len(endpoints) {
+	case 0:
+		m.logger.Warn("Service '%s' has no Service Connect or Cloud Map endpoints with a DNS name", service.Name)
+		return nil
+	case 1:
+		return m.startDiscoveryTunnel(*service, endpoints[0])
+	}
+
+	m.logger.Info("Found %d endpoints - select one to tunnel to", len(endpoints))
+	m.state.PendingEndpointService = service
+	m.state.PendingEndpoints = endpoints
+	m.state.View = state.ViewEndpointSelect
+	m.updateEndpointList()
+	return nil
+}
+
 // handlePortInputKey handles key messages when entering a port number.
 func (m *Model) handlePortInputKey(msg tea.KeyMsg) tea.Cmd {
 	switch msg.String() {
 	case "enter":
+		// Handle EC2 instance port forward, whose input names the remote port too
+		if m.pendingInstance != nil {
+			inst := *m.pendingInstance
+			m.enteringPort = false
+			m.portInput.Blur()
+			m.portInput.CharLimit = 5
+			m.pendingInstance = nil
+			return m.startInstanceTunnel(inst, m.portInput.Value())
+		}
+
 		// Parse port from input
 		portStr := m.portInput.Value()
 		localPort := 0 // 0 means random
@@ -986,60 +1711,603 @@ func (m *Model) handlePortInputKey(msg tea.KeyMsg) tea.Cmd {
 				m.enteringPort = false
 				m.portInput.Blur()
 				m.pendingPortForward = nil
+				m.portOptions = nil
 				m.pendingAPIGWPortForward = nil
 				m.pendingAPIGWAPI = nil
+				m.pendingAPIGWMock = false
+				m.pendingDatabase = nil
 				return nil
 			}
 		}
 
+		// Handle database port forward
+		if m.pendingDatabase != nil {
+			db := *m.pendingDatabase
+			m.enteringPort = false
+			m.portInput.Blur()
+			m.pendingDatabase = nil
+
+			return m.findDatabaseTunnelTarget(db, localPort)
+		}
+
 		// Handle API Gateway port forward
 		if m.pendingAPIGWPortForward != nil {
 			stage := m.pendingAPIGWPortForward
 			api := m.pendingAPIGWAPI
+			mock := m.pendingAPIGWMock
 			m.enteringPort = false
 			m.portInput.Blur()
 			m.pendingAPIGWPortForward = nil
 			m.pendingAPIGWAPI = nil
+			m.pendingAPIGWMock = false
 
+			if mock {
+				return m.startAPIGatewayMock(api, *stage, localPort)
+			}
 			return m.startAPIGatewayTunnel(api, *stage, localPort)
 		}
 
-		// Store the port and start loading tasks for ECS service
+		// Store the ports and start loading tasks for ECS service
 		service := m.pendingPortForward
+		var remote portOption
+		if m.portOptionIdx < len(m.portOptions) {
+			remote = m.portOptions[m.portOptionIdx]
+		}
+		m.enteringPort = false
+		m.portInput.Blur()
+		m.pendingPortForward = nil
+		m.portOptions = nil
+
+		if service == nil {
+			return nil
+		}
+
+		m.logger.Info("Loading tasks for service: %s (cluster: %s)", service.Name, service.ClusterName)
+
+		// Store the requested local port in context for later use
+		requestedPort := localPort
+
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			tasks, err := m.client.ListTasksForService(ctx, service.ClusterARN, service.Name)
+			return tasksLoadedMsgWithPort{
+				service:       *service,
+				tasks:         tasks,
+				err:           err,
+				localPort:     requestedPort,
+				remotePort:    remote.port,
+				containerName: remote.container,
+			}
+		}
+
+	case "up", "down":
+		// Move through the remote port picker
+		if len(m.portOptions) > 0 {
+			if msg.String() == "up" {
+				m.portOptionIdx = (m.portOptionIdx + len(m.portOptions) - 1) % len(m.portOptions)
+			} else {
+				m.portOptionIdx = (m.portOptionIdx + 1) % len(m.portOptions)
+			}
+		}
+		return nil
+
+	case "esc":
 		m.enteringPort = false
 		m.portInput.Blur()
 		m.pendingPortForward = nil
+		m.portOptions = nil
+		m.pendingAPIGWPortForward = nil
+		m.pendingAPIGWAPI = nil
+		m.pendingAPIGWMock = false
+		m.pendingDatabase = nil
+		m.pendingInstance = nil
+		m.portInput.CharLimit = 5
+		return nil
+	}
+
+	// Pass other keys to the input
+	var cmd tea.Cmd
+	m.portInput, cmd = m.portInput.Update(msg)
+	return cmd
+}
+
+// selectedAppRunnerService returns the App Runner service under the cursor.
+func (m *Model) selectedAppRunnerService() *model.AppRunnerService {
+	item := m.appRunnerList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.AppRunnerServices {
+		if m.state.AppRunnerServices[i].ARN == item.ID {
+			return &m.state.AppRunnerServices[i]
+		}
+	}
+	return nil
+}
+
+// handleAppRunnerPauseResume pauses a running App Runner service or resumes a paused one.
+func (m *Model) handleAppRunnerPauseResume() tea.Cmd {
+	if m.state.View != state.ViewAppRunner {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	svc := m.selectedAppRunnerService()
+	if svc == nil {
+		return nil
+	}
+
+	arn, name := svc.ARN, svc.Name
+	switch svc.Status {
+	case model.AppRunnerStatusRunning:
+		return m.askConfirm("Pause service (stops serving traffic)", []string{"Service: " + name}, func() tea.Cmd {
+			m.logger.Info("Pausing App Runner service: %s", name)
+			return func() tea.Msg {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				err := m.client.PauseAppRunnerService(ctx, arn)
+				return appRunnerActionMsg{serviceName: name, action: "pause", err: err}
+			}
+		})
+	case model.AppRunnerStatusPaused:
+		return m.askConfirm("Resume service", []string{"Service: " + name}, func() tea.Cmd {
+			m.logger.Info("Resuming App Runner service: %s", name)
+			return func() tea.Msg {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				err := m.client.ResumeAppRunnerService(ctx, arn)
+				return appRunnerActionMsg{serviceName: name, action: "resume", err: err}
+			}
+		})
+	default:
+		m.logger.Warn("Cannot pause or resume %s while it is %s", name, svc.Status)
+		return nil
+	}
+}
+
+// handleAppRunnerDeploy starts a manual deployment of the selected App Runner service.
+func (m *Model) handleAppRunnerDeploy() tea.Cmd {
+	if m.state.View != state.ViewAppRunner {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	svc := m.selectedAppRunnerService()
+	if svc == nil {
+		return nil
+	}
+	if svc.Status != model.AppRunnerStatusRunning {
+		m.logger.Warn("Cannot deploy %s while it is %s", svc.Name, svc.Status)
+		return nil
+	}
+
+	arn, name := svc.ARN, svc.Name
+	return m.askConfirm("Start deployment", []string{"Service: " + name}, func() tea.Cmd {
+		m.logger.Info("Starting deployment for App Runner service: %s", name)
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			_, err := m.client.StartAppRunnerDeployment(ctx, arn)
+			return appRunnerActionMsg{serviceName: name, action: "deployment", err: err}
+		}
+	})
+}
+
+// selectedDeliveryStream returns the Firehose delivery stream under the cursor.
+func (m *Model) selectedDeliveryStream() *model.DeliveryStream {
+	item := m.firehoseList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.DeliveryStreams {
+		if m.state.DeliveryStreams[i].Name == item.ID {
+			return &m.state.DeliveryStreams[i]
+		}
+	}
+	return nil
+}
+
+// handleFirehoseTestPut sends a sample record to the selected delivery stream.
+func (m *Model) handleFirehoseTestPut() tea.Cmd {
+	if m.state.View != state.ViewFirehose {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	stream := m.selectedDeliveryStream()
+	if stream == nil {
+		return nil
+	}
+	if stream.Status != model.DeliveryStreamStatusActive {
+		m.logger.Warn("Cannot put records to %s while it is %s", stream.Name, stream.Status)
+		return nil
+	}
+
+	name := stream.Name
+	testID := fmt.Sprintf("vaws-test-%x", time.Now().UnixNano())
+	destination := stream.Destination
+	if stream.Target != "" {
+		destination += " " + stream.Target
+	}
+	return m.askConfirm("Put test record (it will reach the destination)", []string{
+		"Stream: " + name,
+		"Destination: " + valueOrDash(destination),
+		"Test ID: " + testID + " (record ID shown after put)",
+	}, func() tea.Cmd {
+		m.logger.Info("Putting test record %s to %s", testID, name)
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			recordID, err := m.client.PutTestRecord(ctx, name, testID)
+			return testRecordPutMsg{streamName: name, testID: testID, recordID: recordID, err: err}
+		}
+	})
+}
+
+// cognitoActionDone describes completed Cognito user actions for the log.
+var cognitoActionDone = map[string]string{
+	"confirm": "Confirmed",
+	"enable":  "Enabled",
+	"disable": "Disabled",
+}
+
+// selectedUserPool returns the Cognito user pool under the cursor.
+func (m *Model) selectedUserPool() *model.UserPool {
+	item := m.userPoolList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.UserPools {
+		if m.state.UserPools[i].ID == item.ID {
+			return &m.state.UserPools[i]
+		}
+	}
+	return nil
+}
+
+// selectedCognitoUser returns the Cognito user under the cursor.
+func (m *Model) selectedCognitoUser() *model.CognitoUser {
+	item := m.cognitoUserList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.CognitoUsers {
+		if m.state.CognitoUsers[i].Username == item.ID {
+			return &m.state.CognitoUsers[i]
+		}
+	}
+	return nil
+}
+
+// startUserSearch opens the user search dialog for the selected pool, or the
+// pool of the current search in the users view.
+func (m *Model) startUserSearch() tea.Cmd {
+	var pool *model.UserPool
+	switch m.state.View {
+	case state.ViewCognito:
+		pool = m.selectedUserPool()
+	case state.ViewCognitoUsers:
+		pool = m.state.SelectedUserPool
+	}
+	if pool == nil {
+		return nil
+	}
+
+	m.searchingUsers = true
+	m.pendingSearchPool = pool
+	m.userSearchInput.SetValue("")
+	m.userSearchInput.Focus()
+	return textinput.Blink
+}
+
+// handleUserSearchInputKey handles key messages when entering a user search.
+func (m *Model) handleUserSearchInputKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		query := strings.TrimSpace(m.userSearchInput.Value())
+		pool := m.pendingSearchPool
+
+		m.searchingUsers = false
+		m.userSearchInput.Blur()
+		m.pendingSearchPool = nil
+
+		if pool == nil || query == "" {
+			return nil
+		}
+
+		m.state.SelectedUserPool = pool
+		m.state.CognitoUserQuery = query
+		m.state.CognitoUsers = nil
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.state.View = state.ViewCognitoUsers
+		return m.loadCognitoUsers()
+
+	case "esc":
+		m.searchingUsers = false
+		m.userSearchInput.Blur()
+		m.pendingSearchPool = nil
+		return nil
+	}
+
+	// Pass other keys to the input
+	var cmd tea.Cmd
+	m.userSearchInput, cmd = m.userSearchInput.Update(msg)
+	return cmd
+}
+
+// handleConfirmCognitoUser confirms the sign-up of the selected user.
+func (m *Model) handleConfirmCognitoUser() tea.Cmd {
+	if m.state.View != state.ViewCognitoUsers {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	user := m.selectedCognitoUser()
+	if user == nil || m.state.SelectedUserPool == nil {
+		return nil
+	}
+	if user.Status != model.CognitoUserStatusUnconfirmed {
+		m.logger.Warn("%s is already %s", user.Username, user.Status)
+		return nil
+	}
+
+	pool, username := *m.state.SelectedUserPool, user.Username
+	return m.askConfirm("Confirm sign-up", []string{
+		"User: " + username,
+		"Pool: " + pool.Name + " (" + pool.ID + ")",
+	}, func() tea.Cmd {
+		m.logger.Info("Confirming user: %s", username)
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := m.client.ConfirmUser(ctx, pool.ID, username)
+			return cognitoUserActionMsg{username: username, action: "confirm", err: err}
+		}
+	})
+}
+
+// handleToggleCognitoUser disables an enabled user or enables a disabled one.
+func (m *Model) handleToggleCognitoUser() tea.Cmd {
+	if m.state.View != state.ViewCognitoUsers {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	user := m.selectedCognitoUser()
+	if user == nil || m.state.SelectedUserPool == nil {
+		return nil
+	}
+
+	pool, username, enable := *m.state.SelectedUserPool, user.Username, !user.Enabled
+	action, title := "disable", "Disable user (blocks sign-in)"
+	if enable {
+		action, title = "enable", "Enable user"
+	}
+	return m.askConfirm(title, []string{
+		"User: " + username,
+		"Pool: " + pool.Name + " (" + pool.ID + ")",
+	}, func() tea.Cmd {
+		m.logger.Info("Requesting %s for user: %s", action, username)
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := m.client.SetUserEnabled(ctx, pool.ID, username, enable)
+			return cognitoUserActionMsg{username: username, action: action, err: err}
+		}
+	})
+}
+
+// selectedSESIdentity returns the SES identity under the cursor.
+func (m *Model) selectedSESIdentity() *model.SESIdentity {
+	item := m.sesList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	name, ok := strings.CutPrefix(item.ID, "identity:")
+	if !ok {
+		return nil
+	}
+	for i := range m.state.SESIdentities {
+		if m.state.SESIdentities[i].Name == name {
+			return &m.state.SESIdentities[i]
+		}
+	}
+	return nil
+}
+
+// selectedSESConfigurationSet returns the SES configuration set under the cursor.
+func (m *Model) selectedSESConfigurationSet() *model.SESConfigurationSet {
+	item := m.sesList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	name, ok := strings.CutPrefix(item.ID, "configset:")
+	if !ok {
+		return nil
+	}
+	for i := range m.state.SESConfigurationSets {
+		if m.state.SESConfigurationSets[i].Name == name {
+			return &m.state.SESConfigurationSets[i]
+		}
+	}
+	return nil
+}
+
+// selectedSESSuppression returns the suppressed address under the cursor.
+func (m *Model) selectedSESSuppression() *model.SESSuppressedDestination {
+	item := m.sesSuppressionList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.SESSuppressions {
+		if m.state.SESSuppressions[i].Email == item.ID {
+			return &m.state.SESSuppressions[i]
+		}
+	}
+	return nil
+}
+
+// startSESTestEmail opens the recipient dialog for a test email from the
+// selected identity.
+func (m *Model) startSESTestEmail() tea.Cmd {
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	identity := m.selectedSESIdentity()
+	if identity == nil {
+		return nil
+	}
+	if !identity.IsVerified() {
+		m.logger.Warn("Cannot send from %s until it is verified (%s)", identity.Name, identity.VerificationStatus)
+		return nil
+	}
+
+	m.enteringSESRecipient = true
+	m.pendingSESFrom = aws.SESTestSender(*identity)
+	m.sesRecipientInput.SetValue("")
+	m.sesRecipientInput.Focus()
+	return textinput.Blink
+}
+
+// handleSESRecipientInputKey handles key messages when entering the test email recipient.
+func (m *Model) handleSESRecipientInputKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		to := strings.TrimSpace(m.sesRecipientInput.Value())
+		if to == "" {
+			to = m.sesRecipientInput.Placeholder
+		}
+		from := m.pendingSESFrom
 
-		if service == nil {
+		m.enteringSESRecipient = false
+		m.sesRecipientInput.Blur()
+		m.pendingSESFrom = ""
+
+		if from == "" {
 			return nil
 		}
+		return m.askConfirm("Send test email", []string{
+			"From: " + from,
+			"To: " + to,
+		}, func() tea.Cmd {
+			m.logger.Info("Sending test email from %s to %s", from, to)
+			return func() tea.Msg {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				messageID, err := m.client.SendTestEmail(ctx, from, to)
+				return sesTestEmailSentMsg{from: from, to: to, messageID: messageID, err: err}
+			}
+		})
 
-		m.logger.Info("Loading tasks for service: %s (cluster: %s)", service.Name, service.ClusterName)
+	case "esc":
+		m.enteringSESRecipient = false
+		m.sesRecipientInput.Blur()
+		m.pendingSESFrom = ""
+		return nil
+	}
 
-		// Store the requested local port in context for later use
-		requestedPort := localPort
+	// Pass other keys to the input
+	var cmd tea.Cmd
+	m.sesRecipientInput, cmd = m.sesRecipientInput.Update(msg)
+	return cmd
+}
+
+// handleRemoveSuppression removes the selected address from the suppression list.
+func (m *Model) handleRemoveSuppression() tea.Cmd {
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	dest := m.selectedSESSuppression()
+	if dest == nil {
+		return nil
+	}
 
+	email := dest.Email
+	return m.askConfirm("Remove from suppression list (SES will send to it again)", []string{
+		"Address: " + email,
+		"Reason: " + dest.Reason,
+		"Suppressed: " + format.Time(dest.LastUpdated),
+	}, func() tea.Cmd {
+		m.logger.Info("Removing %s from the suppression list", email)
 		return func() tea.Msg {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
+			err := m.client.RemoveSuppressedDestination(ctx, email)
+			return sesSuppressionRemovedMsg{email: email, err: err}
+		}
+	})
+}
 
-			tasks, err := m.client.ListTasksForService(ctx, service.ClusterARN, service.Name)
-			return tasksLoadedMsgWithPort{service: *service, tasks: tasks, err: err, localPort: requestedPort}
+// selectedEFSFileSystem returns the EFS file system under the cursor.
+func (m *Model) selectedEFSFileSystem() *model.EFSFileSystem {
+	item := m.efsList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.EFSFileSystems {
+		if m.state.EFSFileSystems[i].ID == item.ID {
+			return &m.state.EFSFileSystems[i]
 		}
+	}
+	return nil
+}
 
-	case "esc":
-		m.enteringPort = false
-		m.portInput.Blur()
-		m.pendingPortForward = nil
-		m.pendingAPIGWPortForward = nil
-		m.pendingAPIGWAPI = nil
+// selectedMSKCluster returns the MSK cluster under the cursor.
+func (m *Model) selectedMSKCluster() *model.MSKCluster {
+	item := m.mskList.SelectedItem()
+	if item == nil {
 		return nil
 	}
+	for i := range m.state.MSKClusters {
+		if m.state.MSKClusters[i].ARN == item.ID {
+			return &m.state.MSKClusters[i]
+		}
+	}
+	return nil
+}
 
-	// Pass other keys to the input
-	var cmd tea.Cmd
-	m.portInput, cmd = m.portInput.Update(msg)
-	return cmd
+// handleMSKPortForward opens tunnels to the bootstrap brokers of the selected
+// MSK cluster through a jump host in the cluster's VPC.
+func (m *Model) handleMSKPortForward() tea.Cmd {
+	cluster := m.selectedMSKCluster()
+	if cluster == nil {
+		return nil
+	}
+	if len(cluster.ClientSubnets) == 0 {
+		m.logger.Warn("MSK cluster %s has no client subnets", cluster.Name)
+		return nil
+	}
+	m.logger.Info("Finding a jump host for %s...", cluster.Name)
+	return m.findMSKTunnelTarget(*cluster)
+}
+
+// selectedCloudResource returns the Cloud Control resource under the cursor.
+func (m *Model) selectedCloudResource() *model.CloudResource {
+	item := m.cloudResourceList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.CloudResources {
+		if m.state.CloudResources[i].Identifier == item.ID {
+			return &m.state.CloudResources[i]
+		}
+	}
+	return nil
 }
 
 // handleLambdaInvoke handles the Lambda invoke key press.
@@ -1047,6 +2315,9 @@ func (m *Model) handleLambdaInvoke() tea.Cmd {
 	if m.state.View != state.ViewLambda {
 		return nil
 	}
+	if !m.checkActionAllowed(config.ActionInvoke) {
+		return nil
+	}
 
 	item := m.lambdaList.SelectedItem()
 	if item == nil {
@@ -1113,6 +2384,13 @@ func (m *Model) handlePayloadInputKey(msg tea.KeyMsg) tea.Cmd {
 		m.payloadInput.Blur()
 		m.pendingInvokeFunction = nil
 		return nil
+
+	case "ctrl+o":
+		payload := m.payloadInput.Value()
+		if payload == "" {
+			payload = "{}"
+		}
+		return m.openEditor(editLambdaPayload, prettyJSON(payload), ".json")
 	}
 
 	// Pass other keys to the input
@@ -1132,6 +2410,8 @@ func (m *Model) handleStopTunnel() tea.Cmd {
 	ecsTunnel := m.tunnelsPanel.SelectedTunnel()
 	if ecsTunnel != nil {
 		m.logger.Info("Stopping ECS tunnel: %s", ecsTunnel.ID)
+		m.recordTimeline(timelineTunnel, "Stopped tunnel %s (localhost:%d)", ecsTunnel.ID, ecsTunnel.LocalPort)
+		m.forgetTunnel(ecsTunnel.ID)
 		if err := m.tunnelManager.StopTunnel(ecsTunnel.ID); err != nil {
 			m.logger.Error("Failed to stop tunnel: %v", err)
 		}
@@ -1143,6 +2423,8 @@ func (m *Model) handleStopTunnel() tea.Cmd {
 	apiGWTunnel := m.tunnelsPanel.SelectedAPIGatewayTunnel()
 	if apiGWTunnel != nil {
 		m.logger.Info("Stopping API Gateway tunnel: %s", apiGWTunnel.ID)
+		m.recordTimeline(timelineTunnel, "Stopped API Gateway tunnel %s (localhost:%d)", apiGWTunnel.ID, apiGWTunnel.LocalPort)
+		m.forgetTunnel(apiGWTunnel.ID)
 		if err := m.apiGWManager.StopTunnel(apiGWTunnel.ID); err != nil {
 			m.logger.Error("Failed to stop API Gateway tunnel: %v", err)
 		}
@@ -1153,12 +2435,78 @@ func (m *Model) handleStopTunnel() tea.Cmd {
 	return nil
 }
 
+// handleEditProxyRules opens the proxy rules dialog for the selected API Gateway tunnel.
+func (m *Model) handleEditProxyRules() tea.Cmd {
+	// Only works in tunnels view
+	if m.state.View != state.ViewTunnels {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionTunnel) {
+		return nil
+	}
+
+	apiGWTunnel := m.tunnelsPanel.SelectedAPIGatewayTunnel()
+	if apiGWTunnel == nil {
+		m.logger.Warn("Proxy rules are only available for API Gateway tunnels")
+		return nil
+	}
+	if apiGWTunnel.TunnelType == model.APIGatewayTunnelMock {
+		m.logger.Warn("Mock servers forward no requests; configure their responses under mocks in the config")
+		return nil
+	}
+
+	m.pendingRulesTunnel = apiGWTunnel.ID
+	m.editingProxyRules = true
+	m.proxyRulesInput.SetValue(tunnel.FormatProxyRules(apiGWTunnel.Rules))
+	m.proxyRulesInput.CursorEnd()
+	m.proxyRulesInput.Focus()
+
+	return textinput.Blink
+}
+
+// handleProxyRulesInputKey handles key messages when editing proxy rules.
+func (m *Model) handleProxyRulesInputKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		tunnelID := m.pendingRulesTunnel
+		m.editingProxyRules = false
+		m.proxyRulesInput.Blur()
+		m.pendingRulesTunnel = ""
+
+		rules, err := tunnel.ParseProxyRules(m.proxyRulesInput.Value())
+		if err != nil {
+			m.logger.Error("Invalid proxy rules: %v", err)
+			return nil
+		}
+		if err := m.apiGWManager.SetTunnelRules(tunnelID, rules); err != nil {
+			m.logger.Error("Failed to update proxy rules: %v", err)
+			return nil
+		}
+		m.updateTunnelsPanel()
+		return nil
+
+	case "esc":
+		m.editingProxyRules = false
+		m.proxyRulesInput.Blur()
+		m.pendingRulesTunnel = ""
+		return nil
+	}
+
+	// Pass other keys to the input
+	var cmd tea.Cmd
+	m.proxyRulesInput, cmd = m.proxyRulesInput.Update(msg)
+	return cmd
+}
+
 // handleRestartTunnel handles restarting a tunnel.
 func (m *Model) handleRestartTunnel() tea.Cmd {
 	// Only works in tunnels view
 	if m.state.View != state.ViewTunnels {
 		return nil
 	}
+	if !m.checkActionAllowed(config.ActionTunnel) {
+		return nil
+	}
 
 	tunnel := m.tunnelsPanel.SelectedTunnel()
 	if tunnel == nil {
@@ -1171,6 +2519,18 @@ func (m *Model) handleRestartTunnel() tea.Cmd {
 		return nil
 	}
 
+	// Jump host tunnels restart against the same instance
+	if tunnel.JumpHostID != "" {
+		info, err := m.tunnelManager.PrepareRestart(tunnel.ID)
+		if err != nil {
+			m.logger.Error("Failed to prepare tunnel restart: %v", err)
+			return nil
+		}
+		m.logger.Info("Restarting tunnel '%s' via %s...", info.ID, info.JumpHostID)
+		jumpHost := model.EC2Instance{InstanceID: info.JumpHostID, Name: info.JumpHostName}
+		return m.startJumpHostTunnel(jumpHost, info.ServiceName, info.RemoteHost, info.RemotePort, info.LocalPort)
+	}
+
 	// Check if we have the cluster ARN needed to fetch tasks
 	if tunnel.ClusterARN == "" {
 		m.logger.Error("Cannot restart tunnel '%s': missing cluster ARN (tunnel was created in an older version)", tunnel.ID)
@@ -1258,10 +2618,7 @@ func (m *Model) handleDynamoDBQuery() tea.Cmd {
 	m.state.SelectTable(table)
 	m.logger.Info("Opening query dialog for table: %s", table.Name)
 
-	// Set size for dialog
-	m.dynamodbQueryDialog.SetSize(m.width, m.height)
-
-	return m.dynamodbQueryDialog.Activate(table.Name, table.PartitionKey(), table.SortKey(), true)
+	return m.openQueryDialog(table, true)
 }
 
 // handleDynamoDBScan opens the scan dialog for the selected table.
@@ -1279,10 +2636,7 @@ func (m *Model) handleDynamoDBScan() tea.Cmd {
 	m.state.SelectTable(table)
 	m.logger.Info("Opening scan dialog for table: %s", table.Name)
 
-	// Set size for dialog
-	m.dynamodbQueryDialog.SetSize(m.width, m.height)
-
-	return m.dynamodbQueryDialog.Activate(table.Name, table.PartitionKey(), table.SortKey(), false)
+	return m.openQueryDialog(table, false)
 }
 
 // handleDynamoDBQueryDialogKey handles key presses when the query dialog is active.
@@ -1293,37 +2647,59 @@ func (m *Model) handleDynamoDBQueryDialogKey(msg tea.KeyMsg) tea.Cmd {
 			m.logger.Debug("Query dialog cancelled")
 			return nil
 		}
-
-		// Execute the query or scan
-		if result.QueryParams != nil {
-			m.state.DynamoDBQueryParams = result.QueryParams
-			m.state.DynamoDBScanParams = nil
-			m.state.DynamoDBIsQuery = true
-			m.state.DynamoDBQueryLoading = true
-			m.state.DynamoDBLastKey = nil
-			m.state.View = state.ViewDynamoDBQuery
-			m.dynamodbQueryResults.SetLoading(true)
-			m.dynamodbQueryResults.Clear()
-			m.logger.Info("Executing query on table: %s (PK: %s)", result.QueryParams.TableName, result.QueryParams.PartitionKeyVal)
-			return m.executeDynamoDBQuery(result.QueryParams)
-		} else if result.ScanParams != nil {
-			m.state.DynamoDBQueryParams = nil
-			m.state.DynamoDBScanParams = result.ScanParams
-			m.state.DynamoDBIsQuery = false
-			m.state.DynamoDBQueryLoading = true
-			m.state.DynamoDBLastKey = nil
-			m.state.View = state.ViewDynamoDBQuery
-			m.dynamodbQueryResults.SetLoading(true)
-			m.dynamodbQueryResults.Clear()
-			m.logger.Info("Executing scan on table: %s", result.ScanParams.TableName)
-			return m.executeDynamoDBScan(result.ScanParams)
-		}
+		return m.runDynamoDBQuery(result)
 	}
 	return cmd
 }
 
+// runDynamoDBQuery runs the query or scan of a dialog result and shows its
+// results, remembering it in the table's recent queries. An unfiltered scan
+// of a large table asks first.
+func (m *Model) runDynamoDBQuery(result *components.QueryDialogResult) tea.Cmd {
+	if details := m.scanWarning(result.ScanParams); details != nil {
+		return m.askConfirm("Scan "+result.ScanParams.TableName+" without a filter", details, func() tea.Cmd {
+			return m.startDynamoDBQuery(result)
+		})
+	}
+	return m.startDynamoDBQuery(result)
+}
+
+// startDynamoDBQuery runs the query or scan of a dialog result.
+func (m *Model) startDynamoDBQuery(result *components.QueryDialogResult) tea.Cmd {
+	m.rememberQuery(result)
+
+	if result.QueryParams != nil {
+		m.state.DynamoDBQueryParams = result.QueryParams
+		m.state.DynamoDBScanParams = nil
+		m.state.DynamoDBIsQuery = true
+		m.state.DynamoDBQueryLoading = true
+		m.state.DynamoDBLastKey = nil
+		m.state.View = state.ViewDynamoDBQuery
+		m.dynamodbQueryResults.SetLoading(true)
+		m.dynamodbQueryResults.Clear()
+		m.logger.Info("Executing query on table: %s (PK: %s)", result.QueryParams.TableName, result.QueryParams.PartitionKeyVal)
+		return m.executeDynamoDBQuery(result.QueryParams)
+	} else if result.ScanParams != nil {
+		m.state.DynamoDBQueryParams = nil
+		m.state.DynamoDBScanParams = result.ScanParams
+		m.state.DynamoDBIsQuery = false
+		m.state.DynamoDBQueryLoading = true
+		m.state.DynamoDBLastKey = nil
+		m.state.View = state.ViewDynamoDBQuery
+		m.dynamodbQueryResults.SetLoading(true)
+		m.dynamodbQueryResults.Clear()
+		m.logger.Info("Executing scan on table: %s", result.ScanParams.TableName)
+		return m.executeDynamoDBScan(result.ScanParams)
+	}
+	return nil
+}
+
 // handleDynamoDBQueryResultsKey handles key presses in the query results view.
 func (m *Model) handleDynamoDBQueryResultsKey(msg tea.KeyMsg) tea.Cmd {
+	if m.handleJSONTreeKey(msg) {
+		return nil
+	}
+
 	switch msg.String() {
 	case "ctrl+c":
 		m.tunnelManager.StopAllTunnels()
@@ -1332,26 +2708,14 @@ func (m *Model) handleDynamoDBQueryResultsKey(msg tea.KeyMsg) tea.Cmd {
 	case "q":
 		// Start a new query on the same table
 		if m.state.SelectedTable != nil {
-			m.dynamodbQueryDialog.SetSize(m.width, m.height)
-			return m.dynamodbQueryDialog.Activate(
-				m.state.SelectedTable.Name,
-				m.state.SelectedTable.PartitionKey(),
-				m.state.SelectedTable.SortKey(),
-				true, // isQuery
-			)
+			return m.openQueryDialog(m.state.SelectedTable, true)
 		}
 		return nil
 
 	case "s":
 		// Start a new scan on the same table
 		if m.state.SelectedTable != nil {
-			m.dynamodbQueryDialog.SetSize(m.width, m.height)
-			return m.dynamodbQueryDialog.Activate(
-				m.state.SelectedTable.Name,
-				m.state.SelectedTable.PartitionKey(),
-				m.state.SelectedTable.SortKey(),
-				false, // isScan
-			)
+			return m.openQueryDialog(m.state.SelectedTable, false)
 		}
 		return nil
 
@@ -1379,6 +2743,53 @@ func (m *Model) handleDynamoDBQueryResultsKey(msg tea.KeyMsg) tea.Cmd {
 		m.dynamodbQueryResults.Bottom()
 		return nil
 
+	case "t":
+		// Switch between the item list and the column view
+		m.dynamodbQueryResults.ToggleColumnView()
+		return nil
+
+	case "enter":
+		// Open the selected row's item from the column view
+		if m.dynamodbQueryResults.IsColumnView() {
+			m.dynamodbQueryResults.ToggleColumnView()
+		}
+		return nil
+
+	case "left", "h":
+		m.dynamodbQueryResults.ColumnLeft()
+		return nil
+
+	case "right":
+		m.dynamodbQueryResults.ColumnRight()
+		return nil
+
+	case "o":
+		// Sort the loaded items by the selected column
+		if m.dynamodbQueryResults.IsColumnView() {
+			m.dynamodbQueryResults.CycleSort()
+			if sort := m.dynamodbQueryResults.SortDescription(); sort != "" {
+				m.logger.Info("Sorted by %s", sort)
+			} else {
+				m.logger.Info("Sort cleared")
+			}
+		}
+		return nil
+
+	case "x":
+		// Hide the selected column
+		if m.dynamodbQueryResults.IsColumnView() {
+			m.dynamodbQueryResults.HideColumn()
+		}
+		return nil
+
+	case "X":
+		// Show hidden columns again
+		if n := m.dynamodbQueryResults.HiddenColumns(); n > 0 {
+			m.dynamodbQueryResults.ShowAllColumns()
+			m.logger.Info("Showing %d hidden columns", n)
+		}
+		return nil
+
 	case "J":
 		// Scroll JSON panel down
 		m.dynamodbQueryResults.ScrollJSONDown()
@@ -1399,6 +2810,25 @@ func (m *Model) handleDynamoDBQueryResultsKey(msg tea.KeyMsg) tea.Cmd {
 		m.dynamodbQueryResults.ScrollJSONHalfPageUp()
 		return nil
 
+	case "/":
+		// Search the selected item's JSON
+		m.startDetailsSearch()
+		return nil
+
+	case "ctrl+n":
+		// Next JSON search match
+		if tree := m.activeJSONTree(); tree != nil {
+			tree.NextMatch()
+		}
+		return nil
+
+	case "ctrl+p":
+		// Previous JSON search match
+		if tree := m.activeJSONTree(); tree != nil {
+			tree.PrevMatch()
+		}
+		return nil
+
 	case "n":
 		// Load next page if available
 		if m.dynamodbQueryResults.HasMorePages() && !m.state.DynamoDBQueryLoading {
@@ -1411,20 +2841,15 @@ func (m *Model) handleDynamoDBQueryResultsKey(msg tea.KeyMsg) tea.Cmd {
 
 	case "r":
 		// Re-run the query/scan
-		if m.state.DynamoDBIsQuery && m.state.DynamoDBQueryParams != nil {
-			m.state.DynamoDBQueryLoading = true
-			m.state.DynamoDBLastKey = nil
-			m.dynamodbQueryResults.SetLoading(true)
-			m.dynamodbQueryResults.Clear()
-			return m.executeDynamoDBQuery(m.state.DynamoDBQueryParams)
-		} else if !m.state.DynamoDBIsQuery && m.state.DynamoDBScanParams != nil {
-			m.state.DynamoDBQueryLoading = true
-			m.state.DynamoDBLastKey = nil
-			m.dynamodbQueryResults.SetLoading(true)
-			m.dynamodbQueryResults.Clear()
-			return m.executeDynamoDBScan(m.state.DynamoDBScanParams)
-		}
-		return nil
+		return m.rerunDynamoDBQuery()
+
+	case "e":
+		// Edit the item in $EDITOR and put it back
+		return m.editDynamoDBItem()
+
+	case "D":
+		// Delete the item
+		return m.deleteDynamoDBItem()
 
 	case "y":
 		// Copy mode - show JSON content for selection
@@ -1442,7 +2867,7 @@ func (m *Model) handleDynamoDBQueryResultsKey(msg tea.KeyMsg) tea.Cmd {
 			return nil
 		}
 		if err := copyToClipboard(text); err != nil {
-			m.logger.Warn("Clipboard not available: " + err.Error())
+			m.logger.Warn("Clipboard not available: %v", err)
 			return nil
 		}
 		m.logger.Info("JSON copied to clipboard")
@@ -1450,8 +2875,7 @@ func (m *Model) handleDynamoDBQueryResultsKey(msg tea.KeyMsg) tea.Cmd {
 
 	case ":":
 		// Open command palette
-		m.commandPalette.SetWidth(m.width)
-		return m.commandPalette.Activate()
+		return m.openCommandPalette()
 
 	case "?":
 		// Show help
@@ -1479,6 +2903,8 @@ func (m *Model) handleDynamoDBQueryResultsKey(msg tea.KeyMsg) tea.Cmd {
 		return m.switchToAPIGateway()
 	case "6":
 		return m.switchToStacks()
+	case "7":
+		return m.switchToAppRunner()
 	}
 
 	return nil
@@ -1493,10 +2919,8 @@ func (m *Model) handleCloudWatchLogsKey(msg tea.KeyMsg) (tea.Cmd, bool) {
 		return tea.Quit, true
 
 	case "esc", "backspace":
-		// Go back to tasks view
-		m.state.CloudWatchLogsStreaming = false
-		m.cloudWatchLogsPanel.SetStreaming(false)
-		m.state.View = state.ViewTasks
+		// Go back to the view the logs were opened from
+		m.handleBack()
 		return nil, true
 
 	case "up", "k":
@@ -1530,18 +2954,36 @@ func (m *Model) handleCloudWatchLogsKey(msg tea.KeyMsg) (tea.Cmd, bool) {
 	case "tab":
 		// Switch to next container tab
 		m.cloudWatchLogsPanel.SelectNextTab()
-		m.cloudWatchLogsPanel.Clear()
-		m.state.CloudWatchLogs = nil
-		m.state.CloudWatchLastFetchTime = 0
-		return m.fetchCloudWatchLogs(), true
+		return m.restartCloudWatchLogs(), true
 
 	case "shift+tab":
 		// Switch to previous container tab
 		m.cloudWatchLogsPanel.SelectPrevTab()
-		m.cloudWatchLogsPanel.Clear()
-		m.state.CloudWatchLogs = nil
-		m.state.CloudWatchLastFetchTime = 0
-		return m.fetchCloudWatchLogs(), true
+		return m.restartCloudWatchLogs(), true
+
+	case "/":
+		// Search the buffered log lines
+		m.startDetailsSearch()
+		return nil, true
+
+	case "n":
+		// Next (newer) search match
+		m.cloudWatchLogsPanel.NextMatch()
+		return nil, true
+
+	case "N":
+		// Previous (older) search match
+		m.cloudWatchLogsPanel.PrevMatch()
+		return nil, true
+
+	case "ctrl+s":
+		// Export the loaded entries to a file, or stop the export
+		m.toggleLogExport("")
+		return nil, true
+
+	case "m":
+		// Merge the logs of every task of the service, or show one task's
+		return m.toggleMergedTail(), true
 	}
 
 	// Not handled - let main handler process (for shortcuts like 1,2,3,4)
@@ -1565,7 +3007,7 @@ func (m *Model) handleMouseWheelUp(x int) {
 	}
 
 	// Split view - determine which pane based on X position
-	listWidth := int(float64(m.width) * listPaneRatio)
+	listWidth, _ := m.splitWidths(m.width)
 
 	if x < listWidth {
 		// Left pane (list) - move cursor up
@@ -1597,7 +3039,7 @@ func (m *Model) handleMouseWheelDown(x int) {
 	}
 
 	// Split view - determine which pane based on X position
-	listWidth := int(float64(m.width) * listPaneRatio)
+	listWidth, _ := m.splitWidths(m.width)
 
 	if x < listWidth {
 		// Left pane (list) - move cursor down
@@ -1611,3 +3053,175 @@ func (m *Model) handleMouseWheelDown(x int) {
 		}
 	}
 }
+
+// openActivity opens the CloudTrail activity feed of the selected stack,
+// service or table.
+func (m *Model) openActivity() tea.Cmd {
+	var resource string
+	var names []string
+	switch m.state.View {
+	case state.ViewStacks:
+		item := m.stacksList.SelectedItem()
+		if item == nil {
+			return nil
+		}
+		for _, s := range m.state.Stacks {
+			if s.Name == item.ID {
+				resource, names = "stack "+s.Name, []string{s.Name, s.ID}
+			}
+		}
+	case state.ViewServices:
+		if svc := m.selectedService(); svc != nil {
+			resource, names = "service "+svc.Name, []string{svc.ARN, svc.Name}
+		}
+	case state.ViewDynamoDB:
+		if t := m.dynamodbTable.SelectedTable(); t != nil {
+			resource, names = "table "+t.Name, []string{t.Name, t.ARN}
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	m.state.ClearActivity()
+	m.state.ActivityResource = resource
+	m.state.ActivityNames = names
+	m.state.ActivityReturnView = m.state.View
+	m.state.View = state.ViewActivity
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.activityList.SetTitle("Activity: " + resource)
+	m.activityList.SetItems(nil)
+	return m.loadActivity()
+}
+
+// selectedActivityEvent returns the CloudTrail event under the cursor.
+func (m *Model) selectedActivityEvent() *model.ActivityEvent {
+	item := m.activityList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.ActivityEvents {
+		if m.state.ActivityEvents[i].ID == item.ID {
+			return &m.state.ActivityEvents[i]
+		}
+	}
+	return nil
+}
+
+// startLogSearch opens the log search dialog for the selected stack, the
+// stack being browsed, or the stack of the current search to refine it.
+func (m *Model) startLogSearch() tea.Cmd {
+	var stack string
+	switch m.state.View {
+	case state.ViewStacks:
+		if item := m.stacksList.SelectedItem(); item != nil {
+			stack = item.ID
+		}
+	case state.ViewStackResources:
+		if m.state.SelectedStack != nil {
+			stack = m.state.SelectedStack.Name
+		}
+	case state.ViewLogSearch:
+		stack = m.state.LogSearchStack
+	}
+	if stack == "" {
+		return nil
+	}
+
+	m.searchingLogs = true
+	m.pendingLogSearchStack = stack
+	if m.state.View == state.ViewLogSearch {
+		m.logSearchInput.SetValue(m.state.LogSearchPattern)
+	} else {
+		m.logSearchInput.SetValue("")
+	}
+	m.logSearchInput.Focus()
+	return textinput.Blink
+}
+
+// cycleLogSearchRange moves the range of log searches step presets on. A
+// custom range moves to the first preset.
+func (m *Model) cycleLogSearchRange(step int) {
+	presets := timeRangePresets(state.ViewLogSearch)
+	i := 0
+	if r := m.timeRange(state.ViewLogSearch); !r.IsAbsolute() {
+		if j := slices.Index(presets, r.Last); j >= 0 {
+			i = (j + step + len(presets)) % len(presets)
+		}
+	}
+	m.setTimeRange(state.ViewLogSearch, model.LastRange(presets[i]))
+}
+
+// handleLogSearchInputKey handles key messages when entering a log search.
+func (m *Model) handleLogSearchInputKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		pattern := strings.TrimSpace(m.logSearchInput.Value())
+		stack := m.pendingLogSearchStack
+
+		m.searchingLogs = false
+		m.logSearchInput.Blur()
+		m.pendingLogSearchStack = ""
+
+		if stack == "" {
+			return nil
+		}
+
+		// Keep the log groups when refining a search of the same stack
+		sources := m.state.LogSearchSources
+		returnView := m.state.View
+		if m.state.View == state.ViewLogSearch {
+			returnView = m.state.LogSearchReturnView
+		}
+		if stack != m.state.LogSearchStack {
+			sources = nil
+		}
+
+		m.state.ClearLogSearch()
+		m.state.LogSearchStack = stack
+		m.state.LogSearchPattern = pattern
+		m.state.LogSearchRange = m.timeRange(state.ViewLogSearch)
+		m.state.LogSearchReturnView = returnView
+		m.state.LogSearchSources = sources
+		m.state.View = state.ViewLogSearch
+		m.state.FilterText = ""
+		m.filterInput.SetValue("")
+		m.logSearchList.SetTitle("Logs: " + stack)
+		m.logSearchList.SetItems(nil)
+		return m.loadLogSearch()
+
+	case "tab":
+		m.cycleLogSearchRange(1)
+		return nil
+
+	case "shift+tab":
+		m.cycleLogSearchRange(-1)
+		return nil
+
+	case "esc":
+		m.searchingLogs = false
+		m.logSearchInput.Blur()
+		m.pendingLogSearchStack = ""
+		return nil
+	}
+
+	// Pass other keys to the input
+	var cmd tea.Cmd
+	m.logSearchInput, cmd = m.logSearchInput.Update(msg)
+	return cmd
+}
+
+// selectedLogSearchHit returns the log event under the cursor.
+func (m *Model) selectedLogSearchHit() *model.LogSearchHit {
+	item := m.logSearchList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.LogSearchHits {
+		if m.state.LogSearchHits[i].ID == item.ID {
+			return &m.state.LogSearchHits[i]
+		}
+	}
+	return nil
+}
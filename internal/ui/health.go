@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+)
+
+// healthCertificateType is the resource type certificates are listed under.
+const healthCertificateType = "AWS::CertificateManager::Certificate"
+
+// openStartView opens the resource of the link vaws was started with, or the
+// configured landing view. The main menu is shown otherwise, so there is
+// nothing to do for it.
+func (m *Model) openStartView() tea.Cmd {
+	if m.client != nil && m.startLink != nil {
+		link := *m.startLink
+		m.startLink = nil
+		return m.openLink(link)
+	}
+	if m.client == nil || m.cfg == nil || m.cfg.Defaults.StartView != config.StartViewHealth {
+		return nil
+	}
+	return m.openHealth()
+}
+
+// openHealth shows the account health summary, running the checks the first
+// time and keeping the result until refreshed.
+func (m *Model) openHealth() tea.Cmd {
+	m.state.SelectedStack = nil
+	m.state.View = state.ViewHealth
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	if m.state.Health == nil && !m.state.HealthLoading {
+		return m.loadHealth()
+	}
+	m.updateHealthList()
+	return nil
+}
+
+// selectedHealthItem returns the check and key of the selected problem, or
+// empty strings on a header or a summary line.
+func (m *Model) selectedHealthItem() (check, key string) {
+	item := m.healthList.SelectedItem()
+	if item == nil {
+		return "", ""
+	}
+	check, key, ok := strings.Cut(item.ID, ":")
+	if !ok {
+		return "", ""
+	}
+	return check, key
+}
+
+// handleHealthEnter opens the view listing the selected problem, filtered
+// down to it.
+func (m *Model) handleHealthEnter() tea.Cmd {
+	check, key := m.selectedHealthItem()
+	h := m.state.Health
+	if h == nil || key == "" {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	filter := ""
+	switch check {
+	case model.HealthStacks:
+		cmd, filter = m.switchToStacks(), key
+	case model.HealthServices:
+		for _, svc := range h.UnhealthyServices {
+			if svc.ARN != key {
+				continue
+			}
+			// Load the clusters too, so going back lands on a full list
+			clusters := m.switchToECS()
+			m.state.SelectCluster(&model.Cluster{ARN: svc.ClusterARN, Name: svc.ClusterName})
+			cmd, filter = tea.Batch(clusters, m.loadServicesForCluster()), svc.Name
+		}
+	case model.HealthAlarms:
+		m.pinToMonitor(config.MonitorPanelConfig{Kind: config.MonitorAlarms})
+		return m.openMonitor()
+	case model.HealthDLQs:
+		for _, q := range h.DLQs {
+			if q.URL == key {
+				cmd, filter = m.switchToSQS(), q.Name
+			}
+		}
+	case model.HealthCertificates:
+		cmd, filter = m.openResourceType(healthCertificateType), key
+	}
+	if filter == "" {
+		return nil
+	}
+
+	m.state.FilterText = filter
+	m.filterInput.SetValue(filter)
+	m.updateCurrentList()
+	return cmd
+}
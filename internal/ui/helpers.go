@@ -2,11 +2,15 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"vaws/internal/model"
+	"vaws/internal/tunnel"
 )
 
 // formatDuration formats seconds into a human-readable duration string.
@@ -92,3 +96,83 @@ func findBestContainer(containers []model.Container) *model.Container {
 
 	return nil
 }
+
+// shortTaskDefinition returns the family:revision part of a task definition ARN.
+func shortTaskDefinition(arn string) string {
+	if idx := strings.LastIndex(arn, "/"); idx >= 0 {
+		return arn[idx+1:]
+	}
+	return arn
+}
+
+// valueOrDash returns s, or "-" if it is empty.
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// joinOrDash joins values with commas, or returns "-" if there are none.
+func joinOrDash(values []string) string {
+	return valueOrDash(strings.Join(values, ", "))
+}
+
+// expandHome expands a leading ~/ in a path to the user's home directory.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, path[2:])
+}
+
+// isActionAllowed returns true if the current profile may perform the action
+// category and the programs it runs are installed.
+func (m *Model) isActionAllowed(action string) bool {
+	return len(m.missingPrerequisites(action)) == 0 && m.profileAllows(action)
+}
+
+// profileAllows returns true if the current profile may perform the action category.
+func (m *Model) profileAllows(action string) bool {
+	if m.cfg == nil {
+		return true
+	}
+	return m.cfg.IsActionAllowed(m.state.Profile, action)
+}
+
+// warnUnknownActions logs allow entries in the config that are not action
+// categories, so a typo does not silently block actions.
+func (m *Model) warnUnknownActions() {
+	if m.cfg == nil {
+		return
+	}
+	for _, entry := range m.cfg.UnknownActions() {
+		m.logger.Warn("Unknown action in allow for profile %s (valid: read, tunnel, invoke, write, shell)", entry)
+	}
+}
+
+// checkActionAllowed is like isActionAllowed but logs a warning when the action is blocked.
+func (m *Model) checkActionAllowed(action string) bool {
+	if !m.checkProfileAllows(action) {
+		return false
+	}
+	if missing := m.missingPrerequisites(action); len(missing) > 0 {
+		b := missing[0]
+		m.logger.Warn("'%s' actions are disabled: %v (install: %s)", action, b.Err, tunnel.InstallHint(b.Name))
+		return false
+	}
+	return true
+}
+
+// checkProfileAllows is like profileAllows but logs a warning when the action is blocked.
+func (m *Model) checkProfileAllows(action string) bool {
+	if m.profileAllows(action) {
+		return true
+	}
+	m.logger.Warn("'%s' actions are disabled for profile %s (see allow in ~/.vaws/config.yaml)", action, m.state.Profile)
+	return false
+}
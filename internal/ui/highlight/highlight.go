@@ -0,0 +1,209 @@
+// Package highlight parses the highlight rules of the config, such as
+// "running < desired -> red bold", and matches them against the fields of a
+// list row.
+package highlight
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/ui/theme"
+)
+
+// Rule styles the rows whose fields match all of its conditions.
+type Rule struct {
+	Source string // The rule as written in the config
+	Style  lipgloss.Style
+	conds  []condition
+}
+
+// condition compares a field with another field or a literal.
+type condition struct {
+	field   string
+	op      string
+	operand string
+}
+
+// ops are the comparison operators, longest first so that "<=" isn't read
+// as "<".
+var ops = []string{"<=", ">=", "==", "!=", "<", ">", "~"}
+
+// colors are the color names rules may use, mapped to the theme's colors
+// where it has one.
+var colors = map[string]lipgloss.TerminalColor{
+	"red":     theme.Error,
+	"yellow":  theme.Warning,
+	"green":   theme.Success,
+	"blue":    theme.Info,
+	"magenta": theme.Primary,
+	"purple":  theme.Primary,
+	"cyan":    lipgloss.Color("6"),
+	"white":   theme.Text,
+	"gray":    theme.TextDim,
+	"grey":    theme.TextDim,
+}
+
+// Parse reads a rule of the form "<conditions> -> <style>". Conditions are
+// joined with "and" and compare a field with a number, a word, a quoted
+// string or another field, e.g. `memory >= 3008` or `status == "DRAINING"`.
+// The style is a color name or "#rrggbb", with bold, italic, underline or
+// faint.
+func Parse(rule string) (Rule, error) {
+	cond, style, ok := strings.Cut(rule, "->")
+	if !ok {
+		return Rule{}, fmt.Errorf("%q: missing -> and a style", rule)
+	}
+
+	r, err := ParseConditions(cond)
+	if err != nil {
+		return Rule{}, fmt.Errorf("%q: %w", rule, err)
+	}
+	r.Source = rule
+	if r.Style, err = parseStyle(style); err != nil {
+		return Rule{}, fmt.Errorf("%q: %w", rule, err)
+	}
+	return r, nil
+}
+
+// ParseConditions reads conditions joined with "and", as on the left of a
+// rule, into a rule without a style that is only used to match.
+func ParseConditions(s string) (Rule, error) {
+	r := Rule{Source: strings.TrimSpace(s)}
+	for _, part := range strings.Split(s, " and ") {
+		c, err := parseCondition(strings.TrimSpace(part))
+		if err != nil {
+			return Rule{}, err
+		}
+		r.conds = append(r.conds, c)
+	}
+	return r, nil
+}
+
+func parseCondition(s string) (condition, error) {
+	for _, op := range ops {
+		field, operand, ok := strings.Cut(s, op)
+		if !ok {
+			continue
+		}
+		c := condition{
+			field:   strings.ToLower(strings.TrimSpace(field)),
+			op:      op,
+			operand: strings.Trim(strings.TrimSpace(operand), `"'`),
+		}
+		if c.field == "" || strings.TrimSpace(operand) == "" {
+			return condition{}, fmt.Errorf("incomplete condition %q", s)
+		}
+		return c, nil
+	}
+	return condition{}, fmt.Errorf("no comparison in %q (use <, <=, >, >=, ==, != or ~)", s)
+}
+
+func parseStyle(s string) (lipgloss.Style, error) {
+	style := lipgloss.NewStyle()
+	words := strings.Fields(strings.ToLower(s))
+	if len(words) == 0 {
+		return style, fmt.Errorf("missing style after ->")
+	}
+	for _, w := range words {
+		switch {
+		case w == "bold":
+			style = style.Bold(true)
+		case w == "italic":
+			style = style.Italic(true)
+		case w == "underline":
+			style = style.Underline(true)
+		case w == "faint":
+			style = style.Faint(true)
+		case colors[w] != nil:
+			style = style.Foreground(colors[w])
+		case strings.HasPrefix(w, "#") && (len(w) == 7 || len(w) == 4):
+			style = style.Foreground(lipgloss.Color(w))
+		default:
+			return style, fmt.Errorf("unknown style %q", w)
+		}
+	}
+	return style, nil
+}
+
+// Fields returns the fields the rule's conditions test, including fields
+// used as operands if known is given.
+func (r Rule) Fields(known map[string]string) []string {
+	var fields []string
+	for _, c := range r.conds {
+		fields = append(fields, c.field)
+		if _, ok := known[strings.ToLower(c.operand)]; ok {
+			fields = append(fields, strings.ToLower(c.operand))
+		}
+	}
+	return fields
+}
+
+// Match returns true if fields satisfy every condition of the rule. An
+// operand naming a field is compared with that field's value. Ordering
+// comparisons need numbers on both sides; == and != compare text, ignoring
+// case, unless both sides are numbers; ~ tests that the field contains the
+// operand.
+func (r Rule) Match(fields map[string]string) bool {
+	for _, c := range r.conds {
+		value, ok := fields[c.field]
+		if !ok || !c.match(value, fields) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c condition) match(value string, fields map[string]string) bool {
+	operand := c.operand
+	if v, ok := fields[strings.ToLower(operand)]; ok {
+		operand = v
+	}
+
+	a, errA := strconv.ParseFloat(value, 64)
+	b, errB := strconv.ParseFloat(operand, 64)
+	numeric := errA == nil && errB == nil
+
+	switch c.op {
+	case "==":
+		if numeric {
+			return a == b
+		}
+		return strings.EqualFold(value, operand)
+	case "!=":
+		if numeric {
+			return a != b
+		}
+		return !strings.EqualFold(value, operand)
+	case "~":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(operand))
+	}
+	if !numeric {
+		return false
+	}
+	switch c.op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	default:
+		return a >= b
+	}
+}
+
+// Rules are the rules of a kind of resource, in config order.
+type Rules []Rule
+
+// Match returns the first rule matching fields, or nil.
+func (rs Rules) Match(fields map[string]string) *Rule {
+	for i := range rs {
+		if rs[i].Match(fields) {
+			return &rs[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/highlight"
+)
+
+// highlightLabels lists the fields highlight rules can test for each kind of
+// resource, keyed as in the config, with the label of the detail row that
+// shows the field.
+var highlightLabels = map[string]map[string]string{
+	"services": {
+		"name":            "Service",
+		"cluster":         "Cluster",
+		"status":          "Status",
+		"running":         "Tasks",
+		"desired":         "Tasks",
+		"pending":         "Pending",
+		"launch_type":     "Launch Type",
+		"task_definition": "Task Def",
+	},
+	"lambda": {
+		"name":         "Name",
+		"runtime":      "Runtime",
+		"handler":      "Handler",
+		"memory":       "Memory",
+		"timeout":      "Timeout",
+		"code_size":    "Code Size",
+		"state":        "State",
+		"package_type": "Package Type",
+	},
+	"queues": {
+		"name":            "Name",
+		"type":            "Type",
+		"messages":        "Messages",
+		"in_flight":       "In Flight",
+		"visibility":      "Visibility",
+		"retention":       "Retention",
+		"delay":           "Delay",
+		"max_receives":    "Max Receives",
+		"content_dedup":   "Dedup",
+		"high_throughput": "Throughput",
+	},
+	"stacks": {
+		"name":        "Name",
+		"status":      "Status",
+		"description": "Description",
+	},
+}
+
+// loadHighlights parses the highlight rules of the config, warning about the
+// rules that can't be applied.
+func (m *Model) loadHighlights() {
+	m.highlights = make(map[string]highlight.Rules)
+	if m.cfg == nil {
+		return
+	}
+
+	kinds := make([]string, 0, len(highlightLabels))
+	for kind := range highlightLabels {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	for kind, rules := range m.cfg.Highlights {
+		labels, ok := highlightLabels[kind]
+		if !ok {
+			m.logger.Warn("Unknown resource %q in highlights (valid: %s)", kind, strings.Join(kinds, ", "))
+			continue
+		}
+		for _, src := range rules {
+			rule, err := highlight.Parse(src)
+			if err != nil {
+				m.logger.Warn("Ignoring highlight rule for %s: %v", kind, err)
+				continue
+			}
+			if i := slices.IndexFunc(rule.Fields(nil), func(f string) bool { return labels[f] == "" }); i >= 0 {
+				fields := make([]string, 0, len(labels))
+				for f := range labels {
+					fields = append(fields, f)
+				}
+				slices.Sort(fields)
+				m.logger.Warn("Ignoring highlight rule for %s %q: unknown field %s (valid: %s)", kind, src, rule.Fields(nil)[i], strings.Join(fields, ", "))
+				continue
+			}
+			m.highlights[kind] = append(m.highlights[kind], rule)
+		}
+	}
+}
+
+// highlightStyle returns the style of the first rule of kind matching
+// fields, or nil if none does.
+func (m *Model) highlightStyle(kind string, fields map[string]string) *lipgloss.Style {
+	if rule := m.highlights[kind].Match(fields); rule != nil {
+		return &rule.Style
+	}
+	return nil
+}
+
+// highlightDetails styles the detail rows of the fields tested by the first
+// rule of kind matching fields.
+func (m *Model) highlightDetails(kind string, fields map[string]string, rows []components.DetailRow) {
+	rule := m.highlights[kind].Match(fields)
+	if rule == nil {
+		return
+	}
+	labels := highlightLabels[kind]
+	for _, f := range rule.Fields(labels) {
+		for i := range rows {
+			if rows[i].Label == labels[f] {
+				rows[i].Style = rule.Style
+			}
+		}
+	}
+}
+
+func serviceFields(s model.Service) map[string]string {
+	return map[string]string{
+		"name":            s.Name,
+		"cluster":         s.ClusterName,
+		"status":          string(s.Status),
+		"running":         strconv.Itoa(s.RunningCount),
+		"desired":         strconv.Itoa(s.DesiredCount),
+		"pending":         strconv.Itoa(s.PendingCount),
+		"launch_type":     s.LaunchType,
+		"task_definition": s.TaskDefinition,
+	}
+}
+
+func functionFields(fn model.Function) map[string]string {
+	return map[string]string{
+		"name":         fn.Name,
+		"runtime":      fn.Runtime,
+		"handler":      fn.Handler,
+		"memory":       strconv.Itoa(fn.MemorySize),
+		"timeout":      strconv.Itoa(fn.Timeout),
+		"code_size":    strconv.FormatInt(fn.CodeSize, 10),
+		"state":        string(fn.State),
+		"package_type": fn.PackageType,
+	}
+}
+
+func queueFields(q model.Queue) map[string]string {
+	return map[string]string{
+		"name":            q.Name,
+		"type":            string(q.Type),
+		"messages":        strconv.Itoa(q.ApproximateMessageCount),
+		"in_flight":       strconv.Itoa(q.ApproximateInFlight),
+		"visibility":      strconv.Itoa(q.VisibilityTimeout),
+		"retention":       strconv.Itoa(q.MessageRetentionPeriod),
+		"delay":           strconv.Itoa(q.DelaySeconds),
+		"max_receives":    strconv.Itoa(q.MaxReceiveCount),
+		"content_dedup":   strconv.FormatBool(q.ContentBasedDeduplication),
+		"high_throughput": strconv.FormatBool(q.IsHighThroughputFIFO()),
+	}
+}
+
+func stackFields(s model.Stack) map[string]string {
+	return map[string]string{
+		"name":        s.Name,
+		"status":      string(s.Status),
+		"description": s.Description,
+	}
+}
@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+// imagesScope names the cluster or stack whose services are listed, or ""
+// if none is selected.
+func (m *Model) imagesScope() string {
+	switch {
+	case m.state.SelectedCluster != nil:
+		return m.state.SelectedCluster.Name
+	case m.state.SelectedStack != nil:
+		return m.state.SelectedStack.Name
+	}
+	return ""
+}
+
+// openImages shows how the images of the listed services compare with their
+// ECR repositories. The report is kept until refreshed, or until it is opened
+// on another cluster or stack.
+func (m *Model) openImages() tea.Cmd {
+	scope := m.imagesScope()
+	if m.state.View != state.ViewServices || scope == "" {
+		m.logger.Warn("Open the services of a cluster or stack first")
+		return nil
+	}
+	m.state.View = state.ViewImages
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	if scope != m.state.ImagesScope {
+		m.state.ClearImages()
+		m.state.ImagesScope = scope
+	}
+	if m.state.Images == nil && !m.state.ImagesLoading {
+		return m.loadImages()
+	}
+	m.updateImagesTable()
+	return nil
+}
+
+// loadImages checks the images of the services of the report's scope.
+func (m *Model) loadImages() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	m.state.ImagesLoading = true
+	m.imagesTable.SetLoading(true)
+	m.logger.Info("Checking the images of %d services in %s...", len(m.state.Services), m.state.ImagesScope)
+
+	scope, services := m.state.ImagesScope, slices.Clone(m.state.Services)
+	return tea.Batch(
+		m.imagesTable.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			images, err := m.client.GetServiceImages(ctx, services)
+			return imagesLoadedMsg{scope: scope, images: images, err: err}
+		},
+	)
+}
+
+// handleImagesLoaded records the checked images, stale ones first.
+func (m *Model) handleImagesLoaded(msg imagesLoadedMsg) {
+	if msg.scope != m.state.ImagesScope {
+		return
+	}
+	m.state.ImagesLoading = false
+	m.refreshIndicator.SetRefreshing(false)
+	if msg.err != nil {
+		m.state.ImagesError = msg.err
+		m.logger.Error("Failed to check images: %v", msg.err)
+		m.updateImagesTable()
+		return
+	}
+
+	rank := func(img model.ServiceImage) int {
+		switch components.ImageStatus(img) {
+		case "stale":
+			return 0
+		case "unknown":
+			return 1
+		}
+		return 2
+	}
+	images := msg.images
+	slices.SortStableFunc(images, func(a, b model.ServiceImage) int {
+		return cmp.Or(cmp.Compare(rank(a), rank(b)), strings.Compare(a.Service, b.Service), strings.Compare(a.Container, b.Container))
+	})
+	m.state.Images = images
+	m.state.ImagesError = nil
+
+	stale := 0
+	for _, img := range images {
+		if img.Stale() {
+			stale++
+		}
+	}
+	if stale > 0 {
+		m.logger.Warn("%d of %d containers in %s run an image older than the last one pushed", stale, len(images), msg.scope)
+	} else {
+		m.logger.Info("All %d containers in %s run the last image pushed, where known", len(images), msg.scope)
+	}
+	m.updateImagesTable()
+}
+
+// updateImagesTable updates the image freshness report with current data.
+func (m *Model) updateImagesTable() {
+	m.imagesTable.SetImages(m.state.FilteredImages())
+	m.imagesTable.SetLoading(m.state.ImagesLoading)
+	m.imagesTable.SetError(m.state.ImagesError)
+	m.updateImageDetails()
+}
+
+// updateImageDetails shows the running and latest image of the selected
+// container.
+func (m *Model) updateImageDetails() {
+	s := GetStyles()
+	m.details.SetTitle("Image")
+	img := m.imagesTable.SelectedImage()
+	if img == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	pushed := func(at time.Time) string {
+		if at.IsZero() {
+			return "-"
+		}
+		return format.Time(at) + " (" + format.Relative(time.Since(at)) + ")"
+	}
+	status := components.ImageStatus(*img)
+	statusStyle := s.Muted
+	switch status {
+	case "stale":
+		statusStyle = s.StatusError
+	case "current":
+		statusStyle = s.StatusHealthy
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Service", Value: img.Service},
+		{Label: "Cluster", Value: img.ClusterName},
+		{Label: "Container", Value: img.Container},
+		{Label: "Status", Value: status, Style: statusStyle},
+		{Label: "Image", Value: img.Image},
+		{Label: "Running Digest", Value: valueOrDash(img.Digest)},
+		{Label: "Pushed", Value: pushed(img.PushedAt)},
+		{Label: "Deployed", Value: pushed(img.DeployedAt)},
+		{Label: "", Value: ""},
+		{Label: "Latest Tag", Value: valueOrDash(img.LatestTag)},
+		{Label: "Latest Digest", Value: valueOrDash(img.LatestDigest)},
+		{Label: "Latest Pushed", Value: pushed(img.LatestPushedAt)},
+	}
+	if img.Error != "" {
+		rows = append(rows, components.DetailRow{Label: "Error", Value: img.Error, Style: s.StatusWarning})
+	}
+	m.details.SetRows(rows)
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+)
+
+// activeJSONTree returns the JSON tree that keys should act on: the selected
+// query result's item, or the details pane's tree when it has focus.
+func (m *Model) activeJSONTree() *components.JSONTree {
+	if m.state.View == state.ViewDynamoDBQuery {
+		if m.dynamodbQueryResults.SelectedItem() == nil || m.dynamodbQueryResults.IsColumnView() {
+			return nil
+		}
+		return m.dynamodbQueryResults.JSONTree()
+	}
+	if m.focusedPane() == focusDetails {
+		return m.details.JSONTree()
+	}
+	return nil
+}
+
+// handleJSONTreeKey handles expand/collapse and path copy for the active JSON
+// tree. It reports whether the key was consumed.
+func (m *Model) handleJSONTreeKey(msg tea.KeyMsg) bool {
+	tree := m.activeJSONTree()
+	if tree == nil {
+		return false
+	}
+
+	switch {
+	case matchKey(msg, m.keys.ToggleNode):
+		tree.Toggle()
+	case matchKey(msg, m.keys.ExpandAll):
+		tree.ExpandAll()
+	case matchKey(msg, m.keys.CollapseAll):
+		tree.CollapseAll()
+	case matchKey(msg, m.keys.CopyPath):
+		path := tree.SelectedPath()
+		if err := copyToClipboard(path); err != nil {
+			m.logger.Warn("Clipboard not available: %v", err)
+			return true
+		}
+		m.logger.Info("Copied path: %s", path)
+	case matchKey(msg, m.keys.JSONFilter):
+		m.jsonFiltering = true
+		m.jsonFilterInput.SetValue(tree.Filter())
+		m.jsonFilterInput.CursorEnd()
+		m.jsonFilterInput.Focus()
+	default:
+		return false
+	}
+	return true
+}
+
+// handleJSONFilterKey handles key messages while a jq expression is typed,
+// filtering the active JSON tree as it changes.
+func (m *Model) handleJSONFilterKey(msg tea.KeyMsg) tea.Cmd {
+	tree := m.activeJSONTree()
+	switch {
+	case tree == nil:
+		m.jsonFiltering = false
+		m.jsonFilterInput.Blur()
+		return nil
+
+	case matchKey(msg, m.keys.FilterAccept):
+		// Keep the filter, for the next items too
+		m.jsonFiltering = false
+		m.jsonFilterInput.Blur()
+		if err := tree.FilterError(); err != nil {
+			m.logger.Warn("jq filter %s: %v", tree.Filter(), err)
+		}
+		return nil
+
+	case matchKey(msg, m.keys.FilterClear):
+		m.jsonFilterInput.SetValue("")
+		tree.SetFilter("")
+		m.jsonFiltering = false
+		m.jsonFilterInput.Blur()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.jsonFilterInput, cmd = m.jsonFilterInput.Update(msg)
+	tree.SetFilter(m.jsonFilterInput.Value())
+	return cmd
+}
+
+// setDetailsSearchQuery searches the query result's JSON in the DynamoDB query
+// view, the buffered lines in the CloudWatch logs view and the details pane
+// everywhere else.
+func (m *Model) setDetailsSearchQuery(query string) {
+	if m.state.View == state.ViewDynamoDBQuery {
+		if tree := m.activeJSONTree(); tree != nil {
+			tree.SetSearchQuery(query)
+		}
+		return
+	}
+	if m.state.View == state.ViewCloudWatchLogs {
+		m.cloudWatchLogsPanel.SetSearchQuery(query)
+		return
+	}
+	m.details.SetSearchQuery(query)
+}
@@ -15,18 +15,40 @@ type KeyMap struct {
 	Bottom key.Binding
 
 	// Actions
-	Refresh        key.Binding
-	Filter         key.Binding
-	Logs           key.Binding
-	CloudWatchLogs key.Binding
-	Help           key.Binding
-	Quit           key.Binding
-	PortForward    key.Binding
-	Tunnels        key.Binding
-	StopTunnel     key.Binding
-	RestartTunnel  key.Binding
-	ClearTunnels   key.Binding
-	LambdaInvoke   key.Binding
+	Refresh         key.Binding
+	Filter          key.Binding
+	Logs            key.Binding
+	CloudWatchLogs  key.Binding
+	Help            key.Binding
+	Quit            key.Binding
+	PortForward     key.Binding
+	DiscoveryTunnel key.Binding
+	Shell           key.Binding
+	Tunnels         key.Binding
+	StopTunnel      key.Binding
+	RestartTunnel   key.Binding
+	ClearTunnels    key.Binding
+	ProxyRules      key.Binding
+	ExportTunnel    key.Binding
+	LambdaInvoke    key.Binding
+	Runtimes        key.Binding
+	Traffic         key.Binding
+	Performance     key.Binding
+	Deployments     key.Binding
+	Protection      key.Binding
+	QueueMap        key.Binding
+	QueueFailures   key.Binding
+	Chaos           key.Binding
+	Images          key.Binding
+	ClusterDash     key.Binding
+	Columns         key.Binding
+	PauseResume     key.Binding
+	Deploy          key.Binding
+	DiffTaskDef     key.Binding
+	TestPut         key.Binding
+	SearchUsers     key.Binding
+	ConfirmUser     key.Binding
+	ToggleUser      key.Binding
 
 	// Log scrolling
 	LogScrollUp   key.Binding
@@ -37,6 +59,29 @@ type KeyMap struct {
 	FilterAccept key.Binding
 	FilterClear  key.Binding
 
+	// JSON tree
+	ToggleNode  key.Binding
+	ExpandAll   key.Binding
+	CollapseAll key.Binding
+	CopyPath    key.Binding
+	JSONFilter  key.Binding
+
+	// Pane layout
+	ShrinkList key.Binding
+	GrowList   key.Binding
+	ShrinkLogs key.Binding
+	GrowLogs   key.Binding
+	Zoom       key.Binding
+	FocusNext  key.Binding
+	FocusPrev  key.Binding
+
+	// Monitor dashboard
+	Pin key.Binding
+
+	// Macros
+	RecordMacro key.Binding
+	ReplayMacro key.Binding
+
 	// Copy mode
 	CopyMode      key.Binding
 	YankClipboard key.Binding
@@ -105,6 +150,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("p"),
 			key.WithHelp("p", "port forward"),
 		),
+		DiscoveryTunnel: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "discovery tunnel"),
+		),
+		Shell: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "shell"),
+		),
 		Tunnels: key.NewBinding(
 			key.WithKeys("t"),
 			key.WithHelp("t", "tunnels"),
@@ -121,10 +174,90 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("c"),
 			key.WithHelp("c", "clear terminated"),
 		),
+		ProxyRules: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit proxy rules"),
+		),
+		ExportTunnel: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "export tunnel"),
+		),
 		LambdaInvoke: key.NewBinding(
 			key.WithKeys("i"),
 			key.WithHelp("i", "invoke"),
 		),
+		Runtimes: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "runtimes report"),
+		),
+		Traffic: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "alias traffic"),
+		),
+		Performance: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "duration and memory"),
+		),
+		Deployments: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "deployment history"),
+		),
+		Protection: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "task protection"),
+		),
+		QueueMap: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "queue consumers and producers"),
+		),
+		QueueFailures: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "why DLQ messages fail"),
+		),
+		Chaos: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "stop % of tasks"),
+		),
+		Images: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "image freshness"),
+		),
+		ClusterDash: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "cluster dashboard"),
+		),
+		Columns: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "choose columns"),
+		),
+		PauseResume: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "pause/resume"),
+		),
+		Deploy: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "deploy"),
+		),
+		TestPut: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "test put"),
+		),
+		SearchUsers: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "search users"),
+		),
+		ConfirmUser: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "confirm user"),
+		),
+		ToggleUser: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "disable/enable user"),
+		),
+		DiffTaskDef: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "diff task definition"),
+		),
 		LogScrollUp: key.NewBinding(
 			key.WithKeys("K", "pgup"),
 			key.WithHelp("K/PgUp", "scroll logs up"),
@@ -145,6 +278,66 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "clear"),
 		),
+		ToggleNode: key.NewBinding(
+			key.WithKeys("enter", " "),
+			key.WithHelp("enter/space", "expand/collapse"),
+		),
+		ExpandAll: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "expand all"),
+		),
+		CollapseAll: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "collapse all"),
+		),
+		CopyPath: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "copy JSON path"),
+		),
+		JSONFilter: key.NewBinding(
+			key.WithKeys("|"),
+			key.WithHelp("|", "jq filter"),
+		),
+		ShrinkList: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrow list pane"),
+		),
+		GrowList: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "widen list pane"),
+		),
+		ShrinkLogs: key.NewBinding(
+			key.WithKeys("{"),
+			key.WithHelp("{", "shrink logs panel"),
+		),
+		GrowLogs: key.NewBinding(
+			key.WithKeys("}"),
+			key.WithHelp("}", "grow logs panel"),
+		),
+		Zoom: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "zoom pane"),
+		),
+		FocusNext: key.NewBinding(
+			key.WithKeys("tab", "ctrl+w"),
+			key.WithHelp("Tab/C-w", "focus next panel"),
+		),
+		FocusPrev: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("S-Tab", "focus previous panel"),
+		),
+		Pin: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "pin to monitor"),
+		),
+		RecordMacro: key.NewBinding(
+			key.WithKeys("Q"),
+			key.WithHelp("Q", "record macro"),
+		),
+		ReplayMacro: key.NewBinding(
+			key.WithKeys("@"),
+			key.WithHelp("@", "replay macro"),
+		),
 		CopyMode: key.NewBinding(
 			key.WithKeys("y"),
 			key.WithHelp("y", "copy mode"),
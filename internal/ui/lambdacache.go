@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+
+	"vaws/internal/aws"
+	"vaws/internal/model"
+)
+
+// lambdaCache keeps the functions described in the session, so reopening
+// the functions of a stack only describes those not seen yet. It also holds
+// the functions of the last stack load that couldn't be described.
+type lambdaCache struct {
+	described map[string]model.Function // By function name
+	failed    map[string]error          // Of the last stack load, by function name
+	stack     string                    // Stack the failures are of
+}
+
+// remember adds loaded functions to the cache, from a listing or from
+// describing them.
+func (c *lambdaCache) remember(functions []model.Function) {
+	if len(functions) == 0 {
+		return
+	}
+	if c.described == nil {
+		c.described = make(map[string]model.Function)
+	}
+	for _, fn := range functions {
+		c.described[fn.Name] = fn
+	}
+}
+
+// snapshot copies the cache for a load running in the background.
+func (c *lambdaCache) snapshot() map[string]model.Function {
+	return maps.Clone(c.described)
+}
+
+// forget drops the cached functions, so a refresh describes them again.
+func (c *lambdaCache) forget() {
+	c.described = nil
+}
+
+// setFailures records the functions of a stack that couldn't be
+// described, none when the load described them all.
+func (c *lambdaCache) setFailures(stack string, failed map[string]error) {
+	c.stack, c.failed = stack, failed
+}
+
+// failedStatus returns how many functions of the stack are missing from the
+// list, e.g. "2 not described", or "" if none are.
+func (c *lambdaCache) failedStatus(stack *model.Stack) string {
+	if stack == nil || stack.Name != c.stack || len(c.failed) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d not described", len(c.failed))
+}
+
+// describeStackFunctions returns the functions of a stack in the order of
+// names, describing only those not in cached.
+func describeStackFunctions(ctx context.Context, client aws.API, names []string, cached map[string]model.Function) ([]model.Function, map[string]error) {
+	byName := make(map[string]model.Function, len(names))
+	var missing []string
+	for _, name := range names {
+		if fn, ok := cached[name]; ok {
+			byName[name] = fn
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	var failed map[string]error
+	if len(missing) > 0 {
+		var described []model.Function
+		described, failed = client.DescribeFunctions(ctx, missing)
+		for _, fn := range described {
+			byName[fn.Name] = fn
+		}
+	}
+
+	var functions []model.Function
+	for _, name := range names {
+		if fn, ok := byName[name]; ok {
+			functions = append(functions, fn)
+		}
+	}
+	return functions, failed
+}
+
+// logFunctionFailures tells which functions of a stack are missing from the
+// list and why, and opens the logs panel so the summary is seen.
+func (m *Model) logFunctionFailures(stack string, total int, failed map[string]error) {
+	if len(failed) == 0 {
+		return
+	}
+	names := make([]string, 0, len(failed))
+	for name := range failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	m.logger.Warn("Listed %d of %d functions of stack %s; %d couldn't be described:", total-len(failed), total, stack, len(failed))
+	for _, name := range names {
+		m.logger.Warn("  %s: %v", name, failed[name])
+	}
+	m.state.ShowLogs = true
+	m.updateComponentSizes()
+}
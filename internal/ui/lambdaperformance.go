@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+const (
+	// lambdaPerformanceTimeout bounds reading the metrics and sampling the
+	// REPORT lines of a function.
+	lambdaPerformanceTimeout = time.Minute
+
+	// lambdaMemoryTight and lambdaMemoryIdle are the shares of the configured
+	// memory above which the p95 used is too close to it, and below which
+	// even the most used leaves most of it idle.
+	lambdaMemoryTight = 0.9
+	lambdaMemoryIdle  = 0.4
+
+	// lambdaMemoryMin and lambdaMemoryStep are the smallest memory size the
+	// panel suggests and what it rounds suggestions up to, in MB.
+	lambdaMemoryMin  = 128
+	lambdaMemoryStep = 64
+)
+
+// lambdaPerformancePanel is the dialog showing the durations, cold starts
+// and memory use of a Lambda function.
+type lambdaPerformancePanel struct {
+	fn      model.Function
+	perf    *model.LambdaPerformance
+	loading bool
+	err     error
+}
+
+// lambdaPerformanceLoadedMsg carries the performance of a function over a
+// time range.
+type lambdaPerformanceLoadedMsg struct {
+	function string
+	r        model.TimeRange
+	perf     *model.LambdaPerformance
+	err      error
+}
+
+// openLambdaPerformance opens the performance panel of the selected Lambda
+// function over the range chosen for it, the last day at first.
+func (m *Model) openLambdaPerformance() tea.Cmd {
+	if m.client == nil || m.state.View != state.ViewLambda {
+		return nil
+	}
+	item := m.lambdaList.SelectedItem()
+	if item == nil {
+		m.logger.Warn("Performance: no Lambda function selected")
+		return nil
+	}
+	for _, fn := range m.state.Functions {
+		if fn.Name == item.ID {
+			m.lambdaPerformance = &lambdaPerformancePanel{fn: fn}
+			return m.loadLambdaPerformance()
+		}
+	}
+	return nil
+}
+
+// loadLambdaPerformance reads the performance of the panel's function over
+// the chosen range in the background.
+func (m *Model) loadLambdaPerformance() tea.Cmd {
+	lp := m.lambdaPerformance
+	lp.loading = true
+	lp.err = nil
+	client, fn, r := m.client, lp.fn, m.timeRange(state.ViewLambda)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), lambdaPerformanceTimeout)
+		defer cancel()
+		perf, err := client.GetLambdaPerformance(ctx, fn, r)
+		return lambdaPerformanceLoadedMsg{function: fn.Name, r: r, perf: perf, err: err}
+	}
+}
+
+// handleLambdaPerformanceLoaded fills the panel if it is still open on the
+// function and range.
+func (m *Model) handleLambdaPerformanceLoaded(msg lambdaPerformanceLoadedMsg) {
+	if msg.err != nil {
+		m.logger.Error("Failed to read performance of %s: %v", msg.function, msg.err)
+	} else {
+		for _, w := range msg.perf.Warnings {
+			m.logger.Warn("Performance of %s incomplete: %s", msg.function, w)
+		}
+	}
+	lp := m.lambdaPerformance
+	if lp == nil || lp.fn.Name != msg.function || m.timeRange(state.ViewLambda) != msg.r {
+		return
+	}
+	lp.loading = false
+	lp.err = msg.err
+	lp.perf = msg.perf
+}
+
+// handleLambdaPerformanceKey handles key messages while the performance
+// panel is open.
+func (m *Model) handleLambdaPerformanceKey(msg tea.KeyMsg) tea.Cmd {
+	lp := m.lambdaPerformance
+	switch msg.String() {
+	case "esc", "q":
+		m.lambdaPerformance = nil
+	case "w":
+		m.setTimeRange(state.ViewLambda, nextLambdaPerformanceWindow(m.timeRange(state.ViewLambda)))
+		return m.loadLambdaPerformance()
+	case "T":
+		return m.openTimeRangePicker()
+	case "r":
+		if !lp.loading {
+			return m.loadLambdaPerformance()
+		}
+	}
+	return nil
+}
+
+// nextLambdaPerformanceWindow returns the window after r, wrapping around,
+// or the first one after a custom range.
+func nextLambdaPerformanceWindow(r model.TimeRange) model.TimeRange {
+	windows := model.LambdaPerformanceWindows
+	if !r.IsAbsolute() {
+		if i := slices.Index(windows, r.Last); i >= 0 {
+			return model.LastRange(windows[(i+1)%len(windows)])
+		}
+	}
+	return model.LastRange(windows[0])
+}
+
+// memorySuggestion suggests a memory size when the sampled invocations use
+// nearly all of the configured memory, or leave most of it idle. It returns
+// "" when the size fits or too little was sampled to tell.
+func memorySuggestion(p *model.LambdaPerformance) string {
+	if p.Sampled == 0 || p.MemorySize == 0 {
+		return ""
+	}
+	configured := float64(p.MemorySize)
+	switch {
+	case float64(p.MemoryP95) >= configured*lambdaMemoryTight:
+		size := roundMemory(p.MemoryMax * 3 / 2)
+		return fmt.Sprintf("Raise memory to %d MB: invocations use %d%% of %d MB, risking out-of-memory errors",
+			size, int(float64(p.MemoryP95)/configured*100), p.MemorySize)
+	case float64(p.MemoryMax) < configured*lambdaMemoryIdle && p.MemorySize > lambdaMemoryMin:
+		size := roundMemory(p.MemoryMax * 13 / 10)
+		if size >= p.MemorySize {
+			return ""
+		}
+		return fmt.Sprintf("Lower memory to %d MB: no invocation used more than %d MB of %d MB. CPU scales with memory, so check the durations after",
+			size, p.MemoryMax, p.MemorySize)
+	}
+	return ""
+}
+
+// roundMemory rounds a memory size up to a multiple of lambdaMemoryStep, and
+// to at least lambdaMemoryMin.
+func roundMemory(mb int) int {
+	size := (mb + lambdaMemoryStep - 1) / lambdaMemoryStep * lambdaMemoryStep
+	return max(size, lambdaMemoryMin)
+}
+
+// formatMillis renders a duration in ms, e.g. "85 ms" or "2.4 s".
+func formatMillis(ms float64) string {
+	if ms >= 1000 {
+		return fmt.Sprintf("%.1f s", ms/1000)
+	}
+	return fmt.Sprintf("%.0f ms", ms)
+}
+
+// renderLambdaPerformanceDialog renders the duration percentiles, cold starts
+// and memory use of a function, with a memory size suggestion.
+func (m *Model) renderLambdaPerformanceDialog() string {
+	lp := m.lambdaPerformance
+	dialogWidth := min(90, max(m.width-10, 40))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Performance: "+truncateString(lp.fn.Name, dialogWidth-30)) +
+		s.Muted.Render(" · "+components.RangeLabel(m.timeRange(state.ViewLambda)))
+
+	switch {
+	case lp.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Reading metrics and REPORT lines..."))
+	case lp.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(lp.err.Error(), dialogWidth-6)) + "\n\n" +
+			hintStyle.Render("r to retry · w window · T time range · esc to close"))
+	}
+
+	p := lp.perf
+	width := dialogWidth - 6
+	var lines []string
+
+	lines = append(lines, labelStyle.Render("Duration"))
+	if !p.HasDurations {
+		lines = append(lines, s.Muted.Render("  No invocations in the range"))
+	} else {
+		p99Style := s.StatusHealthy
+		if timeout := float64(lp.fn.Timeout) * 1000; timeout > 0 && p.P99 >= timeout*0.8 {
+			p99Style = s.StatusWarning
+		}
+		lines = append(lines, fmt.Sprintf("  %s invocations · p50 %s · p95 %s · %s",
+			format.Count(int64(p.Invocations)), formatMillis(p.P50), formatMillis(p.P95),
+			p99Style.Render("p99 "+formatMillis(p.P99))))
+		if lp.fn.Timeout > 0 {
+			lines = append(lines, s.Muted.Render(fmt.Sprintf("  Timeout %ds", lp.fn.Timeout)))
+		}
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, labelStyle.Render("Cold starts")+s.Muted.Render(fmt.Sprintf(" · %s REPORT lines sampled", format.Count(int64(p.Sampled)))))
+	switch {
+	case p.Sampled == 0:
+		lines = append(lines, s.Muted.Render("  No REPORT lines in the function's log group"))
+	case p.ColdStarts == 0:
+		lines = append(lines, "  None of the sampled invocations")
+	default:
+		lines = append(lines, fmt.Sprintf("  %d of %d sampled (%.0f%%) · init p50 %s · max %s",
+			p.ColdStarts, p.Sampled, float64(p.ColdStarts)/float64(p.Sampled)*100,
+			formatMillis(p.InitP50), formatMillis(p.InitMax)))
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, labelStyle.Render("Memory"))
+	if p.Sampled == 0 {
+		lines = append(lines, s.Muted.Render(fmt.Sprintf("  %d MB configured, use unknown", p.MemorySize)))
+	} else {
+		share := ""
+		if p.MemorySize > 0 {
+			share = fmt.Sprintf(" (%d%%)", int(float64(p.MemoryMax)/float64(p.MemorySize)*100))
+		}
+		lines = append(lines, fmt.Sprintf("  Used p95 %d MB · max %d MB%s of %d MB configured",
+			p.MemoryP95, p.MemoryMax, share, p.MemorySize))
+	}
+	if suggestion := memorySuggestion(p); suggestion != "" {
+		lines = append(lines, "", s.StatusWarning.Width(width).Render(suggestion))
+	}
+
+	if len(p.Warnings) > 0 {
+		lines = append(lines, "")
+		for _, w := range p.Warnings {
+			lines = append(lines, s.StatusWarning.Render(truncateString("! "+w, width)))
+		}
+	}
+
+	content := title + "\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		hintStyle.Render("w window · T time range · r reload · esc")
+	return dialogStyle.Render(content)
+}
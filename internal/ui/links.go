@@ -0,0 +1,73 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/deeplink"
+	"vaws/internal/model"
+)
+
+// SetStartLink makes vaws open at the resource of a link once it is
+// connected, instead of the start view.
+func (m *Model) SetStartLink(link deeplink.Link) {
+	m.startLink = &link
+}
+
+// openLink shows the resource a link points at, in its list filtered to its
+// name.
+func (m *Model) openLink(link deeplink.Link) tea.Cmd {
+	res, err := link.Resource()
+	if err != nil {
+		m.logger.Error("%v", err)
+		return nil
+	}
+	if link.Region != "" && link.Region != m.state.Region {
+		m.logger.Warn("Link is for %s but vaws is on %s; use :region %s", link.Region, m.state.Region, link.Region)
+	}
+	m.logger.Info("Opening %s %s", res.Kind, res.Name)
+
+	var cmd tea.Cmd
+	filter := res.Name
+	switch res.Kind {
+	case deeplink.KindStack:
+		cmd = m.switchToStacks()
+	case deeplink.KindCluster, deeplink.KindService:
+		// Load the clusters too, so going back lands on a full list
+		clusters := m.switchToECS()
+		cluster := model.Cluster{ARN: link.ARN, Name: res.Name}
+		if res.Kind == deeplink.KindService {
+			cluster = model.Cluster{ARN: res.ClusterARN, Name: res.Cluster}
+		} else {
+			filter = ""
+		}
+		m.state.SelectCluster(&cluster)
+		cmd = tea.Batch(clusters, m.loadServicesForCluster())
+	case deeplink.KindFunction:
+		cmd = m.switchToLambda()
+	case deeplink.KindQueue:
+		cmd = m.switchToSQS()
+	case deeplink.KindTable:
+		cmd = m.switchToDynamoDB()
+	}
+
+	m.state.FilterText = filter
+	m.filterInput.SetValue(filter)
+	m.updateCurrentList()
+	return cmd
+}
+
+// copyLink copies the vaws:// link of the resource under the cursor.
+func (m *Model) copyLink() {
+	arn, name := m.selectedARN()
+	if arn == "" {
+		m.logger.Warn("Select a stack, service, function, queue or table to link to")
+		return
+	}
+	link := deeplink.New(m.state.Profile, arn).String()
+	if err := copyToClipboard(link); err != nil {
+		m.logger.Warn("Clipboard not available: %v", err)
+		m.logger.Info("Link to %s: %s", name, link)
+		return
+	}
+	m.logger.Info("Copied link to %s: %s", name, link)
+}
@@ -2,25 +2,49 @@ package ui
 
 import (
 	"context"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/service/sqs"
-	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"vaws/internal/model"
+	"vaws/internal/state"
 )
 
+// activityEventLimit is how many CloudTrail events the activity feed shows.
+const activityEventLimit = 50
+
+// logSearchHitLimit is how many matches a stack log search shows.
+const logSearchHitLimit = 500
+
+// cloudWatchFetchLimit is how many log entries one fetch reads.
+const cloudWatchFetchLimit = 100
+
+// fetchCurrentCloudWatchLogs fetches the next logs of what the CloudWatch
+// logs view shows: a Lambda function or access log group across its
+// streams, or the stream of the selected container.
+func (m *Model) fetchCurrentCloudWatchLogs() tea.Cmd {
+	if m.state.CloudWatchLambdaContext != nil {
+		return m.fetchLambdaCloudWatchLogs("/aws/lambda/" + m.state.CloudWatchLambdaContext.Name)
+	}
+	if m.state.CloudWatchAccessLogContext != "" {
+		return m.fetchLambdaCloudWatchLogs(m.state.CloudWatchLogConfigs[0].LogGroup)
+	}
+	return m.fetchCloudWatchLogs()
+}
+
 // fetchCloudWatchLogs fetches CloudWatch logs for the selected container.
 func (m *Model) fetchCloudWatchLogs() tea.Cmd {
 	config := m.cloudWatchLogsPanel.SelectedContainer()
 	if config == nil {
 		return nil
 	}
+	if m.mergedTail != nil && m.mergedTail.listed {
+		return m.fetchMergedCloudWatchLogs(*config)
+	}
 
 	startTime := m.state.CloudWatchLastFetchTime
+	seq := m.cloudWatchLogsSeq
 
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -31,12 +55,13 @@ func (m *Model) fetchCloudWatchLogs() tea.Cmd {
 			config.LogGroup,
 			config.LogStreamName,
 			startTime,
-			100, // Limit per fetch
+			cloudWatchFetchLimit,
 		)
 
 		return cloudWatchLogsLoadedMsg{
 			entries:       entries,
 			lastTimestamp: lastTimestamp,
+			seq:           seq,
 			err:           err,
 		}
 	}
@@ -45,6 +70,7 @@ func (m *Model) fetchCloudWatchLogs() tea.Cmd {
 // fetchLambdaCloudWatchLogs fetches CloudWatch logs for a Lambda function.
 func (m *Model) fetchLambdaCloudWatchLogs(logGroup string) tea.Cmd {
 	startTime := m.state.CloudWatchLastFetchTime
+	seq := m.cloudWatchLogsSeq
 
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -54,12 +80,13 @@ func (m *Model) fetchLambdaCloudWatchLogs(logGroup string) tea.Cmd {
 			ctx,
 			logGroup,
 			startTime,
-			100, // Limit per fetch
+			cloudWatchFetchLimit,
 		)
 
 		return cloudWatchLogsLoadedMsg{
 			entries:       entries,
 			lastTimestamp: lastTimestamp,
+			seq:           seq,
 			err:           err,
 		}
 	}
@@ -67,6 +94,7 @@ func (m *Model) fetchLambdaCloudWatchLogs(logGroup string) tea.Cmd {
 
 // loadStacks loads CloudFormation stacks.
 func (m *Model) loadStacks() tea.Cmd {
+	m.warmStacksList()
 	m.state.StacksLoading = true
 	m.stacksList.SetLoading(true)
 	m.splash.SetLoading("Loading CloudFormation stacks...")
@@ -77,7 +105,7 @@ func (m *Model) loadStacks() tea.Cmd {
 		func() tea.Msg {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
-			stacks, err := m.client.ListStacks(ctx)
+			stacks, err := m.client.ListStacks(m.withProgress(ctx, m.stacksList.Progress()))
 			return stacksLoadedMsg{stacks: stacks, err: err}
 		},
 	)
@@ -89,6 +117,7 @@ func (m *Model) loadServices() tea.Cmd {
 		return nil
 	}
 
+	m.warmServicesList()
 	m.state.ServicesLoading = true
 	m.serviceList.SetLoading(true)
 	stackName := m.state.SelectedStack.Name
@@ -99,7 +128,7 @@ func (m *Model) loadServices() tea.Cmd {
 		func() tea.Msg {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
-			services, err := m.client.GetServicesForStack(ctx, stackName)
+			services, err := m.client.GetServicesForStack(m.withProgress(ctx, m.serviceList.Progress()), stackName)
 			return servicesLoadedMsg{services: services, err: err}
 		},
 	)
@@ -111,6 +140,7 @@ func (m *Model) loadServicesForCluster() tea.Cmd {
 		return nil
 	}
 
+	m.warmServicesList()
 	m.state.ServicesLoading = true
 	m.serviceList.SetLoading(true)
 	clusterARN := m.state.SelectedCluster.ARN
@@ -122,14 +152,40 @@ func (m *Model) loadServicesForCluster() tea.Cmd {
 		func() tea.Msg {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
-			services, err := m.client.ListServices(ctx, clusterARN)
+			services, err := m.client.ListServices(m.withProgress(ctx, m.serviceList.Progress()), clusterARN)
 			return servicesLoadedMsg{services: services, err: err}
 		},
 	)
 }
 
+// reloadServices reloads the services view from where it was opened: the
+// selected cluster, or otherwise the selected stack.
+func (m *Model) reloadServices() tea.Cmd {
+	if m.state.SelectedCluster != nil {
+		return m.loadServicesForCluster()
+	}
+	return m.loadServices()
+}
+
+// refreshable is a list or table that can keep its items on screen while reloading.
+type refreshable interface {
+	SetRefreshing(refreshing bool)
+}
+
+// refreshInPlace runs load as a refresh of list, so the current items stay on
+// screen until the new ones arrive.
+func (m *Model) refreshInPlace(list refreshable, load func() tea.Cmd) tea.Cmd {
+	list.SetRefreshing(true)
+	cmd := load()
+	if cmd == nil {
+		list.SetRefreshing(false)
+	}
+	return cmd
+}
+
 // loadFunctions loads Lambda functions with lazy loading.
 func (m *Model) loadFunctions() tea.Cmd {
+	m.warmFunctionsList()
 	m.state.FunctionsLoading = true
 	m.lambdaList.SetLoading(true)
 
@@ -144,6 +200,7 @@ func (m *Model) loadFunctions() tea.Cmd {
 
 	// Use channel to receive incremental results
 	resultChan := make(chan functionsLoadedMsg, 10)
+	cached := m.lambdas.snapshot()
 
 	// Start background loading
 	go func() {
@@ -164,15 +221,8 @@ func (m *Model) loadFunctions() tea.Cmd {
 				return
 			}
 
-			var functions []model.Function
-			for _, name := range functionNames {
-				fn, err := m.client.DescribeFunction(ctx, name)
-				if err != nil {
-					continue
-				}
-				functions = append(functions, *fn)
-			}
-			resultChan <- functionsLoadedMsg{functions: functions, err: nil}
+			functions, failed := describeStackFunctions(ctx, m.client, functionNames, cached)
+			resultChan <- functionsLoadedMsg{functions: functions, stack: stackName, total: len(functionNames), failed: failed}
 			return
 		}
 
@@ -322,6 +372,442 @@ func (m *Model) loadEC2Instances() tea.Cmd {
 	)
 }
 
+// loadAppRunnerServices loads App Runner services.
+func (m *Model) loadAppRunnerServices() tea.Cmd {
+	m.state.AppRunnerLoading = true
+	m.appRunnerList.SetLoading(true)
+	m.logger.Info("Loading App Runner services...")
+
+	return tea.Batch(
+		m.appRunnerList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			services, err := m.client.ListAppRunnerServices(m.withProgress(ctx, m.appRunnerList.Progress()))
+			return appRunnerServicesLoadedMsg{services: services, err: err}
+		},
+	)
+}
+
+// loadAppRunnerOperations loads the recent operations of an App Runner service.
+func (m *Model) loadAppRunnerOperations(serviceARN string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		ops, err := m.client.ListAppRunnerOperations(ctx, serviceARN, 10) // Most recent operations
+		return appRunnerOperationsLoadedMsg{serviceARN: serviceARN, operations: ops, err: err}
+	}
+}
+
+// loadDeliveryStreams loads Firehose delivery streams.
+func (m *Model) loadDeliveryStreams() tea.Cmd {
+	m.state.FirehoseLoading = true
+	m.firehoseList.SetLoading(true)
+	m.logger.Info("Loading Firehose delivery streams...")
+
+	return tea.Batch(
+		m.firehoseList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			streams, err := m.client.ListDeliveryStreams(m.withProgress(ctx, m.firehoseList.Progress()))
+			return deliveryStreamsLoadedMsg{streams: streams, err: err}
+		},
+	)
+}
+
+// loadDeliveryErrors loads the delivery errors a stream logged in the last hour.
+func (m *Model) loadDeliveryErrors(stream model.DeliveryStream) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		entries, err := m.client.FetchDeliveryErrors(ctx, &stream, time.Now().Add(-time.Hour), 20)
+		return deliveryErrorsLoadedMsg{streamName: stream.Name, errors: entries, err: err}
+	}
+}
+
+// loadUserPools loads Cognito user pools.
+func (m *Model) loadUserPools() tea.Cmd {
+	m.state.UserPoolsLoading = true
+	m.userPoolList.SetLoading(true)
+	m.logger.Info("Loading Cognito user pools...")
+
+	return tea.Batch(
+		m.userPoolList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			pools, err := m.client.ListUserPools(m.withProgress(ctx, m.userPoolList.Progress()))
+			return userPoolsLoadedMsg{pools: pools, err: err}
+		},
+	)
+}
+
+// loadUserPoolDetails loads the configuration and app clients of a user pool.
+func (m *Model) loadUserPoolDetails(poolID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		details, err := m.client.GetUserPoolDetails(ctx, poolID)
+		return userPoolDetailsLoadedMsg{details: details, err: err}
+	}
+}
+
+// loadCognitoUsers runs the current user search again.
+func (m *Model) loadCognitoUsers() tea.Cmd {
+	pool := m.state.SelectedUserPool
+	query := m.state.CognitoUserQuery
+	if pool == nil || query == "" {
+		return nil
+	}
+
+	m.state.CognitoUsersLoading = true
+	m.cognitoUserList.SetLoading(true)
+	m.logger.Info("Searching users in %s for %q...", pool.Name, query)
+
+	poolID := pool.ID
+	return tea.Batch(
+		m.cognitoUserList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			users, err := m.client.SearchUsers(ctx, poolID, query)
+			return cognitoUsersLoadedMsg{query: query, users: users, err: err}
+		},
+	)
+}
+
+// loadMSKClusters loads MSK clusters.
+func (m *Model) loadMSKClusters() tea.Cmd {
+	m.state.MSKLoading = true
+	m.mskList.SetLoading(true)
+	m.logger.Info("Loading MSK clusters...")
+
+	return tea.Batch(
+		m.mskList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			clusters, err := m.client.ListMSKClusters(m.withProgress(ctx, m.mskList.Progress()))
+			return mskClustersLoadedMsg{clusters: clusters, err: err}
+		},
+	)
+}
+
+// loadSES loads the SES account status, identities and configuration sets.
+func (m *Model) loadSES() tea.Cmd {
+	m.state.SESLoading = true
+	m.sesList.SetLoading(true)
+	m.logger.Info("Loading SES account...")
+
+	return tea.Batch(
+		m.sesList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			ctx = m.withProgress(ctx, m.sesList.Progress())
+
+			account, err := m.client.GetSESAccount(ctx)
+			if err != nil {
+				return sesLoadedMsg{err: err}
+			}
+			identities, err := m.client.ListSESIdentities(ctx)
+			if err != nil {
+				return sesLoadedMsg{err: err}
+			}
+			sets, err := m.client.ListSESConfigurationSets(ctx)
+			return sesLoadedMsg{account: account, identities: identities, sets: sets, err: err}
+		},
+	)
+}
+
+// loadSESSuppressions loads the SES account suppression list.
+func (m *Model) loadSESSuppressions() tea.Cmd {
+	m.state.SESSuppressionsLoading = true
+	m.sesSuppressionList.SetLoading(true)
+	m.logger.Info("Loading SES suppression list...")
+
+	return tea.Batch(
+		m.sesSuppressionList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			destinations, truncated, err := m.client.ListSuppressedDestinations(m.withProgress(ctx, m.sesSuppressionList.Progress()))
+			return sesSuppressionsLoadedMsg{destinations: destinations, truncated: truncated, err: err}
+		},
+	)
+}
+
+// loadActivity loads the latest CloudTrail events of the resource the
+// activity feed was opened on.
+func (m *Model) loadActivity() tea.Cmd {
+	if m.client == nil || len(m.state.ActivityNames) == 0 {
+		return nil
+	}
+	m.state.ActivityLoading = true
+	m.activityList.SetLoading(true)
+	m.logger.Info("Loading CloudTrail activity of %s...", m.state.ActivityResource)
+
+	names, r := m.state.ActivityNames, m.timeRange(state.ViewActivity)
+	return tea.Batch(
+		m.activityList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			events, err := m.client.ListResourceActivity(ctx, names, r, activityEventLimit)
+			return activityLoadedMsg{names: names, r: r, events: events, err: err}
+		},
+	)
+}
+
+// loadLogSearch finds the log groups of the searched stack and runs the
+// pattern over all of them.
+func (m *Model) loadLogSearch() tea.Cmd {
+	if m.client == nil || m.state.LogSearchStack == "" {
+		return nil
+	}
+	m.state.LogSearchLoading = true
+	m.logSearchList.SetLoading(true)
+	m.logger.Info("Searching logs of stack %s for %q...", m.state.LogSearchStack, m.state.LogSearchPattern)
+
+	stack, pattern, r := m.state.LogSearchStack, m.state.LogSearchPattern, m.state.LogSearchRange
+	sources := m.state.LogSearchSources
+	return tea.Batch(
+		m.logSearchList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			ctx = m.withProgress(ctx, m.logSearchList.Progress())
+
+			// Log groups are looked up once per search and reused on refresh
+			if sources == nil {
+				var err error
+				sources, err = m.client.StackLogSources(ctx, stack)
+				if err != nil {
+					return logSearchLoadedMsg{stack: stack, pattern: pattern, r: r, err: err}
+				}
+			}
+			hits, err := m.client.SearchLogGroups(ctx, sources, pattern, r, logSearchHitLimit)
+			return logSearchLoadedMsg{stack: stack, pattern: pattern, r: r, sources: sources, hits: hits, err: err}
+		},
+	)
+}
+
+// loadHealth runs the account health checks.
+func (m *Model) loadHealth() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	m.state.HealthLoading = true
+	m.healthList.SetLoading(true)
+	m.logger.Info("Checking account health...")
+
+	return tea.Batch(
+		m.healthList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			return healthLoadedMsg{health: m.client.GetAccountHealth(m.withProgress(ctx, m.healthList.Progress()))}
+		},
+	)
+}
+
+// loadMQBrokers loads Amazon MQ brokers.
+func (m *Model) loadMQBrokers() tea.Cmd {
+	m.state.MQLoading = true
+	m.mqList.SetLoading(true)
+	m.logger.Info("Loading MQ brokers...")
+
+	return tea.Batch(
+		m.mqList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			brokers, err := m.client.ListMQBrokers(m.withProgress(ctx, m.mqList.Progress()))
+			return mqBrokersLoadedMsg{brokers: brokers, err: err}
+		},
+	)
+}
+
+// loadDatabases loads the RDS, RDS Proxy and Redshift endpoints.
+func (m *Model) loadDatabases() tea.Cmd {
+	m.state.DatabasesLoading = true
+	m.databaseList.SetLoading(true)
+	m.logger.Info("Loading databases...")
+
+	return tea.Batch(
+		m.databaseList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			databases, err := m.client.ListDatabases(m.withProgress(ctx, m.databaseList.Progress()))
+			return databasesLoadedMsg{databases: databases, err: err}
+		},
+	)
+}
+
+// loadInstances loads the instances of the EC2 view.
+func (m *Model) loadInstances() tea.Cmd {
+	m.state.InstancesLoading = true
+	m.instanceList.SetLoading(true)
+	m.logger.Info("Loading EC2 instances...")
+
+	return tea.Batch(
+		m.instanceList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			instances, err := m.client.ListInstances(ctx)
+			return instancesLoadedMsg{instances: instances, err: err}
+		},
+	)
+}
+
+// loadEFSFileSystems loads EFS file systems.
+func (m *Model) loadEFSFileSystems() tea.Cmd {
+	m.state.EFSLoading = true
+	m.efsList.SetLoading(true)
+	m.logger.Info("Loading EFS file systems...")
+
+	return tea.Batch(
+		m.efsList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			fileSystems, err := m.client.ListEFSFileSystems(m.withProgress(ctx, m.efsList.Progress()))
+			return efsFileSystemsLoadedMsg{fileSystems: fileSystems, err: err}
+		},
+	)
+}
+
+// loadEFSDetails loads the mount targets, access points and mounts of a file
+// system. Finding the mounts reads every active task definition family, so it
+// gets a longer timeout.
+func (m *Model) loadEFSDetails(fileSystemID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		details, err := m.client.GetEFSDetails(m.withProgress(ctx, m.efsList.Progress()), fileSystemID)
+		return efsDetailsLoadedMsg{details: details, err: err}
+	}
+}
+
+// loadSchedules loads EventBridge Scheduler schedules.
+func (m *Model) loadSchedules() tea.Cmd {
+	m.state.SchedulesLoading = true
+	m.schedulesList.SetLoading(true)
+	m.logger.Info("Loading schedules...")
+
+	return tea.Batch(
+		m.schedulesList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			schedules, err := m.client.ListSchedules(m.withProgress(ctx, m.schedulesList.Progress()))
+			return schedulesLoadedMsg{schedules: schedules, err: err}
+		},
+	)
+}
+
+// loadSecrets loads Secrets Manager secrets.
+func (m *Model) loadSecrets() tea.Cmd {
+	m.state.SecretsLoading = true
+	m.secretsList.SetLoading(true)
+	m.logger.Info("Loading secrets...")
+
+	return tea.Batch(
+		m.secretsList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			secrets, err := m.client.ListSecrets(m.withProgress(ctx, m.secretsList.Progress()))
+			return secretsLoadedMsg{secrets: secrets, err: err}
+		},
+	)
+}
+
+// loadLogGroups loads CloudWatch Logs log groups.
+func (m *Model) loadLogGroups() tea.Cmd {
+	m.state.LogGroupsLoading = true
+	m.logGroupsList.SetLoading(true)
+	m.logger.Info("Loading log groups...")
+
+	return tea.Batch(
+		m.logGroupsList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			groups, err := m.client.ListLogGroups(m.withProgress(ctx, m.logGroupsList.Progress()))
+			return logGroupsLoadedMsg{groups: groups, err: err}
+		},
+	)
+}
+
+// loadMSKBrokers loads the bootstrap brokers of an MSK cluster.
+func (m *Model) loadMSKBrokers(clusterARN string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		brokers, err := m.client.GetMSKBootstrapBrokers(ctx, clusterARN)
+		return mskBrokersLoadedMsg{brokers: brokers, err: err}
+	}
+}
+
+// loadCloudResources loads the resources of the current Cloud Control type.
+func (m *Model) loadCloudResources() tea.Cmd {
+	typeName := m.state.CloudResourceType
+	if typeName == "" {
+		return nil
+	}
+
+	m.state.CloudResourcesLoading = true
+	m.cloudResourceList.SetLoading(true)
+	m.logger.Info("Loading %s resources...", typeName)
+
+	return tea.Batch(
+		m.cloudResourceList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			resources, err := m.client.ListResources(m.withProgress(ctx, m.cloudResourceList.Progress()), typeName)
+			return cloudResourcesLoadedMsg{typeName: typeName, resources: resources, err: err}
+		},
+	)
+}
+
+// loadCloudResource loads the full properties of a resource.
+func (m *Model) loadCloudResource(resource model.CloudResource) tea.Cmd {
+	m.logger.Info("Getting %s", resource.Identifier)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		full, err := m.client.GetResource(ctx, resource.TypeName, resource.Identifier)
+		return cloudResourceLoadedMsg{resource: full, err: err}
+	}
+}
+
 // loadQueues loads SQS queues with lazy loading.
 func (m *Model) loadQueues() tea.Cmd {
 	m.state.QueuesLoading = true
@@ -437,17 +923,11 @@ func (m *Model) enrichQueuesWithDLQ(ctx context.Context, queues []model.Queue) [
 		if queues[i].HasDLQ && queues[i].DLQArn != "" {
 			dlqURL, ok := dlqURLMap[queues[i].DLQArn]
 			if ok {
-				out, err := m.client.SQS().GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
-					QueueUrl:       &dlqURL,
-					AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameApproximateNumberOfMessages},
-				})
+				dlq, err := m.client.GetQueueAttributes(ctx, dlqURL)
 				if err == nil {
-					if countStr, ok := out.Attributes[string(sqstypes.QueueAttributeNameApproximateNumberOfMessages)]; ok {
-						count, _ := strconv.Atoi(countStr)
-						queues[i].DLQMessageCount = count
-						queues[i].DLQURL = dlqURL
-						queues[i].DLQName = extractQueueNameFromURL(dlqURL)
-					}
+					queues[i].DLQMessageCount = dlq.ApproximateMessageCount
+					queues[i].DLQURL = dlqURL
+					queues[i].DLQName = extractQueueNameFromURL(dlqURL)
 				}
 			}
 		}
@@ -503,13 +983,14 @@ func (m *Model) loadAPIStages() tea.Cmd {
 
 // loadClusters loads ECS clusters.
 func (m *Model) loadClusters() tea.Cmd {
+	m.warmClustersList()
 	m.state.ClustersLoading = true
 	m.clustersList.SetLoading(true)
 
 	return tea.Batch(
 		m.clustersList.Spinner().TickCmd(),
 		func() tea.Msg {
-			clusters, err := m.client.ListClusters(context.Background())
+			clusters, err := m.client.ListClusters(m.withProgress(context.Background(), m.clustersList.Progress()))
 			if err != nil {
 				return errMsg{err: err}
 			}
@@ -520,6 +1001,7 @@ func (m *Model) loadClusters() tea.Cmd {
 
 // loadTables loads DynamoDB tables with lazy loading.
 func (m *Model) loadTables() tea.Cmd {
+	m.warmTablesList()
 	m.state.TablesLoading = true
 	m.dynamodbTable.SetLoading(true)
 	m.logger.Info("Loading DynamoDB tables...")
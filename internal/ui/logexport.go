@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"vaws/internal/model"
+)
+
+// logExport is a file the CloudWatch logs being tailed are appended to.
+type logExport struct {
+	path    string
+	entries int
+}
+
+// toggleLogExport writes the loaded CloudWatch log entries to a file, or
+// stops the export running. While the logs stream, new entries go on being
+// appended until it is stopped or the logs are left. The file goes to the
+// working directory, named after the logs and the time, unless a path is
+// given; a given path must not exist yet.
+func (m *Model) toggleLogExport(path string) {
+	if m.logExport != nil {
+		m.stopLogExport("")
+		return
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_EXCL
+	if path == "" {
+		path = fmt.Sprintf("logs-%s-%s.log", logFileName(m.cloudWatchLogsLabel()), time.Now().Format("20060102-150405"))
+		flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+	path = expandHome(path)
+
+	f, err := os.OpenFile(path, flags, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		m.logger.Error("Logs not exported: %s already exists", path)
+		return
+	}
+	if err != nil {
+		m.logger.Error("Failed to export logs: %v", err)
+		return
+	}
+	err = writeLogEntries(f, m.state.CloudWatchLogs)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		m.logger.Error("Failed to export logs: %v", err)
+		return
+	}
+
+	n := len(m.state.CloudWatchLogs)
+	if !m.state.CloudWatchLogsStreaming {
+		m.logger.Info("Exported %d log entries to %s", n, path)
+		return
+	}
+	m.logExport = &logExport{path: path, entries: n}
+	m.logger.Info("Exported %d log entries to %s; new ones are appended while tailing (ctrl+s to stop)", n, path)
+}
+
+// appendLogExport appends newly loaded entries to the running export.
+func (m *Model) appendLogExport(entries []model.CloudWatchLogEntry) {
+	e := m.logExport
+	if e == nil || len(entries) == 0 {
+		return
+	}
+	f, err := os.OpenFile(e.path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err == nil {
+		err = writeLogEntries(f, entries)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		m.logExport = nil
+		m.logger.Error("Stopped exporting logs to %s: %v", e.path, err)
+		return
+	}
+	e.entries += len(entries)
+}
+
+// stopLogExport stops the running export, saying why if reason is set.
+func (m *Model) stopLogExport(reason string) {
+	e := m.logExport
+	if e == nil {
+		return
+	}
+	m.logExport = nil
+	if reason != "" {
+		reason = " (" + reason + ")"
+	}
+	m.logger.Info("Stopped exporting logs%s: %d entries in %s", reason, e.entries, e.path)
+}
+
+// writeLogEntries writes a line per entry: its time in UTC, its stream and
+// its message.
+func writeLogEntries(f *os.File, entries []model.CloudWatchLogEntry) error {
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(e.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z"))
+		if e.LogStreamName != "" {
+			b.WriteString(" [" + e.LogStreamName + "]")
+		}
+		b.WriteString(" " + strings.TrimRight(e.Message, "\n") + "\n")
+	}
+	_, err := f.WriteString(b.String())
+	return err
+}
+
+// cloudWatchLogsLabel names the logs being viewed: the Lambda function, the
+// API stage, or the service.
+func (m *Model) cloudWatchLogsLabel() string {
+	switch {
+	case m.state.CloudWatchLambdaContext != nil:
+		return m.state.CloudWatchLambdaContext.Name
+	case m.state.CloudWatchAccessLogContext != "":
+		return m.state.CloudWatchAccessLogContext
+	case m.state.CloudWatchServiceContext != nil:
+		return m.state.CloudWatchServiceContext.Name
+	}
+	return "cloudwatch"
+}
+
+// logFileName makes a name safe to use in a file name, replacing what isn't
+// a letter, digit, dot, dash or underscore.
+func logFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, name)
+}
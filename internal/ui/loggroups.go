@@ -0,0 +1,353 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/theme"
+)
+
+// logGroupBatchTimeout bounds a bulk retention change or deletion, which
+// calls CloudWatch Logs once per group.
+const logGroupBatchTimeout = 2 * time.Minute
+
+// logGroupsNamed is how many groups a confirm dialog names before summing up
+// the rest.
+const logGroupsNamed = 5
+
+// retentionDialog asks for the retention to give a set of log groups.
+type retentionDialog struct {
+	groups []model.LogGroup
+	input  textinput.Model // Days, or "never"
+}
+
+// selectedLogGroup returns the log group under the cursor.
+func (m *Model) selectedLogGroup() *model.LogGroup {
+	item := m.logGroupsList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.LogGroups {
+		if m.state.LogGroups[i].Name == item.ID {
+			return &m.state.LogGroups[i]
+		}
+	}
+	return nil
+}
+
+// retentionText describes a retention period, e.g. "30 days" or "never
+// expires".
+func retentionText(days int32) string {
+	switch days {
+	case 0:
+		return "never expires"
+	case 1:
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+// logGroupTargets returns the groups a bulk action applies to: the marked
+// ones, or the one under the cursor if none are marked.
+func (m *Model) logGroupTargets() []model.LogGroup {
+	var groups []model.LogGroup
+	for _, g := range m.state.LogGroups {
+		if m.logGroupMarks[g.Name] {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) == 0 {
+		if g := m.selectedLogGroup(); g != nil {
+			groups = append(groups, *g)
+		}
+	}
+	return groups
+}
+
+// toggleLogGroupMark marks the group under the cursor for a bulk action, or
+// unmarks it, and moves to the next group.
+func (m *Model) toggleLogGroupMark() {
+	g := m.selectedLogGroup()
+	if g == nil {
+		return
+	}
+	if m.logGroupMarks == nil {
+		m.logGroupMarks = make(map[string]bool)
+	}
+	if m.logGroupMarks[g.Name] {
+		delete(m.logGroupMarks, g.Name)
+	} else {
+		m.logGroupMarks[g.Name] = true
+	}
+	m.updateLogGroupsList()
+	m.moveCursorDown()
+}
+
+// markOrphanedLogGroups marks the orphaned groups the filter shows, or
+// unmarks them all if they already are.
+func (m *Model) markOrphanedLogGroups() {
+	var orphaned []string
+	for _, g := range m.state.FilteredLogGroups() {
+		if g.Orphaned {
+			orphaned = append(orphaned, g.Name)
+		}
+	}
+	if len(orphaned) == 0 {
+		m.logger.Info("No orphaned log groups found")
+		return
+	}
+	if m.logGroupMarks == nil {
+		m.logGroupMarks = make(map[string]bool)
+	}
+	all := true
+	for _, name := range orphaned {
+		all = all && m.logGroupMarks[name]
+	}
+	for _, name := range orphaned {
+		if all {
+			delete(m.logGroupMarks, name)
+		} else {
+			m.logGroupMarks[name] = true
+		}
+	}
+	if all {
+		m.logger.Info("Unmarked %d orphaned log groups", len(orphaned))
+	} else {
+		m.logger.Info("Marked %d orphaned log groups", len(orphaned))
+	}
+	m.updateLogGroupsList()
+}
+
+// logGroupNames lists the names of groups for a confirm dialog, summing up
+// those past logGroupsNamed.
+func logGroupNames(groups []model.LogGroup) []string {
+	var lines []string
+	for i, g := range groups {
+		if i == logGroupsNamed {
+			lines = append(lines, fmt.Sprintf("  and %d more", len(groups)-logGroupsNamed))
+			break
+		}
+		lines = append(lines, "  "+g.Name)
+	}
+	return lines
+}
+
+// logGroupsCount describes a number of groups, e.g. "3 log groups".
+func logGroupsCount(n int) string {
+	if n == 1 {
+		return "1 log group"
+	}
+	return fmt.Sprintf("%d log groups", n)
+}
+
+// openRetentionDialog asks for the retention to give the marked groups, or
+// the one under the cursor.
+func (m *Model) openRetentionDialog() tea.Cmd {
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+	groups := m.logGroupTargets()
+	if len(groups) == 0 {
+		return nil
+	}
+	input := textinput.New()
+	input.Placeholder = "30"
+	input.CharLimit = 5
+	input.Width = 10
+	input.Focus()
+	m.retention = &retentionDialog{groups: groups, input: input}
+	return textinput.Blink
+}
+
+// handleRetentionKey handles key messages while the retention dialog is
+// open.
+func (m *Model) handleRetentionKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.retention = nil
+		return nil
+	case "enter":
+		return m.submitRetention()
+	}
+	var cmd tea.Cmd
+	m.retention.input, cmd = m.retention.input.Update(msg)
+	return cmd
+}
+
+// submitRetention asks to set the retention typed on the dialog's groups.
+func (m *Model) submitRetention() tea.Cmd {
+	r := m.retention
+	value := strings.ToLower(strings.TrimSpace(r.input.Value()))
+	var days int32
+	if value != "never" && value != "0" {
+		n, err := strconv.Atoi(value)
+		if err != nil || !slices.Contains(model.LogRetentionDays, int32(n)) {
+			m.logger.Warn("Invalid retention %q: use never or one of %s days", value, joinDays(model.LogRetentionDays))
+			return nil
+		}
+		days = int32(n)
+	}
+	m.retention = nil
+
+	groups := r.groups
+	unchanged := 0
+	var stored int64
+	for _, g := range groups {
+		if g.RetentionDays == days {
+			unchanged++
+		}
+		stored += g.StoredBytes
+	}
+	details := []string{"Retention: " + retentionText(days), "Stored: " + formatBytes(stored)}
+	if unchanged > 0 {
+		details = append(details, fmt.Sprintf("%d already keep events for %s", unchanged, retentionText(days)))
+	}
+	if days > 0 {
+		details = append(details, "Events older than that are deleted within a few days")
+	}
+	details = append(details, logGroupNames(groups)...)
+	return m.askConfirm("Set retention of "+logGroupsCount(len(groups)), details, func() tea.Cmd {
+		client := m.client
+		return m.updateLogGroups("retention", groups, func(ctx context.Context, name string) error {
+			return client.SetLogGroupRetention(ctx, name, days)
+		})
+	})
+}
+
+// joinDays lists retention periods for a message, e.g. "1, 3, 5".
+func joinDays(days []int32) string {
+	parts := make([]string, len(days))
+	for i, d := range days {
+		parts[i] = strconv.Itoa(int(d))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// handleLogGroupDelete asks to delete the marked groups, or the one under
+// the cursor. Groups with deletion protection are left out.
+func (m *Model) handleLogGroupDelete() tea.Cmd {
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+	var groups []model.LogGroup
+	for _, g := range m.logGroupTargets() {
+		if g.DeletionProtected {
+			m.logger.Warn("Skipping %s: deletion protection is on", g.Name)
+			continue
+		}
+		groups = append(groups, g)
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	live := 0
+	var stored int64
+	for _, g := range groups {
+		if !g.Orphaned {
+			live++
+		}
+		stored += g.StoredBytes
+	}
+	details := []string{"Stored: " + formatBytes(stored) + ", deleted for good"}
+	if live > 0 {
+		details = append(details, fmt.Sprintf("%d not known to be orphaned; their resources may still log to them", live))
+	}
+	details = append(details, logGroupNames(groups)...)
+	return m.askConfirm("Delete "+logGroupsCount(len(groups)), details, func() tea.Cmd {
+		client := m.client
+		return m.updateLogGroups("delete", groups, func(ctx context.Context, name string) error {
+			return client.DeleteLogGroup(ctx, name)
+		})
+	})
+}
+
+// updateLogGroups runs a retention change or deletion on each group in the
+// background, one call at a time to stay clear of the CloudWatch Logs rate
+// limits.
+func (m *Model) updateLogGroups(action string, groups []model.LogGroup, update func(ctx context.Context, name string) error) tea.Cmd {
+	if action == "delete" {
+		m.logger.Info("Deleting %s...", logGroupsCount(len(groups)))
+	} else {
+		m.logger.Info("Setting retention of %s...", logGroupsCount(len(groups)))
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), logGroupBatchTimeout)
+		defer cancel()
+		msg := logGroupsUpdatedMsg{action: action}
+		for _, g := range groups {
+			if err := update(ctx, g.Name); err != nil {
+				msg.errs = append(msg.errs, err)
+				continue
+			}
+			msg.done = append(msg.done, g.Name)
+		}
+		return msg
+	}
+}
+
+// handleLogGroupsUpdated logs the result of a bulk action, unmarks the groups
+// it handled and reloads the groups.
+func (m *Model) handleLogGroupsUpdated(msg logGroupsUpdatedMsg) tea.Cmd {
+	for _, err := range msg.errs {
+		m.logger.Error("%v", err)
+	}
+	for _, name := range msg.done {
+		delete(m.logGroupMarks, name)
+	}
+	if len(msg.done) > 0 {
+		if msg.action == "delete" {
+			m.logger.Info("Deleted %s", logGroupsCount(len(msg.done)))
+		} else {
+			m.logger.Info("Set retention of %s", logGroupsCount(len(msg.done)))
+		}
+	}
+	if m.state.View != state.ViewLogGroups {
+		return nil
+	}
+	return m.loadLogGroups()
+}
+
+// renderRetentionDialog renders the retention input.
+func (m *Model) renderRetentionDialog() string {
+	r := m.retention
+	dialogWidth := 70
+	if m.width < 80 {
+		dialogWidth = max(m.width-10, 40)
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	target := r.groups[0].Name
+	if len(r.groups) > 1 {
+		target = logGroupsCount(len(r.groups))
+	}
+	content := labelStyle.Render("Retention: "+truncateString(target, dialogWidth-18)) + "\n\n" +
+		"Days: " + r.input.View() + "\n\n" +
+		hintStyle.Render("never, or "+joinDays(model.LogRetentionDays)) + "\n" +
+		hintStyle.Render("enter to continue · esc to cancel")
+	return dialogStyle.Render(content)
+}
@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+)
+
+const (
+	// macroStepDelay is the pause between replayed keys, giving views time to
+	// update before the next key lands.
+	macroStepDelay = 50 * time.Millisecond
+
+	// macroLoadTimeout is how long replay waits for a list to finish loading
+	// before sending the next key anyway.
+	macroLoadTimeout = 30 * time.Second
+)
+
+// macroStepMsg sends the next key of the macro being replayed.
+type macroStepMsg struct {
+	gen int
+}
+
+// macroState holds the recording in progress and the replay in progress.
+type macroState struct {
+	recording bool
+	recorded  []string // Keys of the current or last recording
+	playing   []tea.KeyMsg
+	name      string // Name of the macro being replayed
+	step      int
+	waited    time.Duration
+	gen       int // Bumped to drop the steps of a replay that was stopped
+}
+
+// macroKeyTypes maps key names, as returned by tea.KeyMsg.String, back to
+// their types so that recorded keys can be replayed.
+var macroKeyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for t := tea.KeyF20; t <= tea.KeyCtrlQuestionMark; t++ {
+		if name := t.String(); name != "" && t != tea.KeyRunes {
+			types[name] = t
+		}
+	}
+	return types
+}()
+
+// parseMacroKey turns a recorded key name back into a key message.
+func parseMacroKey(name string) (tea.KeyMsg, error) {
+	alt := false
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		alt, name = true, rest
+	}
+	if t, ok := macroKeyTypes[name]; ok {
+		return tea.KeyMsg{Type: t, Alt: alt}, nil
+	}
+	runes := []rune(name)
+	if len(runes) != 1 {
+		return tea.KeyMsg{}, fmt.Errorf("unknown key %q", name)
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: alt}, nil
+}
+
+// recordMacroKey adds a key to the recording in progress. Replayed keys are
+// not recorded again.
+func (m *Model) recordMacroKey(msg tea.KeyMsg) {
+	if !m.macro.recording || m.macro.playing != nil {
+		return
+	}
+	if msg.Paste {
+		// Record pasted text as typed, since replay can't paste
+		for _, r := range msg.Runes {
+			m.macro.recorded = append(m.macro.recorded, string(r))
+		}
+		return
+	}
+	m.macro.recorded = append(m.macro.recorded, msg.String())
+}
+
+// toggleMacroRecording starts recording keys, or stops and keeps the
+// recording for @ and :macro save.
+func (m *Model) toggleMacroRecording() {
+	if m.macro.playing != nil {
+		m.logger.Warn("Can't record while a macro is replaying")
+		return
+	}
+	if !m.macro.recording {
+		m.macro.recording = true
+		m.macro.recorded = nil
+		m.logger.Info("Recording macro, press Q to stop")
+		return
+	}
+
+	m.macro.recording = false
+	// The key that stopped the recording was recorded too
+	if n := len(m.macro.recorded); n > 0 {
+		m.macro.recorded = m.macro.recorded[:n-1]
+	}
+	if len(m.macro.recorded) == 0 {
+		m.logger.Info("Recording stopped, no keys recorded")
+		return
+	}
+	m.logger.Info("Recorded %d keys: @ to replay, :macro save <name> [key] to keep", len(m.macro.recorded))
+}
+
+// playMacro replays keys one at a time, waiting between them for lists to
+// finish loading so that keys land on the data they were recorded against.
+func (m *Model) playMacro(name string, keys []string) tea.Cmd {
+	if m.macro.recording {
+		m.logger.Warn("Can't replay a macro while recording")
+		return nil
+	}
+	if len(keys) == 0 {
+		m.logger.Warn("Macro %s has no keys", name)
+		return nil
+	}
+
+	msgs := make([]tea.KeyMsg, len(keys))
+	for i, k := range keys {
+		msg, err := parseMacroKey(k)
+		if err != nil {
+			m.logger.Error("Macro %s: %v", name, err)
+			return nil
+		}
+		msgs[i] = msg
+	}
+
+	m.macro.gen++
+	m.macro.playing = msgs
+	m.macro.name = name
+	m.macro.step = 0
+	m.macro.waited = 0
+	m.logger.Info("Replaying macro %s (%d keys)", name, len(msgs))
+	return m.nextMacroStep()
+}
+
+// stopMacro abandons the replay in progress.
+func (m *Model) stopMacro() {
+	if m.macro.playing == nil {
+		return
+	}
+	m.logger.Warn("Stopped macro %s after %d of %d keys", m.macro.name, m.macro.step, len(m.macro.playing))
+	m.macro.playing = nil
+	m.macro.gen++
+}
+
+// nextMacroStep schedules the next key of the replay.
+func (m *Model) nextMacroStep() tea.Cmd {
+	gen := m.macro.gen
+	return tea.Tick(macroStepDelay, func(time.Time) tea.Msg {
+		return macroStepMsg{gen: gen}
+	})
+}
+
+// handleMacroStep sends the next key of the replay as if it was typed.
+func (m *Model) handleMacroStep(msg macroStepMsg) tea.Cmd {
+	if msg.gen != m.macro.gen || m.macro.playing == nil {
+		return nil
+	}
+	if m.state.IsLoading() && m.macro.waited < macroLoadTimeout {
+		m.macro.waited += macroStepDelay
+		return m.nextMacroStep()
+	}
+	m.macro.waited = 0
+
+	key := m.macro.playing[m.macro.step]
+	m.macro.step++
+	_, cmd := m.update(key)
+
+	// The key may have stopped the replay, e.g. by quitting
+	if m.macro.playing == nil {
+		return cmd
+	}
+	if m.macro.step == len(m.macro.playing) {
+		m.logger.Info("Macro %s done", m.macro.name)
+		m.macro.playing = nil
+		return cmd
+	}
+	return tea.Batch(cmd, m.nextMacroStep())
+}
+
+// handleMacroKey replays the macro bound to a key in the config, if any.
+func (m *Model) handleMacroKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if m.cfg == nil || len(m.cfg.Macros) == 0 {
+		return nil, false
+	}
+	macro, ok := m.cfg.GetMacroByKey(msg.String())
+	if !ok {
+		return nil, false
+	}
+	return m.playMacro(macro.Name, macro.Keys), true
+}
+
+// handleMacroCommand lists, replays, saves or deletes macros:
+// :macro, :macro <name>, :macro save <name> [key] and :macro delete <name>.
+func (m *Model) handleMacroCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		if m.cfg == nil || len(m.cfg.Macros) == 0 {
+			m.logger.Info("No macros saved (Q to record, :macro save <name> to keep)")
+			return nil
+		}
+		names := make([]string, len(m.cfg.Macros))
+		for i, macro := range m.cfg.Macros {
+			names[i] = macro.Name
+			if macro.Key != "" {
+				names[i] += " [" + macro.Key + "]"
+			}
+		}
+		m.logger.Info("Macros: %s", strings.Join(names, ", "))
+		return nil
+	}
+
+	switch args[0] {
+	case "save":
+		if len(args) < 2 {
+			m.logger.Warn("Usage: :macro save <name> [key]")
+			return nil
+		}
+		m.saveMacro(args[1], strings.Join(args[2:], " "))
+		return nil
+	case "delete", "rm":
+		if len(args) < 2 {
+			m.logger.Warn("Usage: :macro delete <name>")
+			return nil
+		}
+		if m.cfg == nil || !m.cfg.DeleteMacro(args[1]) {
+			m.logger.Warn("No macro named %s", args[1])
+			return nil
+		}
+		if err := m.cfg.Save(); err != nil {
+			m.logger.Warn("Failed to save config: %v", err)
+		}
+		m.logger.Info("Deleted macro %s", args[1])
+		return nil
+	}
+
+	if m.cfg == nil {
+		m.logger.Warn("No macro named %s", args[0])
+		return nil
+	}
+	macro, ok := m.cfg.GetMacro(args[0])
+	if !ok {
+		m.logger.Warn("No macro named %s", args[0])
+		return nil
+	}
+	return m.playMacro(macro.Name, macro.Keys)
+}
+
+// saveMacro keeps the last recording in the config under a name, optionally
+// bound to a key.
+func (m *Model) saveMacro(name, key string) {
+	if m.cfg == nil {
+		m.logger.Error("No config file to save the macro to")
+		return
+	}
+	if m.macro.recording {
+		m.logger.Warn("Stop the recording with Q first")
+		return
+	}
+	if len(m.macro.recorded) == 0 {
+		m.logger.Warn("Nothing recorded yet (Q to start recording)")
+		return
+	}
+	if key != "" {
+		if _, err := parseMacroKey(key); err != nil {
+			m.logger.Error("Can't bind macro to %q: %v", key, err)
+			return
+		}
+		if other, ok := m.cfg.GetMacroByKey(key); ok && other.Name != name {
+			m.logger.Warn("Key %s is already bound to macro %s", key, other.Name)
+			return
+		}
+	}
+
+	m.cfg.SetMacro(config.MacroConfig{Name: name, Key: key, Keys: append([]string(nil), m.macro.recorded...)})
+	if err := m.cfg.Save(); err != nil {
+		m.logger.Warn("Failed to save macro: %v", err)
+		return
+	}
+	if key != "" {
+		m.logger.Info("Saved macro %s, replay with %s or :macro %s", name, key, name)
+	} else {
+		m.logger.Info("Saved macro %s, replay with :macro %s", name, name)
+	}
+}
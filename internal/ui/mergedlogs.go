@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/aws"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/format"
+)
+
+const (
+	// mergedTaskInterval is how often the tasks of a service whose logs are
+	// merged are listed again.
+	mergedTaskInterval = 30 * time.Second
+
+	// mergedTaskGrace is how long the streams of a task that stopped running
+	// are still read, for what it logs while shutting down.
+	mergedTaskGrace = time.Minute
+
+	// maxMergedTasks is how many tasks are merged, as CloudWatch reads up to
+	// 100 streams at once.
+	maxMergedTasks = 100
+)
+
+// mergedTail merges the logs of every running task of a service into one
+// tail, following tasks as they start and stop.
+type mergedTail struct {
+	service model.Service
+	tasks   []mergedTask // In the order they were found, which picks their colors
+	listed  bool         // The tasks were listed once, so their streams are read
+}
+
+// mergedTask is a task whose streams are merged.
+type mergedTask struct {
+	id      string
+	stopped time.Time // When the task was last seen running, zero while it runs
+}
+
+// mergedTasksLoadedMsg carries the running tasks of a service whose logs
+// are merged.
+type mergedTasksLoadedMsg struct {
+	tail  *mergedTail
+	tasks []model.Task
+	err   error
+}
+
+// mergedTasksTickMsg lists the tasks of a merged tail again.
+type mergedTasksTickMsg struct {
+	tail *mergedTail
+}
+
+// toggleMergedTail merges the logs of every running task of the service the
+// logs are open on, or goes back to the task they were opened on.
+func (m *Model) toggleMergedTail() tea.Cmd {
+	svc := m.state.CloudWatchServiceContext
+	if svc == nil || m.state.CloudWatchLambdaContext != nil || m.state.CloudWatchAccessLogContext != "" {
+		m.logger.Warn("Only the logs of an ECS service can merge its tasks")
+		return nil
+	}
+	if m.mergedTail != nil {
+		m.stopMergedTail()
+		if task := m.state.CloudWatchTaskContext; task != nil {
+			m.logger.Info("Showing the logs of task %s only", task.TaskID)
+		}
+		return m.restartCloudWatchLogs()
+	}
+
+	m.cloudWatchLogsSeq++
+	m.mergedTail = &mergedTail{service: *svc}
+	m.logger.Info("Listing the tasks of %s to merge their logs...", svc.Name)
+	return m.listMergedTasks(m.mergedTail)
+}
+
+// stopMergedTail stops merging the logs of a service's tasks.
+func (m *Model) stopMergedTail() {
+	m.mergedTail = nil
+	m.cloudWatchLogsPanel.SetMergedTasks(nil)
+}
+
+// listMergedTasks lists the running tasks of the service of a merged tail.
+func (m *Model) listMergedTasks(t *mergedTail) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		tasks, err := client.ListTasksForService(ctx, t.service.ClusterARN, t.service.Name)
+		return mergedTasksLoadedMsg{tail: t, tasks: tasks, err: err}
+	}
+}
+
+// handleMergedTasksLoaded adds the tasks that started to the merged tail and
+// drops those that stopped a while ago. The first listing reads the logs of
+// them all from the start again.
+func (m *Model) handleMergedTasksLoaded(msg mergedTasksLoadedMsg) tea.Cmd {
+	t := m.mergedTail
+	if t == nil || t != msg.tail || m.state.View != state.ViewCloudWatchLogs {
+		return nil
+	}
+	tick := tea.Tick(mergedTaskInterval, func(time.Time) tea.Msg {
+		return mergedTasksTickMsg{tail: t}
+	})
+	if msg.err != nil {
+		m.logger.Warn("Failed to list the tasks of %s: %v", t.service.Name, msg.err)
+		return tick
+	}
+
+	now := time.Now()
+	running := make(map[string]bool)
+	for _, task := range msg.tasks {
+		if task.LastStatus != "STOPPED" {
+			running[task.TaskID] = true
+		}
+	}
+	var tasks []mergedTask
+	for _, mt := range t.tasks {
+		switch {
+		case running[mt.id]:
+			mt.stopped = time.Time{}
+		case mt.stopped.IsZero():
+			mt.stopped = now
+			m.logger.Info("Task %s stopped; its logs are read for another %s", mt.id, format.Age(mergedTaskGrace))
+		case now.Sub(mt.stopped) > mergedTaskGrace:
+			continue
+		}
+		tasks = append(tasks, mt)
+	}
+	for _, task := range msg.tasks {
+		if !running[task.TaskID] || slices.ContainsFunc(tasks, func(mt mergedTask) bool { return mt.id == task.TaskID }) {
+			continue
+		}
+		if len(tasks) >= maxMergedTasks {
+			m.logger.Warn("Merging the logs of the first %d tasks of %s only", maxMergedTasks, t.service.Name)
+			break
+		}
+		tasks = append(tasks, mergedTask{id: task.TaskID})
+		if t.listed {
+			m.logger.Info("Task %s started; its logs join the tail", task.TaskID)
+		}
+	}
+	t.tasks = tasks
+
+	ids := make([]string, len(tasks))
+	for i, mt := range tasks {
+		ids[i] = mt.id
+	}
+	m.cloudWatchLogsPanel.SetMergedTasks(ids)
+	if t.listed {
+		return tick
+	}
+	t.listed = true
+	if len(tasks) == 0 {
+		m.logger.Warn("%s has no running tasks; their logs are merged once they start", t.service.Name)
+	} else {
+		m.logger.Info("Merging the logs of %d tasks of %s", len(tasks), t.service.Name)
+	}
+	return tea.Batch(m.restartCloudWatchLogs(), tick)
+}
+
+// handleMergedTasksTick lists the tasks again, unless the tail was stopped or
+// the logs left since.
+func (m *Model) handleMergedTasksTick(msg mergedTasksTickMsg) tea.Cmd {
+	if m.mergedTail == nil || m.mergedTail != msg.tail || m.state.View != state.ViewCloudWatchLogs {
+		return nil
+	}
+	return m.listMergedTasks(msg.tail)
+}
+
+// fetchMergedCloudWatchLogs reads the stream of a container in every task of
+// the merged tail, starting at the time of the newest entry shown, as other
+// lines may have been logged in that millisecond.
+func (m *Model) fetchMergedCloudWatchLogs(config model.ContainerLogConfig) tea.Cmd {
+	var streams []string
+	for _, mt := range m.mergedTail.tasks {
+		streams = append(streams, aws.BuildLogStreamName(config.LogStreamPrefix, config.ContainerName, mt.id))
+	}
+	if len(streams) == 0 {
+		return nil
+	}
+
+	start := m.state.CloudWatchLastFetchTime
+	from := start
+	if loaded := m.state.CloudWatchLogs; len(loaded) > 0 {
+		from = loaded[len(loaded)-1].Timestamp.UnixMilli()
+	}
+	client, seq := m.client, m.cloudWatchLogsSeq
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		entries, next, err := client.FetchLogStreams(ctx, config.LogGroup, streams, from, cloudWatchFetchLimit)
+		return cloudWatchLogsLoadedMsg{entries: entries, lastTimestamp: max(next, start), merged: true, seq: seq, err: err}
+	}
+}
+
+// unseenLogEntries returns the entries of a merged read that aren't loaded
+// yet, as each read starts again at the millisecond of the newest entry.
+func (m *Model) unseenLogEntries(entries []model.CloudWatchLogEntry) []model.CloudWatchLogEntry {
+	if len(entries) == 0 {
+		return entries
+	}
+	type key struct {
+		stream, message string
+		at              time.Time
+	}
+	seen := make(map[key]bool)
+	loaded := m.state.CloudWatchLogs
+	for i := len(loaded) - 1; i >= 0 && !loaded[i].Timestamp.Before(entries[0].Timestamp); i-- {
+		seen[key{loaded[i].LogStreamName, loaded[i].Message, loaded[i].Timestamp}] = true
+	}
+	var unseen []model.CloudWatchLogEntry
+	for _, e := range entries {
+		if !seen[key{e.LogStreamName, e.Message, e.Timestamp}] {
+			unseen = append(unseen, e)
+		}
+	}
+	return unseen
+}
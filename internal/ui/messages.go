@@ -3,6 +3,7 @@ package ui
 import (
 	"vaws/internal/aws"
 	"vaws/internal/model"
+	"vaws/internal/ui/components"
 )
 
 // Messages for bubbletea.
@@ -23,8 +24,11 @@ type (
 	functionsLoadedMsg struct {
 		functions []model.Function
 		err       error
-		hasMore   bool // true if more pages are being loaded
-		isAppend  bool // true if this is an incremental update
+		hasMore   bool             // true if more pages are being loaded
+		isAppend  bool             // true if this is an incremental update
+		stack     string           // Stack the functions were loaded for, if any
+		total     int              // Functions of the stack, described or not
+		failed    map[string]error // Functions of the stack that couldn't be described
 	}
 
 	// restAPIsLoadedMsg is sent when REST APIs are loaded.
@@ -54,10 +58,35 @@ type (
 
 	// tasksLoadedMsgWithPort is sent when tasks are loaded with a custom port.
 	tasksLoadedMsgWithPort struct {
-		service   model.Service
-		tasks     []model.Task
-		err       error
-		localPort int
+		service       model.Service
+		tasks         []model.Task
+		err           error
+		localPort     int
+		remotePort    int    // 0 picks the container's best port
+		containerName string // Empty picks or asks for the container
+	}
+
+	// taskDefDiffLoadedMsg is sent when two task definition revisions are loaded for comparison.
+	taskDefDiffLoadedMsg struct {
+		serviceName string
+		oldTaskDef  string
+		newTaskDef  string
+		oldDoc      string
+		newDoc      string
+		err         error
+	}
+
+	// shellTasksLoadedMsg is sent when tasks are loaded for opening a shell.
+	shellTasksLoadedMsg struct {
+		service model.Service
+		tasks   []model.Task
+		err     error
+	}
+
+	// shellExitedMsg is sent when an interactive shell session ends.
+	shellExitedMsg struct {
+		target string
+		err    error
 	}
 
 	// tasksLoadedMsgForRestart is sent when tasks are loaded for tunnel restart.
@@ -67,6 +96,12 @@ type (
 		err        error
 	}
 
+	// tunnelImportFailedMsg is sent when a shared tunnel cannot be resolved.
+	tunnelImportFailedMsg struct {
+		target string
+		err    error
+	}
+
 	// tunnelStartedMsg is sent when a tunnel is started.
 	tunnelStartedMsg struct {
 		tunnel *model.Tunnel
@@ -123,6 +158,8 @@ type (
 	cloudWatchLogsLoadedMsg struct {
 		entries       []model.CloudWatchLogEntry
 		lastTimestamp int64
+		merged        bool // Read from the streams of every task of the service
+		seq           int  // The cloudWatchLogsSeq the read was made at
 		err           error
 	}
 
@@ -156,14 +193,294 @@ type (
 
 	// regionChangedMsg is sent when AWS region is changed.
 	regionChangedMsg struct {
-		client *aws.Client
+		client aws.API
+		base   aws.API // Client of the profile, when client is in a member account
 		region string
 		err    error
 	}
 
+	// environmentChangedMsg is sent when the client of an environment switched to with :env is created.
+	environmentChangedMsg struct {
+		name   string
+		client *aws.Client
+		err    error
+	}
+
 	// dynamoDBQueryResultMsg is sent when a DynamoDB query/scan completes.
 	dynamoDBQueryResultMsg struct {
 		result *model.QueryResult
 		err    error
 	}
+
+	// appRunnerServicesLoadedMsg is sent when App Runner services are loaded.
+	appRunnerServicesLoadedMsg struct {
+		services []model.AppRunnerService
+		err      error
+	}
+
+	// appRunnerOperationsLoadedMsg is sent when recent operations of an App Runner service are loaded.
+	appRunnerOperationsLoadedMsg struct {
+		serviceARN string
+		operations []model.AppRunnerOperation
+		err        error
+	}
+
+	// appRunnerActionMsg is sent when a pause, resume or deployment request completes.
+	appRunnerActionMsg struct {
+		serviceName string
+		action      string
+		err         error
+	}
+
+	// deliveryStreamsLoadedMsg is sent when Firehose delivery streams are loaded.
+	deliveryStreamsLoadedMsg struct {
+		streams []model.DeliveryStream
+		err     error
+	}
+
+	// deliveryErrorsLoadedMsg is sent when recent delivery errors of a stream are loaded.
+	deliveryErrorsLoadedMsg struct {
+		streamName string
+		errors     []model.CloudWatchLogEntry
+		err        error
+	}
+
+	// testRecordPutMsg is sent when a test record has been put to a delivery stream.
+	testRecordPutMsg struct {
+		streamName string
+		testID     string // test_id field in the record payload
+		recordID   string
+		err        error
+	}
+
+	// userPoolsLoadedMsg is sent when Cognito user pools are loaded.
+	userPoolsLoadedMsg struct {
+		pools []model.UserPool
+		err   error
+	}
+
+	// userPoolDetailsLoadedMsg is sent when the app clients of a user pool are loaded.
+	userPoolDetailsLoadedMsg struct {
+		details *model.UserPoolDetails
+		err     error
+	}
+
+	// cognitoUsersLoadedMsg is sent when a Cognito user search completes.
+	cognitoUsersLoadedMsg struct {
+		query string
+		users []model.CognitoUser
+		err   error
+	}
+
+	// cognitoUserActionMsg is sent when a confirm, enable or disable request completes.
+	cognitoUserActionMsg struct {
+		username string
+		action   string
+		err      error
+	}
+
+	// mskClustersLoadedMsg is sent when MSK clusters are loaded.
+	mskClustersLoadedMsg struct {
+		clusters []model.MSKCluster
+		err      error
+	}
+
+	// mqBrokersLoadedMsg is sent when Amazon MQ brokers are loaded.
+	mqBrokersLoadedMsg struct {
+		brokers []model.MQBroker
+		err     error
+	}
+
+	// mqTunnelTargetMsg is sent when the jump host for tunnels to an MQ broker is found.
+	mqTunnelTargetMsg struct {
+		broker   model.MQBroker
+		jumpHost model.EC2Instance
+		err      error
+	}
+
+	// databasesLoadedMsg is sent when RDS, RDS Proxy and Redshift endpoints are loaded.
+	databasesLoadedMsg struct {
+		databases []model.Database
+		err       error
+	}
+
+	// instancesLoadedMsg is sent when the instances of the EC2 view are loaded.
+	instancesLoadedMsg struct {
+		instances []model.EC2Instance
+		err       error
+	}
+
+	// databaseTunnelTargetMsg is sent when the jump host for a tunnel to a database is found.
+	databaseTunnelTargetMsg struct {
+		database  model.Database
+		jumpHost  model.EC2Instance
+		localPort int
+		err       error
+	}
+
+	// efsFileSystemsLoadedMsg is sent when EFS file systems are loaded.
+	efsFileSystemsLoadedMsg struct {
+		fileSystems []model.EFSFileSystem
+		err         error
+	}
+
+	// efsDetailsLoadedMsg is sent when the mount targets, access points and mounts of a file system are loaded.
+	efsDetailsLoadedMsg struct {
+		details *model.EFSDetails
+		err     error
+	}
+
+	// schedulesLoadedMsg is sent when EventBridge Scheduler schedules are loaded.
+	schedulesLoadedMsg struct {
+		schedules []model.Schedule
+		err       error
+	}
+
+	// scheduleActionMsg is sent when a pause, resume or run request on a schedule completes.
+	scheduleActionMsg struct {
+		name    string
+		action  string
+		runName string // One-time schedule created to run it now
+		err     error
+	}
+
+	// secretsLoadedMsg is sent when Secrets Manager secrets are loaded.
+	secretsLoadedMsg struct {
+		secrets []model.Secret
+		err     error
+	}
+
+	// secretRotatedMsg is sent when a request to rotate a secret completes.
+	secretRotatedMsg struct {
+		name string
+		err  error
+	}
+
+	// logGroupsLoadedMsg is sent when CloudWatch Logs log groups are loaded.
+	logGroupsLoadedMsg struct {
+		groups []model.LogGroup
+		err    error
+	}
+
+	// logGroupsUpdatedMsg is sent when a retention change or deletion of log groups completes.
+	logGroupsUpdatedMsg struct {
+		action string   // retention or delete
+		done   []string // Groups updated
+		errs   []error  // One per group that failed
+	}
+
+	// mskBrokersLoadedMsg is sent when the bootstrap brokers of an MSK cluster are loaded.
+	mskBrokersLoadedMsg struct {
+		brokers *model.MSKBootstrapBrokers
+		err     error
+	}
+
+	// mskTunnelTargetMsg is sent when the brokers and jump host for MSK tunnels are found.
+	mskTunnelTargetMsg struct {
+		cluster  model.MSKCluster
+		brokers  *model.MSKBootstrapBrokers
+		jumpHost model.EC2Instance
+		err      error
+	}
+
+	// sesLoadedMsg is sent when the SES account, identities and configuration sets are loaded.
+	sesLoadedMsg struct {
+		account    *model.SESAccount
+		identities []model.SESIdentity
+		sets       []model.SESConfigurationSet
+		err        error
+	}
+
+	// activityLoadedMsg is sent when the CloudTrail events of a resource are loaded.
+	activityLoadedMsg struct {
+		names  []string
+		r      model.TimeRange
+		events []model.ActivityEvent
+		err    error
+	}
+
+	// logSearchLoadedMsg is sent when a search across the log groups of a stack completes.
+	logSearchLoadedMsg struct {
+		stack   string
+		pattern string
+		r       model.TimeRange
+		sources []model.LogSource
+		hits    []model.LogSearchHit
+		err     error
+	}
+
+	// imagesLoadedMsg is sent when the images of a cluster's or stack's services are checked.
+	imagesLoadedMsg struct {
+		scope  string
+		images []model.ServiceImage
+		err    error
+	}
+
+	// healthLoadedMsg is sent when the account health checks complete.
+	healthLoadedMsg struct {
+		health *model.AccountHealth
+	}
+
+	// sesSuppressionsLoadedMsg is sent when the SES suppression list is loaded.
+	sesSuppressionsLoadedMsg struct {
+		destinations []model.SESSuppressedDestination
+		truncated    bool
+		err          error
+	}
+
+	// sesSuppressionRemovedMsg is sent when an address is removed from the suppression list.
+	sesSuppressionRemovedMsg struct {
+		email string
+		err   error
+	}
+
+	// sesTestEmailSentMsg is sent when an SES test email has been sent.
+	sesTestEmailSentMsg struct {
+		from      string
+		to        string
+		messageID string
+		err       error
+	}
+
+	// cloudResourcesLoadedMsg is sent when resources of a Cloud Control type are loaded.
+	cloudResourcesLoadedMsg struct {
+		typeName  string
+		resources []model.CloudResource
+		err       error
+	}
+
+	// cloudResourceLoadedMsg is sent when the full properties of a resource are loaded.
+	cloudResourceLoadedMsg struct {
+		resource *model.CloudResource
+		err      error
+	}
+
+	// loaderProgressMsg is sent when a loader's API call makes progress.
+	loaderProgressMsg struct {
+		progress *components.LoadingProgress
+		gen      int64 // Load the update belongs to
+		call     string
+		unit     string
+		done     int
+		total    int
+	}
+
+	// monitorTickMsg is sent when a monitor panel is due for a refresh.
+	monitorTickMsg struct {
+		gen   int
+		index int
+	}
+
+	// monitorPanelLoadedMsg is sent when a monitor panel's content is loaded.
+	monitorPanelLoadedMsg struct {
+		gen       int
+		index     int
+		scheduled bool // Fetched by the refresh loop, which continues from here
+		lines     []components.MonitorLine
+		logs      []components.MonitorLine // New log lines since the last load
+		since     int64
+		logGroup  string
+		logStream string
+		err       error
+	}
 )
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+)
+
+// handleAPIGatewayMock opens the port dialog for a mock server of the
+// selected stage, which answers from the API's routes instead of AWS.
+func (m *Model) handleAPIGatewayMock() tea.Cmd {
+	cmd := m.handleAPIGatewayPortForward()
+	if m.pendingAPIGWPortForward != nil {
+		m.pendingAPIGWMock = true
+	}
+	return cmd
+}
+
+// startAPIGatewayMock reads the routes of the API and serves them on a local
+// port, with the example responses configured under mocks.
+func (m *Model) startAPIGatewayMock(api interface{}, stage model.APIStage, localPort int) tea.Cmd {
+	var apiID, apiName string
+	switch a := api.(type) {
+	case *model.RestAPI:
+		apiID, apiName = a.ID, a.Name
+	case *model.HttpAPI:
+		apiID, apiName = a.ID, a.Name
+	}
+	var responses map[string]config.MockResponseConfig
+	if m.cfg != nil {
+		responses = m.cfg.GetMockResponses(m.state.Profile, apiID, apiName)
+	}
+	m.logger.Info("Reading routes of %s for a mock server of stage %s...", apiName, stage.Name)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var routes []model.APIRoute
+		var err error
+		if _, ok := api.(*model.RestAPI); ok {
+			routes, err = m.client.GetRestAPIRoutes(ctx, apiID)
+		} else {
+			routes, err = m.client.GetHttpAPIRoutes(ctx, apiID)
+		}
+		if err != nil {
+			return apiGWTunnelStartedMsg{err: err}
+		}
+
+		tunnel, err := m.apiGWManager.StartMockServer(api, stage, mockRoutes(routes, responses), localPort)
+		return apiGWTunnelStartedMsg{tunnel: tunnel, err: err}
+	}
+}
+
+// mockRoutes pairs the routes of an API with their configured examples.
+// Examples of routes the API doesn't have yet are served too, so a frontend
+// can be built against a route before it is deployed.
+func mockRoutes(routes []model.APIRoute, responses map[string]config.MockResponseConfig) []model.MockRoute {
+	examples := make(map[string]config.MockResponseConfig, len(responses))
+	for key, r := range responses {
+		examples[normalizeRouteKey(key)] = r
+	}
+
+	var mocks []model.MockRoute
+	seen := make(map[string]bool)
+	for _, r := range routes {
+		key := normalizeRouteKey(r.RouteKey)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ex := examples[key]
+		mocks = append(mocks, model.MockRoute{RouteKey: key, Status: ex.Status, Headers: ex.Headers, Body: ex.Body})
+	}
+
+	var extra []string
+	for key := range examples {
+		if !seen[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	for _, key := range extra {
+		ex := examples[key]
+		mocks = append(mocks, model.MockRoute{RouteKey: key, Status: ex.Status, Headers: ex.Headers, Body: ex.Body})
+	}
+	return mocks
+}
+
+// normalizeRouteKey upper-cases the method of a route key, so "get /orders"
+// in the config matches the API's "GET /orders".
+func normalizeRouteKey(key string) string {
+	key = strings.TrimSpace(key)
+	if method, path, ok := strings.Cut(key, " "); ok {
+		return strings.ToUpper(method) + " " + strings.TrimSpace(path)
+	}
+	return key
+}
@@ -0,0 +1,516 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+const (
+	// monitorLogLines is how many log lines a log tail panel keeps
+	monitorLogLines = 100
+	// monitorLogWindow is how far back a log tail panel starts
+	monitorLogWindow = 10 * time.Minute
+)
+
+// monitorIntervals are the default refresh intervals per panel kind.
+var monitorIntervals = map[string]time.Duration{
+	config.MonitorTasks:      15 * time.Second,
+	config.MonitorLogs:       5 * time.Second,
+	config.MonitorQueue:      15 * time.Second,
+	config.MonitorLambdaLogs: 5 * time.Second,
+	config.MonitorAlarms:     time.Minute,
+}
+
+// monitorPanel is a panel of the monitor dashboard with its latest content.
+type monitorPanel struct {
+	cfg  config.MonitorPanelConfig
+	tile components.MonitorTile
+
+	// Log tail state
+	logs      []components.MonitorLine
+	since     int64 // Next start time in milliseconds
+	logGroup  string
+	logStream string
+}
+
+// interval returns how often the panel refreshes.
+func (p *monitorPanel) interval() time.Duration {
+	if d, err := time.ParseDuration(p.cfg.Interval); err == nil && d >= time.Second {
+		return d
+	}
+	if d, ok := monitorIntervals[p.cfg.Kind]; ok {
+		return d
+	}
+	return 30 * time.Second
+}
+
+// monitorPanelTitle returns the title shown on a panel.
+func monitorPanelTitle(cfg config.MonitorPanelConfig) string {
+	switch cfg.Kind {
+	case config.MonitorTasks:
+		return "Tasks · " + cfg.Service
+	case config.MonitorLogs:
+		return "Logs · " + cfg.Service
+	case config.MonitorQueue:
+		return "Queue · " + queueNameFromURL(cfg.Queue)
+	case config.MonitorLambdaLogs:
+		return "Logs · " + cfg.Function
+	case config.MonitorAlarms:
+		return "CloudWatch alarms"
+	}
+	return cfg.Kind
+}
+
+// queueNameFromURL returns the last path segment of an SQS queue URL.
+func queueNameFromURL(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
+// loadMonitorPanels reads the profile's saved panels the first time they are needed.
+func (m *Model) loadMonitorPanels() {
+	if m.monitorPanels != nil || m.cfg == nil {
+		return
+	}
+	for _, cfg := range m.cfg.GetMonitorPanels(m.state.Profile) {
+		m.monitorPanels = append(m.monitorPanels, &monitorPanel{cfg: cfg})
+	}
+}
+
+// resetMonitor drops the panels and stops their refresh loops after a
+// profile or region switch; the panels are reloaded when next needed.
+func (m *Model) resetMonitor() {
+	m.monitorPanels = nil
+	m.monitorGen++
+}
+
+// openMonitor shows the monitor dashboard and starts refreshing its panels.
+func (m *Model) openMonitor() tea.Cmd {
+	m.loadMonitorPanels()
+	if m.state.View != state.ViewMonitor {
+		m.state.MonitorReturnView = m.state.View
+	}
+	m.state.View = state.ViewMonitor
+	return m.restartMonitor()
+}
+
+// restartMonitor fetches every panel now and schedules their refreshes.
+// Refresh loops from before are dropped by bumping the generation.
+func (m *Model) restartMonitor() tea.Cmd {
+	m.monitorGen++
+	m.syncMonitorTiles()
+
+	cmds := make([]tea.Cmd, 0, len(m.monitorPanels))
+	for i := range m.monitorPanels {
+		cmds = append(cmds, m.fetchMonitorPanel(i, true))
+	}
+	return tea.Batch(cmds...)
+}
+
+// closeMonitor returns to the view the dashboard was opened from and stops
+// refreshing panels.
+func (m *Model) closeMonitor() {
+	m.monitorGen++
+	m.state.View = m.state.MonitorReturnView
+	m.updateCurrentList()
+}
+
+// syncMonitorTiles passes the panels' content to the grid.
+func (m *Model) syncMonitorTiles() {
+	tiles := make([]components.MonitorTile, len(m.monitorPanels))
+	for i, p := range m.monitorPanels {
+		p.tile.Title = monitorPanelTitle(p.cfg)
+		p.tile.Interval = p.interval()
+		tiles[i] = p.tile
+	}
+	m.monitor.SetTiles(tiles)
+}
+
+// pinToMonitor adds a panel to the monitor dashboard and saves it in the
+// profile's config.
+func (m *Model) pinToMonitor(cfg config.MonitorPanelConfig) {
+	m.loadMonitorPanels()
+	for _, p := range m.monitorPanels {
+		if p.cfg.Kind == cfg.Kind && p.cfg.Cluster == cfg.Cluster && p.cfg.Service == cfg.Service &&
+			p.cfg.Queue == cfg.Queue && p.cfg.Function == cfg.Function {
+			m.logger.Info("%s is already on the monitor", monitorPanelTitle(cfg))
+			return
+		}
+	}
+
+	m.monitorPanels = append(m.monitorPanels, &monitorPanel{cfg: cfg})
+	m.saveMonitorPanels()
+	m.logger.Info("Pinned %s to the monitor (:monitor to open)", monitorPanelTitle(cfg))
+}
+
+// removeMonitorPanel removes the selected panel from the dashboard.
+func (m *Model) removeMonitorPanel() tea.Cmd {
+	i := m.monitor.Selected()
+	if i < 0 {
+		return nil
+	}
+	m.logger.Info("Removed %s from the monitor", monitorPanelTitle(m.monitorPanels[i].cfg))
+	m.monitorPanels = append(m.monitorPanels[:i], m.monitorPanels[i+1:]...)
+	m.saveMonitorPanels()
+	return m.restartMonitor()
+}
+
+// saveMonitorPanels writes the panel list to the config file.
+func (m *Model) saveMonitorPanels() {
+	if m.cfg == nil {
+		return
+	}
+	panels := make([]config.MonitorPanelConfig, len(m.monitorPanels))
+	for i, p := range m.monitorPanels {
+		panels[i] = p.cfg
+	}
+	m.cfg.SetMonitorPanels(m.state.Profile, panels)
+	if err := m.cfg.Save(); err != nil {
+		m.logger.Warn("Failed to save monitor panels: %v", err)
+	}
+}
+
+// handlePinToMonitor pins the selected resource of the current view: a
+// service's task counts, a queue's depth or a Lambda function's log tail.
+func (m *Model) handlePinToMonitor() {
+	switch m.state.View {
+	case state.ViewServices:
+		if svc := m.selectedService(); svc != nil {
+			m.pinToMonitor(config.MonitorPanelConfig{Kind: config.MonitorTasks, Cluster: svc.ClusterARN, Service: svc.Name})
+		}
+	case state.ViewSQS:
+		if q := m.sqsTable.SelectedQueue(); q != nil {
+			m.pinToMonitor(config.MonitorPanelConfig{Kind: config.MonitorQueue, Queue: q.URL})
+		}
+	case state.ViewLambda:
+		if item := m.lambdaList.SelectedItem(); item != nil {
+			m.pinToMonitor(config.MonitorPanelConfig{Kind: config.MonitorLambdaLogs, Function: item.ID})
+		}
+	default:
+		m.logger.Warn("Nothing to pin here; use M on a service, queue or Lambda function")
+	}
+}
+
+// handleMonitorCommand runs ":monitor [tasks|logs|queue|alarms]": without
+// arguments it opens the dashboard, otherwise it pins a panel.
+func (m *Model) handleMonitorCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		return m.openMonitor()
+	}
+
+	switch args[0] {
+	case config.MonitorTasks, config.MonitorLogs:
+		svc := m.selectedService()
+		if m.state.View != state.ViewServices || svc == nil {
+			m.logger.Warn(":monitor %s needs a selected ECS service", args[0])
+			return nil
+		}
+		m.pinToMonitor(config.MonitorPanelConfig{Kind: args[0], Cluster: svc.ClusterARN, Service: svc.Name})
+	case config.MonitorQueue:
+		q := m.sqsTable.SelectedQueue()
+		if m.state.View != state.ViewSQS || q == nil {
+			m.logger.Warn(":monitor queue needs a selected SQS queue")
+			return nil
+		}
+		m.pinToMonitor(config.MonitorPanelConfig{Kind: config.MonitorQueue, Queue: q.URL})
+	case config.MonitorAlarms:
+		m.pinToMonitor(config.MonitorPanelConfig{Kind: config.MonitorAlarms})
+	default:
+		m.logger.Warn("Unknown monitor panel: %s (tasks, logs, queue, alarms)", args[0])
+	}
+	return nil
+}
+
+// selectedService returns the service under the cursor in the services view.
+func (m *Model) selectedService() *model.Service {
+	item := m.serviceList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.Services {
+		if m.state.Services[i].Name == item.ID {
+			return &m.state.Services[i]
+		}
+	}
+	return nil
+}
+
+// fetchMonitorPanel loads the content of the panel at index i. Only fetches
+// made by the panel's refresh loop schedule the next one; a manual refresh
+// leaves the running loop alone instead of starting a second one.
+func (m *Model) fetchMonitorPanel(i int, scheduled bool) tea.Cmd {
+	if i >= len(m.monitorPanels) || m.client == nil {
+		return nil
+	}
+	p := m.monitorPanels[i]
+	p.tile.Loading = true
+
+	gen := m.monitorGen
+	cfg := p.cfg
+	since, logGroup, logStream := p.since, p.logGroup, p.logStream
+	if since == 0 {
+		since = time.Now().Add(-monitorLogWindow).UnixMilli()
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		msg := monitorPanelLoadedMsg{gen: gen, index: i, scheduled: scheduled, since: since, logGroup: logGroup, logStream: logStream}
+		switch cfg.Kind {
+		case config.MonitorTasks:
+			msg.lines, msg.err = m.monitorTaskLines(ctx, cfg)
+		case config.MonitorQueue:
+			msg.lines, msg.err = m.monitorQueueLines(ctx, cfg)
+		case config.MonitorAlarms:
+			msg.lines, msg.err = m.monitorAlarmLines(ctx)
+		case config.MonitorLogs:
+			if msg.logStream == "" {
+				msg.logGroup, msg.logStream, msg.err = m.resolveServiceLogStream(ctx, cfg)
+				if msg.err != nil {
+					return msg
+				}
+			}
+			var entries []model.CloudWatchLogEntry
+			entries, msg.since, msg.err = m.client.FetchLogs(ctx, msg.logGroup, msg.logStream, since, 100)
+			msg.logs = monitorLogLinesFrom(entries)
+		case config.MonitorLambdaLogs:
+			var entries []model.CloudWatchLogEntry
+			entries, msg.since, msg.err = m.client.FetchLambdaLogs(ctx, "/aws/lambda/"+cfg.Function, since, 100)
+			msg.logs = monitorLogLinesFrom(entries)
+		default:
+			msg.err = fmt.Errorf("unknown panel kind %q", cfg.Kind)
+		}
+		return msg
+	}
+}
+
+// handleMonitorPanelLoaded applies a panel's new content and schedules its
+// next refresh.
+func (m *Model) handleMonitorPanelLoaded(msg monitorPanelLoadedMsg) tea.Cmd {
+	if msg.gen != m.monitorGen || msg.index >= len(m.monitorPanels) {
+		return nil
+	}
+	p := m.monitorPanels[msg.index]
+	p.tile.Loading = false
+	p.tile.Err = msg.err
+	p.tile.UpdatedAt = time.Now()
+
+	switch p.cfg.Kind {
+	case config.MonitorLogs, config.MonitorLambdaLogs:
+		if msg.err != nil {
+			// Resolve the task's log stream again, it may have been replaced
+			p.logStream = ""
+			break
+		}
+		p.since, p.logGroup, p.logStream = msg.since, msg.logGroup, msg.logStream
+		p.logs = append(p.logs, msg.logs...)
+		if len(p.logs) > monitorLogLines {
+			p.logs = p.logs[len(p.logs)-monitorLogLines:]
+		}
+		p.tile.Lines = p.logs
+		if len(p.logs) == 0 {
+			p.tile.Lines = []components.MonitorLine{{Text: "No log events in the last 10 minutes", Style: GetStyles().Muted}}
+		}
+	default:
+		if msg.err == nil {
+			p.tile.Lines = msg.lines
+		}
+	}
+	m.syncMonitorTiles()
+	if !msg.scheduled {
+		return nil
+	}
+
+	gen, index := msg.gen, msg.index
+	return tea.Tick(p.interval(), func(time.Time) tea.Msg {
+		return monitorTickMsg{gen: gen, index: index}
+	})
+}
+
+// handleMonitorTick refreshes a panel when its interval has passed, as long
+// as the dashboard is still open.
+func (m *Model) handleMonitorTick(msg monitorTickMsg) tea.Cmd {
+	if msg.gen != m.monitorGen || m.state.View != state.ViewMonitor {
+		return nil
+	}
+	m.syncMonitorTiles()
+	return m.fetchMonitorPanel(msg.index, true)
+}
+
+// monitorTaskLines describes an ECS service's task counts and deployments.
+func (m *Model) monitorTaskLines(ctx context.Context, cfg config.MonitorPanelConfig) ([]components.MonitorLine, error) {
+	svc, err := m.client.DescribeService(ctx, cfg.Cluster, cfg.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	s := GetStyles()
+	lines := []components.MonitorLine{
+		{Text: fmt.Sprintf("Running %d/%d", svc.RunningCount, svc.DesiredCount), Style: ServiceStatusStyle(svc.RunningCount, svc.DesiredCount).Bold(true)},
+		{Text: fmt.Sprintf("Pending %d", svc.PendingCount), Style: s.Muted},
+	}
+	for _, d := range svc.Deployments {
+		lines = append(lines, components.MonitorLine{
+			Text:  fmt.Sprintf("%-8s %s  %d/%d", d.Status, shortTaskDefinition(d.TaskDefinition), d.RunningCount, d.DesiredCount),
+			Style: s.Muted,
+		})
+	}
+	return lines, nil
+}
+
+// monitorQueueLines describes an SQS queue's message counts.
+func (m *Model) monitorQueueLines(ctx context.Context, cfg config.MonitorPanelConfig) ([]components.MonitorLine, error) {
+	q, err := m.client.GetQueueAttributes(ctx, cfg.Queue)
+	if err != nil {
+		return nil, err
+	}
+
+	s := GetStyles()
+	depthStyle := s.StatusHealthy
+	if q.ApproximateMessageCount > 0 {
+		depthStyle = s.StatusWarning
+	}
+	lines := []components.MonitorLine{
+		{Text: "Messages  " + format.Count(int64(q.ApproximateMessageCount)), Style: depthStyle.Bold(true)},
+		{Text: "In flight " + format.Count(int64(q.ApproximateInFlight)), Style: s.Muted},
+	}
+	if q.HasDLQ {
+		dlqStyle := s.Muted
+		if q.HasDLQMessages() {
+			dlqStyle = s.StatusError
+		}
+		lines = append(lines, components.MonitorLine{Text: fmt.Sprintf("DLQ       %s (%s)", format.Count(int64(q.DLQMessageCount)), q.DLQName), Style: dlqStyle})
+	}
+	return lines, nil
+}
+
+// monitorAlarmLines lists firing CloudWatch alarms under a summary line.
+func (m *Model) monitorAlarmLines(ctx context.Context) ([]components.MonitorLine, error) {
+	alarms, err := m.client.ListAlarms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s := GetStyles()
+	counts := make(map[model.AlarmState]int)
+	for _, a := range alarms {
+		counts[a.State]++
+	}
+
+	summaryStyle := s.StatusHealthy
+	if counts[model.AlarmStateAlarm] > 0 {
+		summaryStyle = s.StatusError
+	}
+	lines := []components.MonitorLine{{
+		Text: fmt.Sprintf("%d in alarm · %d OK · %d insufficient data",
+			counts[model.AlarmStateAlarm], counts[model.AlarmStateOK], counts[model.AlarmStateInsufficientData]),
+		Style: summaryStyle.Bold(true),
+	}}
+	for _, a := range alarms {
+		if a.State != model.AlarmStateAlarm {
+			break
+		}
+		lines = append(lines, components.MonitorLine{Text: theme.Symbol("● ", "* ") + a.Name + "  " + a.Reason, Style: s.StatusError})
+	}
+	return lines, nil
+}
+
+// resolveServiceLogStream finds the log stream of the main container of one
+// of the service's running tasks.
+func (m *Model) resolveServiceLogStream(ctx context.Context, cfg config.MonitorPanelConfig) (string, string, error) {
+	tasks, err := m.client.ListTasksForService(ctx, cfg.Cluster, cfg.Service)
+	if err != nil {
+		return "", "", err
+	}
+	if len(tasks) == 0 {
+		return "", "", fmt.Errorf("no running tasks")
+	}
+
+	task := tasks[0]
+	configs, err := m.client.GetContainerLogConfigs(ctx, task.TaskDefinitionARN, task.TaskID)
+	if err != nil {
+		return "", "", err
+	}
+	if len(configs) == 0 {
+		return "", "", fmt.Errorf("no awslogs configuration")
+	}
+
+	best := configs[0]
+	for _, lc := range configs {
+		c := model.Container{Name: lc.ContainerName}
+		if !c.IsSidecar() {
+			best = lc
+			break
+		}
+	}
+	return best.LogGroup, best.LogStreamName, nil
+}
+
+// monitorLogLinesFrom formats log events as single panel lines.
+func monitorLogLinesFrom(entries []model.CloudWatchLogEntry) []components.MonitorLine {
+	s := GetStyles()
+	lines := make([]components.MonitorLine, 0, len(entries))
+	for _, e := range entries {
+		msg := strings.Join(strings.Fields(e.Message), " ")
+		lines = append(lines, components.MonitorLine{
+			Text:  e.Timestamp.Format("15:04:05") + " " + msg,
+			Style: logLevelStyle(msg, s),
+		})
+	}
+	return lines
+}
+
+// logLevelStyle colors a log line by the level it mentions.
+func logLevelStyle(msg string, s StyleSet) lipgloss.Style {
+	upper := strings.ToUpper(msg)
+	switch {
+	case strings.Contains(upper, "ERROR"), strings.Contains(upper, "FATAL"):
+		return s.StatusError
+	case strings.Contains(upper, "WARN"):
+		return s.StatusWarning
+	}
+	return s.Muted
+}
+
+// handleMonitorKey handles key presses in the monitor dashboard.
+func (m *Model) handleMonitorKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c":
+		m.tunnelManager.StopAllTunnels()
+		return tea.Quit
+	case "esc", "backspace", "q":
+		m.closeMonitor()
+	case "left":
+		m.monitor.Left()
+	case "right":
+		m.monitor.Right()
+	case "up", "k":
+		m.monitor.Up()
+	case "down", "j":
+		m.monitor.Down()
+	case "x":
+		return m.removeMonitorPanel()
+	case "r":
+		if i := m.monitor.Selected(); i >= 0 {
+			return m.fetchMonitorPanel(i, false)
+		}
+	case "l":
+		m.state.ToggleLogs()
+		m.updateComponentSizes()
+	case "?":
+		m.showHelp()
+	}
+	return nil
+}
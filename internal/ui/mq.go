@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+)
+
+// mqEndpoint is a port of an Amazon MQ broker instance that vaws tunnels to.
+type mqEndpoint struct {
+	instance  int    // Number of the broker instance, from 1
+	label     string // console or AMQP
+	scheme    string // Scheme of the broker's URL, e.g. https or amqps
+	host      string
+	port      int
+	localPort int
+}
+
+// localURL returns the endpoint's URL on its local port.
+func (e mqEndpoint) localURL() string {
+	return fmt.Sprintf("%s://localhost:%d", e.scheme, e.localPort)
+}
+
+// selectedMQBroker returns the MQ broker under the cursor.
+func (m *Model) selectedMQBroker() *model.MQBroker {
+	item := m.mqList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.MQBrokers {
+		if m.state.MQBrokers[i].ARN == item.ID {
+			return &m.state.MQBrokers[i]
+		}
+	}
+	return nil
+}
+
+// mqTunnelEndpoints returns the web console and AMQP endpoints of each
+// instance of a broker. Local ports follow mskLocalPort, with the instance
+// number standing in for the broker number, so that they stay the same
+// across sessions.
+func mqTunnelEndpoints(broker model.MQBroker) []mqEndpoint {
+	var endpoints []mqEndpoint
+	for i, inst := range broker.Instances {
+		urls := []string{inst.ConsoleURL}
+		for _, e := range inst.Endpoints {
+			if strings.HasPrefix(e, "amqp") {
+				urls = append(urls, e)
+			}
+		}
+		for _, raw := range urls {
+			u, err := url.Parse(raw)
+			if err != nil || u.Hostname() == "" {
+				continue
+			}
+			port, _ := strconv.Atoi(u.Port())
+			if port == 0 {
+				port = 443
+				if u.Scheme == "http" {
+					port = 80
+				}
+			}
+			label := "AMQP"
+			if raw == inst.ConsoleURL {
+				label = "console"
+			}
+			endpoints = append(endpoints, mqEndpoint{
+				instance:  i + 1,
+				label:     label,
+				scheme:    u.Scheme,
+				host:      u.Hostname(),
+				port:      port,
+				localPort: mskLocalPort(port, i+1),
+			})
+		}
+	}
+	return endpoints
+}
+
+// handleMQPortForward opens tunnels to the web console and AMQP ports of
+// the selected MQ broker through a jump host in the broker's VPC.
+func (m *Model) handleMQPortForward() tea.Cmd {
+	broker := m.selectedMQBroker()
+	if broker == nil {
+		return nil
+	}
+	if broker.Public {
+		m.logger.Warn("MQ broker %s is publicly accessible; connect to its endpoints directly", broker.Name)
+		return nil
+	}
+	if len(broker.Subnets) == 0 || len(mqTunnelEndpoints(*broker)) == 0 {
+		m.logger.Warn("MQ broker %s has no endpoints yet", broker.Name)
+		return nil
+	}
+	m.logger.Info("Finding a jump host for %s...", broker.Name)
+	return m.findMQTunnelTarget(*broker)
+}
+
+// findMQTunnelTarget finds a jump host in the VPC of an MQ broker.
+func (m *Model) findMQTunnelTarget(broker model.MQBroker) tea.Cmd {
+	jumpHostConfig := ""
+	jumpHostTagConfig := m.jumpHostTag()
+	if m.cfg != nil {
+		jumpHostConfig = m.cfg.GetJumpHost(m.state.Profile)
+	}
+	defaultTags, defaultNames := m.jumpHostDefaults()
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		vpcID, err := m.client.GetSubnetVPC(ctx, broker.Subnets[0])
+		if err != nil {
+			return mqTunnelTargetMsg{broker: broker, err: err}
+		}
+		jumpHost, err := m.client.FindJumpHost(ctx, vpcID, jumpHostConfig, jumpHostTagConfig, defaultTags, defaultNames, vpcID)
+		if err != nil {
+			return mqTunnelTargetMsg{broker: broker, err: fmt.Errorf("failed to find jump host: %w", err)}
+		}
+		return mqTunnelTargetMsg{broker: broker, jumpHost: *jumpHost}
+	}
+}
+
+// startMQTunnels opens one tunnel per console and AMQP endpoint of a broker
+// and logs their local URLs.
+func (m *Model) startMQTunnels(broker model.MQBroker, jumpHost model.EC2Instance) tea.Cmd {
+	m.logger.Info("Tunneling to %s via %s (%s)", broker.Name, jumpHost.Name, jumpHost.InstanceID)
+
+	var cmds []tea.Cmd
+	for _, e := range mqTunnelEndpoints(broker) {
+		name := fmt.Sprintf("mq-%s-%d-%s", broker.Name, e.instance, strings.ToLower(e.label))
+		cmds = append(cmds, m.startJumpHostTunnel(jumpHost, name, e.host, e.port, e.localPort))
+		m.logger.Info("  %s: %s", e.label, e.localURL())
+	}
+	m.logger.Info("Brokers present certificates for their AWS hostnames; expect a warning in the browser and skip hostname verification in clients")
+	return tea.Batch(cmds...)
+}
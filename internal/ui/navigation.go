@@ -16,7 +16,14 @@ func (m *Model) startFiltering() {
 // startDetailsSearch enters details search mode.
 func (m *Model) startDetailsSearch() {
 	m.detailsSearching = true
-	m.detailsSearchInput.SetValue(m.details.SearchQuery())
+	query := m.details.SearchQuery()
+	if tree := m.activeJSONTree(); tree != nil && m.state.View == state.ViewDynamoDBQuery {
+		query = tree.SearchQuery()
+	}
+	if m.state.View == state.ViewCloudWatchLogs {
+		query = m.cloudWatchLogsPanel.SearchQuery()
+	}
+	m.detailsSearchInput.SetValue(query)
 	m.detailsSearchInput.Focus()
 }
 
@@ -30,14 +37,94 @@ func (m *Model) moveCursorUp() {
 		m.updateStackDetails()
 	case state.ViewStackResources:
 		m.stackResourcesList.Up()
+	case state.ViewStackEvents:
+		m.stackEventsList.Up()
+		m.updateStackEventDetails()
 	case state.ViewClusters:
 		m.clustersList.Up()
 	case state.ViewServices:
 		m.serviceList.Up()
 		m.updateServiceDetails()
+	case state.ViewTasks:
+		m.tasksList.Up()
+		m.updateTaskDetails()
+	case state.ViewTaskContainers:
+		m.taskContainersList.Up()
+		m.updateTaskContainerDetails()
 	case state.ViewLambda:
 		m.lambdaList.Up()
 		m.updateLambdaDetails()
+	case state.ViewAppRunner:
+		m.appRunnerList.Up()
+		m.updateAppRunnerDetails()
+	case state.ViewFirehose:
+		m.firehoseList.Up()
+		m.updateFirehoseDetails()
+	case state.ViewCognito:
+		m.userPoolList.Up()
+		m.updateUserPoolDetails()
+	case state.ViewCognitoUsers:
+		m.cognitoUserList.Up()
+		m.updateCognitoUserDetails()
+	case state.ViewMSK:
+		m.mskList.Up()
+		m.updateMSKDetails()
+	case state.ViewMQ:
+		m.mqList.Up()
+		m.updateMQDetails()
+	case state.ViewDatabases:
+		m.databaseList.Up()
+		m.updateDatabaseDetails()
+	case state.ViewEC2:
+		m.instanceList.Up()
+		m.updateInstanceDetails()
+	case state.ViewEFS:
+		m.efsList.Up()
+		m.updateEFSDetails()
+	case state.ViewSchedules:
+		m.schedulesList.Up()
+		m.updateScheduleDetails()
+	case state.ViewSecrets:
+		m.secretsList.Up()
+		m.updateSecretDetails()
+	case state.ViewLogGroups:
+		m.logGroupsList.Up()
+		m.updateLogGroupDetails()
+	case state.ViewSES:
+		m.sesList.Up()
+		m.updateSESDetails()
+	case state.ViewSESSuppressions:
+		m.sesSuppressionList.Up()
+		m.updateSESSuppressionDetails()
+	case state.ViewActivity:
+		m.activityList.Up()
+		m.updateActivityDetails()
+	case state.ViewLogSearch:
+		m.logSearchList.Up()
+		m.updateLogSearchDetails()
+	case state.ViewHealth:
+		m.healthList.Up()
+		m.updateHealthDetails()
+	case state.ViewLambdaRuntimes:
+		m.runtimesList.Up()
+		m.updateRuntimeDetails()
+	case state.ViewImages:
+		m.imagesTable.Up()
+		m.updateImageDetails()
+	case state.ViewAlerts:
+		m.alertsList.Up()
+		m.updateAlertsDetails()
+	case state.ViewStats:
+		m.statsList.Up()
+	case state.ViewTimeline:
+		m.timelineList.Up()
+		m.updateTimelineDetails()
+	case state.ViewResourceTypes:
+		m.resourceTypeList.Up()
+		m.updateResourceTypeDetails()
+	case state.ViewCloudResources:
+		m.cloudResourceList.Up()
+		m.updateCloudResourceDetails()
 	case state.ViewAPIGateway:
 		m.apiGatewayList.Up()
 		m.updateAPIGatewayDetails()
@@ -48,6 +135,8 @@ func (m *Model) moveCursorUp() {
 		m.ec2List.Up()
 	case state.ViewContainerSelect:
 		m.containerList.Up()
+	case state.ViewEndpointSelect:
+		m.endpointList.Up()
 	case state.ViewSQS:
 		m.sqsTable.Up()
 		m.updateQueueDetails()
@@ -69,14 +158,94 @@ func (m *Model) moveCursorDown() {
 		m.updateStackDetails()
 	case state.ViewStackResources:
 		m.stackResourcesList.Down()
+	case state.ViewStackEvents:
+		m.stackEventsList.Down()
+		m.updateStackEventDetails()
 	case state.ViewClusters:
 		m.clustersList.Down()
 	case state.ViewServices:
 		m.serviceList.Down()
 		m.updateServiceDetails()
+	case state.ViewTasks:
+		m.tasksList.Down()
+		m.updateTaskDetails()
+	case state.ViewTaskContainers:
+		m.taskContainersList.Down()
+		m.updateTaskContainerDetails()
 	case state.ViewLambda:
 		m.lambdaList.Down()
 		m.updateLambdaDetails()
+	case state.ViewAppRunner:
+		m.appRunnerList.Down()
+		m.updateAppRunnerDetails()
+	case state.ViewFirehose:
+		m.firehoseList.Down()
+		m.updateFirehoseDetails()
+	case state.ViewCognito:
+		m.userPoolList.Down()
+		m.updateUserPoolDetails()
+	case state.ViewCognitoUsers:
+		m.cognitoUserList.Down()
+		m.updateCognitoUserDetails()
+	case state.ViewMSK:
+		m.mskList.Down()
+		m.updateMSKDetails()
+	case state.ViewMQ:
+		m.mqList.Down()
+		m.updateMQDetails()
+	case state.ViewDatabases:
+		m.databaseList.Down()
+		m.updateDatabaseDetails()
+	case state.ViewEC2:
+		m.instanceList.Down()
+		m.updateInstanceDetails()
+	case state.ViewEFS:
+		m.efsList.Down()
+		m.updateEFSDetails()
+	case state.ViewSchedules:
+		m.schedulesList.Down()
+		m.updateScheduleDetails()
+	case state.ViewSecrets:
+		m.secretsList.Down()
+		m.updateSecretDetails()
+	case state.ViewLogGroups:
+		m.logGroupsList.Down()
+		m.updateLogGroupDetails()
+	case state.ViewSES:
+		m.sesList.Down()
+		m.updateSESDetails()
+	case state.ViewSESSuppressions:
+		m.sesSuppressionList.Down()
+		m.updateSESSuppressionDetails()
+	case state.ViewActivity:
+		m.activityList.Down()
+		m.updateActivityDetails()
+	case state.ViewLogSearch:
+		m.logSearchList.Down()
+		m.updateLogSearchDetails()
+	case state.ViewHealth:
+		m.healthList.Down()
+		m.updateHealthDetails()
+	case state.ViewLambdaRuntimes:
+		m.runtimesList.Down()
+		m.updateRuntimeDetails()
+	case state.ViewImages:
+		m.imagesTable.Down()
+		m.updateImageDetails()
+	case state.ViewAlerts:
+		m.alertsList.Down()
+		m.updateAlertsDetails()
+	case state.ViewStats:
+		m.statsList.Down()
+	case state.ViewTimeline:
+		m.timelineList.Down()
+		m.updateTimelineDetails()
+	case state.ViewResourceTypes:
+		m.resourceTypeList.Down()
+		m.updateResourceTypeDetails()
+	case state.ViewCloudResources:
+		m.cloudResourceList.Down()
+		m.updateCloudResourceDetails()
 	case state.ViewAPIGateway:
 		m.apiGatewayList.Down()
 		m.updateAPIGatewayDetails()
@@ -87,6 +256,8 @@ func (m *Model) moveCursorDown() {
 		m.ec2List.Down()
 	case state.ViewContainerSelect:
 		m.containerList.Down()
+	case state.ViewEndpointSelect:
+		m.endpointList.Down()
 	case state.ViewSQS:
 		m.sqsTable.Down()
 		m.updateQueueDetails()
@@ -108,14 +279,94 @@ func (m *Model) moveCursorTop() {
 		m.updateStackDetails()
 	case state.ViewStackResources:
 		m.stackResourcesList.Top()
+	case state.ViewStackEvents:
+		m.stackEventsList.Top()
+		m.updateStackEventDetails()
 	case state.ViewClusters:
 		m.clustersList.Top()
 	case state.ViewServices:
 		m.serviceList.Top()
 		m.updateServiceDetails()
+	case state.ViewTasks:
+		m.tasksList.Top()
+		m.updateTaskDetails()
+	case state.ViewTaskContainers:
+		m.taskContainersList.Top()
+		m.updateTaskContainerDetails()
 	case state.ViewLambda:
 		m.lambdaList.Top()
 		m.updateLambdaDetails()
+	case state.ViewAppRunner:
+		m.appRunnerList.Top()
+		m.updateAppRunnerDetails()
+	case state.ViewFirehose:
+		m.firehoseList.Top()
+		m.updateFirehoseDetails()
+	case state.ViewCognito:
+		m.userPoolList.Top()
+		m.updateUserPoolDetails()
+	case state.ViewCognitoUsers:
+		m.cognitoUserList.Top()
+		m.updateCognitoUserDetails()
+	case state.ViewMSK:
+		m.mskList.Top()
+		m.updateMSKDetails()
+	case state.ViewMQ:
+		m.mqList.Top()
+		m.updateMQDetails()
+	case state.ViewDatabases:
+		m.databaseList.Top()
+		m.updateDatabaseDetails()
+	case state.ViewEC2:
+		m.instanceList.Top()
+		m.updateInstanceDetails()
+	case state.ViewEFS:
+		m.efsList.Top()
+		m.updateEFSDetails()
+	case state.ViewSchedules:
+		m.schedulesList.Top()
+		m.updateScheduleDetails()
+	case state.ViewSecrets:
+		m.secretsList.Top()
+		m.updateSecretDetails()
+	case state.ViewLogGroups:
+		m.logGroupsList.Top()
+		m.updateLogGroupDetails()
+	case state.ViewSES:
+		m.sesList.Top()
+		m.updateSESDetails()
+	case state.ViewSESSuppressions:
+		m.sesSuppressionList.Top()
+		m.updateSESSuppressionDetails()
+	case state.ViewActivity:
+		m.activityList.Top()
+		m.updateActivityDetails()
+	case state.ViewLogSearch:
+		m.logSearchList.Top()
+		m.updateLogSearchDetails()
+	case state.ViewHealth:
+		m.healthList.Top()
+		m.updateHealthDetails()
+	case state.ViewLambdaRuntimes:
+		m.runtimesList.Top()
+		m.updateRuntimeDetails()
+	case state.ViewImages:
+		m.imagesTable.Top()
+		m.updateImageDetails()
+	case state.ViewAlerts:
+		m.alertsList.Top()
+		m.updateAlertsDetails()
+	case state.ViewStats:
+		m.statsList.Top()
+	case state.ViewTimeline:
+		m.timelineList.Top()
+		m.updateTimelineDetails()
+	case state.ViewResourceTypes:
+		m.resourceTypeList.Top()
+		m.updateResourceTypeDetails()
+	case state.ViewCloudResources:
+		m.cloudResourceList.Top()
+		m.updateCloudResourceDetails()
 	case state.ViewAPIGateway:
 		m.apiGatewayList.Top()
 		m.updateAPIGatewayDetails()
@@ -126,6 +377,8 @@ func (m *Model) moveCursorTop() {
 		m.ec2List.Top()
 	case state.ViewContainerSelect:
 		m.containerList.Top()
+	case state.ViewEndpointSelect:
+		m.endpointList.Top()
 	case state.ViewSQS:
 		m.sqsTable.Top()
 		m.updateQueueDetails()
@@ -145,14 +398,94 @@ func (m *Model) moveCursorBottom() {
 		m.updateStackDetails()
 	case state.ViewStackResources:
 		m.stackResourcesList.Bottom()
+	case state.ViewStackEvents:
+		m.stackEventsList.Bottom()
+		m.updateStackEventDetails()
 	case state.ViewClusters:
 		m.clustersList.Bottom()
 	case state.ViewServices:
 		m.serviceList.Bottom()
 		m.updateServiceDetails()
+	case state.ViewTasks:
+		m.tasksList.Bottom()
+		m.updateTaskDetails()
+	case state.ViewTaskContainers:
+		m.taskContainersList.Bottom()
+		m.updateTaskContainerDetails()
 	case state.ViewLambda:
 		m.lambdaList.Bottom()
 		m.updateLambdaDetails()
+	case state.ViewAppRunner:
+		m.appRunnerList.Bottom()
+		m.updateAppRunnerDetails()
+	case state.ViewFirehose:
+		m.firehoseList.Bottom()
+		m.updateFirehoseDetails()
+	case state.ViewCognito:
+		m.userPoolList.Bottom()
+		m.updateUserPoolDetails()
+	case state.ViewCognitoUsers:
+		m.cognitoUserList.Bottom()
+		m.updateCognitoUserDetails()
+	case state.ViewMSK:
+		m.mskList.Bottom()
+		m.updateMSKDetails()
+	case state.ViewMQ:
+		m.mqList.Bottom()
+		m.updateMQDetails()
+	case state.ViewDatabases:
+		m.databaseList.Bottom()
+		m.updateDatabaseDetails()
+	case state.ViewEC2:
+		m.instanceList.Bottom()
+		m.updateInstanceDetails()
+	case state.ViewEFS:
+		m.efsList.Bottom()
+		m.updateEFSDetails()
+	case state.ViewSchedules:
+		m.schedulesList.Bottom()
+		m.updateScheduleDetails()
+	case state.ViewSecrets:
+		m.secretsList.Bottom()
+		m.updateSecretDetails()
+	case state.ViewLogGroups:
+		m.logGroupsList.Bottom()
+		m.updateLogGroupDetails()
+	case state.ViewSES:
+		m.sesList.Bottom()
+		m.updateSESDetails()
+	case state.ViewSESSuppressions:
+		m.sesSuppressionList.Bottom()
+		m.updateSESSuppressionDetails()
+	case state.ViewActivity:
+		m.activityList.Bottom()
+		m.updateActivityDetails()
+	case state.ViewLogSearch:
+		m.logSearchList.Bottom()
+		m.updateLogSearchDetails()
+	case state.ViewHealth:
+		m.healthList.Bottom()
+		m.updateHealthDetails()
+	case state.ViewLambdaRuntimes:
+		m.runtimesList.Bottom()
+		m.updateRuntimeDetails()
+	case state.ViewImages:
+		m.imagesTable.Bottom()
+		m.updateImageDetails()
+	case state.ViewAlerts:
+		m.alertsList.Bottom()
+		m.updateAlertsDetails()
+	case state.ViewStats:
+		m.statsList.Bottom()
+	case state.ViewTimeline:
+		m.timelineList.Bottom()
+		m.updateTimelineDetails()
+	case state.ViewResourceTypes:
+		m.resourceTypeList.Bottom()
+		m.updateResourceTypeDetails()
+	case state.ViewCloudResources:
+		m.cloudResourceList.Bottom()
+		m.updateCloudResourceDetails()
 	case state.ViewAPIGateway:
 		m.apiGatewayList.Bottom()
 		m.updateAPIGatewayDetails()
@@ -163,6 +496,8 @@ func (m *Model) moveCursorBottom() {
 		m.ec2List.Bottom()
 	case state.ViewContainerSelect:
 		m.containerList.Bottom()
+	case state.ViewEndpointSelect:
+		m.endpointList.Bottom()
 	case state.ViewSQS:
 		m.sqsTable.Bottom()
 		m.updateQueueDetails()
@@ -204,6 +539,9 @@ func (m *Model) showHelp() {
 	m.logger.Info("  Enter/→      Select item")
 	m.logger.Info("  Esc/←        Go back")
 	m.logger.Info("  g/G          Jump to top/bottom")
+	m.logger.Info("  Tab/S-Tab    Focus next/previous panel; scroll keys move the focused one")
+	m.logger.Info("  C-w          Focus next panel where Tab switches containers or modes")
+	m.logger.Info("  Esc          Leave the focused logs panel")
 	m.logger.Info("")
 	m.logger.Info("QUICK KEYS:")
 	m.logger.Info("  0            Main menu")
@@ -213,20 +551,88 @@ func (m *Model) showHelp() {
 	m.logger.Info("  4            API Gateway")
 	m.logger.Info("  5            CloudFormation Stacks")
 	m.logger.Info("  6            DynamoDB Tables")
+	m.logger.Info("  7            App Runner Services")
 	m.logger.Info("")
 	m.logger.Info("ACTIONS:")
 	m.logger.Info("  :            Open command palette")
 	m.logger.Info("  /            Filter current list")
 	m.logger.Info("  r            Refresh current view")
 	m.logger.Info("  l            Toggle logs panel")
+	m.logger.Info("  </>          Narrow/widen list pane (saved per view)")
+	m.logger.Info("  {/}          Shrink/grow logs panel (saved per view)")
+	m.logger.Info("  z            Zoom focused pane")
+	m.logger.Info("  M            Pin to monitor dashboard (on service/queue/Lambda, :monitor to open)")
+	m.logger.Info("  O            Map consumers and producers (on queue)")
+	m.logger.Info("  f            Why DLQ messages fail: consumer errors and throttles (on queue)")
+	m.logger.Info("  w            Send a message, with group and deduplication IDs if FIFO (on queue)")
 	m.logger.Info("  L            View CloudWatch logs (on service/Lambda)")
+	m.logger.Info("  L            Search the logs of all services and functions (on stack)")
 	m.logger.Info("  i            Invoke Lambda function")
+	m.logger.Info("  i            Run now (on schedule)")
+	m.logger.Info("  i            Rotate now (on secret)")
+	m.logger.Info("  W            Shift traffic between versions of a Lambda alias")
+	m.logger.Info("  m            Durations, cold starts and memory use of a Lambda function")
+	m.logger.Info("  m            Local mock server answering the API's routes (on API stage)")
+	m.logger.Info("  H            Deployment history and rollback (on REST API stage)")
 	m.logger.Info("  p            Port forward (on service)")
+	m.logger.Info("  p            Tunnel to bootstrap brokers (on MSK cluster)")
+	m.logger.Info("  p            Tunnel to web console and AMQP ports (on MQ broker)")
+	m.logger.Info("  p            Tunnel to the endpoint via a jump host (on database)")
+	m.logger.Info("  d            Tunnel to a discovered endpoint (on service)")
+	m.logger.Info("  enter        Running and recently stopped tasks (on service), containers (on task)")
+	m.logger.Info("  d            Full details, stop reason and status transitions (on task)")
+	m.logger.Info("  S            Open a shell (ECS Exec on service/task/container/tunnel, SSM on EC2 instance)")
+	m.logger.Info("  v            Diff task definition with the previous one (on service)")
+	m.logger.Info("  F            Stop a percent of running tasks at random (on service)")
+	m.logger.Info("  B            Show and toggle scale-in protection of tasks (on service)")
+	m.logger.Info("  B            Toggle termination protection (on stack)")
+	m.logger.Info("  V            Cluster dashboard (on cluster or its services)")
+	m.logger.Info("  Z            Choose and order columns (on services, tasks, Lambda functions, SQS queues)")
 	m.logger.Info("  t            View tunnels")
+	m.logger.Info("  e            Edit proxy rules (on API Gateway tunnel)")
+	m.logger.Info("  e            Event timeline, live while the stack changes (on stack)")
+	m.logger.Info("  w            Export tunnel as YAML (in tunnels view)")
+	m.logger.Info("  P            Pause/resume App Runner service")
+	m.logger.Info("  P            Pause/resume schedule")
+	m.logger.Info("  P            Edit the stack policy in $EDITOR (on stack)")
+	m.logger.Info("  D            Start App Runner deployment")
+	m.logger.Info("  T            Put a test record (on Firehose stream)")
+	m.logger.Info("  T            Send a test email (on SES identity)")
+	m.logger.Info("  T            Show the template in the details pane, / to search it (on stack)")
+	m.logger.Info("  T            Time range (CloudWatch logs, log search, activity, Lambda performance)")
+	m.logger.Info("  U            Search users by email/username (on Cognito pool)")
+	m.logger.Info("  A            CloudTrail activity (on stack/service/table)")
+	m.logger.Info("  A            Confirm unconfirmed Cognito user")
+	m.logger.Info("  X            Disable/enable Cognito user")
+	m.logger.Info("  X            Remove address from SES suppression list")
+	m.logger.Info("  Q            Start/stop recording a macro")
+	m.logger.Info("  @            Replay the last recorded macro")
 	m.logger.Info("  a            Toggle auto-refresh")
 	m.logger.Info("  ?            Show this help")
 	m.logger.Info("  q            Quit")
 	m.logger.Info("")
+	m.logger.Info("JSON TREE (query results, focused details):")
+	m.logger.Info("  enter/space  Expand/collapse node")
+	m.logger.Info("  + / -        Expand/collapse all")
+	m.logger.Info("  C            Copy JSON path (e.g. $.items[3].id)")
+	m.logger.Info("  /            Search keys and values")
+	m.logger.Info("  |            Filter with a jq expression (e.g. .Items[] | .id), esc clears")
+	m.logger.Info("")
+	m.logger.Info("CLOUDWATCH LOGS:")
+	m.logger.Info("  /            Search the buffered lines, matches highlighted")
+	m.logger.Info("  n / N        Next/previous match (stops auto-scroll, G resumes)")
+	m.logger.Info("  Tab          Switch container")
+	m.logger.Info("  ctrl+s       Export the loaded lines to a file, appending while tailing (again to stop)")
+	m.logger.Info("  m            Merge the logs of every running task of the service, or show one task's")
+	m.logger.Info("")
+	m.logger.Info("DYNAMODB RESULTS:")
+	m.logger.Info("  t            Toggle column view")
+	m.logger.Info("  ←/→          Select column (column view)")
+	m.logger.Info("  o            Sort by column: ascending, descending, off")
+	m.logger.Info("  x / X        Hide column / show hidden columns")
+	m.logger.Info("  e            Edit item as DynamoDB JSON in $EDITOR, then put it")
+	m.logger.Info("  D            Delete item (asks first)")
+	m.logger.Info("")
 	m.logger.Info("COMMANDS (type : then command):")
 	m.logger.Info("  :main        Main menu")
 	m.logger.Info("  :ecs         ECS clusters")
@@ -235,8 +641,43 @@ func (m *Model) showHelp() {
 	m.logger.Info("  :apigateway  API Gateway")
 	m.logger.Info("  :stacks      CloudFormation stacks")
 	m.logger.Info("  :dynamodb    DynamoDB tables")
-	m.logger.Info("  :region      Change AWS region")
+	m.logger.Info("  :apprunner   App Runner services")
+	m.logger.Info("  :firehose    Firehose delivery streams")
+	m.logger.Info("  :cognito     Cognito user pools")
+	m.logger.Info("  :msk         MSK (Kafka) clusters")
+	m.logger.Info("  :mq          Amazon MQ (ActiveMQ/RabbitMQ) brokers")
+	m.logger.Info("  :databases   RDS instances and clusters, RDS Proxy and Redshift endpoints")
+	m.logger.Info("  :ec2         EC2 instances: S Session Manager shell, p port forward")
+	m.logger.Info("  :efs         EFS file systems")
+	m.logger.Info("  :schedules   EventBridge Scheduler schedules")
+	m.logger.Info("  :secrets     Secrets Manager secrets with rotation status")
+	m.logger.Info("  :loggroups   Log groups by size: space marks, o orphans, s retention, X delete")
+	m.logger.Info("  :ses         SES sending, identities and suppression list")
+	m.logger.Info("  :resources   Cloud Control resources [type, e.g. AWS::MSK::Cluster]")
+	m.logger.Info("  :macro [n]   List or replay macros (save <name> [key], delete <name>)")
+	m.logger.Info("  :health      Account health: failed stacks, alarms, DLQs, certificates")
+	m.logger.Info("  :costs       Estimated charges of the month and budgets near their limit")
+	m.logger.Info("  :cluster     Dashboard of the selected ECS cluster: tasks, usage, failing and recent services (V)")
+	m.logger.Info("  :runtimes    Lambda functions by runtime with deprecation dates (w for CSV)")
+	m.logger.Info("  :images      Services of the cluster or stack running stale ECR images")
+	m.logger.Info("  :dlqexport   Toggle saving new DLQ messages of the selected queue")
+	m.logger.Info("  :perf        p50/p95/p99 durations, cold starts and memory of the selected function (m)")
+	m.logger.Info("  :failures    DLQ messages of the selected queue beside its consumers' errors (f)")
+	m.logger.Info("  :report [f]  Markdown snapshot of the selected stack or cluster, to a file and the clipboard")
+	m.logger.Info("  :terraform   Read the Terraform states of the profile again (terraform_states)")
+	m.logger.Info("  :alerts      Watch expressions and the alerts they raised")
+	m.logger.Info("  :watch <c>   Watch the selected service or queue (off removes)")
+	m.logger.Info("  :group <tag> Group stacks by tag key or name prefix (- / + fold all)")
+	m.logger.Info("  :stats       vaws's own memory, event loop and cache sizes (w writes profiles)")
+	m.logger.Info("  :timeline    Views, resources, actions and tunnels of the session (Y copies as text)")
+	m.logger.Info("  :region      Change AWS region (p pins a region to the top)")
+	m.logger.Info("  :tag <k=v>   Scope every list to resources with the tag (alone toggles, clear drops)")
+	m.logger.Info("  :accounts    Switch to a member account of the organization (org_role)")
+	m.logger.Info("  :https       Toggle HTTPS for new API proxies")
+	m.logger.Info("  :tasks       Tasks of the selected service")
 	m.logger.Info("  :tunnels     Port forward tunnels")
+	m.logger.Info("  :export [f]  Export selected tunnel as YAML")
+	m.logger.Info("  :import <f>  Recreate tunnel from YAML file")
 	m.logger.Info("  :logs        Toggle logs panel")
 	m.logger.Info("  :refresh     Refresh current view")
 	m.logger.Info("  :quit        Quit application")
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+// selectedARN returns the ARN and name of the resource under the cursor, or
+// "" if the view lists nothing notes can be attached to.
+func (m *Model) selectedARN() (arn, name string) {
+	switch m.state.View {
+	case state.ViewStacks:
+		if item := m.stacksList.SelectedItem(); item != nil && !item.Group {
+			for _, s := range m.state.Stacks {
+				if s.Name == item.ID {
+					return s.ID, s.Name
+				}
+			}
+		}
+	case state.ViewServices:
+		if svc := m.selectedService(); svc != nil {
+			return svc.ARN, svc.Name
+		}
+	case state.ViewLambda:
+		if item := m.lambdaList.SelectedItem(); item != nil {
+			for _, fn := range m.state.Functions {
+				if fn.Name == item.ID {
+					return fn.ARN, fn.Name
+				}
+			}
+		}
+	case state.ViewSQS:
+		if q := m.sqsTable.SelectedQueue(); q != nil {
+			return q.ARN, q.Name
+		}
+	case state.ViewDynamoDB:
+		if t := m.dynamodbTable.SelectedTable(); t != nil {
+			return t.ARN, t.Name
+		}
+	}
+	return "", ""
+}
+
+// handleNote runs :note on the selected resource: no args edit the note in
+// $EDITOR, "clear" removes it, and anything else becomes the note.
+func (m *Model) handleNote(args []string) tea.Cmd {
+	arn, name := m.selectedARN()
+	if arn == "" {
+		m.logger.Warn("Select a stack, service, function, queue or table to attach a note to")
+		return nil
+	}
+
+	switch {
+	case len(args) == 0:
+		m.noteARN, m.noteName = arn, name
+		return m.openEditor(editNote, m.notes[arn].Text, ".md")
+	case len(args) == 1 && args[0] == "clear":
+		m.setNote(arn, name, "")
+	default:
+		m.setNote(arn, name, strings.Join(args, " "))
+	}
+	return nil
+}
+
+// setNote saves the note of a resource, or removes it if text is empty.
+func (m *Model) setNote(arn, name, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		if _, ok := m.notes[arn]; !ok {
+			return
+		}
+		if err := config.DeleteNote(arn); err != nil {
+			m.logger.Error("Failed to remove note: %v", err)
+			return
+		}
+		delete(m.notes, arn)
+		m.logger.Info("Removed the note of %s", name)
+	} else {
+		note := config.Note{ARN: arn, Text: text, UpdatedAt: time.Now()}
+		if err := config.SaveNote(note); err != nil {
+			m.logger.Error("Failed to save note: %v", err)
+			return
+		}
+		m.notes[arn] = note
+		m.logger.Info("Saved the note of %s", name)
+	}
+	m.updateCurrentList()
+}
+
+// hasNote returns true if the resource has a note.
+func (m *Model) hasNote(arn string) bool {
+	_, ok := m.notes[arn]
+	return ok
+}
+
+// noteRows shows the note of a resource, one row per line, for the top of
+// its details.
+func (m *Model) noteRows(arn string) []components.DetailRow {
+	note, ok := m.notes[arn]
+	if !ok {
+		return nil
+	}
+	s := GetStyles()
+	var rows []components.DetailRow
+	for i, line := range strings.Split(note.Text, "\n") {
+		label := ""
+		if i == 0 {
+			label = "Note"
+		}
+		rows = append(rows, components.DetailRow{Label: label, Value: line, Style: s.StatusWarning})
+	}
+	rows = append(rows,
+		components.DetailRow{Label: "Noted", Value: format.Time(note.UpdatedAt), Style: s.Muted},
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+	)
+	return rows
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/deeplink"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+)
+
+// maxRecentResources is how many recently viewed resources the command
+// palette remembers.
+const maxRecentResources = 20
+
+// paletteResource describes the resource an ARN names the way the command
+// palette lists it.
+func (m *Model) paletteResource(arn string) (components.PaletteResource, bool) {
+	res, err := deeplink.New(m.state.Profile, arn).Resource()
+	if err != nil || res.Name == "" {
+		return components.PaletteResource{}, false
+	}
+	r := components.PaletteResource{Kind: string(res.Kind), Name: res.Name, ARN: arn}
+	if res.Cluster != "" {
+		r.Context = "cluster " + res.Cluster
+	}
+	return r, true
+}
+
+// recordRecent remembers the resource under the cursor as viewed, so the
+// command palette offers it first.
+func (m *Model) recordRecent() {
+	arn, _ := m.selectedARN()
+	if m.state.View == state.ViewClusters {
+		if item := m.clustersList.SelectedItem(); item != nil {
+			for _, c := range m.state.Clusters {
+				if c.Name == item.ID {
+					arn = c.ARN
+				}
+			}
+		}
+	}
+	if arn != "" {
+		m.addRecent(arn)
+	}
+}
+
+// addRecent puts a resource at the front of the recently viewed ones and
+// records it in the session timeline.
+func (m *Model) addRecent(arn string) {
+	res, ok := m.paletteResource(arn)
+	if !ok {
+		return
+	}
+	m.recordTimeline(timelineInspect, "Inspected %s", res.Label())
+	res.Recent = true
+	recent := []components.PaletteResource{res}
+	for _, r := range m.recentResources {
+		if r.ARN != arn && len(recent) < maxRecentResources {
+			recent = append(recent, r)
+		}
+	}
+	m.recentResources = recent
+}
+
+// paletteResources returns the resources the command palette offers: the
+// recently viewed ones, then those loaded in the lists.
+func (m *Model) paletteResources() []components.PaletteResource {
+	resources := append([]components.PaletteResource(nil), m.recentResources...)
+	seen := make(map[string]bool, len(resources))
+	for _, r := range resources {
+		seen[r.ARN] = true
+	}
+	add := func(arn string) {
+		if arn == "" || seen[arn] {
+			return
+		}
+		if r, ok := m.paletteResource(arn); ok {
+			seen[arn] = true
+			resources = append(resources, r)
+		}
+	}
+	for _, s := range m.state.Stacks {
+		add(s.ID)
+	}
+	for _, c := range m.state.Clusters {
+		add(c.ARN)
+	}
+	for _, svc := range m.state.Services {
+		add(svc.ARN)
+	}
+	for _, fn := range m.state.Functions {
+		add(fn.ARN)
+	}
+	for _, q := range m.state.Queues {
+		add(q.ARN)
+	}
+	for _, t := range m.state.Tables {
+		add(t.ARN)
+	}
+	return resources
+}
+
+// openCommandPalette shows the command palette with the resources known so
+// far as candidates.
+func (m *Model) openCommandPalette() tea.Cmd {
+	m.commandPalette.SetWidth(m.width)
+	m.commandPalette.SetResources(m.paletteResources())
+	return m.commandPalette.Activate()
+}
+
+// openPaletteResource navigates to a resource picked in the command palette.
+func (m *Model) openPaletteResource(res *components.PaletteResource) tea.Cmd {
+	m.addRecent(res.ARN)
+	return m.openLink(deeplink.New(m.state.Profile, res.ARN))
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"math"
+
+	"vaws/internal/config"
+	"vaws/internal/state"
+)
+
+const (
+	// Limits for resizing panes with the keyboard
+	minListPaneRatio  = 0.2
+	maxListPaneRatio  = 0.8
+	listPaneRatioStep = 0.05
+	minLogsPaneHeight = 3
+	maxLogsPaneHeight = 30
+)
+
+// layoutViewNames are the keys under which each view's pane sizes are saved.
+var layoutViewNames = map[state.View]string{
+	state.ViewMain:            "main",
+	state.ViewStacks:          "stacks",
+	state.ViewStackResources:  "stack_resources",
+	state.ViewStackEvents:     "stack_events",
+	state.ViewClusters:        "clusters",
+	state.ViewServices:        "services",
+	state.ViewTasks:           "tasks",
+	state.ViewTaskContainers:  "task_containers",
+	state.ViewTunnels:         "tunnels",
+	state.ViewLambda:          "lambda",
+	state.ViewAPIGateway:      "apigateway",
+	state.ViewAPIStages:       "api_stages",
+	state.ViewJumpHostSelect:  "jump_host_select",
+	state.ViewContainerSelect: "container_select",
+	state.ViewCloudWatchLogs:  "cloudwatch_logs",
+	state.ViewSQS:             "sqs",
+	state.ViewDynamoDB:        "dynamodb",
+	state.ViewDynamoDBQuery:   "dynamodb_query",
+	state.ViewAppRunner:       "apprunner",
+	state.ViewEndpointSelect:  "endpoint_select",
+	state.ViewDiff:            "diff",
+	state.ViewMonitor:         "monitor",
+	state.ViewFirehose:        "firehose",
+	state.ViewCognito:         "cognito",
+	state.ViewCognitoUsers:    "cognito_users",
+	state.ViewResourceTypes:   "resource_types",
+	state.ViewMSK:             "msk",
+	state.ViewMQ:              "mq",
+	state.ViewDatabases:       "databases",
+	state.ViewEC2:             "ec2",
+	state.ViewEFS:             "efs",
+	state.ViewSchedules:       "schedules",
+	state.ViewSecrets:         "secrets",
+	state.ViewLogGroups:       "log_groups",
+	state.ViewSES:             "ses",
+	state.ViewSESSuppressions: "ses_suppressions",
+	state.ViewActivity:        "activity",
+	state.ViewLogSearch:       "log_search",
+	state.ViewHealth:          "health",
+	state.ViewLambdaRuntimes:  "runtimes",
+	state.ViewImages:          "images",
+	state.ViewAlerts:          "alerts",
+	state.ViewStats:           "stats",
+	state.ViewTimeline:        "timeline",
+	state.ViewCloudResources:  "cloud_resources",
+}
+
+// defaultListRatios are the list pane's share of the width in views whose
+// rows need more room than listPaneRatio leaves, until it is resized.
+var defaultListRatios = map[state.View]float64{
+	state.ViewTasks:       0.55,
+	state.ViewStackEvents: 0.65,
+	state.ViewDatabases:   0.6,
+	state.ViewEC2:         0.6,
+}
+
+// currentLayout returns the saved pane sizes of the current view.
+func (m *Model) currentLayout() (string, config.LayoutConfig) {
+	name, ok := layoutViewNames[m.state.View]
+	if !ok {
+		return name, config.LayoutConfig{}
+	}
+	return name, m.layout[name]
+}
+
+// currentListRatio returns the list pane's share of the width in the current view.
+func (m *Model) currentListRatio() float64 {
+	_, layout := m.currentLayout()
+	if layout.ListRatio == 0 {
+		if ratio, ok := defaultListRatios[m.state.View]; ok {
+			return ratio
+		}
+		return listPaneRatio
+	}
+	return math.Min(maxListPaneRatio, math.Max(minListPaneRatio, layout.ListRatio))
+}
+
+// currentLogsHeight returns the logs panel height in the current view. The
+// panel never takes more than half the terminal, so a size saved on a tall
+// terminal still leaves room for the content on a short one.
+func (m *Model) currentLogsHeight() int {
+	_, layout := m.currentLayout()
+	height := logsHeight
+	if layout.LogsHeight != 0 {
+		height = min(maxLogsPaneHeight, max(minLogsPaneHeight, layout.LogsHeight))
+	}
+	if m.height > 0 {
+		height = min(height, max(minLogsPaneHeight, m.height/2))
+	}
+	return height
+}
+
+// splitWidths divides width between the list and details panes. A zoomed
+// layout gives the whole width to the focused pane.
+func (m *Model) splitWidths(width int) (listWidth, detailsWidth int) {
+	if m.paneZoomed {
+		if m.focusedPane() == focusDetails {
+			return 0, width
+		}
+		return width, 0
+	}
+	listWidth = int(float64(width) * m.currentListRatio())
+	return listWidth, width - listWidth
+}
+
+// resizeListPane grows or shrinks the list pane by delta of the width.
+func (m *Model) resizeListPane(delta float64) {
+	ratio := m.currentListRatio() + delta
+	ratio = math.Round(ratio/listPaneRatioStep) * listPaneRatioStep
+	ratio = math.Min(maxListPaneRatio, math.Max(minListPaneRatio, ratio))
+
+	m.paneZoomed = false
+	m.saveLayout(func(layout *config.LayoutConfig) {
+		layout.ListRatio = ratio
+	})
+	m.logger.Debug("List pane: %.0f%% of width", ratio*100)
+}
+
+// resizeLogsPane grows or shrinks the logs panel by delta lines, showing it
+// first if it is hidden.
+func (m *Model) resizeLogsPane(delta int) {
+	if !m.state.ShowLogs {
+		m.state.ShowLogs = true
+		m.updateComponentSizes()
+		return
+	}
+
+	height := min(maxLogsPaneHeight, max(minLogsPaneHeight, m.currentLogsHeight()+delta))
+	m.saveLayout(func(layout *config.LayoutConfig) {
+		layout.LogsHeight = height
+	})
+	m.updateComponentSizes()
+}
+
+// saveLayout applies update to the current view's pane sizes and writes them
+// to the layout state file.
+func (m *Model) saveLayout(update func(layout *config.LayoutConfig)) {
+	name, layout := m.currentLayout()
+	if name == "" {
+		return
+	}
+	update(&layout)
+	m.layout[name] = layout
+	if err := m.layout.Save(); err != nil {
+		m.logger.Warn("Failed to save layout: %v", err)
+	}
+}
+
+// toggleZoom maximizes the focused pane, hiding the other pane and the logs
+// panel, or restores the split layout.
+func (m *Model) toggleZoom() {
+	m.paneZoomed = !m.paneZoomed
+	m.updateComponentSizes()
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+
+	"vaws/internal/model"
+)
+
+// portOption is a remote port offered in the port forward dialog.
+type portOption struct {
+	port      int
+	container string // Empty for presets, which let the tunnel pick the container
+	label     string
+}
+
+// portPresets are offered after the container's own exposed ports.
+var portPresets = []portOption{
+	{port: 5432, label: "postgres"},
+	{port: 6379, label: "redis"},
+	{port: 8080, label: "http"},
+}
+
+// wellKnownPorts maps common ports to the kind of service usually behind them.
+var wellKnownPorts = map[int]string{
+	80:    "http",
+	443:   "https",
+	3000:  "http",
+	3306:  "mysql",
+	5000:  "http",
+	5432:  "postgres",
+	5672:  "amqp",
+	6379:  "redis",
+	8000:  "http",
+	8080:  "http",
+	8443:  "https",
+	9000:  "http",
+	9092:  "kafka",
+	9200:  "elasticsearch",
+	11211: "memcached",
+	27017: "mongodb",
+}
+
+// servicePortOptions returns the remote ports to offer for a service: every
+// port exposed in its task definition, followed by presets not already listed.
+func servicePortOptions(service model.Service) []portOption {
+	var options []portOption
+	exposed := make(map[int]bool)
+
+	for _, cp := range service.ContainerPorts {
+		for _, port := range cp.Ports {
+			label := cp.ContainerName
+			if kind, ok := wellKnownPorts[port]; ok {
+				label += " · " + kind
+			}
+			options = append(options, portOption{port: port, container: cp.ContainerName, label: label})
+			exposed[port] = true
+		}
+	}
+
+	for _, preset := range portPresets {
+		if !exposed[preset.port] {
+			options = append(options, preset)
+		}
+	}
+	return options
+}
+
+// defaultPortOption returns the index of the option to preselect: the first
+// app port of a non-sidecar container, or the first option otherwise.
+func defaultPortOption(options []portOption) int {
+	for i, opt := range options {
+		c := model.Container{Name: opt.container}
+		if opt.container != "" && !c.IsSidecar() {
+			return i
+		}
+	}
+	return 0
+}
+
+// String formats the option for the port forward dialog.
+func (o portOption) String() string {
+	return fmt.Sprintf("%-5d %s", o.port, o.label)
+}
+
+// startServicePortInput opens the port forward dialog for an ECS service.
+func (m *Model) startServicePortInput(service *model.Service) {
+	m.pendingPortForward = service
+	m.portOptions = servicePortOptions(*service)
+	m.portOptionIdx = defaultPortOption(m.portOptions)
+	m.enteringPort = true
+	m.portInput.SetValue("")
+	m.portInput.Focus()
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/tunnel"
+)
+
+// prereqsCheckedMsg carries which of the programs tunnels and shells run
+// were found.
+type prereqsCheckedMsg struct {
+	prereqs tunnel.Prerequisites
+	took    time.Duration
+}
+
+// checkPrerequisites looks for the AWS CLI and session-manager-plugin in the
+// background, so that a missing one greys out tunnels and shells up front
+// rather than failing when one starts.
+func checkPrerequisites() tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		prereqs := tunnel.CheckPrerequisites()
+		return prereqsCheckedMsg{prereqs: prereqs, took: time.Since(start)}
+	}
+}
+
+// handlePrereqsChecked records the programs found and says how to install
+// the missing ones.
+func (m *Model) handlePrereqsChecked(msg prereqsCheckedMsg) {
+	m.prereqs = &msg.prereqs
+	m.logger.Debug("Checked for the AWS CLI and session-manager-plugin in %s", msg.took.Round(time.Millisecond))
+	for _, b := range []tunnel.Binary{msg.prereqs.AWSCLI, msg.prereqs.Plugin} {
+		if b.OK() {
+			m.logger.Debug("Found %s %s at %s", b.Name, b.Version, b.Path)
+		}
+	}
+	missing := msg.prereqs.Missing()
+	if len(missing) == 0 {
+		return
+	}
+	for _, b := range missing {
+		m.logger.Warn("Port forwarding and shells are disabled: %v (install: %s)", b.Err, tunnel.InstallHint(b.Name))
+	}
+	m.updateQuickBarActions()
+}
+
+// missingPrerequisites returns the programs an action category needs that
+// weren't found. Until the check completes nothing is reported missing.
+func (m *Model) missingPrerequisites(action string) []tunnel.Binary {
+	if m.prereqs == nil || (action != config.ActionTunnel && action != config.ActionShell) {
+		return nil
+	}
+	return m.prereqs.Missing()
+}
+
+// degradedStatus returns the header warning for tunnels and shells turned
+// off by missing programs, or "" if nothing is missing.
+func (m *Model) degradedStatus() string {
+	missing := m.missingPrerequisites(config.ActionTunnel)
+	if len(missing) == 0 {
+		return ""
+	}
+	names := make([]string, len(missing))
+	for i, b := range missing {
+		names[i] = b.Name
+	}
+	return "SSM off: " + strings.Join(names, ", ") + " missing"
+}
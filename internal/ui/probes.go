@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+	"vaws/internal/tunnel"
+)
+
+// tunnelProbeInterval is how often a healthy or failing tunnel is probed again.
+// Tunnels whose session isn't up yet are probed on every tunnel check.
+const tunnelProbeInterval = 30 * time.Second
+
+// tunnelProbedMsg carries the result of a health probe through a tunnel.
+type tunnelProbedMsg struct {
+	id    string
+	probe model.TunnelProbe
+}
+
+// tunnelProbes holds the last probe of each tunnel and the probes running.
+type tunnelProbes struct {
+	results  map[string]model.TunnelProbe // Tunnel ID -> last probe
+	inFlight map[string]bool
+}
+
+// probeTunnels probes the health path through active tunnels that are due,
+// if a path is configured. Ports of well-known non-HTTP services, such as
+// postgres, are skipped.
+func (m *Model) probeTunnels() tea.Cmd {
+	if m.cfg == nil || m.tunnelManager == nil {
+		return nil
+	}
+	path := m.cfg.GetTunnelHealthPath(m.state.Profile)
+	if path == "" {
+		return nil
+	}
+	if m.probes.results == nil {
+		m.probes.results = make(map[string]model.TunnelProbe)
+		m.probes.inFlight = make(map[string]bool)
+	}
+
+	var cmds []tea.Cmd
+	active := make(map[string]bool)
+	for _, t := range m.tunnelManager.GetTunnels() {
+		if t.Status != model.TunnelStatusActive {
+			continue
+		}
+		kind, known := wellKnownPorts[t.RemotePort]
+		if known && kind != "http" && kind != "https" {
+			continue
+		}
+		active[t.ID] = true
+		last, probed := m.probes.results[t.ID]
+		if m.probes.inFlight[t.ID] || (probed && last.SessionUp && time.Since(last.At) < tunnelProbeInterval) {
+			continue
+		}
+
+		m.probes.inFlight[t.ID] = true
+		id, localPort, useTLS := t.ID, t.LocalPort, kind == "https"
+		cmds = append(cmds, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+			return tunnelProbedMsg{id: id, probe: tunnel.Probe(ctx, localPort, path, useTLS)}
+		})
+	}
+
+	// Forget tunnels that stopped
+	for id := range m.probes.results {
+		if !active[id] {
+			delete(m.probes.results, id)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleTunnelProbed records a probe and logs when the application starts or
+// stops answering.
+func (m *Model) handleTunnelProbed(msg tunnelProbedMsg) {
+	delete(m.probes.inFlight, msg.id)
+	t, ok := m.tunnelManager.GetTunnel(msg.id)
+	if !ok || t.Status != model.TunnelStatusActive {
+		return
+	}
+
+	prev, probed := m.probes.results[msg.id]
+	m.probes.results[msg.id] = msg.probe
+	m.tunnelsPanel.SetProbes(m.probes.results)
+
+	p := msg.probe
+	switch {
+	case !p.SessionUp:
+		// Still connecting, or the session died and the watch will notice
+	case p.Healthy():
+		if !probed || !prev.Healthy() {
+			m.logger.Info("localhost:%d%s answered %d in %s", t.LocalPort, p.Path, p.StatusCode, p.Latency.Round(time.Millisecond))
+		}
+	case p.Responding():
+		if !probed || prev.StatusCode != p.StatusCode {
+			m.logger.Warn("localhost:%d%s answered %d", t.LocalPort, p.Path, p.StatusCode)
+		}
+	default:
+		if !probed || prev.Responding() || !prev.SessionUp {
+			m.logger.Warn("Tunnel localhost:%d is up but the application does not respond on %s (%s)", t.LocalPort, p.Path, p.Error)
+		}
+	}
+}
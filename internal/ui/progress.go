@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/aws"
+	"vaws/internal/ui/components"
+)
+
+// withProgress returns a context whose client calls report their progress to
+// the given loading indicator. Intermediate updates are dropped rather than
+// blocking the loader when the UI falls behind, but the update completing a
+// call is always delivered so its step does not keep spinning.
+func (m *Model) withProgress(ctx context.Context, progress *components.LoadingProgress) context.Context {
+	ch := m.progressChan
+	gen := progress.Generation()
+	return aws.WithProgress(ctx, func(call, unit string, done, total int) {
+		msg := loaderProgressMsg{progress: progress, gen: gen, call: call, unit: unit, done: done, total: total}
+		if total > 0 && done >= total {
+			select {
+			case ch <- msg:
+			case <-ctx.Done():
+			}
+			return
+		}
+		select {
+		case ch <- msg:
+		default:
+		}
+	})
+}
+
+// waitForProgress waits for the next progress update from any loader.
+func (m *Model) waitForProgress() tea.Cmd {
+	ch := m.progressChan
+	return func() tea.Msg {
+		return <-ch
+	}
+}
@@ -0,0 +1,278 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// Minutes a task is protected for: ECS protects for 120 unless told
+// otherwise, for up to 48 hours.
+const (
+	protectionDefaultMinutes = 120
+	protectionMaxMinutes     = 2880
+)
+
+// taskProtectionDialog lists the running tasks of a service with their
+// scale-in protection, and turns it on or off per task.
+type taskProtectionDialog struct {
+	service    model.Service
+	tasks      []model.Task // Running tasks
+	protection map[string]model.TaskProtection
+	loading    bool
+	updating   bool
+	err        error
+	cursor     int
+	input      textinput.Model // Minutes to protect for
+}
+
+// taskProtectionLoadedMsg carries the running tasks of a service and their
+// protection.
+type taskProtectionLoadedMsg struct {
+	service    string
+	tasks      []model.Task
+	protection map[string]model.TaskProtection
+	err        error
+}
+
+// taskProtectionUpdatedMsg carries the protection of a task after it was
+// turned on or off.
+type taskProtectionUpdatedMsg struct {
+	service    string
+	protection []model.TaskProtection
+	err        error
+}
+
+// openTaskProtection opens the protection dialog of the selected service and
+// loads its running tasks and their protection.
+func (m *Model) openTaskProtection() tea.Cmd {
+	if m.state.View != state.ViewServices || m.client == nil {
+		return nil
+	}
+	svc := m.selectedService()
+	if svc == nil {
+		return nil
+	}
+
+	input := textinput.New()
+	input.Placeholder = strconv.Itoa(protectionDefaultMinutes)
+	input.CharLimit = 4
+	input.Width = 10
+	input.Focus()
+	m.protection = &taskProtectionDialog{service: *svc, loading: true, input: input}
+
+	client, cluster, name := m.client, svc.ClusterARN, svc.Name
+	return tea.Batch(textinput.Blink, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		msg := taskProtectionLoadedMsg{service: name}
+		tasks, err := client.ListTasksForService(ctx, cluster, name)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		var arns []string
+		for _, t := range tasks {
+			if t.LastStatus == "RUNNING" {
+				msg.tasks = append(msg.tasks, t)
+				arns = append(arns, t.TaskARN)
+			}
+		}
+		if len(arns) > 0 {
+			msg.protection, msg.err = client.GetTaskProtection(ctx, cluster, arns)
+		}
+		return msg
+	})
+}
+
+// handleTaskProtectionLoaded fills the protection dialog, if it is still
+// open on the service.
+func (m *Model) handleTaskProtectionLoaded(msg taskProtectionLoadedMsg) {
+	p := m.protection
+	if p == nil || p.service.Name != msg.service {
+		return
+	}
+	p.loading = false
+	p.err = msg.err
+	p.tasks = msg.tasks
+	p.protection = msg.protection
+	if p.protection == nil {
+		p.protection = make(map[string]model.TaskProtection)
+	}
+}
+
+// handleTaskProtectionKey handles key messages while the protection dialog
+// is open.
+func (m *Model) handleTaskProtectionKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.protection
+	switch msg.String() {
+	case "esc":
+		m.protection = nil
+		return nil
+	case "up":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return nil
+	case "down":
+		if p.cursor < len(p.tasks)-1 {
+			p.cursor++
+		}
+		return nil
+	case "enter":
+		return m.toggleTaskProtection()
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return cmd
+}
+
+// toggleTaskProtection turns the protection of the task under the cursor
+// off if it is on, or on for the minutes typed.
+func (m *Model) toggleTaskProtection() tea.Cmd {
+	p := m.protection
+	if p.loading || p.updating || p.cursor >= len(p.tasks) {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+	task := p.tasks[p.cursor]
+	enable := !p.protection[task.TaskARN].Enabled
+
+	minutes := protectionDefaultMinutes
+	if value := strings.TrimSpace(p.input.Value()); enable && value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > protectionMaxMinutes {
+			m.logger.Warn("Invalid minutes %q: use a whole number from 1 to %d", value, protectionMaxMinutes)
+			return nil
+		}
+		minutes = n
+	}
+
+	if enable {
+		m.logger.Info("Protecting task %s of %s from scale-in for %d minutes", task.TaskID, p.service.Name, minutes)
+	} else {
+		m.logger.Info("Removing scale-in protection of task %s of %s", task.TaskID, p.service.Name)
+	}
+	p.updating = true
+	client, cluster, service := m.client, p.service.ClusterARN, p.service.Name
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		protection, err := client.UpdateTaskProtection(ctx, cluster, []string{task.TaskARN}, enable, minutes)
+		return taskProtectionUpdatedMsg{service: service, protection: protection, err: err}
+	}
+}
+
+// handleTaskProtectionUpdated shows the new protection of a task in the
+// dialog, if it is still open, and logs it.
+func (m *Model) handleTaskProtectionUpdated(msg taskProtectionUpdatedMsg) {
+	p := m.protection
+	if p != nil && p.service.Name == msg.service {
+		p.updating = false
+	}
+	if msg.err != nil {
+		m.logger.Error("Failed to update task protection: %v", msg.err)
+		return
+	}
+	for _, tp := range msg.protection {
+		if p != nil && p.service.Name == msg.service {
+			p.protection[tp.TaskARN] = tp
+		}
+		m.logger.Info("Task %s: %s", taskIDFromARN(tp.TaskARN), protectionText(tp))
+	}
+}
+
+// taskIDFromARN returns the ID at the end of a task ARN.
+func taskIDFromARN(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+// protectionText describes the protection of a task, e.g. "protected until
+// 2024-05-01 14:00:00 (in 2h)".
+func protectionText(p model.TaskProtection) string {
+	if !p.Enabled {
+		return "not protected"
+	}
+	if p.ExpiresAt.IsZero() {
+		return "protected"
+	}
+	return "protected until " + format.Absolute(p.ExpiresAt) + " (" + format.Relative(time.Since(p.ExpiresAt)) + ")"
+}
+
+// renderTaskProtectionDialog renders the protection dialog: the running
+// tasks with their protection and the minutes input.
+func (m *Model) renderTaskProtectionDialog() string {
+	p := m.protection
+	dialogWidth := 80
+	if m.width < 90 {
+		dialogWidth = max(m.width-10, 40)
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Task protection: " + truncateString(p.service.Name, dialogWidth-24))
+
+	switch {
+	case p.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Loading tasks..."))
+	case p.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(p.err.Error(), dialogWidth-6)) + "\n\n" + hintStyle.Render("esc to close"))
+	case len(p.tasks) == 0:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("No running tasks") + "\n\n" + hintStyle.Render("esc to close"))
+	}
+
+	var lines []string
+	for i, t := range p.tasks {
+		cursor := "  "
+		if i == p.cursor {
+			cursor = theme.Symbol("▶ ", "> ")
+		}
+		tp := p.protection[t.TaskARN]
+		style := s.Muted
+		if tp.Enabled {
+			style = s.StatusWarning
+		}
+		lines = append(lines, cursor+fmt.Sprintf("%-32s  ", t.TaskID)+style.Render(protectionText(tp)))
+	}
+
+	action := "enter protects the task"
+	if p.protection[p.tasks[p.cursor].TaskARN].Enabled {
+		action = "enter removes the protection"
+	}
+	if p.updating {
+		action = "updating..."
+	}
+	content := title + "\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		"Minutes: " + p.input.View() + "\n\n" +
+		hintStyle.Render(fmt.Sprintf("↑/↓ task · %s · up to %d minutes · esc", action, protectionMaxMinutes))
+	return dialogStyle.Render(content)
+}
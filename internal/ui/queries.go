@@ -0,0 +1,243 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+// openQueryDialog opens the query or scan dialog on a table, offering its
+// saved and recent queries with ctrl+r.
+func (m *Model) openQueryDialog(table *model.Table, isQuery bool) tea.Cmd {
+	m.dynamodbQueryDialog.SetSize(m.width, m.height)
+	cmd := m.dynamodbQueryDialog.Activate(table.Name, table.PartitionKey(), table.SortKey(), isQuery)
+	m.dynamodbQueryDialog.SetHistory(m.queryHistory(table))
+	return cmd
+}
+
+// queryHistory returns the saved queries of a table, then the recent ones.
+func (m *Model) queryHistory(table *model.Table) []components.QueryHistoryEntry {
+	tq := m.queries[table.Name]
+	var entries []components.QueryHistoryEntry
+	for _, sq := range tq.Saved {
+		entry := queryEntry(sq, table)
+		entry.Label = sq.Name + "  " + entry.Label
+		entry.Saved = true
+		entries = append(entries, entry)
+	}
+	for _, sq := range tq.Recent {
+		entries = append(entries, queryEntry(sq, table))
+	}
+	return entries
+}
+
+// queryEntry turns a stored query back into dialog parameters for a table.
+func queryEntry(sq config.SavedQuery, table *model.Table) components.QueryHistoryEntry {
+	entry := components.QueryHistoryEntry{Label: describeQuery(sq)}
+	if sq.Scan {
+		entry.ScanParams = &model.ScanParams{
+			TableName:        table.Name,
+			PartitionKeyName: table.PartitionKey(),
+			SortKeyName:      table.SortKey(),
+			FilterExpression: sq.FilterExpression,
+			FilterAttrName:   sq.FilterAttr,
+			FilterAttrValue:  sq.FilterValue,
+			Limit:            sq.Limit,
+			IndexName:        sq.Index,
+		}
+		return entry
+	}
+	entry.QueryParams = &model.QueryParams{
+		TableName:        table.Name,
+		PartitionKeyName: table.PartitionKey(),
+		PartitionKeyVal:  sq.PartitionKey,
+		SortKeyName:      table.SortKey(),
+		SortKeyVal:       sq.SortKey,
+		SortKeyCondition: model.SortKeyCondition(sq.SortKeyCondition),
+		FilterExpression: sq.FilterExpression,
+		FilterAttrName:   sq.FilterAttr,
+		FilterAttrValue:  sq.FilterValue,
+		Limit:            sq.Limit,
+		ScanIndexForward: true,
+		IndexName:        sq.Index,
+	}
+	return entry
+}
+
+// storedQuery returns the parameters of a dialog result to store, or false
+// if it has none.
+func storedQuery(result *components.QueryDialogResult) (table string, sq config.SavedQuery, ok bool) {
+	switch {
+	case result.QueryParams != nil:
+		q := result.QueryParams
+		return q.TableName, config.SavedQuery{
+			Index:            q.IndexName,
+			PartitionKey:     q.PartitionKeyVal,
+			SortKey:          q.SortKeyVal,
+			SortKeyCondition: string(q.SortKeyCondition),
+			FilterExpression: q.FilterExpression,
+			FilterAttr:       q.FilterAttrName,
+			FilterValue:      q.FilterAttrValue,
+			Limit:            q.Limit,
+		}, true
+	case result.ScanParams != nil:
+		s := result.ScanParams
+		return s.TableName, config.SavedQuery{
+			Scan:             true,
+			Index:            s.IndexName,
+			FilterExpression: s.FilterExpression,
+			FilterAttr:       s.FilterAttrName,
+			FilterValue:      s.FilterAttrValue,
+			Limit:            s.Limit,
+		}, true
+	}
+	return "", config.SavedQuery{}, false
+}
+
+// describeQuery summarizes a stored query on one line, e.g.
+// "query c-42, sk begins_with 2024 | status = paid | limit 25".
+func describeQuery(sq config.SavedQuery) string {
+	var parts []string
+	if sq.Scan {
+		parts = append(parts, "scan")
+	} else {
+		key := "query " + sq.PartitionKey
+		if sq.SortKey != "" {
+			key += fmt.Sprintf(", sk %s %s", sq.SortKeyCondition, sq.SortKey)
+		}
+		parts = append(parts, key)
+	}
+	if sq.FilterExpression != "" {
+		expr := strings.NewReplacer("#filterAttr", sq.FilterAttr, ":filterVal", sq.FilterValue).Replace(sq.FilterExpression)
+		parts = append(parts, expr)
+	}
+	if sq.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("limit %d", sq.Limit))
+	}
+	if sq.Index != "" {
+		parts = append(parts, "index "+sq.Index)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// rememberQuery adds a query or scan that is about to run to the table's
+// recent queries.
+func (m *Model) rememberQuery(result *components.QueryDialogResult) {
+	table, sq, ok := storedQuery(result)
+	if !ok || m.queries == nil {
+		return
+	}
+	m.queries.AddRecent(table, sq)
+	if err := m.queries.Save(); err != nil {
+		m.logger.Warn("Failed to save query history: %v", err)
+	}
+}
+
+// handleQueryCommand lists, runs, saves or deletes the saved queries of the
+// selected table: :query, :query <name>, :query save <name> and
+// :query delete <name>. Save keeps the query or scan run last.
+func (m *Model) handleQueryCommand(args []string) tea.Cmd {
+	table := m.state.SelectedTable
+	if table == nil || m.queries == nil {
+		m.logger.Warn("Select a DynamoDB table first")
+		return nil
+	}
+	tq := m.queries[table.Name]
+
+	if len(args) == 0 {
+		if len(tq.Saved) == 0 {
+			m.logger.Info("No saved queries for %s (:query save <name> after running one)", table.Name)
+			return nil
+		}
+		names := make([]string, len(tq.Saved))
+		for i, sq := range tq.Saved {
+			names[i] = sq.Name
+		}
+		m.logger.Info("Saved queries for %s: %s", table.Name, strings.Join(names, ", "))
+		return nil
+	}
+
+	switch args[0] {
+	case "save":
+		if len(args) < 2 {
+			m.logger.Warn("Usage: :query save <name>")
+			return nil
+		}
+		name := strings.Join(args[1:], " ")
+		_, sq, ok := storedQuery(&components.QueryDialogResult{
+			QueryParams: m.state.DynamoDBQueryParams,
+			ScanParams:  m.state.DynamoDBScanParams,
+		})
+		if !ok {
+			m.logger.Warn("Run a query or scan on %s first", table.Name)
+			return nil
+		}
+		sq.Name = name
+		m.queries.SaveNamed(table.Name, sq)
+		if err := m.queries.Save(); err != nil {
+			m.logger.Warn("Failed to save query: %v", err)
+			return nil
+		}
+		m.logger.Info("Saved query %s, run it with :query %s or ctrl+r in the query dialog", name, name)
+		return nil
+
+	case "delete", "rm":
+		if len(args) < 2 {
+			m.logger.Warn("Usage: :query delete <name>")
+			return nil
+		}
+		name := strings.Join(args[1:], " ")
+		if !m.queries.DeleteNamed(table.Name, name) {
+			m.logger.Warn("No saved query named %s", name)
+			return nil
+		}
+		if err := m.queries.Save(); err != nil {
+			m.logger.Warn("Failed to save queries: %v", err)
+		}
+		m.logger.Info("Deleted query %s", name)
+		return nil
+	}
+
+	name := strings.Join(args, " ")
+	for _, sq := range tq.Saved {
+		if sq.Name == name {
+			entry := queryEntry(sq, table)
+			return m.runDynamoDBQuery(&components.QueryDialogResult{QueryParams: entry.QueryParams, ScanParams: entry.ScanParams})
+		}
+	}
+	m.logger.Warn("No saved query named %s", name)
+	return nil
+}
+
+// scanWarning returns what an unfiltered scan of the selected table reads if
+// the table is above the configured size, or nil if the scan can run without
+// asking.
+func (m *Model) scanWarning(params *model.ScanParams) []string {
+	table := m.state.SelectedTable
+	if params == nil || params.FilterExpression != "" || m.cfg == nil || table == nil || table.Name != params.TableName {
+		return nil
+	}
+	threshold := m.cfg.GetScanWarnBytes()
+	if threshold == 0 || table.SizeBytes <= threshold {
+		return nil
+	}
+
+	page := table.ScanReadUnits(params.Limit)
+	full := table.ScanReadUnits(0)
+	pageDesc := "each page reads up to 1 MB"
+	if params.Limit > 0 {
+		pageDesc = fmt.Sprintf("each page reads %d items", params.Limit)
+	}
+	return []string{
+		fmt.Sprintf("Table: %s, %s items (DynamoDB updates both every 6 hours or so)", formatBytes(table.SizeBytes), format.Count(table.ItemCount)),
+		fmt.Sprintf("Without a filter %s, ~%s RCU %s", pageDesc, components.FormatReadUnits(page), components.FormatReadCost(page)),
+		fmt.Sprintf("Paging through the whole table reads ~%s RCU %s on demand", components.FormatReadUnits(full), components.FormatReadCost(full)),
+		"Filters apply after the read, so only a query on a key reads less",
+	}
+}
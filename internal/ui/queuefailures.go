@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+const (
+	// queueFailuresTimeout bounds reading the logs of a queue's consumers,
+	// which scans them back to the oldest sampled message.
+	queueFailuresTimeout = 2 * time.Minute
+
+	// queueFailureLinesPerMessage is how many error lines are shown under
+	// each sampled message.
+	queueFailureLinesPerMessage = 3
+
+	// queueFailureRecentErrors is how many of the consumers' latest error
+	// lines are shown.
+	queueFailureRecentErrors = 10
+)
+
+// queueFailuresPanel is the dialog showing why the messages of a queue end
+// up in its DLQ.
+type queueFailuresPanel struct {
+	queue    model.Queue
+	failures *model.QueueFailures
+	loading  bool
+	err      error
+	offset   int // First line shown
+}
+
+// queueFailuresLoadedMsg carries the failures of a queue's consumers.
+type queueFailuresLoadedMsg struct {
+	queueARN string
+	failures *model.QueueFailures
+	err      error
+}
+
+// openQueueFailures opens the failures of the selected queue, whose DLQ must
+// have messages.
+func (m *Model) openQueueFailures() tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	q := m.sqsTable.SelectedQueue()
+	if m.state.View != state.ViewSQS || q == nil {
+		m.logger.Warn("Select a queue in the SQS view first")
+		return nil
+	}
+	switch {
+	case !q.HasDLQ:
+		m.logger.Warn("Queue %s has no DLQ", q.Name)
+		return nil
+	case q.DLQMessageCount == 0:
+		m.logger.Info("The DLQ of %s is empty", q.Name)
+		return nil
+	}
+	m.queueFailures = &queueFailuresPanel{queue: *q}
+	return m.loadQueueFailures()
+}
+
+// loadQueueFailures samples the DLQ of the panel's queue and reads the logs
+// and metrics of its consumers in the background.
+func (m *Model) loadQueueFailures() tea.Cmd {
+	qf := m.queueFailures
+	qf.loading = true
+	qf.err = nil
+	client, queue := m.client, qf.queue
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), queueFailuresTimeout)
+		defer cancel()
+		failures, err := client.GetQueueFailures(ctx, queue)
+		return queueFailuresLoadedMsg{queueARN: queue.ARN, failures: failures, err: err}
+	}
+}
+
+// handleQueueFailuresLoaded fills the panel if it is still open on the
+// queue.
+func (m *Model) handleQueueFailuresLoaded(msg queueFailuresLoadedMsg) {
+	if msg.err != nil {
+		m.logger.Error("Failed to read failures of queue: %v", msg.err)
+	} else {
+		for _, w := range msg.failures.Warnings {
+			m.logger.Warn("Queue failures incomplete: %s", w)
+		}
+	}
+	qf := m.queueFailures
+	if qf == nil || qf.queue.ARN != msg.queueARN {
+		return
+	}
+	qf.loading = false
+	qf.err = msg.err
+	qf.failures = msg.failures
+	qf.offset = 0
+}
+
+// handleQueueFailuresKey handles key messages while the failures panel is
+// open.
+func (m *Model) handleQueueFailuresKey(msg tea.KeyMsg) tea.Cmd {
+	qf := m.queueFailures
+	switch msg.String() {
+	case "esc", "q":
+		m.queueFailures = nil
+	case "up", "k":
+		qf.offset = max(qf.offset-1, 0)
+	case "down", "j":
+		qf.offset++
+	case "r":
+		if !qf.loading {
+			return m.loadQueueFailures()
+		}
+	}
+	return nil
+}
+
+// oneLine flattens a message body or log line to a single line.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// consumerLines render a consumer with its mapping and how its invocations
+// went over the failure window, and the last poll if it failed.
+func consumerLines(c model.QueueConsumer, width int) []string {
+	s := GetStyles()
+	line := fmt.Sprintf("  %s  %s", c.Function, s.Muted.Render(fmt.Sprintf("%s · batch %d", strings.ToLower(c.State), c.BatchSize)))
+	if c.HasMetrics {
+		errStyle, throttleStyle := s.StatusHealthy, s.StatusHealthy
+		if c.Errors > 0 {
+			errStyle = s.StatusError
+		}
+		if c.Throttles > 0 {
+			throttleStyle = s.StatusWarning
+		}
+		line += fmt.Sprintf("  %s invocations · %s · %s",
+			format.Count(int64(c.Invocations)),
+			errStyle.Render(format.Count(int64(c.Errors))+" errors"),
+			throttleStyle.Render(format.Count(int64(c.Throttles))+" throttles"))
+	}
+	lines := []string{line}
+	if c.LastResult != "" && c.LastResult != "OK" && c.LastResult != "No records processed" {
+		lines = append(lines, s.StatusWarning.Render(truncateString("    Last poll: "+c.LastResult, width)))
+	}
+	return lines
+}
+
+// errorLine renders an error logged by a consumer.
+func errorLine(hit model.LogSearchHit, indent string, width int) string {
+	s := GetStyles()
+	prefix := indent + format.Absolute(hit.Timestamp) + "  " + hit.Source.Resource + "  "
+	return s.Muted.Render(prefix) + s.StatusError.Render(truncateString(oneLine(hit.Message), width-len(prefix)))
+}
+
+// renderQueueFailuresDialog renders the consumers of a queue, the messages
+// sampled from its DLQ with the errors logged while processing them, and the
+// consumers' latest errors.
+func (m *Model) renderQueueFailuresDialog() string {
+	qf := m.queueFailures
+	dialogWidth := min(120, max(m.width-10, 40))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Why messages fail: " + truncateString(qf.queue.Name, dialogWidth-26))
+
+	switch {
+	case qf.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Sampling the DLQ and reading the consumers' logs and metrics..."))
+	case qf.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(qf.err.Error(), dialogWidth-6)) + "\n\n" + hintStyle.Render("r to retry · esc to close"))
+	}
+
+	f := qf.failures
+	width := dialogWidth - 6
+	dlq := fmt.Sprintf("DLQ %s: %s messages", qf.queue.DLQName, format.Count(int64(qf.queue.DLQMessageCount)))
+	if qf.queue.MaxReceiveCount > 0 {
+		dlq += fmt.Sprintf(" · moved after %d receives", qf.queue.MaxReceiveCount)
+	}
+	lines := []string{truncateString(dlq, width), ""}
+
+	lines = append(lines, labelStyle.Render(fmt.Sprintf("Consumers (%d)", len(f.Consumers)))+
+		s.Muted.Render(" · last "+format.Age(model.QueueFailureWindow)))
+	if len(f.Consumers) == 0 {
+		lines = append(lines, s.Muted.Render("  No Lambda event source mappings on the queue"))
+	}
+	for _, c := range f.Consumers {
+		lines = append(lines, consumerLines(c, width)...)
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, labelStyle.Render(fmt.Sprintf("Sampled DLQ messages (%d, %d matched)", len(f.Messages), f.Matched())))
+	if len(f.Messages) == 0 {
+		lines = append(lines, s.Muted.Render("  No messages received; others may be hiding them"))
+	}
+	for _, fm := range f.Messages {
+		msg := fm.Message
+		lines = append(lines, fmt.Sprintf("  %s  %s", msg.ID,
+			s.Muted.Render(fmt.Sprintf("received %dx · sent %s", msg.ReceiveCount, format.Time(msg.SentAt)))))
+		lines = append(lines, truncateString("    "+oneLine(msg.Body), width))
+		if len(fm.Errors) == 0 {
+			lines = append(lines, s.Muted.Render("    No error lines with its ID or request ID"))
+		}
+		for _, hit := range fm.Errors[:min(len(fm.Errors), queueFailureLinesPerMessage)] {
+			lines = append(lines, errorLine(hit, "    ", width))
+		}
+		if n := len(fm.Errors) - queueFailureLinesPerMessage; n > 0 {
+			lines = append(lines, s.Muted.Render(fmt.Sprintf("    and %d more", n)))
+		}
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, labelStyle.Render(fmt.Sprintf("Latest consumer errors (%d since %s)", len(f.Errors), format.Time(f.Since))))
+	if len(f.Errors) == 0 {
+		lines = append(lines, s.Muted.Render("  No error lines in the consumers' logs"))
+	}
+	for _, hit := range f.Errors[:min(len(f.Errors), queueFailureRecentErrors)] {
+		lines = append(lines, errorLine(hit, "  ", width))
+	}
+
+	if len(f.Warnings) > 0 {
+		lines = append(lines, "")
+		for _, w := range f.Warnings {
+			lines = append(lines, s.StatusWarning.Render(truncateString("! "+w, width)))
+		}
+	}
+
+	// Scroll when the panel is taller than the container
+	height := max(m.container.ContentHeight()-10, 5)
+	qf.offset = min(qf.offset, max(len(lines)-height, 0))
+	shown := lines[qf.offset:min(qf.offset+height, len(lines))]
+
+	content := title + "\n\n" +
+		strings.Join(shown, "\n") + "\n\n" +
+		hintStyle.Render("Messages are matched by their ID in the logs · ↑/↓ scroll · r reload · esc")
+	return dialogStyle.Render(content)
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/theme"
+)
+
+// queueMapTimeout bounds mapping a queue. It reads the policies of every
+// role in the region, which takes a while in large accounts.
+const queueMapTimeout = 2 * time.Minute
+
+// queueMap is the dialog listing the consumers and producers of a queue.
+type queueMap struct {
+	queue     model.Queue
+	relations *model.QueueRelations
+	loading   bool
+	err       error
+	offset    int // First line shown
+}
+
+// queueMappedMsg carries the relations of a queue.
+type queueMappedMsg struct {
+	queueARN  string
+	relations *model.QueueRelations
+	err       error
+}
+
+// openQueueMap opens the producers and consumers of the selected queue,
+// mapping them unless they were this session.
+func (m *Model) openQueueMap() tea.Cmd {
+	if m.state.View != state.ViewSQS || m.client == nil {
+		return nil
+	}
+	q := m.sqsTable.SelectedQueue()
+	if q == nil {
+		return nil
+	}
+	m.queueMap = &queueMap{queue: *q}
+	if rel, ok := m.queueRelations[q.ARN]; ok {
+		m.queueMap.relations = rel
+		return nil
+	}
+	return m.mapQueue()
+}
+
+// mapQueue maps the queue of the dialog in the background.
+func (m *Model) mapQueue() tea.Cmd {
+	qm := m.queueMap
+	qm.loading = true
+	qm.err = nil
+	m.logger.Info("Mapping consumers and producers of %s...", qm.queue.Name)
+	client, queue := m.client, qm.queue
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), queueMapTimeout)
+		defer cancel()
+		rel, err := client.GetQueueRelations(ctx, queue)
+		return queueMappedMsg{queueARN: queue.ARN, relations: rel, err: err}
+	}
+}
+
+// handleQueueMapped keeps the relations of a queue and shows them if its
+// dialog is still open.
+func (m *Model) handleQueueMapped(msg queueMappedMsg) {
+	if msg.err == nil {
+		if m.queueRelations == nil {
+			m.queueRelations = make(map[string]*model.QueueRelations)
+		}
+		m.queueRelations[msg.queueARN] = msg.relations
+		for _, w := range msg.relations.Warnings {
+			m.logger.Warn("Queue map incomplete: %s", w)
+		}
+	}
+	qm := m.queueMap
+	if qm == nil || qm.queue.ARN != msg.queueARN {
+		return
+	}
+	qm.loading = false
+	qm.err = msg.err
+	qm.relations = msg.relations
+	qm.offset = 0
+}
+
+// handleQueueMapKey handles key messages while the queue map is open.
+func (m *Model) handleQueueMapKey(msg tea.KeyMsg) tea.Cmd {
+	qm := m.queueMap
+	switch msg.String() {
+	case "esc", "q":
+		m.queueMap = nil
+	case "up", "k":
+		qm.offset = max(qm.offset-1, 0)
+	case "down", "j":
+		qm.offset++
+	case "r":
+		if !qm.loading {
+			return m.mapQueue()
+		}
+	}
+	return nil
+}
+
+// queueMapLines renders a section of the queue map, width wide: a heading
+// and a line per relation, configured ones first.
+func queueMapLines(title string, relations []model.QueueRelation, empty string, width int) []string {
+	s := GetStyles()
+	heading := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	lines := []string{heading.Render(fmt.Sprintf("%s (%d)", title, len(relations)))}
+	if len(relations) == 0 {
+		return append(lines, s.Muted.Render("  "+empty))
+	}
+	kindWidth, nameWidth := 0, 0
+	for _, r := range relations {
+		kindWidth = max(kindWidth, len(r.Kind))
+		nameWidth = max(nameWidth, len(r.Name))
+	}
+	for _, r := range relations {
+		style := s.Muted
+		if r.Certain {
+			style = s.StatusHealthy
+		}
+		line := truncateString(fmt.Sprintf("  %-*s  %-*s  ", kindWidth, r.Kind, nameWidth, r.Name), width)
+		lines = append(lines, line+style.Render(truncateString(r.Via, width-len(line))))
+	}
+	return lines
+}
+
+// renderQueueMapDialog renders the consumers and producers of a queue.
+func (m *Model) renderQueueMapDialog() string {
+	qm := m.queueMap
+	dialogWidth := min(100, max(m.width-10, 40))
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Queue map: " + truncateString(qm.queue.Name, dialogWidth-18))
+
+	switch {
+	case qm.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Reading event source mappings, the queue policy and role policies..."))
+	case qm.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(qm.err.Error(), dialogWidth-6)) + "\n\n" + hintStyle.Render("r to retry · esc to close"))
+	}
+
+	rel := qm.relations
+	width := dialogWidth - 6
+	lines := queueMapLines("Consumers", rel.Consumers, "No event source mappings or roles allowed to receive", width)
+	lines = append(lines, "")
+	lines = append(lines, queueMapLines("Producers", rel.Producers, "No roles or principals allowed to send", width)...)
+	if len(rel.Warnings) > 0 {
+		lines = append(lines, "")
+		for _, w := range rel.Warnings {
+			lines = append(lines, s.StatusWarning.Render(truncateString("! "+w, width)))
+		}
+	}
+
+	// Scroll when the map is taller than the container
+	height := max(m.container.ContentHeight()-10, 5)
+	qm.offset = min(qm.offset, max(len(lines)-height, 0))
+	shown := lines[qm.offset:min(qm.offset+height, len(lines))]
+
+	content := title + "\n\n" +
+		strings.Join(shown, "\n") + "\n\n" +
+		hintStyle.Render("Green: configured · grey: allowed by a role policy, so likely · ↑/↓ scroll · r re-map · esc")
+	return dialogStyle.Render(content)
+}
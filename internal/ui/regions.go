@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+)
+
+// regionsListedMsg carries the regions enabled for the profile's account.
+type regionsListedMsg struct {
+	profile string
+	regions []string
+	err     error
+}
+
+// regionLatenciesMsg carries how long each region took to answer.
+type regionLatenciesMsg struct {
+	latencies map[string]time.Duration
+}
+
+// openRegionSelect shows the region selector. The account's regions are
+// listed the first time it opens for a profile, and their latency measured
+// in the background.
+func (m *Model) openRegionSelect() tea.Cmd {
+	m.viewBeforeRegionSelect = m.state.View
+	if m.cfg != nil {
+		m.regionSelector.SetFavorites(m.cfg.Defaults.FavoriteRegions)
+	}
+	m.regionSelector.SetCurrentRegion(m.state.Region)
+	m.state.View = state.ViewRegionSelect
+
+	if m.client == nil || m.regionsProfile == m.state.Profile {
+		return nil
+	}
+	m.regionsProfile = m.state.Profile
+	m.regionSelector.SetMeasuring(true)
+	client, profile := m.client, m.state.Profile
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		regions, err := client.ListRegions(ctx)
+		return regionsListedMsg{profile: profile, regions: regions, err: err}
+	}
+}
+
+// handleRegionsListed shows the account's regions and measures their latency.
+// Without ec2:DescribeRegions the built-in list stays.
+func (m *Model) handleRegionsListed(msg regionsListedMsg) tea.Cmd {
+	if msg.profile != m.state.Profile {
+		return nil
+	}
+	regions := msg.regions
+	if msg.err != nil {
+		m.logger.Debug("Listing regions failed, showing the built-in list: %v", msg.err)
+		regions = nil
+		for _, group := range components.AWSRegions {
+			for _, r := range group.Regions {
+				regions = append(regions, r.Code)
+			}
+		}
+	} else {
+		m.regionSelector.SetRegions(regions)
+	}
+
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		return regionLatenciesMsg{latencies: client.RegionLatencies(ctx, regions)}
+	}
+}
+
+// toggleFavoriteRegion pins or unpins the selected region and saves the
+// pinned regions to the config.
+func (m *Model) toggleFavoriteRegion() {
+	favorites := m.regionSelector.ToggleFavorite()
+	if m.cfg == nil {
+		return
+	}
+	m.cfg.Defaults.FavoriteRegions = favorites
+	if err := m.cfg.Save(); err != nil {
+		m.logger.Warn("Failed to save config: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/report"
+	"vaws/internal/state"
+)
+
+// reportWrittenMsg carries a report after it was written to path.
+type reportWrittenMsg struct {
+	scope  report.Scope
+	path   string
+	report *report.Report
+	err    error
+}
+
+// reportScope returns the stack or cluster under the cursor, or the one
+// whose services are shown.
+func (m *Model) reportScope() (report.Scope, bool) {
+	switch m.state.View {
+	case state.ViewStacks:
+		if s := m.selectedStack(); s != nil {
+			return report.Scope{Kind: report.KindStack, Name: s.Name}, true
+		}
+	case state.ViewClusters:
+		if item := m.clustersList.SelectedItem(); item != nil {
+			return report.Scope{Kind: report.KindCluster, Name: item.ID}, true
+		}
+	case state.ViewServices:
+		switch {
+		case m.state.SelectedCluster != nil:
+			return report.Scope{Kind: report.KindCluster, Name: m.state.SelectedCluster.Name}, true
+		case m.state.SelectedStack != nil:
+			return report.Scope{Kind: report.KindStack, Name: m.state.SelectedStack.Name}, true
+		}
+	}
+	return report.Scope{}, false
+}
+
+// handleReportCommand writes a Markdown report of the selected stack or
+// cluster in the background and copies it to the clipboard. The file goes
+// to the working directory unless a path is given.
+func (m *Model) handleReportCommand(args []string) tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	scope, ok := m.reportScope()
+	if !ok {
+		m.logger.Warn("Select a stack or cluster, or open its services, to report on")
+		return nil
+	}
+	path := strings.Join(args, " ")
+	if path == "" {
+		path = fmt.Sprintf("report-%s-%s.md", scope.Name, time.Now().Format("2006-01-02-1504"))
+	}
+	path = expandHome(path)
+
+	m.logger.Info("Reporting on %s %s...", scope.Kind, scope.Name)
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+		defer cancel()
+		r, err := report.Build(ctx, client, scope)
+		if err == nil {
+			err = os.WriteFile(path, []byte(r.Markdown()), 0644)
+		}
+		return reportWrittenMsg{scope: scope, path: path, report: r, err: err}
+	}
+}
+
+// handleReportWritten logs where the report went and copies it for pasting.
+func (m *Model) handleReportWritten(msg reportWrittenMsg) {
+	if msg.err != nil {
+		m.logger.Error("Failed to report on %s %s: %v", msg.scope.Kind, msg.scope.Name, msg.err)
+		return
+	}
+	for _, w := range msg.report.Warnings {
+		m.logger.Warn("Report incomplete, could not read %s", w)
+	}
+	if err := copyToClipboard(msg.report.Markdown()); err != nil {
+		m.logger.Info("Wrote the report of %s to %s (clipboard not available: %v)", msg.scope.Name, msg.path, err)
+		return
+	}
+	m.logger.Info("Wrote the report of %s to %s and copied it to the clipboard", msg.scope.Name, msg.path)
+}
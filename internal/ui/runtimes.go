@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// runtimeGroup is the functions of a runtime in the runtimes report.
+type runtimeGroup struct {
+	runtime    string // Empty for container images
+	support    model.RuntimeSupport
+	deprecated time.Time
+	functions  []model.Function
+}
+
+// supportOrder lists the runtimes needing an upgrade first.
+var supportOrder = map[model.RuntimeSupport]int{
+	model.RuntimeDeprecated: 0,
+	model.RuntimeExpiring:   1,
+	model.RuntimeSupported:  2,
+	model.RuntimeUnknown:    3,
+}
+
+// openRuntimes shows the Lambda functions grouped by runtime. It uses the
+// functions of the Lambda view, loading them if they aren't yet.
+func (m *Model) openRuntimes() tea.Cmd {
+	m.state.View = state.ViewLambdaRuntimes
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("2")
+	if len(m.state.Functions) == 0 && !m.state.FunctionsLoading {
+		cmd := m.loadFunctions()
+		m.updateRuntimesList()
+		return cmd
+	}
+	m.updateRuntimesList()
+	return nil
+}
+
+// filteredRuntimeFunctions returns the functions whose name or runtime
+// matches the filter.
+func (m *Model) filteredRuntimeFunctions() []model.Function {
+	if m.state.FilterText == "" {
+		return m.state.Functions
+	}
+	filter := strings.ToLower(m.state.FilterText)
+	var filtered []model.Function
+	for _, fn := range m.state.Functions {
+		if strings.Contains(strings.ToLower(fn.Name), filter) || strings.Contains(fn.Runtime, filter) {
+			filtered = append(filtered, fn)
+		}
+	}
+	return filtered
+}
+
+// groupByRuntime groups functions by runtime: deprecated runtimes first,
+// then those expiring soonest, then the others.
+func groupByRuntime(functions []model.Function, now time.Time) []runtimeGroup {
+	byRuntime := make(map[string]*runtimeGroup)
+	var groups []*runtimeGroup
+	for _, fn := range functions {
+		g, ok := byRuntime[fn.Runtime]
+		if !ok {
+			g = &runtimeGroup{runtime: fn.Runtime}
+			g.support, g.deprecated = model.LambdaRuntimeSupport(fn.Runtime, now)
+			byRuntime[fn.Runtime] = g
+			groups = append(groups, g)
+		}
+		g.functions = append(g.functions, fn)
+	}
+
+	slices.SortFunc(groups, func(a, b *runtimeGroup) int {
+		return cmp.Or(
+			cmp.Compare(supportOrder[a.support], supportOrder[b.support]),
+			a.deprecated.Compare(b.deprecated),
+			strings.Compare(a.runtime, b.runtime),
+		)
+	})
+	result := make([]runtimeGroup, len(groups))
+	for i, g := range groups {
+		slices.SortFunc(g.functions, func(a, b model.Function) int { return strings.Compare(a.Name, b.Name) })
+		result[i] = *g
+	}
+	return result
+}
+
+// runtimeStatus describes a runtime's support, e.g. "deprecated 2024-01-08".
+func runtimeStatus(runtime string, support model.RuntimeSupport, deprecated time.Time) string {
+	switch {
+	case runtime == "":
+		return "container image"
+	case support == model.RuntimeDeprecated:
+		return "deprecated " + format.Date(deprecated)
+	case support == model.RuntimeExpiring:
+		return "ends " + format.Date(deprecated)
+	case support == model.RuntimeSupported:
+		return "until " + format.Date(deprecated)
+	default:
+		return "date unknown"
+	}
+}
+
+// updateRuntimesList updates the runtimes report: a header per runtime with
+// its deprecation date, followed by its functions.
+func (m *Model) updateRuntimesList() {
+	s := GetStyles()
+	var items []components.ListItem
+	for _, g := range groupByRuntime(m.filteredRuntimeFunctions(), time.Now()) {
+		name := g.runtime
+		if name == "" {
+			name = "Container images"
+		}
+		status := runtimeStatus(g.runtime, g.support, g.deprecated)
+		items = append(items, components.ListItem{
+			ID:       "runtime:" + g.runtime,
+			Title:    fmt.Sprintf("── %s (%d) · %s ──", name, len(g.functions), status),
+			IsHeader: true,
+		})
+
+		style := s.Muted
+		switch g.support {
+		case model.RuntimeDeprecated, model.RuntimeExpiring:
+			style = s.StatusError
+		case model.RuntimeSupported:
+			style = s.StatusHealthy
+		}
+		upgrade := model.LambdaRuntimeUpgrade(g.runtime)
+		if upgrade != "" {
+			upgrade = theme.Symbol("→ ", "-> ") + upgrade
+		}
+		for _, fn := range g.functions {
+			items = append(items, components.ListItem{
+				ID:          fn.Name,
+				Title:       fn.Name,
+				Status:      string(g.support),
+				StatusStyle: style,
+				Extra:       upgrade,
+			})
+		}
+	}
+
+	m.runtimesList.SetItems(items)
+	m.runtimesList.SetLoading(m.state.FunctionsLoading)
+	m.runtimesList.SetError(m.state.FunctionsError)
+	m.runtimesList.SetEmptyMessage("No Lambda functions found")
+	m.updateRuntimeDetails()
+}
+
+// updateRuntimeDetails shows the runtime support of the selected function.
+func (m *Model) updateRuntimeDetails() {
+	s := GetStyles()
+	m.details.SetTitle("Runtime")
+	item := m.runtimesList.SelectedItem()
+	if item == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	for _, fn := range m.state.Functions {
+		if fn.Name != item.ID {
+			continue
+		}
+		support, deprecated := model.LambdaRuntimeSupport(fn.Runtime, time.Now())
+		rows := []components.DetailRow{
+			{Label: "Function", Value: fn.Name},
+			{Label: "Runtime", Value: valueOrDash(fn.Runtime)},
+			{Label: "Package Type", Value: fn.PackageType},
+		}
+		switch support {
+		case model.RuntimeDeprecated:
+			rows = append(rows, components.DetailRow{Label: "Deprecated", Value: format.Date(deprecated) + " (" + format.Relative(time.Since(deprecated)) + ")", Style: s.StatusError})
+		case model.RuntimeExpiring, model.RuntimeSupported:
+			style := s.StatusHealthy
+			if support == model.RuntimeExpiring {
+				style = s.StatusError
+			}
+			days := int(time.Until(deprecated).Hours() / 24)
+			rows = append(rows, components.DetailRow{Label: "Deprecated", Value: fmt.Sprintf("%s (in %d days)", format.Date(deprecated), days), Style: style})
+		default:
+			rows = append(rows, components.DetailRow{Label: "Deprecated", Value: "-"})
+		}
+		if upgrade := model.LambdaRuntimeUpgrade(fn.Runtime); upgrade != "" {
+			rows = append(rows, components.DetailRow{Label: "Upgrade To", Value: upgrade})
+		}
+		rows = append(rows,
+			components.DetailRow{Label: "Last Modified", Value: format.Time(fn.LastModified)},
+			components.DetailRow{Label: "ARN", Value: fn.ARN},
+		)
+		m.details.SetRows(rows)
+		return
+	}
+	m.details.SetRows(nil)
+}
+
+// exportRuntimes writes the runtimes report, as filtered, to a CSV file. The
+// file goes to the working directory unless a path is given.
+func (m *Model) exportRuntimes(path string) {
+	if path == "" {
+		path = fmt.Sprintf("lambda-runtimes-%s-%s.csv", m.state.Region, time.Now().Format("2006-01-02"))
+	}
+	path = expandHome(path)
+
+	now := time.Now()
+	records := [][]string{{"function", "runtime", "package_type", "support", "deprecation_date", "days_left", "upgrade_to", "last_modified", "arn"}}
+	for _, g := range groupByRuntime(m.filteredRuntimeFunctions(), now) {
+		date, days := "", ""
+		if g.support != model.RuntimeUnknown {
+			date = g.deprecated.Format("2006-01-02")
+			days = strconv.Itoa(int(g.deprecated.Sub(now).Hours() / 24))
+		}
+		for _, fn := range g.functions {
+			lastModified := ""
+			if !fn.LastModified.IsZero() {
+				lastModified = fn.LastModified.UTC().Format(time.RFC3339)
+			}
+			records = append(records, []string{
+				fn.Name, fn.Runtime, fn.PackageType, string(g.support), date, days,
+				model.LambdaRuntimeUpgrade(fn.Runtime), lastModified, fn.ARN,
+			})
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		m.logger.Error("Failed to export runtimes: %v", err)
+		return
+	}
+	w := csv.NewWriter(f)
+	w.WriteAll(records)
+	closeErr := f.Close()
+	if err := cmp.Or(w.Error(), closeErr); err != nil {
+		m.logger.Error("Failed to export runtimes: %v", err)
+		return
+	}
+	m.logger.Info("Exported %d functions to %s", len(records)-1, path)
+}
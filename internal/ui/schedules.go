@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/cron"
+	"vaws/internal/model"
+	"vaws/internal/state"
+)
+
+// selectedSchedule returns the schedule under the cursor.
+func (m *Model) selectedSchedule() *model.Schedule {
+	item := m.schedulesList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.Schedules {
+		if m.state.Schedules[i].ARN == item.ID {
+			return &m.state.Schedules[i]
+		}
+	}
+	return nil
+}
+
+// scheduleTitle returns the name of a schedule, prefixed with its group
+// unless it is in the default group.
+func scheduleTitle(s model.Schedule) string {
+	if s.IsDefaultGroup() {
+		return s.Name
+	}
+	return s.Group + "/" + s.Name
+}
+
+// scheduleNextRun returns when a schedule runs next, or the zero time if it
+// is disabled or doesn't run again. Rate schedules are assumed to count from
+// their start date, or from when they were created.
+func scheduleNextRun(s model.Schedule) (time.Time, error) {
+	if s.State != model.ScheduleStateEnabled || s.Expression == "" {
+		return time.Time{}, nil
+	}
+	loc := time.UTC
+	if s.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(s.Timezone); err != nil {
+			return time.Time{}, err
+		}
+	}
+	start := s.StartDate
+	if start.IsZero() {
+		start = s.CreatedAt
+	}
+	rate := strings.HasPrefix(s.Expression, "rate(")
+	if rate && start.IsZero() {
+		return time.Time{}, nil
+	}
+
+	after := time.Now()
+	if start.After(after) && !rate {
+		after = start.Add(-time.Nanosecond)
+	}
+	next, err := cron.Next(s.Expression, loc, start, after)
+	if err != nil || (!s.EndDate.IsZero() && next.After(s.EndDate)) {
+		return time.Time{}, err
+	}
+	return next, nil
+}
+
+// scheduleTarget describes what a schedule invokes, e.g. "lambda orders" or
+// "ec2:stopInstances".
+func scheduleTarget(s model.Schedule) string {
+	service := s.TargetService()
+	if service == "" || strings.Contains(service, ":") {
+		return service
+	}
+	// arn:partition:service:region:account:resource
+	resource := s.TargetARN
+	if parts := strings.SplitN(s.TargetARN, ":", 6); len(parts) == 6 {
+		resource = parts[5]
+	}
+	resource = strings.TrimPrefix(resource, "function:")
+	resource = strings.TrimPrefix(resource, "stateMachine:")
+	if i := strings.LastIndex(resource, "/"); i >= 0 {
+		resource = resource[i+1:]
+	}
+	return service + " " + resource
+}
+
+// handleSchedulePauseResume disables an enabled schedule or enables a
+// disabled one.
+func (m *Model) handleSchedulePauseResume() tea.Cmd {
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+	s := m.selectedSchedule()
+	if s == nil {
+		return nil
+	}
+
+	group, name := s.Group, s.Name
+	title, action, next := "Pause schedule", "pause", model.ScheduleStateDisabled
+	if s.State == model.ScheduleStateDisabled {
+		title, action, next = "Resume schedule", "resume", model.ScheduleStateEnabled
+	}
+	return m.askConfirm(title, []string{"Schedule: " + scheduleTitle(*s), "Expression: " + s.Expression}, func() tea.Cmd {
+		m.logger.Info("Setting schedule %s to %s", name, next)
+		client := m.client
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := client.SetScheduleState(ctx, group, name, next)
+			return scheduleActionMsg{name: name, action: action, err: err}
+		}
+	})
+}
+
+// handleScheduleRunNow invokes the target of the selected schedule once,
+// through a one-time schedule due within a minute.
+func (m *Model) handleScheduleRunNow() tea.Cmd {
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+	s := m.selectedSchedule()
+	if s == nil {
+		return nil
+	}
+	if s.TargetARN == "" {
+		m.logger.Warn("The target of %s could not be read; refresh and try again", s.Name)
+		return nil
+	}
+
+	group, name := s.Group, s.Name
+	return m.askConfirm("Run schedule now", []string{
+		"Schedule: " + scheduleTitle(*s),
+		"Target: " + scheduleTarget(*s),
+		"Runs within a minute through a one-time schedule that deletes itself",
+	}, func() tea.Cmd {
+		client := m.client
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			runName, err := client.RunScheduleNow(ctx, group, name)
+			return scheduleActionMsg{name: name, action: "run", runName: runName, err: err}
+		}
+	})
+}
+
+// handleScheduleAction logs the result of a schedule action and reloads the
+// schedules.
+func (m *Model) handleScheduleAction(msg scheduleActionMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Error("Failed to %s %s: %v", msg.action, msg.name, msg.err)
+		return nil
+	}
+	if msg.action == "run" {
+		m.logger.Info("%s runs within a minute through schedule %s", msg.name, msg.runName)
+	} else {
+		m.logger.Info("Schedule %s %sd", msg.name, msg.action)
+	}
+	if m.state.View != state.ViewSchedules {
+		return nil
+	}
+	return m.loadSchedules()
+}
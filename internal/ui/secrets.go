@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/format"
+)
+
+// selectedSecret returns the secret under the cursor.
+func (m *Model) selectedSecret() *model.Secret {
+	item := m.secretsList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.Secrets {
+		if m.state.Secrets[i].ARN == item.ID {
+			return &m.state.Secrets[i]
+		}
+	}
+	return nil
+}
+
+// secretRotationRule describes how often a secret rotates, e.g. "every 30
+// days" or "rate(4 hours)".
+func secretRotationRule(s model.Secret) string {
+	switch {
+	case !s.RotationEnabled:
+		return "off"
+	case s.RotationSchedule != "":
+		return s.RotationSchedule
+	case s.RotationDays > 0:
+		return fmt.Sprintf("every %d days", s.RotationDays)
+	}
+	return "on"
+}
+
+// secretRotationSummary is the list line of a secret: when it rotated last
+// and when it rotates next, or how long it has gone unchanged.
+func secretRotationSummary(s model.Secret, now time.Time) string {
+	var parts []string
+	if s.LastRotated.IsZero() {
+		parts = append(parts, "never rotated")
+	} else {
+		parts = append(parts, "rotated "+format.Relative(now.Sub(s.LastRotated)))
+	}
+	switch {
+	case s.RotationEnabled && !s.NextRotation.IsZero():
+		parts = append(parts, "next "+format.Relative(now.Sub(s.NextRotation)))
+	case !s.RotationEnabled && !s.LastChanged.IsZero():
+		parts = append(parts, "changed "+format.Relative(now.Sub(s.LastChanged)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// handleSecretRotate asks to rotate the selected secret now.
+func (m *Model) handleSecretRotate() tea.Cmd {
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+	s := m.selectedSecret()
+	if s == nil {
+		return nil
+	}
+	if !s.RotationEnabled && s.RotationLambda == "" {
+		m.logger.Warn("%s has no rotation configured; set it up in Secrets Manager first", s.Name)
+		return nil
+	}
+
+	arn, name := s.ARN, s.Name
+	rotation := "Rotation: " + secretRotationRule(*s)
+	if s.RotationLambda != "" {
+		rotation += " with " + functionNameFromARN(s.RotationLambda)
+	}
+	return m.askConfirm("Rotate secret now", []string{
+		"Secret: " + name,
+		rotation,
+		"Clients holding the current value must pick up the new one",
+	}, func() tea.Cmd {
+		m.logger.Info("Rotating secret %s...", name)
+		client := m.client
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := client.RotateSecret(ctx, arn)
+			return secretRotatedMsg{name: name, err: err}
+		}
+	})
+}
+
+// functionNameFromARN returns the function name at the end of a Lambda ARN.
+func functionNameFromARN(arn string) string {
+	if _, rest, ok := strings.Cut(arn, ":function:"); ok {
+		name, _, _ := strings.Cut(rest, ":")
+		return name
+	}
+	return arn
+}
+
+// handleSecretRotated logs the result of a rotation request and reloads the
+// secrets.
+func (m *Model) handleSecretRotated(msg secretRotatedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Error("Failed to rotate %s: %v", msg.name, msg.err)
+		return nil
+	}
+	m.logger.Info("Rotation of %s started; the rotation function finishes it in the background", msg.name)
+	if m.state.View != state.ViewSecrets {
+		return nil
+	}
+	return m.loadSecrets()
+}
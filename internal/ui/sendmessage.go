@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/ui/theme"
+)
+
+// maxMessageBytes is the largest message body SQS takes.
+const maxMessageBytes = 256 * 1024
+
+// sendMessageDialog is the dialog sending a message to a queue. FIFO queues
+// also take a message group ID and a deduplication ID.
+type sendMessageDialog struct {
+	queue  model.Queue
+	inputs []textinput.Model // The body, then the group and deduplication IDs of FIFO queues
+	focus  int
+	err    string
+}
+
+// messageSentMsg carries the ID SQS gave a sent message.
+type messageSentMsg struct {
+	queue string
+	id    string
+	err   error
+}
+
+// openSendMessage opens the dialog sending a message to the selected queue.
+func (m *Model) openSendMessage() tea.Cmd {
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+	q := m.sqsTable.SelectedQueue()
+	if m.client == nil || q == nil {
+		return nil
+	}
+
+	body := textinput.New()
+	body.Placeholder = `{"hello": "world"}`
+	body.CharLimit = maxMessageBytes
+	body.Width = 50
+	body.Focus()
+	inputs := []textinput.Model{body}
+	if q.Type == model.QueueTypeFIFO {
+		group := textinput.New()
+		group.Placeholder = "required"
+		group.CharLimit = 128
+		group.Width = 40
+		dedup := textinput.New()
+		dedup.Placeholder = "required"
+		if q.ContentBasedDeduplication {
+			dedup.Placeholder = "optional, a hash of the body by default"
+		}
+		dedup.CharLimit = 128
+		dedup.Width = 40
+		inputs = append(inputs, group, dedup)
+	}
+	m.sendMessage = &sendMessageDialog{queue: *q, inputs: inputs}
+	return textinput.Blink
+}
+
+// handleSendMessageKey handles key messages while the send message dialog is
+// open.
+func (m *Model) handleSendMessageKey(msg tea.KeyMsg) tea.Cmd {
+	d := m.sendMessage
+	switch msg.String() {
+	case "esc":
+		m.sendMessage = nil
+		return nil
+	case "enter":
+		return m.submitSendMessage()
+	case "tab", "down", "shift+tab", "up":
+		step := 1
+		if msg.String() == "shift+tab" || msg.String() == "up" {
+			step = len(d.inputs) - 1
+		}
+		d.inputs[d.focus].Blur()
+		d.focus = (d.focus + step) % len(d.inputs)
+		d.inputs[d.focus].Focus()
+		return textinput.Blink
+	case "ctrl+o":
+		body := d.inputs[0].Value()
+		ext := ".txt"
+		if strings.HasPrefix(strings.TrimSpace(body), "{") || body == "" {
+			ext = ".json"
+		}
+		return m.openEditor(editQueueMessage, prettyJSON(body), ext)
+	}
+	d.err = ""
+	var cmd tea.Cmd
+	d.inputs[d.focus], cmd = d.inputs[d.focus].Update(msg)
+	return cmd
+}
+
+// submitSendMessage sends the message typed in the dialog, keeping the
+// dialog open with the reason if SQS would refuse it.
+func (m *Model) submitSendMessage() tea.Cmd {
+	d := m.sendMessage
+	body := d.inputs[0].Value()
+	var groupID, dedupID string
+	if len(d.inputs) == 3 {
+		groupID, dedupID = strings.TrimSpace(d.inputs[1].Value()), strings.TrimSpace(d.inputs[2].Value())
+	}
+	if body == "" {
+		d.err = "The message body can't be empty"
+		return nil
+	}
+	if err := d.queue.ValidateSend(groupID, dedupID); err != nil {
+		d.err = err.Error()
+		return nil
+	}
+	m.sendMessage = nil
+
+	queue := d.queue
+	m.logger.Info("Sending message to %s: %s", queue.Name, truncateString(body, 50))
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		id, err := client.SendMessage(ctx, queue.URL, body, groupID, dedupID)
+		return messageSentMsg{queue: queue.Name, id: id, err: err}
+	}
+}
+
+// handleMessageSent logs the ID of a sent message.
+func (m *Model) handleMessageSent(msg messageSentMsg) {
+	if msg.err != nil {
+		m.logger.Error("Failed to send message to %s: %v", msg.queue, msg.err)
+		m.state.ShowLogs = true
+		m.updateComponentSizes()
+		return
+	}
+	m.logger.Info("Sent message to %s: MessageId %s", msg.queue, msg.id)
+	m.recordTimeline(timelineAction, "Sent message %s to %s", msg.id, msg.queue)
+}
+
+// renderSendMessageDialog renders the send message dialog.
+func (m *Model) renderSendMessageDialog() string {
+	d := m.sendMessage
+	dialogWidth := 70
+	if m.width < 80 {
+		dialogWidth = max(m.width-10, 40)
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+
+	content := labelStyle.Render("Send Message: "+truncateString(d.queue.Name, dialogWidth-20)) + "\n\n" +
+		"Body:     " + d.inputs[0].View() + "\n"
+	if len(d.inputs) == 3 {
+		content += "Group ID: " + d.inputs[1].View() + "\n" +
+			"Dedup ID: " + d.inputs[2].View() + "\n"
+	}
+	content += "\n"
+	if d.err != "" {
+		content += errorStyle.Render(truncateString(d.err, dialogWidth-6)) + "\n\n"
+	}
+	if len(d.inputs) == 3 {
+		content += hintStyle.Render("tab next field · ctrl+o edit body in $EDITOR") + "\n"
+	} else {
+		content += hintStyle.Render("ctrl+o edit body in $EDITOR") + "\n"
+	}
+	content += hintStyle.Render("enter to send · esc to cancel")
+	return dialogStyle.Render(content)
+}
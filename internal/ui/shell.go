@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+)
+
+// handleShell opens an interactive shell for the current selection:
+// ECS Exec into a service's, task's or ECS tunnel's container, or a
+// Session Manager shell on an EC2 instance or a tunnel's jump host.
+func (m *Model) handleShell() tea.Cmd {
+	if !m.checkActionAllowed(config.ActionShell) {
+		return nil
+	}
+
+	switch m.state.View {
+	case state.ViewServices:
+		item := m.serviceList.SelectedItem()
+		if item == nil {
+			return nil
+		}
+		for i := range m.state.Services {
+			if m.state.Services[i].Name == item.ID {
+				return m.loadTasksForShell(m.state.Services[i])
+			}
+		}
+
+	case state.ViewTasks:
+		return m.shellIntoTask(m.selectedTask(), nil)
+
+	case state.ViewTaskContainers:
+		if c := m.selectedTaskContainer(); c != nil {
+			return m.shellIntoTask(m.state.SelectedTask, c)
+		}
+
+	case state.ViewEC2:
+		if inst := m.selectedInstance(); inst != nil && m.instanceReachable(*inst, "shell") {
+			return m.execShell(inst.InstanceID, m.tunnelManager.InstanceShellCommand(inst.InstanceID))
+		}
+
+	case state.ViewJumpHostSelect:
+		item := m.ec2List.SelectedItem()
+		if item == nil {
+			return nil
+		}
+		return m.execShell(item.ID, m.tunnelManager.InstanceShellCommand(item.ID))
+
+	case state.ViewTunnels:
+		if t := m.tunnelsPanel.SelectedTunnel(); t != nil {
+			if t.JumpHostID != "" {
+				return m.execShell(t.JumpHostID, m.tunnelManager.InstanceShellCommand(t.JumpHostID))
+			}
+			target := fmt.Sprintf("%s/%s", t.ServiceName, t.ContainerName)
+			return m.execShell(target, m.tunnelManager.ECSExecCommand(t.ClusterName, t.TaskID, t.ContainerName))
+		}
+		if t := m.tunnelsPanel.SelectedAPIGatewayTunnel(); t != nil {
+			if t.JumpHost == nil {
+				m.logger.Warn("Shell: tunnel '%s' has no jump host", t.ID)
+				return nil
+			}
+			return m.execShell(t.JumpHost.InstanceID, m.tunnelManager.InstanceShellCommand(t.JumpHost.InstanceID))
+		}
+
+	default:
+		m.logger.Debug("Shell: not available in this view")
+	}
+	return nil
+}
+
+// loadTasksForShell loads the service's tasks so a container can be picked for ECS Exec.
+func (m *Model) loadTasksForShell(service model.Service) tea.Cmd {
+	if !service.EnableExecuteCommand {
+		m.logger.Warn("ECS Exec is not enabled for service '%s' (enableExecuteCommand is false)", service.Name)
+		return nil
+	}
+
+	m.logger.Info("Loading tasks for shell: %s", service.Name)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		tasks, err := m.client.ListTasksForService(ctx, service.ClusterARN, service.Name)
+		return shellTasksLoadedMsg{service: service, tasks: tasks, err: err}
+	}
+}
+
+// handleShellTasksLoaded opens ECS Exec in the best container of the first running task.
+func (m *Model) handleShellTasksLoaded(msg shellTasksLoadedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logger.Error("Failed to load tasks: %v", msg.err)
+		m.state.ShowLogs = true
+		m.updateComponentSizes()
+		return nil
+	}
+	if len(msg.tasks) == 0 {
+		m.logger.Error("No running tasks found for service '%s'", msg.service.Name)
+		m.state.ShowLogs = true
+		m.updateComponentSizes()
+		return nil
+	}
+
+	task := msg.tasks[0]
+	container := findBestContainer(task.Containers)
+	if container == nil {
+		m.logger.Error("No container with RuntimeID found. Is ECS Exec enabled for service '%s'? Task: %s", msg.service.Name, task.TaskID)
+		m.state.ShowLogs = true
+		m.updateComponentSizes()
+		return nil
+	}
+
+	target := fmt.Sprintf("%s/%s", msg.service.Name, container.Name)
+	return m.execShell(target, m.tunnelManager.ECSExecCommand(msg.service.ClusterName, task.TaskID, container.Name))
+}
+
+// shellIntoTask opens ECS Exec in a container of a task of the selected
+// service: the given one, or the task's best one without.
+func (m *Model) shellIntoTask(task *model.Task, container *model.Container) tea.Cmd {
+	service := m.state.SelectedService
+	if service == nil || task == nil {
+		return nil
+	}
+	if !service.EnableExecuteCommand {
+		m.logger.Warn("ECS Exec is not enabled for service '%s' (enableExecuteCommand is false)", service.Name)
+		return nil
+	}
+	if task.LastStatus != "RUNNING" {
+		m.logger.Warn("Shell: task %s is %s, not running", task.TaskID, task.LastStatus)
+		return nil
+	}
+	if container == nil {
+		container = findBestContainer(task.Containers)
+		if container == nil {
+			m.logger.Error("No container with RuntimeID found in task %s", task.TaskID)
+			return nil
+		}
+	}
+	if container.RuntimeID == "" || container.LastStatus != "RUNNING" {
+		m.logger.Warn("Shell: container '%s' is %s, not running", container.Name, valueOrDash(container.LastStatus))
+		return nil
+	}
+
+	target := fmt.Sprintf("%s/%s/%s", service.Name, task.TaskID, container.Name)
+	return m.execShell(target, m.tunnelManager.ECSExecCommand(service.ClusterName, task.TaskID, container.Name))
+}
+
+// execShell suspends the UI and hands the terminal to cmd until it exits.
+func (m *Model) execShell(target string, cmd *exec.Cmd) tea.Cmd {
+	m.logger.Info("Opening shell: %s (exit the shell to return to vaws)", target)
+	m.recordTimeline(timelineAction, "Opened shell %s", target)
+	m.logger.Debug("Running: %v", cmd.Args)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return shellExitedMsg{target: target, err: err}
+	})
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/aws"
+	"vaws/internal/model"
+)
+
+// ssoTokensLoadedMsg carries the SSO profiles of the AWS config and their
+// cached tokens, by profile name.
+type ssoTokensLoadedMsg struct {
+	profiles map[string]model.AWSProfile
+	tokens   map[string]model.SSOToken
+	err      error
+}
+
+// ssoTokenRefreshedMsg reports the refresh of a profile's SSO token.
+type ssoTokenRefreshedMsg struct {
+	profile string
+	err     error
+}
+
+// ssoLoginFinishedMsg reports that aws sso login for a profile exited.
+type ssoLoginFinishedMsg struct {
+	profile string
+	err     error
+}
+
+// loadSSOTokens reads the cached token of every SSO profile in the
+// background, for the profile selector.
+func (m *Model) loadSSOTokens() tea.Cmd {
+	return func() tea.Msg {
+		details, err := aws.ListProfileDetails()
+		if err != nil {
+			return ssoTokensLoadedMsg{err: err}
+		}
+		msg := ssoTokensLoadedMsg{profiles: make(map[string]model.AWSProfile), tokens: make(map[string]model.SSOToken)}
+		for _, p := range details {
+			if !p.SSO || p.SSOSession == "" {
+				continue
+			}
+			token, err := aws.SSOTokenStatus(p)
+			if err != nil && msg.err == nil {
+				msg.err = err
+			}
+			msg.profiles[p.Name] = p
+			msg.tokens[p.Name] = token
+		}
+		return msg
+	}
+}
+
+// handleSSOTokensLoaded shows the token status next to each SSO profile.
+func (m *Model) handleSSOTokensLoaded(msg ssoTokensLoadedMsg) {
+	if msg.err != nil {
+		m.logger.Warn("Failed to read SSO tokens: %v", msg.err)
+	}
+	if msg.profiles == nil {
+		return
+	}
+	m.ssoProfiles = msg.profiles
+	m.profileSelector.SetSSOTokens(msg.tokens)
+}
+
+// selectedSSOProfile returns the SSO profile under the cursor of the
+// profile selector, noting in the selector why there is none.
+func (m *Model) selectedSSOProfile() (model.AWSProfile, bool) {
+	name := m.profileSelector.SelectedProfile()
+	p, ok := m.ssoProfiles[name]
+	if !ok {
+		m.profileSelector.SetStatus(GetStyles().Muted.Render(fmt.Sprintf("Profile %s doesn't sign in through SSO", name)))
+	}
+	return p, ok
+}
+
+// refreshSSOToken trades the refresh token of the selected profile's
+// expired SSO token for a new one, sparing a browser login.
+func (m *Model) refreshSSOToken() tea.Cmd {
+	p, ok := m.selectedSSOProfile()
+	if !ok {
+		return nil
+	}
+	s := GetStyles()
+	token, err := aws.SSOTokenStatus(p)
+	switch {
+	case err != nil:
+		m.profileSelector.SetStatus(s.StatusError.Render(err.Error()))
+		return nil
+	case !token.Expired(time.Now()):
+		m.profileSelector.SetStatus(s.Muted.Render(fmt.Sprintf("The SSO token of %s is still valid", p.Name)))
+		return nil
+	case !token.Refreshable:
+		m.profileSelector.SetStatus(s.StatusWarning.Render(fmt.Sprintf("The SSO token of %s can't be refreshed: l runs aws sso login", p.Name)))
+		return nil
+	}
+
+	m.profileSelector.SetStatus(s.Muted.Render(fmt.Sprintf("Refreshing the SSO token of %s...", p.Name)))
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_, err := aws.RefreshSSOToken(ctx, p)
+		return ssoTokenRefreshedMsg{profile: p.Name, err: err}
+	}
+}
+
+// handleSSOTokenRefreshed reports the refresh and reads the tokens again,
+// since the profiles of the same session share the token.
+func (m *Model) handleSSOTokenRefreshed(msg ssoTokenRefreshedMsg) tea.Cmd {
+	s := GetStyles()
+	if msg.err != nil {
+		m.logger.Error("Failed to refresh the SSO token of %s: %v", msg.profile, msg.err)
+		m.profileSelector.SetStatus(s.StatusError.Render(truncateString(fmt.Sprintf("Refresh failed, l runs aws sso login: %v", msg.err), max(m.width-20, 40))))
+	} else {
+		m.profileSelector.SetStatus(s.StatusHealthy.Render(fmt.Sprintf("Refreshed the SSO token of %s", msg.profile)))
+	}
+	return m.loadSSOTokens()
+}
+
+// ssoLogin runs aws sso login for the selected profile in the foreground,
+// which opens the browser to sign in.
+func (m *Model) ssoLogin() tea.Cmd {
+	p, ok := m.selectedSSOProfile()
+	if !ok {
+		return nil
+	}
+	cmd := exec.Command("aws", "sso", "login", "--profile", p.Name)
+	m.logger.Debug("Running: %v", cmd.Args)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return ssoLoginFinishedMsg{profile: p.Name, err: err}
+	})
+}
+
+// handleSSOLoginFinished reports the login and reads the tokens again.
+func (m *Model) handleSSOLoginFinished(msg ssoLoginFinishedMsg) tea.Cmd {
+	s := GetStyles()
+	if msg.err != nil {
+		m.profileSelector.SetStatus(s.StatusError.Render(fmt.Sprintf("aws sso login for %s failed: %v", msg.profile, msg.err)))
+	} else {
+		m.profileSelector.SetStatus(s.StatusHealthy.Render(fmt.Sprintf("Signed in to %s", msg.profile)))
+	}
+	return m.loadSSOTokens()
+}
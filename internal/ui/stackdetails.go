@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+// stackDescribeDelay is how long the cursor has to rest on a stack before it
+// is described, so scrolling through the list doesn't describe every stack.
+const stackDescribeDelay = 200 * time.Millisecond
+
+// stackDescriber describes the stack under the cursor, since ListStacks only
+// returns summaries.
+type stackDescriber struct {
+	pending string           // Stack waiting for the cursor to rest on it
+	seq     int              // Bumped per pending stack, so only the last tick describes
+	loading string           // Stack being described
+	failed  map[string]error // Stacks that failed to describe, until the list reloads
+}
+
+// stackDescribeTickMsg describes the pending stack, unless the cursor moved
+// on since the tick was scheduled.
+type stackDescribeTickMsg struct {
+	seq int
+}
+
+// stackDescribedMsg carries a described stack.
+type stackDescribedMsg struct {
+	name  string
+	stack *model.Stack
+	err   error
+}
+
+// selectedStack returns the stack under the cursor of the stacks list.
+func (m *Model) selectedStack() *model.Stack {
+	item := m.stacksList.SelectedItem()
+	if item == nil || item.Group {
+		return nil
+	}
+	for i := range m.state.Stacks {
+		if m.state.Stacks[i].Name == item.ID {
+			return &m.state.Stacks[i]
+		}
+	}
+	return nil
+}
+
+// describeSelectedStack schedules describing the stack under the cursor if
+// only its summary is known. It runs after every message, so it also picks
+// up selections changed by filtering and reloads.
+func (m *Model) describeSelectedStack() tea.Cmd {
+	if m.state.View != state.ViewStacks || m.client == nil {
+		return nil
+	}
+	s := m.selectedStack()
+	d := &m.stackDescriber
+	if s == nil || s.Described || d.pending == s.Name || d.loading == s.Name || d.failed[s.Name] != nil {
+		return nil
+	}
+	d.pending = s.Name
+	d.seq++
+	seq := d.seq
+	return tea.Tick(stackDescribeDelay, func(time.Time) tea.Msg {
+		return stackDescribeTickMsg{seq: seq}
+	})
+}
+
+// handleStackDescribeTick describes the pending stack if the cursor is still
+// on it.
+func (m *Model) handleStackDescribeTick(msg stackDescribeTickMsg) tea.Cmd {
+	d := &m.stackDescriber
+	if msg.seq != d.seq || d.pending == "" {
+		return nil
+	}
+	name := d.pending
+	d.pending = ""
+	if s := m.selectedStack(); s == nil || s.Name != name || m.state.View != state.ViewStacks {
+		return nil
+	}
+	d.loading = name
+	m.updateStackDetails()
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		stack, err := client.DescribeStack(ctx, name)
+		return stackDescribedMsg{name: name, stack: stack, err: err}
+	}
+}
+
+// handleStackDescribed replaces the summary of the stack with its
+// description.
+func (m *Model) handleStackDescribed(msg stackDescribedMsg) {
+	d := &m.stackDescriber
+	if d.loading == msg.name {
+		d.loading = ""
+	}
+	if msg.err != nil {
+		m.logger.Error("Failed to describe stack %s: %v", msg.name, msg.err)
+		if d.failed == nil {
+			d.failed = make(map[string]error)
+		}
+		d.failed[msg.name] = msg.err
+	} else {
+		// By ID, so a description for the previous profile or region is dropped
+		for i := range m.state.Stacks {
+			if m.state.Stacks[i].ID == msg.stack.ID {
+				m.state.Stacks[i] = *msg.stack
+			}
+		}
+	}
+	if m.state.View == state.ViewStacks {
+		m.updateStackDetails()
+	}
+}
+
+// keepStackDescriptions carries the descriptions of stacks over to a reload
+// of the list, for the stacks that haven't changed since they were
+// described. Failed stacks are tried again.
+func (m *Model) keepStackDescriptions(stacks []model.Stack) {
+	described := make(map[string]model.Stack)
+	for _, s := range m.state.Stacks {
+		if s.Described {
+			described[s.ID] = s
+		}
+	}
+	for i, s := range stacks {
+		if prev, ok := described[s.ID]; ok && prev.Status == s.Status && prev.UpdatedAt.Equal(s.UpdatedAt) {
+			stacks[i] = prev
+		}
+	}
+	m.stackDescriber.failed = nil
+}
+
+// stackDescriptionRows show the drift status, safety settings, outputs,
+// parameters and tags of a described stack, or that they are being loaded.
+func (m *Model) stackDescriptionRows(s model.Stack) []components.DetailRow {
+	st := GetStyles()
+	rows := []components.DetailRow{{Label: "", Value: ""}} // Spacer
+	if !s.Described {
+		d := m.stackDescriber
+		if err := d.failed[s.Name]; err != nil {
+			return append(rows, components.DetailRow{Label: "Details", Value: err.Error(), Style: st.StatusError})
+		}
+		return append(rows, components.DetailRow{Label: "Details", Value: "Loading outputs, parameters and tags...", Style: st.Muted})
+	}
+
+	drift := strings.ReplaceAll(s.DriftStatus, "_", " ")
+	driftStyle := st.Muted
+	switch s.DriftStatus {
+	case "DRIFTED":
+		driftStyle = st.StatusWarning
+	case "IN_SYNC":
+		driftStyle = st.StatusHealthy
+	}
+	if !s.DriftCheckedAt.IsZero() {
+		drift += " (checked " + format.Time(s.DriftCheckedAt) + ")"
+	}
+	if drift != "" {
+		rows = append(rows, components.DetailRow{Label: "Drift", Value: drift, Style: driftStyle})
+	}
+	rows = append(rows, stackSafetyRows(s)...)
+
+	rows = append(rows, components.DetailRow{Label: "Outputs", Value: countOrNone(len(s.Outputs))})
+	for _, o := range s.Outputs {
+		value := o.Value
+		if o.ExportName != "" {
+			value += " (export " + o.ExportName + ")"
+		}
+		rows = append(rows, components.DetailRow{Label: "  " + o.Key, Value: value})
+	}
+
+	rows = append(rows, components.DetailRow{Label: "Parameters", Value: countOrNone(len(s.Parameters))})
+	for _, p := range s.Parameters {
+		rows = append(rows, components.DetailRow{Label: "  " + p.Key, Value: p.Value})
+	}
+
+	rows = append(rows, components.DetailRow{Label: "Tags", Value: countOrNone(len(s.Tags))})
+	keys := make([]string, 0, len(s.Tags))
+	for k := range s.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		rows = append(rows, components.DetailRow{Label: "  " + k, Value: s.Tags[k]})
+	}
+	return rows
+}
+
+// countOrNone renders the size of a section of the details, e.g. "3" or
+// "None".
+func countOrNone(n int) string {
+	if n == 0 {
+		return "None"
+	}
+	return fmt.Sprintf("%d", n)
+}
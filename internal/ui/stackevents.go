@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+// stackEventsInterval is how often the events of a stack being changed are
+// read again.
+const stackEventsInterval = 5 * time.Second
+
+// stackEventsLoadedMsg carries the events of a stack, newest first.
+type stackEventsLoadedMsg struct {
+	stack  string
+	events []model.StackEvent
+	err    error
+}
+
+// stackEventsTickMsg reads the events of the stack again while it is being
+// changed.
+type stackEventsTickMsg struct {
+	stack string
+}
+
+// openStackEvents opens the event timeline of the selected stack.
+func (m *Model) openStackEvents() tea.Cmd {
+	s := m.selectedStack()
+	if s == nil {
+		return nil
+	}
+
+	m.state.ClearStackEvents()
+	m.state.StackEventsStack = s.Name
+	m.state.View = state.ViewStackEvents
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.stackEventsList.SetItems(nil)
+	return m.loadStackEvents()
+}
+
+// loadStackEvents loads the events of the stack the timeline is open on.
+func (m *Model) loadStackEvents() tea.Cmd {
+	name := m.state.StackEventsStack
+	if name == "" {
+		return nil
+	}
+	m.state.StackEventsLoading = true
+	m.stackEventsList.SetLoading(true)
+
+	client := m.client
+	return tea.Batch(
+		m.stackEventsList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			events, err := client.GetStackEvents(ctx, name)
+			return stackEventsLoadedMsg{stack: name, events: events, err: err}
+		},
+	)
+}
+
+// handleStackEventsLoaded shows the events of the stack, if the timeline is
+// still open on it, and keeps reading them while the stack is being changed.
+func (m *Model) handleStackEventsLoaded(msg stackEventsLoadedMsg) tea.Cmd {
+	if m.state.View != state.ViewStackEvents || m.state.StackEventsStack != msg.stack {
+		return nil
+	}
+	m.state.StackEventsLoading = false
+	m.refreshIndicator.SetRefreshing(false)
+	if msg.err != nil {
+		m.state.StackEventsError = msg.err
+		m.logger.Error("Failed to load events of %s: %v", msg.stack, msg.err)
+		m.updateStackEventsList()
+		return nil
+	}
+
+	wasChanging := m.stackEventsChanging()
+	m.state.StackEvents = msg.events
+	m.state.StackEventsError = nil
+	m.updateStackEventsList()
+
+	if !m.stackEventsChanging() {
+		if wasChanging && len(m.state.StackEvents) > 0 {
+			m.logger.Info("Stack %s is %s", msg.stack, m.stackEventsStatus())
+		}
+		return nil
+	}
+	if m.stackEventsPolling || !m.state.AutoRefresh {
+		return nil
+	}
+	m.stackEventsPolling = true
+	return tea.Tick(stackEventsInterval, func(time.Time) tea.Msg {
+		return stackEventsTickMsg{stack: msg.stack}
+	})
+}
+
+// handleStackEventsTick reads the events again, unless the timeline was
+// closed or opened on another stack since.
+func (m *Model) handleStackEventsTick(msg stackEventsTickMsg) tea.Cmd {
+	m.stackEventsPolling = false
+	if m.state.View != state.ViewStackEvents || m.state.StackEventsStack != msg.stack {
+		return nil
+	}
+	return m.refreshInPlace(m.stackEventsList, m.loadStackEvents)
+}
+
+// stackEventsStatus returns the status of the stack as its latest own event
+// reports it, or as the stacks list has it without one.
+func (m *Model) stackEventsStatus() string {
+	name := m.state.StackEventsStack
+	for _, e := range m.state.StackEvents {
+		if e.IsStack(name) {
+			return e.Status
+		}
+	}
+	for _, s := range m.state.Stacks {
+		if s.Name == name {
+			return string(s.Status)
+		}
+	}
+	return ""
+}
+
+// stackEventsChanging returns true while the stack of the timeline is being
+// created, updated, rolled back or deleted.
+func (m *Model) stackEventsChanging() bool {
+	return model.StackEvent{Status: m.stackEventsStatus()}.InProgress()
+}
+
+// selectedStackEvent returns the event under the cursor of the timeline.
+func (m *Model) selectedStackEvent() *model.StackEvent {
+	item := m.stackEventsList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.StackEvents {
+		if m.state.StackEvents[i].ID == item.ID {
+			return &m.state.StackEvents[i]
+		}
+	}
+	return nil
+}
+
+// updateStackEventsList updates the event timeline with current data. A
+// failed event shows its reason next to the resource, highlighted.
+func (m *Model) updateStackEventsList() {
+	s := GetStyles()
+	events := m.state.FilteredStackEvents()
+
+	columns := []components.Column{{Key: "time", Title: "TIME", Width: len(format.Absolute(time.Now()))}}
+	items := make([]components.ListItem, len(events))
+	for i, e := range events {
+		item := components.ListItem{
+			ID:          e.ID,
+			Title:       e.LogicalID,
+			Status:      e.Status,
+			StatusStyle: StatusStyle(e.Status),
+			Cells:       map[string]string{"time": format.Absolute(e.Time)},
+		}
+		if e.Failed() && e.StatusReason != "" {
+			item.Title += ": " + e.StatusReason
+			item.Highlight = &s.StatusError
+		}
+		items[i] = item
+	}
+	m.stackEventsList.SetColumns(columns)
+	m.stackEventsList.SetItems(items)
+	m.stackEventsList.SetLoading(m.state.StackEventsLoading && len(m.state.StackEvents) == 0)
+	m.stackEventsList.SetError(m.state.StackEventsError)
+	m.stackEventsList.SetEmptyMessage("No events")
+	m.updateStackEventDetails()
+}
+
+// updateStackEventDetails updates the details panel with the selected event,
+// below how the stack stands.
+func (m *Model) updateStackEventDetails() {
+	st := GetStyles()
+	m.details.SetTitle("Stack Event")
+
+	status := m.stackEventsStatus()
+	failed := 0
+	for _, e := range m.state.StackEvents {
+		if e.Failed() {
+			failed++
+		}
+	}
+	rows := []components.DetailRow{
+		{Label: "Stack", Value: m.state.StackEventsStack},
+		{Label: "Stack Status", Value: valueOrDash(status), Style: StatusStyle(status)},
+	}
+	if failed > 0 {
+		rows = append(rows, components.DetailRow{Label: "Failed Events", Value: fmt.Sprintf("%d of %d", failed, len(m.state.StackEvents)), Style: st.StatusError})
+	}
+	switch {
+	case m.stackEventsChanging() && m.state.AutoRefresh:
+		rows = append(rows, components.DetailRow{Label: "Refresh", Value: fmt.Sprintf("Every %s while in progress", format.Age(stackEventsInterval)), Style: st.StatusInProgress})
+	case m.stackEventsChanging():
+		rows = append(rows, components.DetailRow{Label: "Refresh", Value: "Auto-refresh off (a to turn on, r to refresh)", Style: st.Muted})
+	}
+
+	e := m.selectedStackEvent()
+	if e == nil {
+		m.details.SetRows(rows)
+		return
+	}
+	reasonStyle := st.Muted
+	if e.Failed() {
+		reasonStyle = st.StatusError
+	}
+	rows = append(rows,
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+		components.DetailRow{Label: "Resource", Value: e.LogicalID},
+		components.DetailRow{Label: "Physical ID", Value: valueOrDash(e.PhysicalID)},
+		components.DetailRow{Label: "Type", Value: e.ResourceType},
+		components.DetailRow{Label: "Status", Value: e.Status, Style: StatusStyle(e.Status)},
+		components.DetailRow{Label: "Time", Value: format.Absolute(e.Time)},
+		components.DetailRow{Label: "Reason", Value: valueOrDash(e.StatusReason), Style: reasonStyle},
+	)
+	m.details.SetRows(rows)
+}
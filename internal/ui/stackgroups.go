@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/theme"
+)
+
+// groupByPrefix groups stacks by their name up to the first - or _ instead
+// of by a tag.
+const groupByPrefix = "prefix"
+
+// stackGrouping holds how the stacks list is grouped.
+type stackGrouping struct {
+	key       string // Tag key, or groupByPrefix; "" for no grouping
+	set       bool   // key was chosen with :group, overriding the config
+	collapsed map[string]bool
+}
+
+// stackGroupKey returns what the stacks list is grouped by, "" if it isn't.
+func (m *Model) stackGroupKey() string {
+	if m.stackGroups.set || m.cfg == nil {
+		return m.stackGroups.key
+	}
+	return m.cfg.Defaults.StackGroupTag
+}
+
+// stackGroup returns the group of a stack, "" if it has none.
+func stackGroup(s model.Stack, key string) string {
+	if key == groupByPrefix {
+		if i := strings.IndexAny(s.Name, "-_"); i > 0 {
+			return s.Name[:i]
+		}
+		return s.Name
+	}
+	return s.Tags[key]
+}
+
+// groupStackItems puts the stack items under a header per group, sorted by
+// group, with stacks that have no group last. Collapsed groups show only
+// their header, unless the list is filtered.
+func (m *Model) groupStackItems(stacks []model.Stack, items []components.ListItem, key string) []components.ListItem {
+	groups := make(map[string][]int)
+	for i, s := range stacks {
+		g := stackGroup(s, key)
+		groups[g] = append(groups[g], i)
+	}
+	names := make([]string, 0, len(groups))
+	for g := range groups {
+		names = append(names, g)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == "") != (names[j] == "") {
+			return names[j] == ""
+		}
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	failedStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	grouped := make([]components.ListItem, 0, len(items)+len(names))
+	for _, g := range names {
+		idx := groups[g]
+		label := g
+		if g == "" {
+			label = "(no " + key + ")"
+		}
+		failed := 0
+		for _, i := range idx {
+			if stacks[i].Status.IsFailed() {
+				failed++
+			}
+		}
+		header := components.ListItem{
+			ID:        "group:" + g,
+			Title:     fmt.Sprintf("%s (%d)", label, len(idx)),
+			Group:     true,
+			Collapsed: m.stackGroups.collapsed[g],
+		}
+		if failed > 0 {
+			header.Status = fmt.Sprintf("%d failed", failed)
+			header.StatusStyle = failedStyle
+		}
+		grouped = append(grouped, header)
+
+		if header.Collapsed && m.state.FilterText == "" {
+			continue
+		}
+		for _, i := range idx {
+			grouped = append(grouped, items[i])
+		}
+	}
+	return grouped
+}
+
+// toggleStackGroup folds or unfolds the group under the cursor, reporting
+// whether the cursor was on a group.
+func (m *Model) toggleStackGroup() bool {
+	item := m.stacksList.SelectedItem()
+	if item == nil || !item.Group {
+		return false
+	}
+	g := strings.TrimPrefix(item.ID, "group:")
+	if m.stackGroups.collapsed == nil {
+		m.stackGroups.collapsed = make(map[string]bool)
+	}
+	m.stackGroups.collapsed[g] = !m.stackGroups.collapsed[g]
+	m.updateStacksList()
+	return true
+}
+
+// setAllStackGroups folds or unfolds every group of the stacks list.
+func (m *Model) setAllStackGroups(collapsed bool) {
+	key := m.stackGroupKey()
+	if key == "" {
+		return
+	}
+	m.stackGroups.collapsed = make(map[string]bool)
+	if collapsed {
+		for _, s := range m.state.FilteredStacks() {
+			m.stackGroups.collapsed[stackGroup(s, key)] = true
+		}
+	}
+	m.updateStacksList()
+}
+
+// handleGroupCommand groups the stacks list by a tag key or the name prefix,
+// or stops grouping: :group <tag key>, :group prefix and :group off. Without
+// arguments it shows what the list is grouped by.
+func (m *Model) handleGroupCommand(args []string) {
+	if len(args) == 0 {
+		if key := m.stackGroupKey(); key != "" {
+			m.logger.Info("Stacks are grouped by %s (:group off to stop)", key)
+		} else {
+			m.logger.Info("Usage: :group <tag key>, :group prefix or :group off")
+		}
+		return
+	}
+
+	key := strings.Join(args, " ")
+	if key == "off" || key == "none" {
+		key = ""
+	}
+	m.stackGroups = stackGrouping{key: key, set: true}
+	m.updateStacksList()
+	if key == "" {
+		m.logger.Info("Stacks are no longer grouped")
+		return
+	}
+	m.logger.Info("Grouping stacks by %s: enter folds a group, - and + fold and unfold all", key)
+}
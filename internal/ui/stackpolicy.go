@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/ui/components"
+)
+
+// allowAllStackPolicy is offered to edit for stacks without a policy. It is
+// also what a policy is relaxed to, as CloudFormation can't remove one.
+const allowAllStackPolicy = `{
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": "Update:*",
+      "Principal": "*",
+      "Resource": "*"
+    }
+  ]
+}
+`
+
+// stackSafetyMsg carries the result of changing the termination protection
+// or the stack policy of a stack.
+type stackSafetyMsg struct {
+	name       string
+	protection *bool   // New termination protection, if it was changed
+	policy     *string // New stack policy, if it was set
+	err        error
+}
+
+// stackPolicyStatement is a statement of a stack policy, as far as the
+// details show it. Action and Resource are a string or a list.
+type stackPolicyStatement struct {
+	Effect      string
+	Action      any
+	NotAction   any
+	Resource    any
+	NotResource any
+}
+
+// toggleTerminationProtection asks to turn the termination protection of
+// the selected stack off if it is on, or on.
+func (m *Model) toggleTerminationProtection() tea.Cmd {
+	s := m.selectedStack()
+	if s == nil {
+		return nil
+	}
+	if !s.Described {
+		m.logger.Warn("Wait for the details of %s to load", s.Name)
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	enable := !s.TerminationProtection
+	title := "Enable termination protection"
+	details := []string{"Stack: " + s.Name, "Deleting the stack fails until it is disabled again"}
+	if !enable {
+		title = "Disable termination protection"
+		details = []string{"Stack: " + s.Name, "The stack can be deleted afterwards"}
+	}
+	name := s.Name
+	return m.askConfirm(title, details, func() tea.Cmd {
+		client := m.client
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := client.SetTerminationProtection(ctx, name, enable)
+			return stackSafetyMsg{name: name, protection: &enable, err: err}
+		}
+	})
+}
+
+// editStackPolicy opens the stack policy of the selected stack in $EDITOR,
+// or a policy allowing every update if it has none.
+func (m *Model) editStackPolicy() tea.Cmd {
+	s := m.selectedStack()
+	if s == nil {
+		return nil
+	}
+	if !s.Described {
+		m.logger.Warn("Wait for the details of %s to load", s.Name)
+		return nil
+	}
+	if s.StackPolicyError != "" {
+		m.logger.Warn("Can't read the stack policy of %s: %s", s.Name, s.StackPolicyError)
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+
+	policy := allowAllStackPolicy
+	if s.StackPolicy != "" {
+		policy = prettyJSON(s.StackPolicy)
+	}
+	m.policyStack = s.Name
+	return m.openEditor(editStackPolicy, policy, ".json")
+}
+
+// applyStackPolicy asks to set the stack policy saved in the editor, unless
+// it is invalid or unchanged.
+func (m *Model) applyStackPolicy(text string) tea.Cmd {
+	name := m.policyStack
+	m.policyStack = ""
+	var s *model.Stack
+	for i := range m.state.Stacks {
+		if m.state.Stacks[i].Name == name {
+			s = &m.state.Stacks[i]
+		}
+	}
+	if s == nil {
+		return nil
+	}
+
+	policy := strings.TrimSpace(text)
+	if policy == "" {
+		m.logger.Warn("A stack policy can't be removed; to allow every update, set one with Effect Allow on Update:* and Resource *")
+		return nil
+	}
+	statements, err := parseStackPolicy(policy)
+	if err != nil {
+		m.logger.Error("Stack policy of %s not set: %v", name, err)
+		return nil
+	}
+	if sameJSON(policy, s.StackPolicy) || (s.StackPolicy == "" && sameJSON(policy, allowAllStackPolicy)) {
+		m.logger.Info("Stack policy of %s unchanged", name)
+		return nil
+	}
+
+	details := []string{"Stack: " + name}
+	for _, st := range statements {
+		details = append(details, "  "+st.Effect+" "+st.target())
+	}
+	return m.askConfirm("Set stack policy", details, func() tea.Cmd {
+		client := m.client
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := client.SetStackPolicy(ctx, name, policy)
+			return stackSafetyMsg{name: name, policy: &policy, err: err}
+		}
+	})
+}
+
+// handleStackSafety shows the new termination protection or stack policy of
+// a stack.
+func (m *Model) handleStackSafety(msg stackSafetyMsg) {
+	if msg.err != nil {
+		m.logger.Error("%v", msg.err)
+		return
+	}
+	for i := range m.state.Stacks {
+		s := &m.state.Stacks[i]
+		if s.Name != msg.name {
+			continue
+		}
+		if msg.protection != nil {
+			s.TerminationProtection = *msg.protection
+		}
+		if msg.policy != nil {
+			s.StackPolicy = *msg.policy
+		}
+	}
+	switch {
+	case msg.protection != nil && *msg.protection:
+		m.logger.Info("Enabled termination protection of %s", msg.name)
+	case msg.protection != nil:
+		m.logger.Info("Disabled termination protection of %s", msg.name)
+	default:
+		m.logger.Info("Set the stack policy of %s", msg.name)
+	}
+	m.updateStackDetails()
+}
+
+// parseStackPolicy checks that text is a stack policy with statements and
+// returns them.
+func parseStackPolicy(text string) ([]stackPolicyStatement, error) {
+	var policy struct {
+		Statement []stackPolicyStatement
+	}
+	if err := json.Unmarshal([]byte(text), &policy); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if len(policy.Statement) == 0 {
+		return nil, fmt.Errorf("the policy has no Statement")
+	}
+	for i, st := range policy.Statement {
+		if st.Effect != "Allow" && st.Effect != "Deny" {
+			return nil, fmt.Errorf("statement %d: Effect must be Allow or Deny, got %q", i+1, st.Effect)
+		}
+	}
+	return policy.Statement, nil
+}
+
+// target sums up what a statement applies to, e.g. "Update:Replace on
+// LogicalResourceId/Database".
+func (st stackPolicyStatement) target() string {
+	action := policyList(st.Action)
+	if st.NotAction != nil {
+		action = "every action but " + policyList(st.NotAction)
+	}
+	resource := policyList(st.Resource)
+	if st.NotResource != nil {
+		resource = "every resource but " + policyList(st.NotResource)
+	}
+	return action + " on " + resource
+}
+
+// policyList joins a policy element that is a string or a list of them.
+func policyList(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, e := range v {
+			parts = append(parts, fmt.Sprint(e))
+		}
+		return strings.Join(parts, ", ")
+	}
+	return "?"
+}
+
+// sameJSON reports whether two texts hold the same JSON value.
+func sameJSON(a, b string) bool {
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return string(ja) == string(jb)
+}
+
+// stackSafetyRows show the termination protection and stack policy of a
+// described stack, the policy as one row per statement.
+func stackSafetyRows(s model.Stack) []components.DetailRow {
+	st := GetStyles()
+	protection := components.DetailRow{Label: "Termination", Value: "Not protected (B to protect)", Style: st.StatusWarning}
+	if s.TerminationProtection {
+		protection = components.DetailRow{Label: "Termination", Value: "Protected", Style: st.StatusHealthy}
+	}
+	rows := []components.DetailRow{protection}
+
+	switch {
+	case s.StackPolicyError != "":
+		rows = append(rows, components.DetailRow{Label: "Stack Policy", Value: s.StackPolicyError, Style: st.StatusError})
+	case s.StackPolicy == "":
+		rows = append(rows, components.DetailRow{Label: "Stack Policy", Value: "None, every update allowed (P to edit)", Style: st.Muted})
+	default:
+		statements, err := parseStackPolicy(s.StackPolicy)
+		if err != nil {
+			rows = append(rows, components.DetailRow{Label: "Stack Policy", Value: err.Error(), Style: st.StatusError})
+			break
+		}
+		rows = append(rows, components.DetailRow{Label: "Stack Policy", Value: fmt.Sprintf("%d statement(s) (P to edit)", len(statements))})
+		for _, stmt := range statements {
+			style := st.StatusHealthy
+			if stmt.Effect == "Deny" {
+				style = st.StatusWarning
+			}
+			rows = append(rows, components.DetailRow{Label: "  " + stmt.Effect, Value: stmt.target(), Style: style})
+		}
+	}
+	return rows
+}
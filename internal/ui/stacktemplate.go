@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+)
+
+// stackTemplate is the template of a stack, shown in the details pane in
+// place of the stack's details until T is pressed again or the cursor
+// moves to another stack.
+type stackTemplate struct {
+	stack   string
+	loading bool
+}
+
+// stackTemplateLoadedMsg carries the template of a stack.
+type stackTemplateLoadedMsg struct {
+	stack string
+	body  string
+	err   error
+}
+
+// toggleStackTemplate shows the template of the selected stack in the
+// details pane, or the stack's details again if it is shown.
+func (m *Model) toggleStackTemplate() tea.Cmd {
+	s := m.selectedStack()
+	if s == nil {
+		return nil
+	}
+	if t := m.stackTemplate; t != nil && t.stack == s.Name {
+		m.closeStackTemplate()
+		return nil
+	}
+	if !m.hasDetailsPane() {
+		m.logger.Warn("Widen the terminal to show the details pane for the template")
+		return nil
+	}
+
+	name := s.Name
+	m.stackTemplate = &stackTemplate{stack: name, loading: true}
+	m.details.SetTitle("Template: " + name)
+	m.details.SetRows([]components.DetailRow{{Label: "Template", Value: "Loading...", Style: GetStyles().Muted}})
+	m.logger.Info("Loading template of %s...", name)
+
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		body, err := client.GetTemplate(ctx, name)
+		return stackTemplateLoadedMsg{stack: name, body: body, err: err}
+	}
+}
+
+// handleStackTemplateLoaded shows a loaded template, if it is still waited
+// for, and focuses the details pane to scroll and search it.
+func (m *Model) handleStackTemplateLoaded(msg stackTemplateLoadedMsg) {
+	t := m.stackTemplate
+	if t == nil || t.stack != msg.stack || !t.loading {
+		return
+	}
+	t.loading = false
+	if msg.err != nil {
+		m.logger.Error("Failed to load template of %s: %v", msg.stack, msg.err)
+		m.closeStackTemplate()
+		return
+	}
+
+	m.details.ClearSearch()
+	m.details.SetTitle("Template: " + msg.stack)
+	m.details.SetRows(templateRows(msg.body))
+	m.setFocus(focusDetails)
+	m.logger.Info("Showing template of %s (/ to search, T or esc to close)", msg.stack)
+}
+
+// closeStackTemplate shows the details of the selected stack again.
+func (m *Model) closeStackTemplate() {
+	m.stackTemplate = nil
+	m.details.ClearSearch()
+	if m.focusedPane() == focusDetails {
+		m.setFocus(focusMain)
+	}
+	m.updateStackDetails()
+}
+
+// dropStackTemplate forgets the template once the stacks view is left, so
+// that coming back shows the stack's details.
+func (m *Model) dropStackTemplate() {
+	if m.stackTemplate != nil && m.state.View != state.ViewStacks {
+		m.stackTemplate = nil
+	}
+}
+
+// showsStackTemplate reports whether the details pane shows the template of
+// the stack under the cursor, which moving the cursor elsewhere closes.
+func (m *Model) showsStackTemplate() bool {
+	t := m.stackTemplate
+	if t == nil {
+		return false
+	}
+	if s := m.selectedStack(); s != nil && s.Name == t.stack {
+		return true
+	}
+	m.stackTemplate = nil
+	m.details.ClearSearch()
+	return false
+}
+
+// templateSyntax tells JSON templates from YAML ones.
+func templateSyntax(body string) string {
+	if strings.HasPrefix(strings.TrimSpace(body), "{") {
+		return components.SyntaxJSON
+	}
+	return components.SyntaxYAML
+}
+
+// templateRows returns a row per line of a template, JSON indented if it
+// was submitted on one line.
+func templateRows(body string) []components.DetailRow {
+	syntax := templateSyntax(body)
+	if syntax == components.SyntaxJSON && !strings.Contains(strings.TrimSpace(body), "\n") && json.Valid([]byte(body)) {
+		body = prettyJSON(body)
+	}
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(body, "\t", "  "), "\n"), "\n")
+	rows := make([]components.DetailRow, len(lines))
+	for i, line := range lines {
+		rows[i] = components.DetailRow{Value: strings.TrimRight(line, "\r "), Syntax: syntax}
+	}
+	return rows
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/state"
+)
+
+// startupTimer times the phases vaws goes through until the first view
+// shows, for the breakdown logged with --debug.
+type startupTimer struct {
+	started time.Time
+	last    time.Time
+	phases  []string
+	splash  bool // The splash was shown, so the last phase waited on it
+}
+
+// newStartupTimer starts timing startup at started.
+func newStartupTimer(started time.Time, splash bool) *startupTimer {
+	return &startupTimer{started: started, last: started, splash: splash}
+}
+
+// mark ends the phase that ran since the previous mark. It does nothing
+// once startup is over.
+func (t *startupTimer) mark(phase string) {
+	if t == nil {
+		return
+	}
+	now := time.Now()
+	t.phases = append(t.phases, phase+" "+now.Sub(t.last).Round(time.Millisecond).String())
+	t.last = now
+}
+
+// TimeStartupFrom counts startup from started, when vaws began before the
+// model was created, and calls the time until then phase.
+func (m *Model) TimeStartupFrom(started time.Time, phase string) {
+	t := m.startup
+	if t == nil {
+		return
+	}
+	t.phases = append([]string{phase + " " + t.started.Sub(started).Round(time.Millisecond).String()}, t.phases...)
+	t.started = started
+}
+
+// fastStart reports whether the config skips the splash and holds back the
+// loads of startup until a view is opened.
+func (m *Model) fastStart() bool {
+	return m.cfg != nil && m.cfg.Defaults.FastStart
+}
+
+// finishStartup logs the startup breakdown once a view is on screen after
+// the splash, if it hasn't been logged yet.
+func (m *Model) finishStartup() {
+	t := m.startup
+	if t == nil || !m.ready || m.showSplash || m.client == nil || m.state.View == state.ViewProfileSelect {
+		return
+	}
+	if t.splash {
+		t.mark("splash")
+	} else {
+		t.mark("first view")
+	}
+	m.logger.Debug("Started in %s: %s", time.Since(t.started).Round(time.Millisecond), strings.Join(t.phases, " · "))
+	m.startup = nil
+}
+
+// startDeferredLoads runs the loads fast_start held back once a view other
+// than the main menu is opened: the check for the programs tunnels run, if
+// not done yet, and the Terraform states.
+func (m *Model) startDeferredLoads() tea.Cmd {
+	if !m.startupDeferred || m.state.View == state.ViewMain || m.state.View == state.ViewProfileSelect {
+		return nil
+	}
+	m.startupDeferred = false
+	m.logger.Debug("Running the startup loads held back by fast_start")
+	cmds := []tea.Cmd{m.loadTerraform()}
+	if m.prereqs == nil {
+		cmds = append(cmds, checkPrerequisites())
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,315 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+// statsInterval is how often the diagnostics view samples the runtime.
+const statsInterval = time.Second
+
+// statsCPUProfile is how long the CPU profile written from the diagnostics
+// view records for.
+const statsCPUProfile = 10 * time.Second
+
+// diagnostics are timings of vaws's own event loop, for the :stats view.
+type diagnostics struct {
+	started time.Time
+	ticking bool // A sample tick is scheduled
+
+	messages   int     // Messages handled
+	lastSample int     // Messages handled at the last sample
+	rate       float64 // Messages per second over the last sample
+	lastLag    time.Duration
+	maxLag     time.Duration
+	update     timing
+	render     timing
+
+	profiling   bool   // A CPU profile is being recorded
+	lastProfile string // Directory of the last profiles written
+}
+
+// timing is the duration of a repeated step: the last one, the longest and
+// the total, for the average.
+type timing struct {
+	count int
+	last  time.Duration
+	max   time.Duration
+	total time.Duration
+}
+
+// add records one run of the step.
+func (t *timing) add(d time.Duration) {
+	t.count++
+	t.last = d
+	t.total += d
+	t.max = max(t.max, d)
+}
+
+// average returns the mean duration of the step.
+func (t timing) average() time.Duration {
+	if t.count == 0 {
+		return 0
+	}
+	return t.total / time.Duration(t.count)
+}
+
+// statsTickMsg samples the runtime for the diagnostics view. at is when the
+// tick fired, so that the time it waited to be handled shows how far behind
+// the event loop is.
+type statsTickMsg struct {
+	at time.Time
+}
+
+// profilesWrittenMsg carries the directory profiles were written to.
+type profilesWrittenMsg struct {
+	dir string
+	err error
+}
+
+// statsTick schedules the next sample of the diagnostics view.
+func statsTick() tea.Cmd {
+	return tea.Tick(statsInterval, func(t time.Time) tea.Msg {
+		return statsTickMsg{at: t}
+	})
+}
+
+// openStats shows vaws's own memory use, event loop timings and cache sizes.
+func (m *Model) openStats() tea.Cmd {
+	if m.state.View != state.ViewStats {
+		m.state.StatsReturnView = m.state.View
+	}
+	m.state.View = state.ViewStats
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	m.updateStatsList()
+	if m.stats.ticking {
+		return nil
+	}
+	m.stats.ticking = true
+	return statsTick()
+}
+
+// closeStats returns to the view the diagnostics were opened from.
+func (m *Model) closeStats() {
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.state.View = m.state.StatsReturnView
+	m.updateCurrentList()
+}
+
+// handleStatsTick records how late the tick was and refreshes the view. The
+// ticks stop once the view is left.
+func (m *Model) handleStatsTick(msg statsTickMsg) tea.Cmd {
+	m.stats.lastLag = time.Since(msg.at)
+	m.stats.maxLag = max(m.stats.maxLag, m.stats.lastLag)
+	m.stats.rate = float64(m.stats.messages-m.stats.lastSample) / statsInterval.Seconds()
+	m.stats.lastSample = m.stats.messages
+
+	if m.state.View != state.ViewStats {
+		m.stats.ticking = false
+		return nil
+	}
+	m.updateStatsList()
+	return statsTick()
+}
+
+// resetStats clears the longest durations, so that they cover what happens
+// from now on.
+func (m *Model) resetStats() {
+	m.stats.maxLag = 0
+	m.stats.update = timing{}
+	m.stats.render = timing{}
+	m.logger.Info("Diagnostics maxima reset")
+	m.updateStatsList()
+}
+
+// statsCaches returns the number of entries held by the caches and buffers
+// that grow during a session.
+func (m *Model) statsCaches() [][2]string {
+	count := func(n int) string { return format.Count(int64(n)) }
+	tunnels := 0
+	if m.tunnelManager != nil {
+		tunnels += len(m.tunnelManager.GetTunnels())
+	}
+	if m.apiGWManager != nil {
+		tunnels += len(m.apiGWManager.GetTunnels())
+	}
+	items := 0
+	if m.state.DynamoDBQueryResult != nil {
+		items = len(m.state.DynamoDBQueryResult.Items)
+	}
+	return [][2]string{
+		{"Log panel lines", count(m.logs.Len())},
+		{"CloudWatch log events", count(len(m.state.CloudWatchLogs))},
+		{"Stacks", count(len(m.state.Stacks))},
+		{"Services", count(len(m.state.Services))},
+		{"Lambda functions", count(len(m.state.Functions))},
+		{"SQS queues", count(len(m.state.Queues))},
+		{"DynamoDB tables", count(len(m.state.Tables))},
+		{"DynamoDB items", count(items)},
+		{"Tunnels", count(tunnels)},
+		{"Tunnel probes", count(len(m.probes.results))},
+		{"Monitor panels", count(len(m.monitorPanels))},
+		{"Watches", count(len(m.alerts.watches))},
+		{"Notes", count(len(m.notes))},
+	}
+}
+
+// updateStatsList lists the diagnostics, grouped into sections.
+func (m *Model) updateStatsList() {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	d := m.stats
+	ms := func(t time.Duration) string {
+		return strconv.FormatFloat(float64(t.Microseconds())/1000, 'f', 1, 64) + " ms"
+	}
+
+	var items []components.ListItem
+	filter := strings.ToLower(m.state.FilterText)
+	section := func(title string, rows [][2]string) {
+		var matched []components.ListItem
+		for _, r := range rows {
+			if filter != "" && !strings.Contains(strings.ToLower(r[0]), filter) {
+				continue
+			}
+			matched = append(matched, components.ListItem{ID: "stat-" + r[0], Title: r[0], Status: r[1]})
+		}
+		if len(matched) == 0 {
+			return
+		}
+		items = append(items, components.ListItem{ID: "cat-" + title, Title: "── " + title + " ──", IsHeader: true})
+		items = append(items, matched...)
+	}
+
+	section("Runtime", [][2]string{
+		{"Uptime", format.Age(time.Since(d.started))},
+		{"Goroutines", format.Count(int64(runtime.NumGoroutine()))},
+		{"Heap in use", formatBytes(int64(mem.HeapInuse))},
+		{"Heap objects", format.Count(int64(mem.HeapObjects))},
+		{"Memory from OS", formatBytes(int64(mem.Sys))},
+		{"Allocated in total", formatBytes(int64(mem.TotalAlloc))},
+		{"GC cycles", format.Count(int64(mem.NumGC))},
+		{"Last GC pause", ms(time.Duration(mem.PauseNs[(mem.NumGC+255)%256]))},
+	})
+	section("Event loop", [][2]string{
+		{"Messages handled", format.Count(int64(d.messages))},
+		{"Messages per second", strconv.FormatFloat(d.rate, 'f', 1, 64)},
+		{"Queue delay", ms(d.lastLag)},
+		{"Queue delay (max)", ms(d.maxLag)},
+		{"Update time", ms(d.update.last)},
+		{"Update time (avg)", ms(d.update.average())},
+		{"Update time (max)", ms(d.update.max)},
+	})
+	section("Rendering", [][2]string{
+		{"Frames rendered", format.Count(int64(d.render.count))},
+		{"Render time", ms(d.render.last)},
+		{"Render time (avg)", ms(d.render.average())},
+		{"Render time (max)", ms(d.render.max)},
+	})
+	section("Caches", m.statsCaches())
+
+	m.statsList.SetItems(items)
+	m.statsList.SetError(nil)
+	m.statsList.SetEmptyMessage("No diagnostics match the filter")
+	m.updateStatsDetails()
+}
+
+// updateStatsDetails explains what the diagnostics measure and where
+// profiles are written.
+func (m *Model) updateStatsDetails() {
+	s := GetStyles()
+	m.details.SetTitle("Diagnostics")
+	profile := "-"
+	switch {
+	case m.stats.profiling:
+		profile = "recording CPU profile..."
+	case m.stats.lastProfile != "":
+		profile = m.stats.lastProfile
+	}
+	m.details.SetRows([]components.DetailRow{
+		{Label: "Queue delay", Value: "How late a 1s tick is handled; grows when messages queue up faster than they are handled"},
+		{Label: "Update time", Value: "Time spent handling one message"},
+		{Label: "Render time", Value: "Time spent drawing one frame"},
+		{Label: "", Value: ""},
+		{Label: "Profiles", Value: config.ProfilesDir(), Style: s.Muted},
+		{Label: "Last written", Value: profile},
+	})
+}
+
+// writeProfiles writes heap, goroutine and allocation profiles at once,
+// then records a CPU profile for statsCPUProfile, into a directory named
+// after the time. They open with go tool pprof.
+func (m *Model) writeProfiles() tea.Cmd {
+	if m.stats.profiling {
+		m.logger.Warn("A CPU profile is already being recorded")
+		return nil
+	}
+	dir := filepath.Join(config.ProfilesDir(), time.Now().Format("20060102-150405"))
+	m.stats.profiling = true
+	m.logger.Info("Writing profiles to %s; recording CPU for %s...", dir, statsCPUProfile)
+	m.updateStatsDetails()
+
+	return func() tea.Msg {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return profilesWrittenMsg{dir: dir, err: err}
+		}
+		for _, name := range []string{"heap", "goroutine", "allocs"} {
+			if err := writeProfile(filepath.Join(dir, name+".pprof"), func(f *os.File) error {
+				return pprof.Lookup(name).WriteTo(f, 0)
+			}); err != nil {
+				return profilesWrittenMsg{dir: dir, err: err}
+			}
+		}
+		err := writeProfile(filepath.Join(dir, "cpu.pprof"), func(f *os.File) error {
+			if err := pprof.StartCPUProfile(f); err != nil {
+				return err
+			}
+			time.Sleep(statsCPUProfile)
+			pprof.StopCPUProfile()
+			return nil
+		})
+		return profilesWrittenMsg{dir: dir, err: err}
+	}
+}
+
+// writeProfile creates a profile file and writes it with write.
+func writeProfile(path string, write func(*os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return f.Close()
+}
+
+// handleProfilesWritten logs where the profiles were written.
+func (m *Model) handleProfilesWritten(msg profilesWrittenMsg) {
+	m.stats.profiling = false
+	if msg.err != nil {
+		m.logger.Error("Failed to write profiles: %v", msg.err)
+	} else {
+		m.stats.lastProfile = msg.dir
+		m.logger.Info("Profiles written to %s (go tool pprof %s)", msg.dir, filepath.Join(msg.dir, "cpu.pprof"))
+	}
+	if m.state.View == state.ViewStats {
+		m.updateStatsDetails()
+	}
+}
@@ -24,13 +24,13 @@ func GetStyles() StyleSet {
 		ListTitle:    lipgloss.NewStyle().Bold(true).Foreground(t.Primary).MarginBottom(1),
 
 		// Details pane
-		Details:      lipgloss.NewStyle().Padding(1, 2).Border(lipgloss.RoundedBorder()).BorderForeground(t.Border),
+		Details:      lipgloss.NewStyle().Padding(1, 2).Border(theme.BorderStyle()).BorderForeground(t.Border),
 		DetailsTitle: lipgloss.NewStyle().Bold(true).Foreground(t.Primary).MarginBottom(1),
 		DetailsLabel: lipgloss.NewStyle().Foreground(t.TextMuted).Width(16),
 		DetailsValue: lipgloss.NewStyle().Foreground(t.Text),
 
 		// Logs panel
-		Logs:      lipgloss.NewStyle().Padding(0, 1).Border(lipgloss.RoundedBorder()).BorderForeground(t.Border),
+		Logs:      lipgloss.NewStyle().Padding(0, 1).Border(theme.BorderStyle()).BorderForeground(t.Border),
 		LogsTitle: lipgloss.NewStyle().Bold(true).Foreground(t.TextMuted),
 
 		// Status indicators
@@ -145,6 +145,41 @@ func ServiceStatusStyle(running, desired int) lipgloss.Style {
 	}
 }
 
+// TaskStatusStyle returns the style of a task's status: healthy while it
+// runs and passes its health checks, an error if it failed.
+func TaskStatusStyle(t model.Task) lipgloss.Style {
+	s := GetStyles()
+	switch t.LastStatus {
+	case "RUNNING":
+		if t.HealthStatus == "UNHEALTHY" {
+			return s.StatusWarning
+		}
+		return s.StatusHealthy
+	case "PROVISIONING", "PENDING", "ACTIVATING":
+		return s.StatusInProgress
+	case "STOPPED":
+		if taskFailed(t) {
+			return s.StatusError
+		}
+		return s.Muted
+	}
+	return s.StatusWarning
+}
+
+// ContainerHealthStyle returns the style of a container's status and health.
+func ContainerHealthStyle(c model.Container) lipgloss.Style {
+	s := GetStyles()
+	switch {
+	case c.HealthStatus == "UNHEALTHY", c.ExitCode != nil && *c.ExitCode != 0:
+		return s.StatusError
+	case c.LastStatus == "RUNNING":
+		return s.StatusHealthy
+	case c.LastStatus == "PENDING":
+		return s.StatusInProgress
+	}
+	return s.Muted
+}
+
 // FunctionStatusStyle returns the appropriate style for a Lambda function state.
 func FunctionStatusStyle(state model.FunctionState) lipgloss.Style {
 	s := GetStyles()
@@ -181,6 +216,221 @@ func TableStatusStyle(status model.TableStatus) lipgloss.Style {
 	}
 }
 
+// AppRunnerStatusStyle returns the appropriate style for an App Runner service status.
+func AppRunnerStatusStyle(status model.AppRunnerStatus) lipgloss.Style {
+	s := GetStyles()
+	switch {
+	case status.IsHealthy():
+		return s.StatusHealthy
+	case status == model.AppRunnerStatusInProgress:
+		return s.StatusInProgress
+	case status == model.AppRunnerStatusPaused:
+		return s.StatusWarning
+	case status.IsFailed():
+		return s.StatusError
+	default:
+		return s.Muted
+	}
+}
+
+// DeliveryStreamStatusStyle returns the appropriate style for a Firehose delivery stream status.
+func DeliveryStreamStatusStyle(status model.DeliveryStreamStatus) lipgloss.Style {
+	s := GetStyles()
+	switch {
+	case status == model.DeliveryStreamStatusActive:
+		return s.StatusHealthy
+	case status == model.DeliveryStreamStatusCreating || status == model.DeliveryStreamStatusDeleting:
+		return s.StatusInProgress
+	case status.IsFailed():
+		return s.StatusError
+	default:
+		return s.Muted
+	}
+}
+
+// MSKClusterStateStyle returns the appropriate style for an MSK cluster state.
+func MSKClusterStateStyle(state model.MSKClusterState) lipgloss.Style {
+	s := GetStyles()
+	switch state {
+	case model.MSKClusterStateActive:
+		return s.StatusHealthy
+	case model.MSKClusterStateCreating, model.MSKClusterStateUpdating, model.MSKClusterStateDeleting,
+		model.MSKClusterStateHealing, model.MSKClusterStateMaintenance:
+		return s.StatusInProgress
+	case model.MSKClusterStateFailed:
+		return s.StatusError
+	default:
+		return s.Muted
+	}
+}
+
+// MQBrokerStateStyle returns the appropriate style for an MQ broker state.
+func MQBrokerStateStyle(state model.MQBrokerState) lipgloss.Style {
+	s := GetStyles()
+	switch state {
+	case model.MQBrokerStateRunning:
+		return s.StatusHealthy
+	case model.MQBrokerStateCreating, model.MQBrokerStateDeleting, model.MQBrokerStateRebooting:
+		return s.StatusInProgress
+	case model.MQBrokerStateCreationFailed, model.MQBrokerStateActionRequired:
+		return s.StatusError
+	default:
+		return s.Muted
+	}
+}
+
+// DatabaseStatusStyle returns the appropriate style for the status of an
+// RDS instance or cluster, RDS proxy or Redshift cluster.
+func DatabaseStatusStyle(status string) lipgloss.Style {
+	s := GetStyles()
+	switch status {
+	case "available":
+		return s.StatusHealthy
+	case "creating", "modifying", "backing-up", "rebooting", "starting", "stopping", "upgrading", "maintenance",
+		"renaming", "resizing", "rotating-keys", "updating", "deleting":
+		return s.StatusInProgress
+	case "failed", "incompatible-network", "incompatible-parameters", "incompatible-restore", "inaccessible-encryption-credentials",
+		"storage-full", "incompatible-credentials", "insufficient-resource-limits", "hardware-failure":
+		return s.StatusError
+	default:
+		return s.Muted
+	}
+}
+
+// InstanceStateStyle returns the appropriate style for the state of an EC2
+// instance.
+func InstanceStateStyle(state string) lipgloss.Style {
+	s := GetStyles()
+	switch state {
+	case "running":
+		return s.StatusHealthy
+	case "pending", "stopping", "shutting-down":
+		return s.StatusInProgress
+	default:
+		return s.Muted
+	}
+}
+
+// EFSStateStyle returns the appropriate style for the life cycle state of an
+// EFS file system, mount target or access point.
+func EFSStateStyle(state string) lipgloss.Style {
+	s := GetStyles()
+	switch state {
+	case "available":
+		return s.StatusHealthy
+	case "creating", "updating", "deleting":
+		return s.StatusInProgress
+	case "error":
+		return s.StatusError
+	default:
+		return s.Muted
+	}
+}
+
+// ScheduleStateStyle returns the appropriate style for a schedule state.
+func ScheduleStateStyle(state model.ScheduleState) lipgloss.Style {
+	s := GetStyles()
+	switch state {
+	case model.ScheduleStateEnabled:
+		return s.StatusHealthy
+	case model.ScheduleStateDisabled:
+		return s.StatusWarning
+	default:
+		return s.Muted
+	}
+}
+
+// SecretRotationStyle returns the appropriate style for how a secret's
+// rotation stands.
+func SecretRotationStyle(rotation model.SecretRotation) lipgloss.Style {
+	s := GetStyles()
+	switch rotation {
+	case model.SecretRotationOK:
+		return s.StatusHealthy
+	case model.SecretRotationOverdue:
+		return s.StatusError
+	case model.SecretRotationNever, model.SecretRotationStale:
+		return s.StatusWarning
+	default:
+		return s.Muted
+	}
+}
+
+// CognitoUserStatusStyle returns the appropriate style for a Cognito user's account status.
+func CognitoUserStatusStyle(user model.CognitoUser) lipgloss.Style {
+	s := GetStyles()
+	switch {
+	case !user.Enabled || user.Status == model.CognitoUserStatusCompromised:
+		return s.StatusError
+	case user.Status == model.CognitoUserStatusConfirmed || user.Status == model.CognitoUserStatusExternalProvider:
+		return s.StatusHealthy
+	case user.Status == model.CognitoUserStatusUnconfirmed || user.Status == model.CognitoUserStatusResetRequired ||
+		user.Status == model.CognitoUserStatusForceChangePassword:
+		return s.StatusWarning
+	default:
+		return s.Muted
+	}
+}
+
+// SES reputation rates at which AWS places an account under review.
+const (
+	sesBounceReviewRate    = 0.05
+	sesComplaintReviewRate = 0.001
+)
+
+// SESEnforcementStyle returns the appropriate style for an SES account's enforcement status.
+func SESEnforcementStyle(account model.SESAccount) lipgloss.Style {
+	s := GetStyles()
+	switch {
+	case account.EnforcementStatus == "SHUTDOWN" || !account.SendingEnabled:
+		return s.StatusError
+	case account.EnforcementStatus == "PROBATION":
+		return s.StatusWarning
+	case account.EnforcementStatus == "HEALTHY":
+		return s.StatusHealthy
+	default:
+		return s.Muted
+	}
+}
+
+// SESVerificationStyle returns the appropriate style for an SES identity's verification status.
+func SESVerificationStyle(identity model.SESIdentity) lipgloss.Style {
+	s := GetStyles()
+	switch identity.VerificationStatus {
+	case "SUCCESS":
+		return s.StatusHealthy
+	case "PENDING":
+		return s.StatusInProgress
+	case "FAILED", "TEMPORARY_FAILURE":
+		return s.StatusError
+	default:
+		return s.Muted
+	}
+}
+
+// SESRateStyle returns the style for a reputation rate: error at the review
+// threshold, warning from half of it.
+func SESRateStyle(rate, reviewAt float64) lipgloss.Style {
+	s := GetStyles()
+	switch {
+	case rate >= reviewAt:
+		return s.StatusError
+	case rate >= reviewAt/2:
+		return s.StatusWarning
+	default:
+		return s.StatusHealthy
+	}
+}
+
+// SESSuppressionReasonStyle returns the style for the reason an address was suppressed.
+func SESSuppressionReasonStyle(reason string) lipgloss.Style {
+	s := GetStyles()
+	if reason == "COMPLAINT" {
+		return s.StatusError
+	}
+	return s.StatusWarning
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && findSubstring(s, substr) >= 0
 }
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+)
+
+// tagScope tracks reading the resources that carry the tag filter of the
+// state. The filter itself lives in the state, next to what it scopes.
+type tagScope struct {
+	last    model.TagFilter // Filter :tag without arguments turns back on
+	loading bool
+	stale   bool // Read again in the background, e.g. after r
+	gen     int  // Bumped per read, so reads for another region are dropped
+}
+
+// tagFilterLoadedMsg carries the ARNs of the resources carrying a tag.
+type tagFilterLoadedMsg struct {
+	gen    int
+	filter model.TagFilter
+	arns   []string
+	err    error
+}
+
+// parseTagFilter reads a tag filter typed as Key=value, Key=value1,value2
+// or just Key for any value.
+func parseTagFilter(s string) (model.TagFilter, error) {
+	key, values, hasValues := strings.Cut(s, "=")
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return model.TagFilter{}, fmt.Errorf("no tag key in %q", s)
+	}
+	f := model.TagFilter{Key: key}
+	if hasValues {
+		for _, v := range strings.Split(values, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				f.Values = append(f.Values, v)
+			}
+		}
+		if len(f.Values) == 0 {
+			return model.TagFilter{}, fmt.Errorf("no value for tag %s", key)
+		}
+	}
+	return f, nil
+}
+
+// handleTagCommand runs :tag. Key=value scopes every list to the resources
+// carrying the tag, no argument turns the filter off and back on, and clear
+// drops it.
+func (m *Model) handleTagCommand(args []string) {
+	switch {
+	case len(args) == 0 && m.state.TagFilter.Key != "":
+		m.tags.last = m.state.TagFilter
+		m.setTagFilter(model.TagFilter{})
+		m.logger.Info("Tag filter %s off, :tag turns it back on", m.tags.last)
+	case len(args) == 0 && m.tags.last.Key == "":
+		m.logger.Warn("Usage: :tag Key=value, e.g. :tag Environment=prod")
+	case len(args) == 0:
+		m.setTagFilter(m.tags.last)
+	case len(args) == 1 && args[0] == "clear":
+		m.tags.last = model.TagFilter{}
+		m.setTagFilter(model.TagFilter{})
+		m.logger.Info("Tag filter cleared")
+	default:
+		f, err := parseTagFilter(strings.Join(args, " "))
+		if err != nil {
+			m.logger.Warn("Invalid tag filter: %v", err)
+			return
+		}
+		m.tags.last = f
+		m.setTagFilter(f)
+	}
+}
+
+// setTagFilter scopes the lists to the resources carrying f, none until
+// they are read, or removes the scope if f has no key.
+func (m *Model) setTagFilter(f model.TagFilter) {
+	m.state.TagFilter = f
+	m.state.TaggedARNs = nil
+	m.tags.loading = false
+	m.tags.stale = false
+	m.updateCurrentList()
+}
+
+// loadTaggedResources reads the resources carrying the tag filter when they
+// aren't known for the region, or are stale. Like describeSelectedStack it
+// runs after every message.
+func (m *Model) loadTaggedResources() tea.Cmd {
+	f := m.state.TagFilter
+	if f.Key == "" || m.client == nil || m.tags.loading || (m.state.TaggedARNs != nil && !m.tags.stale) {
+		return nil
+	}
+	m.tags.loading = true
+	m.tags.stale = false
+	m.tags.gen++
+	client, gen := m.client, m.tags.gen
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		arns, err := client.GetTaggedResources(ctx, f)
+		return tagFilterLoadedMsg{gen: gen, filter: f, arns: arns, err: err}
+	}
+}
+
+// handleTagFilterLoaded scopes the lists to the tagged resources. A filter
+// that can't be read is dropped rather than leaving every list empty.
+func (m *Model) handleTagFilterLoaded(msg tagFilterLoadedMsg) {
+	if msg.gen != m.tags.gen || msg.filter.String() != m.state.TagFilter.String() {
+		return
+	}
+	m.tags.loading = false
+	if msg.err != nil {
+		m.logger.Error("Failed to read resources tagged %s, showing all resources: %v", msg.filter, msg.err)
+		m.state.TagFilter = model.TagFilter{}
+		m.state.TaggedARNs = nil
+		m.updateCurrentList()
+		return
+	}
+	tagged := make(map[string]bool, len(msg.arns))
+	for _, arn := range msg.arns {
+		tagged[arn] = true
+	}
+	if m.state.TaggedARNs == nil {
+		m.logger.Info("Scoped lists to the %d resources tagged %s", len(tagged), msg.filter)
+	}
+	m.state.TaggedARNs = tagged
+	m.updateCurrentList()
+}
+
+// resetTaggedResources forgets the tagged resources of the previous profile
+// or region, so they are read again for the new one.
+func (m *Model) resetTaggedResources() {
+	m.state.TaggedARNs = nil
+	m.tags.loading = false
+	m.tags.stale = false
+}
+
+// tagFilterStatus returns the header indicator of the tag filter, or "" if
+// there is none.
+func (m *Model) tagFilterStatus() string {
+	f := m.state.TagFilter
+	if f.Key == "" {
+		return ""
+	}
+	if m.state.TaggedARNs == nil {
+		return f.String() + " (reading tags)"
+	}
+	return f.String()
+}
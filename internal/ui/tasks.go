@@ -0,0 +1,586 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/theme"
+)
+
+// serviceTasksLoadedMsg carries the running and recently stopped tasks of a
+// service. stoppedErr is set if only the stopped ones couldn't be listed.
+type serviceTasksLoadedMsg struct {
+	service    string
+	tasks      []model.Task
+	err        error
+	stoppedErr error
+}
+
+// taskContainerColumns are the columns of a task's containers list.
+var taskContainerColumns = []components.Column{
+	{Key: "health", Title: "HEALTH", Width: 9},
+	{Key: "exit", Title: "EXIT", Width: 4, Right: true},
+}
+
+// taskDetailsDialog shows everything known about a task: its settings, its
+// status transitions, why it stopped and the state of each container.
+type taskDetailsDialog struct {
+	task   model.Task
+	scroll int
+}
+
+// openServiceTasks opens the tasks view on the selected service, or reloads
+// it for the service it is open on.
+func (m *Model) openServiceTasks() tea.Cmd {
+	switch m.state.View {
+	case state.ViewServices:
+		svc := m.selectedService()
+		if svc == nil {
+			return nil
+		}
+		selected := *svc
+		m.state.SelectService(&selected)
+	case state.ViewTasks, state.ViewTaskContainers:
+	default:
+		m.logger.Warn("Select an ECS service to list its tasks")
+		return nil
+	}
+	if m.state.SelectedService == nil {
+		return nil
+	}
+
+	m.state.ClearTasks()
+	m.state.View = state.ViewTasks
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	return m.loadTasks()
+}
+
+// loadTasks loads the running and recently stopped tasks of the selected
+// service.
+func (m *Model) loadTasks() tea.Cmd {
+	svc := m.state.SelectedService
+	if svc == nil {
+		return nil
+	}
+	m.state.TasksLoading = true
+	m.tasksList.SetLoading(true)
+	m.logger.Info("Loading tasks of %s...", svc.Name)
+
+	client, cluster, name := m.client, svc.ClusterARN, svc.Name
+	return tea.Batch(
+		m.tasksList.Spinner().TickCmd(),
+		func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			msg := serviceTasksLoadedMsg{service: name}
+			msg.tasks, msg.err = client.ListTasksForService(ctx, cluster, name)
+			if msg.err != nil {
+				return msg
+			}
+			var stopped []model.Task
+			stopped, msg.stoppedErr = client.ListStoppedTasksForService(ctx, cluster, name)
+			msg.tasks = append(msg.tasks, stopped...)
+			return msg
+		},
+	)
+}
+
+// handleTasksLoaded shows loaded tasks, if the view is still on their
+// service: the running ones first, then the stopped ones, newest first.
+func (m *Model) handleTasksLoaded(msg serviceTasksLoadedMsg) {
+	if m.state.View != state.ViewTasks && m.state.View != state.ViewTaskContainers {
+		return
+	}
+	if m.state.SelectedService == nil || m.state.SelectedService.Name != msg.service {
+		return
+	}
+	m.state.TasksLoading = false
+	m.refreshIndicator.SetRefreshing(false)
+	if msg.err != nil {
+		m.state.TasksError = msg.err
+		m.logger.Error("Failed to load tasks of %s: %v", msg.service, msg.err)
+		m.updateTasksList()
+		return
+	}
+	if msg.stoppedErr != nil {
+		m.logger.Warn("Listed the running tasks of %s only; stopped tasks: %v", msg.service, msg.stoppedErr)
+	}
+
+	tasks := msg.tasks
+	sort.SliceStable(tasks, func(i, j int) bool {
+		si, sj := tasks[i].LastStatus == "STOPPED", tasks[j].LastStatus == "STOPPED"
+		if si != sj {
+			return sj
+		}
+		return taskTime(tasks[i]).After(taskTime(tasks[j]))
+	})
+	m.state.Tasks = tasks
+	m.state.TasksError = nil
+	m.logger.Info("Loaded %d tasks of %s", len(tasks), msg.service)
+
+	// Keep the containers view on the same task
+	if t := m.state.SelectedTask; t != nil {
+		m.state.SelectTask(nil)
+		for i := range m.state.Tasks {
+			if m.state.Tasks[i].TaskARN == t.TaskARN {
+				m.state.SelectTask(&m.state.Tasks[i])
+			}
+		}
+		if m.state.SelectedTask == nil && m.state.View == state.ViewTaskContainers {
+			m.state.View = state.ViewTasks
+		}
+	}
+	m.updateTasksList()
+	if m.state.View == state.ViewTaskContainers {
+		m.updateTaskContainersList()
+	}
+}
+
+// taskTime returns when a task stopped, or started if it hasn't, or was
+// created if it hasn't started yet.
+func taskTime(t model.Task) time.Time {
+	switch {
+	case !t.StoppedAt.IsZero():
+		return t.StoppedAt
+	case !t.StartedAt.IsZero():
+		return t.StartedAt
+	}
+	return t.CreatedAt
+}
+
+// selectedTask returns the task under the cursor of the tasks view.
+func (m *Model) selectedTask() *model.Task {
+	item := m.tasksList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	for i := range m.state.Tasks {
+		if m.state.Tasks[i].TaskARN == item.ID {
+			return &m.state.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// selectedTaskContainer returns the container under the cursor of the task
+// containers view.
+func (m *Model) selectedTaskContainer() *model.Container {
+	item := m.taskContainersList.SelectedItem()
+	if item == nil || m.state.SelectedTask == nil {
+		return nil
+	}
+	for i := range m.state.SelectedTask.Containers {
+		if m.state.SelectedTask.Containers[i].Name == item.ID {
+			return &m.state.SelectedTask.Containers[i]
+		}
+	}
+	return nil
+}
+
+// openTaskContainers opens the containers of the selected task.
+func (m *Model) openTaskContainers() tea.Cmd {
+	t := m.selectedTask()
+	if t == nil {
+		return nil
+	}
+	m.state.SelectTask(t)
+	m.state.View = state.ViewTaskContainers
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.updateTaskContainersList()
+	return nil
+}
+
+// openTaskDetails opens the full details of the selected task, or of the
+// task whose containers are shown.
+func (m *Model) openTaskDetails() tea.Cmd {
+	t := m.selectedTask()
+	if m.state.View == state.ViewTaskContainers {
+		t = m.state.SelectedTask
+	}
+	if t == nil {
+		return nil
+	}
+	m.taskDetails = &taskDetailsDialog{task: *t}
+	return nil
+}
+
+// handleTaskDetailsKey handles key messages while the task details are
+// open.
+func (m *Model) handleTaskDetailsKey(msg tea.KeyMsg) tea.Cmd {
+	d := m.taskDetails
+	switch msg.String() {
+	case "esc", "q", "d":
+		m.taskDetails = nil
+	case "up", "k":
+		if d.scroll > 0 {
+			d.scroll--
+		}
+	case "down", "j":
+		d.scroll++
+	case "g":
+		d.scroll = 0
+	case "ctrl+c":
+		m.tunnelManager.StopAllTunnels()
+		return tea.Quit
+	}
+	return nil
+}
+
+// taskFailed reports whether a stopped task failed rather than being stopped:
+// it couldn't start, or an essential container exited with an error.
+func taskFailed(t model.Task) bool {
+	if t.StopCode == "TaskFailedToStart" {
+		return true
+	}
+	for _, c := range t.Containers {
+		if c.Essential && c.ExitCode != nil && *c.ExitCode != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// taskHealthSummary sums up the health checks of a task's containers, e.g.
+// "2/2 healthy", or "no health checks" if none has one.
+func taskHealthSummary(t model.Task) string {
+	if cell := taskHealthCell(t); cell != "" {
+		return cell + " healthy"
+	}
+	return "no health checks"
+}
+
+// taskHealthCell is the health column of a task: how many of the
+// containers with a health check pass it, e.g. "2/2", "" if none has one.
+func taskHealthCell(t model.Task) string {
+	checked, healthy := 0, 0
+	for _, c := range t.Containers {
+		switch c.HealthStatus {
+		case "HEALTHY":
+			checked++
+			healthy++
+		case "UNHEALTHY":
+			checked++
+		}
+	}
+	if checked == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", healthy, checked)
+}
+
+// containerHealth describes a container's health check, "-" without one.
+func containerHealth(c model.Container) string {
+	if c.HealthStatus == "" || c.HealthStatus == "UNKNOWN" {
+		return "-"
+	}
+	return strings.ToLower(c.HealthStatus)
+}
+
+// taskSummary is the list line of a task: how it runs and since when, or
+// when and why it stopped.
+func taskSummary(t model.Task, now time.Time) string {
+	parts := []string{valueOrDash(t.LaunchType), valueOrDash(t.AvailabilityZone)}
+	switch {
+	case t.LastStatus == "STOPPED":
+		parts = append(parts, "stopped "+format.Relative(now.Sub(t.StoppedAt)))
+		if t.StopCode != "" {
+			parts = append(parts, t.StopCode)
+		}
+		return strings.Join(parts, " · ")
+	case !t.StartedAt.IsZero():
+		parts = append(parts, "started "+format.Relative(now.Sub(t.StartedAt)))
+	default:
+		parts = append(parts, "not started")
+	}
+	return strings.Join(append(parts, taskHealthSummary(t)), " · ")
+}
+
+// containerSummary is the list line of a container: its health, and how it
+// exited if it did.
+func containerSummary(c model.Container) string {
+	parts := []string{"health " + containerHealth(c)}
+	if c.ExitCode != nil {
+		parts = append(parts, fmt.Sprintf("exit %d", *c.ExitCode))
+	}
+	if !c.Essential {
+		parts = append(parts, "not essential")
+	}
+	parts = append(parts, shortImage(c.Image))
+	return strings.Join(parts, " · ")
+}
+
+// shortImage drops the registry of an image reference, e.g.
+// "web:1.4" for "123456789012.dkr.ecr.eu-west-1.amazonaws.com/web:1.4".
+func shortImage(image string) string {
+	return image[strings.LastIndex(image, "/")+1:]
+}
+
+// updateTasksList updates the tasks list with current data.
+func (m *Model) updateTasksList() {
+	tasks := m.state.FilteredTasks()
+	now := time.Now()
+	items := make([]components.ListItem, len(tasks))
+	for i, t := range tasks {
+		items[i] = components.ListItem{
+			ID:          t.TaskARN,
+			Title:       t.TaskID,
+			Description: taskSummary(t, now),
+			Status:      t.LastStatus,
+			StatusStyle: TaskStatusStyle(t),
+			Cells:       taskCells(t, now),
+		}
+	}
+	m.tasksList.SetColumns(m.columnsFor("tasks"))
+	m.tasksList.SetItems(items)
+	m.tasksList.SetLoading(false)
+	m.tasksList.SetError(m.state.TasksError)
+	m.tasksList.SetEmptyMessage("No running or recently stopped tasks")
+	m.updateTaskDetails()
+}
+
+// updateTaskContainersList updates the containers list of the selected task.
+func (m *Model) updateTaskContainersList() {
+	containers := m.state.FilteredTaskContainers()
+	items := make([]components.ListItem, len(containers))
+	for i, c := range containers {
+		exit := ""
+		if c.ExitCode != nil {
+			exit = fmt.Sprintf("%d", *c.ExitCode)
+		}
+		items[i] = components.ListItem{
+			ID:          c.Name,
+			Title:       c.Name,
+			Description: containerSummary(c),
+			Status:      c.LastStatus,
+			StatusStyle: ContainerHealthStyle(c),
+			Cells:       map[string]string{"health": containerHealth(c), "exit": exit},
+		}
+	}
+	m.taskContainersList.SetColumns(taskContainerColumns)
+	m.taskContainersList.SetItems(items)
+	m.taskContainersList.SetLoading(false)
+	m.taskContainersList.SetEmptyMessage("No containers")
+	m.updateTaskContainerDetails()
+}
+
+// updateTaskDetails updates the details panel with the selected task.
+func (m *Model) updateTaskDetails() {
+	t := m.selectedTask()
+	m.details.SetTitle("Task")
+	if t == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	st := GetStyles()
+	rows := []components.DetailRow{
+		{Label: "Task ID", Value: t.TaskID},
+		{Label: "Status", Value: t.LastStatus + " (desired " + t.DesiredStatus + ")", Style: TaskStatusStyle(*t)},
+		{Label: "Health", Value: taskHealthSummary(*t)},
+		{Label: "Launch Type", Value: valueOrDash(t.LaunchType)},
+		{Label: "Zone", Value: valueOrDash(t.AvailabilityZone)},
+		{Label: "Started", Value: format.Time(t.StartedAt)},
+		{Label: "Task Definition", Value: valueOrDash(shortTaskDefinition(t.TaskDefinitionARN))},
+		{Label: "Private IP", Value: valueOrDash(t.PrivateIP)},
+	}
+	if t.LastStatus == "STOPPED" {
+		style := st.Muted
+		if taskFailed(*t) {
+			style = st.StatusError
+		}
+		rows = append(rows,
+			components.DetailRow{Label: "Stopped", Value: format.Time(t.StoppedAt)},
+			components.DetailRow{Label: "Stop Code", Value: valueOrDash(t.StopCode), Style: style},
+			components.DetailRow{Label: "Stopped Reason", Value: valueOrDash(t.StoppedReason), Style: style},
+		)
+	}
+
+	rows = append(rows,
+		components.DetailRow{Label: "", Value: ""}, // Spacer
+		components.DetailRow{Label: "Containers", Value: fmt.Sprintf("%d (enter to list, d for full details)", len(t.Containers))},
+	)
+	for _, c := range t.Containers {
+		rows = append(rows, components.DetailRow{
+			Label: "  " + c.Name,
+			Value: strings.ToLower(c.LastStatus) + " · health " + containerHealth(c),
+			Style: ContainerHealthStyle(c),
+		})
+	}
+	m.details.SetRows(rows)
+}
+
+// updateTaskContainerDetails updates the details panel with the selected
+// container of a task.
+func (m *Model) updateTaskContainerDetails() {
+	c := m.selectedTaskContainer()
+	m.details.SetTitle("Container")
+	if c == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	exit := "-"
+	if c.ExitCode != nil {
+		exit = fmt.Sprintf("%d", *c.ExitCode)
+	}
+	var ports []string
+	for _, p := range c.GetExposedPorts() {
+		ports = append(ports, fmt.Sprintf("%d", p))
+	}
+	essential := "yes"
+	if !c.Essential {
+		essential = "no"
+	}
+
+	rows := []components.DetailRow{
+		{Label: "Name", Value: c.Name},
+		{Label: "Status", Value: c.LastStatus, Style: ContainerHealthStyle(*c)},
+		{Label: "Health", Value: containerHealth(*c)},
+		{Label: "Essential", Value: essential},
+		{Label: "Exit Code", Value: exit},
+		{Label: "Reason", Value: valueOrDash(c.Reason)},
+		{Label: "", Value: ""}, // Spacer
+		{Label: "Image", Value: valueOrDash(c.Image)},
+		{Label: "Ports", Value: valueOrDash(strings.Join(ports, ", "))},
+		{Label: "CPU", Value: valueOrDash(c.CPU)},
+		{Label: "Memory", Value: valueOrDash(c.Memory)},
+		{Label: "Runtime ID", Value: valueOrDash(c.RuntimeID)},
+		{Label: "ARN", Value: valueOrDash(c.ContainerARN)},
+	}
+	m.details.SetRows(rows)
+}
+
+// taskDetailLines returns the lines of the task details dialog.
+func taskDetailLines(t model.Task, width int) []string {
+	s := GetStyles()
+	labelStyle := lipgloss.NewStyle().Foreground(theme.TextDim)
+	headerStyle := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+
+	row := func(label, value string, style lipgloss.Style) string {
+		return labelStyle.Render(fmt.Sprintf("%-17s", label)) + style.Render(truncateString(value, max(width-17, 10)))
+	}
+	plain := lipgloss.NewStyle().Foreground(theme.Text)
+
+	lines := []string{
+		row("Status", t.LastStatus+" (desired "+t.DesiredStatus+")", TaskStatusStyle(t)),
+		row("Health", valueOrDash(t.HealthStatus)+" · "+taskHealthSummary(t), plain),
+		row("Launch Type", valueOrDash(t.LaunchType), plain),
+		row("Zone", valueOrDash(t.AvailabilityZone), plain),
+		row("CPU / Memory", valueOrDash(t.CPU)+" / "+valueOrDash(t.Memory)+" MiB", plain),
+		row("Group", valueOrDash(t.Group), plain),
+		row("Private IP", valueOrDash(t.PrivateIP), plain),
+		row("Subnet", valueOrDash(t.SubnetID), plain),
+		row("Task Definition", valueOrDash(shortTaskDefinition(t.TaskDefinitionARN)), plain),
+		row("ARN", t.TaskARN, plain),
+	}
+
+	if t.LastStatus == "STOPPED" || t.StoppedReason != "" {
+		style := s.Muted
+		if taskFailed(t) {
+			style = s.StatusError
+		}
+		lines = append(lines, "", headerStyle.Render("Stopped"))
+		lines = append(lines, row("Stop Code", valueOrDash(t.StopCode), style))
+		for i, part := range wrapText(valueOrDash(t.StoppedReason), max(width-17, 10)) {
+			label := ""
+			if i == 0 {
+				label = "Reason"
+			}
+			lines = append(lines, row(label, part, style))
+		}
+	}
+
+	lines = append(lines, "", headerStyle.Render("Transitions"))
+	transitions := t.Transitions()
+	if len(transitions) == 0 {
+		lines = append(lines, s.Muted.Render("None recorded"))
+	}
+	var prev time.Time
+	for _, tr := range transitions {
+		value := format.Absolute(tr.At)
+		if !prev.IsZero() {
+			value += " (+" + format.Age(tr.At.Sub(prev)) + ")"
+		}
+		lines = append(lines, row(tr.Status, value, plain))
+		prev = tr.At
+	}
+
+	lines = append(lines, "", headerStyle.Render("Containers"))
+	for _, c := range t.Containers {
+		status := strings.ToLower(c.LastStatus) + " · health " + containerHealth(c)
+		if c.ExitCode != nil {
+			status += fmt.Sprintf(" · exit %d", *c.ExitCode)
+		}
+		lines = append(lines, row(c.Name, status, ContainerHealthStyle(c)))
+		if c.Reason != "" {
+			lines = append(lines, row("", c.Reason, s.StatusError))
+		}
+		lines = append(lines, row("", shortImage(c.Image), s.Muted))
+	}
+	return lines
+}
+
+// wrapText splits text into lines of at most width characters, at spaces
+// where it can.
+func wrapText(text string, width int) []string {
+	var lines []string
+	for len(text) > width {
+		cut := strings.LastIndex(text[:width], " ")
+		if cut <= 0 {
+			cut = width
+		}
+		lines = append(lines, text[:cut])
+		text = strings.TrimLeft(text[cut:], " ")
+	}
+	return append(lines, text)
+}
+
+// renderTaskDetailsDialog renders the full details of a task, scrolled to
+// fit the screen.
+func (m *Model) renderTaskDetailsDialog() string {
+	d := m.taskDetails
+	dialogWidth := 90
+	if m.width < 100 {
+		dialogWidth = max(m.width-10, 40)
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	lines := taskDetailLines(d.task, dialogWidth-6)
+	height := max(m.container.ContentHeight()-10, 5)
+	d.scroll = min(d.scroll, max(len(lines)-height, 0))
+	visible := lines[d.scroll:min(d.scroll+height, len(lines))]
+
+	title := titleStyle.Render("Task " + d.task.TaskID)
+	hint := "↑/↓ scroll · esc to close"
+	if len(lines) > height {
+		hint = fmt.Sprintf("%d-%d of %d · %s", d.scroll+1, d.scroll+len(visible), len(lines), hint)
+	}
+	content := title + "\n\n" + strings.Join(visible, "\n") + "\n\n" + hintStyle.Render(hint)
+	return dialogStyle.Render(content)
+}
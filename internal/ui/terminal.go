@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/ui/theme"
+)
+
+// tunnelWatchInterval is how often tunnel processes are checked for exits.
+const tunnelWatchInterval = 5 * time.Second
+
+// tunnelWatchTickMsg triggers a check for tunnels that died.
+type tunnelWatchTickMsg struct{}
+
+// terminalState holds the last title sent to the terminal and what was in
+// progress at the last check, so the end of it can be notified once.
+type terminalState struct {
+	title       string
+	tunnels     map[string]model.TunnelStatus // Tunnel ID -> status
+	deployments map[string]string             // ECS service ARN -> primary deployment ID while rolling out
+	appRunner   map[string]bool               // App Runner service ARN -> operation in progress
+}
+
+// terminalTitle returns the window title for the current profile, region and view.
+func (m *Model) terminalTitle() string {
+	parts := []string{"vaws"}
+	if m.state.Profile != "" || m.state.Region != "" {
+		parts = append(parts, strings.Trim(m.state.Profile+"/"+m.state.Region, "/"))
+	}
+	if title := m.container.Title(); title != "" {
+		parts = append(parts, title)
+	}
+	return strings.Join(parts, theme.Symbol(" · ", " - "))
+}
+
+// syncTerminalTitle returns a command setting the terminal title if it changed.
+// Inside tmux the same sequence sets the pane title.
+func (m *Model) syncTerminalTitle() tea.Cmd {
+	if m.cfg != nil && m.cfg.Terminal.NoTitle {
+		return nil
+	}
+	title := m.terminalTitle()
+	if title == m.term.title {
+		return nil
+	}
+	m.term.title = title
+	return tea.SetWindowTitle(title)
+}
+
+// notify sends a desktop notification through the terminal.
+func (m *Model) notify(title, body string) tea.Cmd {
+	mode := config.NotifyOSC9
+	if m.cfg != nil {
+		mode = m.cfg.GetNotifyMode()
+	}
+	seq := notificationSequence(mode, title, body, os.Getenv("TMUX") != "")
+	if seq == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		// One write, so it cannot land in the middle of a rendered frame
+		_, _ = os.Stdout.WriteString(seq)
+		return nil
+	}
+}
+
+// notificationSequence builds the escape sequence of a notification.
+// tmux drops unknown sequences unless they are wrapped for passthrough, which
+// also needs "set -g allow-passthrough on".
+func notificationSequence(mode, title, body string, tmux bool) string {
+	var seq string
+	switch mode {
+	case config.NotifyOSC9:
+		seq = "\x1b]9;" + oscText(title+": "+body) + "\x07"
+	case config.NotifyOSC777:
+		seq = "\x1b]777;notify;" + strings.ReplaceAll(oscText(title), ";", ",") + ";" + oscText(body) + "\x07"
+	default:
+		return ""
+	}
+	if tmux {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
+
+// oscText strips control characters that would end an OSC sequence early.
+func oscText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// tunnelWatchTick schedules the next tunnel check.
+func tunnelWatchTick() tea.Cmd {
+	return tea.Tick(tunnelWatchInterval, func(time.Time) tea.Msg {
+		return tunnelWatchTickMsg{}
+	})
+}
+
+// watchTunnels refreshes the tunnels panel and notifies about tunnels that
+// exited on their own since the last check.
+func (m *Model) watchTunnels() tea.Cmd {
+	if m.term.tunnels == nil {
+		m.term.tunnels = make(map[string]model.TunnelStatus)
+	}
+
+	var cmds []tea.Cmd
+	seen := make(map[string]bool)
+	check := func(id string, status model.TunnelStatus, target string, localPort int, errMsg string) {
+		seen[id] = true
+		prev := m.term.tunnels[id]
+		m.term.tunnels[id] = status
+		if prev != model.TunnelStatusActive {
+			return
+		}
+		switch status {
+		case model.TunnelStatusError:
+			cmds = append(cmds, m.notify("vaws tunnel died", fmt.Sprintf("localhost:%d → %s: %s", localPort, target, errMsg)))
+		case model.TunnelStatusTerminated:
+			cmds = append(cmds, m.notify("vaws tunnel closed", fmt.Sprintf("localhost:%d → %s", localPort, target)))
+		}
+	}
+
+	if m.tunnelManager != nil {
+		for _, t := range m.tunnelManager.GetTunnels() {
+			target := t.ServiceName
+			if t.RemoteHost != "" {
+				target = t.RemoteHost
+			}
+			check(t.ID, t.Status, target, t.LocalPort, t.Error)
+		}
+	}
+	if m.apiGWManager != nil {
+		for _, t := range m.apiGWManager.GetTunnels() {
+			check(t.ID, t.Status, t.APIName, t.LocalPort, t.Error)
+		}
+	}
+	for id := range m.term.tunnels {
+		if !seen[id] {
+			delete(m.term.tunnels, id)
+		}
+	}
+
+	m.updateTunnelsPanel()
+	return tea.Batch(cmds...)
+}
+
+// forgetTunnel marks a tunnel as stopped by the user, so its exit is not notified.
+func (m *Model) forgetTunnel(id string) {
+	if m.term.tunnels != nil {
+		m.term.tunnels[id] = model.TunnelStatusTerminated
+	}
+}
+
+// watchDeployments notifies when an ECS service that was rolling out a
+// deployment is down to a single one again.
+func (m *Model) watchDeployments(services []model.Service) tea.Cmd {
+	if m.term.deployments == nil {
+		m.term.deployments = make(map[string]string)
+	}
+
+	var cmds []tea.Cmd
+	for _, svc := range services {
+		primary := ""
+		for _, d := range svc.Deployments {
+			if d.Status == "PRIMARY" {
+				primary = d.ID
+			}
+		}
+
+		watched, rolling := m.term.deployments[svc.ARN]
+		switch {
+		case len(svc.Deployments) > 1:
+			m.term.deployments[svc.ARN] = primary
+		case rolling:
+			delete(m.term.deployments, svc.ARN)
+			if primary == watched {
+				cmds = append(cmds, m.notify("vaws deployment finished", fmt.Sprintf("%s: %d/%d tasks running", svc.Name, svc.RunningCount, svc.DesiredCount)))
+			} else {
+				cmds = append(cmds, m.notify("vaws deployment rolled back", svc.Name))
+			}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// watchAppRunner notifies when an App Runner operation (deploy, pause, resume) ends.
+func (m *Model) watchAppRunner(services []model.AppRunnerService) tea.Cmd {
+	if m.term.appRunner == nil {
+		m.term.appRunner = make(map[string]bool)
+	}
+
+	var cmds []tea.Cmd
+	for _, svc := range services {
+		busy := svc.Status == model.AppRunnerStatusInProgress
+		if m.term.appRunner[svc.ARN] && !busy {
+			cmds = append(cmds, m.notify("vaws App Runner operation finished", fmt.Sprintf("%s: %s", svc.Name, svc.Status)))
+		}
+		m.term.appRunner[svc.ARN] = busy
+	}
+	return tea.Batch(cmds...)
+}
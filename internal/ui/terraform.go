@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/model"
+	"vaws/internal/ui/components"
+)
+
+// terraformStates are the Terraform resources managing the AWS resources of
+// the profile, read from the states in its config.
+type terraformStates struct {
+	byARN   map[string]model.TerraformResource
+	loading bool
+	gen     int // Bumped on profile switches, so loads for the previous profile are dropped
+}
+
+// terraformState is the outcome of reading one state.
+type terraformState struct {
+	location  string
+	resources []model.TerraformResource
+	err       error
+}
+
+// terraformLoadedMsg carries the states of the profile.
+type terraformLoadedMsg struct {
+	gen    int
+	states []terraformState
+}
+
+// resetTerraform forgets the states of the previous profile.
+func (m *Model) resetTerraform() {
+	m.terraform.byARN = nil
+	m.terraform.loading = false
+	m.terraform.gen++
+}
+
+// loadTerraform reads the Terraform states of the profile in the
+// background. Profiles without any have nothing to read.
+func (m *Model) loadTerraform() tea.Cmd {
+	if m.client == nil || m.cfg == nil {
+		return nil
+	}
+	locations := m.cfg.GetTerraformStates(m.state.Profile)
+	if len(locations) == 0 {
+		return nil
+	}
+	m.terraform.loading = true
+	client, gen := m.client, m.terraform.gen
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		msg := terraformLoadedMsg{gen: gen}
+		for _, location := range locations {
+			resources, err := client.LoadTerraformState(ctx, location)
+			msg.states = append(msg.states, terraformState{location: location, resources: resources, err: err})
+		}
+		return msg
+	}
+}
+
+// handleTerraformLoaded indexes the resources of the states by ARN. When two
+// states claim a resource, the first configured wins.
+func (m *Model) handleTerraformLoaded(msg terraformLoadedMsg) {
+	if msg.gen != m.terraform.gen {
+		return
+	}
+	m.terraform.loading = false
+	m.terraform.byARN = make(map[string]model.TerraformResource)
+	for _, st := range msg.states {
+		if st.err != nil {
+			m.logger.Warn("Failed to read Terraform state %s: %v", st.location, st.err)
+			continue
+		}
+		for _, r := range st.resources {
+			for _, arn := range r.ARNs {
+				if _, ok := m.terraform.byARN[arn]; !ok {
+					m.terraform.byARN[arn] = r
+				}
+			}
+		}
+		m.logger.Info("Read %d resources from Terraform state %s", len(st.resources), st.location)
+	}
+	m.updateCurrentList()
+}
+
+// handleTerraformCommand reads the states of the profile again.
+func (m *Model) handleTerraformCommand() tea.Cmd {
+	if m.cfg == nil || len(m.cfg.GetTerraformStates(m.state.Profile)) == 0 {
+		m.logger.Warn("No Terraform states for profile %s: add terraform_states to its config", m.state.Profile)
+		return nil
+	}
+	if m.terraform.loading {
+		m.logger.Info("Still reading the Terraform states...")
+		return nil
+	}
+	m.logger.Info("Reading Terraform states...")
+	return m.loadTerraform()
+}
+
+// terraformAddress returns the Terraform address of a resource, or "".
+func (m *Model) terraformAddress(arn string) string {
+	return m.terraform.byARN[arn].Address
+}
+
+// withTerraform adds the Terraform address of a resource to its cells.
+func (m *Model) withTerraform(cells map[string]string, arn string) map[string]string {
+	cells["terraform"] = m.terraformAddress(arn)
+	return cells
+}
+
+// terraformRows show the Terraform resource managing a resource, for the end
+// of its details.
+func (m *Model) terraformRows(arn string) []components.DetailRow {
+	r, ok := m.terraform.byARN[arn]
+	if !ok {
+		return nil
+	}
+	s := GetStyles()
+	rows := []components.DetailRow{
+		{Label: "", Value: ""}, // Spacer
+		{Label: "Terraform", Value: r.Address},
+	}
+	if r.Module != "" {
+		rows = append(rows, components.DetailRow{Label: "Module", Value: r.Module})
+	}
+	return append(rows, components.DetailRow{Label: "State", Value: r.State, Style: s.Muted})
+}
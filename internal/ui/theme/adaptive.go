@@ -100,7 +100,7 @@ func DefaultStyles() Styles {
 
 		// Sidebar
 		Sidebar: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
+			Border(BorderStyle()).
 			BorderForeground(Border).
 			Padding(1, 1),
 		SidebarTitle: lipgloss.NewStyle().
@@ -122,7 +122,7 @@ func DefaultStyles() Styles {
 
 		// Content area
 		Content: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
+			Border(BorderStyle()).
 			BorderForeground(Border).
 			Padding(1, 2),
 		ContentTitle: lipgloss.NewStyle().
@@ -160,7 +160,7 @@ func DefaultStyles() Styles {
 
 		// Input
 		Input: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
+			Border(BorderStyle()).
 			BorderForeground(BorderFocus).
 			Padding(0, 1),
 		InputLabel: lipgloss.NewStyle().
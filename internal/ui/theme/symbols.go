@@ -0,0 +1,93 @@
+package theme
+
+import (
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	asciiOnly   bool
+	statusText  bool
+	symbolsLock sync.RWMutex
+)
+
+// SetASCII switches icons, spinners and borders to plain ASCII, for
+// terminals and fonts that cannot render emoji or box drawing characters.
+func SetASCII(on bool) {
+	symbolsLock.Lock()
+	defer symbolsLock.Unlock()
+	asciiOnly = on
+}
+
+// ASCII reports whether ASCII-only mode is on.
+func ASCII() bool {
+	symbolsLock.RLock()
+	defer symbolsLock.RUnlock()
+	return asciiOnly
+}
+
+// SetStatusText adds OK/WARN/ERR tags next to colored statuses, so they can
+// be told apart without relying on color.
+func SetStatusText(on bool) {
+	symbolsLock.Lock()
+	defer symbolsLock.Unlock()
+	statusText = on
+}
+
+// StatusText reports whether colored statuses get a text tag.
+func StatusText() bool {
+	symbolsLock.RLock()
+	defer symbolsLock.RUnlock()
+	return statusText
+}
+
+// Symbol returns unicode, or ascii in ASCII-only mode.
+func Symbol(unicode, ascii string) string {
+	if ASCII() {
+		return ascii
+	}
+	return unicode
+}
+
+// BorderStyle returns the border used around panes and dialogs.
+func BorderStyle() lipgloss.Border {
+	if ASCII() {
+		return lipgloss.ASCIIBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
+// Status tags shown next to colored statuses when StatusText is on.
+const (
+	TagOK   = "OK"
+	TagWarn = "WARN"
+	TagErr  = "ERR"
+)
+
+// StatusTag returns the text tag of a status color: OK for success, WARN for
+// warning and ERR for error colors, of either the current or the adaptive
+// palette. It returns "" for other colors or when StatusText is off.
+func StatusTag(c lipgloss.TerminalColor) string {
+	if !StatusText() || c == nil {
+		return ""
+	}
+	t := Current()
+	switch c {
+	case t.Success, Success:
+		return TagOK
+	case t.Warning, Warning:
+		return TagWarn
+	case t.Error, Error:
+		return TagErr
+	}
+	return ""
+}
+
+// Tagged prefixes s with the status tag of style's foreground, e.g. "[ERR] FAILED".
+func Tagged(style lipgloss.Style, s string) string {
+	if tag := StatusTag(style.GetForeground()); tag != "" {
+		return "[" + tag + "] " + s
+	}
+	return s
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+// maxTimelineEntries caps the session timeline, dropping the oldest entries.
+const maxTimelineEntries = 1000
+
+// Kinds of timeline entries.
+const (
+	timelineView    = "view"
+	timelineInspect = "inspect"
+	timelineAction  = "action"
+	timelineTunnel  = "tunnel"
+	timelineSession = "session"
+)
+
+// timelineEntry is one thing done in the session.
+type timelineEntry struct {
+	at   time.Time
+	kind string
+	text string
+}
+
+// line renders the entry as a line of the copied timeline.
+func (e timelineEntry) line() string {
+	return fmt.Sprintf("%s  %-7s  %s", e.at.Format("2006-01-02 15:04:05 MST"), e.kind, e.text)
+}
+
+// timeline records the views visited, resources inspected, actions taken
+// and tunnels opened in the session, for the :timeline view.
+type timeline struct {
+	entries  []timelineEntry
+	view     state.View // Last view recorded
+	recorded bool       // A view was recorded, so view is set
+}
+
+// recordTimeline adds an entry to the session timeline. An entry repeating
+// the last one, e.g. from pressing enter twice, is dropped.
+func (m *Model) recordTimeline(kind, format string, args ...any) {
+	e := timelineEntry{at: time.Now(), kind: kind, text: fmt.Sprintf(format, args...)}
+	t := &m.timeline
+	if n := len(t.entries); n > 0 && t.entries[n-1].kind == e.kind && t.entries[n-1].text == e.text {
+		return
+	}
+	if len(t.entries) >= maxTimelineEntries {
+		t.entries = append(t.entries[:0], t.entries[len(t.entries)-maxTimelineEntries+1:]...)
+	}
+	t.entries = append(t.entries, e)
+	if m.state.View == state.ViewTimeline {
+		m.updateTimelineList()
+	}
+}
+
+// trackTimelineView records the view when it changes, by the title of its
+// pane, e.g. "Services (prod)". Like describeSelectedStack it runs after
+// every message. The timeline itself and the screens before a profile is
+// picked aren't recorded.
+func (m *Model) trackTimelineView() {
+	v := m.state.View
+	t := &m.timeline
+	if v == state.ViewTimeline || v == state.ViewProfileSelect || m.client == nil || (t.recorded && t.view == v) {
+		return
+	}
+	t.view, t.recorded = v, true
+	m.updateContainerContext()
+	m.recordTimeline(timelineView, "Opened %s", m.container.Title())
+}
+
+// recordConfirmed records an action confirmed in the confirm dialog, with
+// what it affects.
+func (m *Model) recordConfirmed(prompt *confirmPrompt) {
+	if len(prompt.details) == 0 {
+		m.recordTimeline(timelineAction, "%s", prompt.title)
+		return
+	}
+	m.recordTimeline(timelineAction, "%s (%s)", prompt.title, strings.Join(prompt.details, ", "))
+}
+
+// openTimeline shows the session timeline, newest entry selected.
+func (m *Model) openTimeline() tea.Cmd {
+	if m.state.View != state.ViewTimeline {
+		m.state.TimelineReturnView = m.state.View
+	}
+	m.state.View = state.ViewTimeline
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	m.updateTimelineList()
+	m.timelineList.Bottom()
+	m.updateTimelineDetails()
+	return nil
+}
+
+// closeTimeline returns to the view the timeline was opened from.
+func (m *Model) closeTimeline() {
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.state.View = m.state.TimelineReturnView
+	m.updateCurrentList()
+}
+
+// filteredTimeline returns the indexes of the entries matching the filter,
+// oldest first.
+func (m *Model) filteredTimeline() []int {
+	filter := strings.ToLower(m.state.FilterText)
+	var matched []int
+	for i, e := range m.timeline.entries {
+		if filter == "" || strings.Contains(strings.ToLower(e.text), filter) || strings.Contains(e.kind, filter) {
+			matched = append(matched, i)
+		}
+	}
+	return matched
+}
+
+// updateTimelineList lists the entries of the timeline, oldest first.
+func (m *Model) updateTimelineList() {
+	s := GetStyles()
+	var items []components.ListItem
+	for _, i := range m.filteredTimeline() {
+		e := m.timeline.entries[i]
+		item := components.ListItem{
+			ID:          strconv.Itoa(i),
+			Title:       e.at.Format("15:04:05") + "  " + e.text,
+			Status:      e.kind,
+			StatusStyle: s.Muted,
+		}
+		switch e.kind {
+		case timelineAction:
+			item.StatusStyle = s.StatusWarning
+		case timelineTunnel:
+			item.StatusStyle = s.StatusHealthy
+		}
+		items = append(items, item)
+	}
+
+	m.timelineList.SetItems(items)
+	m.timelineList.SetError(nil)
+	if m.state.FilterText != "" {
+		m.timelineList.SetEmptyMessage("No timeline entries match the filter")
+	} else {
+		m.timelineList.SetEmptyMessage("Nothing recorded yet")
+	}
+	m.updateTimelineDetails()
+}
+
+// updateTimelineDetails describes the selected entry.
+func (m *Model) updateTimelineDetails() {
+	s := GetStyles()
+	m.details.SetTitle("Timeline")
+	item := m.timelineList.SelectedItem()
+	if item == nil {
+		m.details.SetRows(nil)
+		return
+	}
+	i, err := strconv.Atoi(item.ID)
+	if err != nil || i >= len(m.timeline.entries) {
+		m.details.SetRows(nil)
+		return
+	}
+	e := m.timeline.entries[i]
+	m.details.SetRows([]components.DetailRow{
+		{Label: "Time", Value: e.at.Format("2006-01-02 15:04:05 MST")},
+		{Label: "Ago", Value: format.Relative(time.Since(e.at))},
+		{Label: "Kind", Value: e.kind},
+		{Label: "What", Value: e.text},
+		{Label: "", Value: ""},
+		{Label: "Profile", Value: m.state.Profile, Style: s.Muted},
+		{Label: "Region", Value: m.state.Region, Style: s.Muted},
+		{Label: "", Value: ""},
+		{Label: "Copy", Value: "Y copies the timeline as text, e.g. for an incident write-up", Style: s.Muted},
+	})
+}
+
+// copyTimeline copies the entries matching the filter to the clipboard, one
+// line each.
+func (m *Model) copyTimeline() {
+	var lines []string
+	for _, i := range m.filteredTimeline() {
+		lines = append(lines, m.timeline.entries[i].line())
+	}
+	if len(lines) == 0 {
+		m.logger.Warn("No timeline entries to copy")
+		return
+	}
+	if err := copyToClipboard(strings.Join(lines, "\n") + "\n"); err != nil {
+		m.logger.Warn("Clipboard not available: %v", err)
+		return
+	}
+	m.logger.Info("Copied %d timeline entries to the clipboard", len(lines))
+}
@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/aws"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+)
+
+// timeRangePresets returns the ranges the picker offers for a view: the
+// common ones, and longer ones where the view reads further back.
+func timeRangePresets(v state.View) []time.Duration {
+	presets := components.TimeRangePresets
+	switch v {
+	case state.ViewLogSearch, state.ViewLambda:
+		presets = append(presets[:len(presets):len(presets)], 7*24*time.Hour)
+	case state.ViewActivity:
+		presets = append(presets[:len(presets):len(presets)], 7*24*time.Hour, aws.ActivityLookback)
+	}
+	return presets
+}
+
+// timeRange returns the range chosen for a view in the session, or the one
+// it starts with. CloudWatch logs start zero, reading the stream from its
+// start or access logs from accessLogWindow ago.
+func (m *Model) timeRange(v state.View) model.TimeRange {
+	if r, ok := m.timeRanges[v]; ok {
+		return r
+	}
+	switch v {
+	case state.ViewLogSearch:
+		return model.LastRange(time.Hour)
+	case state.ViewActivity:
+		return model.LastRange(aws.ActivityLookback)
+	case state.ViewLambda:
+		return model.LastRange(24 * time.Hour)
+	}
+	return model.TimeRange{}
+}
+
+// setTimeRange keeps the range chosen for a view for the rest of the
+// session.
+func (m *Model) setTimeRange(v state.View, r model.TimeRange) {
+	if m.timeRanges == nil {
+		m.timeRanges = make(map[state.View]model.TimeRange)
+	}
+	m.timeRanges[v] = r
+}
+
+// timeRangeView returns the view whose range the picker chooses from here:
+// the performance panel's over the Lambda list, or the current view if it
+// reads over a range.
+func (m *Model) timeRangeView() (state.View, bool) {
+	if m.lambdaPerformance != nil {
+		return state.ViewLambda, true
+	}
+	switch m.state.View {
+	case state.ViewCloudWatchLogs, state.ViewLogSearch, state.ViewActivity:
+		return m.state.View, true
+	}
+	return 0, false
+}
+
+// openTimeRangePicker opens the time range picker for the current view.
+func (m *Model) openTimeRangePicker() tea.Cmd {
+	v, ok := m.timeRangeView()
+	if !ok {
+		return nil
+	}
+	title := map[state.View]string{
+		state.ViewCloudWatchLogs: "CloudWatch logs",
+		state.ViewLogSearch:      "log search",
+		state.ViewActivity:       "activity",
+		state.ViewLambda:         "performance",
+	}[v]
+	m.timeRangePicker.Activate(title, timeRangePresets(v), m.timeRange(v))
+	return nil
+}
+
+// handleTimeRangePickerKey passes keys to the picker and reloads the view
+// over the chosen range.
+func (m *Model) handleTimeRangePickerKey(msg tea.KeyMsg) tea.Cmd {
+	result, cmd := m.timeRangePicker.Update(msg)
+	if result == nil || result.Cancelled {
+		return cmd
+	}
+	v, ok := m.timeRangeView()
+	if !ok {
+		return cmd
+	}
+	m.setTimeRange(v, result.Range)
+	m.logger.Info("Time range of %s: %s", m.timeRangeTitle(v), components.RangeLabel(result.Range))
+
+	switch v {
+	case state.ViewCloudWatchLogs:
+		return m.restartCloudWatchLogs()
+	case state.ViewLogSearch:
+		m.state.LogSearchRange = result.Range
+		return m.loadLogSearch()
+	case state.ViewActivity:
+		return m.loadActivity()
+	case state.ViewLambda:
+		return m.loadLambdaPerformance()
+	}
+	return cmd
+}
+
+// timeRangeTitle names what a view's range applies to in messages.
+func (m *Model) timeRangeTitle(v state.View) string {
+	switch v {
+	case state.ViewLogSearch:
+		return "the log search of " + m.state.LogSearchStack
+	case state.ViewActivity:
+		return "the activity of " + m.state.ActivityResource
+	case state.ViewLambda:
+		return "performance"
+	}
+	return "CloudWatch logs"
+}
+
+// rangeClause describes a range to follow a sentence, e.g. "in the last
+// 1h" or "from 2024-05-01 09:00 to 12:30".
+func rangeClause(r model.TimeRange) string {
+	if r.IsAbsolute() {
+		return components.RangeLabel(r)
+	}
+	return "in the " + components.RangeLabel(r)
+}
+
+// cloudWatchStartTime returns where a CloudWatch logs view starts reading,
+// in Unix ms: the start of the chosen range, or def without one.
+func (m *Model) cloudWatchStartTime(def int64) int64 {
+	r := m.timeRange(state.ViewCloudWatchLogs)
+	if r.IsZero() {
+		return def
+	}
+	start, _ := r.Bounds(time.Now())
+	return start.UnixMilli()
+}
+
+// cloudWatchEndTime returns where the chosen CloudWatch logs range ends, or
+// zero if it runs up to now and the logs keep streaming.
+func (m *Model) cloudWatchEndTime() time.Time {
+	return m.timeRange(state.ViewCloudWatchLogs).End
+}
+
+// restartCloudWatchLogs reads the logs being viewed again from the start of
+// the chosen range, e.g. of another container or once the range changed,
+// streaming until the range's end if it has one.
+func (m *Model) restartCloudWatchLogs() tea.Cmd {
+	def := int64(0)
+	if m.state.CloudWatchAccessLogContext != "" {
+		def = time.Now().Add(-accessLogWindow).UnixMilli()
+	}
+	m.stopLogExport("the logs are read again")
+	m.cloudWatchLogsSeq++
+	m.state.CloudWatchLogs = nil
+	m.state.CloudWatchLastFetchTime = m.cloudWatchStartTime(def)
+	m.cloudWatchLogsPanel.Clear()
+	m.cloudWatchLogsPanel.SetRange(m.cloudWatchRangeLabel())
+
+	// Polling goes on while streaming, its next tick reading from the new
+	// start; otherwise it starts again
+	if m.state.CloudWatchLogsStreaming {
+		return nil
+	}
+	m.state.CloudWatchLogsStreaming = true
+	m.cloudWatchLogsPanel.SetStreaming(true)
+	return tea.Batch(
+		m.fetchCurrentCloudWatchLogs(),
+		m.cloudWatchLogsPanel.TickCmd(),
+		m.cloudWatchLogsPanel.SpinnerTickCmd(),
+	)
+}
+
+// cloudWatchRangeLabel describes the chosen CloudWatch logs range for the
+// panel header, "" without one.
+func (m *Model) cloudWatchRangeLabel() string {
+	r := m.timeRange(state.ViewCloudWatchLogs)
+	if r.IsZero() {
+		return ""
+	}
+	return components.RangeLabel(r)
+}
@@ -0,0 +1,334 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/theme"
+)
+
+// trafficVersionsShown is how many of the latest versions the traffic
+// dialog lists.
+const trafficVersionsShown = 10
+
+// aliasTraffic is the traffic shifting dialog of a Lambda function's
+// aliases.
+type aliasTraffic struct {
+	function string
+	aliases  []model.LambdaAlias
+	versions []string // Published versions, oldest first
+	loading  bool
+	err      error
+	cursor   int
+	input    textinput.Model
+	guard    *aliasShift // Shift of all traffic, run once the alias name is typed
+}
+
+// aliasShift is the routing an alias is updated to: weight (0 to 1) of its
+// traffic goes to routing and the rest to version.
+type aliasShift struct {
+	function string
+	alias    string
+	version  string
+	routing  string
+	weight   float64
+}
+
+// aliasesLoadedMsg carries the aliases and versions of a function.
+type aliasesLoadedMsg struct {
+	function string
+	aliases  []model.LambdaAlias
+	versions []string
+	err      error
+}
+
+// aliasShiftedMsg carries an alias after its traffic was shifted.
+type aliasShiftedMsg struct {
+	function string
+	alias    *model.LambdaAlias
+	err      error
+}
+
+// openTraffic opens the traffic dialog of the selected Lambda function and
+// loads its aliases and versions.
+func (m *Model) openTraffic() tea.Cmd {
+	if m.state.View != state.ViewLambda || m.client == nil {
+		return nil
+	}
+	item := m.lambdaList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+
+	input := textinput.New()
+	input.Placeholder = "7 10 (10% to version 7) or 7 (all to 7)"
+	input.CharLimit = 64
+	input.Width = 40
+	input.Focus()
+	m.traffic = &aliasTraffic{function: item.ID, loading: true, input: input}
+
+	client, function := m.client, item.ID
+	return tea.Batch(textinput.Blink, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		msg := aliasesLoadedMsg{function: function}
+		if msg.aliases, msg.err = client.ListAliases(ctx, function); msg.err != nil {
+			return msg
+		}
+		msg.versions, msg.err = client.ListVersions(ctx, function)
+		return msg
+	})
+}
+
+// handleAliasesLoaded fills the traffic dialog, if it is still open on the
+// function.
+func (m *Model) handleAliasesLoaded(msg aliasesLoadedMsg) {
+	t := m.traffic
+	if t == nil || t.function != msg.function {
+		return
+	}
+	t.loading = false
+	t.err = msg.err
+	t.aliases = msg.aliases
+	t.versions = msg.versions
+}
+
+// handleTrafficKey handles key messages while the traffic dialog is open.
+func (m *Model) handleTrafficKey(msg tea.KeyMsg) tea.Cmd {
+	t := m.traffic
+	switch msg.String() {
+	case "esc":
+		if t.guard != nil {
+			t.guard = nil
+			t.input.SetValue("")
+			return nil
+		}
+		m.traffic = nil
+		return nil
+	case "up":
+		if t.guard == nil && t.cursor > 0 {
+			t.cursor--
+		}
+		return nil
+	case "down":
+		if t.guard == nil && t.cursor < len(t.aliases)-1 {
+			t.cursor++
+		}
+		return nil
+	case "enter":
+		return m.submitTraffic()
+	}
+
+	var cmd tea.Cmd
+	t.input, cmd = t.input.Update(msg)
+	return cmd
+}
+
+// submitTraffic runs the shift typed in the traffic dialog. Shifts that
+// leave a version with part of the traffic are confirmed with y; shifts of
+// all traffic need the alias name typed first.
+func (m *Model) submitTraffic() tea.Cmd {
+	t := m.traffic
+	value := strings.TrimSpace(t.input.Value())
+
+	if t.guard != nil {
+		if value != t.guard.alias {
+			m.logger.Warn("Type %s to send all of its traffic to version %s", t.guard.alias, t.guard.version)
+			return nil
+		}
+		shift := *t.guard
+		m.traffic = nil
+		return m.shiftTraffic(shift)
+	}
+
+	if t.loading || t.cursor >= len(t.aliases) || value == "" {
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionWrite) {
+		return nil
+	}
+	alias := t.aliases[t.cursor]
+	shift, err := parseShift(alias, t.versions, value)
+	if err != nil {
+		m.logger.Warn("%v", err)
+		return nil
+	}
+	shift.function = t.function
+
+	if shift.weight == 0 {
+		t.guard = &shift
+		t.input.SetValue("")
+		return nil
+	}
+	m.traffic = nil
+	return m.askConfirm("Shift traffic of "+alias.Name, []string{
+		"Function: " + shift.function,
+		"Now: " + aliasShares(alias.Version, alias.RoutingVersion, alias.RoutingWeight),
+		"After: " + aliasShares(shift.version, shift.routing, shift.weight),
+	}, func() tea.Cmd {
+		return m.shiftTraffic(shift)
+	})
+}
+
+// parseShift reads "<version> [percent]" as the shift of an alias: the
+// version gets that share of the traffic, all of it without a percent. The
+// share left goes to the alias's primary version, or to its additional
+// version if the version named is the primary.
+func parseShift(alias model.LambdaAlias, versions []string, s string) (aliasShift, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, "%", " "))
+	if len(fields) == 0 || len(fields) > 2 {
+		return aliasShift{}, fmt.Errorf("enter a version and a percent, e.g. 7 10")
+	}
+	version := strings.TrimPrefix(fields[0], "v")
+	if !slices.Contains(versions, version) && version != alias.Version {
+		return aliasShift{}, fmt.Errorf("%s has no version %s", alias.Name, version)
+	}
+	percent := 100.0
+	if len(fields) == 2 {
+		p, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || p <= 0 || p > 100 {
+			return aliasShift{}, fmt.Errorf("invalid percent %q: use a number above 0, up to 100", fields[1])
+		}
+		percent = p
+	}
+
+	shift := aliasShift{alias: alias.Name, version: version}
+	switch {
+	case percent == 100:
+		// All traffic to the version, without routing
+	case version == alias.Version:
+		if alias.RoutingVersion == "" {
+			return aliasShift{}, fmt.Errorf("%s sends all traffic to version %s; name the version to shift to", alias.Name, version)
+		}
+		shift.routing, shift.weight = alias.RoutingVersion, (100-percent)/100
+	default:
+		shift.version, shift.routing, shift.weight = alias.Version, version, percent/100
+	}
+	return shift, nil
+}
+
+// aliasShares describes the routing of an alias, e.g. "v6 90% / v7 10%".
+func aliasShares(version, routing string, weight float64) string {
+	if routing == "" || weight <= 0 {
+		return "v" + version + " 100%"
+	}
+	return fmt.Sprintf("v%s %s / v%s %s", version, percentText(1-weight), routing, percentText(weight))
+}
+
+// percentText renders a weight as a percent with up to two decimals.
+func percentText(weight float64) string {
+	return strconv.FormatFloat(float64(int(weight*10000+0.5))/100, 'f', -1, 64) + "%"
+}
+
+// shiftTraffic updates the routing of an alias.
+func (m *Model) shiftTraffic(shift aliasShift) tea.Cmd {
+	m.logger.Info("Shifting %s of %s to %s", shift.alias, shift.function, aliasShares(shift.version, shift.routing, shift.weight))
+	client := m.client
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		alias, err := client.ShiftAliasTraffic(ctx, shift.function, shift.alias, shift.version, shift.routing, shift.weight)
+		return aliasShiftedMsg{function: shift.function, alias: alias, err: err}
+	}
+}
+
+// handleAliasShifted logs the routing an alias was updated to.
+func (m *Model) handleAliasShifted(msg aliasShiftedMsg) {
+	if msg.err != nil {
+		m.logger.Error("Failed to shift traffic: %v", msg.err)
+		return
+	}
+	a := msg.alias
+	m.logger.Info("%s of %s now sends %s", a.Name, msg.function, aliasShares(a.Version, a.RoutingVersion, a.RoutingWeight))
+}
+
+// renderTrafficDialog renders the traffic dialog: the aliases with their
+// routing, the latest versions and the shift input.
+func (m *Model) renderTrafficDialog() string {
+	t := m.traffic
+	dialogWidth := 70
+	if m.width < 80 {
+		dialogWidth = m.width - 10
+		if dialogWidth < 40 {
+			dialogWidth = 40
+		}
+	}
+
+	border := theme.BorderFocus
+	if t.guard != nil {
+		border = theme.Error
+	}
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(border).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	s := GetStyles()
+	title := labelStyle.Render("Traffic: " + truncateString(t.function, dialogWidth-16))
+
+	switch {
+	case t.loading:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("Loading aliases..."))
+	case t.err != nil:
+		return dialogStyle.Render(title + "\n\n" + s.StatusError.Render(truncateString(t.err.Error(), dialogWidth-6)) + "\n\n" + hintStyle.Render("esc to close"))
+	case len(t.aliases) == 0:
+		return dialogStyle.Render(title + "\n\n" + s.Muted.Render("No aliases; publish a version and create an alias to shift traffic") + "\n\n" + hintStyle.Render("esc to close"))
+	}
+
+	if g := t.guard; g != nil {
+		warn := lipgloss.NewStyle().Foreground(theme.Error).Bold(true)
+		content := title + "\n\n" +
+			warn.Render(fmt.Sprintf("Send all traffic of %s to version %s", g.alias, g.version)) + "\n\n" +
+			"Type " + g.alias + " to confirm: " + t.input.View() + "\n\n" +
+			hintStyle.Render("esc to go back")
+		return dialogStyle.Render(content)
+	}
+
+	nameWidth := 0
+	for _, a := range t.aliases {
+		nameWidth = max(nameWidth, len(a.Name))
+	}
+	var lines []string
+	for i, a := range t.aliases {
+		cursor := "  "
+		if i == t.cursor {
+			cursor = theme.Symbol("▶ ", "> ")
+		}
+		style := s.Muted
+		if a.Weighted() {
+			style = s.StatusWarning
+		}
+		lines = append(lines, cursor+fmt.Sprintf("%-*s  ", nameWidth, a.Name)+style.Render(aliasShares(a.Version, a.RoutingVersion, a.RoutingWeight)))
+	}
+
+	versions := t.versions
+	if len(versions) > trafficVersionsShown {
+		versions = versions[len(versions)-trafficVersionsShown:]
+	}
+	content := title + "\n\n" +
+		strings.Join(lines, "\n") + "\n\n" +
+		s.Muted.Render("Versions: "+valueOrDash(strings.Join(versions, ", "))) + "\n\n" +
+		"Shift: " + t.input.View() + "\n\n" +
+		hintStyle.Render("<version> <percent> shifts that share, <version> alone shifts all · ↑/↓ alias · esc")
+	return dialogStyle.Render(content)
+}
@@ -3,14 +3,39 @@ package ui
 import (
 	"context"
 	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"vaws/internal/config"
+	"vaws/internal/metrics"
 	"vaws/internal/model"
 	"vaws/internal/state"
+	"vaws/internal/tunnel"
 )
 
+// newAPIGatewayManager creates an API Gateway tunnel manager with the profile's proxy settings.
+func newAPIGatewayManager(cfg *config.Config, profile, region string) *tunnel.APIGatewayManager {
+	mgr := tunnel.NewAPIGatewayManager(profile, region)
+	if cfg != nil {
+		mgr.SetTLS(cfg.GetProxyTLS(profile))
+	}
+	return mgr
+}
+
+// newTunnelManager creates an ECS tunnel manager with the profile's tunnel strategy.
+func newTunnelManager(cfg *config.Config, profile, region string) *tunnel.Manager {
+	mgr := tunnel.NewManager(profile, region)
+	if cfg != nil {
+		mgr.SetStrategy(tunnel.Strategy(cfg.GetTunnelStrategy(profile)))
+	}
+	return mgr
+}
+
 // updateTunnelsPanel updates the tunnels panel with current tunnel data.
 func (m *Model) updateTunnelsPanel() {
 	tunnels := m.tunnelManager.GetTunnels()
@@ -22,6 +47,35 @@ func (m *Model) updateTunnelsPanel() {
 	m.tunnelsPanel.SetAPIGatewayTunnels(apiGWTunnels)
 }
 
+// exportTunnelMetrics hands the tunnels and their last probes to the
+// metrics endpoint.
+func (m *Model) exportTunnelMetrics() {
+	var tunnels []metrics.Tunnel
+	add := func(t metrics.Tunnel, status model.TunnelStatus) {
+		t.Status = strings.ToLower(string(status))
+		t.Up = status == model.TunnelStatusActive
+		if probe, ok := m.probes.results[t.ID]; ok {
+			t.Probed, t.Responding, t.ProbeLatency = true, probe.Responding(), probe.Latency
+		}
+		tunnels = append(tunnels, t)
+	}
+	if m.tunnelManager != nil {
+		for _, t := range m.tunnelManager.GetTunnels() {
+			target := t.ServiceName
+			if t.RemoteHost != "" {
+				target = t.RemoteHost
+			}
+			add(metrics.Tunnel{ID: t.ID, Kind: "ecs", Target: target, LocalPort: t.LocalPort, StartedAt: t.StartedAt}, t.Status)
+		}
+	}
+	if m.apiGWManager != nil {
+		for _, t := range m.apiGWManager.GetTunnels() {
+			add(metrics.Tunnel{ID: t.ID, Kind: "apigateway", Target: t.APIName, LocalPort: t.LocalPort, StartedAt: t.StartedAt}, t.Status)
+		}
+	}
+	metrics.Default().SetTunnels(tunnels)
+}
+
 // startTunnel starts a tunnel with a random local port.
 func (m *Model) startTunnel(service model.Service, task model.Task, container model.Container, remotePort int) tea.Cmd {
 	return m.startTunnelWithPort(service, task, container, remotePort, 0)
@@ -38,6 +92,39 @@ func (m *Model) startTunnelWithPort(service model.Service, task model.Task, cont
 	}
 }
 
+// startRemoteHostTunnel starts a tunnel to a host reachable from the task, such as a discovered endpoint.
+func (m *Model) startRemoteHostTunnel(service model.Service, task model.Task, container model.Container, remoteHost string, remotePort, localPort int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		tunnel, err := m.tunnelManager.StartRemoteHostTunnel(ctx, service, task, container, remoteHost, remotePort, localPort)
+		return tunnelStartedMsg{tunnel: tunnel, err: err}
+	}
+}
+
+// startDiscoveryTunnel starts a tunnel to a discovered endpoint through one of the service's tasks.
+// The task's own container is the SSM target, so the endpoint resolves as it does for the service.
+func (m *Model) startDiscoveryTunnel(service model.Service, ep model.DiscoveryEndpoint) tea.Cmd {
+	m.logger.Info("Loading tasks for service '%s' to reach %s...", service.Name, ep.Address())
+
+	info := model.Tunnel{
+		RemoteHost:  ep.DNSName,
+		RemotePort:  ep.Port,
+		ServiceName: service.Name,
+		ClusterARN:  service.ClusterARN,
+		ClusterName: service.ClusterName,
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		tasks, err := m.client.ListTasksForService(ctx, service.ClusterARN, service.Name)
+		return tasksLoadedMsgForRestart{tunnelInfo: info, tasks: tasks, err: err}
+	}
+}
+
 // startAPIGatewayTunnel starts a tunnel for the API Gateway based on its type.
 func (m *Model) startAPIGatewayTunnel(api interface{}, stage model.APIStage, localPort int) tea.Cmd {
 	// Determine if this is a private or public API Gateway
@@ -47,6 +134,9 @@ func (m *Model) startAPIGatewayTunnel(api interface{}, stage model.APIStage, loc
 	}
 
 	if isPrivate {
+		if !m.checkActionAllowed(config.ActionTunnel) {
+			return nil
+		}
 		m.logger.Info("Loading EC2 instances for jump host selection...")
 		// Store pending tunnel info and show jump host selection
 		m.state.PendingTunnelAPI = api
@@ -100,29 +190,12 @@ func (m *Model) findJumpHostForAPIGateway(api interface{}, stage model.APIStage,
 
 		// Get config for the current profile
 		jumpHostConfig := ""
-		jumpHostTagConfig := ""
+		jumpHostTagConfig := m.jumpHostTag()
 		if m.cfg != nil {
 			jumpHostConfig = m.cfg.GetJumpHost(m.state.Profile)
-			jumpHostTagConfig = m.cfg.GetJumpHostTag(m.state.Profile)
-		}
-
-		defaultTags := []string{
-			"vaws:jump-host=true",
-			"Name=bastion",
-			"Name=jump-host",
-		}
-		defaultNames := []string{
-			"bastion",
-			"jump-host",
-			"jumphost",
 		}
 
-		if m.cfg != nil && len(m.cfg.Defaults.JumpHostTags) > 0 {
-			defaultTags = m.cfg.Defaults.JumpHostTags
-		}
-		if m.cfg != nil && len(m.cfg.Defaults.JumpHostNames) > 0 {
-			defaultNames = m.cfg.Defaults.JumpHostNames
-		}
+		defaultTags, defaultNames := m.jumpHostDefaults()
 
 		// Build list of preferred VPCs (those with execute-api endpoints)
 		preferredVPCs := make([]string, 0, len(vpcEndpoints))
@@ -161,6 +234,29 @@ func (m *Model) findJumpHostForAPIGateway(api interface{}, stage model.APIStage,
 	}
 }
 
+// jumpHostDefaults returns the tags and names jump hosts are discovered by,
+// from the config or built-in defaults.
+func (m *Model) jumpHostDefaults() (tags, names []string) {
+	tags = []string{
+		"vaws:jump-host=true",
+		"Name=bastion",
+		"Name=jump-host",
+	}
+	names = []string{
+		"bastion",
+		"jump-host",
+		"jumphost",
+	}
+
+	if m.cfg != nil && len(m.cfg.Defaults.JumpHostTags) > 0 {
+		tags = m.cfg.Defaults.JumpHostTags
+	}
+	if m.cfg != nil && len(m.cfg.Defaults.JumpHostNames) > 0 {
+		names = m.cfg.Defaults.JumpHostNames
+	}
+	return tags, names
+}
+
 // startPrivateAPIGWTunnel starts an SSM tunnel for private API Gateway.
 func (m *Model) startPrivateAPIGWTunnel(api interface{}, stage model.APIStage, jumpHost *model.EC2Instance, vpcEndpoint *model.VpcEndpoint, localPort int) tea.Cmd {
 	// Get configured VPC endpoint ID for cross-account access
@@ -239,3 +335,442 @@ func (m *Model) startPrivateAPIGWTunnelWithJumpHost(jumpHost *model.EC2Instance)
 		return apiGWTunnelStartedMsg{tunnel: tunnel, err: err}
 	}
 }
+
+// applyConfiguredProxyRules applies proxy rules from the config file to a newly started API Gateway tunnel.
+func (m *Model) applyConfiguredProxyRules(t *model.APIGatewayTunnel) {
+	if m.cfg == nil || m.apiGWManager == nil {
+		return
+	}
+
+	rc, ok := m.cfg.GetProxyRules(m.state.Profile, t.APIID, t.APIName)
+	if !ok {
+		return
+	}
+
+	rules := model.ProxyRules{Headers: rc.Headers}
+	for _, rw := range rc.Rewrites {
+		rules.Rewrites = append(rules.Rewrites, model.RewriteRule{From: rw.From, To: rw.To})
+	}
+
+	if err := m.apiGWManager.SetTunnelRules(t.ID, rules); err != nil {
+		m.logger.Warn("Failed to apply configured proxy rules: %v", err)
+	}
+}
+
+// handleExportTunnel exports the selected tunnel as a shareable YAML snippet.
+// The snippet is copied to the clipboard and, if path is set, written to a file.
+func (m *Model) handleExportTunnel(path string) tea.Cmd {
+	if m.state.View != state.ViewTunnels {
+		m.logger.Warn("Select a tunnel in the tunnels view to export it")
+		return nil
+	}
+
+	var shared tunnel.SharedTunnel
+	if t := m.tunnelsPanel.SelectedTunnel(); t != nil {
+		if t.JumpHostID != "" {
+			m.logger.Warn("Export: jump host tunnels like '%s' cannot be shared yet", t.ID)
+			return nil
+		}
+		shared = tunnel.ExportECSTunnel(*t, m.state.Region)
+	} else if t := m.tunnelsPanel.SelectedAPIGatewayTunnel(); t != nil {
+		if t.TunnelType == model.APIGatewayTunnelMock {
+			m.logger.Warn("Export: mock servers like '%s' run from the local config and cannot be shared", t.ID)
+			return nil
+		}
+		shared = tunnel.ExportAPIGatewayTunnel(*t, m.state.Region, m.jumpHostTag())
+	} else {
+		return nil
+	}
+
+	snippet, err := shared.YAML()
+	if err != nil {
+		m.logger.Error("Failed to export tunnel: %v", err)
+		return nil
+	}
+
+	if path != "" {
+		path = expandHome(path)
+		if err := os.WriteFile(path, []byte(snippet), 0644); err != nil {
+			m.logger.Error("Failed to write tunnel definition: %v", err)
+			return nil
+		}
+		m.logger.Info("Tunnel definition written to %s", path)
+	}
+
+	if err := copyToClipboard(snippet); err != nil {
+		m.logger.Warn("Clipboard not available: %v", err)
+	} else {
+		m.logger.Info("Tunnel definition copied to clipboard (import with :import <file>)")
+	}
+	for _, line := range strings.Split(strings.TrimRight(snippet, "\n"), "\n") {
+		m.logger.Info("  %s", line)
+	}
+	m.state.ShowLogs = true
+	m.updateComponentSizes()
+	return nil
+}
+
+// handleImportTunnel recreates a tunnel from a shared YAML definition under the current profile.
+func (m *Model) handleImportTunnel(path string) tea.Cmd {
+	if path == "" {
+		m.logger.Warn("Usage: :import <file>")
+		return nil
+	}
+	if !m.checkActionAllowed(config.ActionTunnel) {
+		return nil
+	}
+
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		m.logger.Error("Failed to read tunnel definition: %v", err)
+		return nil
+	}
+
+	shared, err := tunnel.ParseSharedTunnel(data)
+	if err != nil {
+		m.logger.Error("Failed to import tunnel: %v", err)
+		return nil
+	}
+
+	if shared.Region != "" && shared.Region != m.state.Region {
+		m.logger.Warn("Tunnel was exported from %s, importing into %s", shared.Region, m.state.Region)
+	}
+
+	switch shared.Type {
+	case tunnel.SharedTunnelECS:
+		return m.importECSTunnel(shared)
+	default:
+		return m.importAPIGatewayTunnel(shared)
+	}
+}
+
+// importECSTunnel resolves the shared ECS target in the current account and starts the tunnel.
+func (m *Model) importECSTunnel(shared *tunnel.SharedTunnel) tea.Cmd {
+	target := shared.ECS
+	m.logger.Info("Importing tunnel for service '%s' (cluster: %s)...", target.Service, target.Cluster)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		// ECS accepts cluster names, resolve the ARN so the tunnel can be restarted later
+		service, err := m.client.DescribeService(ctx, target.Cluster, target.Service)
+		if err != nil {
+			return tunnelImportFailedMsg{target: target.Service, err: err}
+		}
+
+		info := model.Tunnel{
+			LocalPort:     shared.LocalPort,
+			RemotePort:    target.RemotePort,
+			ServiceName:   service.Name,
+			ClusterARN:    service.ClusterARN,
+			ClusterName:   target.Cluster,
+			ContainerName: target.Container,
+			RemoteHost:    target.RemoteHost,
+		}
+
+		tasks, err := m.client.ListTasksForService(ctx, service.ClusterARN, service.Name)
+		if err != nil {
+			return tunnelImportFailedMsg{target: target.Service, err: err}
+		}
+		if len(tasks) == 0 {
+			return tunnelImportFailedMsg{target: target.Service, err: fmt.Errorf("no running tasks")}
+		}
+		return tasksLoadedMsgForRestart{tunnelInfo: info, tasks: tasks}
+	}
+}
+
+// importAPIGatewayTunnel resolves the shared API Gateway stage and starts the tunnel.
+// Private APIs use the shared jump host tag, falling back to the profile's jump host config.
+func (m *Model) importAPIGatewayTunnel(shared *tunnel.SharedTunnel) tea.Cmd {
+	target := shared.APIGateway
+	m.logger.Info("Importing tunnel for API '%s' (stage: %s)...", target.APIName, target.Stage)
+
+	jumpHostConfig := ""
+	jumpHostTagConfig := target.JumpHostTag
+	var defaultTags, defaultNames []string
+	if m.cfg != nil {
+		jumpHostConfig = m.cfg.GetJumpHost(m.state.Profile)
+		if jumpHostTagConfig == "" {
+			jumpHostTagConfig = m.jumpHostTag()
+		}
+		defaultTags = m.cfg.Defaults.JumpHostTags
+		defaultNames = m.cfg.Defaults.JumpHostNames
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		api, stages, err := m.resolveSharedAPI(ctx, target)
+		if err != nil {
+			return apiGWTunnelStartedMsg{err: err}
+		}
+
+		var stage *model.APIStage
+		for i := range stages {
+			if stages[i].Name == target.Stage {
+				stage = &stages[i]
+				break
+			}
+		}
+		if stage == nil {
+			return apiGWTunnelStartedMsg{err: fmt.Errorf("stage %s not found for API %s", target.Stage, target.APIName)}
+		}
+
+		if !target.Private {
+			tun, err := m.apiGWManager.StartPublicTunnel(ctx, api, *stage, shared.LocalPort)
+			return apiGWTunnelStartedMsg{tunnel: tun, err: err}
+		}
+
+		vpcEndpoints, err := m.client.ListAPIGatewayVpcEndpoints(ctx)
+		if err != nil {
+			m.logger.Warn("Failed to list API Gateway VPC endpoints: %v", err)
+		}
+		preferredVPCs := make([]string, 0, len(vpcEndpoints))
+		for vpcID := range vpcEndpoints {
+			preferredVPCs = append(preferredVPCs, vpcID)
+		}
+
+		jumpHost, err := m.client.FindJumpHost(ctx, "", jumpHostConfig, jumpHostTagConfig, defaultTags, defaultNames, preferredVPCs...)
+		if err != nil {
+			return jumpHostFoundMsg{err: fmt.Errorf("failed to find jump host: %w", err)}
+		}
+
+		return jumpHostFoundMsg{
+			jumpHost:          jumpHost,
+			vpcEndpoint:       vpcEndpoints[jumpHost.VpcID],
+			stage:             *stage,
+			api:               api,
+			localPort:         shared.LocalPort,
+			vpcsWithEndpoints: preferredVPCs,
+		}
+	}
+}
+
+// resolveSharedAPI finds the shared API by ID, falling back to its name since
+// IDs differ between accounts.
+func (m *Model) resolveSharedAPI(ctx context.Context, target *tunnel.SharedAPIGatewayTarget) (interface{}, []model.APIStage, error) {
+	if target.APIType == "HTTP" {
+		if target.APIID != "" {
+			if api, err := m.client.GetHttpAPI(ctx, target.APIID); err == nil && (target.APIName == "" || api.Name == target.APIName) {
+				stages, err := m.client.GetHttpAPIStages(ctx, api.ID)
+				return api, stages, err
+			}
+		}
+		apis, err := m.client.ListHttpAPIs(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range apis {
+			if apis[i].Name == target.APIName {
+				stages, err := m.client.GetHttpAPIStages(ctx, apis[i].ID)
+				return &apis[i], stages, err
+			}
+		}
+		return nil, nil, fmt.Errorf("HTTP API %s not found", target.APIName)
+	}
+
+	if target.APIID != "" {
+		if api, err := m.client.GetRestAPI(ctx, target.APIID); err == nil && (target.APIName == "" || api.Name == target.APIName) {
+			stages, err := m.client.GetRestAPIStages(ctx, api.ID)
+			return api, stages, err
+		}
+	}
+	apis, err := m.client.ListRestAPIs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range apis {
+		if apis[i].Name == target.APIName {
+			stages, err := m.client.GetRestAPIStages(ctx, apis[i].ID)
+			return &apis[i], stages, err
+		}
+	}
+	return nil, nil, fmt.Errorf("REST API %s not found", target.APIName)
+}
+
+// findMSKTunnelTarget loads the bootstrap brokers of an MSK cluster and finds a
+// jump host in the cluster's VPC to reach them through.
+func (m *Model) findMSKTunnelTarget(cluster model.MSKCluster) tea.Cmd {
+	jumpHostConfig := ""
+	jumpHostTagConfig := m.jumpHostTag()
+	if m.cfg != nil {
+		jumpHostConfig = m.cfg.GetJumpHost(m.state.Profile)
+	}
+	defaultTags, defaultNames := m.jumpHostDefaults()
+	brokers := m.state.MSKBrokers
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		if brokers == nil || brokers.ClusterARN != cluster.ARN {
+			var err error
+			brokers, err = m.client.GetMSKBootstrapBrokers(ctx, cluster.ARN)
+			if err != nil {
+				return mskTunnelTargetMsg{cluster: cluster, err: err}
+			}
+		}
+		if _, preferred := brokers.Preferred(); preferred == "" {
+			return mskTunnelTargetMsg{cluster: cluster, err: fmt.Errorf("cluster has no bootstrap brokers yet")}
+		}
+
+		vpcID, err := m.client.GetSubnetVPC(ctx, cluster.ClientSubnets[0])
+		if err != nil {
+			return mskTunnelTargetMsg{cluster: cluster, err: err}
+		}
+
+		jumpHost, err := m.client.FindJumpHost(ctx, vpcID, jumpHostConfig, jumpHostTagConfig, defaultTags, defaultNames, vpcID)
+		if err != nil {
+			return mskTunnelTargetMsg{cluster: cluster, err: fmt.Errorf("failed to find jump host: %w", err)}
+		}
+		return mskTunnelTargetMsg{cluster: cluster, brokers: brokers, jumpHost: *jumpHost}
+	}
+}
+
+// startMSKTunnels opens one tunnel per bootstrap broker. Each broker always
+// gets the same local port (see mskLocalPort), so client configs keep working
+// across sessions. The local bootstrap string is copied to the clipboard.
+func (m *Model) startMSKTunnels(cluster model.MSKCluster, brokers model.MSKBootstrapBrokers, jumpHost model.EC2Instance) tea.Cmd {
+	method, preferred := brokers.Preferred()
+	m.logger.Info("Tunneling to %s brokers (%s) via %s (%s)", cluster.Name, method, jumpHost.Name, jumpHost.InstanceID)
+
+	var cmds []tea.Cmd
+	var local []string
+	for i, endpoint := range splitBrokers(preferred) {
+		host, port, err := splitHostPort(endpoint)
+		if err != nil {
+			m.logger.Warn("Skipping broker %q: %v", endpoint, err)
+			continue
+		}
+		broker := brokerNumber(host, i)
+		localPort := mskLocalPort(port, broker)
+		name := fmt.Sprintf("msk-%s-b-%d", cluster.Name, broker)
+		cmds = append(cmds, m.startJumpHostTunnel(jumpHost, name, host, port, localPort))
+		local = append(local, fmt.Sprintf("localhost:%d", localPort))
+	}
+
+	if len(local) > 0 {
+		bootstrap := strings.Join(local, ",")
+		if err := copyToClipboard(bootstrap); err != nil {
+			m.logger.Info("Local bootstrap servers: %s", bootstrap)
+		} else {
+			m.logger.Info("Local bootstrap servers (copied): %s", bootstrap)
+		}
+		m.logger.Info("Brokers advertise their VPC addresses; map each one to its local port in your client")
+	}
+	return tea.Batch(cmds...)
+}
+
+// startTaskIPTunnel forwards to the private IP of an awsvpc task through a
+// jump host in the task's VPC. It is the fallback for tasks without ECS Exec,
+// whose containers have no RuntimeID to open an SSM session against.
+// containerName and remotePort pick the port as in the port dialog; empty and
+// 0 use the best port of the first container that exposes one.
+func (m *Model) startTaskIPTunnel(service model.Service, task model.Task, containerName string, remotePort, localPort int) tea.Cmd {
+	if remotePort == 0 {
+		var container *model.Container
+		for i := range task.Containers {
+			c := &task.Containers[i]
+			if containerName != "" && c.Name == containerName {
+				container = c
+				break
+			}
+			if container == nil && len(c.GetExposedPorts()) > 0 {
+				container = c
+			}
+		}
+		if container == nil && len(task.Containers) > 0 {
+			container = &task.Containers[0]
+		}
+		if container == nil {
+			m.logger.Error("Task %s has no containers to forward to", task.TaskID)
+			return nil
+		}
+		remotePort = container.GetBestPort()
+	}
+
+	jumpHostConfig := ""
+	jumpHostTagConfig := m.jumpHostTag()
+	if m.cfg != nil {
+		jumpHostConfig = m.cfg.GetJumpHost(m.state.Profile)
+	}
+	defaultTags, defaultNames := m.jumpHostDefaults()
+	m.logger.Info("ECS Exec is not enabled for '%s', forwarding to task IP %s:%d through a jump host", service.Name, task.PrivateIP, remotePort)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		var vpcID string
+		if task.SubnetID != "" {
+			var err error
+			if vpcID, err = m.client.GetSubnetVPC(ctx, task.SubnetID); err != nil {
+				return tunnelStartedMsg{err: err}
+			}
+		}
+		jumpHost, err := m.client.FindJumpHost(ctx, vpcID, jumpHostConfig, jumpHostTagConfig, defaultTags, defaultNames, vpcID)
+		if err != nil {
+			return tunnelStartedMsg{err: fmt.Errorf("failed to find jump host: %w", err)}
+		}
+
+		tunnel, err := m.tunnelManager.StartJumpHostTunnel(ctx, *jumpHost, service.Name, task.PrivateIP, remotePort, localPort)
+		return tunnelStartedMsg{tunnel: tunnel, err: err}
+	}
+}
+
+// startJumpHostTunnel starts a tunnel to remoteHost:remotePort through a jump host.
+func (m *Model) startJumpHostTunnel(jumpHost model.EC2Instance, name, remoteHost string, remotePort, localPort int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		tunnel, err := m.tunnelManager.StartJumpHostTunnel(ctx, jumpHost, name, remoteHost, remotePort, localPort)
+		return tunnelStartedMsg{tunnel: tunnel, err: err}
+	}
+}
+
+// mskLocalPort returns the local port for a broker: the broker port plus
+// 10000, then 10 ports per broker number (b-1 on 9098 gets 19098, b-2 19108).
+// The step leaves room for the other authentication ports (9092-9098).
+func mskLocalPort(remotePort, broker int) int {
+	return remotePort + 10000 + (broker-1)*10
+}
+
+// brokerNumber returns N of an MSK broker host named b-N.<cluster>..., or
+// fallback+1 for hosts without a broker number (e.g. serverless bootstrap).
+func brokerNumber(host string, fallback int) int {
+	if rest, ok := strings.CutPrefix(host, "b-"); ok {
+		if dot := strings.IndexByte(rest, '.'); dot > 0 {
+			if n, err := strconv.Atoi(rest[:dot]); err == nil && n > 0 {
+				return n
+			}
+		}
+	}
+	return fallback + 1
+}
+
+// splitBrokers splits a comma-separated bootstrap broker string.
+func splitBrokers(brokers string) []string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(brokers, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// splitHostPort splits a host:port endpoint.
+func splitHostPort(endpoint string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port %q", portStr)
+	}
+	return host, port, nil
+}
@@ -2,18 +2,23 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"vaws/internal/aws"
 	"vaws/internal/config"
+	"vaws/internal/deeplink"
 	"vaws/internal/log"
 	"vaws/internal/model"
 	"vaws/internal/state"
 	"vaws/internal/tunnel"
 	"vaws/internal/ui/components"
+	"vaws/internal/ui/highlight"
 )
 
 // Layout constants for responsive design
@@ -42,11 +47,14 @@ const (
 // Model is the main bubbletea model.
 type Model struct {
 	// Dependencies
-	client        *aws.Client
+	client        aws.API
 	logger        *log.Logger
 	tunnelManager *tunnel.Manager
 	apiGWManager  *tunnel.APIGatewayManager
+	prereqs       *tunnel.Prerequisites // Programs tunnels and shells run, nil until checked
 	cfg           *config.Config
+	layout        config.Layout  // Pane sizes per view, saved by the resize keys
+	queries       config.Queries // Recent and saved DynamoDB queries per table
 
 	// State
 	state *state.State
@@ -56,18 +64,49 @@ type Model struct {
 	mainMenuList        *components.List // Main menu with resource type selection
 	stacksList          *components.List
 	stackResourcesList  *components.List
+	stackEventsList     *components.List
 	clustersList        *components.List // ECS clusters list
 	serviceList         *components.List
+	tasksList           *components.List
+	taskContainersList  *components.List
 	lambdaList          *components.List
+	appRunnerList       *components.List
+	firehoseList        *components.List
+	userPoolList        *components.List
+	cognitoUserList     *components.List
+	resourceTypeList    *components.List
+	mskList             *components.List
+	mqList              *components.List
+	databaseList        *components.List
+	instanceList        *components.List
+	efsList             *components.List
+	schedulesList       *components.List
+	secretsList         *components.List
+	logGroupsList       *components.List
+	sesList             *components.List
+	sesSuppressionList  *components.List
+	activityList        *components.List
+	logSearchList       *components.List
+	healthList          *components.List
+	runtimesList        *components.List
+	alertsList          *components.List
+	statsList           *components.List
+	timelineList        *components.List
+	cloudResourceList   *components.List
 	apiGatewayList      *components.List
 	apiStagesList       *components.List
 	ec2List             *components.List            // For jump host selection
 	containerList       *components.List            // For container selection in port forwarding
+	endpointList        *components.List            // For discovered endpoint selection
 	sqsTable             *components.SQSTable             // For SQS queues table view
 	sqsDetails           *components.SQSDetails           // For SQS queue details view
+	imagesTable          *components.ImagesTable          // For the image freshness report
 	dynamodbTable        *components.DynamoDBTable        // For DynamoDB tables view
 	dynamodbQueryDialog  *components.DynamoDBQueryDialog  // For DynamoDB query input
+	timeRangePicker      *components.TimeRangePicker      // For choosing the time range of a view
 	dynamodbQueryResults *components.DynamoDBQueryResults // For DynamoDB query results
+	diffViewer           *components.Diff                 // For comparing documents
+	monitor              *components.Monitor              // For the monitor dashboard
 	details              *components.Details
 	logs                *components.Logs
 	tunnelsPanel        *components.TunnelsPanel
@@ -83,18 +122,27 @@ type Model struct {
 	regionSelector *components.RegionSelector
 
 	// Filter input
-	filterInput textinput.Model
-	filtering   bool
+	filterInput        textinput.Model
+	filtering          bool
+	filterSeq          int       // Bumped per keystroke, so only the last debounce tick applies
+	filterPendingSince time.Time // First keystroke not applied yet, zero if none
 
 	// Details search input
 	detailsSearchInput textinput.Model
 	detailsSearching   bool
 
+	// jq expression input for the active JSON tree
+	jsonFilterInput textinput.Model
+	jsonFiltering   bool
+
 	// Port forward input
 	portInput          textinput.Model
 	enteringPort       bool
 	pendingPortForward *model.Service
 	pendingLocalPort   int // Stores local port while selecting container
+	pendingRemotePort  int // Stores remote port picked in the dialog while selecting container
+	portOptions        []portOption
+	portOptionIdx      int
 
 	// Lambda invocation input
 	payloadInput          textinput.Model
@@ -104,6 +152,166 @@ type Model struct {
 	// API Gateway port forward
 	pendingAPIGWPortForward *model.APIStage
 	pendingAPIGWAPI         interface{} // *model.RestAPI or *model.HttpAPI
+	pendingAPIGWMock        bool        // Serve a mock of the stage instead
+
+	// Database port forward
+	pendingDatabase *model.Database
+
+	// EC2 instance port forward
+	pendingInstance *model.EC2Instance
+
+	// API Gateway proxy rules input
+	proxyRulesInput    textinput.Model
+	editingProxyRules  bool
+	pendingRulesTunnel string // ID of the tunnel whose rules are being edited
+
+	// Action waiting for y/n confirmation
+	pendingConfirm *confirmPrompt
+
+	// Terminal title and notification tracking
+	term terminalState
+
+	// Macro recording and replay
+	macro macroState
+
+	// Auto-refresh failures per view
+	backoff refreshBackoff
+
+	// Health probes through tunnels
+	probes tunnelProbes
+
+	// Dead-letter queue messages saved to files
+	dlq dlqExports
+
+	// Watch expressions and the alerts they raised
+	alerts watchAlerts
+
+	// Timings of the event loop, for :stats
+	stats diagnostics
+
+	// What was done in the session, for :timeline
+	timeline timeline
+
+	// Resource lists of the last run, shown while the first loads run
+	warm warmCache
+
+	// Grouping of the stacks list
+	stackGroups stackGrouping
+
+	// Versions of stacks, services and functions when first listed
+	changes changeTracker
+
+	// Highlight rules of the config, by kind of resource
+	highlights map[string]highlight.Rules
+
+	// Terraform resources of the profile's states, by the ARNs they manage
+	terraform terraformStates
+
+	// Local notes by ARN, and the resource whose note is open in $EDITOR
+	notes    map[string]config.Note
+	noteARN  string
+	noteName string
+
+	// Stack whose policy is open in $EDITOR
+	policyStack string
+
+	// DynamoDB item open in $EDITOR
+	editingItem *editedItem
+
+	// Resources opened this session, newest first, for the command palette
+	recentResources []components.PaletteResource
+
+	// Resource to open once connected, from vaws open
+	startLink *deeplink.Link
+
+	// Cognito user search input
+	userSearchInput   textinput.Model
+	searchingUsers    bool
+	pendingSearchPool *model.UserPool
+
+	// SES test email recipient input
+	sesRecipientInput    textinput.Model
+	enteringSESRecipient bool
+	pendingSESFrom       string // Sender address of the test email
+
+	// Traffic shifting dialog of a Lambda function's aliases
+	traffic *aliasTraffic
+
+	// Deployment history dialog of a REST API stage
+	deployments *stageDeployments
+
+	// Dialog showing and toggling the scale-in protection of a service's tasks
+	protection *taskProtectionDialog
+
+	// Full details of a task opened with d in the tasks view
+	taskDetails *taskDetailsDialog
+
+	// Consumers and producers of a queue, and those mapped so far by queue ARN
+	queueMap       *queueMap
+	queueRelations map[string]*model.QueueRelations
+
+	// Panel tying the DLQ messages of a queue to its consumers' errors
+	queueFailures *queueFailuresPanel
+
+	// Dialog sending a message to a queue
+	sendMessage *sendMessageDialog
+
+	// File the CloudWatch logs being tailed are appended to
+	logExport *logExport
+
+	// Tail merging the logs of every task of a service
+	mergedTail *mergedTail
+
+	// Panel of a Lambda function's durations, cold starts and memory use
+	lambdaPerformance *lambdaPerformancePanel
+
+	// Dialog stopping a share of a service's tasks
+	chaos *taskChaos
+
+	// Log groups marked for a bulk action by name, and the retention dialog
+	logGroupMarks map[string]bool
+	retention     *retentionDialog
+
+	// Panel of the month's estimated charges and budgets
+	costs *costsPanel
+
+	// Dashboard summing up the services of a cluster
+	clusterDash *clusterDashboard
+
+	// Dialog choosing the columns of the current view's table
+	columns *columnChooser
+
+	// Describes the stack under the cursor, of which the list has a summary
+	stackDescriber stackDescriber
+
+	// A tick is scheduled to read the events of a stack being changed again
+	stackEventsPolling bool
+
+	// Template of a stack shown in the details pane with T
+	stackTemplate *stackTemplate
+
+	// Reads the ECR scans of the images of the service under the cursor
+	imageScans imageScanner
+
+	// Reads the resources carrying the tag filter that scopes every list
+	tags tagScope
+
+	// Dialog listing the accounts of the organization, and the member
+	// account switched to with it, if any
+	accounts *accountPicker
+	account  *memberAccount
+
+	// Stack log search pattern input
+	logSearchInput        textinput.Model
+	searchingLogs         bool
+	pendingLogSearchStack string
+
+	// Time ranges chosen per view, kept for the session
+	timeRanges map[state.View]model.TimeRange
+
+	// Counts the times the CloudWatch logs were read again from the start, so
+	// that reads made before are dropped
+	cloudWatchLogsSeq int
 
 	// Key bindings
 	keys KeyMap
@@ -115,24 +323,50 @@ type Model struct {
 	// Status
 	ready      bool
 	showSplash bool
+
+	// Startup phases timed until the first view shows, nil after; and
+	// whether fast_start holds back the startup loads until a view opens
+	startup         *startupTimer
+	startupDeferred bool
 	copyMode       bool // Copy mode for clean text selection
 	copyModeScroll int  // Scroll offset for copy mode content
 
 	// Profile selection mode (when no profile specified on command line)
 	pendingRegion        string
 	awaitingClientCreate bool
+	ssoProfiles          map[string]model.AWSProfile // SSO profiles of the selector, by name
+
+	// Focused pane fills the content area (toggled with z)
+	paneZoomed bool
+
+	// Panel scrolling keys apply to, cycled with tab or ctrl+w
+	focus focusPane
+
+	// Lambda functions described in the session
+	lambdas lambdaCache
 
-	// Track view before region selection to return to it
+	// Monitor dashboard panels; monitorGen drops refreshes from before the
+	// dashboard was last reopened or changed
+	monitorPanels []*monitorPanel
+	monitorGen    int
+
+	// Track view before region selection to return to it, and the profile
+	// whose regions the selector lists
 	viewBeforeRegionSelect state.View
+	regionsProfile         string
 
 	// Lazy loading channels
 	functionsResultChan chan functionsLoadedMsg
 	queuesResultChan    chan queuesLoadedMsg
 	tablesResultChan    chan tablesLoadedMsg
+
+	// Progress updates from loaders, shown in the loading indicator
+	progressChan chan loaderProgressMsg
 }
 
 // New creates a new Model.
-func New(client *aws.Client, logger *log.Logger, version string) *Model {
+func New(client aws.API, logger *log.Logger, version string) *Model {
+	started := time.Now()
 	ti := textinput.New()
 	ti.Placeholder = "Type to filter..."
 	ti.CharLimit = 64
@@ -144,15 +378,40 @@ func New(client *aws.Client, logger *log.Logger, version string) *Model {
 
 	payloadInput := textinput.New()
 	payloadInput.Placeholder = "{} or press Enter for empty payload"
-	payloadInput.CharLimit = 10000
+	payloadInput.CharLimit = 0 // Payloads edited in $EDITOR can be large
 	payloadInput.Width = 60
 
+	proxyRulesInput := textinput.New()
+	proxyRulesInput.Placeholder = "x-api-key: abc123; /v1 -> /v2"
+	proxyRulesInput.CharLimit = 2000
+	proxyRulesInput.Width = 60
+
 	detailsSearchInput := textinput.New()
 	detailsSearchInput.Placeholder = "Search..."
 	detailsSearchInput.CharLimit = 64
 
+	jsonFilterInput := textinput.New()
+	jsonFilterInput.Placeholder = ".Items[] | {id, status}"
+	jsonFilterInput.CharLimit = 512
+
+	userSearchInput := textinput.New()
+	userSearchInput.Placeholder = "jane@example.com or username prefix"
+	userSearchInput.CharLimit = 128
+	userSearchInput.Width = 50
+
+	sesRecipientInput := textinput.New()
+	sesRecipientInput.Placeholder = "success@simulator.amazonses.com"
+	sesRecipientInput.CharLimit = 254
+	sesRecipientInput.Width = 50
+
+	logSearchInput := textinput.New()
+	logSearchInput.Placeholder = "ERROR or { $.level = \"error\" }"
+	logSearchInput.CharLimit = 1024
+	logSearchInput.Width = 50
+
 	// Load configuration
 	cfg, _ := config.Load()
+	applyDisplayConfig(cfg)
 
 	statusBar := components.NewStatusBar()
 	statusBar.SetVersion(version)
@@ -161,26 +420,61 @@ func New(client *aws.Client, logger *log.Logger, version string) *Model {
 	m := &Model{
 		client:              client,
 		logger:              logger,
-		tunnelManager:       tunnel.NewManager(client.Profile(), client.Region()),
-		apiGWManager:        tunnel.NewAPIGatewayManager(client.Profile(), client.Region()),
+		tunnelManager:       newTunnelManager(cfg, client.Profile(), client.Region()),
+		apiGWManager:        newAPIGatewayManager(cfg, client.Profile(), client.Region()),
 		cfg:                 cfg,
+		layout:              config.LoadLayout(),
+		queries:             config.LoadQueries(),
+		notes:               config.LoadNotes(),
 		state:               state.New(),
 		splash:              components.NewSplash(version),
 		mainMenuList:        components.NewList("AWS Resources"),
 		stacksList:          components.NewList("CloudFormation Stacks"),
 		stackResourcesList:  components.NewList("Stack Resources"),
+		stackEventsList:     components.NewList("Stack Events"),
 		clustersList:        components.NewList("ECS Clusters"),
 		serviceList:         components.NewList("ECS Services"),
+		tasksList:           components.NewList("Tasks"),
+		taskContainersList:  components.NewList("Containers"),
 		lambdaList:          components.NewList("Lambda Functions"),
+		appRunnerList:       components.NewList("App Runner Services"),
+		firehoseList:        components.NewList("Firehose Delivery Streams"),
+		userPoolList:        components.NewList("Cognito User Pools"),
+		cognitoUserList:     components.NewList("Cognito Users"),
+		resourceTypeList:    components.NewList("Resource Types"),
+		mskList:             components.NewList("MSK Clusters"),
+		mqList:              components.NewList("MQ Brokers"),
+		databaseList:        components.NewList("Databases"),
+		instanceList:        components.NewList("EC2 Instances"),
+		efsList:             components.NewList("EFS File Systems"),
+		schedulesList:       components.NewList("Schedules"),
+		secretsList:         components.NewList("Secrets"),
+		logGroupsList:       components.NewList("Log Groups"),
+		sesList:             components.NewList("SES"),
+		sesSuppressionList:  components.NewList("Suppression List"),
+		activityList:        components.NewList("Activity"),
+		logSearchList:       components.NewList("Log Search"),
+		healthList:          components.NewList("Account Health"),
+		runtimesList:        components.NewList("Lambda Runtimes"),
+		alertsList:          components.NewList("Alerts"),
+		statsList:           components.NewList("Diagnostics"),
+		timelineList:        components.NewList("Timeline"),
+		cloudResourceList:   components.NewList("Resources"),
 		apiGatewayList:      components.NewList("API Gateway"),
 		apiStagesList:       components.NewList("API Stages"),
 		ec2List:             components.NewList("Select Jump Host"),
 		containerList:       components.NewList("Select Container"),
+		endpointList:        components.NewList("Select Endpoint"),
 		sqsTable:            components.NewSQSTable(),
 		sqsDetails:          components.NewSQSDetails(),
+		imagesTable:         components.NewImagesTable(),
 		dynamodbTable:        components.NewDynamoDBTable(),
 		dynamodbQueryDialog:  components.NewDynamoDBQueryDialog(),
+		timeRangePicker:      components.NewTimeRangePicker(),
 		dynamodbQueryResults: components.NewDynamoDBQueryResults(),
+		diffViewer:           components.NewDiff(),
+		monitor:              components.NewMonitor(),
+		progressChan:         make(chan loaderProgressMsg, 64),
 		details:              components.NewDetails(),
 		logs:                 components.NewLogs(logger),
 		tunnelsPanel:         components.NewTunnelsPanel(),
@@ -194,13 +488,24 @@ func New(client *aws.Client, logger *log.Logger, version string) *Model {
 		filterInput:          ti,
 		portInput:            portInput,
 		payloadInput:         payloadInput,
+		proxyRulesInput:      proxyRulesInput,
+		userSearchInput:      userSearchInput,
+		sesRecipientInput:    sesRecipientInput,
+		logSearchInput:       logSearchInput,
 		detailsSearchInput:   detailsSearchInput,
+		jsonFilterInput:      jsonFilterInput,
 		keys:                 DefaultKeyMap(),
-		showSplash:           true,
+		stats:                diagnostics{started: time.Now()},
 	}
 
 	m.state.Profile = client.Profile()
 	m.state.Region = client.Region()
+	m.warnUnknownActions()
+	m.loadHighlights()
+	m.loadColumns()
+	m.showSplash = !m.fastStart()
+	m.startup = newStartupTimer(started, m.showSplash)
+	m.startup.mark("model")
 
 	return m
 }
@@ -221,15 +526,40 @@ func NewWithProfileSelection(profiles []string, region string, logger *log.Logge
 	payloadInput.CharLimit = 10000
 	payloadInput.Width = 60
 
+	proxyRulesInput := textinput.New()
+	proxyRulesInput.Placeholder = "x-api-key: abc123; /v1 -> /v2"
+	proxyRulesInput.CharLimit = 2000
+	proxyRulesInput.Width = 60
+
 	detailsSearchInput := textinput.New()
 	detailsSearchInput.Placeholder = "Search..."
 	detailsSearchInput.CharLimit = 64
 
+	jsonFilterInput := textinput.New()
+	jsonFilterInput.Placeholder = ".Items[] | {id, status}"
+	jsonFilterInput.CharLimit = 512
+
+	userSearchInput := textinput.New()
+	userSearchInput.Placeholder = "jane@example.com or username prefix"
+	userSearchInput.CharLimit = 128
+	userSearchInput.Width = 50
+
+	sesRecipientInput := textinput.New()
+	sesRecipientInput.Placeholder = "success@simulator.amazonses.com"
+	sesRecipientInput.CharLimit = 254
+	sesRecipientInput.Width = 50
+
+	logSearchInput := textinput.New()
+	logSearchInput.Placeholder = "ERROR or { $.level = \"error\" }"
+	logSearchInput.CharLimit = 1024
+	logSearchInput.Width = 50
+
 	profileSelector := components.NewProfileSelector()
 	profileSelector.SetProfiles(profiles)
 
 	// Load configuration
 	cfg, _ := config.Load()
+	applyDisplayConfig(cfg)
 
 	statusBar := components.NewStatusBar()
 	statusBar.SetVersion(version)
@@ -241,23 +571,58 @@ func NewWithProfileSelection(profiles []string, region string, logger *log.Logge
 		tunnelManager:       nil, // Will be created after profile selection
 		apiGWManager:        nil, // Will be created after profile selection
 		cfg:                 cfg,
+		layout:              config.LoadLayout(),
+		queries:             config.LoadQueries(),
+		notes:               config.LoadNotes(),
 		state:               state.New(),
 		splash:              components.NewSplash(version),
 		mainMenuList:        components.NewList("AWS Resources"),
 		stacksList:          components.NewList("CloudFormation Stacks"),
 		stackResourcesList:  components.NewList("Stack Resources"),
+		stackEventsList:     components.NewList("Stack Events"),
 		clustersList:        components.NewList("ECS Clusters"),
 		serviceList:         components.NewList("ECS Services"),
+		tasksList:           components.NewList("Tasks"),
+		taskContainersList:  components.NewList("Containers"),
 		lambdaList:          components.NewList("Lambda Functions"),
+		appRunnerList:       components.NewList("App Runner Services"),
+		firehoseList:        components.NewList("Firehose Delivery Streams"),
+		userPoolList:        components.NewList("Cognito User Pools"),
+		cognitoUserList:     components.NewList("Cognito Users"),
+		resourceTypeList:    components.NewList("Resource Types"),
+		mskList:             components.NewList("MSK Clusters"),
+		mqList:              components.NewList("MQ Brokers"),
+		databaseList:        components.NewList("Databases"),
+		instanceList:        components.NewList("EC2 Instances"),
+		efsList:             components.NewList("EFS File Systems"),
+		schedulesList:       components.NewList("Schedules"),
+		secretsList:         components.NewList("Secrets"),
+		logGroupsList:       components.NewList("Log Groups"),
+		sesList:             components.NewList("SES"),
+		sesSuppressionList:  components.NewList("Suppression List"),
+		activityList:        components.NewList("Activity"),
+		logSearchList:       components.NewList("Log Search"),
+		healthList:          components.NewList("Account Health"),
+		runtimesList:        components.NewList("Lambda Runtimes"),
+		alertsList:          components.NewList("Alerts"),
+		statsList:           components.NewList("Diagnostics"),
+		timelineList:        components.NewList("Timeline"),
+		cloudResourceList:   components.NewList("Resources"),
 		apiGatewayList:      components.NewList("API Gateway"),
 		apiStagesList:       components.NewList("API Stages"),
 		ec2List:             components.NewList("Select Jump Host"),
 		containerList:       components.NewList("Select Container"),
+		endpointList:        components.NewList("Select Endpoint"),
 		sqsTable:             components.NewSQSTable(),
 		sqsDetails:           components.NewSQSDetails(),
+		imagesTable:          components.NewImagesTable(),
 		dynamodbTable:        components.NewDynamoDBTable(),
 		dynamodbQueryDialog:  components.NewDynamoDBQueryDialog(),
+		timeRangePicker:      components.NewTimeRangePicker(),
 		dynamodbQueryResults: components.NewDynamoDBQueryResults(),
+		diffViewer:           components.NewDiff(),
+		monitor:              components.NewMonitor(),
+		progressChan:         make(chan loaderProgressMsg, 64),
 		details:              components.NewDetails(),
 		logs:                 components.NewLogs(logger),
 		tunnelsPanel:         components.NewTunnelsPanel(),
@@ -272,36 +637,145 @@ func NewWithProfileSelection(profiles []string, region string, logger *log.Logge
 		filterInput:          ti,
 		portInput:            portInput,
 		payloadInput:         payloadInput,
+		proxyRulesInput:      proxyRulesInput,
+		userSearchInput:      userSearchInput,
+		sesRecipientInput:    sesRecipientInput,
+		logSearchInput:       logSearchInput,
 		detailsSearchInput:   detailsSearchInput,
+		jsonFilterInput:      jsonFilterInput,
 		keys:                 DefaultKeyMap(),
+		stats:                diagnostics{started: time.Now()},
 		showSplash:          false, // Skip splash, go straight to profile selection
 		pendingRegion:       region,
 	}
 
 	m.state.View = state.ViewProfileSelect
 	m.state.Profiles = profiles
+	m.warnUnknownActions()
+	m.loadHighlights()
+	m.loadColumns()
 
 	return m
 }
 
+// newClient creates an AWS client for a profile and region, with the
+// concurrency limits the profile is configured with.
+func (m *Model) newClient(ctx context.Context, profile, region string) (*aws.Client, error) {
+	client, err := aws.NewClient(ctx, profile, region)
+	if err != nil {
+		return nil, err
+	}
+	if m.cfg != nil {
+		client.SetConcurrency(m.cfg.GetConcurrency(profile))
+	}
+	return client, nil
+}
+
+// clearCachedResources drops everything loaded from the previous profile or
+// region, so views reload against the new client.
+func (m *Model) clearCachedResources() {
+	m.state.ClearStacks()
+	m.stackDescriber = stackDescriber{}
+	m.state.ClearServices()
+	m.imageScans = imageScanner{}
+	m.resetTaggedResources()
+	m.state.ClearQueues()
+	m.state.ClearTables()
+	m.state.ClearFunctions()
+	m.state.ClearAppRunnerServices()
+	m.state.ClearDeliveryStreams()
+	m.state.ClearUserPools()
+	m.state.ClearCloudResources()
+	m.state.ClearMSKClusters()
+	m.state.ClearMQBrokers()
+	m.state.ClearDatabases()
+	m.state.ClearInstances()
+	m.state.ClearEFS()
+	m.state.ClearSchedules()
+	m.state.ClearSecrets()
+	m.state.ClearLogGroups()
+	m.logGroupMarks = nil
+	m.state.ClearSES()
+	m.state.ClearActivity()
+	m.state.ClearLogSearch()
+	m.state.ClearHealth()
+	m.state.ClearImages()
+	m.state.ClearAPIs()
+	m.resetMonitor()
+	m.resetWatches()
+	m.recentResources = nil
+	m.queueRelations = nil
+	m.state.Clusters = nil
+	m.state.ClustersError = nil
+	m.warm.stale = nil
+	m.lambdas = lambdaCache{}
+}
+
 // Init implements tea.Model.
 func (m *Model) Init() tea.Cmd {
 	// If in profile selection mode, don't load anything yet
 	if m.state.View == state.ViewProfileSelect {
-		return tea.EnableMouseCellMotion
+		return tea.Batch(tea.EnableMouseCellMotion, m.waitForProgress(), tunnelWatchTick(), checkPrerequisites(), m.loadSSOTokens())
 	}
 	// Start at main menu - don't load stacks automatically
 	// User will select what to load from the main menu
 	m.updateMainMenuList()
+	if m.fastStart() {
+		// Straight to the main menu, loading nothing until a view is opened
+		m.startupDeferred = true
+		return tea.Batch(
+			tea.EnableMouseCellMotion,
+			m.refreshIndicator.TickCmd(),
+			m.waitForProgress(),
+			tunnelWatchTick(),
+			m.openStartView(),
+		)
+	}
 	return tea.Batch(
 		tea.EnableMouseCellMotion,    // Enable mouse for scroll wheel
 		m.splash.TickCmd(),           // Start splash animation
 		m.refreshIndicator.TickCmd(), // Start auto-refresh timer
+		m.waitForProgress(),          // Feed loading indicators
+		tunnelWatchTick(),            // Notify when tunnels die
+		m.openStartView(),            // Account health, if configured
+		checkPrerequisites(),         // Grey out tunnels if the AWS CLI or plugin is missing
+		m.loadTerraform(),            // Terraform addresses, if the profile has states
 	)
 }
 
 // Update implements tea.Model.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// A key typed during a replay takes over from the macro
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.stopMacro()
+	}
+	start := time.Now()
+	next, cmd := m.update(msg)
+	m.stats.messages++
+	m.stats.update.add(time.Since(start))
+	if titleCmd := m.syncTerminalTitle(); titleCmd != nil {
+		cmd = tea.Batch(cmd, titleCmd)
+	}
+	m.dropStackTemplate()
+	if describeCmd := m.describeSelectedStack(); describeCmd != nil {
+		cmd = tea.Batch(cmd, describeCmd)
+	}
+	if scanCmd := m.scanSelectedService(); scanCmd != nil {
+		cmd = tea.Batch(cmd, scanCmd)
+	}
+	if tagCmd := m.loadTaggedResources(); tagCmd != nil {
+		cmd = tea.Batch(cmd, tagCmd)
+	}
+	m.trackTimelineView()
+	if deferredCmd := m.startDeferredLoads(); deferredCmd != nil {
+		cmd = tea.Batch(cmd, deferredCmd)
+	}
+	m.finishStartup()
+	return next, cmd
+}
+
+// update handles a message and returns the commands it starts.
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -337,6 +811,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		m.recordMacroKey(msg)
+
 		// Handle command palette if active
 		if m.commandPalette.IsActive() {
 			result, cmd := m.commandPalette.Update(msg)
@@ -369,29 +845,46 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if inputCmd != nil {
 				cmds = append(cmds, inputCmd)
 			}
+			if filterCmd := m.scheduleFilter(); filterCmd != nil {
+				cmds = append(cmds, filterCmd)
+			}
 		}
 
 	case clientCreatedMsg:
 		m.awaitingClientCreate = false
 		if msg.err != nil {
 			m.logger.Error("Failed to create AWS client: %v", msg.err)
-			// Show error and go back to profile selection
+			// Show error and go back to profile selection, where an
+			// expired SSO token shows
 			m.state.View = state.ViewProfileSelect
-			return m, nil
+			m.profileSelector.SetStatus(GetStyles().StatusError.Render(truncateString(msg.err.Error(), max(m.width-20, 40))))
+			m.startup = nil
+			return m, m.loadSSOTokens()
 		}
 		// AWS client created successfully
 		m.client = msg.client
-		m.tunnelManager = tunnel.NewManager(msg.client.Profile(), msg.client.Region())
-		m.apiGWManager = tunnel.NewAPIGatewayManager(msg.client.Profile(), msg.client.Region())
+		m.account = nil
+		m.state.Account = ""
+		m.tunnelManager = newTunnelManager(m.cfg, msg.client.Profile(), msg.client.Region())
+		m.apiGWManager = newAPIGatewayManager(m.cfg, msg.client.Profile(), msg.client.Region())
 		m.state.Profile = msg.client.Profile()
 		m.state.Region = msg.client.Region()
+		m.recordTimeline(timelineSession, "Connected to profile %s in %s", m.state.Profile, m.state.Region)
+		m.resetMonitor()
+		m.resetWatches()
+		m.resetTerraform()
 		m.state.View = state.ViewMain
-		m.showSplash = true
-		m.splash.SetLoading("Connected to " + msg.client.Region())
+		m.startup.mark("aws client")
 		m.updateComponentSizes()
 		m.updateMainMenuList()
+		if m.fastStart() {
+			m.startupDeferred = true
+			return m, m.openStartView()
+		}
+		m.showSplash = true
+		m.splash.SetLoading("Connected to " + msg.client.Region())
 		// Show main menu - don't load stacks automatically
-		return m, m.splash.TickCmd()
+		return m, tea.Batch(m.splash.TickCmd(), m.openStartView(), m.loadTerraform())
 
 	case regionChangedMsg:
 		if msg.err != nil {
@@ -401,27 +894,37 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Region changed successfully - update client and clear all cached data
 		m.client = msg.client
+		if m.account != nil {
+			m.account.base = msg.base
+		}
 		m.state.Region = msg.region
-		m.tunnelManager = tunnel.NewManager(m.state.Profile, msg.region)
-		m.apiGWManager = tunnel.NewAPIGatewayManager(m.state.Profile, msg.region)
-
-		// Clear all cached data
-		m.state.ClearStacks()
-		m.state.ClearServices()
-		m.state.ClearQueues()
-		m.state.ClearTables()
-		m.state.ClearFunctions()
-		m.state.ClearAPIs()
-		m.state.Clusters = nil
-		m.state.ClustersError = nil
+		m.tunnelManager = newTunnelManager(m.cfg, m.state.Profile, msg.region)
+		m.apiGWManager = newAPIGatewayManager(m.cfg, m.state.Profile, msg.region)
+		m.useAccountCredentials()
+
+		m.clearCachedResources()
 
 		m.logger.Info("Switched to region: %s", msg.region)
+		m.recordTimeline(timelineSession, "Switched to region %s", msg.region)
 
 		// Go back to previous view and refresh its data
 		m.state.View = m.viewBeforeRegionSelect
 		return m, m.handleRefresh()
 
+	case environmentChangedMsg:
+		return m, m.applyEnvironment(msg)
+
+	case regionsListedMsg:
+		return m, m.handleRegionsListed(msg)
+
+	case regionLatenciesMsg:
+		m.regionSelector.SetLatencies(msg.latencies)
+		return m, nil
+
 	case tea.WindowSizeMsg:
+		if !m.ready {
+			m.startup.mark("terminal")
+		}
 		m.width = msg.Width
 		m.height = msg.Height
 		m.ready = true
@@ -446,53 +949,203 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case components.SpinnerTickMsg:
 		// Update list spinners for loading states
 		m.stacksList.Spinner().Tick()
+		m.stackEventsList.Spinner().Tick()
 		m.clustersList.Spinner().Tick()
 		m.serviceList.Spinner().Tick()
+		m.tasksList.Spinner().Tick()
 		m.sqsTable.Spinner().Tick()
+		m.imagesTable.Spinner().Tick()
 		m.dynamodbTable.Spinner().Tick()
 		m.lambdaList.Spinner().Tick()
+		m.appRunnerList.Spinner().Tick()
+		m.firehoseList.Spinner().Tick()
+		m.userPoolList.Spinner().Tick()
+		m.cognitoUserList.Spinner().Tick()
+		m.cloudResourceList.Spinner().Tick()
+		m.mskList.Spinner().Tick()
+		m.mqList.Spinner().Tick()
+		m.databaseList.Spinner().Tick()
+		m.instanceList.Spinner().Tick()
+		m.efsList.Spinner().Tick()
+		m.schedulesList.Spinner().Tick()
+		m.secretsList.Spinner().Tick()
+		m.logGroupsList.Spinner().Tick()
+		m.sesList.Spinner().Tick()
+		m.sesSuppressionList.Spinner().Tick()
+		m.activityList.Spinner().Tick()
+		m.logSearchList.Spinner().Tick()
+		m.healthList.Spinner().Tick()
+		m.runtimesList.Spinner().Tick()
 		m.apiGatewayList.Spinner().Tick()
 		m.ec2List.Spinner().Tick()
 
 		// Keep ticking while anything is loading
-		if m.state.StacksLoading || m.state.ClustersLoading || m.state.ServicesLoading || m.state.QueuesLoading ||
-			m.state.TablesLoading || m.state.FunctionsLoading || m.state.APIsLoading || m.state.EC2InstancesLoading {
+		if m.state.IsLoading() {
 			cmds = append(cmds, m.stacksList.Spinner().TickCmd())
 		}
 
+	case monitorPanelLoadedMsg:
+		cmds = append(cmds, m.handleMonitorPanelLoaded(msg))
+
+	case monitorTickMsg:
+		cmds = append(cmds, m.handleMonitorTick(msg))
+
+	case tunnelWatchTickMsg:
+		m.exportTunnelMetrics()
+		cmds = append(cmds, m.watchTunnels(), m.probeTunnels(), m.exportDLQs(), tunnelWatchTick())
+
+	case dlqExportedMsg:
+		cmds = append(cmds, m.handleDLQExported(msg))
+
+	case statsTickMsg:
+		cmds = append(cmds, m.handleStatsTick(msg))
+
+	case profilesWrittenMsg:
+		m.handleProfilesWritten(msg)
+
+	case prereqsCheckedMsg:
+		m.handlePrereqsChecked(msg)
+
+	case filterDebounceMsg:
+		m.handleFilterDebounce(msg)
+
+	case aliasesLoadedMsg:
+		m.handleAliasesLoaded(msg)
+
+	case aliasShiftedMsg:
+		m.handleAliasShifted(msg)
+
+	case deploymentsLoadedMsg:
+		m.handleDeploymentsLoaded(msg)
+
+	case stageDeploymentSetMsg:
+		cmds = append(cmds, m.handleStageDeploymentSet(msg))
+
+	case chaosTasksLoadedMsg:
+		m.handleChaosTasksLoaded(msg)
+
+	case queueMappedMsg:
+		m.handleQueueMapped(msg)
+
+	case queueFailuresLoadedMsg:
+		m.handleQueueFailuresLoaded(msg)
+
+	case messageSentMsg:
+		m.handleMessageSent(msg)
+
+	case lambdaPerformanceLoadedMsg:
+		m.handleLambdaPerformanceLoaded(msg)
+
+	case reportWrittenMsg:
+		m.handleReportWritten(msg)
+
+	case ssoTokensLoadedMsg:
+		m.handleSSOTokensLoaded(msg)
+
+	case ssoTokenRefreshedMsg:
+		return m, m.handleSSOTokenRefreshed(msg)
+
+	case ssoLoginFinishedMsg:
+		return m, m.handleSSOLoginFinished(msg)
+
+	case terraformLoadedMsg:
+		m.handleTerraformLoaded(msg)
+
+	case taskProtectionLoadedMsg:
+		m.handleTaskProtectionLoaded(msg)
+
+	case taskProtectionUpdatedMsg:
+		m.handleTaskProtectionUpdated(msg)
+
+	case tasksStoppedMsg:
+		cmds = append(cmds, m.handleTasksStopped(msg))
+
+	case watchCheckedMsg:
+		cmds = append(cmds, m.handleWatchChecked(msg))
+
+	case alertBlinkMsg:
+		cmds = append(cmds, m.handleAlertBlink())
+
+	case tunnelProbedMsg:
+		m.handleTunnelProbed(msg)
+
+	case macroStepMsg:
+		cmds = append(cmds, m.handleMacroStep(msg))
+
+	case loaderProgressMsg:
+		// Ignore updates from a load that has been replaced
+		if msg.gen == msg.progress.Generation() {
+			msg.progress.Update(msg.call, msg.unit, msg.done, msg.total)
+		}
+		cmds = append(cmds, m.waitForProgress())
+
 	case components.AutoRefreshTickMsg:
 		// Auto-refresh current view data
 		if m.state.AutoRefresh && !m.showSplash && m.client != nil {
 			m.refreshIndicator.Tick()
-			m.refreshIndicator.SetRefreshing(true)
 
-			// Refresh based on current view
+			// Refresh based on current view, unless its loads keep failing
 			var refreshCmd tea.Cmd
-			switch m.state.View {
-			case state.ViewStacks:
-				refreshCmd = m.loadStacks()
-			case state.ViewServices:
-				refreshCmd = m.loadServices()
+			if view := m.state.View; m.backoff.due(view, time.Now()) {
+				switch view {
+				case state.ViewStacks:
+					refreshCmd = m.refreshInPlace(m.stacksList, m.loadStacks)
+				case state.ViewServices:
+					refreshCmd = m.refreshInPlace(m.serviceList, m.reloadServices)
+				}
+				if refreshCmd != nil {
+					m.backoff.startAuto(view)
+				}
 			}
 
 			if refreshCmd != nil {
+				m.refreshIndicator.SetRefreshing(true)
 				cmds = append(cmds, refreshCmd)
 			}
 
+			// Watches are checked on the same loop, whatever the view
+			cmds = append(cmds, m.checkWatches(false))
+
 			// Schedule next refresh
 			cmds = append(cmds, m.refreshIndicator.TickCmd())
 		}
 
+	case stackDescribeTickMsg:
+		return m, m.handleStackDescribeTick(msg)
+
+	case stackDescribedMsg:
+		m.handleStackDescribed(msg)
+
+	case stackSafetyMsg:
+		m.handleStackSafety(msg)
+
+	case itemWrittenMsg:
+		return m, m.handleItemWritten(msg)
+
+	case imageScanTickMsg:
+		return m, m.handleImageScanTick(msg)
+
+	case imageScansLoadedMsg:
+		m.handleImageScansLoaded(msg)
+
+	case tagFilterLoadedMsg:
+		m.handleTagFilterLoaded(msg)
+
 	case stacksLoadedMsg:
 		m.state.StacksLoading = false
 		m.refreshIndicator.SetRefreshing(false)
 		if msg.err != nil {
 			m.state.StacksError = msg.err
-			m.logger.Error("Failed to load stacks: %v", msg.err)
+			if m.noteRefresh(state.ViewStacks, msg.err) {
+				m.logger.Error("Failed to load stacks: %v", msg.err)
+			}
 			m.splash.SetLoading("Error loading stacks")
 		} else {
+			m.noteRefresh(state.ViewStacks, nil)
+			m.keepStackDescriptions(msg.stacks)
 			m.state.Stacks = msg.stacks
 			m.state.StacksError = nil
+			cmds = append(cmds, m.saveWarmCache(warmStacks))
 			m.logger.Info("Loaded %d CloudFormation stacks", len(msg.stacks))
 			m.splash.SetLoading(fmt.Sprintf("Loaded %d stacks", len(msg.stacks)))
 			// Auto-dismiss splash when stacks loaded successfully
@@ -507,10 +1160,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.refreshIndicator.SetRefreshing(false)
 		if msg.err != nil {
 			m.state.ServicesError = msg.err
-			m.logger.Error("Failed to load services: %v", msg.err)
+			if m.noteRefresh(state.ViewServices, msg.err) {
+				m.logger.Error("Failed to load services: %v", msg.err)
+			}
 		} else {
+			m.noteRefresh(state.ViewServices, nil)
 			m.state.Services = msg.services
 			m.state.ServicesError = nil
+			cmds = append(cmds, m.watchDeployments(msg.services), m.saveWarmCache(warmServices+m.servicesScope()))
 		}
 		m.updateServicesList()
 
@@ -531,9 +1188,17 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logger.Info("Loaded %d Lambda functions", len(msg.functions))
 			}
 			m.state.FunctionsError = nil
+			m.lambdas.remember(msg.functions)
+			if msg.stack != "" {
+				m.lambdas.setFailures(msg.stack, msg.failed)
+				m.logFunctionFailures(msg.stack, msg.total, msg.failed)
+			}
 
 			// Update UI immediately to show partial results
 			m.updateLambdaList()
+			if m.state.View == state.ViewLambdaRuntimes {
+				m.updateRuntimesList()
+			}
 
 			// Continue loading if more pages available
 			if msg.hasMore {
@@ -544,8 +1209,437 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.FunctionsLoading = false
 			m.lambdaList.SetLoading(false)
 			m.refreshIndicator.SetRefreshing(false)
+			cmds = append(cmds, m.saveWarmCache(warmFunctions))
 		}
 		m.updateLambdaList()
+		if m.state.View == state.ViewLambdaRuntimes {
+			m.updateRuntimesList()
+		}
+
+	case appRunnerServicesLoadedMsg:
+		m.state.AppRunnerLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.AppRunnerError = msg.err
+			m.logger.Error("Failed to load App Runner services: %v", msg.err)
+		} else {
+			m.state.AppRunnerServices = msg.services
+			m.state.AppRunnerError = nil
+			m.logger.Info("Loaded %d App Runner services", len(msg.services))
+			cmds = append(cmds, m.watchAppRunner(msg.services))
+		}
+		m.updateAppRunnerList()
+
+	case appRunnerOperationsLoadedMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to load App Runner operations: %v", msg.err)
+		} else {
+			m.state.AppRunnerOperations = msg.operations
+			m.state.AppRunnerOperationsLoaded = msg.serviceARN
+		}
+		m.updateAppRunnerDetails()
+
+	case appRunnerActionMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to %s %s: %v", msg.action, msg.serviceName, msg.err)
+		} else {
+			m.logger.Info("Requested %s for %s", msg.action, msg.serviceName)
+			return m, m.loadAppRunnerServices()
+		}
+
+	case deliveryStreamsLoadedMsg:
+		m.state.FirehoseLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.FirehoseError = msg.err
+			m.logger.Error("Failed to load delivery streams: %v", msg.err)
+		} else {
+			m.state.DeliveryStreams = msg.streams
+			m.state.FirehoseError = nil
+			m.logger.Info("Loaded %d delivery streams", len(msg.streams))
+		}
+		m.updateFirehoseList()
+
+	case deliveryErrorsLoadedMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to load delivery errors: %v", msg.err)
+		} else {
+			m.state.DeliveryErrors = msg.errors
+			m.state.DeliveryErrorsLoaded = msg.streamName
+			if len(msg.errors) > 0 {
+				m.logger.Warn("%d delivery error(s) for %s in the last hour", len(msg.errors), msg.streamName)
+			}
+		}
+		m.updateFirehoseDetails()
+
+	case testRecordPutMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to put test record to %s: %v", msg.streamName, msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+		} else {
+			m.logger.Info("Put test record %s to %s (record ID %s)", msg.testID, msg.streamName, msg.recordID)
+		}
+
+	case userPoolsLoadedMsg:
+		m.state.UserPoolsLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.UserPoolsError = msg.err
+			m.logger.Error("Failed to load user pools: %v", msg.err)
+		} else {
+			m.state.UserPools = msg.pools
+			m.state.UserPoolsError = nil
+			m.logger.Info("Loaded %d user pools", len(msg.pools))
+		}
+		m.updateUserPoolList()
+
+	case userPoolDetailsLoadedMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to load user pool details: %v", msg.err)
+		} else {
+			m.state.UserPoolDetails = msg.details
+		}
+		m.updateUserPoolDetails()
+
+	case cognitoUsersLoadedMsg:
+		m.state.CognitoUsersLoading = false
+		if msg.err != nil {
+			m.state.CognitoUsersError = msg.err
+			m.logger.Error("Failed to search users: %v", msg.err)
+		} else {
+			m.state.CognitoUsers = msg.users
+			m.state.CognitoUsersError = nil
+			m.logger.Info("Found %d user(s) matching %q", len(msg.users), msg.query)
+		}
+		m.updateCognitoUserList()
+
+	case cognitoUserActionMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to %s %s: %v", msg.action, msg.username, msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+		} else {
+			m.logger.Info("%s %s", cognitoActionDone[msg.action], msg.username)
+			return m, m.loadCognitoUsers()
+		}
+
+	case mskClustersLoadedMsg:
+		m.state.MSKLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.MSKError = msg.err
+			m.logger.Error("Failed to load MSK clusters: %v", msg.err)
+		} else {
+			m.state.MSKClusters = msg.clusters
+			m.state.MSKError = nil
+			m.logger.Info("Loaded %d MSK clusters", len(msg.clusters))
+		}
+		m.updateMSKList()
+
+	case mqBrokersLoadedMsg:
+		m.state.MQLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.MQError = msg.err
+			m.logger.Error("Failed to load MQ brokers: %v", msg.err)
+		} else {
+			m.state.MQBrokers = msg.brokers
+			m.state.MQError = nil
+			m.logger.Info("Loaded %d MQ brokers", len(msg.brokers))
+		}
+		m.updateMQList()
+
+	case mqTunnelTargetMsg:
+		if msg.err != nil {
+			m.logger.Error("Cannot tunnel to %s: %v", msg.broker.Name, msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+			return m, nil
+		}
+		return m, m.startMQTunnels(msg.broker, msg.jumpHost)
+
+	case databasesLoadedMsg:
+		m.state.DatabasesLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.DatabasesError = msg.err
+			m.logger.Error("Failed to load databases: %v", msg.err)
+		} else {
+			m.state.Databases = msg.databases
+			m.state.DatabasesError = nil
+			m.logger.Info("Loaded %d databases", len(msg.databases))
+		}
+		m.updateDatabaseList()
+
+	case instancesLoadedMsg:
+		m.state.InstancesLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.InstancesError = msg.err
+			m.logger.Error("Failed to load EC2 instances: %v", msg.err)
+		} else {
+			m.state.Instances = msg.instances
+			m.state.InstancesError = nil
+			m.logger.Info("Loaded %d EC2 instances", len(msg.instances))
+		}
+		m.updateInstanceList()
+
+	case databaseTunnelTargetMsg:
+		if msg.err != nil {
+			m.logger.Error("Cannot tunnel to %s: %v", msg.database.Name, msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+			return m, nil
+		}
+		return m, m.startDatabaseTunnel(msg.database, msg.jumpHost, msg.localPort)
+
+	case efsFileSystemsLoadedMsg:
+		m.state.EFSLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.EFSError = msg.err
+			m.logger.Error("Failed to load EFS file systems: %v", msg.err)
+		} else {
+			m.state.EFSFileSystems = msg.fileSystems
+			m.state.EFSError = nil
+			m.logger.Info("Loaded %d EFS file systems", len(msg.fileSystems))
+		}
+		m.updateEFSList()
+
+	case efsDetailsLoadedMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to load file system details: %v", msg.err)
+		} else {
+			m.state.EFSDetails = msg.details
+			m.logger.Info("%s: %d mount targets, %d access points, mounted by %d containers and functions",
+				msg.details.FileSystemID, len(msg.details.MountTargets), len(msg.details.AccessPoints), len(msg.details.Mounts))
+		}
+		m.updateEFSDetails()
+
+	case schedulesLoadedMsg:
+		m.state.SchedulesLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.SchedulesError = msg.err
+			m.logger.Error("Failed to load schedules: %v", msg.err)
+		} else {
+			m.state.Schedules = msg.schedules
+			m.state.SchedulesError = nil
+			m.logger.Info("Loaded %d schedules", len(msg.schedules))
+		}
+		m.updateSchedulesList()
+
+	case scheduleActionMsg:
+		return m, m.handleScheduleAction(msg)
+
+	case secretsLoadedMsg:
+		m.state.SecretsLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.SecretsError = msg.err
+			m.logger.Error("Failed to load secrets: %v", msg.err)
+		} else {
+			m.state.Secrets = msg.secrets
+			m.state.SecretsError = nil
+			m.logger.Info("Loaded %d secrets", len(msg.secrets))
+		}
+		m.updateSecretsList()
+
+	case secretRotatedMsg:
+		return m, m.handleSecretRotated(msg)
+
+	case logGroupsLoadedMsg:
+		m.state.LogGroupsLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.LogGroupsError = msg.err
+			m.logger.Error("Failed to load log groups: %v", msg.err)
+		} else {
+			m.state.LogGroups = msg.groups
+			m.state.LogGroupsError = nil
+			never, orphaned := 0, 0
+			for _, g := range msg.groups {
+				if g.NeverExpires() {
+					never++
+				}
+				if g.Orphaned {
+					orphaned++
+				}
+			}
+			m.logger.Info("Loaded %d log groups: %d never expire, %d orphaned", len(msg.groups), never, orphaned)
+		}
+		m.updateLogGroupsList()
+
+	case logGroupsUpdatedMsg:
+		return m, m.handleLogGroupsUpdated(msg)
+
+	case costsLoadedMsg:
+		m.handleCostsLoaded(msg)
+
+	case clusterDashboardLoadedMsg:
+		m.handleClusterDashboardLoaded(msg)
+
+	case orgAccountsLoadedMsg:
+		m.handleOrgAccountsLoaded(msg)
+
+	case accountSwitchedMsg:
+		return m, m.applyAccount(msg)
+
+	case mskBrokersLoadedMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to load bootstrap brokers: %v", msg.err)
+		} else {
+			m.state.MSKBrokers = msg.brokers
+		}
+		m.updateMSKDetails()
+
+	case mskTunnelTargetMsg:
+		if msg.err != nil {
+			m.logger.Error("Cannot tunnel to %s: %v", msg.cluster.Name, msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+			return m, nil
+		}
+		m.state.MSKBrokers = msg.brokers
+		m.updateMSKDetails()
+		return m, m.startMSKTunnels(msg.cluster, *msg.brokers, msg.jumpHost)
+
+	case sesLoadedMsg:
+		m.state.SESLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.SESError = msg.err
+			m.logger.Error("Failed to load SES: %v", msg.err)
+		} else {
+			m.state.SESAccount = msg.account
+			m.state.SESIdentities = msg.identities
+			m.state.SESConfigurationSets = msg.sets
+			m.state.SESError = nil
+			m.logger.Info("Loaded %d SES identities and %d configuration sets", len(msg.identities), len(msg.sets))
+		}
+		m.updateSESList()
+
+	case sesSuppressionsLoadedMsg:
+		m.state.SESSuppressionsLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.SESSuppressionsError = msg.err
+			m.logger.Error("Failed to load suppression list: %v", msg.err)
+		} else {
+			m.state.SESSuppressions = msg.destinations
+			m.state.SESSuppressionsTruncated = msg.truncated
+			m.state.SESSuppressionsError = nil
+			if msg.truncated {
+				m.logger.Warn("Showing the %d most recent suppressed addresses; the list has more", len(msg.destinations))
+			} else {
+				m.logger.Info("Loaded %d suppressed addresses", len(msg.destinations))
+			}
+		}
+		m.updateSESSuppressionList()
+
+	case activityLoadedMsg:
+		// Drop results of a feed that was closed or reopened on another resource
+		if !slices.Equal(msg.names, m.state.ActivityNames) || msg.r != m.timeRange(state.ViewActivity) {
+			return m, nil
+		}
+		m.state.ActivityLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.ActivityError = msg.err
+			m.logger.Error("Failed to load activity of %s: %v", m.state.ActivityResource, msg.err)
+		} else {
+			m.state.ActivityEvents = msg.events
+			m.state.ActivityError = nil
+			m.logger.Info("Loaded %d CloudTrail events of %s", len(msg.events), m.state.ActivityResource)
+		}
+		m.updateActivityList()
+
+	case imagesLoadedMsg:
+		m.handleImagesLoaded(msg)
+
+	case healthLoadedMsg:
+		// Drop checks of a region or environment switched away from
+		if !m.state.HealthLoading {
+			return m, nil
+		}
+		m.state.HealthLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		m.state.Health = msg.health
+		m.logger.Info("Account health: %d failed stacks, %d unhealthy services, %d alarms, %d DLQs with messages, %d expiring certificates",
+			len(msg.health.FailedStacks), len(msg.health.UnhealthyServices), len(msg.health.Alarms),
+			len(msg.health.DLQs), len(msg.health.ExpiringCertificates))
+		m.updateHealthList()
+
+	case logSearchLoadedMsg:
+		// Drop results of a search that was closed or replaced by another one
+		if msg.stack != m.state.LogSearchStack || msg.pattern != m.state.LogSearchPattern || msg.r != m.state.LogSearchRange {
+			return m, nil
+		}
+		m.state.LogSearchLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.LogSearchError = msg.err
+			m.logger.Error("Failed to search logs of stack %s: %v", msg.stack, msg.err)
+		} else {
+			m.state.LogSearchSources = msg.sources
+			m.state.LogSearchHits = msg.hits
+			m.state.LogSearchError = nil
+			m.logger.Info("Found %d log events in %d log groups of stack %s", len(msg.hits), len(msg.sources), msg.stack)
+		}
+		m.updateLogSearchList()
+
+	case sesSuppressionRemovedMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to unsuppress %s: %v", msg.email, msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+		} else {
+			m.logger.Info("Removed %s from the suppression list", msg.email)
+			return m, m.loadSESSuppressions()
+		}
+
+	case sesTestEmailSentMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to send test email from %s: %v", msg.from, msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+		} else {
+			m.logger.Info("Sent test email from %s to %s (message ID %s)", msg.from, msg.to, msg.messageID)
+		}
+
+	case cloudResourcesLoadedMsg:
+		// Ignore results of a type that is no longer shown
+		if msg.typeName != m.state.CloudResourceType {
+			return m, nil
+		}
+		m.state.CloudResourcesLoading = false
+		m.refreshIndicator.SetRefreshing(false)
+		if msg.err != nil {
+			m.state.CloudResourcesError = msg.err
+			m.logger.Error("Failed to load %s resources: %v", msg.typeName, msg.err)
+		} else {
+			m.state.CloudResources = msg.resources
+			m.state.CloudResourcesError = nil
+			m.logger.Info("Loaded %d %s resources", len(msg.resources), msg.typeName)
+		}
+		m.updateCloudResourceList()
+
+	case cloudResourceLoadedMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to get resource: %v", msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+		} else {
+			// Replace the listed properties with the full ones
+			for i := range m.state.CloudResources {
+				r := &m.state.CloudResources[i]
+				if r.TypeName == msg.resource.TypeName && r.Identifier == msg.resource.Identifier {
+					*r = *msg.resource
+				}
+			}
+		}
+		m.updateCloudResourceDetails()
 
 	case restAPIsLoadedMsg:
 		m.state.APIsLoading = false
@@ -618,6 +1712,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if len(containersWithRuntime) == 0 {
+			if task.PrivateIP != "" {
+				return m, m.startTaskIPTunnel(msg.service, task, "", 0, 0)
+			}
 			m.logger.Error("No container with RuntimeID found. Is ECS Exec enabled for service '%s'? Task: %s", msg.service.Name, task.TaskID)
 			m.state.ShowLogs = true
 			m.updateComponentSizes()
@@ -639,9 +1736,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state.PendingContainerTask = &task
 		m.state.PendingContainers = containersWithRuntime
 		m.pendingLocalPort = 0 // Use random port
+		m.pendingRemotePort = 0
 		m.state.View = state.ViewContainerSelect
 		m.updateContainerList()
 
+	case serviceTasksLoadedMsg:
+		m.handleTasksLoaded(msg)
+
+	case stackEventsLoadedMsg:
+		cmds = append(cmds, m.handleStackEventsLoaded(msg))
+
+	case stackEventsTickMsg:
+		cmds = append(cmds, m.handleStackEventsTick(msg))
+
+	case stackTemplateLoadedMsg:
+		m.handleStackTemplateLoaded(msg)
+
 	case tasksLoadedMsgWithPort:
 		if msg.err != nil {
 			m.logger.Error("Failed to load tasks: %v", msg.err)
@@ -667,16 +1777,32 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if len(containersWithRuntime) == 0 {
+			if task.PrivateIP != "" {
+				return m, m.startTaskIPTunnel(msg.service, task, msg.containerName, msg.remotePort, msg.localPort)
+			}
 			m.logger.Error("No container with RuntimeID found. Is ECS Exec enabled for service '%s'? Task: %s", msg.service.Name, task.TaskID)
 			m.state.ShowLogs = true
 			m.updateComponentSizes()
 			return m, nil
 		}
 
+		// Use the container whose port was picked in the dialog, if it has a RuntimeID
+		if msg.containerName != "" {
+			for i := range containersWithRuntime {
+				if containersWithRuntime[i].Name == msg.containerName {
+					containersWithRuntime = containersWithRuntime[i : i+1]
+					break
+				}
+			}
+		}
+
 		// If only one container, use it directly
 		if len(containersWithRuntime) == 1 {
 			container := &containersWithRuntime[0]
 			remotePort := container.GetBestPort()
+			if msg.remotePort > 0 {
+				remotePort = msg.remotePort
+			}
 			localPortStr := "random"
 			if msg.localPort > 0 {
 				localPortStr = fmt.Sprintf("%d", msg.localPort)
@@ -692,9 +1818,43 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state.PendingContainerTask = &task
 		m.state.PendingContainers = containersWithRuntime
 		m.pendingLocalPort = msg.localPort
+		m.pendingRemotePort = msg.remotePort
 		m.state.View = state.ViewContainerSelect
 		m.updateContainerList()
 
+	case taskDefDiffLoadedMsg:
+		if msg.err != nil {
+			m.logger.Error("Failed to compare task definitions for '%s': %v", msg.serviceName, msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+			return m, nil
+		}
+		m.openDiff(shortTaskDefinition(msg.oldTaskDef), msg.oldDoc, shortTaskDefinition(msg.newTaskDef), msg.newDoc)
+		m.logger.Info("%d change(s) between task definitions", m.diffViewer.ChangeCount())
+		return m, nil
+
+	case shellTasksLoadedMsg:
+		return m, m.handleShellTasksLoaded(msg)
+
+	case editorFinishedMsg:
+		return m, m.handleEditorFinished(msg)
+
+	case shellExitedMsg:
+		if msg.err != nil {
+			m.logger.Error("Shell session to %s failed: %v", msg.target, msg.err)
+			m.state.ShowLogs = true
+			m.updateComponentSizes()
+		} else {
+			m.logger.Info("Shell session to %s ended", msg.target)
+		}
+		return m, nil
+
+	case tunnelImportFailedMsg:
+		m.logger.Error("Failed to import tunnel for '%s': %v", msg.target, msg.err)
+		m.state.ShowLogs = true
+		m.updateComponentSizes()
+		return m, nil
+
 	case tasksLoadedMsgForRestart:
 		if msg.err != nil {
 			m.logger.Error("Failed to load tasks for restart: %v", msg.err)
@@ -729,8 +1889,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				ClusterARN:  msg.tunnelInfo.ClusterARN,
 				ClusterName: msg.tunnelInfo.ClusterName,
 			}
-			m.logger.Info("Restarting tunnel for '%s' (local: %d, remote: %d)...", msg.tunnelInfo.ServiceName, msg.tunnelInfo.LocalPort, msg.tunnelInfo.RemotePort)
-			cmds = append(cmds, m.startTunnelWithPort(service, task, *container, msg.tunnelInfo.RemotePort, msg.tunnelInfo.LocalPort))
+			if msg.tunnelInfo.RemoteHost != "" {
+				m.logger.Info("Starting tunnel to %s:%d via '%s'...", msg.tunnelInfo.RemoteHost, msg.tunnelInfo.RemotePort, msg.tunnelInfo.ServiceName)
+				cmds = append(cmds, m.startRemoteHostTunnel(service, task, *container, msg.tunnelInfo.RemoteHost, msg.tunnelInfo.RemotePort, msg.tunnelInfo.LocalPort))
+			} else {
+				m.logger.Info("Restarting tunnel for '%s' (local: %d, remote: %d)...", msg.tunnelInfo.ServiceName, msg.tunnelInfo.LocalPort, msg.tunnelInfo.RemotePort)
+				cmds = append(cmds, m.startTunnelWithPort(service, task, *container, msg.tunnelInfo.RemotePort, msg.tunnelInfo.LocalPort))
+			}
 		} else {
 			m.logger.Error("No container with RuntimeID found for restart. Task: %s", task.TaskID)
 			m.state.ShowLogs = true
@@ -741,8 +1906,18 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			m.logger.Error("Failed to start tunnel: %v", msg.err)
 		} else if msg.tunnel != nil {
-			m.logger.Info("Tunnel started: localhost:%d -> %s:%d",
-				msg.tunnel.LocalPort, msg.tunnel.ServiceName, msg.tunnel.RemotePort)
+			target := msg.tunnel.ServiceName
+			if msg.tunnel.RemoteHost != "" {
+				target = msg.tunnel.RemoteHost
+			}
+			if msg.tunnel.Status == model.TunnelStatusStarting {
+				m.logger.Info("Tunnel starting: localhost:%d -> %s:%d, looking for a relay in %s via ECS Exec...",
+					msg.tunnel.LocalPort, target, msg.tunnel.RemotePort, msg.tunnel.ContainerName)
+			} else {
+				m.logger.Info("Tunnel started: localhost:%d -> %s:%d",
+					msg.tunnel.LocalPort, target, msg.tunnel.RemotePort)
+			}
+			m.recordTimeline(timelineTunnel, "Opened tunnel localhost:%d -> %s:%d", msg.tunnel.LocalPort, target, msg.tunnel.RemotePort)
 		}
 		m.updateTunnelsPanel()
 		// Switch to tunnels view to show the new tunnel
@@ -754,8 +1929,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.ShowLogs = true
 			m.updateComponentSizes()
 		} else if msg.tunnel != nil {
-			m.logger.Info("API Gateway tunnel started: localhost:%d -> %s (%s)",
-				msg.tunnel.LocalPort, msg.tunnel.APIName, msg.tunnel.StageName)
+			if msg.tunnel.TunnelType == model.APIGatewayTunnelMock {
+				m.logger.Info("Mock server started: localhost:%d -> %s (%s), %d routes",
+					msg.tunnel.LocalPort, msg.tunnel.APIName, msg.tunnel.StageName, msg.tunnel.MockRoutes)
+				m.recordTimeline(timelineTunnel, "Started mock server localhost:%d for %s (%s)", msg.tunnel.LocalPort, msg.tunnel.APIName, msg.tunnel.StageName)
+			} else {
+				m.logger.Info("API Gateway tunnel started: localhost:%d -> %s (%s)",
+					msg.tunnel.LocalPort, msg.tunnel.APIName, msg.tunnel.StageName)
+				m.recordTimeline(timelineTunnel, "Opened API Gateway tunnel localhost:%d -> %s (%s)", msg.tunnel.LocalPort, msg.tunnel.APIName, msg.tunnel.StageName)
+				m.applyConfiguredProxyRules(msg.tunnel)
+			}
 			// Switch to tunnels view to show the new tunnel
 			m.state.View = state.ViewTunnels
 		}
@@ -813,17 +1996,22 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		m.stopLogExport("")
+		m.stopMergedTail()
 		m.state.CloudWatchLogConfigs = msg.configs
 		m.state.CloudWatchServiceContext = &msg.service
 		m.state.CloudWatchTaskContext = &msg.task
 		m.state.View = state.ViewCloudWatchLogs
 		m.state.CloudWatchLogsStreaming = true
-		m.state.CloudWatchLastFetchTime = 0
+		m.state.CloudWatchLastFetchTime = m.cloudWatchStartTime(0)
+		m.cloudWatchLogsSeq++
 
 		m.cloudWatchLogsPanel.SetContainers(msg.configs)
 		m.cloudWatchLogsPanel.SetContext(msg.service.Name, msg.task.TaskID)
+		m.cloudWatchLogsPanel.SetRange(m.cloudWatchRangeLabel())
 		m.cloudWatchLogsPanel.SetStreaming(true)
 		m.cloudWatchLogsPanel.Clear()
+		m.cloudWatchLogsPanel.ClearSearch()
 
 		// Start fetching logs and spinner animation
 		return m, tea.Batch(
@@ -833,12 +2021,34 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		)
 
 	case cloudWatchLogsLoadedMsg:
+		// A read made before the logs were read again from the start
+		if msg.seq != m.cloudWatchLogsSeq {
+			return m, nil
+		}
 		if msg.err != nil {
 			m.logger.Error("Failed to fetch CloudWatch logs: %v", msg.err)
 			return m, nil
 		}
 
 		m.state.CloudWatchLastFetchTime = msg.lastTimestamp
+		if msg.merged {
+			msg.entries = m.unseenLogEntries(msg.entries)
+		}
+
+		// A range with an end stops streaming once the logs reach it
+		if end := m.cloudWatchEndTime(); !end.IsZero() {
+			entries := msg.entries[:0:0]
+			for _, e := range msg.entries {
+				if !e.Timestamp.After(end) {
+					entries = append(entries, e)
+				}
+			}
+			if len(entries) < len(msg.entries) || len(msg.entries) < cloudWatchFetchLimit {
+				m.state.CloudWatchLogsStreaming = false
+				m.cloudWatchLogsPanel.SetStreaming(false)
+			}
+			msg.entries = entries
+		}
 
 		if len(m.state.CloudWatchLogs) == 0 {
 			m.state.CloudWatchLogs = msg.entries
@@ -847,6 +2057,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.CloudWatchLogs = append(m.state.CloudWatchLogs, msg.entries...)
 			m.cloudWatchLogsPanel.AppendEntries(msg.entries)
 		}
+		m.appendLogExport(msg.entries)
+		if !m.state.CloudWatchLogsStreaming {
+			m.stopLogExport("the end of the time range was reached")
+		}
+
+	case mergedTasksLoadedMsg:
+		return m, m.handleMergedTasksLoaded(msg)
+
+	case mergedTasksTickMsg:
+		return m, m.handleMergedTasksTick(msg)
 
 	case components.CloudWatchSpinnerTickMsg:
 		// Advance spinner animation and continue if streaming
@@ -858,17 +2078,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case components.CloudWatchLogsTickMsg:
 		// Continue polling if still in CloudWatch logs view and streaming
 		if m.state.View == state.ViewCloudWatchLogs && m.state.CloudWatchLogsStreaming {
-			var fetchCmd tea.Cmd
-			if m.state.CloudWatchLambdaContext != nil {
-				// Lambda logs - query across all streams
-				logGroup := fmt.Sprintf("/aws/lambda/%s", m.state.CloudWatchLambdaContext.Name)
-				fetchCmd = m.fetchLambdaCloudWatchLogs(logGroup)
-			} else {
-				// ECS container logs - query specific stream
-				fetchCmd = m.fetchCloudWatchLogs()
-			}
 			return m, tea.Batch(
-				fetchCmd,
+				m.fetchCurrentCloudWatchLogs(),
 				m.cloudWatchLogsPanel.TickCmd(),
 			)
 		}
@@ -916,6 +2127,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.Clusters = msg.clusters
 			m.state.ClustersError = nil
 			m.logger.Info("Loaded %d ECS clusters", len(msg.clusters))
+			cmds = append(cmds, m.saveWarmCache(warmClusters))
 		}
 		m.updateClustersList()
 
@@ -949,6 +2161,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.TablesLoading = false
 			m.dynamodbTable.SetLoading(false)
 			m.refreshIndicator.SetRefreshing(false)
+			cmds = append(cmds, m.saveWarmCache(warmTables))
 		}
 		m.updateTablesList()
 
@@ -1017,6 +2230,78 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, cmd)
 			}
 		}
+		// Pass other messages to proxy rules input if editing rules
+		if m.editingProxyRules {
+			var cmd tea.Cmd
+			m.proxyRulesInput, cmd = m.proxyRulesInput.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		// Pass other messages to user search input if searching Cognito users
+		if m.searchingUsers {
+			var cmd tea.Cmd
+			m.userSearchInput, cmd = m.userSearchInput.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		// Pass other messages to the recipient input if entering an SES test email
+		if m.enteringSESRecipient {
+			var cmd tea.Cmd
+			m.sesRecipientInput, cmd = m.sesRecipientInput.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		// Pass other messages to the shift input if shifting alias traffic
+		if m.traffic != nil {
+			var cmd tea.Cmd
+			m.traffic.input, cmd = m.traffic.input.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		// Pass other messages to the minutes input if protecting tasks
+		if m.protection != nil {
+			var cmd tea.Cmd
+			m.protection.input, cmd = m.protection.input.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		// Pass other messages to the focused input if sending a message
+		if d := m.sendMessage; d != nil {
+			var cmd tea.Cmd
+			d.inputs[d.focus], cmd = d.inputs[d.focus].Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		// Pass other messages to the days input if setting log retention
+		if m.retention != nil {
+			var cmd tea.Cmd
+			m.retention.input, cmd = m.retention.input.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		// Pass other messages to the percent input if stopping tasks
+		if m.chaos != nil {
+			var cmd tea.Cmd
+			m.chaos.input, cmd = m.chaos.input.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		// Pass other messages to the pattern input if searching the logs of a stack
+		if m.searchingLogs {
+			var cmd tea.Cmd
+			m.logSearchInput, cmd = m.logSearchInput.Update(msg)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
 	}
 
 	return m, tea.Batch(cmds...)
@@ -0,0 +1,109 @@
+// Package uitest runs the vaws UI against a fake AWS client in a virtual
+// terminal, so that flows such as opening a service, querying a table or
+// starting a tunnel can be driven key by key from tests:
+//
+//	client := fake.New()
+//	client.Clusters = []model.Cluster{{Name: "prod", ARN: "arn:cluster/prod"}}
+//	h := uitest.New(t, client)
+//	h.Type(":ecs")
+//	h.Press(tea.KeyEnter)
+//	h.WaitFor("prod")
+//	h.Quit()
+package uitest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+
+	"vaws/internal/aws/fake"
+	"vaws/internal/log"
+	"vaws/internal/ui"
+)
+
+const (
+	// DefaultWidth and DefaultHeight are the size of the virtual terminal.
+	DefaultWidth  = 160
+	DefaultHeight = 48
+
+	// DefaultTimeout is how long WaitFor waits for text to show up.
+	DefaultTimeout = 5 * time.Second
+)
+
+// Harness is a running UI backed by a fake client.
+type Harness struct {
+	tb     testing.TB
+	Client *fake.Client
+	tm     *teatest.TestModel
+}
+
+// New starts the UI on client in a DefaultWidth x DefaultHeight terminal and
+// dismisses the splash screen. HOME points at a temporary directory for the
+// duration of the test, so the user's config, layout and saved tunnels are
+// neither read nor written.
+func New(tb testing.TB, client *fake.Client) *Harness {
+	tb.Helper()
+	tb.Setenv("HOME", tb.TempDir())
+
+	logger := log.Default()
+	m := ui.New(client, logger, "test")
+	tm := teatest.NewTestModel(tb, m, teatest.WithInitialTermSize(DefaultWidth, DefaultHeight))
+	h := &Harness{tb: tb, Client: client, tm: tm}
+	h.Press(tea.KeyEsc)
+	return h
+}
+
+// Type sends text one rune at a time, as if typed.
+func (h *Harness) Type(text string) {
+	h.tm.Type(text)
+}
+
+// Press sends special keys such as tea.KeyEnter or tea.KeyDown.
+func (h *Harness) Press(keys ...tea.KeyType) {
+	for _, k := range keys {
+		h.tm.Send(tea.KeyMsg{Type: k})
+	}
+}
+
+// Send sends any message to the UI, e.g. a tea.WindowSizeMsg.
+func (h *Harness) Send(msg tea.Msg) {
+	h.tm.Send(msg)
+}
+
+// WaitFor waits up to DefaultTimeout for text to be drawn and fails the test
+// otherwise. Only output since the previous wait is looked at, and the
+// renderer only redraws lines that changed, so wait for text that the last
+// keys are expected to bring up.
+func (h *Harness) WaitFor(text string) {
+	h.tb.Helper()
+	teatest.WaitFor(h.tb, h.tm.Output(), func(out []byte) bool {
+		return bytes.Contains(out, []byte(text))
+	}, teatest.WithDuration(DefaultTimeout))
+}
+
+// WaitForCall waits up to DefaultTimeout for the fake client to receive a
+// call to method and returns it.
+func (h *Harness) WaitForCall(method string) fake.Call {
+	h.tb.Helper()
+	deadline := time.Now().Add(DefaultTimeout)
+	for time.Now().Before(deadline) {
+		if calls := h.Client.CallsTo(method); len(calls) > 0 {
+			return calls[len(calls)-1]
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	h.tb.Fatalf("%s was not called within %s (calls: %v)", method, DefaultTimeout, h.Client.Calls())
+	return fake.Call{}
+}
+
+// Quit stops the UI and waits for it to exit.
+func (h *Harness) Quit() {
+	h.tb.Helper()
+	if err := h.tm.Quit(); err != nil {
+		h.tb.Fatal(err)
+	}
+	h.tm.WaitFinished(h.tb, teatest.WithFinalTimeout(DefaultTimeout))
+}
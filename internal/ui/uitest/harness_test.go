@@ -0,0 +1,55 @@
+package uitest_test
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/aws/fake"
+	"vaws/internal/model"
+	"vaws/internal/ui/uitest"
+)
+
+func TestOpenService(t *testing.T) {
+	client := fake.New()
+	client.Clusters = []model.Cluster{{Name: "prod", ARN: "arn:aws:ecs:us-east-1:123456789012:cluster/prod"}}
+	client.Services = map[string][]model.Service{
+		"arn:aws:ecs:us-east-1:123456789012:cluster/prod": {{Name: "orders", DesiredCount: 2, RunningCount: 2}},
+	}
+
+	h := uitest.New(t, client)
+	h.Type(":ecs")
+	h.Press(tea.KeyEnter)
+	h.WaitFor("prod")
+	h.Press(tea.KeyEnter)
+	h.WaitFor("orders")
+	h.Quit()
+}
+
+func TestScanFails(t *testing.T) {
+	client := fake.New()
+	client.Tables = []model.Table{{
+		Name:      "orders",
+		Status:    model.TableStatusActive,
+		KeySchema: []model.KeySchemaElement{{AttributeName: "id", KeyType: "HASH"}},
+	}}
+	client.Errors["ScanTable"] = errors.New("access denied")
+
+	h := uitest.New(t, client)
+	h.Type(":dynamodb")
+	h.Press(tea.KeyEnter)
+	h.WaitFor("orders")
+	h.Type("s")
+	h.WaitFor("Scan")
+	h.Press(tea.KeyEnter)
+	call := h.WaitForCall("ScanTable")
+	if params := call.Args[0].(model.ScanParams); params.TableName != "orders" {
+		t.Errorf("scanned %q, want orders", params.TableName)
+	}
+	h.WaitFor("access denied")
+	if calls := client.CallsTo("ScanTable"); len(calls) != 1 {
+		t.Errorf("ScanTable called %d times, want once", len(calls))
+	}
+	h.Quit()
+}
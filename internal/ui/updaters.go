@@ -2,12 +2,18 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
+	"vaws/internal/aws"
+	"vaws/internal/config"
+	"vaws/internal/model"
 	"vaws/internal/state"
 	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
 	"vaws/internal/ui/theme"
 )
 
@@ -19,8 +25,8 @@ func (m *Model) updateComponentSizes() {
 
 	// Calculate logs height
 	logsHeight := 0
-	if m.state.ShowLogs {
-		logsHeight = min(10, m.height/4)
+	if m.shouldShowLogs() {
+		logsHeight = m.currentLogsHeight()
 	}
 
 	// Note: List and details sizes are set in renderMainContent() before View() calls
@@ -32,63 +38,323 @@ func (m *Model) updateComponentSizes() {
 func (m *Model) updateQuickBarActions() {
 	var actions []components.QuickKey
 
+	// Actions disabled by the profile's allow list are shown greyed out
+	noTunnel := !m.isActionAllowed(config.ActionTunnel)
+	noInvoke := !m.isActionAllowed(config.ActionInvoke)
+	noWrite := !m.isActionAllowed(config.ActionWrite)
+	noShell := !m.isActionAllowed(config.ActionShell)
+
 	switch m.state.View {
 	case state.ViewServices:
 		actions = []components.QuickKey{
-			{Key: "p", Label: "port-forward"},
+			{Key: "enter", Label: "tasks"},
+			{Key: "p", Label: "port-forward", Disabled: noTunnel},
+			{Key: "d", Label: "discovery tunnel", Disabled: noTunnel},
+			{Key: "S", Label: "shell", Disabled: noShell},
+			{Key: "v", Label: "diff task def"},
 			{Key: "l", Label: "logs"},
+			{Key: "M", Label: "monitor"},
+			{Key: "A", Label: "activity"},
+			{Key: "I", Label: "images"},
+			{Key: "V", Label: "cluster", Disabled: m.state.SelectedCluster == nil},
+			{Key: "B", Label: "protection"},
+			{Key: "F", Label: "stop tasks", Disabled: noWrite},
+		}
+	case state.ViewTasks:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "containers"},
+			{Key: "s", Label: "shell", Disabled: noShell},
+			{Key: "d", Label: "full details"},
+			{Key: "r", Label: "refresh"},
+			{Key: "/", Label: "filter"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewTaskContainers:
+		actions = []components.QuickKey{
+			{Key: "s", Label: "shell", Disabled: noShell},
+			{Key: "d", Label: "task details"},
+			{Key: "r", Label: "refresh"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewImages:
+		actions = []components.QuickKey{
+			{Key: "r", Label: "re-check"},
+			{Key: "/", Label: "filter"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewAlerts:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "open"},
+			{Key: "r", Label: "check now"},
+			{Key: "/", Label: "filter"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewStats:
+		actions = []components.QuickKey{
+			{Key: "w", Label: "write profiles"},
+			{Key: "r", Label: "reset max"},
+			{Key: "/", Label: "filter"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewTimeline:
+		actions = []components.QuickKey{
+			{Key: "Y", Label: "copy as text"},
+			{Key: "/", Label: "filter"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewClusters:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "services"},
+			{Key: "V", Label: "dashboard"},
+		}
+	case state.ViewStacks:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "resources"},
+			{Key: "e", Label: "events"},
+			{Key: "T", Label: "template"},
+			{Key: "A", Label: "activity"},
+			{Key: "L", Label: "search logs"},
+			{Key: "B", Label: "termination protection", Disabled: noWrite},
+			{Key: "P", Label: "stack policy", Disabled: noWrite},
 		}
 	case state.ViewAPIStages:
 		actions = []components.QuickKey{
-			{Key: "p", Label: "port-forward"},
+			{Key: "p", Label: "port-forward", Disabled: !m.profileAllows(config.ActionTunnel)},
+			{Key: "m", Label: "mock"},
+			{Key: "L", Label: "access logs"},
+			{Key: "H", Label: "deployments", Disabled: m.state.SelectedRestAPI == nil},
 		}
 	case state.ViewLambda:
 		actions = []components.QuickKey{
-			{Key: "i", Label: "invoke"},
+			{Key: "i", Label: "invoke", Disabled: noInvoke},
 			{Key: "l", Label: "logs"},
+			{Key: "M", Label: "monitor"},
+			{Key: "R", Label: "runtimes"},
+			{Key: "W", Label: "traffic"},
+			{Key: "m", Label: "perf"},
+		}
+	case state.ViewLambdaRuntimes:
+		actions = []components.QuickKey{
+			{Key: "w", Label: "export CSV"},
+			{Key: "r", Label: "refresh"},
+			{Key: "/", Label: "filter"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewAppRunner:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "operations"},
+			{Key: "P", Label: "pause/resume", Disabled: noWrite},
+			{Key: "D", Label: "deploy", Disabled: noWrite},
+		}
+	case state.ViewFirehose:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "delivery errors"},
+			{Key: "T", Label: "test put", Disabled: noWrite},
+		}
+	case state.ViewCognito:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "app clients"},
+			{Key: "U", Label: "search users"},
+		}
+	case state.ViewMSK:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "bootstrap brokers"},
+			{Key: "p", Label: "tunnel brokers", Disabled: noTunnel},
+		}
+	case state.ViewMQ:
+		actions = []components.QuickKey{
+			{Key: "p", Label: "tunnel console/AMQP", Disabled: noTunnel},
+		}
+	case state.ViewDatabases:
+		actions = []components.QuickKey{
+			{Key: "p", Label: "tunnel via jump host", Disabled: noTunnel},
+		}
+	case state.ViewEC2:
+		actions = []components.QuickKey{
+			{Key: "S", Label: "shell", Disabled: noShell},
+			{Key: "p", Label: "port forward", Disabled: noTunnel},
+		}
+	case state.ViewEFS:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "mount targets & mounts"},
+		}
+	case state.ViewSchedules:
+		actions = []components.QuickKey{
+			{Key: "P", Label: "pause/resume", Disabled: noWrite},
+			{Key: "i", Label: "run now", Disabled: noWrite},
+		}
+	case state.ViewSecrets:
+		actions = []components.QuickKey{
+			{Key: "i", Label: "rotate now", Disabled: noWrite},
+		}
+	case state.ViewLogGroups:
+		actions = []components.QuickKey{
+			{Key: "space", Label: "mark"},
+			{Key: "o", Label: "mark orphaned"},
+			{Key: "s", Label: "retention", Disabled: noWrite},
+			{Key: "X", Label: "delete", Disabled: noWrite},
+		}
+	case state.ViewSES:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "suppression list"},
+			{Key: "T", Label: "test email", Disabled: noWrite},
+		}
+	case state.ViewSESSuppressions:
+		actions = []components.QuickKey{
+			{Key: "/", Label: "search"},
+			{Key: "X", Label: "remove", Disabled: noWrite},
+		}
+	case state.ViewResourceTypes:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "list resources"},
+		}
+	case state.ViewCloudResources:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "full properties"},
+			{Key: "tab", Label: "browse JSON"},
+		}
+	case state.ViewCognitoUsers:
+		actions = []components.QuickKey{
+			{Key: "U", Label: "new search"},
+			{Key: "A", Label: "confirm", Disabled: noWrite},
+			{Key: "X", Label: "disable/enable", Disabled: noWrite},
 		}
 	case state.ViewTunnels:
 		actions = []components.QuickKey{
-			{Key: "p", Label: "new tunnel"},
+			{Key: "p", Label: "new tunnel", Disabled: noTunnel},
 			{Key: "s", Label: "stop"},
-			{Key: "r", Label: "restart"},
+			{Key: "r", Label: "restart", Disabled: noTunnel},
+			{Key: "e", Label: "proxy rules", Disabled: noTunnel},
+			{Key: "w", Label: "export"},
+			{Key: "S", Label: "shell", Disabled: noShell},
+		}
+	case state.ViewJumpHostSelect:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "select"},
+			{Key: "S", Label: "shell", Disabled: noShell},
 		}
 	case state.ViewSQS:
-		// No special actions for SQS list
+		actions = []components.QuickKey{
+			{Key: "M", Label: "monitor"},
+			{Key: "O", Label: "consumers/producers"},
+			{Key: "f", Label: "failures"},
+			{Key: "w", Label: "send message", Disabled: noWrite},
+		}
 	case state.ViewDynamoDB:
 		actions = []components.QuickKey{
 			{Key: "q", Label: "query"},
 			{Key: "s", Label: "scan"},
+			{Key: "A", Label: "activity"},
+		}
+	case state.ViewStackEvents:
+		actions = []components.QuickKey{
+			{Key: "r", Label: "refresh"},
+			{Key: "/", Label: "filter"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewActivity:
+		actions = []components.QuickKey{
+			{Key: "/", Label: "search"},
+			{Key: "tab", Label: "browse JSON"},
+			{Key: "T", Label: "time range"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewLogSearch:
+		actions = []components.QuickKey{
+			{Key: "L", Label: "new search"},
+			{Key: "T", Label: "time range"},
+			{Key: "/", Label: "filter"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewHealth:
+		actions = []components.QuickKey{
+			{Key: "enter", Label: "open"},
+			{Key: "r", Label: "re-check"},
+			{Key: "/", Label: "filter"},
+			{Key: "esc", Label: "back"},
 		}
 	case state.ViewDynamoDBQuery:
 		actions = []components.QuickKey{
 			{Key: "q", Label: "query"},
 			{Key: "s", Label: "scan"},
 			{Key: "n", Label: "next page"},
-			{Key: "J/K", Label: "scroll JSON"},
+			{Key: "J/K", Label: "JSON tree"},
+			{Key: "enter", Label: "fold"},
+			{Key: "+/-", Label: "expand/collapse all"},
+			{Key: "C", Label: "copy path"},
+			{Key: "/", Label: "search JSON"},
+			{Key: "|", Label: "jq"},
 			{Key: "C-d/u", Label: "half page"},
 			{Key: "y", Label: "copy"},
 			{Key: "Y", Label: "yank"},
+			{Key: "e", Label: "edit item", Disabled: noWrite},
+			{Key: "D", Label: "delete item", Disabled: noWrite},
 			{Key: "r", Label: "refresh"},
 		}
 	case state.ViewCloudWatchLogs:
+		exportLabel := "export"
+		if m.logExport != nil {
+			exportLabel = "stop export"
+		}
 		actions = []components.QuickKey{
 			{Key: "Tab", Label: "switch container"},
+			{Key: "/", Label: "search"},
+			{Key: "n/N", Label: "next/prev match"},
+			{Key: "T", Label: "time range"},
+			{Key: "C-s", Label: exportLabel},
+		}
+		if m.state.CloudWatchServiceContext != nil && m.state.CloudWatchLambdaContext == nil && m.state.CloudWatchAccessLogContext == "" {
+			if m.mergedTail != nil {
+				actions = append(actions, components.QuickKey{Key: "m", Label: "one task"})
+			} else {
+				actions = append(actions, components.QuickKey{Key: "m", Label: "merge tasks"})
+			}
+		}
+	case state.ViewDiff:
+		actions = []components.QuickKey{
+			{Key: "n/N", Label: "next/prev change"},
+			{Key: "Tab", Label: "unified/side-by-side"},
+			{Key: "C-d/u", Label: "half page"},
+			{Key: "esc", Label: "back"},
+		}
+	case state.ViewMonitor:
+		actions = []components.QuickKey{
+			{Key: theme.Symbol("←→↑↓", "arrows"), Label: "select"},
+			{Key: "r", Label: "refresh"},
+			{Key: "x", Label: "remove"},
+			{Key: "esc", Label: "back"},
 		}
 	}
 
-	// Add focus-specific hints in split view layout
-	if m.getLayoutMode() == layoutFull && m.state.View != state.ViewTunnels &&
-		m.state.View != state.ViewCloudWatchLogs && m.state.View != state.ViewDynamoDBQuery {
-		if m.details.IsFocused() {
-			// Details focused - show scroll hints
-			actions = append(actions, components.QuickKey{Key: "Tab", Label: "list"})
-			actions = append(actions, components.QuickKey{Key: "↑↓", Label: "scroll"})
-			actions = append(actions, components.QuickKey{Key: "C-d/u", Label: "half page"})
-		} else {
+	// Add focus-specific hints: where Tab goes next, and how the focused
+	// panel scrolls
+	focusKey := "Tab"
+	if m.viewOwnsTab() {
+		focusKey = "C-w"
+	}
+	switch m.focusedPane() {
+	case focusLogs:
+		// Logs focused - scroll keys move the logs
+		actions = []components.QuickKey{
+			{Key: focusKey, Label: "next panel"},
+			{Key: theme.Symbol("↑↓", "up/down"), Label: "scroll logs"},
+			{Key: "C-d/u", Label: "half page"},
+			{Key: "esc", Label: "leave logs"},
+		}
+	case focusDetails:
+		// Details focused - show scroll hints
+		actions = append(actions, components.QuickKey{Key: focusKey, Label: "next panel"})
+		actions = append(actions, components.QuickKey{Key: theme.Symbol("↑↓", "up/down"), Label: "scroll"})
+		actions = append(actions, components.QuickKey{Key: "C-d/u", Label: "half page"})
+	default:
+		if m.hasDetailsPane() {
 			// List focused - show Tab hint
-			actions = append(actions, components.QuickKey{Key: "Tab", Label: "details"})
+			actions = append(actions, components.QuickKey{Key: focusKey, Label: "details"})
+		} else if m.shouldShowLogs() {
+			actions = append(actions, components.QuickKey{Key: focusKey, Label: "logs"})
 		}
+	}
+	if m.hasDetailsPane() && m.focusedPane() != focusLogs {
 		actions = append(actions, components.QuickKey{Key: "y", Label: "copy"})
 		actions = append(actions, components.QuickKey{Key: "Y", Label: "yank"})
 	}
@@ -100,10 +366,34 @@ func (m *Model) updateQuickBarActions() {
 	}
 }
 
+// withIcons marks the statuses of menu items as icons rather than states.
+func withIcons(items []components.ListItem) []components.ListItem {
+	for i := range items {
+		items[i].Icon = true
+	}
+	return items
+}
+
 // updateMainMenuList updates the main menu list items.
 func (m *Model) updateMainMenuList() {
 	// Show all supported AWS resource types with shortcuts, organized by category
 	items := []components.ListItem{
+		// Overview category
+		{ID: "cat-overview", Title: "── Overview ──", IsHeader: true},
+		{
+			ID:          "health",
+			Title:       "Account Health",
+			Description: "Failed stacks, unhealthy services, alarms, DLQs and expiring certificates (:health)",
+			Status:      "🩺",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Error),
+		},
+		{
+			ID:          "costs",
+			Title:       "Costs",
+			Description: "Estimated charges of the month and budgets near their limit (:costs)",
+			Status:      "💰",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Warning),
+		},
 		// Compute category
 		{ID: "cat-compute", Title: "── Compute ──", IsHeader: true},
 		{
@@ -120,6 +410,22 @@ func (m *Model) updateMainMenuList() {
 			Status:      "λ",
 			StatusStyle: lipgloss.NewStyle().Foreground(theme.Warning),
 		},
+		{
+			ID:          "apprunner-services",
+			Title:       "[7] App Runner",
+			Description: "View App Runner services and deployments",
+			Status:      "🏃",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Success),
+		},
+		// Security category
+		{ID: "cat-security", Title: "── Security ──", IsHeader: true},
+		{
+			ID:          "cognito-pools",
+			Title:       "Cognito User Pools",
+			Description: "View app clients and search users (:cognito)",
+			Status:      "👤",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Primary),
+		},
 		// Data category
 		{ID: "cat-data", Title: "── Data ──", IsHeader: true},
 		{
@@ -136,6 +442,76 @@ func (m *Model) updateMainMenuList() {
 			Status:      "🗃️",
 			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
 		},
+		{
+			ID:          "firehose-streams",
+			Title:       "Firehose Streams",
+			Description: "View delivery streams and delivery errors (:firehose)",
+			Status:      "🚒",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
+		{
+			ID:          "msk-clusters",
+			Title:       "MSK Clusters",
+			Description: "View Kafka clusters and tunnel to brokers (:msk)",
+			Status:      "🪵",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
+		{
+			ID:          "mq-brokers",
+			Title:       "MQ Brokers",
+			Description: "View Amazon MQ brokers and tunnel to their console and AMQP ports (:mq)",
+			Status:      "🐇",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
+		{
+			ID:          "databases",
+			Title:       "Databases",
+			Description: "View RDS, RDS Proxy and Redshift endpoints and tunnel to them via a jump host (:databases)",
+			Status:      "🛢",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
+		{
+			ID:          "ec2-instances",
+			Title:       "EC2 Instances",
+			Description: "View EC2 instances, open a Session Manager shell and forward their ports (:ec2)",
+			Status:      "🖥",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
+		{
+			ID:          "efs-file-systems",
+			Title:       "EFS File Systems",
+			Description: "View file systems, mount targets, access points and what mounts them (:efs)",
+			Status:      "🗄",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
+		{
+			ID:          "schedules",
+			Title:       "Schedules",
+			Description: "View EventBridge Scheduler schedules and their next runs (:schedules)",
+			Status:      "⏰",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
+		{
+			ID:          "secrets",
+			Title:       "Secrets",
+			Description: "View Secrets Manager rotation and flag overdue or stale secrets (:secrets)",
+			Status:      "🔑",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
+		{
+			ID:          "log-groups",
+			Title:       "Log Groups",
+			Description: "Set retention of log groups and delete orphaned ones (:loggroups)",
+			Status:      "🪵",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
+		{
+			ID:          "ses",
+			Title:       "SES",
+			Description: "View sending quota, reputation and suppression list (:ses)",
+			Status:      "📧",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+		},
 		// Infrastructure category
 		{ID: "cat-infra", Title: "── Infrastructure ──", IsHeader: true},
 		{
@@ -152,8 +528,15 @@ func (m *Model) updateMainMenuList() {
 			Status:      "📦",
 			StatusStyle: lipgloss.NewStyle().Foreground(theme.TextMuted),
 		},
+		{
+			ID:          "cloud-resources",
+			Title:       "Other Resources",
+			Description: "Browse resource types from config via Cloud Control (:resources)",
+			Status:      "🧩",
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.TextMuted),
+		},
 	}
-	m.mainMenuList.SetItems(items)
+	m.mainMenuList.SetItems(withIcons(items))
 	// Ensure cursor starts on first selectable item (not a header)
 	m.mainMenuList.Top()
 	m.mainMenuList.SetLoading(false)
@@ -166,7 +549,7 @@ func (m *Model) updateMainMenuList() {
 		{Label: "Profile", Value: m.state.Profile},
 		{Label: "Region", Value: m.state.Region},
 		{Label: "", Value: ""},
-		{Label: "Hint", Value: "Select a resource or press 1-6"},
+		{Label: "Hint", Value: "Select a resource or press 1-7"},
 	})
 }
 
@@ -180,8 +563,14 @@ func (m *Model) updateStacksList() {
 			Title:       s.Name,
 			Status:      string(s.Status),
 			StatusStyle: StatusStyle(string(s.Status)),
+			Changed:     m.changes.observe(s.ID, stackFingerprint(s.UpdatedAt)),
+			Highlight:   m.highlightStyle("stacks", stackFields(s)),
+			Noted:       m.hasNote(s.ID),
 		}
 	}
+	if key := m.stackGroupKey(); key != "" {
+		items = m.groupStackItems(stacks, items, key)
+	}
 	m.stacksList.SetItems(items)
 	m.stacksList.SetLoading(false)
 	m.stacksList.SetError(m.state.StacksError)
@@ -243,7 +632,7 @@ func (m *Model) updateStackResourcesList() {
 			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
 		},
 	}
-	m.stackResourcesList.SetItems(items)
+	m.stackResourcesList.SetItems(withIcons(items))
 	m.stackResourcesList.SetLoading(false)
 	m.stackResourcesList.SetError(nil)
 	m.stackResourcesList.SetEmptyMessage("No resources available")
@@ -254,7 +643,7 @@ func (m *Model) updateStackResourcesList() {
 			{Label: "Stack Name", Value: m.state.SelectedStack.Name},
 			{Label: "Status", Value: string(m.state.SelectedStack.Status)},
 			{Label: "Description", Value: m.state.SelectedStack.Description},
-			{Label: "Created", Value: m.state.SelectedStack.CreatedAt.Format("2006-01-02 15:04:05")},
+			{Label: "Created", Value: format.Time(m.state.SelectedStack.CreatedAt)},
 		}
 		m.details.SetTitle("Stack Info")
 		m.details.SetRows(rows)
@@ -272,8 +661,13 @@ func (m *Model) updateServicesList() {
 			Status:      fmt.Sprintf("%d/%d", s.RunningCount, s.DesiredCount),
 			StatusStyle: ServiceStatusStyle(s.RunningCount, s.DesiredCount),
 			Extra:       s.ClusterName,
+			Changed:     m.changes.observe(s.ARN, s.TaskDefinition),
+			Highlight:   m.highlightStyle("services", serviceFields(s)),
+			Noted:       m.hasNote(s.ARN),
+			Cells:       m.withTerraform(serviceCells(s), s.ARN),
 		}
 	}
+	m.serviceList.SetColumns(m.columnsFor("services"))
 	m.serviceList.SetItems(items)
 	m.serviceList.SetLoading(false)
 	m.serviceList.SetError(m.state.ServicesError)
@@ -292,8 +686,13 @@ func (m *Model) updateLambdaList() {
 			Status:      string(fn.State),
 			StatusStyle: FunctionStatusStyle(fn.State),
 			Extra:       fn.Runtime,
+			Changed:     m.changes.observe(fn.ARN, fn.CodeSha256),
+			Highlight:   m.highlightStyle("lambda", functionFields(fn)),
+			Noted:       m.hasNote(fn.ARN),
+			Cells:       m.withTerraform(functionCells(fn), fn.ARN),
 		}
 	}
+	m.lambdaList.SetColumns(m.columnsFor("lambda"))
 	m.lambdaList.SetItems(items)
 	m.lambdaList.SetLoading(false)
 	m.lambdaList.SetError(m.state.FunctionsError)
@@ -301,6 +700,541 @@ func (m *Model) updateLambdaList() {
 	m.updateLambdaDetails()
 }
 
+// updateAppRunnerList updates the App Runner services list with current data.
+func (m *Model) updateAppRunnerList() {
+	services := m.state.FilteredAppRunnerServices()
+	items := make([]components.ListItem, len(services))
+	for i, svc := range services {
+		items[i] = components.ListItem{
+			ID:          svc.ARN,
+			Title:       svc.Name,
+			Description: svc.URL,
+			Status:      string(svc.Status),
+			StatusStyle: AppRunnerStatusStyle(svc.Status),
+			Extra:       svc.SourceType,
+		}
+	}
+	m.appRunnerList.SetItems(items)
+	m.appRunnerList.SetLoading(false)
+	m.appRunnerList.SetError(m.state.AppRunnerError)
+	m.appRunnerList.SetEmptyMessage("No App Runner services found")
+	m.updateAppRunnerDetails()
+}
+
+// updateFirehoseList updates the Firehose delivery streams list with current data.
+func (m *Model) updateFirehoseList() {
+	streams := m.state.FilteredDeliveryStreams()
+	items := make([]components.ListItem, len(streams))
+	for i, stream := range streams {
+		items[i] = components.ListItem{
+			ID:          stream.Name,
+			Title:       stream.Name,
+			Description: stream.Target,
+			Status:      string(stream.Status),
+			StatusStyle: DeliveryStreamStatusStyle(stream.Status),
+			Extra:       stream.Destination,
+		}
+	}
+	m.firehoseList.SetItems(items)
+	m.firehoseList.SetLoading(false)
+	m.firehoseList.SetError(m.state.FirehoseError)
+	m.firehoseList.SetEmptyMessage("No Firehose delivery streams found")
+	m.updateFirehoseDetails()
+}
+
+// updateUserPoolList updates the Cognito user pools list with current data.
+func (m *Model) updateUserPoolList() {
+	pools := m.state.FilteredUserPools()
+	items := make([]components.ListItem, len(pools))
+	for i, pool := range pools {
+		items[i] = components.ListItem{
+			ID:          pool.ID,
+			Title:       pool.Name,
+			Description: pool.ID,
+		}
+	}
+	m.userPoolList.SetItems(items)
+	m.userPoolList.SetLoading(false)
+	m.userPoolList.SetError(m.state.UserPoolsError)
+	m.userPoolList.SetEmptyMessage("No Cognito user pools found")
+	m.updateUserPoolDetails()
+}
+
+// updateCognitoUserList updates the Cognito users list with the current search results.
+func (m *Model) updateCognitoUserList() {
+	users := m.state.FilteredCognitoUsers()
+	items := make([]components.ListItem, len(users))
+	for i, user := range users {
+		status := string(user.Status)
+		if !user.Enabled {
+			status = "DISABLED"
+		}
+		items[i] = components.ListItem{
+			ID:          user.Username,
+			Title:       user.Username,
+			Description: user.Email,
+			Status:      status,
+			StatusStyle: CognitoUserStatusStyle(user),
+		}
+	}
+	m.cognitoUserList.SetItems(items)
+	m.cognitoUserList.SetLoading(false)
+	m.cognitoUserList.SetError(m.state.CognitoUsersError)
+	m.cognitoUserList.SetEmptyMessage(fmt.Sprintf("No users matching %q", m.state.CognitoUserQuery))
+	m.updateCognitoUserDetails()
+}
+
+// updateMSKList updates the MSK clusters list with current data.
+func (m *Model) updateMSKList() {
+	clusters := m.state.FilteredMSKClusters()
+	items := make([]components.ListItem, len(clusters))
+	for i, c := range clusters {
+		description := "serverless"
+		if !c.IsServerless() {
+			description = fmt.Sprintf("Kafka %s · %d brokers", c.KafkaVersion, c.BrokerCount)
+		}
+		items[i] = components.ListItem{
+			ID:          c.ARN,
+			Title:       c.Name,
+			Description: description,
+			Status:      string(c.State),
+			StatusStyle: MSKClusterStateStyle(c.State),
+		}
+	}
+	m.mskList.SetItems(items)
+	m.mskList.SetLoading(false)
+	m.mskList.SetError(m.state.MSKError)
+	m.mskList.SetEmptyMessage("No MSK clusters found")
+	m.updateMSKDetails()
+}
+
+// updateMQList updates the MQ brokers list with current data.
+func (m *Model) updateMQList() {
+	brokers := m.state.FilteredMQBrokers()
+	items := make([]components.ListItem, len(brokers))
+	for i, b := range brokers {
+		description := strings.ToLower(b.Engine)
+		if b.EngineVersion != "" {
+			description += " " + b.EngineVersion
+		}
+		description += " · " + b.InstanceType
+		items[i] = components.ListItem{
+			ID:          b.ARN,
+			Title:       b.Name,
+			Description: description,
+			Status:      string(b.State),
+			StatusStyle: MQBrokerStateStyle(b.State),
+		}
+	}
+	m.mqList.SetItems(items)
+	m.mqList.SetLoading(false)
+	m.mqList.SetError(m.state.MQError)
+	m.mqList.SetEmptyMessage("No MQ brokers found")
+	m.updateMQDetails()
+}
+
+// updateDatabaseList updates the databases list with current data.
+func (m *Model) updateDatabaseList() {
+	databases := m.state.FilteredDatabases()
+	items := make([]components.ListItem, len(databases))
+	for i, d := range databases {
+		port := ""
+		if d.Port > 0 {
+			port = strconv.Itoa(d.Port)
+		}
+		items[i] = components.ListItem{
+			ID:          d.ID,
+			Title:       d.Name,
+			Description: fmt.Sprintf("%s · %s · port %d", d.Kind, d.Engine, d.Port),
+			Status:      d.Status,
+			StatusStyle: DatabaseStatusStyle(d.Status),
+			Cells:       map[string]string{"kind": string(d.Kind), "engine": d.Engine, "port": port},
+		}
+	}
+	m.databaseList.SetColumns(databaseColumns)
+	m.databaseList.SetItems(items)
+	m.databaseList.SetLoading(false)
+	m.databaseList.SetError(m.state.DatabasesError)
+	m.databaseList.SetEmptyMessage("No databases found")
+	m.updateDatabaseDetails()
+}
+
+// updateEFSList updates the EFS file systems list with current data.
+func (m *Model) updateEFSList() {
+	fileSystems := m.state.FilteredEFSFileSystems()
+	items := make([]components.ListItem, len(fileSystems))
+	for i, fs := range fileSystems {
+		title := fs.ID
+		if fs.Name != "" {
+			title = fs.Name + " (" + fs.ID + ")"
+		}
+		items[i] = components.ListItem{
+			ID:          fs.ID,
+			Title:       title,
+			Description: fmt.Sprintf("%s · %s · %d mount targets", formatBytes(fs.SizeBytes), fs.ThroughputMode, fs.MountTargetCount),
+			Status:      fs.State,
+			StatusStyle: EFSStateStyle(fs.State),
+		}
+	}
+	m.efsList.SetItems(items)
+	m.efsList.SetLoading(false)
+	m.efsList.SetError(m.state.EFSError)
+	m.efsList.SetEmptyMessage("No EFS file systems found")
+	m.updateEFSDetails()
+}
+
+// updateSchedulesList updates the schedules list with current data.
+func (m *Model) updateSchedulesList() {
+	schedules := m.state.FilteredSchedules()
+	items := make([]components.ListItem, len(schedules))
+	for i, s := range schedules {
+		description := valueOrDash(s.Expression)
+		if next, err := scheduleNextRun(s); err == nil && !next.IsZero() {
+			description += " · next " + format.Relative(time.Since(next))
+		}
+		items[i] = components.ListItem{
+			ID:          s.ARN,
+			Title:       scheduleTitle(s),
+			Description: description,
+			Status:      string(s.State),
+			StatusStyle: ScheduleStateStyle(s.State),
+		}
+	}
+	m.schedulesList.SetItems(items)
+	m.schedulesList.SetLoading(false)
+	m.schedulesList.SetError(m.state.SchedulesError)
+	m.schedulesList.SetEmptyMessage("No schedules found")
+	m.updateScheduleDetails()
+}
+
+// updateSecretsList updates the secrets list with current data.
+func (m *Model) updateSecretsList() {
+	secrets := m.state.FilteredSecrets()
+	now := time.Now()
+	items := make([]components.ListItem, len(secrets))
+	for i, s := range secrets {
+		rotation := s.Rotation(now)
+		items[i] = components.ListItem{
+			ID:          s.ARN,
+			Title:       s.Name,
+			Description: secretRotationSummary(s, now),
+			Status:      string(rotation),
+			StatusStyle: SecretRotationStyle(rotation),
+		}
+	}
+	m.secretsList.SetItems(items)
+	m.secretsList.SetLoading(false)
+	m.secretsList.SetError(m.state.SecretsError)
+	m.secretsList.SetEmptyMessage("No secrets found")
+	m.updateSecretDetails()
+}
+
+// updateLogGroupsList updates the log groups list with current data.
+func (m *Model) updateLogGroupsList() {
+	s := GetStyles()
+	groups := m.state.FilteredLogGroups()
+	items := make([]components.ListItem, len(groups))
+	for i, g := range groups {
+		description := formatBytes(g.StoredBytes) + " · " + retentionText(g.RetentionDays)
+		if g.Owner != "" {
+			description += " · " + g.Owner
+		}
+		status, style := fmt.Sprintf("%dd", g.RetentionDays), s.Muted
+		switch {
+		case g.Orphaned:
+			status, style = "ORPHANED", s.StatusError
+		case g.NeverExpires():
+			status, style = "NEVER EXPIRES", s.StatusWarning
+		}
+		items[i] = components.ListItem{
+			ID:          g.Name,
+			Title:       g.Name,
+			Description: description,
+			Status:      status,
+			StatusStyle: style,
+			Marked:      m.logGroupMarks[g.Name],
+		}
+	}
+	m.logGroupsList.SetItems(items)
+	m.logGroupsList.SetLoading(false)
+	m.logGroupsList.SetError(m.state.LogGroupsError)
+	m.logGroupsList.SetEmptyMessage("No log groups found")
+	m.updateLogGroupDetails()
+}
+
+// updateResourceTypeList updates the resource types list with the types configured for the profile.
+func (m *Model) updateResourceTypeList() {
+	types := m.state.FilteredResourceTypes()
+	items := make([]components.ListItem, len(types))
+	for i, t := range types {
+		items[i] = components.ListItem{
+			ID:    t,
+			Title: t,
+		}
+	}
+	m.resourceTypeList.SetItems(items)
+	m.resourceTypeList.SetLoading(false)
+	m.resourceTypeList.SetEmptyMessage("No resource types configured (add resource_types to ~/.vaws/config.yaml)")
+	m.updateResourceTypeDetails()
+}
+
+// updateSESList updates the SES list with the account, identities and configuration sets.
+func (m *Model) updateSESList() {
+	var items []components.ListItem
+	if account := m.state.SESAccount; account != nil {
+		access := "sandbox"
+		if account.ProductionAccess {
+			access = "production"
+		}
+		items = append(items,
+			components.ListItem{ID: "hdr-account", Title: "── Account ──", IsHeader: true},
+			components.ListItem{
+				ID:          "account",
+				Title:       "Sending quota",
+				Description: fmt.Sprintf("%s of %s in 24h (%.1f%%), %s", format.Count(int64(account.SentLast24Hours)), format.Count(int64(account.Max24HourSend)), account.QuotaUsed()*100, access),
+				Status:      valueOrDash(account.EnforcementStatus),
+				StatusStyle: SESEnforcementStyle(*account),
+			},
+			components.ListItem{
+				ID:          "suppression",
+				Title:       "Suppression list",
+				Description: "Suppressed for " + joinOrDash(account.SuppressedReasons),
+			},
+		)
+	}
+
+	if identities := m.state.FilteredSESIdentities(); len(identities) > 0 {
+		items = append(items, components.ListItem{ID: "hdr-identities", Title: "── Identities ──", IsHeader: true})
+		for _, id := range identities {
+			items = append(items, components.ListItem{
+				ID:          "identity:" + id.Name,
+				Title:       id.Name,
+				Description: id.Type,
+				Status:      id.VerificationStatus,
+				StatusStyle: SESVerificationStyle(id),
+			})
+		}
+	}
+
+	if sets := m.state.FilteredSESConfigurationSets(); len(sets) > 0 {
+		items = append(items, components.ListItem{ID: "hdr-configsets", Title: "── Configuration Sets ──", IsHeader: true})
+		for _, set := range sets {
+			status, style := "ENABLED", GetStyles().StatusHealthy
+			if !set.SendingEnabled {
+				status, style = "PAUSED", GetStyles().StatusWarning
+			}
+			items = append(items, components.ListItem{
+				ID:          "configset:" + set.Name,
+				Title:       set.Name,
+				Description: fmt.Sprintf("%d event destination(s)", len(set.EventDestinations)),
+				Status:      status,
+				StatusStyle: style,
+			})
+		}
+	}
+
+	m.sesList.SetItems(items)
+	m.sesList.SetLoading(false)
+	m.sesList.SetError(m.state.SESError)
+	m.sesList.SetEmptyMessage("No SES data found")
+	m.updateSESDetails()
+}
+
+// updateSESSuppressionList updates the suppression list with current data.
+func (m *Model) updateSESSuppressionList() {
+	destinations := m.state.FilteredSESSuppressions()
+	items := make([]components.ListItem, len(destinations))
+	for i, d := range destinations {
+		items[i] = components.ListItem{
+			ID:          d.Email,
+			Title:       d.Email,
+			Description: format.Time(d.LastUpdated),
+			Status:      d.Reason,
+			StatusStyle: SESSuppressionReasonStyle(d.Reason),
+		}
+	}
+	m.sesSuppressionList.SetItems(items)
+	m.sesSuppressionList.SetLoading(false)
+	m.sesSuppressionList.SetError(m.state.SESSuppressionsError)
+	m.sesSuppressionList.SetEmptyMessage("No suppressed addresses")
+	m.updateSESSuppressionDetails()
+}
+
+// updateLogSearchList updates the stack log search results with current data.
+func (m *Model) updateLogSearchList() {
+	hits := m.state.FilteredLogSearchHits()
+	items := make([]components.ListItem, len(hits))
+	for i, h := range hits {
+		// Only the first line fits the list; the details show all of it
+		message, _, _ := strings.Cut(strings.TrimSpace(h.Message), "\n")
+		items[i] = components.ListItem{
+			ID:          h.ID,
+			Title:       "[" + h.Source.Resource + "] " + message,
+			Status:      format.Time(h.Timestamp),
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.TextDim),
+		}
+	}
+	m.logSearchList.SetItems(items)
+	m.logSearchList.SetLoading(m.state.LogSearchLoading)
+	m.logSearchList.SetError(m.state.LogSearchError)
+	switch {
+	case m.state.LogSearchLoading:
+	case len(m.state.LogSearchSources) == 0:
+		m.logSearchList.SetEmptyMessage("No log groups found for the services and functions of this stack")
+	default:
+		m.logSearchList.SetEmptyMessage(fmt.Sprintf("No matches in %d log groups %s", len(m.state.LogSearchSources), rangeClause(m.state.LogSearchRange)))
+	}
+	m.updateLogSearchDetails()
+}
+
+// updateHealthList updates the account health summary: a header per check
+// with the number of problems found, followed by the problems, or a line
+// saying the check passed or couldn't run.
+func (m *Model) updateHealthList() {
+	s := GetStyles()
+	h := m.state.FilteredHealth()
+	if h == nil {
+		m.healthList.SetItems(nil)
+		m.healthList.SetLoading(m.state.HealthLoading)
+		m.healthList.SetError(nil)
+		m.healthList.SetEmptyMessage("Press r to check the account")
+		m.updateHealthDetails()
+		return
+	}
+
+	var items []components.ListItem
+	section := func(check, title string, problems []components.ListItem) {
+		items = append(items, components.ListItem{
+			ID:       "cat-" + check,
+			Title:    fmt.Sprintf("── %s (%d) ──", title, len(problems)),
+			IsHeader: true,
+		})
+		switch {
+		case h.Errors[check] != "":
+			items = append(items, components.ListItem{ID: "error-" + check, Title: "Could not check (see details)", Status: "ERROR", StatusStyle: s.StatusWarning})
+		case len(problems) == 0:
+			items = append(items, components.ListItem{ID: "ok-" + check, Title: "Nothing to report", Status: "OK", StatusStyle: s.StatusHealthy})
+		default:
+			items = append(items, problems...)
+		}
+	}
+
+	var stacks []components.ListItem
+	for _, st := range h.FailedStacks {
+		stacks = append(stacks, components.ListItem{
+			ID:          model.HealthStacks + ":" + st.Name,
+			Title:       st.Name,
+			Status:      string(st.Status),
+			StatusStyle: StatusStyle(string(st.Status)),
+		})
+	}
+	section(model.HealthStacks, "Failed stacks", stacks)
+
+	var services []components.ListItem
+	for _, svc := range h.UnhealthyServices {
+		services = append(services, components.ListItem{
+			ID:          model.HealthServices + ":" + svc.ARN,
+			Title:       svc.ClusterName + "/" + svc.Name,
+			Status:      fmt.Sprintf("%d/%d", svc.RunningCount, svc.DesiredCount),
+			StatusStyle: s.StatusError,
+		})
+	}
+	section(model.HealthServices, "Unhealthy services", services)
+
+	var alarms []components.ListItem
+	for _, a := range h.Alarms {
+		alarms = append(alarms, components.ListItem{
+			ID:          model.HealthAlarms + ":" + a.Name,
+			Title:       a.Name,
+			Status:      string(a.State),
+			StatusStyle: s.StatusError,
+		})
+	}
+	section(model.HealthAlarms, "Alarms firing", alarms)
+
+	var dlqs []components.ListItem
+	for _, q := range h.DLQs {
+		dlqs = append(dlqs, components.ListItem{
+			ID:          model.HealthDLQs + ":" + q.URL,
+			Title:       q.Name + " → " + q.DLQName,
+			Status:      format.Count(int64(q.DLQMessageCount)) + " msgs",
+			StatusStyle: s.StatusWarning,
+		})
+	}
+	section(model.HealthDLQs, "Dead-letter queues with messages", dlqs)
+
+	var certs []components.ListItem
+	for _, c := range h.ExpiringCertificates {
+		item := components.ListItem{
+			ID:          model.HealthCertificates + ":" + c.ARN,
+			Title:       c.DomainName,
+			Status:      "expires " + format.Relative(-time.Until(c.NotAfter)),
+			StatusStyle: s.StatusWarning,
+		}
+		if time.Now().After(c.NotAfter) {
+			item.Status = "expired"
+			item.StatusStyle = s.StatusError
+		}
+		certs = append(certs, item)
+	}
+	section(model.HealthCertificates, fmt.Sprintf("Certificates expiring in %d days", int(aws.CertificateExpiryWindow.Hours()/24)), certs)
+
+	m.healthList.SetItems(items)
+	m.healthList.SetLoading(m.state.HealthLoading)
+	m.healthList.SetError(nil)
+	m.healthList.SetEmptyMessage("Nothing to report")
+	m.updateHealthDetails()
+}
+
+// updateActivityList updates the activity feed with current data.
+func (m *Model) updateActivityList() {
+	s := GetStyles()
+	events := m.state.FilteredActivity()
+	items := make([]components.ListItem, len(events))
+	for i, e := range events {
+		item := components.ListItem{
+			ID:          e.ID,
+			Title:       e.Name + " by " + valueOrDash(e.Username),
+			Status:      format.Time(e.Time),
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.TextDim),
+		}
+		if e.Failed() {
+			item.Status = e.ErrorCode
+			item.StatusStyle = s.StatusError
+		}
+		items[i] = item
+	}
+	m.activityList.SetItems(items)
+	m.activityList.SetLoading(m.state.ActivityLoading)
+	m.activityList.SetError(m.state.ActivityError)
+	m.activityList.SetEmptyMessage("No changes " + rangeClause(m.timeRange(state.ViewActivity)))
+	m.updateActivityDetails()
+}
+
+// updateCloudResourceList updates the Cloud Control resources list with current data.
+func (m *Model) updateCloudResourceList() {
+	resources := m.state.FilteredCloudResources()
+	items := make([]components.ListItem, len(resources))
+	for i, r := range resources {
+		title := r.Identifier
+		description := ""
+		if r.Name != "" && r.Name != r.Identifier {
+			title = r.Name
+			description = r.Identifier
+		}
+		items[i] = components.ListItem{
+			ID:          r.Identifier,
+			Title:       title,
+			Description: description,
+		}
+	}
+	m.cloudResourceList.SetItems(items)
+	m.cloudResourceList.SetLoading(false)
+	m.cloudResourceList.SetError(m.state.CloudResourcesError)
+	m.cloudResourceList.SetEmptyMessage(fmt.Sprintf("No %s resources found", m.state.CloudResourceType))
+	m.updateCloudResourceDetails()
+}
+
 // updateAPIGatewayList updates the API Gateway list with current data.
 func (m *Model) updateAPIGatewayList() {
 	// Combine REST and HTTP APIs into a single list
@@ -433,10 +1367,39 @@ func (m *Model) updateContainerList() {
 	m.containerList.SetEmptyMessage("No containers found")
 }
 
+// updateEndpointList updates the endpoint list for discovery tunnel selection.
+func (m *Model) updateEndpointList() {
+	items := make([]components.ListItem, len(m.state.PendingEndpoints))
+	for i, ep := range m.state.PendingEndpoints {
+		// Service Connect and Cloud Map can expose the same address, so the
+		// position identifies the endpoint
+		items[i] = components.ListItem{
+			ID:          strconv.Itoa(i),
+			Title:       ep.Address(),
+			Status:      ep.Source,
+			StatusStyle: lipgloss.NewStyle().Foreground(theme.Info),
+			Extra:       ep.Namespace,
+		}
+	}
+	m.endpointList.SetItems(items)
+	m.endpointList.SetLoading(false)
+	m.endpointList.SetEmptyMessage("No discovered endpoints")
+}
+
 // updateQueuesList updates the SQS queues list with current data.
 func (m *Model) updateQueuesList() {
 	queues := m.state.FilteredQueues()
+	highlights := make(map[string]lipgloss.Style)
+	for _, q := range queues {
+		if style := m.highlightStyle("queues", queueFields(q)); style != nil {
+			highlights[q.URL] = *style
+		}
+	}
 	m.sqsTable.SetQueues(queues)
+	m.sqsTable.SetHighlights(highlights)
+	m.sqsTable.SetColumns(m.columnsFor("queues"), func(q model.Queue) map[string]string {
+		return m.withTerraform(queueCells(q), q.ARN)
+	})
 	m.sqsTable.SetLoading(false)
 	m.sqsTable.SetError(m.state.QueuesError)
 	m.updateQueueDetails()
@@ -460,12 +1423,66 @@ func (m *Model) updateCurrentList() {
 		m.updateStacksList()
 	case state.ViewStackResources:
 		m.updateStackResourcesList()
+	case state.ViewStackEvents:
+		m.updateStackEventsList()
 	case state.ViewClusters:
 		m.updateClustersList()
 	case state.ViewServices:
 		m.updateServicesList()
+	case state.ViewTasks:
+		m.updateTasksList()
+	case state.ViewTaskContainers:
+		m.updateTaskContainersList()
 	case state.ViewLambda:
 		m.updateLambdaList()
+	case state.ViewAppRunner:
+		m.updateAppRunnerList()
+	case state.ViewFirehose:
+		m.updateFirehoseList()
+	case state.ViewCognito:
+		m.updateUserPoolList()
+	case state.ViewCognitoUsers:
+		m.updateCognitoUserList()
+	case state.ViewMSK:
+		m.updateMSKList()
+	case state.ViewMQ:
+		m.updateMQList()
+	case state.ViewDatabases:
+		m.updateDatabaseList()
+	case state.ViewEC2:
+		m.updateInstanceList()
+	case state.ViewEFS:
+		m.updateEFSList()
+	case state.ViewSchedules:
+		m.updateSchedulesList()
+	case state.ViewSecrets:
+		m.updateSecretsList()
+	case state.ViewLogGroups:
+		m.updateLogGroupsList()
+	case state.ViewSES:
+		m.updateSESList()
+	case state.ViewSESSuppressions:
+		m.updateSESSuppressionList()
+	case state.ViewActivity:
+		m.updateActivityList()
+	case state.ViewLogSearch:
+		m.updateLogSearchList()
+	case state.ViewHealth:
+		m.updateHealthList()
+	case state.ViewLambdaRuntimes:
+		m.updateRuntimesList()
+	case state.ViewImages:
+		m.updateImagesTable()
+	case state.ViewAlerts:
+		m.updateAlertsList()
+	case state.ViewStats:
+		m.updateStatsList()
+	case state.ViewTimeline:
+		m.updateTimelineList()
+	case state.ViewResourceTypes:
+		m.updateResourceTypeList()
+	case state.ViewCloudResources:
+		m.updateCloudResourceList()
 	case state.ViewAPIGateway:
 		m.updateAPIGatewayList()
 	case state.ViewAPIStages:
@@ -474,6 +1491,8 @@ func (m *Model) updateCurrentList() {
 		m.updateEC2List()
 	case state.ViewContainerSelect:
 		m.updateContainerList()
+	case state.ViewEndpointSelect:
+		m.updateEndpointList()
 	case state.ViewSQS:
 		m.updateQueuesList()
 	case state.ViewDynamoDB:
@@ -484,6 +1503,10 @@ func (m *Model) updateCurrentList() {
 // updateContainerContext sets the container's title and context based on current view.
 func (m *Model) updateContainerContext() {
 	region := m.state.Region
+	if cached := m.warmCacheStatus(); cached != "" {
+		// The list shows the last run's resources until it is loaded
+		region += " · " + cached
+	}
 	m.container.SetContext(region)
 	// Don't use Container's loading/error - Lists handle their own states
 	m.container.SetLoading(false)
@@ -494,7 +1517,11 @@ func (m *Model) updateContainerContext() {
 		m.container.SetTitle("Main Menu")
 		m.container.SetItemCount(0)
 	case state.ViewStacks:
-		m.container.SetTitle("CloudFormation Stacks")
+		title := "CloudFormation Stacks"
+		if m.state.StackPattern != "" {
+			title += " (" + m.state.StackPattern + ")"
+		}
+		m.container.SetTitle(title)
 		if m.state.StacksLoading {
 			m.container.SetItemCount(0)
 		} else {
@@ -507,6 +1534,17 @@ func (m *Model) updateContainerContext() {
 		}
 		m.container.SetTitle(title)
 		m.container.SetItemCount(0)
+	case state.ViewStackEvents:
+		title := "Events: " + m.state.StackEventsStack
+		if m.stackEventsChanging() && m.state.AutoRefresh {
+			title += " (live)"
+		}
+		m.container.SetTitle(title)
+		if m.state.StackEventsLoading && len(m.state.StackEvents) == 0 {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredStackEvents()))
+		}
 	case state.ViewClusters:
 		m.container.SetTitle("ECS Clusters")
 		if m.state.ClustersLoading {
@@ -525,13 +1563,201 @@ func (m *Model) updateContainerContext() {
 		} else {
 			m.container.SetItemCount(len(m.state.FilteredServices()))
 		}
+	case state.ViewTasks:
+		title := "Tasks"
+		if m.state.SelectedService != nil {
+			title = "Tasks: " + m.state.SelectedService.Name
+		}
+		m.container.SetTitle(title)
+		if m.state.TasksLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredTasks()))
+		}
+	case state.ViewTaskContainers:
+		title := "Containers"
+		if t := m.state.SelectedTask; t != nil {
+			title = "Containers: task " + t.TaskID
+		}
+		m.container.SetTitle(title)
+		m.container.SetItemCount(len(m.state.FilteredTaskContainers()))
 	case state.ViewLambda:
 		m.container.SetTitle("Lambda Functions")
+		if failed := m.lambdas.failedStatus(m.state.SelectedStack); failed != "" {
+			// Functions of the stack left out of the list
+			m.container.SetContext(region + " · " + failed)
+		}
 		if m.state.FunctionsLoading {
 			m.container.SetItemCount(0)
 		} else {
 			m.container.SetItemCount(len(m.state.FilteredFunctions()))
 		}
+	case state.ViewAppRunner:
+		m.container.SetTitle("App Runner Services")
+		if m.state.AppRunnerLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredAppRunnerServices()))
+		}
+	case state.ViewFirehose:
+		m.container.SetTitle("Firehose Delivery Streams")
+		if m.state.FirehoseLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredDeliveryStreams()))
+		}
+	case state.ViewCognito:
+		m.container.SetTitle("Cognito User Pools")
+		if m.state.UserPoolsLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredUserPools()))
+		}
+	case state.ViewCognitoUsers:
+		title := "Cognito Users"
+		if m.state.SelectedUserPool != nil {
+			title = fmt.Sprintf("Users: %s \"%s\"", m.state.SelectedUserPool.Name, m.state.CognitoUserQuery)
+		}
+		m.container.SetTitle(title)
+		if m.state.CognitoUsersLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredCognitoUsers()))
+		}
+	case state.ViewMSK:
+		m.container.SetTitle("MSK Clusters")
+		if m.state.MSKLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredMSKClusters()))
+		}
+	case state.ViewMQ:
+		m.container.SetTitle("MQ Brokers")
+		if m.state.MQLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredMQBrokers()))
+		}
+	case state.ViewDatabases:
+		m.container.SetTitle("Databases")
+		if m.state.DatabasesLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredDatabases()))
+		}
+	case state.ViewEC2:
+		m.container.SetTitle("EC2 Instances")
+		if m.state.InstancesLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredInstances()))
+		}
+	case state.ViewEFS:
+		m.container.SetTitle("EFS File Systems")
+		if m.state.EFSLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredEFSFileSystems()))
+		}
+	case state.ViewSchedules:
+		m.container.SetTitle("Schedules")
+		if m.state.SchedulesLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredSchedules()))
+		}
+	case state.ViewSecrets:
+		m.container.SetTitle("Secrets")
+		if m.state.SecretsLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredSecrets()))
+		}
+	case state.ViewLogGroups:
+		m.container.SetTitle("Log Groups")
+		if n := len(m.logGroupMarks); n > 0 {
+			m.container.SetTitle(fmt.Sprintf("Log Groups (%d marked)", n))
+		}
+		if m.state.LogGroupsLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredLogGroups()))
+		}
+	case state.ViewSES:
+		m.container.SetTitle("SES")
+		if m.state.SESLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredSESIdentities()) + len(m.state.FilteredSESConfigurationSets()))
+		}
+	case state.ViewSESSuppressions:
+		title := "SES Suppression List"
+		if m.state.SESSuppressionsTruncated {
+			title += " (most recent)"
+		}
+		m.container.SetTitle(title)
+		if m.state.SESSuppressionsLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredSESSuppressions()))
+		}
+	case state.ViewActivity:
+		m.container.SetTitle(fmt.Sprintf("Activity: %s (%s)", m.state.ActivityResource, components.RangeLabel(m.timeRange(state.ViewActivity))))
+		if m.state.ActivityLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredActivity()))
+		}
+	case state.ViewLogSearch:
+		m.container.SetTitle(fmt.Sprintf("Logs: %s (%s)", m.state.LogSearchStack, components.RangeLabel(m.state.LogSearchRange)))
+		if m.state.LogSearchLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredLogSearchHits()))
+		}
+	case state.ViewImages:
+		m.container.SetTitle("Images: " + m.state.ImagesScope)
+		if m.state.ImagesLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredImages()))
+		}
+	case state.ViewAlerts:
+		title := "Alerts"
+		if n := m.alerts.firing(); n > 0 {
+			title = fmt.Sprintf("Alerts (%d firing)", n)
+		}
+		m.container.SetTitle(title)
+	case state.ViewStats:
+		m.container.SetTitle("Diagnostics")
+		m.container.SetItemCount(len(m.filteredWatches()))
+	case state.ViewTimeline:
+		m.container.SetTitle("Session Timeline")
+		m.container.SetItemCount(len(m.filteredTimeline()))
+	case state.ViewLambdaRuntimes:
+		m.container.SetTitle("Lambda Runtimes")
+		if m.state.FunctionsLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.filteredRuntimeFunctions()))
+		}
+	case state.ViewHealth:
+		m.container.SetTitle("Account Health")
+		if h := m.state.FilteredHealth(); h != nil && !m.state.HealthLoading {
+			m.container.SetItemCount(h.ProblemCount())
+		} else {
+			m.container.SetItemCount(0)
+		}
+	case state.ViewResourceTypes:
+		m.container.SetTitle("Resource Types")
+		m.container.SetItemCount(len(m.state.FilteredResourceTypes()))
+	case state.ViewCloudResources:
+		m.container.SetTitle(m.state.CloudResourceType)
+		if m.state.CloudResourcesLoading {
+			m.container.SetItemCount(0)
+		} else {
+			m.container.SetItemCount(len(m.state.FilteredCloudResources()))
+		}
 	case state.ViewAPIGateway:
 		m.container.SetTitle("API Gateway")
 		if m.state.APIsLoading {
@@ -569,6 +1795,9 @@ func (m *Model) updateContainerContext() {
 	case state.ViewContainerSelect:
 		m.container.SetTitle("Select Container")
 		m.container.SetItemCount(len(m.state.PendingContainers))
+	case state.ViewEndpointSelect:
+		m.container.SetTitle("Select Endpoint")
+		m.container.SetItemCount(len(m.state.PendingEndpoints))
 	case state.ViewTunnels:
 		m.container.SetTitle("Active Tunnels")
 		m.container.SetItemCount(len(m.tunnelManager.GetTunnels()))
@@ -589,9 +1818,17 @@ func (m *Model) updateContainerContext() {
 			title = "Logs: " + m.state.CloudWatchServiceContext.Name
 		} else if m.state.CloudWatchLambdaContext != nil {
 			title = "Logs: " + m.state.CloudWatchLambdaContext.Name
+		} else if m.state.CloudWatchAccessLogContext != "" {
+			title = "Access Logs: " + m.state.CloudWatchAccessLogContext
 		}
 		m.container.SetTitle(title)
 		m.container.SetItemCount(len(m.state.CloudWatchLogs))
+	case state.ViewDiff:
+		m.container.SetTitle("Diff")
+		m.container.SetItemCount(m.diffViewer.ChangeCount())
+	case state.ViewMonitor:
+		m.container.SetTitle("Monitor")
+		m.container.SetItemCount(len(m.monitorPanels))
 	default:
 		m.container.SetTitle("vaws")
 		m.container.SetItemCount(0)
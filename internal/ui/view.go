@@ -3,10 +3,13 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
 	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
 	"vaws/internal/ui/theme"
 )
 
@@ -23,11 +26,19 @@ func (m *Model) getLayoutMode() layoutMode {
 
 // shouldShowLogs returns whether logs can be shown at current height.
 func (m *Model) shouldShowLogs() bool {
-	return m.state.ShowLogs && m.height >= minHeightLogs
+	return m.state.ShowLogs && m.height >= minHeightLogs && !m.paneZoomed
 }
 
 // View implements tea.Model.
 func (m *Model) View() string {
+	start := time.Now()
+	v := m.view()
+	m.stats.render.add(time.Since(start))
+	return v
+}
+
+// view renders the current screen.
+func (m *Model) view() string {
 	if !m.ready {
 		return "Initializing..."
 	}
@@ -81,7 +92,7 @@ func (m *Model) View() string {
 	quickBarHeight := 1
 	currentLogsHeight := 0
 	if m.shouldShowLogs() {
-		currentLogsHeight = logsHeight
+		currentLogsHeight = m.currentLogsHeight()
 	}
 	contentHeight := m.height - statusBarHeight - quickBarHeight - currentLogsHeight
 	if contentHeight < 1 {
@@ -90,13 +101,37 @@ func (m *Model) View() string {
 
 	// Status bar (single row header)
 	m.statusBar.SetWidth(m.width)
-	m.statusBar.SetProfile(m.state.Profile)
+	profile := m.state.Profile
+	if m.state.Environment != "" {
+		profile += " [" + m.state.Environment + "]"
+	}
+	if m.state.Account != "" {
+		profile += theme.Symbol(" → ", " -> ") + m.state.Account
+	}
+	m.statusBar.SetProfile(profile)
 	m.statusBar.SetRegion(m.state.Region)
 	m.statusBar.SetActiveTunnels(len(m.tunnelManager.GetTunnels()))
+	switch {
+	case m.macro.recording:
+		m.statusBar.SetMacro(fmt.Sprintf("REC %d keys", len(m.macro.recorded)))
+	case m.macro.playing != nil:
+		m.statusBar.SetMacro(fmt.Sprintf("%s %d/%d", m.macro.name, m.macro.step, len(m.macro.playing)))
+	default:
+		m.statusBar.SetMacro("")
+	}
+	if m.state.AutoRefresh {
+		m.statusBar.SetRefresh(m.backoff.status(m.state.View, time.Now()))
+	} else {
+		m.statusBar.SetRefresh("")
+	}
+	m.statusBar.SetTagFilter(m.tagFilterStatus())
+	m.statusBar.SetDegraded(m.degradedStatus())
+	m.statusBar.SetAlerts(m.alerts.firing(), m.alerts.unseen && m.alerts.blinkOn)
 	header := m.statusBar.View()
 
 	// Update container with current context and size FIRST
 	m.updateContainerContext()
+	m.syncFocus()
 	m.container.SetSize(m.width, contentHeight)
 
 	// Use Container's content dimensions for inner components
@@ -138,6 +173,30 @@ func (m *Model) View() string {
 		payloadInputView = m.renderPayloadDialog()
 	}
 
+	// Proxy rules dialog (if editing API Gateway tunnel rules)
+	var proxyRulesView string
+	if m.editingProxyRules {
+		proxyRulesView = m.renderProxyRulesDialog()
+	}
+
+	// User search dialog (if searching Cognito users)
+	var userSearchView string
+	if m.searchingUsers {
+		userSearchView = m.renderUserSearchDialog()
+	}
+
+	// Recipient dialog (if sending an SES test email)
+	var sesRecipientView string
+	if m.enteringSESRecipient {
+		sesRecipientView = m.renderSESRecipientDialog()
+	}
+
+	// Log search dialog (if searching the logs of a stack)
+	var logSearchView string
+	if m.searchingLogs {
+		logSearchView = m.renderLogSearchDialog()
+	}
+
 	// QuickBar (footer with quick keys)
 	m.quickBar.SetWidth(m.width)
 
@@ -150,6 +209,9 @@ func (m *Model) View() string {
 	} else if m.detailsSearching {
 		m.quickBar.SetMode("search")
 		m.quickBar.SetFilterText(m.detailsSearchInput.Value())
+	} else if m.jsonFiltering {
+		m.quickBar.SetMode("jq")
+		m.quickBar.SetFilterText(m.jsonFilterInput.Value())
 	} else if m.commandPalette.IsActive() {
 		m.quickBar.SetMode("command")
 	} else {
@@ -174,6 +236,87 @@ func (m *Model) View() string {
 		// Center the payload input dialog inside container
 		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, payloadInputView))
 		sections = append(sections, m.container.View())
+	} else if m.editingProxyRules {
+		// Center the proxy rules dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, proxyRulesView))
+		sections = append(sections, m.container.View())
+	} else if m.pendingConfirm != nil {
+		// Center the confirm dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderConfirmDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.searchingUsers {
+		// Center the user search dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, userSearchView))
+		sections = append(sections, m.container.View())
+	} else if m.enteringSESRecipient {
+		// Center the SES recipient dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, sesRecipientView))
+		sections = append(sections, m.container.View())
+	} else if m.traffic != nil {
+		// Center the alias traffic dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderTrafficDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.deployments != nil {
+		// Center the stage deployments dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderDeploymentsDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.protection != nil {
+		// Center the task protection dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderTaskProtectionDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.taskDetails != nil {
+		// Center the task details inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderTaskDetailsDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.queueMap != nil {
+		// Center the queue map inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderQueueMapDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.queueFailures != nil {
+		// Center the queue failures panel inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderQueueFailuresDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.sendMessage != nil {
+		// Center the send message dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderSendMessageDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.timeRangePicker.IsActive() {
+		// Center the time range picker inside container
+		m.timeRangePicker.SetSize(m.container.ContentWidth(), m.container.ContentHeight())
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.timeRangePicker.View()))
+		sections = append(sections, m.container.View())
+	} else if m.lambdaPerformance != nil {
+		// Center the Lambda performance panel inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderLambdaPerformanceDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.chaos != nil {
+		// Center the task chaos dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderChaosDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.retention != nil {
+		// Center the log retention dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderRetentionDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.costs != nil {
+		// Center the costs panel inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderCostsDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.clusterDash != nil {
+		// Center the cluster dashboard inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderClusterDashboard()))
+		sections = append(sections, m.container.View())
+	} else if m.accounts != nil {
+		// Center the accounts dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderAccountsDialog()))
+		sections = append(sections, m.container.View())
+	} else if m.columns != nil {
+		// Center the column chooser inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, m.renderColumnChooser()))
+		sections = append(sections, m.container.View())
+	} else if m.searchingLogs {
+		// Center the log search dialog inside container
+		m.container.SetContent(lipgloss.Place(m.container.ContentWidth(), m.container.ContentHeight(), lipgloss.Center, lipgloss.Center, logSearchView))
+		sections = append(sections, m.container.View())
 	} else if m.dynamodbQueryDialog.IsActive() {
 		// Center the DynamoDB query dialog inside container
 		m.dynamodbQueryDialog.SetSize(m.container.ContentWidth(), m.container.ContentHeight())
@@ -233,32 +376,73 @@ func (m *Model) renderMainContent(layout layoutMode, contentHeight int) string {
 		return m.dynamodbQueryResults.View()
 	}
 
+	// Diff view takes full screen
+	if m.state.View == state.ViewDiff {
+		m.diffViewer.SetSize(containerWidth, contentHeight)
+		return m.diffViewer.View()
+	}
+
+	// Monitor dashboard takes full screen
+	if m.state.View == state.ViewMonitor {
+		m.monitor.SetSize(containerWidth, contentHeight)
+		return m.monitor.View()
+	}
+
 	// Calculate sizes first
 	var listWidth, detailsWidth int
 	if layout == layoutSingle {
 		listWidth = containerWidth
 	} else {
-		listWidth = int(float64(containerWidth) * listPaneRatio)
-		detailsWidth = containerWidth - listWidth
+		listWidth, detailsWidth = m.splitWidths(containerWidth)
 	}
 
 	// Set sizes on all lists BEFORE calling View()
 	m.mainMenuList.SetSize(listWidth, contentHeight)
 	m.stacksList.SetSize(listWidth, contentHeight)
 	m.stackResourcesList.SetSize(listWidth, contentHeight)
+	m.stackEventsList.SetSize(listWidth, contentHeight)
 	m.clustersList.SetSize(listWidth, contentHeight)
 	m.serviceList.SetSize(listWidth, contentHeight)
+	m.tasksList.SetSize(listWidth, contentHeight)
+	m.taskContainersList.SetSize(listWidth, contentHeight)
 	m.lambdaList.SetSize(listWidth, contentHeight)
+	m.appRunnerList.SetSize(listWidth, contentHeight)
+	m.firehoseList.SetSize(listWidth, contentHeight)
+	m.userPoolList.SetSize(listWidth, contentHeight)
+	m.cognitoUserList.SetSize(listWidth, contentHeight)
+	m.resourceTypeList.SetSize(listWidth, contentHeight)
+	m.mskList.SetSize(listWidth, contentHeight)
+	m.mqList.SetSize(listWidth, contentHeight)
+	m.databaseList.SetSize(listWidth, contentHeight)
+	m.instanceList.SetSize(listWidth, contentHeight)
+	m.efsList.SetSize(listWidth, contentHeight)
+	m.schedulesList.SetSize(listWidth, contentHeight)
+	m.secretsList.SetSize(listWidth, contentHeight)
+	m.logGroupsList.SetSize(listWidth, contentHeight)
+	m.sesList.SetSize(listWidth, contentHeight)
+	m.sesSuppressionList.SetSize(listWidth, contentHeight)
+	m.activityList.SetSize(listWidth, contentHeight)
+	m.logSearchList.SetSize(listWidth, contentHeight)
+	m.healthList.SetSize(listWidth, contentHeight)
+	m.runtimesList.SetSize(listWidth, contentHeight)
+	m.alertsList.SetSize(listWidth, contentHeight)
+	m.statsList.SetSize(listWidth, contentHeight)
+	m.timelineList.SetSize(listWidth, contentHeight)
+	m.cloudResourceList.SetSize(listWidth, contentHeight)
 	m.apiGatewayList.SetSize(listWidth, contentHeight)
 	m.apiStagesList.SetSize(listWidth, contentHeight)
 	m.ec2List.SetSize(listWidth, contentHeight)
 	m.containerList.SetSize(listWidth, contentHeight)
+	m.endpointList.SetSize(listWidth, contentHeight)
 	m.sqsTable.SetSize(listWidth, contentHeight)
+	m.imagesTable.SetSize(listWidth, contentHeight)
 	m.dynamodbTable.SetSize(listWidth, contentHeight)
-	if layout != layoutSingle {
+	if detailsWidth > 0 {
 		m.details.SetSize(detailsWidth, contentHeight)
 	}
 
+	m.highlightFilter()
+
 	// Now render the list view with correct size
 	var listView string
 	switch m.state.View {
@@ -268,12 +452,66 @@ func (m *Model) renderMainContent(layout layoutMode, contentHeight int) string {
 		listView = m.stacksList.View()
 	case state.ViewStackResources:
 		listView = m.stackResourcesList.View()
+	case state.ViewStackEvents:
+		listView = m.stackEventsList.View()
 	case state.ViewClusters:
 		listView = m.clustersList.View()
 	case state.ViewServices:
 		listView = m.serviceList.View()
+	case state.ViewTasks:
+		listView = m.tasksList.View()
+	case state.ViewTaskContainers:
+		listView = m.taskContainersList.View()
 	case state.ViewLambda:
 		listView = m.lambdaList.View()
+	case state.ViewAppRunner:
+		listView = m.appRunnerList.View()
+	case state.ViewFirehose:
+		listView = m.firehoseList.View()
+	case state.ViewCognito:
+		listView = m.userPoolList.View()
+	case state.ViewCognitoUsers:
+		listView = m.cognitoUserList.View()
+	case state.ViewResourceTypes:
+		listView = m.resourceTypeList.View()
+	case state.ViewMSK:
+		listView = m.mskList.View()
+	case state.ViewMQ:
+		listView = m.mqList.View()
+	case state.ViewDatabases:
+		listView = m.databaseList.View()
+	case state.ViewEC2:
+		listView = m.instanceList.View()
+	case state.ViewEFS:
+		listView = m.efsList.View()
+	case state.ViewSchedules:
+		listView = m.schedulesList.View()
+	case state.ViewSecrets:
+		listView = m.secretsList.View()
+	case state.ViewLogGroups:
+		listView = m.logGroupsList.View()
+	case state.ViewSES:
+		listView = m.sesList.View()
+	case state.ViewSESSuppressions:
+		listView = m.sesSuppressionList.View()
+	case state.ViewActivity:
+		listView = m.activityList.View()
+	case state.ViewLogSearch:
+		listView = m.logSearchList.View()
+	case state.ViewHealth:
+		listView = m.healthList.View()
+	case state.ViewLambdaRuntimes:
+		listView = m.runtimesList.View()
+	case state.ViewImages:
+		listView = m.imagesTable.View()
+	case state.ViewAlerts:
+		listView = m.alertsList.View()
+	case state.ViewStats:
+		listView = m.statsList.View()
+	case state.ViewTimeline:
+		listView = m.timelineList.View()
+	case state.ViewCloudResources:
+		listView = m.cloudResourceList.View()
 	case state.ViewAPIGateway:
 		listView = m.apiGatewayList.View()
 	case state.ViewAPIStages:
@@ -282,6 +520,8 @@ func (m *Model) renderMainContent(layout layoutMode, contentHeight int) string {
 		listView = m.ec2List.View()
 	case state.ViewContainerSelect:
 		listView = m.containerList.View()
+	case state.ViewEndpointSelect:
+		listView = m.endpointList.View()
 	case state.ViewSQS:
 		listView = m.sqsTable.View()
 	case state.ViewDynamoDB:
@@ -294,23 +534,30 @@ func (m *Model) renderMainContent(layout layoutMode, contentHeight int) string {
 			Foreground(theme.Primary).
 			Bold(true)
 		filterLabel := filterStyle.Render("Filter: ")
-		listView = filterLabel + m.filterInput.View() + "\n\n" + listView
+		count := ""
+		if m.state.FilterText != "" {
+			count = lipgloss.NewStyle().Foreground(theme.TextDim).Render("  " + m.filterMatchesText())
+		}
+		listView = filterLabel + m.filterInput.View() + count + "\n\n" + listView
 	} else if m.state.FilterText != "" {
 		filterStyle := lipgloss.NewStyle().
 			Foreground(theme.TextDim)
 		filterLabel := filterStyle.Render(fmt.Sprintf("Filtered: \"%s\"", m.state.FilterText))
+		if text := m.filterMatchesText(); text != "" {
+			filterLabel += filterStyle.Render(" · " + text)
+		}
 		listView = filterLabel + "\n\n" + listView
 	}
 
-	// Single pane layout - list only, full width
-	if layout == layoutSingle {
+	// Single pane layout (or a zoomed list) - list only, full width
+	if detailsWidth == 0 {
 		return listView
 	}
 
 	// Full two-pane layout with focus indicator
 	// Border color indicates which pane is focused
 	borderColor := theme.Border
-	if m.details.IsFocused() {
+	if m.focusedPane() == focusDetails {
 		borderColor = theme.BorderFocus
 	}
 
@@ -334,6 +581,16 @@ func (m *Model) renderMainContent(layout layoutMode, contentHeight int) string {
 		detailsContent = searchLabel + m.detailsSearchInput.View() + "\n\n" + detailsContent
 	}
 
+	// Zoomed details pane - no list, no border
+	if listWidth == 0 {
+		return lipgloss.NewStyle().
+			Width(detailsWidth).
+			Height(contentHeight).
+			MaxWidth(detailsWidth).
+			MaxHeight(contentHeight).
+			Render(detailsContent)
+	}
+
 	detailsPane := lipgloss.NewStyle().
 		Width(detailsWidth - 1). // Account for border
 		Height(contentHeight).
@@ -355,7 +612,7 @@ func (m *Model) renderPortDialog() string {
 	}
 
 	dialogStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BorderStyle()).
 		BorderForeground(theme.BorderFocus).
 		Padding(1, 2).
 		Width(dialogWidth)
@@ -371,11 +628,60 @@ func (m *Model) renderPortDialog() string {
 	serviceName := ""
 	if m.pendingPortForward != nil {
 		serviceName = truncateString(m.pendingPortForward.Name, dialogWidth-20)
+	} else if m.pendingDatabase != nil {
+		serviceName = truncateString(m.pendingDatabase.Name, dialogWidth-20)
+	} else if m.pendingAPIGWPortForward != nil {
+		serviceName = truncateString(m.pendingAPIGWPortForward.Name, dialogWidth-20)
+	} else if m.pendingInstance != nil {
+		serviceName = truncateString(instanceLabel(*m.pendingInstance), dialogWidth-20)
+	}
+
+	title := "Port Forward: "
+	if m.pendingAPIGWMock {
+		title = "Mock Server: "
+	}
+	dialogContent := labelStyle.Render(title+serviceName) + "\n\n"
+	if m.pendingAPIGWMock {
+		dialogContent += hintStyle.Render("Answers from the API's routes, without AWS") + "\n\n"
+	}
+
+	// Remote endpoint of databases, reached through a jump host
+	if db := m.pendingDatabase; db != nil {
+		dialogContent += "Remote: " + truncateString(fmt.Sprintf("%s:%d", db.Endpoint, db.Port), dialogWidth-14) + "\n\n"
 	}
 
-	dialogContent := labelStyle.Render("Port Forward: "+serviceName) + "\n\n" +
-		"Local port: " + m.portInput.View() + "\n\n" +
-		hintStyle.Render("Enter port or press Enter for random")
+	// Remote port picker for ECS services
+	if len(m.portOptions) > 0 {
+		selectedStyle := lipgloss.NewStyle().
+			Foreground(theme.Primary).
+			Bold(true)
+
+		dialogContent += "Remote port:\n"
+		for i, opt := range m.portOptions {
+			line := truncateString(opt.String(), dialogWidth-10)
+			if i == m.portOptionIdx {
+				dialogContent += selectedStyle.Render(theme.Symbol("▸ ", "> ")+line) + "\n"
+			} else {
+				dialogContent += "  " + line + "\n"
+			}
+		}
+		dialogContent += "\n"
+	}
+
+	// Any port of EC2 instances, typed with the local one
+	if inst := m.pendingInstance; inst != nil {
+		dialogContent += "Instance: " + truncateString(fmt.Sprintf("%s (%s)", inst.InstanceID, valueOrDash(inst.PrivateIPAddress)), dialogWidth-16) + "\n\n"
+		dialogContent += "Port: " + m.portInput.View() + "\n\n"
+		dialogContent += hintStyle.Render("Remote port, or local:remote (:remote for a random local port)")
+		return dialogStyle.Render(dialogContent)
+	}
+
+	dialogContent += "Local port: " + m.portInput.View() + "\n\n"
+	if len(m.portOptions) > 0 {
+		dialogContent += hintStyle.Render("↑↓ remote port • Enter port or press Enter for random")
+	} else {
+		dialogContent += hintStyle.Render("Enter port or press Enter for random")
+	}
 
 	return dialogStyle.Render(dialogContent)
 }
@@ -391,7 +697,7 @@ func (m *Model) renderPayloadDialog() string {
 	}
 
 	dialogStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BorderStyle()).
 		BorderForeground(theme.BorderFocus).
 		Padding(1, 2).
 		Width(dialogWidth)
@@ -411,7 +717,159 @@ func (m *Model) renderPayloadDialog() string {
 
 	dialogContent := labelStyle.Render("Invoke Lambda: "+fnName) + "\n\n" +
 		"Payload (JSON): " + m.payloadInput.View() + "\n\n" +
-		hintStyle.Render("Enter JSON payload or press Enter for empty")
+		hintStyle.Render("Enter JSON payload or press Enter for empty, ctrl+o to edit in $EDITOR")
+
+	return dialogStyle.Render(dialogContent)
+}
+
+// renderUserSearchDialog renders the Cognito user search input dialog.
+func (m *Model) renderUserSearchDialog() string {
+	dialogWidth := 70
+	if m.width < 80 {
+		dialogWidth = m.width - 10
+		if dialogWidth < 40 {
+			dialogWidth = 40
+		}
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	poolName := ""
+	if m.pendingSearchPool != nil {
+		poolName = truncateString(m.pendingSearchPool.Name, dialogWidth-20)
+	}
+
+	dialogContent := labelStyle.Render("Search users: "+poolName) + "\n\n" +
+		"Email or username: " + m.userSearchInput.View() + "\n\n" +
+		hintStyle.Render("Matches the start of the email (with @) or the username")
+
+	return dialogStyle.Render(dialogContent)
+}
+
+// renderSESRecipientDialog renders the SES test email recipient input dialog.
+func (m *Model) renderSESRecipientDialog() string {
+	dialogWidth := 70
+	if m.width < 80 {
+		dialogWidth = m.width - 10
+		if dialogWidth < 40 {
+			dialogWidth = 40
+		}
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	from := truncateString(m.pendingSESFrom, dialogWidth-20)
+
+	dialogContent := labelStyle.Render("Send test email from "+from) + "\n\n" +
+		"To: " + m.sesRecipientInput.View() + "\n\n" +
+		hintStyle.Render("Press Enter for the SES mailbox simulator; sandbox accounts can only send to verified addresses")
+
+	return dialogStyle.Render(dialogContent)
+}
+
+// renderLogSearchDialog renders the stack log search dialog.
+func (m *Model) renderLogSearchDialog() string {
+	dialogWidth := 70
+	if m.width < 80 {
+		dialogWidth = m.width - 10
+		if dialogWidth < 40 {
+			dialogWidth = 40
+		}
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	current := m.timeRange(state.ViewLogSearch)
+	var ranges []string
+	for _, r := range timeRangePresets(state.ViewLogSearch) {
+		if !current.IsAbsolute() && r == current.Last {
+			ranges = append(ranges, selectedStyle.Render("["+format.Age(r)+"]"))
+		} else {
+			ranges = append(ranges, hintStyle.Render(" "+format.Age(r)+" "))
+		}
+	}
+	if current.IsAbsolute() {
+		ranges = append(ranges, selectedStyle.Render("["+components.RangeLabel(current)+"]"))
+	}
+
+	stack := truncateString(m.pendingLogSearchStack, dialogWidth-20)
+
+	dialogContent := labelStyle.Render("Search logs of stack "+stack) + "\n\n" +
+		"Pattern: " + m.logSearchInput.View() + "\n\n" +
+		"Last:    " + strings.Join(ranges, " ") + "\n\n" +
+		hintStyle.Render("CloudWatch Logs filter pattern, empty for all events; tab changes the range, T on the results picks a custom one")
+
+	return dialogStyle.Render(dialogContent)
+}
+
+// renderProxyRulesDialog renders the API Gateway proxy rules input dialog.
+func (m *Model) renderProxyRulesDialog() string {
+	dialogWidth := 70
+	if m.width < 80 {
+		dialogWidth = m.width - 10
+		if dialogWidth < 40 {
+			dialogWidth = 40
+		}
+	}
+
+	dialogStyle := lipgloss.NewStyle().
+		Border(theme.BorderStyle()).
+		BorderForeground(theme.BorderFocus).
+		Padding(1, 2).
+		Width(dialogWidth)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.Primary).
+		Bold(true)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.TextDim).
+		Italic(true)
+
+	dialogContent := labelStyle.Render("Proxy Rules: "+truncateString(m.pendingRulesTunnel, dialogWidth-20)) + "\n\n" +
+		"Rules: " + m.proxyRulesInput.View() + "\n\n" +
+		hintStyle.Render("Separate with ';'  Header: value  |  /from -> /to") + "\n" +
+		hintStyle.Render("Applied to the next request, no restart needed")
 
 	return dialogStyle.Render(dialogContent)
 }
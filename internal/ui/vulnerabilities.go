@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+)
+
+// imageScanDelay is how long the cursor has to rest on a service before the
+// scans of its images are read.
+const imageScanDelay = 300 * time.Millisecond
+
+// imageScanner reads the ECR scans of the images of the service under the
+// cursor, for its details. Scans are kept by service and task definition,
+// so a deployment of other images reads them again.
+type imageScanner struct {
+	pending string // Service waiting for the cursor to rest on it
+	seq     int    // Bumped per pending service, so only the last tick reads
+	scans   map[string]*serviceImageScans
+}
+
+// serviceImageScans are the scans of the images of a service.
+type serviceImageScans struct {
+	loading bool
+	images  []model.ImageVulnerabilities
+	err     error
+}
+
+// imageScanTickMsg reads the scans of the pending service, unless the
+// cursor moved on since the tick was scheduled.
+type imageScanTickMsg struct {
+	seq int
+}
+
+// imageScansLoadedMsg carries the scans of the images of a service.
+type imageScansLoadedMsg struct {
+	key    string
+	images []model.ImageVulnerabilities
+	err    error
+}
+
+// imageScanKey identifies the images of a service by its task definition.
+func imageScanKey(svc model.Service) string {
+	return svc.ARN + "@" + svc.TaskDefinition
+}
+
+// scanSelectedService schedules reading the image scans of the service
+// under the cursor if they aren't known. Like describeSelectedStack it runs
+// after every message.
+func (m *Model) scanSelectedService() tea.Cmd {
+	if m.state.View != state.ViewServices || m.client == nil {
+		return nil
+	}
+	svc := m.selectedService()
+	if svc == nil {
+		return nil
+	}
+	key := imageScanKey(*svc)
+	sc := &m.imageScans
+	if sc.pending == key || sc.scans[key] != nil {
+		return nil
+	}
+	sc.pending = key
+	sc.seq++
+	seq := sc.seq
+	return tea.Tick(imageScanDelay, func(time.Time) tea.Msg {
+		return imageScanTickMsg{seq: seq}
+	})
+}
+
+// handleImageScanTick reads the scans of the pending service if the cursor
+// is still on it.
+func (m *Model) handleImageScanTick(msg imageScanTickMsg) tea.Cmd {
+	sc := &m.imageScans
+	if msg.seq != sc.seq || sc.pending == "" {
+		return nil
+	}
+	key := sc.pending
+	sc.pending = ""
+	svc := m.selectedService()
+	if svc == nil || imageScanKey(*svc) != key || m.state.View != state.ViewServices {
+		return nil
+	}
+	if sc.scans == nil {
+		sc.scans = make(map[string]*serviceImageScans)
+	}
+	sc.scans[key] = &serviceImageScans{loading: true}
+	m.updateServiceDetails()
+
+	client, service := m.client, *svc
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		images, err := client.GetServiceVulnerabilities(ctx, service)
+		return imageScansLoadedMsg{key: key, images: images, err: err}
+	}
+}
+
+// handleImageScansLoaded records the scans of a service and warns of
+// critical findings.
+func (m *Model) handleImageScansLoaded(msg imageScansLoadedMsg) {
+	scans := m.imageScans.scans[msg.key]
+	if scans == nil {
+		// Dropped by a profile or region switch
+		return
+	}
+	scans.loading = false
+	scans.images = msg.images
+	scans.err = msg.err
+	if msg.err != nil {
+		m.logger.Error("Failed to read image scans: %v", msg.err)
+	}
+	for _, v := range msg.images {
+		if n := v.Counts["CRITICAL"]; n > 0 {
+			m.logger.Warn("Container %s runs an image with %d critical vulnerabilities: %s", v.Container, n, v.Image)
+		}
+	}
+	if m.state.View == state.ViewServices {
+		m.updateServiceDetails()
+	}
+}
+
+// vulnerabilityRows show the finding counts of the last scan of each image
+// the service runs from ECR. Services without scanned ECR images get none.
+func (m *Model) vulnerabilityRows(svc model.Service) []components.DetailRow {
+	scans := m.imageScans.scans[imageScanKey(svc)]
+	if scans == nil {
+		return nil
+	}
+	s := GetStyles()
+	spacer := components.DetailRow{Label: "", Value: ""}
+	switch {
+	case scans.loading:
+		return []components.DetailRow{spacer, {Label: "Vulnerabilities", Value: "Reading ECR scans...", Style: s.Muted}}
+	case scans.err != nil:
+		return []components.DetailRow{spacer, {Label: "Vulnerabilities", Value: scans.err.Error(), Style: s.StatusError}}
+	}
+
+	var rows []components.DetailRow
+	critical := 0
+	for _, v := range scans.images {
+		value, style, ok := vulnerabilitySummary(v)
+		if !ok {
+			continue
+		}
+		critical += v.Counts["CRITICAL"]
+		rows = append(rows, components.DetailRow{Label: "  " + v.Container, Value: value, Style: style})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	header := components.DetailRow{Label: "Vulnerabilities", Value: "No critical findings", Style: s.StatusHealthy}
+	if critical > 0 {
+		header = components.DetailRow{Label: "Vulnerabilities", Value: fmt.Sprintf("%d critical, check before deploying", critical), Style: s.StatusError}
+	}
+	return append([]components.DetailRow{spacer, header}, rows...)
+}
+
+// vulnerabilitySummary renders the finding counts of an image by severity,
+// e.g. "2 critical · 5 high (scanned 3d ago)", styled by the worst. It
+// reports false for images that were never scanned.
+func vulnerabilitySummary(v model.ImageVulnerabilities) (string, lipgloss.Style, bool) {
+	s := GetStyles()
+	switch {
+	case v.Error != "":
+		return v.Error, s.StatusError, true
+	case !v.Scanned():
+		switch v.ScanStatus {
+		case "":
+			return "", s.Muted, false
+		case "IN_PROGRESS", "PENDING", "ACTIVE":
+			return "Scan in progress", s.Muted, true
+		}
+		value := "Scan " + strings.ToLower(strings.ReplaceAll(v.ScanStatus, "_", " "))
+		if v.ScanDetail != "" {
+			value += ": " + v.ScanDetail
+		}
+		return value, s.StatusWarning, true
+	}
+
+	var parts []string
+	for _, severity := range model.VulnerabilitySeverities {
+		if n := v.Counts[severity]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, strings.ToLower(severity)))
+		}
+	}
+	scanned := " (scanned " + format.Relative(time.Since(v.ScannedAt)) + ")"
+	switch {
+	case len(parts) == 0:
+		return "No findings" + scanned, s.StatusHealthy, true
+	case v.Counts["CRITICAL"] > 0:
+		return strings.Join(parts, " · ") + scanned, s.StatusError, true
+	case v.Counts["HIGH"] > 0:
+		return strings.Join(parts, " · ") + scanned, s.StatusWarning, true
+	}
+	return strings.Join(parts, " · ") + scanned, s.Muted, true
+}
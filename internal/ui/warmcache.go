@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/cache"
+	"vaws/internal/log"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/format"
+)
+
+// Lists kept in the warm cache, as keys of warmCache.stale; services are
+// keyed further by the cluster or stack they were listed for.
+const (
+	warmStacks    = "stacks"
+	warmClusters  = "clusters"
+	warmServices  = "services:"
+	warmFunctions = "functions"
+	warmTables    = "tables"
+)
+
+// warmCache holds the snapshot of the resource lists of the last run for
+// the profile and region, which fills a list while its first load runs.
+type warmCache struct {
+	key    string // Profile, region and account of the snapshot
+	loaded bool
+	snap   cache.Snapshot
+	stale  map[string]bool // Lists showing the snapshot until they are loaded
+}
+
+// warmCacheEnabled reports whether lists are kept between runs, which
+// no_warm_cache turns off.
+func (m *Model) warmCacheEnabled() bool {
+	return m.cfg == nil || !m.cfg.Defaults.NoWarmCache
+}
+
+// warmCacheScope returns the profile, region and member account the lists
+// on screen belong to.
+func (m *Model) warmCacheScope() (profile, region, account string) {
+	if m.account != nil {
+		account = m.account.id
+	}
+	return m.state.Profile, m.state.Region, account
+}
+
+// warmSnapshot returns the snapshot of the current profile and region,
+// reading it when the profile, region or account changed since.
+func (m *Model) warmSnapshot() *cache.Snapshot {
+	profile, region, account := m.warmCacheScope()
+	key := profile + "/" + region + "/" + account
+	w := &m.warm
+	if !w.loaded || w.key != key {
+		w.key, w.loaded, w.stale = key, true, nil
+		w.snap, _ = cache.Load(profile, region, account)
+	}
+	return &w.snap
+}
+
+// servicesScope identifies the services list by what it was opened from, as
+// reloadServices loads it.
+func (m *Model) servicesScope() string {
+	switch {
+	case m.state.SelectedCluster != nil:
+		return "cluster:" + m.state.SelectedCluster.ARN
+	case m.state.SelectedStack != nil:
+		return "stack:" + m.state.SelectedStack.Name
+	}
+	return ""
+}
+
+// warmList fills an empty list from the snapshot, so it shows at once and
+// is refreshed in place by the load about to start. fill reports whether
+// the snapshot had the list, and update redraws it.
+func (m *Model) warmList(kind string, list refreshable, update func(), fill func(*cache.Snapshot) bool) {
+	if !m.warmCacheEnabled() || m.client == nil {
+		return
+	}
+	snap := m.warmSnapshot()
+	if !fill(snap) {
+		return
+	}
+	if m.warm.stale == nil {
+		m.warm.stale = make(map[string]bool)
+	}
+	m.warm.stale[kind] = true
+	update()
+	list.SetRefreshing(true)
+	m.logger.Debug("Showing %s cached %s while they load", kind, format.Relative(time.Since(snap.SavedAt)))
+}
+
+// warmStacksList fills the stacks list from the snapshot before a load.
+func (m *Model) warmStacksList() {
+	m.warmList(warmStacks, m.stacksList, m.updateStacksList, func(snap *cache.Snapshot) bool {
+		if len(m.state.Stacks) > 0 || len(snap.Stacks) == 0 {
+			return false
+		}
+		m.state.Stacks = slices.Clone(snap.Stacks)
+		return true
+	})
+}
+
+// warmClustersList fills the clusters list from the snapshot before a load.
+func (m *Model) warmClustersList() {
+	m.warmList(warmClusters, m.clustersList, m.updateClustersList, func(snap *cache.Snapshot) bool {
+		if len(m.state.Clusters) > 0 || len(snap.Clusters) == 0 {
+			return false
+		}
+		m.state.Clusters = slices.Clone(snap.Clusters)
+		return true
+	})
+}
+
+// warmServicesList fills the services list of the selected cluster or
+// stack from the snapshot before a load.
+func (m *Model) warmServicesList() {
+	scope := m.servicesScope()
+	m.warmList(warmServices+scope, m.serviceList, m.updateServicesList, func(snap *cache.Snapshot) bool {
+		if len(m.state.Services) > 0 || len(snap.Services[scope]) == 0 {
+			return false
+		}
+		m.state.Services = slices.Clone(snap.Services[scope])
+		return true
+	})
+}
+
+// warmFunctionsList fills the Lambda list from the snapshot before a load
+// of all functions.
+func (m *Model) warmFunctionsList() {
+	if m.state.SelectedStack != nil {
+		return
+	}
+	m.warmList(warmFunctions, m.lambdaList, m.updateLambdaList, func(snap *cache.Snapshot) bool {
+		if len(m.state.Functions) > 0 || len(snap.Functions) == 0 {
+			return false
+		}
+		m.state.Functions = slices.Clone(snap.Functions)
+		return true
+	})
+}
+
+// warmTablesList fills the DynamoDB list from the snapshot before a load.
+func (m *Model) warmTablesList() {
+	m.warmList(warmTables, m.dynamodbTable, m.updateTablesList, func(snap *cache.Snapshot) bool {
+		if len(m.state.Tables) > 0 || len(snap.Tables) == 0 {
+			return false
+		}
+		m.state.Tables = slices.Clone(snap.Tables)
+		return true
+	})
+}
+
+// saveWarmCache records a list that finished loading in the snapshot and
+// writes it in the background. The lists are copied here, as the state
+// changes them in place later.
+func (m *Model) saveWarmCache(kind string) tea.Cmd {
+	if !m.warmCacheEnabled() || m.client == nil {
+		return nil
+	}
+	snap := m.warmSnapshot()
+	delete(m.warm.stale, kind)
+	switch kind {
+	case warmStacks:
+		snap.Stacks = slices.Clone(m.state.Stacks)
+	case warmClusters:
+		snap.Clusters = slices.Clone(m.state.Clusters)
+	case warmFunctions:
+		if m.state.SelectedStack != nil {
+			return nil
+		}
+		snap.Functions = slices.Clone(m.state.Functions)
+	case warmTables:
+		snap.Tables = slices.Clone(m.state.Tables)
+	default:
+		scope := kind[len(warmServices):]
+		if scope == "" {
+			return nil
+		}
+		if snap.Services == nil {
+			snap.Services = make(map[string][]model.Service)
+		}
+		snap.Services[scope] = slices.Clone(m.state.Services)
+	}
+	snap.SavedAt = time.Now()
+
+	out := *snap
+	out.Services = maps.Clone(snap.Services)
+	profile, region, account := m.warmCacheScope()
+	return func() tea.Msg {
+		if err := cache.Save(profile, region, account, out); err != nil {
+			log.Warn("Failed to save the warm cache: %v", err)
+		}
+		return nil
+	}
+}
+
+// warmCacheView returns the key of the list of the current view, or "" if
+// the view has none in the snapshot.
+func (m *Model) warmCacheView() string {
+	switch m.state.View {
+	case state.ViewStacks:
+		return warmStacks
+	case state.ViewClusters:
+		return warmClusters
+	case state.ViewServices:
+		return warmServices + m.servicesScope()
+	case state.ViewLambda:
+		if m.state.SelectedStack == nil {
+			return warmFunctions
+		}
+	case state.ViewDynamoDB:
+		return warmTables
+	}
+	return ""
+}
+
+// warmCacheStatus returns how old the list on screen is while it shows the
+// snapshot, e.g. "cached 2h ago", or "" once it was loaded.
+func (m *Model) warmCacheStatus() string {
+	kind := m.warmCacheView()
+	if kind == "" || !m.warm.stale[kind] {
+		return ""
+	}
+	return fmt.Sprintf("cached %s", format.Relative(time.Since(m.warm.snap.SavedAt)))
+}
@@ -0,0 +1,521 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"vaws/internal/aws"
+	"vaws/internal/config"
+	"vaws/internal/model"
+	"vaws/internal/state"
+	"vaws/internal/ui/components"
+	"vaws/internal/ui/format"
+	"vaws/internal/ui/highlight"
+)
+
+const (
+	// watchInterval is how often a watch is checked when no interval is configured.
+	watchInterval = 30 * time.Second
+
+	// alertBlinkInterval is how often the alerts badge flashes until the
+	// alerts are seen.
+	alertBlinkInterval = 500 * time.Millisecond
+)
+
+// watch is a watch expression of the profile and what its checks found.
+type watch struct {
+	cfg      config.WatchConfig
+	kind     string // Key of the fields in highlightLabels: services or queues
+	rule     highlight.Rule
+	hold     time.Duration // How long the condition must hold to raise the alert
+	interval time.Duration
+
+	fields    map[string]string // Fields of the last successful check
+	lastCheck time.Time
+	checking  bool
+	err       string
+	since     time.Time // When the condition started to hold, zero if it doesn't
+	firing    bool
+	firedAt   time.Time
+}
+
+// watchAlerts holds the watches of the profile. gen drops the checks made
+// before the watches were reloaded.
+type watchAlerts struct {
+	watches  []*watch
+	loaded   bool
+	gen      int
+	unseen   bool // An alert was raised since the alerts view was last opened
+	blinking bool // The blink loop is running
+	blinkOn  bool
+}
+
+// watchCheckedMsg carries the fields of the resource of a watch.
+type watchCheckedMsg struct {
+	gen    int
+	index  int
+	at     time.Time
+	fields map[string]string
+	err    error
+}
+
+// alertBlinkMsg flashes the alerts badge.
+type alertBlinkMsg struct{}
+
+// firing returns the number of alerts raised.
+func (a *watchAlerts) firing() int {
+	n := 0
+	for _, w := range a.watches {
+		if w.firing {
+			n++
+		}
+	}
+	return n
+}
+
+// parseWatch reads a watch of the config. The condition uses the fields and
+// syntax of highlight rules, and may end with "for <duration>".
+func parseWatch(cfg config.WatchConfig) (*watch, error) {
+	w := &watch{cfg: cfg, interval: watchInterval}
+	switch {
+	case cfg.Queue != "":
+		w.kind = "queues"
+	case cfg.Cluster != "" && cfg.Service != "":
+		w.kind = "services"
+	default:
+		return nil, fmt.Errorf("watch %q needs a queue, or a cluster and service", cfg.When)
+	}
+
+	cond := cfg.When
+	if i := strings.LastIndex(cond, " for "); i >= 0 {
+		d, err := time.ParseDuration(strings.TrimSpace(cond[i+len(" for "):]))
+		if err != nil {
+			return nil, fmt.Errorf("watch %q: invalid duration after for", cfg.When)
+		}
+		cond, w.hold = cond[:i], d
+	}
+	rule, err := highlight.ParseConditions(cond)
+	if err != nil {
+		return nil, fmt.Errorf("watch %q: %w", cfg.When, err)
+	}
+	labels := highlightLabels[w.kind]
+	for _, f := range rule.Fields(nil) {
+		if labels[f] == "" {
+			fields := make([]string, 0, len(labels))
+			for f := range labels {
+				fields = append(fields, f)
+			}
+			slices.Sort(fields)
+			return nil, fmt.Errorf("watch %q: unknown field %s (valid: %s)", cfg.When, f, strings.Join(fields, ", "))
+		}
+	}
+	w.rule = rule
+
+	if d, err := time.ParseDuration(cfg.Interval); err == nil && d >= 10*time.Second {
+		w.interval = d
+	}
+	return w, nil
+}
+
+// resource returns the name of the watched service or queue.
+func (w *watch) resource() string {
+	if w.kind == "queues" {
+		return queueNameFromURL(w.cfg.Queue)
+	}
+	return w.cfg.Service
+}
+
+// title returns the name of the watch, or its resource and condition.
+func (w *watch) title() string {
+	if w.cfg.Name != "" {
+		return w.cfg.Name
+	}
+	return w.resource() + ": " + w.cfg.When
+}
+
+// summary describes the last values checked, e.g. "running 1/2, pending 0".
+func (w *watch) summary() string {
+	if w.fields == nil {
+		return ""
+	}
+	if w.kind == "queues" {
+		return fmt.Sprintf("%s messages, %s in flight", w.fields["messages"], w.fields["in_flight"])
+	}
+	return fmt.Sprintf("running %s/%s, pending %s", w.fields["running"], w.fields["desired"], w.fields["pending"])
+}
+
+// loadWatches parses the watches of the profile the first time they are
+// needed, warning about those that can't be checked.
+func (m *Model) loadWatches() {
+	if m.alerts.loaded || m.cfg == nil {
+		return
+	}
+	m.alerts.loaded = true
+	for _, cfg := range m.cfg.GetWatches(m.state.Profile) {
+		w, err := parseWatch(cfg)
+		if err != nil {
+			m.logger.Warn("Ignoring %v", err)
+			continue
+		}
+		m.alerts.watches = append(m.alerts.watches, w)
+	}
+}
+
+// resetWatches drops the watches and their alerts after a profile or region
+// switch; they are reloaded on the next check.
+func (m *Model) resetWatches() {
+	m.alerts.watches = nil
+	m.alerts.loaded = false
+	m.alerts.unseen = false
+	m.alerts.gen++
+}
+
+// checkWatches starts checking the watches that are due, or all of them if
+// force is set.
+func (m *Model) checkWatches(force bool) tea.Cmd {
+	if m.client == nil {
+		return nil
+	}
+	m.loadWatches()
+
+	var cmds []tea.Cmd
+	for i, w := range m.alerts.watches {
+		if w.checking || (!force && time.Since(w.lastCheck) < w.interval) {
+			continue
+		}
+		w.checking = true
+		w.lastCheck = time.Now()
+
+		client, gen, kind, cfg := m.client, m.alerts.gen, w.kind, w.cfg
+		cmds = append(cmds, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			msg := watchCheckedMsg{gen: gen, index: i}
+			msg.fields, msg.err = fetchWatchFields(ctx, client, kind, cfg)
+			msg.at = time.Now()
+			return msg
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// fetchWatchFields loads the resource of a watch as the fields its
+// condition tests.
+func fetchWatchFields(ctx context.Context, client aws.API, kind string, cfg config.WatchConfig) (map[string]string, error) {
+	if kind == "queues" {
+		q, err := client.GetQueueAttributes(ctx, cfg.Queue)
+		if err != nil {
+			return nil, err
+		}
+		return queueFields(*q), nil
+	}
+	svc, err := client.DescribeService(ctx, cfg.Cluster, cfg.Service)
+	if err != nil {
+		return nil, err
+	}
+	return serviceFields(*svc), nil
+}
+
+// handleWatchChecked evaluates a watch against the fields just checked,
+// logging errors that changed since the last check.
+func (m *Model) handleWatchChecked(msg watchCheckedMsg) tea.Cmd {
+	if msg.gen != m.alerts.gen || msg.index >= len(m.alerts.watches) {
+		return nil
+	}
+	w := m.alerts.watches[msg.index]
+	w.checking = false
+
+	var cmd tea.Cmd
+	if msg.err != nil {
+		if w.err != msg.err.Error() {
+			m.logger.Warn("Could not check %s: %v", w.title(), msg.err)
+		}
+		w.err = msg.err.Error()
+	} else {
+		w.err = ""
+		w.fields = msg.fields
+		cmd = m.evaluateWatch(w, msg.at)
+	}
+	if m.state.View == state.ViewAlerts {
+		m.updateAlertsList()
+	}
+	return cmd
+}
+
+// evaluateWatch raises the alert of a watch once its condition has held for
+// long enough, and clears it when it no longer holds.
+func (m *Model) evaluateWatch(w *watch, at time.Time) tea.Cmd {
+	if !w.rule.Match(w.fields) {
+		if w.firing {
+			m.logger.Info("Alert cleared: %s (%s)", w.title(), w.summary())
+		}
+		w.since, w.firing = time.Time{}, false
+		return nil
+	}
+	if w.since.IsZero() {
+		w.since = at
+	}
+	if w.firing || at.Sub(w.since) < w.hold {
+		return nil
+	}
+
+	w.firing, w.firedAt = true, at
+	m.alerts.unseen = true
+	m.logger.Warn("Alert: %s (%s)", w.title(), w.summary())
+	cmds := []tea.Cmd{m.startAlertBlink()}
+	if w.cfg.Notify {
+		cmds = append(cmds, m.notify("vaws alert", w.title()+": "+w.summary()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// startAlertBlink starts flashing the alerts badge, unless it already is.
+func (m *Model) startAlertBlink() tea.Cmd {
+	if m.alerts.blinking {
+		return nil
+	}
+	m.alerts.blinking = true
+	return alertBlinkTick()
+}
+
+func alertBlinkTick() tea.Cmd {
+	return tea.Tick(alertBlinkInterval, func(time.Time) tea.Msg { return alertBlinkMsg{} })
+}
+
+// handleAlertBlink flashes the alerts badge until the alerts are seen or
+// cleared.
+func (m *Model) handleAlertBlink() tea.Cmd {
+	if !m.alerts.unseen || m.alerts.firing() == 0 {
+		m.alerts.blinking, m.alerts.blinkOn = false, false
+		return nil
+	}
+	m.alerts.blinkOn = !m.alerts.blinkOn
+	return alertBlinkTick()
+}
+
+// openAlerts shows the watches of the profile and checks those that are
+// due. Opening it stops the badge flashing.
+func (m *Model) openAlerts() tea.Cmd {
+	if m.state.View != state.ViewAlerts {
+		m.state.AlertsReturnView = m.state.View
+	}
+	m.state.View = state.ViewAlerts
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.quickBar.SetActiveResource("")
+	m.alerts.unseen = false
+	cmd := m.checkWatches(false)
+	m.updateAlertsList()
+	return cmd
+}
+
+// closeAlerts returns to the view the alerts were opened from.
+func (m *Model) closeAlerts() {
+	m.state.FilterText = ""
+	m.filterInput.SetValue("")
+	m.state.View = m.state.AlertsReturnView
+	m.updateCurrentList()
+}
+
+// filteredWatches returns the indexes of the watches whose title matches the
+// filter.
+func (m *Model) filteredWatches() []int {
+	filter := strings.ToLower(m.state.FilterText)
+	var indexes []int
+	for i, w := range m.alerts.watches {
+		if filter == "" || strings.Contains(strings.ToLower(w.title()), filter) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// updateAlertsList lists the watches in config order, with their state.
+func (m *Model) updateAlertsList() {
+	s := GetStyles()
+	now := time.Now()
+	var items []components.ListItem
+	for _, i := range m.filteredWatches() {
+		w := m.alerts.watches[i]
+		item := components.ListItem{ID: strconv.Itoa(i), Title: w.title()}
+		switch {
+		case w.firing:
+			item.Status, item.StatusStyle = "FIRING "+format.Age(now.Sub(w.firedAt)), s.StatusError
+		case w.err != "":
+			item.Status, item.StatusStyle = "ERROR", s.StatusWarning
+		case !w.since.IsZero():
+			item.Status, item.StatusStyle = "pending "+format.Age(now.Sub(w.since)), s.StatusWarning
+		case w.fields == nil:
+			item.Status, item.StatusStyle = "not checked", s.Muted
+		default:
+			item.Status, item.StatusStyle = "OK", s.StatusHealthy
+		}
+		items = append(items, item)
+	}
+
+	m.alertsList.SetItems(items)
+	m.alertsList.SetError(nil)
+	m.alertsList.SetEmptyMessage("No watches; use :watch <condition> on a service or queue, or add watches to the config")
+	m.updateAlertsDetails()
+}
+
+// selectedWatch returns the watch under the cursor in the alerts view.
+func (m *Model) selectedWatch() *watch {
+	item := m.alertsList.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	i, err := strconv.Atoi(item.ID)
+	if err != nil || i >= len(m.alerts.watches) {
+		return nil
+	}
+	return m.alerts.watches[i]
+}
+
+// updateAlertsDetails describes the selected watch and its last check.
+func (m *Model) updateAlertsDetails() {
+	s := GetStyles()
+	m.details.SetTitle("Alert")
+	w := m.selectedWatch()
+	if w == nil {
+		m.details.SetRows(nil)
+		return
+	}
+
+	resource := "Queue " + w.resource()
+	if w.kind == "services" {
+		resource = "Service " + w.cfg.Service + " in " + shortClusterName(w.cfg.Cluster)
+	}
+	hold := "-"
+	if w.hold > 0 {
+		hold = format.Age(w.hold)
+	}
+	rows := []components.DetailRow{
+		{Label: "Watch", Value: w.title()},
+		{Label: "Resource", Value: resource},
+		{Label: "Condition", Value: w.rule.Source},
+		{Label: "Held For", Value: hold},
+		{Label: "Every", Value: format.Age(w.interval)},
+		{Label: "", Value: ""}, // Spacer
+	}
+	switch {
+	case w.firing:
+		rows = append(rows, components.DetailRow{Label: "State", Value: "FIRING since " + format.Time(w.firedAt), Style: s.StatusError})
+	case !w.since.IsZero():
+		rows = append(rows, components.DetailRow{Label: "State", Value: "Holding since " + format.Time(w.since), Style: s.StatusWarning})
+	case w.fields != nil:
+		rows = append(rows, components.DetailRow{Label: "State", Value: "OK", Style: s.StatusHealthy})
+	}
+	rows = append(rows, components.DetailRow{Label: "Values", Value: valueOrDash(w.summary())})
+	if !w.lastCheck.IsZero() {
+		rows = append(rows, components.DetailRow{Label: "Checked", Value: format.Time(w.lastCheck), Style: s.Muted})
+	}
+	if w.err != "" {
+		rows = append(rows, components.DetailRow{Label: "Error", Value: w.err, Style: s.StatusWarning})
+	}
+	m.details.SetRows(rows)
+}
+
+// shortClusterName returns the name of a cluster given by name or ARN.
+func shortClusterName(cluster string) string {
+	return cluster[strings.LastIndex(cluster, "/")+1:]
+}
+
+// handleAlertsEnter opens the service or queue of the selected watch, in its
+// list filtered down to it.
+func (m *Model) handleAlertsEnter() tea.Cmd {
+	w := m.selectedWatch()
+	if w == nil {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	if w.kind == "queues" {
+		cmd = m.switchToSQS()
+	} else {
+		// Load the clusters too, so going back lands on a full list
+		clusters := m.switchToECS()
+		m.state.SelectCluster(&model.Cluster{ARN: w.cfg.Cluster, Name: shortClusterName(w.cfg.Cluster)})
+		cmd = tea.Batch(clusters, m.loadServicesForCluster())
+	}
+	m.state.FilterText = w.resource()
+	m.filterInput.SetValue(w.resource())
+	m.updateCurrentList()
+	return cmd
+}
+
+// handleWatchCommand runs ":watch [condition|off]": without arguments it
+// opens the alerts, "off" removes the watches of the selected resource, and
+// anything else becomes a watch on it, saved to the config.
+func (m *Model) handleWatchCommand(args []string) tea.Cmd {
+	if len(args) == 0 {
+		return m.openAlerts()
+	}
+	if m.cfg == nil {
+		return nil
+	}
+
+	var target config.WatchConfig
+	switch m.state.View {
+	case state.ViewServices:
+		if svc := m.selectedService(); svc != nil {
+			target = config.WatchConfig{Cluster: svc.ClusterARN, Service: svc.Name}
+		}
+	case state.ViewSQS:
+		if q := m.sqsTable.SelectedQueue(); q != nil {
+			target = config.WatchConfig{Queue: q.URL}
+		}
+	case state.ViewAlerts:
+		if w := m.selectedWatch(); w != nil {
+			target = config.WatchConfig{Cluster: w.cfg.Cluster, Service: w.cfg.Service, Queue: w.cfg.Queue}
+		}
+	}
+	if target.Queue == "" && target.Service == "" {
+		m.logger.Warn(":watch needs a selected service or queue")
+		return nil
+	}
+	sameResource := func(c config.WatchConfig) bool {
+		return c.Cluster == target.Cluster && c.Service == target.Service && c.Queue == target.Queue
+	}
+
+	watches := m.cfg.GetWatches(m.state.Profile)
+	if len(args) == 1 && args[0] == "off" {
+		kept := slices.DeleteFunc(slices.Clone(watches), sameResource)
+		if len(kept) == len(watches) {
+			m.logger.Warn("No watches on this resource")
+			return nil
+		}
+		m.cfg.SetWatches(m.state.Profile, kept)
+		m.logger.Info("Removed %d watches", len(watches)-len(kept))
+		m.alerts.watches = slices.DeleteFunc(m.alerts.watches, func(w *watch) bool { return sameResource(w.cfg) })
+		// Checks in flight carry indexes from before the removal
+		m.alerts.gen++
+		for _, w := range m.alerts.watches {
+			w.checking = false
+		}
+	} else {
+		target.When = strings.Join(args, " ")
+		w, err := parseWatch(target)
+		if err != nil {
+			m.logger.Warn("%v", err)
+			return nil
+		}
+		m.loadWatches()
+		m.cfg.SetWatches(m.state.Profile, append(slices.Clone(watches), target))
+		m.alerts.watches = append(m.alerts.watches, w)
+		m.logger.Info("Watching %s every %s", w.title(), format.Age(w.interval))
+	}
+	if err := m.cfg.Save(); err != nil {
+		m.logger.Warn("Failed to save config: %v", err)
+	}
+	if m.state.View == state.ViewAlerts {
+		m.updateAlertsList()
+	}
+	return m.checkWatches(false)
+}